@@ -35,9 +35,37 @@ const callerLevel = 2
 
 // Error Aos error type.
 type Error struct {
-	pc   uintptr
-	line int
-	err  error
+	pcs []uintptr
+	err error
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// Error categories. Errors wrapping these errors are mapped to corresponding Aos error codes when sent to the cloud.
+//
+//nolint:gochecknoglobals
+var (
+	ErrFailed          = errors.New("failed")
+	ErrRuntime         = errors.New("runtime error")
+	ErrNoMemory        = errors.New("not enough memory")
+	ErrOutOfRange      = errors.New("out of range")
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrTimeout         = errors.New("timeout")
+	ErrAlreadyExist    = errors.New("already exists")
+	ErrWrongState      = errors.New("wrong state")
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	ErrAlreadyLoggedIn = errors.New("already logged in")
+	ErrNotSupported    = errors.New("not supported")
+	ErrCanceled        = errors.New("canceled")
+)
+
+//nolint:gochecknoglobals
+var categories = []error{
+	ErrRuntime, ErrNoMemory, ErrOutOfRange, ErrNotFound, ErrInvalidArgument, ErrTimeout, ErrAlreadyExist,
+	ErrWrongState, ErrInvalidChecksum, ErrAlreadyLoggedIn, ErrNotSupported, ErrCanceled, ErrFailed,
 }
 
 /***********************************************************************************************************************
@@ -67,14 +95,33 @@ func Wrap(fromErr error) error {
 	return createAosError(fromErr)
 }
 
+// Category returns error category (one of Err* category errors) wrapped by error or nil if there is no one.
+func Category(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, category := range categories {
+		if errors.Is(err, category) {
+			return category
+		}
+	}
+
+	return nil
+}
+
 // Error returns Aos error message.
 func (aosErr *Error) Error() string {
-	f := runtime.FuncForPC(aosErr.pc)
-	if f == nil {
+	if len(aosErr.pcs) == 0 {
+		return aosErr.err.Error()
+	}
+
+	frame, _ := runtime.CallersFrames(aosErr.pcs[:1]).Next()
+	if frame.Function == "" {
 		return "[unknown:???]"
 	}
 
-	return fmt.Sprintf("%s [%s:%d]", aosErr.err.Error(), f.Name(), aosErr.line)
+	return fmt.Sprintf("%s [%s:%d]", aosErr.err.Error(), frame.Function, frame.Line)
 }
 
 // Unwrap unwraps error.
@@ -87,9 +134,5 @@ func (aosErr *Error) Unwrap() error {
  **********************************************************************************************************************/
 
 func createAosError(fromErr error) *Error {
-	aosErr := &Error{err: fromErr}
-
-	aosErr.pc, _, aosErr.line, _ = runtime.Caller(callerLevel)
-
-	return aosErr
+	return &Error{err: fromErr, pcs: captureStack(callerLevel, fromErr)}
 }
@@ -18,16 +18,31 @@
 package aoserrors_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"reflect"
 	"runtime"
+	"strings"
+	"syscall"
 	"testing"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/aosedge/aos_common/aoserrors"
 )
 
 var errTestError = errors.New("test error")
 
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testLogHook struct {
+	entries []*log.Entry
+}
+
 /***********************************************************************************************************************
  * Tests
  **********************************************************************************************************************/
@@ -61,3 +76,244 @@ func TestAosError(t *testing.T) {
 		t.Errorf("Wrong error message: %s", err.Error())
 	}
 }
+
+func TestErrorFields(t *testing.T) {
+	err := aoserrors.WithField(errTestError, aoserrors.FieldPath, "/var/aos")
+
+	if !errors.Is(err, errTestError) {
+		t.Error("Error should be errTestError")
+	}
+
+	if !strings.HasPrefix(err.Error(), errTestError.Error()+" [") {
+		t.Errorf("Wrong error message: %s", err.Error())
+	}
+
+	err = aoserrors.WithFields(fmt.Errorf("wrapped: %w", err), aoserrors.Fields{
+		aoserrors.FieldInstanceID: "instance0", aoserrors.FieldPath: "/var/aos/instance0",
+	})
+
+	err = errors.Join(err, aoserrors.WithField(errors.New("other error"), aoserrors.FieldNodeID, "node0"))
+
+	expectedFields := aoserrors.Fields{
+		aoserrors.FieldInstanceID: "instance0", aoserrors.FieldPath: "/var/aos/instance0",
+		aoserrors.FieldNodeID: "node0",
+	}
+
+	if fields := aoserrors.GetFields(err); !reflect.DeepEqual(fields, expectedFields) {
+		t.Errorf("Wrong error fields: %v", fields)
+	}
+
+	if value, ok := aoserrors.GetField(err, aoserrors.FieldNodeID); !ok || value != "node0" {
+		t.Errorf("Wrong error field: %v", value)
+	}
+
+	logger := log.New()
+	testHook := &testLogHook{}
+
+	logger.SetOutput(io.Discard)
+	logger.AddHook(&aoserrors.LogHook{})
+	logger.AddHook(testHook)
+	logger.WithError(err).WithField(aoserrors.FieldNodeID, "node1").Error("Operation failed")
+
+	if len(testHook.entries) != 1 {
+		t.Fatal("Log entry expected")
+	}
+
+	entry := testHook.entries[0]
+
+	if entry.Data[aoserrors.FieldInstanceID] != "instance0" || entry.Data[aoserrors.FieldNodeID] != "node1" {
+		t.Errorf("Wrong log entry fields: %v", entry.Data)
+	}
+
+	if fields := aoserrors.LogFields(err); fields[aoserrors.FieldPath] != "/var/aos/instance0" {
+		t.Errorf("Wrong log fields: %v", fields)
+	}
+}
+
+func TestStructuredLogHook(t *testing.T) {
+	err := aoserrors.Errorf("can't find instance: %w", aoserrors.ErrNotFound)
+
+	_, _, line, _ := runtime.Caller(0)
+	line -= 2
+
+	err = aoserrors.Errorf("can't start instance: %w", aoserrors.WithField(err, aoserrors.FieldInstanceID, "instance0"))
+
+	if category := aoserrors.Category(err); category != aoserrors.ErrNotFound { //nolint:errorlint
+		t.Errorf("Wrong error category: %v", category)
+	}
+
+	if category := aoserrors.Category(errTestError); category != nil {
+		t.Errorf("Unexpected error category: %v", category)
+	}
+
+	logger := log.New()
+	testHook := &testLogHook{}
+
+	logger.SetOutput(io.Discard)
+	logger.AddHook(&aoserrors.LogHook{Structured: true, ErrorCode: func(err error) int {
+		if errors.Is(err, aoserrors.ErrNotFound) {
+			return 5
+		}
+
+		return 1
+	}})
+	logger.AddHook(testHook)
+	logger.WithError(err).Error("Operation failed")
+
+	if len(testHook.entries) != 1 {
+		t.Fatal("Log entry expected")
+	}
+
+	entry := testHook.entries[0]
+
+	if entry.Data[aoserrors.FieldErrorCode] != 5 ||
+		entry.Data[aoserrors.FieldErrorCategory] != aoserrors.ErrNotFound.Error() ||
+		entry.Data[aoserrors.FieldInstanceID] != "instance0" {
+		t.Errorf("Wrong log entry fields: %v", entry.Data)
+	}
+
+	if source, _ := entry.Data[aoserrors.FieldErrorSource].(string); !strings.HasSuffix(
+		source, fmt.Sprintf("TestStructuredLogHook:%d", line)) {
+		t.Errorf("Wrong error source: %s", source)
+	}
+}
+
+func TestMultiError(t *testing.T) {
+	if err := aoserrors.Combine(nil, nil); err != nil {
+		t.Errorf("Nil error expected: %v", err)
+	}
+
+	var err error
+
+	for i := range 2 {
+		err = aoserrors.Append(err, nil, aoserrors.Errorf("instance %d: %w", i, aoserrors.ErrNotFound))
+	}
+
+	err = aoserrors.Append(err, errTestError)
+
+	multiErr := &aoserrors.MultiError{}
+
+	if !errors.As(err, &multiErr) {
+		t.Fatal("Multi-error expected")
+	}
+
+	if len(multiErr.Errors()) != 3 {
+		t.Fatalf("Wrong children count: %d", len(multiErr.Errors()))
+	}
+
+	if !errors.Is(err, aoserrors.ErrNotFound) || !errors.Is(err, errTestError) {
+		t.Error("Multi-error should match all children")
+	}
+
+	for _, childErr := range multiErr.Errors() {
+		if !errors.As(childErr, new(*aoserrors.Error)) {
+			t.Errorf("Child should be Aos error: %v", childErr)
+		}
+	}
+
+	if !strings.HasPrefix(err.Error(), "3 errors: instance 0: not found [") ||
+		!strings.Contains(err.Error(), "; instance 1: not found [") {
+		t.Errorf("Wrong error message: %s", err.Error())
+	}
+
+	err = aoserrors.Combine(nil, errTestError)
+
+	if !strings.HasPrefix(err.Error(), errTestError.Error()+" [") {
+		t.Errorf("Wrong error message: %s", err.Error())
+	}
+}
+
+func TestRetryableErrors(t *testing.T) {
+	if aoserrors.IsRetryable(nil) || aoserrors.IsTemporary(nil) {
+		t.Error("Nil error should not be retryable")
+	}
+
+	if aoserrors.IsRetryable(errTestError) {
+		t.Error("Error should not be retryable")
+	}
+
+	if !aoserrors.IsTemporary(aoserrors.Wrap(aoserrors.ErrTimeout)) ||
+		!aoserrors.IsRetryable(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)) {
+		t.Error("Timeout error should be retryable")
+	}
+
+	if !aoserrors.IsTemporary(syscall.EAGAIN) {
+		t.Error("EAGAIN error should be temporary")
+	}
+
+	if aoserrors.IsRetryable(aoserrors.Wrap(context.Canceled)) {
+		t.Error("Canceled error should not be retryable")
+	}
+
+	err := aoserrors.MarkRetryable(errTestError)
+
+	if !aoserrors.IsRetryable(err) || !errors.Is(err, errTestError) {
+		t.Error("Marked error should be retryable")
+	}
+
+	if !strings.HasPrefix(err.Error(), errTestError.Error()+" [") {
+		t.Errorf("Wrong error message: %s", err.Error())
+	}
+
+	err = aoserrors.MarkPermanent(fmt.Errorf("wrapped: %w", aoserrors.MarkRetryable(aoserrors.ErrTimeout)))
+
+	if aoserrors.IsRetryable(err) || !aoserrors.IsPermanent(err) {
+		t.Error("Outer mark should take precedence")
+	}
+}
+
+func TestStackPolicy(t *testing.T) {
+	defer aoserrors.SetStackPolicy(aoserrors.StackPolicy{Mode: aoserrors.StackCaller})
+
+	aoserrors.SetStackPolicy(aoserrors.StackPolicy{Mode: aoserrors.StackNone})
+
+	if err := aoserrors.Wrap(errTestError); err.Error() != errTestError.Error() {
+		t.Errorf("Wrong error message: %s", err.Error())
+	}
+
+	aoserrors.SetStackPolicy(aoserrors.StackPolicy{Mode: aoserrors.StackFull, MaxDepth: 8})
+
+	if policy := aoserrors.GetStackPolicy(); policy.Mode != aoserrors.StackFull || policy.MaxDepth != 8 {
+		t.Errorf("Wrong stack policy: %v", policy)
+	}
+
+	err := aoserrors.Errorf("outer: %w", createTestError())
+
+	if !strings.HasPrefix(err.Error(), "outer: ") || !strings.Contains(err.Error(), ".createTestError:") {
+		t.Errorf("Wrong error message: %s", err.Error())
+	}
+
+	frames := aoserrors.StackTrace(err)
+	functions := make(map[string]int)
+
+	for _, frame := range frames {
+		functions[frame.Function]++
+	}
+
+	if functions["github.com/aosedge/aos_common/aoserrors_test.createTestError"] != 1 {
+		t.Errorf("Wrong stack frames: %v", functions)
+	}
+
+	if functions["testing.tRunner"] != 1 {
+		t.Errorf("Wrong stack frames: %v", functions)
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (hook *testLogHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (hook *testLogHook) Fire(entry *log.Entry) error {
+	hook.entries = append(hook.entries, entry)
+
+	return nil
+}
+
+//go:noinline
+func createTestError() error {
+	return aoserrors.Wrap(errTestError)
+}
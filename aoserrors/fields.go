@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aoserrors
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	log "github.com/sirupsen/logrus"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Common error field keys.
+const (
+	FieldInstanceID = "instanceID"
+	FieldNodeID     = "nodeID"
+	FieldURL        = "url"
+	FieldPath       = "path"
+)
+
+// Structured error log field keys.
+const (
+	FieldErrorCode     = "errorCode"
+	FieldErrorCategory = "errorCategory"
+	FieldErrorSource   = "errorSource"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Fields error context fields.
+type Fields map[string]interface{}
+
+// LogHook logrus hook which adds fields of error set with log.WithError to log entry.
+type LogHook struct {
+	// Structured adds error category and source location (where error originated) fields.
+	Structured bool
+	// ErrorCode adds error code field if set, e.g. cloudprotocol.GetErrorCode may be used.
+	ErrorCode func(err error) int
+}
+
+type fieldsError struct {
+	err    error
+	fields Fields
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// WithField attaches key/value context to error. Error message is not changed.
+func WithField(err error, key string, value interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	if !errors.As(err, new(*Error)) {
+		err = createAosError(err)
+	}
+
+	return &fieldsError{err: err, fields: Fields{key: value}}
+}
+
+// WithFields attaches key/value context to error. Error message is not changed.
+func WithFields(err error, fields Fields) error {
+	if err == nil {
+		return nil
+	}
+
+	if !errors.As(err, new(*Error)) {
+		err = createAosError(err)
+	}
+
+	return &fieldsError{err: err, fields: fields}
+}
+
+// GetFields returns all fields attached to error chain. Outer fields override inner ones with the same key.
+func GetFields(err error) Fields {
+	fields := make(Fields)
+
+	collectFields(err, fields)
+
+	return fields
+}
+
+// GetField returns error field value.
+func GetField(err error, key string) (value interface{}, ok bool) {
+	value, ok = GetFields(err)[key]
+
+	return value, ok
+}
+
+// LogFields returns error fields as logrus fields.
+func LogFields(err error) log.Fields {
+	return log.Fields(GetFields(err))
+}
+
+// Levels returns hook log levels.
+func (hook *LogHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire adds error fields to log entry. Fields already set in the entry are not overridden.
+func (hook *LogHook) Fire(entry *log.Entry) error {
+	err, ok := entry.Data[log.ErrorKey].(error)
+	if !ok {
+		return nil
+	}
+
+	for key, value := range GetFields(err) {
+		if _, exists := entry.Data[key]; !exists {
+			entry.Data[key] = value
+		}
+	}
+
+	if hook.ErrorCode != nil {
+		entry.Data[FieldErrorCode] = hook.ErrorCode(err)
+	}
+
+	if !hook.Structured {
+		return nil
+	}
+
+	if category := Category(err); category != nil {
+		entry.Data[FieldErrorCategory] = category.Error()
+	}
+
+	if source, ok := errorSource(err); ok {
+		entry.Data[FieldErrorSource] = source
+	}
+
+	return nil
+}
+
+// Error returns error message.
+func (err *fieldsError) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap unwraps error.
+func (err *fieldsError) Unwrap() error {
+	return err.err
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+// errorSource returns location of the innermost Aos error in error chain.
+func errorSource(err error) (source string, ok bool) {
+	var aosErr *Error
+
+	for errors.As(err, &aosErr) {
+		if len(aosErr.pcs) > 0 {
+			if frame, _ := runtime.CallersFrames(aosErr.pcs[:1]).Next(); frame.Function != "" {
+				source = fmt.Sprintf("%s:%d", frame.Function, frame.Line)
+				ok = true
+			}
+		}
+
+		err = aosErr.err
+	}
+
+	return source, ok
+}
+
+func collectFields(err error, fields Fields) {
+	switch wrappedErr := err.(type) { //nolint:errorlint // walk error tree manually
+	case nil:
+		return
+
+	case *fieldsError:
+		collectFields(wrappedErr.err, fields)
+
+		for key, value := range wrappedErr.fields {
+			fields[key] = value
+		}
+
+	case interface{ Unwrap() []error }:
+		for _, joinedErr := range wrappedErr.Unwrap() {
+			collectFields(joinedErr, fields)
+		}
+
+	case interface{ Unwrap() error }:
+		collectFields(wrappedErr.Unwrap(), fields)
+	}
+}
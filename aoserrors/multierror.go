@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aoserrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// MultiError aggregates multiple errors. Each child keeps its own Aos error location.
+type MultiError struct {
+	errs []error
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Append appends errors to err. If err is a multi-error, the errors are added to its children. Nil errors are
+// skipped. Nil is returned if there are no errors at all.
+func Append(err error, errs ...error) error {
+	multiErr := &MultiError{}
+
+	if existingErr, ok := err.(*MultiError); ok { //nolint:errorlint // only top level multi-error is extended
+		if existingErr != nil {
+			multiErr.errs = append(multiErr.errs, existingErr.errs...)
+		}
+	} else if err != nil {
+		multiErr.errs = append(multiErr.errs, wrapChild(err))
+	}
+
+	for _, childErr := range errs {
+		if childErr != nil {
+			multiErr.errs = append(multiErr.errs, wrapChild(childErr))
+		}
+	}
+
+	if len(multiErr.errs) == 0 {
+		return nil
+	}
+
+	return multiErr
+}
+
+// Combine combines errors into one multi-error. Nil errors are skipped. Nil is returned if there are no errors.
+func Combine(errs ...error) error {
+	multiErr := &MultiError{}
+
+	for _, childErr := range errs {
+		if childErr != nil {
+			multiErr.errs = append(multiErr.errs, wrapChild(childErr))
+		}
+	}
+
+	if len(multiErr.errs) == 0 {
+		return nil
+	}
+
+	return multiErr
+}
+
+// Errors returns multi-error children.
+func (multiErr *MultiError) Errors() []error {
+	return multiErr.errs
+}
+
+// Error returns compact multi-error message.
+func (multiErr *MultiError) Error() string {
+	if len(multiErr.errs) == 1 {
+		return multiErr.errs[0].Error()
+	}
+
+	messages := make([]string, 0, len(multiErr.errs))
+
+	for _, childErr := range multiErr.errs {
+		messages = append(messages, childErr.Error())
+	}
+
+	return fmt.Sprintf("%d errors: %s", len(multiErr.errs), strings.Join(messages, "; "))
+}
+
+// Unwrap returns multi-error children. It allows errors.Is and errors.As to match any child.
+func (multiErr *MultiError) Unwrap() []error {
+	return multiErr.errs
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+// wrapChild converts child error to Aos error pointing to the caller of Append or Combine.
+func wrapChild(fromErr error) error {
+	if errors.As(fromErr, new(*Error)) {
+		return fromErr
+	}
+
+	return &Error{err: fromErr, pcs: captureStack(callerLevel, fromErr)}
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aoserrors
+
+import (
+	"context"
+	"errors"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type retryableError struct {
+	err       error
+	retryable bool
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// MarkRetryable marks error as retryable. Error message is not changed.
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if !errors.As(err, new(*Error)) {
+		err = createAosError(err)
+	}
+
+	return &retryableError{err: err, retryable: true}
+}
+
+// MarkPermanent marks error as not retryable. Error message is not changed.
+func MarkPermanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if !errors.As(err, new(*Error)) {
+		err = createAosError(err)
+	}
+
+	return &retryableError{err: err, retryable: false}
+}
+
+// IsTemporary returns true if error is caused by temporary condition: timeout, resource temporary unavailable etc.
+func IsTemporary(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var temporaryErr interface{ Temporary() bool }
+
+	if errors.As(err, &temporaryErr) && temporaryErr.Temporary() {
+		return true
+	}
+
+	var timeoutErr interface{ Timeout() bool }
+
+	return errors.As(err, &timeoutErr) && timeoutErr.Timeout()
+}
+
+// IsRetryable returns true if operation failed with error may be retried. Explicit marking with MarkRetryable or
+// MarkPermanent takes precedence: the outermost mark wins. Not marked errors are retryable if they are temporary.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var retryableErr *retryableError
+
+	if errors.As(err, &retryableErr) {
+		return retryableErr.retryable
+	}
+
+	if errors.Is(err, ErrCanceled) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	return IsTemporary(err)
+}
+
+// IsPermanent returns true if error is explicitly marked as not retryable.
+func IsPermanent(err error) bool {
+	var retryableErr *retryableError
+
+	return errors.As(err, &retryableErr) && !retryableErr.retryable
+}
+
+// Error returns error message.
+func (err *retryableError) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap unwraps error.
+func (err *retryableError) Unwrap() error {
+	return err.err
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aoserrors
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Stack capture modes.
+const (
+	// StackCaller captures only the location where error is created or wrapped. This is default mode.
+	StackCaller StackMode = iota
+	// StackNone disables location capture. Error message contains only error text.
+	StackNone
+	// StackFull captures call stack up to StackPolicy.MaxDepth frames.
+	StackFull
+)
+
+const defaultMaxStackDepth = 32
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// StackMode stack capture mode.
+type StackMode int
+
+// StackPolicy defines how call stack is captured on error creation.
+type StackPolicy struct {
+	Mode     StackMode
+	MaxDepth int
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+var stackPolicy atomic.Pointer[StackPolicy] //nolint:gochecknoglobals // global policy switch
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// SetStackPolicy sets global stack capture policy. It affects errors created after the call.
+func SetStackPolicy(policy StackPolicy) {
+	if policy.MaxDepth <= 0 {
+		policy.MaxDepth = defaultMaxStackDepth
+	}
+
+	stackPolicy.Store(&policy)
+}
+
+// GetStackPolicy returns current stack capture policy.
+func GetStackPolicy() StackPolicy {
+	if policy := stackPolicy.Load(); policy != nil {
+		return *policy
+	}
+
+	return StackPolicy{Mode: StackCaller, MaxDepth: defaultMaxStackDepth}
+}
+
+// StackTrace returns call stack frames of all Aos errors in error chain starting from the outermost one. Frames
+// already present in inner errors are not repeated. Frames are resolved lazily on this call.
+func StackTrace(err error) (frames []runtime.Frame) {
+	for err != nil {
+		var aosErr *Error
+
+		if !errors.As(err, &aosErr) {
+			break
+		}
+
+		frames = append(frames, aosErr.frames()...)
+		err = aosErr.err
+	}
+
+	return frames
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+// captureStack captures raw program counters. Frames are not resolved here to keep error creation cheap. The skip
+// argument has the same meaning as for runtime.Caller called by the captureStack caller.
+func captureStack(skip int, fromErr error) []uintptr {
+	policy := GetStackPolicy()
+
+	switch policy.Mode {
+	case StackNone:
+		return nil
+
+	case StackFull:
+		pcs := make([]uintptr, policy.MaxDepth)
+
+		pcs = pcs[:runtime.Callers(skip+2, pcs)]
+
+		var innerErr *Error
+
+		if errors.As(fromErr, &innerErr) {
+			pcs = trimCommonFrames(pcs, innerErr.pcs)
+		}
+
+		return pcs
+
+	default:
+		pcs := make([]uintptr, 1)
+
+		return pcs[:runtime.Callers(skip+2, pcs)]
+	}
+}
+
+// trimCommonFrames removes outer frames shared with inner error stack keeping at least one frame.
+func trimCommonFrames(pcs, innerPCs []uintptr) []uintptr {
+	i, j := len(pcs)-1, len(innerPCs)-1
+
+	for i > 0 && j >= 0 && pcs[i] == innerPCs[j] {
+		i--
+		j--
+	}
+
+	return pcs[:i+1]
+}
+
+func (aosErr *Error) frames() (frames []runtime.Frame) {
+	if len(aosErr.pcs) == 0 {
+		return nil
+	}
+
+	callersFrames := runtime.CallersFrames(aosErr.pcs)
+
+	for {
+		frame, more := callersFrames.Next()
+
+		frames = append(frames, frame)
+
+		if !more {
+			break
+		}
+	}
+
+	return frames
+}
@@ -19,6 +19,8 @@ package aostypes
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -35,8 +37,9 @@ import (
 
 //nolint:revive
 const (
-	alternativePattern = `^P(((?P<year>\d+)-)((?P<month>\d+)-)((?P<day>\d+)))?(T((?P<hour>\d+):)((?P<minute>\d+):)(?P<second>\d+))?$`
-	canonicPattern     = `^P((?P<year>\d+)Y)?((?P<month>\d+)M)?((?P<week>\d+)W)?((?P<day>\d+)D)?(T((?P<hour>\d+)H)?((?P<minute>\d+)M)?((?P<second>\d+)S)?)?$`
+	alternativePattern    = `^P(((?P<year>\d+)-)((?P<month>\d+)-)((?P<day>\d+)))?(T((?P<hour>\d+):)((?P<minute>\d+):)(?P<second>\d+))?$`
+	canonicPattern        = `^P((?P<year>\d+)Y)?((?P<month>\d+)M)?((?P<week>\d+)W)?((?P<day>\d+)D)?(T((?P<hour>\d+)H)?((?P<minute>\d+)M)?((?P<second>\d+)S)?)?$`
+	durationSuffixPattern = `^(?:(\d+)w)?(?:(\d+)d)?(.*)$`
 )
 
 const (
@@ -61,6 +64,12 @@ const (
 	BalancingDisabled = "disabled"
 )
 
+// Alert rule bases.
+const (
+	AlertBasisNode  = "node"
+	AlertBasisLimit = "limit"
+)
+
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
@@ -109,6 +118,8 @@ type FirewallRule struct {
 	DstPort string `json:"dstPort"`
 	Proto   string `json:"proto"`
 	SrcIP   string `json:"srcIp"`
+	// Direction traffic direction relative to instance: in or out. Empty means out.
+	Direction string `json:"direction,omitempty"`
 }
 
 // NetworkParameters networks parameters.
@@ -146,16 +157,16 @@ type ServiceDevice struct {
 // ServiceQuotas service quotas representation.
 type ServiceQuotas struct {
 	CPUDMIPSLimit *uint64 `json:"cpuDmipsLimit,omitempty"`
-	RAMLimit      *uint64 `json:"ramLimit,omitempty"`
+	RAMLimit      *Size   `json:"ramLimit,omitempty"`
 	PIDsLimit     *uint64 `json:"pidsLimit,omitempty"`
 	NoFileLimit   *uint64 `json:"noFileLimit,omitempty"`
-	TmpLimit      *uint64 `json:"tmpLimit,omitempty"`
-	StateLimit    *uint64 `json:"stateLimit,omitempty"`
-	StorageLimit  *uint64 `json:"storageLimit,omitempty"`
-	UploadSpeed   *uint64 `json:"uploadSpeed,omitempty"`
-	DownloadSpeed *uint64 `json:"downloadSpeed,omitempty"`
-	UploadLimit   *uint64 `json:"uploadLimit,omitempty"`
-	DownloadLimit *uint64 `json:"downloadLimit,omitempty"`
+	TmpLimit      *Size   `json:"tmpLimit,omitempty"`
+	StateLimit    *Size   `json:"stateLimit,omitempty"`
+	StorageLimit  *Size   `json:"storageLimit,omitempty"`
+	UploadSpeed   *Size   `json:"uploadSpeed,omitempty"`
+	DownloadSpeed *Size   `json:"downloadSpeed,omitempty"`
+	UploadLimit   *Size   `json:"uploadLimit,omitempty"`
+	DownloadLimit *Size   `json:"downloadLimit,omitempty"`
 }
 
 // RunParameters service startup parameters.
@@ -168,12 +179,23 @@ type RunParameters struct {
 // AlertRulePercents describes alert rule.
 type AlertRulePercents struct {
 	MinTimeout   Duration `json:"minTimeout"`
-	MinThreshold float64  `json:"minThreshold"`
-	MaxThreshold float64  `json:"maxThreshold"`
+	MinThreshold Percent  `json:"minThreshold"`
+	MaxThreshold Percent  `json:"maxThreshold"`
+	// Basis defines what thresholds are percents of: node resource (default) or instance own limit. Only instance
+	// CPU rule supports limit basis.
+	Basis string `json:"basis,omitempty"`
 }
 
-// AlertRulePoints describes alert rule.
+// AlertRulePoints describes alert rule with thresholds in bytes. It is used for byte-valued resources only, such as
+// download and upload traffic.
 type AlertRulePoints struct {
+	MinTimeout   Duration `json:"minTimeout"`
+	MinThreshold Size     `json:"minThreshold"`
+	MaxThreshold Size     `json:"maxThreshold"`
+}
+
+// AlertRuleCount describes alert rule with unit-less count thresholds, such as number of file descriptors or tasks.
+type AlertRuleCount struct {
 	MinTimeout   Duration `json:"minTimeout"`
 	MinThreshold uint64   `json:"minThreshold"`
 	MaxThreshold uint64   `json:"maxThreshold"`
@@ -192,6 +214,13 @@ type AlertRules struct {
 	Partitions []PartitionAlertRule `json:"partitions,omitempty"`
 	Download   *AlertRulePoints     `json:"download,omitempty"`
 	Upload     *AlertRulePoints     `json:"upload,omitempty"`
+	// FileDescriptors open file descriptors count rule.
+	FileDescriptors *AlertRuleCount `json:"fileDescriptors,omitempty"`
+	// PIDs tasks count rule.
+	PIDs *AlertRuleCount `json:"pids,omitempty"`
+	// Entropy kernel available entropy rule, applied to node only. Alert is raised when entropy stays below
+	// MinThreshold and falls when entropy rises above MaxThreshold.
+	Entropy *AlertRuleCount `json:"entropy,omitempty"`
 }
 
 // ResourceRatiosInfo resource ratios info.
@@ -205,9 +234,9 @@ type ResourceRatiosInfo struct {
 // RequestedResources requested service resources (in absolute values: dmips, bytes).
 type RequestedResources struct {
 	CPU     *uint64 `json:"cpu"`
-	RAM     *uint64 `json:"ram"`
-	Storage *uint64 `json:"storage"`
-	State   *uint64 `json:"state"`
+	RAM     *Size   `json:"ram"`
+	Storage *Size   `json:"storage"`
+	State   *Size   `json:"state"`
 }
 
 // ServiceConfig Aos service configuration.
@@ -244,11 +273,21 @@ type MonitoringData struct {
 	Download   uint64           `json:"download"`
 	Upload     uint64           `json:"upload"`
 	Partitions []PartitionUsage `json:"partitions"`
+	// FileDescriptors open file descriptors count.
+	FileDescriptors uint64 `json:"fileDescriptors,omitempty"`
+	// PIDs tasks count.
+	PIDs uint64 `json:"pids,omitempty"`
+	// Entropy kernel available entropy in bits, reported for node only.
+	Entropy uint64 `json:"entropy,omitempty"`
 }
 
 type InstanceMonitoring struct {
 	InstanceIdent
 	MonitoringData
+	// CPULimit instance CPU limit in DMIPs. Zero means instance CPU is not limited.
+	CPULimit uint64 `json:"cpuLimit,omitempty"`
+	// CPULimitPercent instance CPU usage in percents of its own limit or of node CPU if instance is not limited.
+	CPULimitPercent uint64 `json:"cpuLimitPercent,omitempty"`
 }
 
 type NodeMonitoring struct {
@@ -257,6 +296,12 @@ type NodeMonitoring struct {
 	InstancesData []InstanceMonitoring `json:"instancesData"`
 }
 
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+var durationSuffixRegexp = regexp.MustCompile(durationSuffixPattern) //nolint:gochecknoglobals
+
 /***********************************************************************************************************************
  * Interfaces
  **********************************************************************************************************************/
@@ -337,27 +382,23 @@ func (d *Duration) UnmarshalJSON(b []byte) (err error) {
 
 	switch value := v.(type) {
 	case float64:
-		d.Duration = time.Duration(int64(value*float64(time.Second) + 0.5))
+		duration, err := secondsToDuration(value)
+		if err != nil {
+			return err
+		}
+
+		d.Duration = duration
 
 		return nil
 
 	case string:
-		if !strings.HasPrefix(value, "P") {
-			duration, err := time.ParseDuration(value)
-			if err != nil {
-				return aoserrors.Wrap(err)
-			}
-
-			d.Duration = duration
-		} else {
-			duration, err := parseISO8601Duration(value)
-			if err != nil {
-				return aoserrors.Wrap(err)
-			}
-
-			d.Duration = duration
+		duration, err := parseDuration(value)
+		if err != nil {
+			return err
 		}
 
+		d.Duration = duration
+
 		return nil
 
 	default:
@@ -365,6 +406,98 @@ func (d *Duration) UnmarshalJSON(b []byte) (err error) {
 	}
 }
 
+// MarshalText marshals Duration type to text.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.Duration.String()), nil
+}
+
+// UnmarshalText unmarshals Duration type from text.
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := parseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	d.Duration = duration
+
+	return nil
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "P") {
+		return parseISO8601Duration(value)
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+		return secondsToDuration(seconds)
+	}
+
+	match := durationSuffixRegexp.FindStringSubmatch(value)
+	if match == nil || (match[1] == "" && match[2] == "") {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+
+		return duration, nil
+	}
+
+	var duration time.Duration
+
+	for i, unit := range []time.Duration{weekDuration, dayDuration} {
+		if match[i+1] == "" {
+			continue
+		}
+
+		count, err := strconv.ParseInt(match[i+1], 10, 64)
+		if err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+
+		if count > math.MaxInt64/int64(unit) {
+			return 0, aoserrors.Errorf("duration out of range: %s", value)
+		}
+
+		if duration, err = addDuration(duration, time.Duration(count)*unit); err != nil {
+			return 0, aoserrors.Errorf("duration out of range: %s", value)
+		}
+	}
+
+	if match[3] != "" {
+		rest, err := time.ParseDuration(match[3])
+		if err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+
+		if duration, err = addDuration(duration, rest); err != nil {
+			return 0, aoserrors.Errorf("duration out of range: %s", value)
+		}
+	}
+
+	return duration, nil
+}
+
+func addDuration(duration, delta time.Duration) (time.Duration, error) {
+	if (delta > 0 && duration > math.MaxInt64-delta) || (delta < 0 && duration < math.MinInt64-delta) {
+		return 0, aoserrors.New("duration overflow")
+	}
+
+	return duration + delta, nil
+}
+
+func secondsToDuration(seconds float64) (time.Duration, error) {
+	nanoseconds := math.Round(seconds * float64(time.Second))
+
+	// float64(math.MaxInt64) is rounded up to 2^63, so equality also means overflow
+	if math.IsNaN(nanoseconds) || nanoseconds >= math.MaxInt64 || nanoseconds < math.MinInt64 {
+		return 0, aoserrors.Errorf("duration out of range: %v", seconds)
+	}
+
+	return time.Duration(nanoseconds), nil
+}
+
 func parseISO8601Duration(value string) (time.Duration, error) {
 	var (
 		patternStr = canonicPattern
@@ -19,6 +19,10 @@ package aostypes_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -98,6 +102,31 @@ func TestTimeMarshalign(t *testing.T) {
 			t.Errorf("Wrong json data: %s", string(rawJSON))
 		}
 	}
+
+	// test text marshaling
+
+	var durationMap map[aostypes.Duration]string
+
+	if err := json.Unmarshal([]byte(`{"2d":"value1","PT10S":"value2"}`), &durationMap); err != nil {
+		t.Fatalf("Can't unmarshal json: %s", err)
+	}
+
+	expectedMap := map[aostypes.Duration]string{
+		{2 * dayDuration}: "value1", {10 * time.Second}: "value2",
+	}
+
+	if !reflect.DeepEqual(durationMap, expectedMap) {
+		t.Errorf("Wrong duration map: %v", durationMap)
+	}
+
+	text, err := aostypes.Duration{90 * time.Second}.MarshalText()
+	if err != nil {
+		t.Fatalf("Can't marshal text: %s", err)
+	}
+
+	if string(text) != "1m30s" {
+		t.Errorf("Wrong duration text: %s", string(text))
+	}
 }
 
 func TestDurationMarshal(t *testing.T) {
@@ -123,6 +152,15 @@ func TestDurationMarshal(t *testing.T) {
 		{rawJSON: `{"duration":"P0001-01-01T01:01:01"}`, duration: aostypes.Duration{
 			yearDuration + monthDuration + dayDuration + time.Hour + time.Minute + time.Second,
 		}},
+		{rawJSON: `{"duration":10}`, duration: aostypes.Duration{10 * time.Second}},
+		{rawJSON: `{"duration":1.5}`, duration: aostypes.Duration{1500 * time.Millisecond}},
+		{rawJSON: `{"duration":-1.5}`, duration: aostypes.Duration{-1500 * time.Millisecond}},
+		{rawJSON: `{"duration":"-0.0000000015"}`, duration: aostypes.Duration{-2 * time.Nanosecond}},
+		{rawJSON: `{"duration":"2d"}`, duration: aostypes.Duration{2 * dayDuration}},
+		{rawJSON: `{"duration":"1w"}`, duration: aostypes.Duration{weekDuration}},
+		{rawJSON: `{"duration":"1w2d3h30m"}`, duration: aostypes.Duration{
+			weekDuration + 2*dayDuration + 3*time.Hour + 30*time.Minute,
+		}},
 	}
 
 	for _, item := range unmarshalData {
@@ -146,6 +184,18 @@ func TestDurationMarshal(t *testing.T) {
 		`{"duration":"T1H1M1S"}`,
 		`{"duration":"P0001-01T01:01:01"}`,
 		`{"duration":"P0001-01-01T01:01"}`,
+		`{"duration":"2d1x"}`,
+		`{"duration":"NaN"}`,
+		`{"duration":"Inf"}`,
+		`{"duration":"-Inf"}`,
+		`{"duration":"1e400"}`,
+		`{"duration":"9300000000"}`,
+		`{"duration":1e30}`,
+		`{"duration":-1e30}`,
+		`{"duration":"15251w"}`,
+		`{"duration":"15250w2d"}`,
+		`{"duration":"15250w1d24h"}`,
+		`{"duration":"99999999999999999999d"}`,
 	}
 
 	for _, item := range invalidUnmarshalData {
@@ -180,3 +230,334 @@ func TestDurationMarshal(t *testing.T) {
 		}
 	}
 }
+
+func TestQuantityMarshal(t *testing.T) {
+	var quantities struct {
+		Sizes       []aostypes.Size      `json:"sizes"`
+		Percents    []aostypes.Percent   `json:"percents"`
+		Frequencies []aostypes.Frequency `json:"frequencies"`
+	}
+
+	rawJSON := `{
+		"sizes": [1024, "512Mi", "2G", "1.5Ki", "100B", "18446744073709551615"],
+		"percents": [50, "75%", "12.5"],
+		"frequencies": [100, "1.2GHz", "800MHz", "32kHz"]
+	}`
+
+	if err := json.Unmarshal([]byte(rawJSON), &quantities); err != nil {
+		t.Fatalf("Can't unmarshal json: %v", err)
+	}
+
+	expectedSizes := []aostypes.Size{1024, 512 << 20, 2e9, 1536, 100, 18446744073709551615}
+
+	if !reflect.DeepEqual(quantities.Sizes, expectedSizes) {
+		t.Errorf("Wrong sizes: %v", quantities.Sizes)
+	}
+
+	if !reflect.DeepEqual(quantities.Percents, []aostypes.Percent{50, 75, 12.5}) {
+		t.Errorf("Wrong percents: %v", quantities.Percents)
+	}
+
+	if !reflect.DeepEqual(quantities.Frequencies, []aostypes.Frequency{100, 1.2e9, 800e6, 32e3}) {
+		t.Errorf("Wrong frequencies: %v", quantities.Frequencies)
+	}
+
+	for _, invalid := range []string{
+		`{"sizes":["10Xi"]}`, `{"percents":["10Hz"]}`, `{"frequencies":[true]}`, `{"sizes":["1e30Gi"]}`,
+		`{"sizes":["NaN"]}`, `{"sizes":["1e400"]}`, `{"sizes":[18446744073709551616]}`, `{"frequencies":["2e10GHz"]}`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &quantities); err == nil {
+			t.Errorf("Should be error unmarshal for quantity: %s", invalid)
+		}
+	}
+
+	// Quantities are marshaled to JSON as numbers and to text with units to be used as map keys.
+
+	data, err := json.Marshal(map[aostypes.Size]aostypes.Size{512 << 20: 2e9})
+	if err != nil {
+		t.Fatalf("Can't marshal json: %v", err)
+	}
+
+	if string(data) != `{"512Mi":2000000000}` {
+		t.Errorf("Wrong json data: %s", string(data))
+	}
+
+	if aostypes.Frequency(1.2e9).String() != "1200MHz" || aostypes.Percent(12.5).String() != "12.5%" {
+		t.Errorf("Wrong quantity strings: %s, %s", aostypes.Frequency(1.2e9), aostypes.Percent(12.5))
+	}
+	var alertRule aostypes.AlertRulePoints
+
+	if err = json.Unmarshal([]byte(`{"minThreshold":"512Ki","maxThreshold":"1Mi"}`), &alertRule); err != nil {
+		t.Fatalf("Can't unmarshal json: %v", err)
+	}
+
+	if alertRule.MinThreshold != 512<<10 || alertRule.MaxThreshold != 1<<20 {
+		t.Errorf("Wrong alert rule thresholds: %v, %v", alertRule.MinThreshold, alertRule.MaxThreshold)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testData := []struct {
+		item           aostypes.Validatable
+		expectedFields []string
+	}{
+		{
+			item: aostypes.ServiceInfo{
+				ServiceID: "service1", Version: "1.0.0", URL: "file:///service1", Sha256: make([]byte, 32),
+			},
+		},
+		{
+			item:           aostypes.ServiceInfo{ServiceID: "service1", Sha256: []byte{1, 2, 3}},
+			expectedFields: []string{"version", "url", "sha256"},
+		},
+		{
+			item:           aostypes.LayerInfo{LayerID: "layer1", Version: "1.0.0", URL: "file:///layer1"},
+			expectedFields: []string{"digest", "sha256"},
+		},
+		{
+			item: aostypes.InstanceInfo{
+				InstanceIdent: aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1"},
+				NetworkParameters: aostypes.NetworkParameters{
+					IP: "172.17.0.2", Subnet: "172.17.0.0/16", DNSServers: []string{"10.10.0.1"},
+					FirewallRules: []aostypes.FirewallRule{
+						{DstIP: "10.0.0.0/24", DstPort: "80-8080", Proto: "tcp", SrcIP: "172.17.0.2"},
+					},
+				},
+			},
+		},
+		{
+			item: aostypes.InstanceInfo{
+				InstanceIdent: aostypes.InstanceIdent{ServiceID: "service1"},
+				NetworkParameters: aostypes.NetworkParameters{
+					IP: "172.17.0.256", FirewallRules: []aostypes.FirewallRule{
+						{DstIP: "10.0.0.1", DstPort: "70000", Proto: "icmp"},
+					},
+				},
+			},
+			expectedFields: []string{
+				"subjectId", "ip", "firewallRules[0].proto", "firewallRules[0].dstPort",
+			},
+		},
+		{
+			item: aostypes.AlertRules{
+				RAM:        &aostypes.AlertRulePercents{MinThreshold: 90, MaxThreshold: 80},
+				Partitions: []aostypes.PartitionAlertRule{{AlertRulePercents: aostypes.AlertRulePercents{MaxThreshold: 120}}},
+				Download:   &aostypes.AlertRulePoints{MinThreshold: 100, MaxThreshold: 200},
+				PIDs:       &aostypes.AlertRuleCount{MinThreshold: 20, MaxThreshold: 10},
+				Entropy:    &aostypes.AlertRuleCount{MinThreshold: 128, MaxThreshold: 256},
+			},
+			expectedFields: []string{
+				"ram.minThreshold", "partitions[0].name", "partitions[0].maxThreshold", "pids.minThreshold",
+			},
+		},
+		{
+			item: aostypes.ServiceConfig{
+				BalancingPolicy: "unknown",
+				AlertRules:      &aostypes.AlertRules{Upload: &aostypes.AlertRulePoints{MinThreshold: 2, MaxThreshold: 1}},
+			},
+			expectedFields: []string{"balancingPolicy", "alertRules.upload.minThreshold"},
+		},
+	}
+
+	for i, testItem := range testData {
+		err := testItem.item.Validate()
+
+		if len(testItem.expectedFields) == 0 {
+			if err != nil {
+				t.Errorf("Unexpected validation error for item %d: %v", i, err)
+			}
+
+			continue
+		}
+
+		var validationErrs aostypes.ValidationErrors
+
+		if !errors.As(err, &validationErrs) {
+			t.Errorf("Validation errors expected for item %d, got: %v", i, err)
+
+			continue
+		}
+
+		fields := make([]string, 0, len(validationErrs))
+
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, fieldErr.Field)
+		}
+
+		if !reflect.DeepEqual(fields, testItem.expectedFields) {
+			t.Errorf("Wrong invalid fields for item %d: %v", i, fields)
+		}
+	}
+}
+
+func TestInstanceIdentEncoding(t *testing.T) {
+	testData := []struct {
+		ident    aostypes.InstanceIdent
+		str      string
+		pathName string
+	}{
+		{
+			ident:    aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1", Instance: 2},
+			str:      "service1:subject1:2",
+			pathName: "service1_subject1_2",
+		},
+		{
+			ident:    aostypes.InstanceIdent{ServiceID: "srv:1_a", SubjectID: "sub/ject%", Instance: 0},
+			str:      "srv%3A1_a:sub/ject%25:0",
+			pathName: "srv%3A1%5Fa_sub%2Fject%25_0",
+		},
+	}
+
+	for _, item := range testData {
+		if item.ident.Key() != item.str {
+			t.Errorf("Wrong instance ident string: %s", item.ident.Key())
+		}
+
+		if item.ident.PathName() != item.pathName {
+			t.Errorf("Wrong instance ident path name: %s", item.ident.PathName())
+		}
+
+		ident, err := aostypes.ParseInstanceIdent(item.str)
+		if err != nil || ident != item.ident {
+			t.Errorf("Wrong parsed instance ident: %v, %v", ident, err)
+		}
+
+		if ident, err = aostypes.ParseInstanceIdentPathName(item.pathName); err != nil || ident != item.ident {
+			t.Errorf("Wrong parsed instance ident: %v, %v", ident, err)
+		}
+	}
+
+	for _, invalid := range []string{"service1:subject1", "service1:subject1:a", ":subject1:0", "service%1:subject1:0"} {
+		if _, err := aostypes.ParseInstanceIdent(invalid); err == nil {
+			t.Errorf("Should be error parse instance ident: %s", invalid)
+		}
+	}
+
+	info := aostypes.InstanceInfo{
+		InstanceIdent: aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1", Instance: 2},
+		StoragePath:   "storage1",
+	}
+
+	if str := fmt.Sprintf("%v", info); !strings.Contains(str, "storage1") {
+		t.Errorf("Embedded instance ident hides instance info fields: %s", str)
+	}
+}
+
+func TestFirewallRules(t *testing.T) {
+	overlapData := []struct {
+		first, second aostypes.FirewallRule
+		overlaps      bool
+	}{
+		{
+			first:    aostypes.FirewallRule{DstIP: "10.0.0.0/24", DstPort: "80-90", Proto: "tcp"},
+			second:   aostypes.FirewallRule{DstIP: "10.0.0.5", DstPort: "85", Proto: "TCP"},
+			overlaps: true,
+		},
+		{
+			first:  aostypes.FirewallRule{DstIP: "10.0.0.0/24", DstPort: "80-90", Proto: "tcp"},
+			second: aostypes.FirewallRule{DstIP: "10.0.1.5", DstPort: "85", Proto: "tcp"},
+		},
+		{
+			first:  aostypes.FirewallRule{DstPort: "80:90", Proto: "tcp"},
+			second: aostypes.FirewallRule{DstPort: "91", Proto: "tcp"},
+		},
+		{
+			first:  aostypes.FirewallRule{DstPort: "80", Proto: "tcp"},
+			second: aostypes.FirewallRule{DstPort: "80", Proto: "udp"},
+		},
+		{
+			first:    aostypes.FirewallRule{DstPort: "80"},
+			second:   aostypes.FirewallRule{DstPort: "80", Proto: "udp", Direction: aostypes.FirewallDirectionOut},
+			overlaps: true,
+		},
+		{
+			first:  aostypes.FirewallRule{DstPort: "80", Direction: aostypes.FirewallDirectionIn},
+			second: aostypes.FirewallRule{DstPort: "80"},
+		},
+	}
+
+	for i, item := range overlapData {
+		overlaps, err := item.first.Overlaps(item.second)
+		if err != nil {
+			t.Fatalf("Can't check overlap %d: %v", i, err)
+		}
+
+		if overlaps != item.overlaps {
+			t.Errorf("Wrong overlap result %d: %v", i, overlaps)
+		}
+	}
+
+	if _, err := (aostypes.FirewallRule{DstPort: "90-80"}).Parse(); err == nil {
+		t.Error("Error expected for wrong port range")
+	}
+
+	if _, err := (aostypes.FirewallRule{Direction: "both"}).Parse(); err == nil {
+		t.Error("Error expected for wrong direction")
+	}
+
+	canonical, err := aostypes.FirewallRule{DstIP: "10.0.0.7/24", DstPort: "80:80", Proto: "TCP"}.Canonical()
+	if err != nil {
+		t.Fatalf("Can't get canonical rule: %v", err)
+	}
+
+	if canonical != (aostypes.FirewallRule{
+		DstIP: "10.0.0.0/24", DstPort: "80", Proto: "tcp", Direction: aostypes.FirewallDirectionOut,
+	}) {
+		t.Errorf("Wrong canonical rule: %v", canonical)
+	}
+
+	rules := []aostypes.FirewallRule{
+		{DstIP: "10.0.0.2", DstPort: "443", Proto: "tcp"},
+		{DstIP: "10.0.0.2", DstPort: "80", Proto: "tcp"},
+		{DstPort: "53", Proto: "udp"},
+		{DstPort: "8080", Proto: "tcp", Direction: aostypes.FirewallDirectionIn},
+		{DstIP: "10.0.0.1", DstPort: "443", Proto: "tcp"},
+	}
+
+	aostypes.SortFirewallRules(rules)
+
+	expectedRules := []aostypes.FirewallRule{
+		{DstPort: "8080", Proto: "tcp", Direction: aostypes.FirewallDirectionIn},
+		{DstIP: "10.0.0.1", DstPort: "443", Proto: "tcp"},
+		{DstIP: "10.0.0.2", DstPort: "80", Proto: "tcp"},
+		{DstIP: "10.0.0.2", DstPort: "443", Proto: "tcp"},
+		{DstPort: "53", Proto: "udp"},
+	}
+
+	if !reflect.DeepEqual(rules, expectedRules) {
+		t.Errorf("Wrong sorted rules: %v", rules)
+	}
+
+	instances := []aostypes.InstanceInfo{
+		{
+			InstanceIdent: aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1"},
+			NetworkParameters: aostypes.NetworkParameters{FirewallRules: []aostypes.FirewallRule{
+				{DstPort: "8000-8100", Proto: "tcp", Direction: aostypes.FirewallDirectionIn},
+				{DstPort: "443", Proto: "tcp"},
+			}},
+		},
+		{
+			InstanceIdent: aostypes.InstanceIdent{ServiceID: "service2", SubjectID: "subject1"},
+			NetworkParameters: aostypes.NetworkParameters{FirewallRules: []aostypes.FirewallRule{
+				{DstPort: "8080", Proto: "tcp", Direction: aostypes.FirewallDirectionIn},
+				{DstPort: "443", Proto: "tcp"},
+			}},
+		},
+		{
+			InstanceIdent: aostypes.InstanceIdent{ServiceID: "service3", SubjectID: "subject1"},
+			NetworkParameters: aostypes.NetworkParameters{FirewallRules: []aostypes.FirewallRule{
+				{DstPort: "8080", Proto: "udp", Direction: aostypes.FirewallDirectionIn},
+			}},
+		},
+	}
+
+	conflicts, err := aostypes.FindFirewallConflicts(instances)
+	if err != nil {
+		t.Fatalf("Can't find firewall conflicts: %v", err)
+	}
+
+	if len(conflicts) != 1 || conflicts[0].Instances[0] != instances[0].InstanceIdent ||
+		conflicts[0].Instances[1] != instances[1].InstanceIdent {
+		t.Errorf("Wrong firewall conflicts: %v", conflicts)
+	}
+}
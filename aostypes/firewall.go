@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aostypes
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Firewall rule directions.
+const (
+	FirewallDirectionIn  = "in"
+	FirewallDirectionOut = "out"
+)
+
+// Firewall rule protocols.
+const (
+	FirewallProtoTCP = "tcp"
+	FirewallProtoUDP = "udp"
+)
+
+const maxPort = 65535
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// PortRange inclusive port range.
+type PortRange struct {
+	From uint16
+	To   uint16
+}
+
+// FirewallRuleSpec parsed firewall rule. Nil network and empty protocol match any value.
+type FirewallRuleSpec struct {
+	Direction string
+	Proto     string
+	SrcNet    *net.IPNet
+	DstNet    *net.IPNet
+	DstPorts  PortRange
+}
+
+// FirewallConflict overlapping inbound rules of different instances.
+type FirewallConflict struct {
+	Instances [2]InstanceIdent
+	Rules     [2]FirewallRule
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ParsePortRange parses port or port range in "from-to" or "from:to" format. Empty value means all ports.
+func ParsePortRange(value string) (portRange PortRange, err error) {
+	if value == "" {
+		return PortRange{From: 0, To: maxPort}, nil
+	}
+
+	if !isValidPortRange(value) {
+		return PortRange{}, aoserrors.Errorf("invalid port %q", value)
+	}
+
+	ports := strings.FieldsFunc(value, func(r rune) bool { return r == '-' || r == ':' })
+
+	from, _ := strconv.ParseUint(ports[0], 10, 16)
+	to := from
+
+	if len(ports) > 1 {
+		to, _ = strconv.ParseUint(ports[1], 10, 16)
+	}
+
+	if from > to {
+		return PortRange{}, aoserrors.Errorf("invalid port range %q", value)
+	}
+
+	return PortRange{From: uint16(from), To: uint16(to)}, nil
+}
+
+// String returns port range in canonical format.
+func (portRange PortRange) String() string {
+	if portRange.From == portRange.To {
+		return strconv.FormatUint(uint64(portRange.From), 10)
+	}
+
+	return fmt.Sprintf("%d-%d", portRange.From, portRange.To)
+}
+
+// Overlaps returns true if port ranges have common ports.
+func (portRange PortRange) Overlaps(other PortRange) bool {
+	return portRange.From <= other.To && other.From <= portRange.To
+}
+
+// Parse parses firewall rule.
+func (rule FirewallRule) Parse() (spec FirewallRuleSpec, err error) {
+	if err = rule.Validate(); err != nil {
+		return FirewallRuleSpec{}, err
+	}
+
+	spec.Direction = rule.Direction
+	if spec.Direction == "" {
+		spec.Direction = FirewallDirectionOut
+	}
+
+	spec.Proto = strings.ToLower(rule.Proto)
+
+	if spec.SrcNet, err = parseNetwork(rule.SrcIP); err != nil {
+		return FirewallRuleSpec{}, err
+	}
+
+	if spec.DstNet, err = parseNetwork(rule.DstIP); err != nil {
+		return FirewallRuleSpec{}, err
+	}
+
+	if spec.DstPorts, err = ParsePortRange(rule.DstPort); err != nil {
+		return FirewallRuleSpec{}, err
+	}
+
+	return spec, nil
+}
+
+// Canonical returns firewall rule in canonical form: lower case protocol, explicit direction, normalized networks
+// and port range.
+func (rule FirewallRule) Canonical() (FirewallRule, error) {
+	spec, err := rule.Parse()
+	if err != nil {
+		return FirewallRule{}, err
+	}
+
+	canonical := FirewallRule{
+		DstIP: formatNetwork(spec.DstNet), SrcIP: formatNetwork(spec.SrcNet), Proto: spec.Proto,
+		Direction: spec.Direction,
+	}
+
+	if rule.DstPort != "" {
+		canonical.DstPort = spec.DstPorts.String()
+	}
+
+	return canonical, nil
+}
+
+// Overlaps returns true if there is traffic matched by both rules.
+func (rule FirewallRule) Overlaps(other FirewallRule) (bool, error) {
+	spec, err := rule.Parse()
+	if err != nil {
+		return false, err
+	}
+
+	otherSpec, err := other.Parse()
+	if err != nil {
+		return false, err
+	}
+
+	return spec.Overlaps(otherSpec), nil
+}
+
+// Overlaps returns true if there is traffic matched by both rules.
+func (spec FirewallRuleSpec) Overlaps(other FirewallRuleSpec) bool {
+	if spec.Direction != other.Direction {
+		return false
+	}
+
+	if spec.Proto != "" && other.Proto != "" && spec.Proto != other.Proto {
+		return false
+	}
+
+	return networksOverlap(spec.SrcNet, other.SrcNet) && networksOverlap(spec.DstNet, other.DstNet) &&
+		spec.DstPorts.Overlaps(other.DstPorts)
+}
+
+// SortFirewallRules sorts firewall rules in canonical order: by direction, protocol, destination, destination port
+// and source.
+func SortFirewallRules(rules []FirewallRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return compareFirewallRules(rules[i], rules[j]) < 0
+	})
+}
+
+// FindFirewallConflicts returns overlapping inbound rules of different instances.
+func FindFirewallConflicts(instances []InstanceInfo) (conflicts []FirewallConflict, err error) {
+	type instanceRule struct {
+		ident InstanceIdent
+		rule  FirewallRule
+		spec  FirewallRuleSpec
+	}
+
+	var rules []instanceRule
+
+	for _, instance := range instances {
+		for _, rule := range instance.FirewallRules {
+			spec, err := rule.Parse()
+			if err != nil {
+				return nil, aoserrors.Errorf("instance %v: %w", instance.InstanceIdent, err)
+			}
+
+			if spec.Direction == FirewallDirectionIn {
+				rules = append(rules, instanceRule{ident: instance.InstanceIdent, rule: rule, spec: spec})
+			}
+		}
+	}
+
+	for i := range rules {
+		for j := i + 1; j < len(rules); j++ {
+			if rules[i].ident == rules[j].ident || !rules[i].spec.Overlaps(rules[j].spec) {
+				continue
+			}
+
+			conflicts = append(conflicts, FirewallConflict{
+				Instances: [2]InstanceIdent{rules[i].ident, rules[j].ident},
+				Rules:     [2]FirewallRule{rules[i].rule, rules[j].rule},
+			})
+		}
+	}
+
+	return conflicts, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func parseNetwork(address string) (*net.IPNet, error) {
+	if address == "" {
+		return nil, nil
+	}
+
+	if ip := net.ParseIP(address); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)}, nil //nolint:mnd
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)}, nil //nolint:mnd
+	}
+
+	_, network, err := net.ParseCIDR(address)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return network, nil
+}
+
+func formatNetwork(network *net.IPNet) string {
+	if network == nil {
+		return ""
+	}
+
+	if ones, bits := network.Mask.Size(); ones == bits {
+		return network.IP.String()
+	}
+
+	return network.String()
+}
+
+func networksOverlap(first, second *net.IPNet) bool {
+	if first == nil || second == nil {
+		return true
+	}
+
+	return first.Contains(second.IP) || second.Contains(first.IP)
+}
+
+func compareFirewallRules(first, second FirewallRule) int {
+	firstSpec, firstErr := first.Parse()
+	secondSpec, secondErr := second.Parse()
+
+	// Invalid rules are ordered after valid ones by raw fields
+	if firstErr != nil || secondErr != nil {
+		if (firstErr == nil) != (secondErr == nil) {
+			if firstErr == nil {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(fmt.Sprint(first), fmt.Sprint(second))
+	}
+
+	if result := strings.Compare(firstSpec.Direction, secondSpec.Direction); result != 0 {
+		return result
+	}
+
+	if result := strings.Compare(firstSpec.Proto, secondSpec.Proto); result != 0 {
+		return result
+	}
+
+	if result := compareNetworks(firstSpec.DstNet, secondSpec.DstNet); result != 0 {
+		return result
+	}
+
+	if firstSpec.DstPorts != secondSpec.DstPorts {
+		if firstSpec.DstPorts.From != secondSpec.DstPorts.From {
+			return int(firstSpec.DstPorts.From) - int(secondSpec.DstPorts.From)
+		}
+
+		return int(firstSpec.DstPorts.To) - int(secondSpec.DstPorts.To)
+	}
+
+	return compareNetworks(firstSpec.SrcNet, secondSpec.SrcNet)
+}
+
+func compareNetworks(first, second *net.IPNet) int {
+	switch {
+	case first == nil && second == nil:
+		return 0
+
+	case first == nil:
+		return -1
+
+	case second == nil:
+		return 1
+	}
+
+	if result := bytes.Compare(first.IP.To16(), second.IP.To16()); result != 0 {
+		return result
+	}
+
+	return bytes.Compare(first.Mask, second.Mask)
+}
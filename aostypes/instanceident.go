@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aostypes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	instanceIdentSeparator     = ':'
+	instanceIdentPathSeparator = '_'
+	instanceIdentFieldsCount   = 3
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Key returns canonical instance ident representation: "<serviceID>:<subjectID>:<instance>".
+// Separator and escape characters inside IDs are percent-encoded. It is intentionally not named String: InstanceIdent
+// is embedded into many structures and a String method would be promoted to them and hide their other fields.
+func (ident InstanceIdent) Key() string {
+	return encodeInstanceIdent(ident, instanceIdentSeparator, func(r rune) bool {
+		return r != instanceIdentSeparator && r != '%'
+	})
+}
+
+// ParseInstanceIdent parses instance ident from canonical representation.
+func ParseInstanceIdent(value string) (InstanceIdent, error) {
+	return decodeInstanceIdent(value, instanceIdentSeparator)
+}
+
+// PathName returns filepath-safe instance ident representation: "<serviceID>_<subjectID>_<instance>".
+// All characters except letters, digits, dot and dash are percent-encoded.
+func (ident InstanceIdent) PathName() string {
+	return encodeInstanceIdent(ident, instanceIdentPathSeparator, func(r rune) bool {
+		return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-'
+	})
+}
+
+// ParseInstanceIdentPathName parses instance ident from filepath-safe representation.
+func ParseInstanceIdentPathName(name string) (InstanceIdent, error) {
+	return decodeInstanceIdent(name, instanceIdentPathSeparator)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func encodeInstanceIdent(ident InstanceIdent, separator rune, isAllowed func(r rune) bool) string {
+	return escapeIdentField(ident.ServiceID, isAllowed) + string(separator) +
+		escapeIdentField(ident.SubjectID, isAllowed) + string(separator) +
+		strconv.FormatUint(ident.Instance, 10)
+}
+
+func decodeInstanceIdent(value string, separator rune) (ident InstanceIdent, err error) {
+	fields := strings.Split(value, string(separator))
+	if len(fields) != instanceIdentFieldsCount {
+		return ident, aoserrors.Errorf("invalid instance ident: %s", value)
+	}
+
+	if ident.ServiceID, err = unescapeIdentField(fields[0]); err != nil {
+		return ident, err
+	}
+
+	if ident.SubjectID, err = unescapeIdentField(fields[1]); err != nil {
+		return ident, err
+	}
+
+	if ident.Instance, err = strconv.ParseUint(fields[2], 10, 64); err != nil {
+		return ident, aoserrors.Errorf("invalid instance ident: %s", value)
+	}
+
+	if ident.ServiceID == "" || ident.SubjectID == "" {
+		return ident, aoserrors.Errorf("invalid instance ident: %s", value)
+	}
+
+	return ident, nil
+}
+
+func escapeIdentField(field string, isAllowed func(r rune) bool) string {
+	var builder strings.Builder
+
+	for _, b := range []byte(field) {
+		if b < 0x80 && isAllowed(rune(b)) {
+			builder.WriteByte(b)
+
+			continue
+		}
+
+		fmt.Fprintf(&builder, "%%%02X", b)
+	}
+
+	return builder.String()
+}
+
+func unescapeIdentField(field string) (string, error) {
+	if !strings.Contains(field, "%") {
+		return field, nil
+	}
+
+	result := make([]byte, 0, len(field))
+
+	for i := 0; i < len(field); i++ {
+		if field[i] != '%' {
+			result = append(result, field[i])
+
+			continue
+		}
+
+		if i+2 >= len(field) {
+			return "", aoserrors.Errorf("invalid escape sequence in %s", field)
+		}
+
+		value, err := strconv.ParseUint(field[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", aoserrors.Errorf("invalid escape sequence in %s", field)
+		}
+
+		result = append(result, byte(value))
+		i += 2
+	}
+
+	return string(result), nil
+}
@@ -0,0 +1,330 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aostypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const quantityPattern = `^([0-9]*\.?[0-9]+(?:[eE][-+]?[0-9]+)?)\s*([a-zA-Z%]*)$`
+
+const percentSuffix = "%"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Size represents size in bytes. Accepts plain numbers and strings with decimal ("2G") or binary ("512Mi") suffixes.
+type Size uint64 //nolint:recvcheck
+
+// Percent represents percent value. Accepts plain numbers and strings with optional "%" suffix.
+type Percent float64 //nolint:recvcheck
+
+// Frequency represents frequency in Hz. Accepts plain numbers and strings with "Hz", "kHz", "MHz", "GHz" suffixes.
+type Frequency uint64 //nolint:recvcheck
+
+type quantityUnit struct {
+	suffix     string
+	multiplier uint64
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var (
+	quantityRegexp = regexp.MustCompile(quantityPattern)
+
+	// Units are ordered from the largest to the smallest to format values with the largest exact unit.
+	sizeUnits = []quantityUnit{
+		{"Pi", 1 << 50}, {"P", 1e15}, {"Ti", 1 << 40}, {"T", 1e12}, {"Gi", 1 << 30}, {"G", 1e9},
+		{"Mi", 1 << 20}, {"M", 1e6}, {"Ki", 1 << 10}, {"K", 1e3},
+	}
+
+	frequencyUnits = []quantityUnit{{"GHz", 1e9}, {"MHz", 1e6}, {"kHz", 1e3}, {"Hz", 1}}
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ParseSize parses size string.
+func ParseSize(value string) (Size, error) {
+	if size, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64); err == nil {
+		return Size(size), nil
+	}
+
+	number, suffix, err := parseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+
+	// Accept optional byte suffix: "512MiB", "2GB", "100B" and lower case "k".
+	suffix = strings.TrimSuffix(suffix, "B")
+
+	if suffix == "k" {
+		suffix = "K"
+	}
+
+	multiplier, ok := findUnit(sizeUnits, suffix)
+	if !ok && suffix != "" {
+		return 0, aoserrors.Errorf("invalid size suffix: %s", suffix)
+	}
+
+	if !ok {
+		multiplier = 1
+	}
+
+	size, err := scaleQuantity(number, multiplier)
+	if err != nil {
+		return 0, err
+	}
+
+	return Size(size), nil
+}
+
+// String returns size string with the largest exact unit.
+func (size Size) String() string {
+	return formatQuantity(uint64(size), sizeUnits, "")
+}
+
+// MarshalJSON marshals JSON Size type.
+func (size Size) MarshalJSON() ([]byte, error) {
+	return marshalJSON(uint64(size))
+}
+
+// UnmarshalJSON unmarshals JSON Size type.
+func (size *Size) UnmarshalJSON(b []byte) error {
+	return unmarshalQuantityJSON(b, size.UnmarshalText)
+}
+
+// MarshalText marshals Size type to text.
+func (size Size) MarshalText() ([]byte, error) {
+	return []byte(size.String()), nil
+}
+
+// UnmarshalText unmarshals Size type from text.
+func (size *Size) UnmarshalText(text []byte) error {
+	value, err := ParseSize(string(text))
+	if err != nil {
+		return err
+	}
+
+	*size = value
+
+	return nil
+}
+
+// ParsePercent parses percent string.
+func ParsePercent(value string) (Percent, error) {
+	number, suffix, err := parseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if suffix != "" && suffix != percentSuffix {
+		return 0, aoserrors.Errorf("invalid percent suffix: %s", suffix)
+	}
+
+	return Percent(number), nil
+}
+
+// String returns percent string.
+func (percent Percent) String() string {
+	return strconv.FormatFloat(float64(percent), 'f', -1, 64) + percentSuffix
+}
+
+// MarshalJSON marshals JSON Percent type.
+func (percent Percent) MarshalJSON() ([]byte, error) {
+	return marshalJSON(float64(percent))
+}
+
+// UnmarshalJSON unmarshals JSON Percent type.
+func (percent *Percent) UnmarshalJSON(b []byte) error {
+	return unmarshalQuantityJSON(b, percent.UnmarshalText)
+}
+
+// MarshalText marshals Percent type to text.
+func (percent Percent) MarshalText() ([]byte, error) {
+	return []byte(percent.String()), nil
+}
+
+// UnmarshalText unmarshals Percent type from text.
+func (percent *Percent) UnmarshalText(text []byte) error {
+	value, err := ParsePercent(string(text))
+	if err != nil {
+		return err
+	}
+
+	*percent = value
+
+	return nil
+}
+
+// ParseFrequency parses frequency string.
+func ParseFrequency(value string) (Frequency, error) {
+	if frequency, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64); err == nil {
+		return Frequency(frequency), nil
+	}
+
+	number, suffix, err := parseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+
+	multiplier, ok := findUnit(frequencyUnits, suffix)
+	if !ok && suffix != "" {
+		return 0, aoserrors.Errorf("invalid frequency suffix: %s", suffix)
+	}
+
+	if !ok {
+		multiplier = 1
+	}
+
+	frequency, err := scaleQuantity(number, multiplier)
+	if err != nil {
+		return 0, err
+	}
+
+	return Frequency(frequency), nil
+}
+
+// String returns frequency string with the largest exact unit.
+func (frequency Frequency) String() string {
+	return formatQuantity(uint64(frequency), frequencyUnits, "Hz")
+}
+
+// MarshalJSON marshals JSON Frequency type.
+func (frequency Frequency) MarshalJSON() ([]byte, error) {
+	return marshalJSON(uint64(frequency))
+}
+
+// UnmarshalJSON unmarshals JSON Frequency type.
+func (frequency *Frequency) UnmarshalJSON(b []byte) error {
+	return unmarshalQuantityJSON(b, frequency.UnmarshalText)
+}
+
+// MarshalText marshals Frequency type to text.
+func (frequency Frequency) MarshalText() ([]byte, error) {
+	return []byte(frequency.String()), nil
+}
+
+// UnmarshalText unmarshals Frequency type from text.
+func (frequency *Frequency) UnmarshalText(text []byte) error {
+	value, err := ParseFrequency(string(text))
+	if err != nil {
+		return err
+	}
+
+	*frequency = value
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func parseQuantity(value string) (number float64, suffix string, err error) {
+	match := quantityRegexp.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, "", aoserrors.Errorf("invalid quantity value: %s", value)
+	}
+
+	if number, err = strconv.ParseFloat(match[1], 64); err != nil {
+		return 0, "", aoserrors.Wrap(err)
+	}
+
+	return number, match[2], nil
+}
+
+func scaleQuantity(number float64, multiplier uint64) (uint64, error) {
+	value := math.Round(number * float64(multiplier))
+
+	// float64(math.MaxUint64) is rounded up to 2^64, so equality also means overflow
+	if math.IsNaN(value) || math.IsInf(value, 0) || value < 0 || value >= math.MaxUint64 {
+		return 0, aoserrors.Errorf("quantity out of range: %v", number)
+	}
+
+	return uint64(value), nil
+}
+
+func findUnit(units []quantityUnit, suffix string) (multiplier uint64, ok bool) {
+	for _, unit := range units {
+		if unit.suffix == suffix {
+			return unit.multiplier, true
+		}
+	}
+
+	return 0, false
+}
+
+func formatQuantity(value uint64, units []quantityUnit, defaultSuffix string) string {
+	if value != 0 {
+		for _, unit := range units {
+			if value%unit.multiplier == 0 {
+				return strconv.FormatUint(value/unit.multiplier, 10) + unit.suffix
+			}
+		}
+	}
+
+	return strconv.FormatUint(value, 10) + defaultSuffix
+}
+
+func marshalJSON(value interface{}) ([]byte, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return b, nil
+}
+
+func unmarshalQuantityJSON(b []byte, unmarshalText func(text []byte) error) error {
+	var v interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&v); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	switch value := v.(type) {
+	case json.Number:
+		return unmarshalText([]byte(value.String()))
+
+	case string:
+		return unmarshalText([]byte(value))
+
+	default:
+		return aoserrors.Errorf("invalid quantity value: %v", value)
+	}
+}
@@ -0,0 +1,351 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aostypes
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const maxPercent = 100
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Validatable interface for types which can validate own content.
+type Validatable interface {
+	Validate() error
+}
+
+// FieldError validation error of specific field.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+// ValidationErrors aggregated validation errors.
+type ValidationErrors []FieldError
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Error returns field error message.
+func (fieldErr FieldError) Error() string {
+	return fmt.Sprintf("invalid field %s: %s", fieldErr.Field, fieldErr.Reason)
+}
+
+// Error returns aggregated errors message.
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns list of field errors.
+func (errs ValidationErrors) Unwrap() []error {
+	unwrapped := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		unwrapped = append(unwrapped, err)
+	}
+
+	return unwrapped
+}
+
+// Validate validates service info.
+func (service ServiceInfo) Validate() error {
+	var errs ValidationErrors
+
+	service.validate("", &errs)
+
+	return errs.toError()
+}
+
+// Validate validates layer info.
+func (layer LayerInfo) Validate() error {
+	var errs ValidationErrors
+
+	layer.validate("", &errs)
+
+	return errs.toError()
+}
+
+// Validate validates instance info.
+func (instance InstanceInfo) Validate() error {
+	var errs ValidationErrors
+
+	instance.validate("", &errs)
+
+	return errs.toError()
+}
+
+// Validate validates network parameters.
+func (params NetworkParameters) Validate() error {
+	var errs ValidationErrors
+
+	params.validate("", &errs)
+
+	return errs.toError()
+}
+
+// Validate validates firewall rule.
+func (rule FirewallRule) Validate() error {
+	var errs ValidationErrors
+
+	rule.validate("", &errs)
+
+	return errs.toError()
+}
+
+// Validate validates alert rules.
+func (rules AlertRules) Validate() error {
+	var errs ValidationErrors
+
+	rules.validate("", &errs)
+
+	return errs.toError()
+}
+
+// Validate validates service config.
+func (config ServiceConfig) Validate() error {
+	var errs ValidationErrors
+
+	switch config.BalancingPolicy {
+	case "", BalancingEnabled, BalancingDisabled:
+
+	default:
+		errs.add("balancingPolicy", fmt.Sprintf("unsupported value %q", config.BalancingPolicy))
+	}
+
+	if config.AlertRules != nil {
+		config.AlertRules.validate("alertRules", &errs)
+	}
+
+	return errs.toError()
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (errs *ValidationErrors) add(field, reason string) {
+	*errs = append(*errs, FieldError{Field: field, Reason: reason})
+}
+
+func (errs *ValidationErrors) require(field, value string) {
+	if value == "" {
+		errs.add(field, "is required")
+	}
+}
+
+func (errs ValidationErrors) toError() error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return aoserrors.Wrap(errs)
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
+
+func validateSha256(path string, sha []byte, errs *ValidationErrors) {
+	if len(sha) != sha256.Size {
+		errs.add(joinPath(path, "sha256"), fmt.Sprintf("wrong length %d", len(sha)))
+	}
+}
+
+func (service ServiceInfo) validate(path string, errs *ValidationErrors) {
+	errs.require(joinPath(path, "serviceId"), service.ServiceID)
+	errs.require(joinPath(path, "version"), service.Version)
+	errs.require(joinPath(path, "url"), service.URL)
+	validateSha256(path, service.Sha256, errs)
+}
+
+func (layer LayerInfo) validate(path string, errs *ValidationErrors) {
+	errs.require(joinPath(path, "layerId"), layer.LayerID)
+	errs.require(joinPath(path, "digest"), layer.Digest)
+	errs.require(joinPath(path, "version"), layer.Version)
+	errs.require(joinPath(path, "url"), layer.URL)
+	validateSha256(path, layer.Sha256, errs)
+}
+
+func (instance InstanceInfo) validate(path string, errs *ValidationErrors) {
+	errs.require(joinPath(path, "serviceId"), instance.ServiceID)
+	errs.require(joinPath(path, "subjectId"), instance.SubjectID)
+	instance.NetworkParameters.validate(path, errs)
+}
+
+func (params NetworkParameters) validate(path string, errs *ValidationErrors) {
+	if params.IP != "" && net.ParseIP(params.IP) == nil {
+		errs.add(joinPath(path, "ip"), fmt.Sprintf("invalid IP %q", params.IP))
+	}
+
+	if params.Subnet != "" {
+		if _, _, err := net.ParseCIDR(params.Subnet); err != nil {
+			errs.add(joinPath(path, "subnet"), fmt.Sprintf("invalid subnet %q", params.Subnet))
+		}
+	}
+
+	for i, server := range params.DNSServers {
+		if net.ParseIP(server) == nil {
+			errs.add(joinPath(path, fmt.Sprintf("dnsServers[%d]", i)), fmt.Sprintf("invalid IP %q", server))
+		}
+	}
+
+	for i, rule := range params.FirewallRules {
+		rule.validate(joinPath(path, fmt.Sprintf("firewallRules[%d]", i)), errs)
+	}
+}
+
+func (rule FirewallRule) validate(path string, errs *ValidationErrors) {
+	validateAddress(joinPath(path, "dstIp"), rule.DstIP, errs)
+	validateAddress(joinPath(path, "srcIp"), rule.SrcIP, errs)
+
+	switch strings.ToLower(rule.Proto) {
+	case "", "tcp", "udp":
+
+	default:
+		errs.add(joinPath(path, "proto"), fmt.Sprintf("unsupported value %q", rule.Proto))
+	}
+
+	if rule.DstPort != "" && !isValidPortRange(rule.DstPort) {
+		errs.add(joinPath(path, "dstPort"), fmt.Sprintf("invalid port %q", rule.DstPort))
+	}
+
+	switch rule.Direction {
+	case "", FirewallDirectionIn, FirewallDirectionOut:
+
+	default:
+		errs.add(joinPath(path, "direction"), fmt.Sprintf("unsupported value %q", rule.Direction))
+	}
+}
+
+func (rules AlertRules) validate(path string, errs *ValidationErrors) {
+	if rules.RAM != nil {
+		rules.RAM.validate(joinPath(path, "ram"), errs)
+	}
+
+	if rules.CPU != nil {
+		rules.CPU.validate(joinPath(path, "cpu"), errs)
+	}
+
+	for i, partition := range rules.Partitions {
+		partitionPath := joinPath(path, fmt.Sprintf("partitions[%d]", i))
+
+		errs.require(joinPath(partitionPath, "name"), partition.Name)
+		partition.AlertRulePercents.validate(partitionPath, errs)
+	}
+
+	if rules.Download != nil {
+		rules.Download.validate(joinPath(path, "download"), errs)
+	}
+
+	if rules.Upload != nil {
+		rules.Upload.validate(joinPath(path, "upload"), errs)
+	}
+
+	if rules.FileDescriptors != nil {
+		rules.FileDescriptors.validate(joinPath(path, "fileDescriptors"), errs)
+	}
+
+	if rules.PIDs != nil {
+		rules.PIDs.validate(joinPath(path, "pids"), errs)
+	}
+
+	if rules.Entropy != nil {
+		rules.Entropy.validate(joinPath(path, "entropy"), errs)
+	}
+}
+
+func (rule AlertRulePercents) validate(path string, errs *ValidationErrors) {
+	if rule.MinThreshold < 0 || rule.MinThreshold > maxPercent {
+		errs.add(joinPath(path, "minThreshold"), fmt.Sprintf("out of range %s", rule.MinThreshold))
+	}
+
+	if rule.MaxThreshold < 0 || rule.MaxThreshold > maxPercent {
+		errs.add(joinPath(path, "maxThreshold"), fmt.Sprintf("out of range %s", rule.MaxThreshold))
+	}
+
+	if rule.MinThreshold > rule.MaxThreshold {
+		errs.add(joinPath(path, "minThreshold"), "is greater than maxThreshold")
+	}
+}
+
+func (rule AlertRulePoints) validate(path string, errs *ValidationErrors) {
+	if rule.MinThreshold > rule.MaxThreshold {
+		errs.add(joinPath(path, "minThreshold"), "is greater than maxThreshold")
+	}
+}
+
+func (rule AlertRuleCount) validate(path string, errs *ValidationErrors) {
+	if rule.MinThreshold > rule.MaxThreshold {
+		errs.add(joinPath(path, "minThreshold"), "is greater than maxThreshold")
+	}
+}
+
+func validateAddress(path, address string, errs *ValidationErrors) {
+	if address == "" {
+		return
+	}
+
+	if net.ParseIP(address) != nil {
+		return
+	}
+
+	if _, _, err := net.ParseCIDR(address); err == nil {
+		return
+	}
+
+	errs.add(path, fmt.Sprintf("invalid address %q", address))
+}
+
+func isValidPortRange(value string) bool {
+	ports := strings.FieldsFunc(value, func(r rune) bool { return r == '-' || r == ':' })
+	if len(ports) == 0 || len(ports) > 2 {
+		return false
+	}
+
+	for _, port := range ports {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
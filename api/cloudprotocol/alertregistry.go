@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var (
+	alertTypesMutex sync.RWMutex
+	alertTypes      = map[string]reflect.Type{
+		AlertTagSystemError:      reflect.TypeOf(SystemAlert{}),
+		AlertTagAosCore:          reflect.TypeOf(CoreAlert{}),
+		AlertTagResourceValidate: reflect.TypeOf(ResourceValidateAlert{}),
+		AlertTagDeviceAllocate:   reflect.TypeOf(DeviceAllocateAlert{}),
+		AlertTagSystemQuota:      reflect.TypeOf(SystemQuotaAlert{}),
+		AlertTagInstanceQuota:    reflect.TypeOf(InstanceQuotaAlert{}),
+		AlertTagDownloadProgress: reflect.TypeOf(DownloadAlert{}),
+		AlertTagServiceInstance:  reflect.TypeOf(ServiceInstanceAlert{}),
+		AlertTagKernel:           reflect.TypeOf(KernelAlert{}),
+		AlertTagCrash:            reflect.TypeOf(CrashAlert{}),
+		AlertTagSecurity:         reflect.TypeOf(SecurityAlert{}),
+		AlertTagMonitoring:       reflect.TypeOf(MonitoringAlert{}),
+	}
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// RegisterAlertType registers payload type for custom alert tag. Payload should be alert structure value,
+// e.g. RegisterAlertType("storageAlert", RuleAlert{}).
+func RegisterAlertType(tag string, payload interface{}) error {
+	payloadType := reflect.TypeOf(payload)
+
+	if payloadType == nil || payloadType.Kind() != reflect.Struct {
+		return aoserrors.Errorf("wrong alert payload type: %T", payload)
+	}
+
+	alertTypesMutex.Lock()
+	defer alertTypesMutex.Unlock()
+
+	alertTypes[tag] = payloadType
+
+	return nil
+}
+
+// UnregisterAlertType removes payload type of alert tag.
+func UnregisterAlertType(tag string) {
+	alertTypesMutex.Lock()
+	defer alertTypesMutex.Unlock()
+
+	delete(alertTypes, tag)
+}
+
+// DecodeAlertItem decodes alert item to the payload type registered for its tag. Alerts with unknown tags are
+// decoded to map[string]interface{}.
+func DecodeAlertItem(data []byte) (alert interface{}, err error) {
+	var alertItem AlertItem
+
+	if err = json.Unmarshal(data, &alertItem); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	alertTypesMutex.RLock()
+	payloadType, ok := alertTypes[alertItem.Tag]
+	alertTypesMutex.RUnlock()
+
+	if !ok {
+		var payload map[string]interface{}
+
+		if err = json.Unmarshal(data, &payload); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return payload, nil
+	}
+
+	payload := reflect.New(payloadType)
+
+	if err = json.Unmarshal(data, payload.Interface()); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return payload.Elem().Interface(), nil
+}
+
+// GetAlertItem returns common alert item of alert structure. Empty item is returned if alert doesn't embed
+// AlertItem.
+func GetAlertItem(alert interface{}) AlertItem {
+	value := reflect.ValueOf(alert)
+	if value.Kind() != reflect.Struct {
+		return AlertItem{}
+	}
+
+	field, ok := value.Type().FieldByName("AlertItem")
+	if !ok || field.Type != reflect.TypeOf(AlertItem{}) {
+		return AlertItem{}
+	}
+
+	alertItem, _ := value.FieldByIndex(field.Index).Interface().(AlertItem)
+
+	return alertItem
+}
+
+// GetAlertMessage returns alert message. Alerts without message are formatted with default format.
+func GetAlertMessage(alert interface{}) string {
+	if field := getAlertMessageField(reflect.ValueOf(alert)); field.IsValid() {
+		return field.String()
+	}
+
+	return fmt.Sprintf("%v", alert)
+}
+
+// SetAlertMessage returns copy of alert with replaced message. Alerts without message are returned as is.
+func SetAlertMessage(alert interface{}, message string) interface{} {
+	value := reflect.ValueOf(alert)
+	if !getAlertMessageField(value).IsValid() {
+		return alert
+	}
+
+	alertCopy := reflect.New(value.Type()).Elem()
+
+	alertCopy.Set(value)
+	getAlertMessageField(alertCopy).SetString(message)
+
+	return alertCopy.Interface()
+}
+
+// UnmarshalJSON decodes alerts message with typed alert items.
+func (alerts *Alerts) UnmarshalJSON(data []byte) (err error) {
+	var rawAlerts struct {
+		MessageType string            `json:"messageType"`
+		Sequence    uint64            `json:"sequence,omitempty"`
+		Items       []json.RawMessage `json:"items"`
+	}
+
+	if err = json.Unmarshal(data, &rawAlerts); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	alerts.MessageType = rawAlerts.MessageType
+	alerts.Sequence = rawAlerts.Sequence
+	alerts.Items = nil
+
+	if rawAlerts.Items == nil {
+		return nil
+	}
+
+	alerts.Items = make([]interface{}, 0, len(rawAlerts.Items))
+
+	for _, rawItem := range rawAlerts.Items {
+		item, err := DecodeAlertItem(rawItem)
+		if err != nil {
+			return err
+		}
+
+		alerts.Items = append(alerts.Items, item)
+	}
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func getAlertMessageField(value reflect.Value) reflect.Value {
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	field, ok := value.Type().FieldByName("Message")
+	if !ok || field.Type.Kind() != reflect.String || !field.IsExported() {
+		return reflect.Value{}
+	}
+
+	return value.FieldByIndex(field.Index)
+}
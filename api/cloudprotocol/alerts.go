@@ -27,8 +27,12 @@ import (
  * Consts
  **********************************************************************************************************************/
 
-// AlertsMessageType alerts message type.
-const AlertsMessageType = "alerts"
+// Alerts message types.
+const (
+	AlertsMessageType          = "alerts"
+	AlertsAckMessageType       = "alertsAck"
+	AlertsVerbosityMessageType = "alertsVerbosity"
+)
 
 // Alert tags.
 const (
@@ -40,6 +44,39 @@ const (
 	AlertTagInstanceQuota    = "instanceQuotaAlert"
 	AlertTagDownloadProgress = "downloadProgressAlert"
 	AlertTagServiceInstance  = "serviceInstanceAlert"
+	AlertTagKernel           = "kernelAlert"
+	AlertTagCrash            = "crashAlert"
+	AlertTagSecurity         = "securityAlert"
+	AlertTagMonitoring       = "monitoringAlert"
+)
+
+// Alert severities.
+const (
+	AlertSeverityCritical = "critical"
+	AlertSeverityError    = "error"
+	AlertSeverityWarning  = "warning"
+	AlertSeverityInfo     = "info"
+)
+
+// Kernel alert categories.
+const (
+	KernelAlertCategoryOops     = "oops"
+	KernelAlertCategoryOOM      = "oom"
+	KernelAlertCategoryIOError  = "ioError"
+	KernelAlertCategoryWatchdog = "watchdog"
+)
+
+// Security alert categories.
+const (
+	SecurityAlertCategoryAVCDenial       = "avcDenial"
+	SecurityAlertCategoryLoginFailure    = "loginFailure"
+	SecurityAlertCategoryPolicyViolation = "policyViolation"
+)
+
+// Monitoring alert statuses.
+const (
+	MonitoringStatusDegraded  = "degraded"
+	MonitoringStatusRecovered = "recovered"
 )
 
 // Download target types.
@@ -55,8 +92,9 @@ const (
 
 // AlertItem common alert data.
 type AlertItem struct {
-	Timestamp time.Time `json:"timestamp"`
-	Tag       string    `json:"tag"`
+	Timestamp time.Time         `json:"timestamp"`
+	Tag       string            `json:"tag"`
+	Fields    map[string]string `json:"fields,omitempty"`
 }
 
 // SystemAlert system alert structure.
@@ -121,16 +159,97 @@ type ResourceValidateAlert struct {
 	Errors []ErrorInfo `json:"errors"`
 }
 
+// UnitState systemd unit state of service instance.
+type UnitState struct {
+	ActiveState    string `json:"activeState"`
+	SubState       string `json:"subState"`
+	ExecMainStatus int    `json:"execMainStatus"`
+}
+
 // ServiceInstanceAlert system alert structure.
 type ServiceInstanceAlert struct {
 	AlertItem
 	aostypes.InstanceIdent
-	ServiceVersion string `json:"version"`
-	Message        string `json:"message"`
+	ServiceVersion string     `json:"version"`
+	Message        string     `json:"message"`
+	UnitState      *UnitState `json:"unitState,omitempty"`
+}
+
+// KernelAlert kernel alert structure.
+type KernelAlert struct {
+	AlertItem
+	NodeID   string `json:"nodeId"`
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+// CrashAlert process crash (core dump) alert structure.
+type CrashAlert struct {
+	AlertItem
+	*aostypes.InstanceIdent
+	NodeID     string `json:"nodeId"`
+	Executable string `json:"executable"`
+	Signal     string `json:"signal"`
+	PID        string `json:"pid,omitempty"`
+	Unit       string `json:"unit,omitempty"`
+	Backtrace  string `json:"backtrace,omitempty"`
+	Message    string `json:"message"`
+}
+
+// SecurityAlert security (audit) alert structure.
+type SecurityAlert struct {
+	AlertItem
+	*aostypes.InstanceIdent
+	NodeID    string `json:"nodeId"`
+	Category  string `json:"category"`
+	AuditType string `json:"auditType,omitempty"`
+	PID       string `json:"pid,omitempty"`
+	UID       string `json:"uid,omitempty"`
+	Message   string `json:"message"`
+}
+
+// RuleAlert alert produced by message classification rule.
+type RuleAlert struct {
+	AlertItem
+	NodeID     string            `json:"nodeId"`
+	Rule       string            `json:"rule"`
+	Severity   string            `json:"severity"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Message    string            `json:"message"`
+}
+
+// MonitoringAlert alert of failing monitoring data collector.
+type MonitoringAlert struct {
+	AlertItem
+	*aostypes.InstanceIdent
+	NodeID    string     `json:"nodeId"`
+	Parameter string     `json:"parameter"`
+	Status    string     `json:"status"`
+	ErrorInfo *ErrorInfo `json:"errorInfo,omitempty"`
 }
 
 // Alerts alerts message structure.
 type Alerts struct {
-	MessageType string        `json:"messageType"`
-	Items       []interface{} `json:"items"`
+	MessageType string `json:"messageType"`
+	// Sequence alerts message sequence number, used by cloud to acknowledge alerts receipt.
+	Sequence uint64        `json:"sequence,omitempty"`
+	Items    []interface{} `json:"items"`
+}
+
+// AlertsAck cloud acknowledgement of received alerts messages.
+type AlertsAck struct {
+	MessageType string `json:"messageType"`
+	// Sequence all alerts messages up to and including this sequence number are received.
+	Sequence uint64 `json:"sequence"`
+}
+
+// AlertsVerbosity cloud request to adjust on-device alerts verbosity. Empty request restores default verbosity.
+type AlertsVerbosity struct {
+	MessageType string `json:"messageType"`
+	// MutedTags alerts with these tags are not sent.
+	MutedTags []string `json:"mutedTags,omitempty"`
+	// MinSeverity alerts with lower severity are not sent.
+	MinSeverity string `json:"minSeverity,omitempty"`
+	// Till time when default verbosity is restored, not set means till next verbosity request.
+	Till *time.Time `json:"till,omitempty"`
 }
@@ -31,8 +31,14 @@ const (
 	InstallUnitCertsConfirmationMessageType = "installUnitCertificatesConfirmation"
 )
 
-// UnitSecretVersion specifies supported version of UnitSecret message.
-const UnitSecretVersion = "2.0.0"
+// Supported versions of UnitSecret message.
+const (
+	UnitSecretVersion   = "2.0.0"
+	UnitSecretVersionV3 = "3.0.0"
+)
+
+// UnitSecretNamePassword name of unit secret used as certificate password.
+const UnitSecretNamePassword = "password"
 
 /***********************************************************************************************************************
  * Types
@@ -62,10 +68,20 @@ type RenewCertData struct {
 	ValidTill time.Time `json:"validTill"`
 }
 
+// UnitSecret named node secret with rotation metadata.
+type UnitSecret struct {
+	Name       string     `json:"name"`
+	Version    uint64     `json:"version"`
+	Secret     string     `json:"secret"`
+	ValidFrom  *time.Time `json:"validFrom,omitempty"`
+	Supersedes uint64     `json:"supersedes,omitempty"`
+}
+
 // UnitSecrets keeps secrets for nodes.
 type UnitSecrets struct {
-	Version string            `json:"version"`
-	Nodes   map[string]string `json:"nodes"`
+	Version string                  `json:"version"`
+	Nodes   map[string]string       `json:"nodes"`
+	Secrets map[string][]UnitSecret `json:"secrets,omitempty"`
 }
 
 // IssueCertData issue certificate data.
@@ -26,6 +26,12 @@ import "encoding/json"
 // ProtocolVersion specifies supported protocol version.
 const ProtocolVersion = 6
 
+// Message signature algorithms.
+const (
+	SignatureAlgRS256 = "RS256"
+	SignatureAlgES256 = "ES256"
+)
+
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
@@ -42,10 +48,29 @@ type Message struct {
 	Data   interface{}   `json:"data"`
 }
 
+// SignedMessage message envelope with detached signature over header and data.
+type SignedMessage struct {
+	Header    MessageHeader    `json:"header"`
+	Data      json.RawMessage  `json:"data"`
+	Signature MessageSignature `json:"signature"`
+}
+
+// MessageSignature message signature.
+type MessageSignature struct {
+	Alg   string `json:"alg"`
+	Value []byte `json:"value"`
+	// CertificateChain PEM encoded signer certificate chain starting from the leaf certificate.
+	CertificateChain string `json:"certificateChain"`
+}
+
 // MessageHeader message header.
 type MessageHeader struct {
 	Version  uint64 `json:"version"`
 	SystemID string `json:"systemId"`
+	// Encoding message encoding (json or cbor). Empty means json.
+	Encoding string `json:"encoding,omitempty"`
+	// ContentEncoding compression of message data. Empty means data is not compressed.
+	ContentEncoding string `json:"contentEncoding,omitempty"`
 }
 
 // ErrorInfo error information.
@@ -18,11 +18,23 @@
 package cloudprotocol_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/klauspost/compress/zstd"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -97,6 +109,214 @@ func TestNewInstanceFilter(t *testing.T) {
 	}
 }
 
+func TestInstanceFilterMatches(t *testing.T) {
+	var (
+		service1  = "service1"
+		service2  = "service2"
+		subject1  = "subject1"
+		empty     = ""
+		instance0 = uint64(0)
+		instance1 = uint64(1)
+		ident     = aostypes.InstanceIdent{ServiceID: service1, SubjectID: subject1, Instance: 1}
+	)
+
+	testData := []struct {
+		filter  cloudprotocol.InstanceFilter
+		matches bool
+	}{
+		{filter: cloudprotocol.InstanceFilter{}, matches: true},
+		{filter: cloudprotocol.InstanceFilter{ServiceID: &empty, SubjectID: &empty}, matches: true},
+		{filter: cloudprotocol.InstanceFilter{ServiceID: &service1, Instance: &instance1}, matches: true},
+		{filter: cloudprotocol.InstanceFilter{ServiceID: &service2}, matches: false},
+		{filter: cloudprotocol.InstanceFilter{SubjectID: &subject1, Instance: &instance0}, matches: false},
+	}
+
+	for i, testItem := range testData {
+		if testItem.filter.Matches(ident) != testItem.matches {
+			t.Errorf("Wrong match result for filter %d", i)
+		}
+	}
+
+	if !(cloudprotocol.InstanceFilter{ServiceID: &empty}).IsEmpty() {
+		t.Error("Filter with empty service ID should be empty")
+	}
+
+	intersection, ok := cloudprotocol.InstanceFilter{ServiceID: &service1}.Intersect(
+		cloudprotocol.InstanceFilter{ServiceID: &service1, SubjectID: &subject1})
+	if !ok || !reflect.DeepEqual(intersection, cloudprotocol.InstanceFilter{ServiceID: &service1, SubjectID: &subject1}) {
+		t.Errorf("Wrong intersection: %v", intersection)
+	}
+
+	if _, ok = (cloudprotocol.InstanceFilter{Instance: &instance0}).Intersect(
+		cloudprotocol.InstanceFilter{Instance: &instance1}); ok {
+		t.Error("Filters should not intersect")
+	}
+}
+
+func TestErrorInfo(t *testing.T) {
+	if cloudprotocol.NewErrorInfo(nil) != nil {
+		t.Error("Error info should be nil for nil error")
+	}
+
+	testData := []struct {
+		err          error
+		expectedCode int
+		category     error
+	}{
+		{err: aoserrors.New("generic error"), expectedCode: cloudprotocol.ErrorCodeFailed, category: aoserrors.ErrFailed},
+		{
+			err:          aoserrors.Errorf("service not installed: %w", aoserrors.ErrNotFound),
+			expectedCode: cloudprotocol.ErrorCodeNotFound, category: aoserrors.ErrNotFound,
+		},
+		{
+			err:          aoserrors.Wrap(context.DeadlineExceeded),
+			expectedCode: cloudprotocol.ErrorCodeTimeout, category: aoserrors.ErrTimeout,
+		},
+		{
+			err:          aoserrors.Wrap(os.ErrNotExist),
+			expectedCode: cloudprotocol.ErrorCodeNotFound, category: aoserrors.ErrNotFound,
+		},
+	}
+
+	for _, testItem := range testData {
+		errorInfo := cloudprotocol.NewErrorInfo(testItem.err)
+
+		if errorInfo.AosCode != testItem.expectedCode || errorInfo.Message != testItem.err.Error() {
+			t.Errorf("Wrong error info: %v", errorInfo)
+		}
+
+		err := errorInfo.Err()
+
+		if !errors.Is(err, testItem.category) {
+			t.Errorf("Error %v should match category %v", err, testItem.category)
+		}
+
+		if !strings.HasPrefix(err.Error(), testItem.err.Error()) {
+			t.Errorf("Wrong error message: %v", err)
+		}
+	}
+
+	if err := (&cloudprotocol.ErrorInfo{AosCode: 1000}).Err(); !errors.Is(err, aoserrors.ErrFailed) {
+		t.Errorf("Unknown code should match failed category: %v", err)
+	}
+}
+
+func TestMessageCompression(t *testing.T) {
+	pushLog := cloudprotocol.PushLog{
+		MessageType: cloudprotocol.PushLogMessageType,
+		LogID:       "log1",
+		Content:     []byte(strings.Repeat("log line\n", 1000)),
+	}
+
+	message := cloudprotocol.Message{Header: cloudprotocol.MessageHeader{Version: 1}, Data: pushLog}
+
+	small, err := cloudprotocol.CompressMessage(message, 1024*1024)
+	if err != nil {
+		t.Fatalf("Can't compress message: %v", err)
+	}
+
+	if small.Header.ContentEncoding != "" {
+		t.Error("Message below threshold should not be compressed")
+	}
+
+	for _, contentEncoding := range []string{cloudprotocol.ContentEncodingGzip, cloudprotocol.ContentEncodingZstd} {
+		compressed, err := cloudprotocol.CompressMessageWithEncoding(
+			message, cloudprotocol.DefaultCompressionThreshold, contentEncoding)
+		if err != nil {
+			t.Fatalf("Can't compress message: %v", err)
+		}
+
+		if compressed.Header.ContentEncoding != contentEncoding {
+			t.Errorf("Wrong content encoding: %s", compressed.Header.ContentEncoding)
+		}
+
+		rawCompressed, err := json.Marshal(compressed)
+		if err != nil {
+			t.Fatalf("Can't marshal message: %v", err)
+		}
+
+		rawOriginal, err := json.Marshal(message)
+		if err != nil {
+			t.Fatalf("Can't marshal message: %v", err)
+		}
+
+		if len(rawCompressed) >= len(rawOriginal) {
+			t.Errorf("Compressed message is not smaller: %d >= %d", len(rawCompressed), len(rawOriginal))
+		}
+
+		var received cloudprotocol.ReceivedMessage
+
+		if err = json.Unmarshal(rawCompressed, &received); err != nil {
+			t.Fatalf("Can't unmarshal message: %v", err)
+		}
+
+		if received, err = cloudprotocol.DecompressMessage(received); err != nil {
+			t.Fatalf("Can't decompress message: %v", err)
+		}
+
+		var receivedLog cloudprotocol.PushLog
+
+		if err = json.Unmarshal(received.Data, &receivedLog); err != nil {
+			t.Fatalf("Can't unmarshal data: %v", err)
+		}
+
+		if !reflect.DeepEqual(receivedLog, pushLog) || received.Header.ContentEncoding != "" {
+			t.Errorf("Wrong decompressed %s message", contentEncoding)
+		}
+	}
+
+	if _, err = cloudprotocol.CompressMessageWithEncoding(
+		message, cloudprotocol.DefaultCompressionThreshold, "unknown"); !errors.Is(
+		err, cloudprotocol.ErrContentEncodingNotSupported) {
+		t.Errorf("Not supported error expected for unknown content encoding: %v", err)
+	}
+
+	received := cloudprotocol.ReceivedMessage{
+		Header: cloudprotocol.MessageHeader{ContentEncoding: "unknown"}, Data: json.RawMessage(`"AA=="`),
+	}
+
+	if _, err = cloudprotocol.DecompressMessage(received); !errors.Is(err, cloudprotocol.ErrContentEncodingNotSupported) {
+		t.Errorf("Not supported error expected for unknown content encoding: %v", err)
+	}
+
+	// Data exceeding max decompressed size should be rejected for any encoding.
+
+	var bomb bytes.Buffer
+
+	writer := gzip.NewWriter(&bomb)
+
+	if _, err = writer.Write(make([]byte, cloudprotocol.MaxDecompressedSize+1)); err != nil {
+		t.Fatalf("Can't compress data: %v", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		t.Fatalf("Can't compress data: %v", err)
+	}
+
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatalf("Can't create zstd encoder: %v", err)
+	}
+	defer encoder.Close()
+
+	bombs := map[string][]byte{
+		cloudprotocol.ContentEncodingGzip: bomb.Bytes(),
+		cloudprotocol.ContentEncodingZstd: encoder.EncodeAll(make([]byte, cloudprotocol.MaxDecompressedSize+1), nil),
+	}
+
+	for contentEncoding, data := range bombs {
+		if received.Data, err = json.Marshal(data); err != nil {
+			t.Fatalf("Can't marshal data: %v", err)
+		}
+
+		received.Header.ContentEncoding = contentEncoding
+
+		if _, err = cloudprotocol.DecompressMessage(received); err == nil {
+			t.Errorf("Decompression of too big %s data should fail", contentEncoding)
+		}
+	}
+}
+
 func TestNodeInfoAttrs(t *testing.T) {
 	nodeInfo := cloudprotocol.NodeInfo{
 		Attrs: map[string]interface{}{
@@ -130,3 +350,748 @@ func TestNodeInfoAttrs(t *testing.T) {
 		t.Error("Incorrect runners")
 	}
 }
+
+func TestValidate(t *testing.T) {
+	validArtifactInfo := cloudprotocol.ArtifactInfo{DownloadInfo: cloudprotocol.DownloadInfo{
+		URLs: []string{"https://example.com/item"}, Sha256: make([]byte, 32),
+	}}
+	from := time.Now()
+	till := from.Add(-time.Hour)
+
+	testData := []struct {
+		message       cloudprotocol.Validator
+		expectedField string
+	}{
+		{
+			message: cloudprotocol.DesiredStatus{
+				MessageType: cloudprotocol.DesiredStatusMessageType,
+				Services: []cloudprotocol.ServiceInfo{
+					{ServiceID: "service1", Version: "1.0.0", ArtifactInfo: validArtifactInfo},
+				},
+				Instances:    []cloudprotocol.InstanceInfo{{ServiceID: "service1", SubjectID: "subject1"}},
+				SOTASchedule: cloudprotocol.ScheduleRule{Type: cloudprotocol.ForceUpdate},
+			},
+		},
+		{
+			message:       cloudprotocol.DesiredStatus{MessageType: cloudprotocol.RequestLogMessageType},
+			expectedField: "messageType",
+		},
+		{
+			message: cloudprotocol.DesiredStatus{
+				Services: []cloudprotocol.ServiceInfo{
+					{ServiceID: "service1", Version: "1.0.0", ArtifactInfo: validArtifactInfo},
+					{ServiceID: "service2", Version: "1.0.0", ArtifactInfo: cloudprotocol.ArtifactInfo{
+						DownloadInfo: cloudprotocol.DownloadInfo{URLs: []string{"https://example.com/item"}, Sha256: []byte{1, 2, 3}},
+					}},
+				},
+			},
+			expectedField: "services[1].sha256",
+		},
+		{
+			message: cloudprotocol.DesiredStatus{
+				Layers: []cloudprotocol.LayerInfo{{LayerID: "layer1", Version: "1.0.0", ArtifactInfo: validArtifactInfo}},
+			},
+			expectedField: "layers[0].digest",
+		},
+		{
+			message: cloudprotocol.DesiredStatus{
+				FOTASchedule: cloudprotocol.ScheduleRule{
+					Type:      cloudprotocol.TimetableUpdate,
+					Timetable: []cloudprotocol.TimetableEntry{{DayOfWeek: 8}},
+				},
+			},
+			expectedField: "fotaSchedule.timetable[0].dayOfWeek",
+		},
+		{
+			message: cloudprotocol.OverrideEnvVars{
+				Items: []cloudprotocol.EnvVarsInstanceInfo{
+					{Variables: []cloudprotocol.EnvVarInfo{{Name: "VAR1", Value: "1"}, {Name: "VAR=2"}}},
+				},
+			},
+			expectedField: "items[0].variables[1].name",
+		},
+		{
+			message: cloudprotocol.RequestLog{LogID: "log1", LogType: cloudprotocol.SystemLog},
+		},
+		{
+			message:       cloudprotocol.RequestLog{LogID: "log1", LogType: "unknown"},
+			expectedField: "logType",
+		},
+		{
+			message: cloudprotocol.RequestLog{
+				LogID: "log1", LogType: cloudprotocol.ServiceLog,
+				Filter: cloudprotocol.LogFilter{From: &from, Till: &till},
+			},
+			expectedField: "filter.till",
+		},
+		{
+			message: cloudprotocol.RenewCertsNotification{
+				Certificates: []cloudprotocol.RenewCertData{{Type: "online"}},
+			},
+			expectedField: "certificates[0].serial",
+		},
+		{
+			message: cloudprotocol.RenewCertsNotification{
+				UnitSecrets: cloudprotocol.UnitSecrets{
+					Version: cloudprotocol.UnitSecretVersionV3,
+					Secrets: map[string][]cloudprotocol.UnitSecret{"node1": {{Name: "password", Secret: "pwd"}}},
+				},
+			},
+			expectedField: "unitSecrets.secrets[node1][0].version",
+		},
+		{
+			message: cloudprotocol.RebootRequest{
+				RequestID: "reboot1", Window: &cloudprotocol.MaintenanceWindow{Start: &till, End: &from},
+			},
+		},
+		{
+			message: cloudprotocol.RebootRequest{
+				RequestID: "reboot1", Window: &cloudprotocol.MaintenanceWindow{Start: &from, End: &till},
+			},
+			expectedField: "window.end",
+		},
+		{
+			message:       cloudprotocol.MaintenanceMode{Enabled: true},
+			expectedField: "requestId",
+		},
+		{
+			message: cloudprotocol.FileTransferRequest{
+				TransferID: "transfer1", Direction: cloudprotocol.FileTransferUpload, ChunkSize: 1024,
+			},
+		},
+		{
+			message: cloudprotocol.FileTransferRequest{
+				TransferID: "transfer1", Direction: cloudprotocol.FileTransferDownload, Size: 10, Offset: 20,
+				ChunkSize: 1024,
+			},
+			expectedField: "offset",
+		},
+		{
+			message: cloudprotocol.AlertsVerbosity{
+				MutedTags: []string{cloudprotocol.AlertTagKernel}, MinSeverity: cloudprotocol.AlertSeverityError,
+				Till: &from,
+			},
+		},
+		{
+			message:       cloudprotocol.AlertsVerbosity{MinSeverity: "debug"},
+			expectedField: "minSeverity",
+		},
+		{
+			message:       cloudprotocol.AlertsVerbosity{MutedTags: []string{""}},
+			expectedField: "mutedTags[0]",
+		},
+		{
+			message:       cloudprotocol.AlertsAck{MessageType: cloudprotocol.AlertsMessageType, Sequence: 1},
+			expectedField: "messageType",
+		},
+	}
+
+	for _, testItem := range testData {
+		err := testItem.message.Validate()
+
+		if testItem.expectedField == "" {
+			if err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), "invalid field "+testItem.expectedField+":") {
+			t.Errorf("Expected error for field %s, got: %v", testItem.expectedField, err)
+		}
+	}
+}
+
+func TestUnitSecrets(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+
+	secrets := cloudprotocol.UnitSecrets{
+		Version: cloudprotocol.UnitSecretVersionV3,
+		Secrets: map[string][]cloudprotocol.UnitSecret{
+			"node1": {
+				{Name: cloudprotocol.UnitSecretNamePassword, Version: 1, Secret: "pwd1"},
+				{Name: cloudprotocol.UnitSecretNamePassword, Version: 2, Secret: "pwd2", Supersedes: 1},
+				{Name: cloudprotocol.UnitSecretNamePassword, Version: 3, Secret: "pwd3", ValidFrom: &future, Supersedes: 2},
+				{Name: "other", Version: 4, Secret: "other"},
+			},
+			"node2": {
+				{Name: cloudprotocol.UnitSecretNamePassword, Version: 1, Secret: "pwd1"},
+				{Name: cloudprotocol.UnitSecretNamePassword, Version: 2, Secret: "pwd2", ValidFrom: &future, Supersedes: 1},
+			},
+		},
+	}
+
+	testData := []struct {
+		nodeID           string
+		now              time.Time
+		expectedPassword string
+		expectError      bool
+	}{
+		{nodeID: "node1", now: now, expectedPassword: "pwd2"},
+		{nodeID: "node1", now: future, expectedPassword: "pwd3"},
+		{nodeID: "node2", now: now, expectedPassword: "pwd1"},
+		{nodeID: "node2", now: future.Add(time.Second), expectedPassword: "pwd2"},
+		{nodeID: "node3", now: now, expectError: true},
+	}
+
+	for _, testItem := range testData {
+		password, err := secrets.GetNodePassword(testItem.nodeID, testItem.now)
+		if testItem.expectError {
+			if err == nil {
+				t.Errorf("Error expected for node %s", testItem.nodeID)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Can't get node password: %v", err)
+		}
+
+		if password != testItem.expectedPassword {
+			t.Errorf("Wrong password for node %s: %s", testItem.nodeID, password)
+		}
+	}
+
+	legacySecrets := cloudprotocol.UnitSecrets{
+		Version: cloudprotocol.UnitSecretVersion, Nodes: map[string]string{"node1": "legacy"},
+	}
+
+	if password, err := legacySecrets.GetNodePassword("node1", now); err != nil || password != "legacy" {
+		t.Errorf("Wrong legacy password: %s, %v", password, err)
+	}
+}
+
+func TestCBOREncoding(t *testing.T) {
+	from := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	instance := uint64(1)
+
+	messages := []interface{}{
+		&cloudprotocol.RequestLog{
+			MessageType: cloudprotocol.RequestLogMessageType, LogID: "log1", LogType: cloudprotocol.SystemLog,
+			Filter: cloudprotocol.LogFilter{
+				From: &from, NodeIDs: []string{"node0", "node1"},
+				InstanceFilter: cloudprotocol.InstanceFilter{Instance: &instance},
+			},
+		},
+		&cloudprotocol.PushLog{
+			MessageType: cloudprotocol.PushLogMessageType, LogID: "log1", PartsCount: 1 << 40, Part: 1,
+			Content: []byte{0, 1, 2, 255}, Status: cloudprotocol.LogStatusOk,
+		},
+		&cloudprotocol.DesiredStatus{
+			MessageType: cloudprotocol.DesiredStatusMessageType,
+			Services: []cloudprotocol.ServiceInfo{{
+				ServiceID: "service1", Version: "1.0.0",
+				ArtifactInfo: cloudprotocol.ArtifactInfo{
+					DownloadInfo: cloudprotocol.DownloadInfo{URLs: []string{"url"}, Sha256: []byte{1, 2}, Size: 1024},
+				},
+			}},
+		},
+	}
+
+	for _, message := range messages {
+		data, err := cloudprotocol.MarshalCBOR(message)
+		if err != nil {
+			t.Fatalf("Can't marshal CBOR: %v", err)
+		}
+
+		jsonData, err := json.Marshal(message)
+		if err != nil {
+			t.Fatalf("Can't marshal JSON: %v", err)
+		}
+
+		if len(data) >= len(jsonData) {
+			t.Errorf("CBOR is not smaller than JSON: %d >= %d", len(data), len(jsonData))
+		}
+
+		decoded := reflect.New(reflect.TypeOf(message).Elem()).Interface()
+
+		if err = cloudprotocol.UnmarshalCBOR(data, decoded); err != nil {
+			t.Fatalf("Can't unmarshal CBOR: %v", err)
+		}
+
+		if !reflect.DeepEqual(decoded, message) {
+			t.Errorf("Wrong decoded message: %v", decoded)
+		}
+	}
+
+	for _, encoding := range []string{"", cloudprotocol.EncodingCBOR} {
+		data, err := cloudprotocol.MarshalMessage(cloudprotocol.Message{
+			Header: cloudprotocol.MessageHeader{Version: cloudprotocol.ProtocolVersion, Encoding: encoding},
+			Data:   messages[0],
+		})
+		if err != nil {
+			t.Fatalf("Can't marshal message: %v", err)
+		}
+
+		received, err := cloudprotocol.UnmarshalMessage(data, encoding)
+		if err != nil {
+			t.Fatalf("Can't unmarshal message: %v", err)
+		}
+
+		wrongEncoding := cloudprotocol.EncodingCBOR
+
+		if encoding == cloudprotocol.EncodingCBOR {
+			wrongEncoding = cloudprotocol.EncodingJSON
+		}
+
+		if _, err = cloudprotocol.UnmarshalMessage(data, wrongEncoding); err == nil {
+			t.Error("Error expected for wrong message encoding")
+		}
+
+		if received.Header.Encoding != encoding {
+			t.Errorf("Wrong message encoding: %s", received.Header.Encoding)
+		}
+
+		var requestLog cloudprotocol.RequestLog
+
+		if err = json.Unmarshal(received.Data, &requestLog); err != nil {
+			t.Fatalf("Can't unmarshal message data: %v", err)
+		}
+
+		if !reflect.DeepEqual(&requestLog, messages[0]) {
+			t.Errorf("Wrong message data: %v", requestLog)
+		}
+	}
+
+	// Indefinite length map with half precision float and text chunks.
+	var value map[string]interface{}
+
+	if err := cloudprotocol.UnmarshalCBOR(
+		[]byte{0xbf, 0x61, 'a', 0xf9, 0x3e, 0x00, 0x61, 'b', 0x7f, 0x61, 'x', 0x61, 'y', 0xff, 0xff}, &value); err != nil {
+		t.Fatalf("Can't unmarshal CBOR: %v", err)
+	}
+
+	if !reflect.DeepEqual(value, map[string]interface{}{"a": 1.5, "b": "xy"}) {
+		t.Errorf("Wrong decoded value: %v", value)
+	}
+
+	truncatedData := []byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+	if err := cloudprotocol.UnmarshalCBOR(truncatedData, &value); err == nil {
+		t.Error("Error expected for truncated CBOR data")
+	}
+}
+
+func TestCBORDirectEncoding(t *testing.T) {
+	type embedded struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	type testValue struct {
+		embedded
+		*cloudprotocol.AlertItem
+		Data      []byte            `json:"data"`
+		Negative  int64             `json:"negative"`
+		Large     uint64            `json:"large"`
+		Float     float64           `json:"float"`
+		Count     int               `json:"count"`
+		Empty     string            `json:"empty,omitempty"`
+		Skipped   string            `json:"-"`
+		Raw       json.RawMessage   `json:"raw"`
+		Number    json.Number       `json:"number"`
+		Duration  aostypes.Duration `json:"duration"`
+		Timestamp time.Time         `json:"timestamp"`
+		Labels    map[string]string `json:"labels"`
+		Untagged  []string
+	}
+
+	value := testValue{
+		embedded:  embedded{Name: "name", Count: 1},
+		AlertItem: &cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagSystemError},
+		Data:      []byte{1, 2, 3}, Negative: -1000, Large: math.MaxUint64, Float: 1.5, Count: 2,
+		Skipped: "skipped", Raw: json.RawMessage(`{"a":[1,"b"]}`), Number: "123",
+		Duration:  aostypes.Duration{Duration: 90 * time.Second},
+		Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC), Labels: map[string]string{"b": "2", "a": "1"},
+		Untagged: []string{"untagged"},
+	}
+
+	data, err := cloudprotocol.MarshalCBOR(struct {
+		Data []byte `json:"data"`
+	}{Data: []byte{1, 2, 3}})
+	if err != nil {
+		t.Fatalf("Can't marshal CBOR: %v", err)
+	}
+
+	// Map with one entry: text "data" and byte string of 3 bytes.
+	if expected := []byte{0xa1, 0x64, 'd', 'a', 't', 'a', 0x43, 1, 2, 3}; !bytes.Equal(data, expected) {
+		t.Errorf("Wrong CBOR data: %x, expected: %x", data, expected)
+	}
+
+	if data, err = cloudprotocol.MarshalCBOR(value); err != nil {
+		t.Fatalf("Can't marshal CBOR: %v", err)
+	}
+
+	var decoded testValue
+
+	if err = cloudprotocol.UnmarshalCBOR(data, &decoded); err != nil {
+		t.Fatalf("Can't unmarshal CBOR: %v", err)
+	}
+
+	// Skipped field is not encoded, embedded count is shadowed by outer one as in JSON.
+	expected := value
+	expected.Skipped = ""
+	expected.embedded.Count = 0
+
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("Wrong decoded value:\n%+v\nexpected:\n%+v", decoded, expected)
+	}
+}
+
+func TestApplyDesiredStatusDelta(t *testing.T) {
+	desiredStatus := cloudprotocol.DesiredStatus{
+		Revision: 1,
+		Services: []cloudprotocol.ServiceInfo{
+			{ServiceID: "service1", Version: "1.0.0"},
+			{ServiceID: "service2", Version: "1.0.0"},
+			{ServiceID: "service3", Version: "1.0.0"},
+		},
+		Instances: []cloudprotocol.InstanceInfo{
+			{ServiceID: "service1", SubjectID: "subject1", NumInstances: 1},
+			{ServiceID: "service2", SubjectID: "subject1", NumInstances: 1},
+		},
+		Layers: []cloudprotocol.LayerInfo{{LayerID: "layer1", Digest: "digest1"}},
+	}
+
+	delta := cloudprotocol.DesiredStatusDelta{
+		BaseRevision: 1,
+		Revision:     2,
+		Services: []cloudprotocol.ServiceInfo{
+			{ServiceID: "service2", Version: "2.0.0"},
+			{ServiceID: "service4", Version: "1.0.0"},
+		},
+		RemovedServices: []string{"service3"},
+		Instances: []cloudprotocol.InstanceInfo{
+			{ServiceID: "service1", SubjectID: "subject1", NumInstances: 2},
+		},
+		RemovedInstances: []cloudprotocol.InstanceKey{{ServiceID: "service2", SubjectID: "subject1"}},
+	}
+
+	result, err := desiredStatus.ApplyDelta(delta)
+	if err != nil {
+		t.Fatalf("Can't apply delta: %v", err)
+	}
+
+	expectedStatus := cloudprotocol.DesiredStatus{
+		Revision: 2,
+		Services: []cloudprotocol.ServiceInfo{
+			{ServiceID: "service1", Version: "1.0.0"},
+			{ServiceID: "service2", Version: "2.0.0"},
+			{ServiceID: "service4", Version: "1.0.0"},
+		},
+		Instances: []cloudprotocol.InstanceInfo{
+			{ServiceID: "service1", SubjectID: "subject1", NumInstances: 2},
+		},
+		Layers: []cloudprotocol.LayerInfo{{LayerID: "layer1", Digest: "digest1"}},
+	}
+
+	if !reflect.DeepEqual(result, expectedStatus) {
+		t.Errorf("Wrong desired status: %v", result)
+	}
+
+	if desiredStatus.Services[1].Version != "1.0.0" || len(desiredStatus.Services) != 3 {
+		t.Error("Original desired status is modified")
+	}
+
+	if _, err = result.ApplyDelta(delta); err == nil {
+		t.Error("Error expected for wrong base revision")
+	}
+}
+
+func TestDiffDesiredStatus(t *testing.T) {
+	componentID := "component1"
+
+	from := cloudprotocol.DesiredStatus{
+		UnitConfig: &cloudprotocol.UnitConfig{Version: "1.0.0"},
+		Components: []cloudprotocol.ComponentInfo{
+			{ComponentID: &componentID, ComponentType: "type1", Version: "1.0.0"},
+		},
+		Layers: []cloudprotocol.LayerInfo{
+			{LayerID: "layer1", Digest: "digest1", Version: "1.0.0"},
+			{LayerID: "layer2", Digest: "digest2", Version: "1.0.0"},
+		},
+		Services: []cloudprotocol.ServiceInfo{
+			{ServiceID: "service1", Version: "1.0.0"},
+			{ServiceID: "service2", Version: "1.0.0"},
+		},
+		Instances: []cloudprotocol.InstanceInfo{
+			{ServiceID: "service1", SubjectID: "subject1", NumInstances: 1},
+			{ServiceID: "service2", SubjectID: "subject1", NumInstances: 1},
+		},
+	}
+
+	if changes := cloudprotocol.DiffDesiredStatus(from, from); !changes.IsEmpty() {
+		t.Errorf("Unexpected changes: %v", changes)
+	}
+
+	to := cloudprotocol.DesiredStatus{
+		UnitConfig: &cloudprotocol.UnitConfig{Version: "2.0.0"},
+		Components: []cloudprotocol.ComponentInfo{
+			{ComponentID: &componentID, ComponentType: "type1", Version: "2.0.0"},
+		},
+		Layers: []cloudprotocol.LayerInfo{
+			{LayerID: "layer1", Digest: "digest1", Version: "1.0.0"},
+			{LayerID: "layer3", Digest: "digest3", Version: "1.0.0"},
+		},
+		Services: []cloudprotocol.ServiceInfo{
+			{ServiceID: "service1", Version: "1.0.0"},
+			{ServiceID: "service2", Version: "2.0.0"},
+			{ServiceID: "service3", Version: "1.0.0"},
+		},
+		Instances: []cloudprotocol.InstanceInfo{
+			{ServiceID: "service1", SubjectID: "subject1", NumInstances: 2},
+		},
+	}
+
+	expectedChanges := cloudprotocol.DesiredStatusChanges{
+		UnitConfigChanged: true,
+		UpdatedComponents: []cloudprotocol.ComponentInfo{to.Components[0]},
+		AddedLayers:       []cloudprotocol.LayerInfo{to.Layers[1]},
+		RemovedLayers:     []cloudprotocol.LayerInfo{from.Layers[1]},
+		AddedServices:     []cloudprotocol.ServiceInfo{to.Services[2]},
+		UpdatedServices:   []cloudprotocol.ServiceInfo{to.Services[1]},
+		RemovedInstances:  []cloudprotocol.InstanceInfo{from.Instances[1]},
+		UpdatedInstances:  []cloudprotocol.InstanceInfo{to.Instances[0]},
+	}
+
+	if changes := cloudprotocol.DiffDesiredStatus(from, to); !reflect.DeepEqual(changes, expectedChanges) {
+		t.Errorf("Wrong changes: %+v", changes)
+	}
+}
+
+func TestDecodeAlerts(t *testing.T) {
+	timestamp := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	alerts := cloudprotocol.Alerts{
+		MessageType: cloudprotocol.AlertsMessageType,
+		Sequence:    42,
+		Items: []interface{}{
+			cloudprotocol.SystemAlert{
+				AlertItem: cloudprotocol.AlertItem{Timestamp: timestamp, Tag: cloudprotocol.AlertTagSystemError},
+				NodeID:    "node0", Message: "system message",
+			},
+			cloudprotocol.KernelAlert{
+				AlertItem: cloudprotocol.AlertItem{Timestamp: timestamp, Tag: cloudprotocol.AlertTagKernel},
+				Category:  cloudprotocol.KernelAlertCategoryOOM, Message: "kernel message",
+			},
+			cloudprotocol.RuleAlert{
+				AlertItem: cloudprotocol.AlertItem{Timestamp: timestamp, Tag: "storageAlert"},
+				Rule:      "storage", Severity: cloudprotocol.AlertSeverityError, Message: "storage message",
+			},
+		},
+	}
+
+	data, err := json.Marshal(alerts)
+	if err != nil {
+		t.Fatalf("Can't marshal alerts: %v", err)
+	}
+
+	var decodedAlerts cloudprotocol.Alerts
+
+	if err = json.Unmarshal(data, &decodedAlerts); err != nil {
+		t.Fatalf("Can't unmarshal alerts: %v", err)
+	}
+
+	if decodedAlerts.Sequence != alerts.Sequence {
+		t.Errorf("Wrong decoded alerts sequence: %d", decodedAlerts.Sequence)
+	}
+
+	if _, ok := decodedAlerts.Items[2].(map[string]interface{}); !ok {
+		t.Errorf("Unregistered alert should be decoded to map: %T", decodedAlerts.Items[2])
+	}
+
+	if err = cloudprotocol.RegisterAlertType("storageAlert", cloudprotocol.RuleAlert{}); err != nil {
+		t.Fatalf("Can't register alert type: %v", err)
+	}
+	defer cloudprotocol.UnregisterAlertType("storageAlert")
+
+	if err = cloudprotocol.RegisterAlertType("wrongAlert", "wrong"); err == nil {
+		t.Error("Error expected for wrong alert payload type")
+	}
+
+	if err = json.Unmarshal(data, &decodedAlerts); err != nil {
+		t.Fatalf("Can't unmarshal alerts: %v", err)
+	}
+
+	if !reflect.DeepEqual(decodedAlerts, alerts) {
+		t.Errorf("Wrong decoded alerts: %v", decodedAlerts)
+	}
+}
+
+func TestAlertHelpers(t *testing.T) {
+	alertItem := cloudprotocol.AlertItem{Timestamp: time.Now().UTC(), Tag: cloudprotocol.AlertTagKernel}
+
+	testData := []struct {
+		alert           interface{}
+		alertItem       cloudprotocol.AlertItem
+		message         string
+		expectedMessage string
+	}{
+		{
+			alert:           cloudprotocol.KernelAlert{AlertItem: alertItem, Message: "kernel message"},
+			alertItem:       alertItem,
+			message:         "kernel message",
+			expectedMessage: "new message",
+		},
+		{
+			alert:           cloudprotocol.RuleAlert{AlertItem: alertItem, Rule: "rule", Message: "rule message"},
+			alertItem:       alertItem,
+			message:         "rule message",
+			expectedMessage: "new message",
+		},
+		{
+			alert:           cloudprotocol.MonitoringAlert{AlertItem: alertItem, Parameter: "cpu"},
+			alertItem:       alertItem,
+			message:         fmt.Sprintf("%v", cloudprotocol.MonitoringAlert{AlertItem: alertItem, Parameter: "cpu"}),
+			expectedMessage: fmt.Sprintf("%v", cloudprotocol.MonitoringAlert{AlertItem: alertItem, Parameter: "cpu"}),
+		},
+		{alert: "not alert", message: "not alert", expectedMessage: "not alert"},
+	}
+
+	for _, data := range testData {
+		if item := cloudprotocol.GetAlertItem(data.alert); !reflect.DeepEqual(item, data.alertItem) {
+			t.Errorf("Wrong alert item: %v", item)
+		}
+
+		if message := cloudprotocol.GetAlertMessage(data.alert); message != data.message {
+			t.Errorf("Wrong alert message: %s", message)
+		}
+
+		changedAlert := cloudprotocol.SetAlertMessage(data.alert, "new message")
+
+		if message := cloudprotocol.GetAlertMessage(changedAlert); message != data.expectedMessage {
+			t.Errorf("Wrong changed alert message: %s", message)
+		}
+
+		if message := cloudprotocol.GetAlertMessage(data.alert); message != data.message {
+			t.Errorf("Original alert should not be changed: %s", message)
+		}
+	}
+}
+
+func TestNodeConfigStatus(t *testing.T) {
+	status := cloudprotocol.NewNodeConfigAccepted("node0", "main", "2.0.0")
+
+	if !status.IsApplied() {
+		t.Errorf("Node config should be applied: %v", status)
+	}
+
+	err := aoserrors.Append(
+		aoserrors.Wrap(cloudprotocol.NewConfigFieldError("alertRules.cpu.maxThreshold", "should be positive")),
+		cloudprotocol.NewConfigFieldError("resourceRatios.ram", "out of range: %d", 200))
+
+	status = cloudprotocol.NewNodeConfigRejected("node0", "main", "3.0.0", "2.0.0", err)
+
+	if status.IsApplied() {
+		t.Errorf("Node config should not be applied: %v", status)
+	}
+
+	expectedFieldErrors := []cloudprotocol.ConfigFieldError{
+		{Field: "alertRules.cpu.maxThreshold", Message: "should be positive"},
+		{Field: "resourceRatios.ram", Message: "out of range: 200"},
+	}
+
+	if !reflect.DeepEqual(status.FieldErrors, expectedFieldErrors) {
+		t.Errorf("Wrong field errors: %v", status.FieldErrors)
+	}
+
+	if status.ErrorInfo == nil || status.ErrorInfo.AosCode == cloudprotocol.ErrorCodeNone {
+		t.Errorf("Wrong error info: %v", status.ErrorInfo)
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		t.Fatalf("Can't marshal node config status: %v", err)
+	}
+
+	var decodedStatus cloudprotocol.NodeConfigStatus
+
+	if err = json.Unmarshal(data, &decodedStatus); err != nil {
+		t.Fatalf("Can't unmarshal node config status: %v", err)
+	}
+
+	if !reflect.DeepEqual(decodedStatus, status) {
+		t.Errorf("Wrong decoded node config status: %v", decodedStatus)
+	}
+}
+
+func TestArtifacts(t *testing.T) {
+	componentID := "component1"
+	artifactInfo := cloudprotocol.ArtifactInfo{
+		DownloadInfo: cloudprotocol.DownloadInfo{URLs: []string{"url"}, Sha256: make([]byte, 32), Size: 1024},
+		Signs:        cloudprotocol.Signs{ChainName: "chain1"},
+	}
+
+	desiredStatus := cloudprotocol.DesiredStatus{
+		Components: []cloudprotocol.ComponentInfo{
+			{ComponentID: &componentID, ComponentType: "type1", Version: "1.0.0", ArtifactInfo: artifactInfo},
+			{ComponentType: "type2", Version: "2.0.0", ArtifactInfo: artifactInfo},
+		},
+		Layers: []cloudprotocol.LayerInfo{
+			{LayerID: "layer1", Version: "3.0.0", ArtifactInfo: artifactInfo},
+		},
+		Services: []cloudprotocol.ServiceInfo{
+			{ServiceID: "service1", Version: "4.0.0", ArtifactInfo: artifactInfo},
+		},
+	}
+
+	type artifactData struct {
+		artifactType string
+		id           string
+		version      string
+	}
+
+	expectedArtifacts := []artifactData{
+		{cloudprotocol.ArtifactTypeComponent, "component1", "1.0.0"},
+		{cloudprotocol.ArtifactTypeComponent, "type2", "2.0.0"},
+		{cloudprotocol.ArtifactTypeLayer, "layer1", "3.0.0"},
+		{cloudprotocol.ArtifactTypeService, "service1", "4.0.0"},
+	}
+
+	artifacts := desiredStatus.AllArtifacts()
+
+	if len(artifacts) != len(expectedArtifacts) {
+		t.Fatalf("Wrong artifacts count: %d", len(artifacts))
+	}
+
+	for i, artifact := range artifacts {
+		data := artifactData{artifact.ArtifactType(), artifact.ArtifactID(), artifact.ArtifactVersion()}
+
+		if data != expectedArtifacts[i] {
+			t.Errorf("Wrong artifact: %v", data)
+		}
+
+		if !reflect.DeepEqual(artifact.GetArtifactInfo(), artifactInfo) {
+			t.Errorf("Wrong artifact info: %v", artifact.GetArtifactInfo())
+		}
+	}
+
+	if err := desiredStatus.Layers[0].Validate(); err == nil ||
+		!strings.Contains(err.Error(), "invalid field layer.digest:") {
+		t.Errorf("Wrong validation error: %v", err)
+	}
+
+	if err := desiredStatus.Services[0].Validate(); err != nil {
+		t.Errorf("Validation error: %v", err)
+	}
+
+	data, err := json.Marshal(desiredStatus.Services[0])
+	if err != nil {
+		t.Fatalf("Can't marshal service: %v", err)
+	}
+
+	var fields map[string]interface{}
+
+	if err = json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Can't unmarshal service: %v", err)
+	}
+
+	for _, field := range []string{"id", "urls", "sha256", "size", "decryptionInfo", "signs"} {
+		if _, ok := fields[field]; !ok {
+			t.Errorf("Field %s not found", field)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/klauspost/compress/zstd"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Content encodings of message data.
+const (
+	// ContentEncodingGzip gzip compressed message data.
+	ContentEncodingGzip = "gzip"
+	// ContentEncodingZstd zstd compressed message data.
+	ContentEncodingZstd = "zstd"
+)
+
+// DefaultCompressionThreshold default size of JSON encoded message data starting from which data is compressed.
+const DefaultCompressionThreshold = 4 * 1024
+
+// MaxDecompressedSize max size of decompressed message data.
+const MaxDecompressedSize = 64 * 1024 * 1024
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// ErrContentEncodingNotSupported content encoding of message data is not supported.
+var ErrContentEncodingNotSupported = errors.New("content encoding not supported") //nolint:gochecknoglobals
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// CompressMessage compresses message data with gzip if size of JSON encoded data is not less than threshold.
+// Compressed data is stored as base64 encoded string and content encoding is set in message header.
+func CompressMessage(message Message, threshold int) (Message, error) {
+	return CompressMessageWithEncoding(message, threshold, ContentEncodingGzip)
+}
+
+// CompressMessageWithEncoding compresses message data with specified content encoding (gzip or zstd) if size of
+// JSON encoded data is not less than threshold.
+func CompressMessageWithEncoding(message Message, threshold int, contentEncoding string) (Message, error) {
+	if message.Header.ContentEncoding != "" {
+		return message, nil
+	}
+
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return message, aoserrors.Wrap(err)
+	}
+
+	if len(data) < threshold {
+		return message, nil
+	}
+
+	var compressed []byte
+
+	switch contentEncoding {
+	case ContentEncodingGzip:
+		if compressed, err = compressGzip(data); err != nil {
+			return message, err
+		}
+
+	case ContentEncodingZstd:
+		if compressed, err = compressZstd(data); err != nil {
+			return message, err
+		}
+
+	default:
+		return message, aoserrors.Errorf("%w: %s", ErrContentEncodingNotSupported, contentEncoding)
+	}
+
+	message.Header.ContentEncoding = contentEncoding
+	message.Data = compressed
+
+	return message, nil
+}
+
+// DecompressMessage decompresses received message data according to content encoding set in message header.
+// Gzip and zstd encodings are supported, ErrContentEncodingNotSupported is returned for other encodings. Size of
+// decompressed data is limited by MaxDecompressedSize.
+func DecompressMessage(message ReceivedMessage) (ReceivedMessage, error) {
+	switch message.Header.ContentEncoding {
+	case "":
+		return message, nil
+
+	case ContentEncodingGzip, ContentEncodingZstd:
+
+	default:
+		return message, aoserrors.Errorf("%w: %s", ErrContentEncodingNotSupported, message.Header.ContentEncoding)
+	}
+
+	var compressed []byte
+
+	if err := json.Unmarshal(message.Data, &compressed); err != nil {
+		return message, aoserrors.Wrap(err)
+	}
+
+	reader, err := newDecompressReader(message.Header.ContentEncoding, compressed)
+	if err != nil {
+		return message, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, MaxDecompressedSize+1))
+	if err != nil {
+		return message, aoserrors.Wrap(err)
+	}
+
+	if len(data) > MaxDecompressedSize {
+		return message, aoserrors.Errorf("decompressed message data exceeds %d bytes", MaxDecompressedSize)
+	}
+
+	message.Header.ContentEncoding = ""
+	message.Data = data
+
+	return message, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func compressGzip(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	writer := gzip.NewWriter(&buffer)
+
+	if _, err := writer.Write(data); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func compressZstd(data []byte) ([]byte, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer encoder.Close()
+
+	return encoder.EncodeAll(data, nil), nil
+}
+
+func newDecompressReader(contentEncoding string, compressed []byte) (io.ReadCloser, error) {
+	if contentEncoding == ContentEncodingZstd {
+		// Decoder memory is limited as well to avoid big window allocation requested by malicious frame header.
+		decoder, err := zstd.NewReader(bytes.NewReader(compressed),
+			zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(MaxDecompressedSize))
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return decoder.IOReadCloser(), nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return reader, nil
+}
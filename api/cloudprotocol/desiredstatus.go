@@ -38,6 +38,13 @@ const (
 	TimetableUpdate = "timetable"
 )
 
+// Artifact types.
+const (
+	ArtifactTypeComponent = "component"
+	ArtifactTypeLayer     = "layer"
+	ArtifactTypeService   = "service"
+)
+
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
@@ -120,6 +127,22 @@ type DownloadInfo struct {
 	Size   uint64   `json:"size"`
 }
 
+// ArtifactInfo common download, decryption and sign info of components, layers and services.
+type ArtifactInfo struct {
+	DownloadInfo
+	DecryptionInfo DecryptionInfo `json:"decryptionInfo"`
+	Signs          Signs          `json:"signs"`
+}
+
+// Artifact common interface of downloadable desired status items.
+type Artifact interface {
+	ArtifactType() string
+	ArtifactID() string
+	ArtifactVersion() string
+	GetArtifactInfo() ArtifactInfo
+	Validate() error
+}
+
 // NodeStatus node status.
 type NodeStatus struct {
 	NodeID string `json:"nodeId"`
@@ -131,9 +154,7 @@ type ServiceInfo struct {
 	ServiceID  string `json:"id"`
 	ProviderID string `json:"providerId"`
 	Version    string `json:"version"`
-	DownloadInfo
-	DecryptionInfo DecryptionInfo `json:"decryptionInfo"`
-	Signs          Signs          `json:"signs"`
+	ArtifactInfo
 }
 
 // LayerInfo decrypted layer info.
@@ -141,9 +162,7 @@ type LayerInfo struct {
 	LayerID string `json:"id"`
 	Digest  string `json:"digest"`
 	Version string `json:"version"`
-	DownloadInfo
-	DecryptionInfo DecryptionInfo `json:"decryptionInfo"`
-	Signs          Signs          `json:"signs"`
+	ArtifactInfo
 }
 
 // ComponentInfo decrypted component info.
@@ -152,9 +171,7 @@ type ComponentInfo struct {
 	ComponentType string          `json:"type"`
 	Version       string          `json:"version"`
 	Annotations   json.RawMessage `json:"annotations,omitempty"`
-	DownloadInfo
-	DecryptionInfo DecryptionInfo `json:"decryptionInfo"`
-	Signs          Signs          `json:"signs"`
+	ArtifactInfo
 }
 
 // InstanceInfo decrypted desired instance runtime info.
@@ -200,6 +217,7 @@ type ScheduleRule struct {
 // DesiredStatus desired status.
 type DesiredStatus struct {
 	MessageType       string             `json:"messageType"`
+	Revision          uint64             `json:"revision,omitempty"`
 	UnitConfig        *UnitConfig        `json:"unitConfig,omitempty"`
 	Nodes             []NodeStatus       `json:"nodes"`
 	Components        []ComponentInfo    `json:"components"`
@@ -234,3 +252,73 @@ func (component ComponentInfo) String() string {
 	return fmt.Sprintf("{id: %s, type: %s, annotations: %s, version: %s}",
 		id, component.ComponentType, component.Annotations, component.Version)
 }
+
+// AllArtifacts returns all components, layers and services of desired status.
+func (desiredStatus DesiredStatus) AllArtifacts() (artifacts []Artifact) {
+	artifacts = make([]Artifact, 0,
+		len(desiredStatus.Components)+len(desiredStatus.Layers)+len(desiredStatus.Services))
+
+	for _, component := range desiredStatus.Components {
+		artifacts = append(artifacts, component)
+	}
+
+	for _, layer := range desiredStatus.Layers {
+		artifacts = append(artifacts, layer)
+	}
+
+	for _, service := range desiredStatus.Services {
+		artifacts = append(artifacts, service)
+	}
+
+	return artifacts
+}
+
+// GetArtifactInfo returns artifact download, decryption and sign info.
+func (artifactInfo ArtifactInfo) GetArtifactInfo() ArtifactInfo {
+	return artifactInfo
+}
+
+// ArtifactType returns service artifact type.
+func (service ServiceInfo) ArtifactType() string {
+	return ArtifactTypeService
+}
+
+// ArtifactID returns service ID.
+func (service ServiceInfo) ArtifactID() string {
+	return service.ServiceID
+}
+
+// ArtifactVersion returns service version.
+func (service ServiceInfo) ArtifactVersion() string {
+	return service.Version
+}
+
+// ArtifactType returns layer artifact type.
+func (layer LayerInfo) ArtifactType() string {
+	return ArtifactTypeLayer
+}
+
+// ArtifactID returns layer ID.
+func (layer LayerInfo) ArtifactID() string {
+	return layer.LayerID
+}
+
+// ArtifactVersion returns layer version.
+func (layer LayerInfo) ArtifactVersion() string {
+	return layer.Version
+}
+
+// ArtifactType returns component artifact type.
+func (component ComponentInfo) ArtifactType() string {
+	return ArtifactTypeComponent
+}
+
+// ArtifactID returns component ID or component type if ID is not set.
+func (component ComponentInfo) ArtifactID() string {
+	return component.key()
+}
+
+// ArtifactVersion returns component version.
+func (component ComponentInfo) ArtifactVersion() string {
+	return component.Version
+}
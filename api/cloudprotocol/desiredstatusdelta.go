@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import "github.com/aosedge/aos_common/aoserrors"
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Desired status delta message types.
+const (
+	DesiredStatusDeltaMessageType   = "desiredStatusDelta"
+	RequestDesiredStatusMessageType = "requestDesiredStatus"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// InstanceKey identifies desired instance info.
+type InstanceKey struct {
+	ServiceID string `json:"serviceId"`
+	SubjectID string `json:"subjectId"`
+}
+
+// DesiredStatusDelta incremental desired status update relative to base revision. Items in lists are added or
+// replace existing items with the same key, removed lists contain keys of items to remove:
+// components are keyed by ID (or type if ID is not set), layers by digest, services by ID, instances by service and
+// subject IDs, certificates by fingerprint and certificate chains by name.
+type DesiredStatusDelta struct {
+	MessageType              string             `json:"messageType"`
+	BaseRevision             uint64             `json:"baseRevision"`
+	Revision                 uint64             `json:"revision"`
+	UnitConfig               *UnitConfig        `json:"unitConfig,omitempty"`
+	FOTASchedule             *ScheduleRule      `json:"fotaSchedule,omitempty"`
+	SOTASchedule             *ScheduleRule      `json:"sotaSchedule,omitempty"`
+	Nodes                    []NodeStatus       `json:"nodes,omitempty"`
+	Components               []ComponentInfo    `json:"components,omitempty"`
+	RemovedComponents        []string           `json:"removedComponents,omitempty"`
+	Layers                   []LayerInfo        `json:"layers,omitempty"`
+	RemovedLayers            []string           `json:"removedLayers,omitempty"`
+	Services                 []ServiceInfo      `json:"services,omitempty"`
+	RemovedServices          []string           `json:"removedServices,omitempty"`
+	Instances                []InstanceInfo     `json:"instances,omitempty"`
+	RemovedInstances         []InstanceKey      `json:"removedInstances,omitempty"`
+	Certificates             []Certificate      `json:"certificates,omitempty"`
+	RemovedCertificates      []string           `json:"removedCertificates,omitempty"`
+	CertificateChains        []CertificateChain `json:"certificateChains,omitempty"`
+	RemovedCertificateChains []string           `json:"removedCertificateChains,omitempty"`
+}
+
+// RequestDesiredStatus requests full desired status from cloud, e.g. when delta base revision doesn't match.
+type RequestDesiredStatus struct {
+	MessageType string `json:"messageType"`
+	Revision    uint64 `json:"revision"`
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ApplyDelta returns new desired status with applied delta. Error is returned if delta base revision doesn't match
+// desired status revision.
+func (desiredStatus DesiredStatus) ApplyDelta(delta DesiredStatusDelta) (DesiredStatus, error) {
+	if delta.BaseRevision != desiredStatus.Revision {
+		return desiredStatus, aoserrors.Errorf("delta base revision %d doesn't match desired status revision %d",
+			delta.BaseRevision, desiredStatus.Revision)
+	}
+
+	result := desiredStatus
+
+	result.Revision = delta.Revision
+
+	if delta.UnitConfig != nil {
+		result.UnitConfig = delta.UnitConfig
+	}
+
+	if delta.FOTASchedule != nil {
+		result.FOTASchedule = *delta.FOTASchedule
+	}
+
+	if delta.SOTASchedule != nil {
+		result.SOTASchedule = *delta.SOTASchedule
+	}
+
+	result.Nodes = mergeDeltaItems(desiredStatus.Nodes, delta.Nodes, nil,
+		func(node NodeStatus) string { return node.NodeID })
+	result.Components = mergeDeltaItems(desiredStatus.Components, delta.Components, delta.RemovedComponents,
+		ComponentInfo.key)
+	result.Layers = mergeDeltaItems(desiredStatus.Layers, delta.Layers, delta.RemovedLayers,
+		func(layer LayerInfo) string { return layer.Digest })
+	result.Services = mergeDeltaItems(desiredStatus.Services, delta.Services, delta.RemovedServices,
+		func(service ServiceInfo) string { return service.ServiceID })
+	result.Certificates = mergeDeltaItems(desiredStatus.Certificates, delta.Certificates, delta.RemovedCertificates,
+		func(certificate Certificate) string { return certificate.Fingerprint })
+	result.CertificateChains = mergeDeltaItems(desiredStatus.CertificateChains, delta.CertificateChains,
+		delta.RemovedCertificateChains, func(chain CertificateChain) string { return chain.Name })
+
+	removedInstances := make([]string, 0, len(delta.RemovedInstances))
+
+	for _, instance := range delta.RemovedInstances {
+		removedInstances = append(removedInstances, instance.key())
+	}
+
+	result.Instances = mergeDeltaItems(desiredStatus.Instances, delta.Instances, removedInstances,
+		func(instance InstanceInfo) string { return InstanceKey{instance.ServiceID, instance.SubjectID}.key() })
+
+	return result, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (component ComponentInfo) key() string {
+	if component.ComponentID != nil {
+		return *component.ComponentID
+	}
+
+	return component.ComponentType
+}
+
+func (instance InstanceKey) key() string {
+	return instance.ServiceID + "/" + instance.SubjectID
+}
+
+// mergeDeltaItems returns new slice where items with removed keys are dropped, items with changed keys are replaced
+// in place and new items are appended.
+func mergeDeltaItems[T any](items, changed []T, removed []string, getKey func(T) string) []T {
+	if len(changed) == 0 && len(removed) == 0 {
+		return items
+	}
+
+	removedKeys := make(map[string]struct{}, len(removed))
+
+	for _, key := range removed {
+		removedKeys[key] = struct{}{}
+	}
+
+	changedItems := make(map[string]int, len(changed))
+
+	for i, item := range changed {
+		changedItems[getKey(item)] = i
+	}
+
+	result := make([]T, 0, len(items)+len(changed))
+
+	for _, item := range items {
+		key := getKey(item)
+
+		if _, ok := removedKeys[key]; ok {
+			continue
+		}
+
+		if index, ok := changedItems[key]; ok {
+			result = append(result, changed[index])
+			delete(changedItems, key)
+
+			continue
+		}
+
+		result = append(result, item)
+	}
+
+	for _, item := range changed {
+		key := getKey(item)
+
+		if index, ok := changedItems[key]; ok {
+			result = append(result, changed[index])
+			delete(changedItems, key)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import "reflect"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// DesiredStatusChanges change set between two desired statuses. Updated lists contain new items for which version
+// (or runtime parameters for instances) is changed. Components are matched by ID (or type if ID is not set), layers
+// by ID, services by ID and instances by service and subject IDs.
+type DesiredStatusChanges struct {
+	UnitConfigChanged bool
+	AddedComponents   []ComponentInfo
+	RemovedComponents []ComponentInfo
+	UpdatedComponents []ComponentInfo
+	AddedLayers       []LayerInfo
+	RemovedLayers     []LayerInfo
+	UpdatedLayers     []LayerInfo
+	AddedServices     []ServiceInfo
+	RemovedServices   []ServiceInfo
+	UpdatedServices   []ServiceInfo
+	AddedInstances    []InstanceInfo
+	RemovedInstances  []InstanceInfo
+	UpdatedInstances  []InstanceInfo
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// DiffDesiredStatus returns changes required to move from one desired status to another.
+func DiffDesiredStatus(from, to DesiredStatus) (changes DesiredStatusChanges) {
+	changes.UnitConfigChanged = isUnitConfigChanged(from.UnitConfig, to.UnitConfig)
+
+	changes.AddedComponents, changes.RemovedComponents, changes.UpdatedComponents = diffItems(
+		from.Components, to.Components, ComponentInfo.ArtifactID,
+		func(from, to ComponentInfo) bool { return from.Version != to.Version })
+
+	changes.AddedLayers, changes.RemovedLayers, changes.UpdatedLayers = diffItems(
+		from.Layers, to.Layers, LayerInfo.ArtifactID,
+		func(from, to LayerInfo) bool { return from.Version != to.Version || from.Digest != to.Digest })
+
+	changes.AddedServices, changes.RemovedServices, changes.UpdatedServices = diffItems(
+		from.Services, to.Services, ServiceInfo.ArtifactID,
+		func(from, to ServiceInfo) bool { return from.Version != to.Version })
+
+	changes.AddedInstances, changes.RemovedInstances, changes.UpdatedInstances = diffItems(
+		from.Instances, to.Instances,
+		func(instance InstanceInfo) string { return InstanceKey{instance.ServiceID, instance.SubjectID}.key() },
+		func(from, to InstanceInfo) bool {
+			return from.Priority != to.Priority || from.NumInstances != to.NumInstances ||
+				!reflect.DeepEqual(from.Labels, to.Labels)
+		})
+
+	return changes
+}
+
+// IsEmpty returns true if there are no changes.
+func (changes DesiredStatusChanges) IsEmpty() bool {
+	return reflect.DeepEqual(changes, DesiredStatusChanges{})
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func isUnitConfigChanged(from, to *UnitConfig) bool {
+	if from == nil || to == nil {
+		return from != to
+	}
+
+	return from.Version != to.Version
+}
+
+// diffItems returns added and updated items in new items order and removed items in old items order.
+func diffItems[T any](
+	from, to []T, getKey func(T) string, isUpdated func(from, to T) bool,
+) (added, removed, updated []T) {
+	fromItems := make(map[string]T, len(from))
+
+	for _, item := range from {
+		fromItems[getKey(item)] = item
+	}
+
+	toKeys := make(map[string]struct{}, len(to))
+
+	for _, item := range to {
+		key := getKey(item)
+
+		toKeys[key] = struct{}{}
+
+		fromItem, ok := fromItems[key]
+		if !ok {
+			added = append(added, item)
+
+			continue
+		}
+
+		if isUpdated(fromItem, item) {
+			updated = append(updated, item)
+		}
+	}
+
+	for _, item := range from {
+		if _, ok := toKeys[getKey(item)]; !ok {
+			removed = append(removed, item)
+		}
+	}
+
+	return added, removed, updated
+}
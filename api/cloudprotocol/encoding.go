@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/fxamacker/cbor/v2"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Message encodings.
+const (
+	EncodingJSON = "json"
+	EncodingCBOR = "cbor"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// jsonToCBORTranscoder encodes JSON representation of types with custom JSON marshalers to CBOR.
+type jsonToCBORTranscoder struct{}
+
+// cborToJSONTranscoder decodes CBOR to JSON representation for types with custom JSON unmarshalers.
+type cborToJSONTranscoder struct{}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+var (
+	cborEncMode cbor.EncMode
+	cborDecMode cbor.DecMode
+	jsonMapType = reflect.TypeOf(map[string]interface{}(nil))
+)
+
+/***********************************************************************************************************************
+ * Init
+ **********************************************************************************************************************/
+
+func init() {
+	var err error
+
+	if cborEncMode, err = (cbor.EncOptions{
+		Time:                    cbor.TimeRFC3339Nano,
+		JSONMarshalerTranscoder: jsonToCBORTranscoder{},
+	}).EncMode(); err != nil {
+		panic(err)
+	}
+
+	if cborDecMode, err = (cbor.DecOptions{
+		DefaultMapType:            jsonMapType,
+		JSONUnmarshalerTranscoder: cborToJSONTranscoder{},
+	}).DecMode(); err != nil {
+		panic(err)
+	}
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// MarshalCBOR encodes value to CBOR. JSON field names and options are used, thus any cloud protocol message may be
+// encoded. Byte slices are encoded as CBOR byte strings, types with custom JSON marshalers are encoded from their
+// JSON representation.
+func MarshalCBOR(value interface{}) (data []byte, err error) {
+	if data, err = cborEncMode.Marshal(value); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalCBOR decodes CBOR data to value.
+func UnmarshalCBOR(data []byte, value interface{}) (err error) {
+	return aoserrors.Wrap(cborDecMode.Unmarshal(data, value))
+}
+
+// MarshalMessage encodes message using encoding specified in message header. JSON is used by default.
+func MarshalMessage(message Message) (data []byte, err error) {
+	switch message.Header.Encoding {
+	case "", EncodingJSON:
+		if data, err = json.Marshal(message); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return data, nil
+
+	case EncodingCBOR:
+		return MarshalCBOR(message)
+
+	default:
+		return nil, aoserrors.Errorf("unsupported message encoding: %s", message.Header.Encoding)
+	}
+}
+
+// UnmarshalMessage decodes message using encoding negotiated for the connection. The encoding should match the one
+// specified in message header. Compressed data is decompressed, message data is always returned as JSON.
+func UnmarshalMessage(data []byte, encoding string) (message ReceivedMessage, err error) {
+	switch encoding {
+	case "", EncodingJSON:
+		if err = json.Unmarshal(data, &message); err != nil {
+			return message, aoserrors.Wrap(err)
+		}
+
+	case EncodingCBOR:
+		if err = UnmarshalCBOR(data, &message); err != nil {
+			return message, err
+		}
+
+	default:
+		return message, aoserrors.Errorf("unsupported message encoding: %s", encoding)
+	}
+
+	if normalizeEncoding(message.Header.Encoding) != normalizeEncoding(encoding) {
+		return message, aoserrors.Errorf("message encoding mismatch: %s, expected: %s",
+			message.Header.Encoding, encoding)
+	}
+
+	return DecompressMessage(message)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func normalizeEncoding(encoding string) string {
+	if encoding == "" {
+		return EncodingJSON
+	}
+
+	return encoding
+}
+
+func (jsonToCBORTranscoder) Transcode(dst io.Writer, src io.Reader) error {
+	decoder := json.NewDecoder(src)
+
+	decoder.UseNumber()
+
+	var item interface{}
+
+	if err := decoder.Decode(&item); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	item, err := convertJSONNumbers(item)
+	if err != nil {
+		return err
+	}
+
+	return aoserrors.Wrap(cborEncMode.NewEncoder(dst).Encode(item))
+}
+
+func (cborToJSONTranscoder) Transcode(dst io.Writer, src io.Reader) error {
+	var item interface{}
+
+	if err := cborDecMode.NewDecoder(src).Decode(&item); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	_, err = dst.Write(data)
+
+	return aoserrors.Wrap(err)
+}
+
+// convertJSONNumbers converts JSON numbers to integer types if possible, thus they are encoded as CBOR integers.
+func convertJSONNumbers(item interface{}) (interface{}, error) {
+	switch value := item.(type) {
+	case json.Number:
+		if intValue, err := strconv.ParseInt(value.String(), 10, 64); err == nil {
+			return intValue, nil
+		}
+
+		if uintValue, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return uintValue, nil
+		}
+
+		floatValue, err := value.Float64()
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return floatValue, nil
+
+	case []interface{}:
+		for i, element := range value {
+			converted, err := convertJSONNumbers(element)
+			if err != nil {
+				return nil, err
+			}
+
+			value[i] = converted
+		}
+
+	case map[string]interface{}:
+		for key, element := range value {
+			converted, err := convertJSONNumbers(element)
+			if err != nil {
+				return nil, err
+			}
+
+			value[key] = converted
+		}
+	}
+
+	return item, nil
+}
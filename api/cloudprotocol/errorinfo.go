@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Aos error codes.
+const (
+	// ErrorCodeNone no error.
+	ErrorCodeNone = iota
+	// ErrorCodeFailed generic failure, used for errors without specific category.
+	ErrorCodeFailed
+	// ErrorCodeRuntime runtime (system call, library) error.
+	ErrorCodeRuntime
+	// ErrorCodeNoMemory not enough memory or storage.
+	ErrorCodeNoMemory
+	// ErrorCodeOutOfRange value or index out of range.
+	ErrorCodeOutOfRange
+	// ErrorCodeNotFound requested entity not found.
+	ErrorCodeNotFound
+	// ErrorCodeInvalidArgument invalid argument or malformed request.
+	ErrorCodeInvalidArgument
+	// ErrorCodeTimeout operation timed out.
+	ErrorCodeTimeout
+	// ErrorCodeAlreadyExist entity already exists.
+	ErrorCodeAlreadyExist
+	// ErrorCodeWrongState operation is not allowed in current state.
+	ErrorCodeWrongState
+	// ErrorCodeInvalidChecksum checksum or signature verification failed.
+	ErrorCodeInvalidChecksum
+	// ErrorCodeAlreadyLoggedIn entity is already logged in.
+	ErrorCodeAlreadyLoggedIn
+	// ErrorCodeNotSupported operation or format is not supported.
+	ErrorCodeNotSupported
+	// ErrorCodeCanceled operation canceled.
+	ErrorCodeCanceled
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type errorCategory struct {
+	code int
+	err  error
+}
+
+// codedError error restored from error info.
+type codedError struct {
+	message  string
+	category error
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// Order matters: the first matched category defines the code.
+//
+//nolint:gochecknoglobals
+var errorCategories = []errorCategory{
+	{ErrorCodeRuntime, aoserrors.ErrRuntime},
+	{ErrorCodeNoMemory, aoserrors.ErrNoMemory},
+	{ErrorCodeOutOfRange, aoserrors.ErrOutOfRange},
+	{ErrorCodeNotFound, aoserrors.ErrNotFound},
+	{ErrorCodeInvalidArgument, aoserrors.ErrInvalidArgument},
+	{ErrorCodeTimeout, aoserrors.ErrTimeout},
+	{ErrorCodeAlreadyExist, aoserrors.ErrAlreadyExist},
+	{ErrorCodeWrongState, aoserrors.ErrWrongState},
+	{ErrorCodeInvalidChecksum, aoserrors.ErrInvalidChecksum},
+	{ErrorCodeAlreadyLoggedIn, aoserrors.ErrAlreadyLoggedIn},
+	{ErrorCodeNotSupported, aoserrors.ErrNotSupported},
+	{ErrorCodeCanceled, aoserrors.ErrCanceled},
+	{ErrorCodeFailed, aoserrors.ErrFailed},
+	{ErrorCodeTimeout, context.DeadlineExceeded},
+	{ErrorCodeTimeout, os.ErrDeadlineExceeded},
+	{ErrorCodeCanceled, context.Canceled},
+	{ErrorCodeNotFound, fs.ErrNotExist},
+	{ErrorCodeAlreadyExist, fs.ErrExist},
+	{ErrorCodeInvalidArgument, fs.ErrInvalid},
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewErrorInfo creates error info from error. Aos code is detected from error category, exit code is set if error
+// wraps process exit error.
+func NewErrorInfo(err error) *ErrorInfo {
+	if err == nil {
+		return nil
+	}
+
+	errorInfo := &ErrorInfo{AosCode: GetErrorCode(err), Message: err.Error()}
+
+	var exitErr *exec.ExitError
+
+	if errors.As(err, &exitErr) {
+		errorInfo.ExitCode = exitErr.ExitCode()
+	}
+
+	return errorInfo
+}
+
+// GetErrorCode returns Aos error code for error.
+func GetErrorCode(err error) int {
+	if err == nil {
+		return ErrorCodeNone
+	}
+
+	for _, category := range errorCategories {
+		if errors.Is(err, category.err) {
+			return category.code
+		}
+	}
+
+	return ErrorCodeFailed
+}
+
+// Err converts error info back to error which matches corresponding aoserrors category with errors.Is.
+func (errorInfo *ErrorInfo) Err() error {
+	if errorInfo == nil {
+		return nil
+	}
+
+	err := &codedError{message: errorInfo.Message, category: aoserrors.ErrFailed}
+
+	for _, category := range errorCategories {
+		if category.code == errorInfo.AosCode {
+			err.category = category.err
+
+			break
+		}
+	}
+
+	if err.message == "" {
+		err.message = err.category.Error()
+	}
+
+	return aoserrors.Wrap(err)
+}
+
+// Error returns error message.
+func (err *codedError) Error() string {
+	return err.message
+}
+
+// Unwrap returns error category.
+func (err *codedError) Unwrap() error {
+	return err.category
+}
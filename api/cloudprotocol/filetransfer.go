@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// File transfer message types.
+const (
+	FileTransferRequestMessageType = "fileTransferRequest"
+	FileChunkMessageType           = "fileChunk"
+	FileChunkAckMessageType        = "fileChunkAck"
+	FileTransferStatusMessageType  = "fileTransferStatus"
+)
+
+// File transfer directions.
+const (
+	// FileTransferUpload file is transferred from unit to cloud.
+	FileTransferUpload = "upload"
+	// FileTransferDownload file is transferred from cloud to unit.
+	FileTransferDownload = "download"
+)
+
+// File transfer statuses.
+const (
+	FileTransferStatusInProgress = "inProgress"
+	FileTransferStatusCompleted  = "completed"
+	FileTransferStatusFailed     = "failed"
+	FileTransferStatusCanceled   = "canceled"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// FileTransferRequest cloud request to start or resume file transfer.
+type FileTransferRequest struct {
+	MessageType string `json:"messageType"`
+	TransferID  string `json:"transferId"`
+	Direction   string `json:"direction"`
+	FileName    string `json:"fileName"`
+	// Size and Sha256 describe whole file, set by the side which sends the file.
+	Size      uint64 `json:"size,omitempty"`
+	Sha256    []byte `json:"sha256,omitempty"`
+	ChunkSize uint64 `json:"chunkSize"`
+	// Offset resume offset, transfer starts from beginning if not set.
+	Offset uint64 `json:"offset,omitempty"`
+}
+
+// FileChunk file data chunk.
+type FileChunk struct {
+	MessageType string `json:"messageType"`
+	TransferID  string `json:"transferId"`
+	Offset      uint64 `json:"offset"`
+	Data        []byte `json:"data"`
+	Sha256      []byte `json:"sha256"`
+	Last        bool   `json:"last,omitempty"`
+}
+
+// FileChunkAck file chunk acknowledgement.
+type FileChunkAck struct {
+	MessageType string `json:"messageType"`
+	TransferID  string `json:"transferId"`
+	// Offset next expected offset.
+	Offset    uint64     `json:"offset"`
+	ErrorInfo *ErrorInfo `json:"errorInfo,omitempty"`
+}
+
+// FileTransferStatus file transfer status.
+type FileTransferStatus struct {
+	MessageType string     `json:"messageType"`
+	TransferID  string     `json:"transferId"`
+	Status      string     `json:"status"`
+	Offset      uint64     `json:"offset"`
+	ErrorInfo   *ErrorInfo `json:"errorInfo,omitempty"`
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import (
+	"time"
+
+	"github.com/aosedge/aos_common/aostypes"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// UnitHeartbeatMessageType unit heartbeat message type.
+const UnitHeartbeatMessageType = "unitHeartbeat"
+
+// Boot reasons.
+const (
+	BootReasonUnknown  = "unknown"
+	BootReasonPowerOn  = "powerOn"
+	BootReasonReboot   = "reboot"
+	BootReasonUpdate   = "update"
+	BootReasonWatchdog = "watchdog"
+	BootReasonCrash    = "crash"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// UnitHeartbeat lightweight unit liveness message.
+type UnitHeartbeat struct {
+	MessageType     string            `json:"messageType"`
+	NodeID          string            `json:"nodeId,omitempty"`
+	Timestamp       time.Time         `json:"timestamp"`
+	Sequence        uint64            `json:"sequence"`
+	ProtocolVersion uint64            `json:"protocolVersion"`
+	Uptime          aostypes.Duration `json:"uptime"`
+	BootReason      string            `json:"bootReason,omitempty"`
+	// ConnectivityRTT last measured round trip time to the cloud, zero if unknown.
+	ConnectivityRTT aostypes.Duration `json:"connectivityRtt"`
+}
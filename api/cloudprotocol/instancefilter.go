@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import "github.com/aosedge/aos_common/aostypes"
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Normalize returns filter where empty service and subject IDs are replaced by nil, i.e. match any value.
+func (filter InstanceFilter) Normalize() InstanceFilter {
+	if filter.ServiceID != nil && *filter.ServiceID == "" {
+		filter.ServiceID = nil
+	}
+
+	if filter.SubjectID != nil && *filter.SubjectID == "" {
+		filter.SubjectID = nil
+	}
+
+	return filter
+}
+
+// IsEmpty returns true if filter matches all instances.
+func (filter InstanceFilter) IsEmpty() bool {
+	filter = filter.Normalize()
+
+	return filter.ServiceID == nil && filter.SubjectID == nil && filter.Instance == nil
+}
+
+// Matches returns true if instance ident matches the filter.
+func (filter InstanceFilter) Matches(ident aostypes.InstanceIdent) bool {
+	filter = filter.Normalize()
+
+	if filter.ServiceID != nil && *filter.ServiceID != ident.ServiceID {
+		return false
+	}
+
+	if filter.SubjectID != nil && *filter.SubjectID != ident.SubjectID {
+		return false
+	}
+
+	if filter.Instance != nil && *filter.Instance != ident.Instance {
+		return false
+	}
+
+	return true
+}
+
+// Intersect returns filter which matches instances matched by both filters. False is returned if there is no
+// instance which matches both filters.
+func (filter InstanceFilter) Intersect(other InstanceFilter) (InstanceFilter, bool) {
+	var (
+		result InstanceFilter
+		ok     bool
+	)
+
+	filter, other = filter.Normalize(), other.Normalize()
+
+	if result.ServiceID, ok = intersectField(filter.ServiceID, other.ServiceID); !ok {
+		return InstanceFilter{}, false
+	}
+
+	if result.SubjectID, ok = intersectField(filter.SubjectID, other.SubjectID); !ok {
+		return InstanceFilter{}, false
+	}
+
+	if result.Instance, ok = intersectField(filter.Instance, other.Instance); !ok {
+		return InstanceFilter{}, false
+	}
+
+	return result, true
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func intersectField[T comparable](value1, value2 *T) (*T, bool) {
+	switch {
+	case value1 == nil:
+		return value2, true
+
+	case value2 == nil:
+		return value1, true
+
+	case *value1 == *value2:
+		return value1, true
+
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import "time"
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Maintenance message types.
+const (
+	RebootRequestMessageType         = "rebootRequest"
+	RebootStatusMessageType          = "rebootStatus"
+	MaintenanceModeMessageType       = "maintenanceMode"
+	MaintenanceModeStatusMessageType = "maintenanceModeStatus"
+)
+
+// Maintenance statuses.
+const (
+	MaintenanceStatusAccepted   = "accepted"
+	MaintenanceStatusScheduled  = "scheduled"
+	MaintenanceStatusInProgress = "inProgress"
+	MaintenanceStatusDone       = "done"
+	MaintenanceStatusRejected   = "rejected"
+	MaintenanceStatusFailed     = "failed"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// MaintenanceWindow time window in which maintenance operation should be performed.
+// Not set start means as soon as possible, not set end means no deadline.
+type MaintenanceWindow struct {
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// RebootRequest request to reboot unit or nodes.
+type RebootRequest struct {
+	MessageType string `json:"messageType"`
+	RequestID   string `json:"requestId"`
+	// NodeIDs nodes to reboot, empty means whole unit.
+	NodeIDs []string           `json:"nodeIds,omitempty"`
+	Window  *MaintenanceWindow `json:"window,omitempty"`
+	Reason  string             `json:"reason,omitempty"`
+}
+
+// RebootStatus reboot request status.
+type RebootStatus struct {
+	MessageType string     `json:"messageType"`
+	RequestID   string     `json:"requestId"`
+	NodeID      string     `json:"nodeId,omitempty"`
+	Status      string     `json:"status"`
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+	ErrorInfo   *ErrorInfo `json:"errorInfo,omitempty"`
+}
+
+// MaintenanceMode request to enter or exit maintenance mode.
+type MaintenanceMode struct {
+	MessageType string             `json:"messageType"`
+	RequestID   string             `json:"requestId"`
+	Enabled     bool               `json:"enabled"`
+	NodeIDs     []string           `json:"nodeIds,omitempty"`
+	Window      *MaintenanceWindow `json:"window,omitempty"`
+	Reason      string             `json:"reason,omitempty"`
+}
+
+// MaintenanceModeStatus maintenance mode request status.
+type MaintenanceModeStatus struct {
+	MessageType string     `json:"messageType"`
+	RequestID   string     `json:"requestId"`
+	NodeID      string     `json:"nodeId,omitempty"`
+	Enabled     bool       `json:"enabled"`
+	Status      string     `json:"status"`
+	ErrorInfo   *ErrorInfo `json:"errorInfo,omitempty"`
+}
@@ -18,6 +18,8 @@
 package cloudprotocol
 
 import (
+	"time"
+
 	"github.com/aosedge/aos_common/aostypes"
 )
 
@@ -25,8 +27,11 @@ import (
  * Consts
  **********************************************************************************************************************/
 
-// MonitoringMessageType monitoring message type.
-const MonitoringMessageType = "monitoringData"
+// Monitoring message types.
+const (
+	MonitoringMessageType           = "monitoringData"
+	TimeSeriesMonitoringMessageType = "timeSeriesMonitoringData"
+)
 
 /***********************************************************************************************************************
  * Types
@@ -51,3 +56,161 @@ type Monitoring struct {
 	Nodes            []NodeMonitoringData     `json:"nodes"`
 	ServiceInstances []InstanceMonitoringData `json:"serviceInstances"`
 }
+
+// PartitionUsageSeries partition usage samples.
+type PartitionUsageSeries struct {
+	Name     string   `json:"name"`
+	UsedSize []uint64 `json:"usedSize"`
+}
+
+// MonitoringSeries monitoring samples stored as columnar arrays: value with index i belongs to timestamp with index i.
+type MonitoringSeries struct {
+	Timestamps []time.Time            `json:"timestamps"`
+	RAM        []uint64               `json:"ram"`
+	CPU        []uint64               `json:"cpu"`
+	Download   []uint64               `json:"download"`
+	Upload     []uint64               `json:"upload"`
+	Partitions []PartitionUsageSeries `json:"partitions,omitempty"`
+
+	FileDescriptors []uint64 `json:"fileDescriptors,omitempty"`
+	PIDs            []uint64 `json:"pids,omitempty"`
+	Entropy         []uint64 `json:"entropy,omitempty"`
+}
+
+// NodeMonitoringSeries node monitoring samples.
+type NodeMonitoringSeries struct {
+	NodeID string `json:"nodeId"`
+	MonitoringSeries
+}
+
+// InstanceMonitoringSeries instance monitoring samples.
+type InstanceMonitoringSeries struct {
+	aostypes.InstanceIdent
+	NodeID string `json:"nodeId"`
+	MonitoringSeries
+	CPULimit        []uint64 `json:"cpuLimit,omitempty"`
+	CPULimitPercent []uint64 `json:"cpuLimitPercent,omitempty"`
+}
+
+// TimeSeriesMonitoring time-series monitoring message structure.
+type TimeSeriesMonitoring struct {
+	MessageType      string                     `json:"messageType"`
+	Nodes            []NodeMonitoringSeries     `json:"nodes"`
+	ServiceInstances []InstanceMonitoringSeries `json:"serviceInstances"`
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Len returns number of samples in series.
+func (series *MonitoringSeries) Len() int {
+	return len(series.Timestamps)
+}
+
+// Append appends monitoring sample to series.
+func (series *MonitoringSeries) Append(data aostypes.MonitoringData) {
+	count := series.Len()
+
+	series.Timestamps = append(series.Timestamps, data.Timestamp)
+	series.RAM = append(series.RAM, data.RAM)
+	series.CPU = append(series.CPU, data.CPU)
+	series.Download = append(series.Download, data.Download)
+	series.Upload = append(series.Upload, data.Upload)
+	series.FileDescriptors = append(series.FileDescriptors, data.FileDescriptors)
+	series.PIDs = append(series.PIDs, data.PIDs)
+	series.Entropy = append(series.Entropy, data.Entropy)
+
+	for _, partition := range data.Partitions {
+		index := series.partitionIndex(partition.Name)
+		if index < 0 {
+			// Partition appeared later than first sample: fill previous samples with zero usage to keep columns aligned.
+			series.Partitions = append(series.Partitions, PartitionUsageSeries{
+				Name: partition.Name, UsedSize: make([]uint64, count, count+1),
+			})
+			index = len(series.Partitions) - 1
+		}
+
+		if len(series.Partitions[index].UsedSize) == count {
+			series.Partitions[index].UsedSize = append(series.Partitions[index].UsedSize, partition.UsedSize)
+		}
+	}
+
+	for i := range series.Partitions {
+		if len(series.Partitions[i].UsedSize) == count {
+			series.Partitions[i].UsedSize = append(series.Partitions[i].UsedSize, 0)
+		}
+	}
+}
+
+// Samples converts series back to list of monitoring data.
+func (series *MonitoringSeries) Samples() []aostypes.MonitoringData {
+	samples := make([]aostypes.MonitoringData, series.Len())
+
+	for i := range samples {
+		samples[i] = aostypes.MonitoringData{
+			Timestamp: series.Timestamps[i],
+			RAM:       getSeriesValue(series.RAM, i),
+			CPU:       getSeriesValue(series.CPU, i),
+			Download:  getSeriesValue(series.Download, i),
+			Upload:    getSeriesValue(series.Upload, i),
+
+			FileDescriptors: getSeriesValue(series.FileDescriptors, i),
+			PIDs:            getSeriesValue(series.PIDs, i),
+			Entropy:         getSeriesValue(series.Entropy, i),
+		}
+
+		for _, partition := range series.Partitions {
+			samples[i].Partitions = append(samples[i].Partitions, aostypes.PartitionUsage{
+				Name: partition.Name, UsedSize: getSeriesValue(partition.UsedSize, i),
+			})
+		}
+	}
+
+	return samples
+}
+
+// AppendInstance appends instance monitoring sample to series.
+func (series *InstanceMonitoringSeries) AppendInstance(data aostypes.InstanceMonitoring) {
+	series.MonitoringSeries.Append(data.MonitoringData)
+	series.CPULimit = append(series.CPULimit, data.CPULimit)
+	series.CPULimitPercent = append(series.CPULimitPercent, data.CPULimitPercent)
+}
+
+// InstanceSamples converts series back to list of instance monitoring data.
+func (series *InstanceMonitoringSeries) InstanceSamples() []aostypes.InstanceMonitoring {
+	samples := make([]aostypes.InstanceMonitoring, series.Len())
+
+	for i, data := range series.Samples() {
+		samples[i] = aostypes.InstanceMonitoring{
+			InstanceIdent:   series.InstanceIdent,
+			MonitoringData:  data,
+			CPULimit:        getSeriesValue(series.CPULimit, i),
+			CPULimitPercent: getSeriesValue(series.CPULimitPercent, i),
+		}
+	}
+
+	return samples
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (series *MonitoringSeries) partitionIndex(name string) int {
+	for i, partition := range series.Partitions {
+		if partition.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func getSeriesValue(values []uint64, index int) uint64 {
+	if index >= len(values) {
+		return 0
+	}
+
+	return values[index]
+}
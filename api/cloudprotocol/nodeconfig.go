@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import "fmt"
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// NodeConfigStatusMessageType node config status message type.
+const NodeConfigStatusMessageType = "nodeConfigStatus"
+
+// Node config statuses.
+const (
+	NodeConfigStatusAccepted = "accepted"
+	NodeConfigStatusRejected = "rejected"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// ConfigFieldError node config field validation error.
+type ConfigFieldError struct {
+	// Field path to invalid field, e.g. alertRules.cpu.maxThreshold.
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// NodeConfigStatus result of applying node config on the node.
+type NodeConfigStatus struct {
+	MessageType string `json:"messageType"`
+	NodeID      string `json:"nodeId"`
+	NodeType    string `json:"nodeType,omitempty"`
+	// Version unit config version the status is reported for.
+	Version string `json:"version"`
+	// AppliedVersion unit config version which is currently in effect on the node.
+	AppliedVersion string             `json:"appliedVersion,omitempty"`
+	Status         string             `json:"status"`
+	FieldErrors    []ConfigFieldError `json:"fieldErrors,omitempty"`
+	ErrorInfo      *ErrorInfo         `json:"errorInfo,omitempty"`
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewConfigFieldError creates node config field error.
+func NewConfigFieldError(field string, format string, args ...interface{}) *ConfigFieldError {
+	return &ConfigFieldError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// Error returns field error message.
+func (err *ConfigFieldError) Error() string {
+	return fmt.Sprintf("invalid field %s: %s", err.Field, err.Message)
+}
+
+// NewNodeConfigAccepted creates status of successfully applied node config.
+func NewNodeConfigAccepted(nodeID, nodeType, version string) NodeConfigStatus {
+	return NodeConfigStatus{
+		MessageType:    NodeConfigStatusMessageType,
+		NodeID:         nodeID,
+		NodeType:       nodeType,
+		Version:        version,
+		AppliedVersion: version,
+		Status:         NodeConfigStatusAccepted,
+	}
+}
+
+// NewNodeConfigRejected creates status of rejected node config. Field errors are collected from the error tree,
+// appliedVersion is the version which remains in effect on the node.
+func NewNodeConfigRejected(nodeID, nodeType, version, appliedVersion string, err error) NodeConfigStatus {
+	return NodeConfigStatus{
+		MessageType:    NodeConfigStatusMessageType,
+		NodeID:         nodeID,
+		NodeType:       nodeType,
+		Version:        version,
+		AppliedVersion: appliedVersion,
+		Status:         NodeConfigStatusRejected,
+		FieldErrors:    collectFieldErrors(err, nil),
+		ErrorInfo:      NewErrorInfo(err),
+	}
+}
+
+// IsApplied returns true if requested node config version took effect on the node.
+func (status NodeConfigStatus) IsApplied() bool {
+	return status.Status == NodeConfigStatusAccepted && status.AppliedVersion == status.Version
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func collectFieldErrors(err error, fieldErrors []ConfigFieldError) []ConfigFieldError {
+	if err == nil {
+		return fieldErrors
+	}
+
+	switch unwrapErr := err.(type) { //nolint:errorlint // walk whole error tree
+	case *ConfigFieldError:
+		return append(fieldErrors, *unwrapErr)
+
+	case interface{ Unwrap() []error }:
+		for _, childErr := range unwrapErr.Unwrap() {
+			fieldErrors = collectFieldErrors(childErr, fieldErrors)
+		}
+
+	case interface{ Unwrap() error }:
+		fieldErrors = collectFieldErrors(unwrapErr.Unwrap(), fieldErrors)
+	}
+
+	return fieldErrors
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import (
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// GetActiveSecret returns node secret with specified name active at specified time.
+//
+// Secret is active if its valid from time is reached and it is not superseded by another active secret. If several
+// secrets fit, the one with the highest version is selected.
+func (secrets UnitSecrets) GetActiveSecret(nodeID, name string, now time.Time) (UnitSecret, error) {
+	candidates := make([]UnitSecret, 0, len(secrets.Secrets[nodeID]))
+
+	for _, secret := range secrets.Secrets[nodeID] {
+		if secret.Name == name && (secret.ValidFrom == nil || !secret.ValidFrom.After(now)) {
+			candidates = append(candidates, secret)
+		}
+	}
+
+	var (
+		activeSecret UnitSecret
+		found        bool
+	)
+
+	for _, secret := range candidates {
+		if isSecretSuperseded(secret, candidates) {
+			continue
+		}
+
+		if !found || secret.Version > activeSecret.Version {
+			activeSecret = secret
+			found = true
+		}
+	}
+
+	if !found {
+		return UnitSecret{}, aoserrors.Errorf("no active secret %s for node %s", name, nodeID)
+	}
+
+	return activeSecret, nil
+}
+
+// GetNodePassword returns active certificate password for node.
+func (secrets UnitSecrets) GetNodePassword(nodeID string, now time.Time) (string, error) {
+	if secrets.Version == UnitSecretVersionV3 || len(secrets.Secrets) != 0 {
+		secret, err := secrets.GetActiveSecret(nodeID, UnitSecretNamePassword, now)
+		if err != nil {
+			return "", err
+		}
+
+		return secret.Secret, nil
+	}
+
+	password, ok := secrets.Nodes[nodeID]
+	if !ok {
+		return "", aoserrors.Errorf("no password for node %s", nodeID)
+	}
+
+	return password, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func isSecretSuperseded(secret UnitSecret, candidates []UnitSecret) bool {
+	for _, candidate := range candidates {
+		if candidate.Supersedes != 0 && candidate.Supersedes == secret.Version && candidate.Version != secret.Version {
+			return true
+		}
+	}
+
+	return false
+}
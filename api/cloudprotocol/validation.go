@@ -0,0 +1,475 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprotocol
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	minDayOfWeek = 1
+	maxDayOfWeek = 7
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Validator validates incoming cloud message.
+type Validator interface {
+	Validate() error
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Validate validates desired status message.
+func (desiredStatus DesiredStatus) Validate() error {
+	if err := validateMessageType(desiredStatus.MessageType, DesiredStatusMessageType); err != nil {
+		return err
+	}
+
+	if desiredStatus.UnitConfig != nil {
+		if err := desiredStatus.UnitConfig.validate("unitConfig"); err != nil {
+			return err
+		}
+	}
+
+	for i, node := range desiredStatus.Nodes {
+		path := fmt.Sprintf("nodes[%d]", i)
+
+		if err := requireField(path+".nodeId", node.NodeID); err != nil {
+			return err
+		}
+
+		if err := requireField(path+".status", node.Status); err != nil {
+			return err
+		}
+	}
+
+	for i, component := range desiredStatus.Components {
+		if err := component.validate(fmt.Sprintf("components[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	for i, layer := range desiredStatus.Layers {
+		if err := layer.validate(fmt.Sprintf("layers[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	for i, service := range desiredStatus.Services {
+		if err := service.validate(fmt.Sprintf("services[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	for i, instance := range desiredStatus.Instances {
+		path := fmt.Sprintf("instances[%d]", i)
+
+		if err := requireField(path+".serviceId", instance.ServiceID); err != nil {
+			return err
+		}
+
+		if err := requireField(path+".subjectId", instance.SubjectID); err != nil {
+			return err
+		}
+	}
+
+	if err := desiredStatus.FOTASchedule.validate("fotaSchedule"); err != nil {
+		return err
+	}
+
+	if err := desiredStatus.SOTASchedule.validate("sotaSchedule"); err != nil {
+		return err
+	}
+
+	for i, certificate := range desiredStatus.Certificates {
+		if err := requireField(fmt.Sprintf("certificates[%d].fingerprint", i), certificate.Fingerprint); err != nil {
+			return err
+		}
+	}
+
+	for i, chain := range desiredStatus.CertificateChains {
+		if err := requireField(fmt.Sprintf("certificateChains[%d].name", i), chain.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate validates service info.
+func (service ServiceInfo) Validate() error {
+	return service.validate(ArtifactTypeService)
+}
+
+// Validate validates layer info.
+func (layer LayerInfo) Validate() error {
+	return layer.validate(ArtifactTypeLayer)
+}
+
+// Validate validates component info.
+func (component ComponentInfo) Validate() error {
+	return component.validate(ArtifactTypeComponent)
+}
+
+// Validate validates override env vars message.
+func (envVars OverrideEnvVars) Validate() error {
+	if err := validateMessageType(envVars.MessageType, OverrideEnvVarsMessageType); err != nil {
+		return err
+	}
+
+	for i, item := range envVars.Items {
+		for j, variable := range item.Variables {
+			path := fmt.Sprintf("items[%d].variables[%d].name", i, j)
+
+			if err := requireField(path, variable.Name); err != nil {
+				return err
+			}
+
+			if strings.ContainsAny(variable.Name, "= \t\n") {
+				return fieldError(path, "contains invalid characters")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates request log message.
+func (request RequestLog) Validate() error {
+	if err := validateMessageType(request.MessageType, RequestLogMessageType); err != nil {
+		return err
+	}
+
+	if err := requireField("logId", request.LogID); err != nil {
+		return err
+	}
+
+	switch request.LogType {
+	case SystemLog, ServiceLog, CrashLog:
+
+	default:
+		return fieldError("logType", fmt.Sprintf("unsupported value %q", request.LogType))
+	}
+
+	if request.Filter.From != nil && request.Filter.Till != nil && request.Filter.Till.Before(*request.Filter.From) {
+		return fieldError("filter.till", "is before filter.from")
+	}
+
+	if request.Filter.UploadOptions != nil {
+		if err := requireField("filter.uploadOptions.url", request.Filter.UploadOptions.URL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate validates renew certificates notification message.
+func (notification RenewCertsNotification) Validate() error {
+	if err := validateMessageType(notification.MessageType, RenewCertsNotificationMessageType); err != nil {
+		return err
+	}
+
+	for i, certificate := range notification.Certificates {
+		path := fmt.Sprintf("certificates[%d]", i)
+
+		if err := requireField(path+".type", certificate.Type); err != nil {
+			return err
+		}
+
+		if err := requireField(path+".serial", certificate.Serial); err != nil {
+			return err
+		}
+	}
+
+	return notification.UnitSecrets.validate("unitSecrets")
+}
+
+// Validate validates reboot request message.
+func (request RebootRequest) Validate() error {
+	if err := validateMessageType(request.MessageType, RebootRequestMessageType); err != nil {
+		return err
+	}
+
+	if err := requireField("requestId", request.RequestID); err != nil {
+		return err
+	}
+
+	return request.Window.validate("window")
+}
+
+// Validate validates maintenance mode message.
+func (request MaintenanceMode) Validate() error {
+	if err := validateMessageType(request.MessageType, MaintenanceModeMessageType); err != nil {
+		return err
+	}
+
+	if err := requireField("requestId", request.RequestID); err != nil {
+		return err
+	}
+
+	return request.Window.validate("window")
+}
+
+// Validate validates file transfer request message.
+func (request FileTransferRequest) Validate() error {
+	if err := validateMessageType(request.MessageType, FileTransferRequestMessageType); err != nil {
+		return err
+	}
+
+	if err := requireField("transferId", request.TransferID); err != nil {
+		return err
+	}
+
+	switch request.Direction {
+	case FileTransferUpload, FileTransferDownload:
+
+	default:
+		return fieldError("direction", fmt.Sprintf("unsupported value %q", request.Direction))
+	}
+
+	if request.ChunkSize == 0 {
+		return fieldError("chunkSize", "is required")
+	}
+
+	if request.Size != 0 && request.Offset > request.Size {
+		return fieldError("offset", "exceeds size")
+	}
+
+	return nil
+}
+
+// Validate validates alerts acknowledgement message.
+func (ack AlertsAck) Validate() error {
+	return validateMessageType(ack.MessageType, AlertsAckMessageType)
+}
+
+// Validate validates alerts verbosity message.
+func (verbosity AlertsVerbosity) Validate() error {
+	if err := validateMessageType(verbosity.MessageType, AlertsVerbosityMessageType); err != nil {
+		return err
+	}
+
+	for i, tag := range verbosity.MutedTags {
+		if err := requireField(fmt.Sprintf("mutedTags[%d]", i), tag); err != nil {
+			return err
+		}
+	}
+
+	switch verbosity.MinSeverity {
+	case "", AlertSeverityInfo, AlertSeverityWarning, AlertSeverityError, AlertSeverityCritical:
+
+	default:
+		return fieldError("minSeverity", fmt.Sprintf("unsupported value %q", verbosity.MinSeverity))
+	}
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func fieldError(path, reason string) error {
+	return aoserrors.Errorf("invalid field %s: %s", path, reason)
+}
+
+func requireField(path, value string) error {
+	if value == "" {
+		return fieldError(path, "is required")
+	}
+
+	return nil
+}
+
+func validateMessageType(messageType, expectedType string) error {
+	if messageType != "" && messageType != expectedType {
+		return fieldError("messageType", fmt.Sprintf("expected %q, got %q", expectedType, messageType))
+	}
+
+	return nil
+}
+
+func (unitConfig UnitConfig) validate(path string) error {
+	if err := requireField(path+".version", unitConfig.Version); err != nil {
+		return err
+	}
+
+	for i, node := range unitConfig.Nodes {
+		if err := requireField(fmt.Sprintf("%s.nodes[%d].nodeType", path, i), node.NodeType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (secrets UnitSecrets) validate(path string) error {
+	if len(secrets.Nodes) == 0 && len(secrets.Secrets) == 0 {
+		return nil
+	}
+
+	switch secrets.Version {
+	case UnitSecretVersion:
+		if len(secrets.Secrets) != 0 {
+			return fieldError(path+".secrets", fmt.Sprintf("not supported by version %q", secrets.Version))
+		}
+
+	case UnitSecretVersionV3:
+
+	default:
+		return fieldError(path+".version", fmt.Sprintf("unsupported value %q", secrets.Version))
+	}
+
+	for nodeID, nodeSecrets := range secrets.Secrets {
+		for i, secret := range nodeSecrets {
+			secretPath := fmt.Sprintf("%s.secrets[%s][%d]", path, nodeID, i)
+
+			if err := requireField(secretPath+".name", secret.Name); err != nil {
+				return err
+			}
+
+			if secret.Version == 0 {
+				return fieldError(secretPath+".version", "is required")
+			}
+
+			if secret.Supersedes >= secret.Version {
+				return fieldError(secretPath+".supersedes", "is not lower than version")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (artifactInfo ArtifactInfo) validate(path string) error {
+	return artifactInfo.DownloadInfo.validate(path)
+}
+
+func (downloadInfo DownloadInfo) validate(path string) error {
+	if len(downloadInfo.URLs) == 0 {
+		return fieldError(path+".urls", "is required")
+	}
+
+	for i, url := range downloadInfo.URLs {
+		if err := requireField(fmt.Sprintf("%s.urls[%d]", path, i), url); err != nil {
+			return err
+		}
+	}
+
+	if len(downloadInfo.Sha256) != sha256.Size {
+		return fieldError(path+".sha256", fmt.Sprintf("wrong length %d", len(downloadInfo.Sha256)))
+	}
+
+	return nil
+}
+
+func (component ComponentInfo) validate(path string) error {
+	if err := requireField(path+".type", component.ComponentType); err != nil {
+		return err
+	}
+
+	if err := requireField(path+".version", component.Version); err != nil {
+		return err
+	}
+
+	return component.ArtifactInfo.validate(path)
+}
+
+func (layer LayerInfo) validate(path string) error {
+	if err := requireField(path+".id", layer.LayerID); err != nil {
+		return err
+	}
+
+	if err := requireField(path+".digest", layer.Digest); err != nil {
+		return err
+	}
+
+	if err := requireField(path+".version", layer.Version); err != nil {
+		return err
+	}
+
+	return layer.ArtifactInfo.validate(path)
+}
+
+func (service ServiceInfo) validate(path string) error {
+	if err := requireField(path+".id", service.ServiceID); err != nil {
+		return err
+	}
+
+	if err := requireField(path+".version", service.Version); err != nil {
+		return err
+	}
+
+	return service.ArtifactInfo.validate(path)
+}
+
+func (schedule ScheduleRule) validate(path string) error {
+	switch schedule.Type {
+	case "", ForceUpdate, TriggerUpdate:
+
+	case TimetableUpdate:
+		if len(schedule.Timetable) == 0 {
+			return fieldError(path+".timetable", "is required")
+		}
+
+	default:
+		return fieldError(path+".type", fmt.Sprintf("unsupported value %q", schedule.Type))
+	}
+
+	for i, entry := range schedule.Timetable {
+		entryPath := fmt.Sprintf("%s.timetable[%d]", path, i)
+
+		if entry.DayOfWeek < minDayOfWeek || entry.DayOfWeek > maxDayOfWeek {
+			return fieldError(entryPath+".dayOfWeek", fmt.Sprintf("out of range %d", entry.DayOfWeek))
+		}
+
+		for j, slot := range entry.TimeSlots {
+			if !slot.End.After(slot.Start.Time) {
+				return fieldError(fmt.Sprintf("%s.timeSlots[%d].end", entryPath, j), "is not after start")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (window *MaintenanceWindow) validate(path string) error {
+	if window == nil || window.Start == nil || window.End == nil {
+		return nil
+	}
+
+	if !window.End.After(*window.Start) {
+		return fieldError(path+".end", "is not after start")
+	}
+
+	return nil
+}
@@ -0,0 +1,743 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package downloader provides HTTP/HTTPS file downloader with resume, parallel segments, streaming checksum
+// verification, bandwidth limit and download progress alerts.
+package downloader
+
+import (
+	"context"
+	_ "crypto/sha256" // register sha256 digest algorithm
+	_ "crypto/sha512" // register sha512 digest algorithm
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/utils/contextreader"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	defaultProgressInterval = 10 * time.Second
+	defaultMinSegmentSize   = 1024 * 1024
+	stateFileSuffix         = ".segments"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Config downloader configuration.
+type Config struct {
+	// MaxBandwidth max total download bandwidth in bytes per second shared by all downloads. Zero means unlimited.
+	MaxBandwidth int64
+	// Segments max number of parallel segments per download. Segments are used only if server supports ranges.
+	Segments int
+	// MinSegmentSize min segment size in bytes.
+	MinSegmentSize int64
+	// ProgressInterval download progress alerts interval.
+	ProgressInterval time.Duration
+	// Client HTTP client used for downloads. If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Request download request.
+type Request struct {
+	URL string
+	// FileName destination file. Existing partial file is resumed.
+	FileName string
+	// Size expected file size. Zero means size is unknown.
+	Size int64
+	// Digests expected file digests.
+	Digests []digest.Digest
+	// TargetType, TargetID and Version are reported in download alerts.
+	TargetType string
+	TargetID   string
+	Version    string
+}
+
+// ProgressFunc download progress alert callback.
+type ProgressFunc func(alert cloudprotocol.DownloadAlert)
+
+// Downloader downloads files over HTTP/HTTPS.
+type Downloader struct {
+	config   Config
+	client   *http.Client
+	limiter  *rateLimiter
+	progress ProgressFunc
+}
+
+type download struct {
+	request    Request
+	total      atomic.Int64
+	downloaded atomic.Int64
+	segments   []*segment
+
+	stateMutex sync.Mutex
+	stateDone  bool
+}
+
+type segment struct {
+	start  int64
+	end    int64
+	offset atomic.Int64
+}
+
+type segmentState struct {
+	Start  int64 `json:"start"`
+	End    int64 `json:"end"`
+	Offset int64 `json:"offset"`
+}
+
+type downloadState struct {
+	URL      string         `json:"url"`
+	Size     int64          `json:"size"`
+	Segments []segmentState `json:"segments"`
+}
+
+type countingWriter struct {
+	writer  io.Writer
+	counter func(count int64)
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// New creates downloader.
+func New(config Config, progress ProgressFunc) (downloader *Downloader) {
+	downloader = &Downloader{config: config, client: config.Client, progress: progress}
+
+	if downloader.client == nil {
+		downloader.client = http.DefaultClient
+	}
+
+	if downloader.config.Segments <= 0 {
+		downloader.config.Segments = 1
+	}
+
+	if downloader.config.MinSegmentSize <= 0 {
+		downloader.config.MinSegmentSize = defaultMinSegmentSize
+	}
+
+	if downloader.config.ProgressInterval <= 0 {
+		downloader.config.ProgressInterval = defaultProgressInterval
+	}
+
+	if config.MaxBandwidth > 0 {
+		downloader.limiter = newRateLimiter(config.MaxBandwidth)
+	}
+
+	return downloader
+}
+
+// Download downloads file. If download is interrupted, partial file is kept and the next download of the same
+// request resumes it. File is removed if its size or digests mismatch.
+func (downloader *Downloader) Download(ctx context.Context, request Request) (size int64, err error) {
+	log.WithFields(log.Fields{"url": request.URL, "file": request.FileName}).Debug("Start downloading file")
+
+	for _, requestDigest := range request.Digests {
+		if err = requestDigest.Validate(); err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+	}
+
+	current := &download{request: request}
+
+	current.total.Store(request.Size)
+
+	remoteSize, acceptRanges := downloader.probe(ctx, request.URL)
+
+	if remoteSize > 0 {
+		if request.Size > 0 && remoteSize != request.Size {
+			return 0, aoserrors.Errorf("remote file size %d mismatch: %w", remoteSize, aoserrors.ErrInvalidChecksum)
+		}
+
+		current.total.Store(remoteSize)
+	}
+
+	segmented := acceptRanges && current.total.Load() > 0 && downloader.config.Segments > 1
+	resumed := false
+
+	if segmented {
+		if resumed = downloader.loadState(current); !resumed {
+			downloader.createSegments(current)
+		}
+	}
+
+	downloader.sendProgress(current, "download started")
+
+	stopProgress := downloader.startProgress(current)
+
+	if segmented {
+		err = downloader.downloadSegments(ctx, current, resumed)
+	} else {
+		err = downloader.downloadSequential(ctx, current)
+	}
+
+	stopProgress()
+
+	if err != nil {
+		if errors.Is(err, aoserrors.ErrInvalidChecksum) {
+			removeFiles(request.FileName)
+		}
+
+		downloader.sendProgress(current, "download interrupted: "+err.Error())
+
+		return current.downloaded.Load(), err
+	}
+
+	downloader.sendProgress(current, "download finished")
+
+	log.WithFields(log.Fields{"file": request.FileName, "size": current.downloaded.Load()}).Debug("Download complete")
+
+	return current.downloaded.Load(), nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (downloader *Downloader) probe(ctx context.Context, url string) (size int64, acceptRanges bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, false
+	}
+
+	resp, err := downloader.client.Do(req)
+	if err != nil {
+		log.WithField("url", url).Warnf("Can't probe download: %v", err)
+
+		return 0, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, false
+	}
+
+	return max(resp.ContentLength, 0), resp.Header.Get("Accept-Ranges") == "bytes"
+}
+
+func (downloader *Downloader) downloadSequential(ctx context.Context, current *download) (err error) {
+	file, err := os.OpenFile(current.request.FileName, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	// Sequential download after interrupted segmented one can't be resumed.
+	if _, statErr := os.Stat(stateFileName(current.request.FileName)); statErr == nil {
+		if err = restartFile(file, current.request.FileName); err != nil {
+			return err
+		}
+	}
+
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	total := current.total.Load()
+
+	if total > 0 && offset > total {
+		if err = restartFile(file, current.request.FileName); err != nil {
+			return err
+		}
+
+		offset = 0
+	}
+
+	hashes, err := downloader.hashExisting(ctx, current.request, offset)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 && offset == total {
+		current.downloaded.Store(offset)
+
+		return verifyHashes(current.request.Digests, hashes)
+	}
+
+	resp, err := downloader.get(ctx, current.request.URL, offset, -1)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if offset > 0 && resp.StatusCode == http.StatusOK {
+		log.WithField("url", current.request.URL).Warn("Server doesn't support resume, restart download")
+
+		if err = restartFile(file, current.request.FileName); err != nil {
+			return err
+		}
+
+		offset = 0
+
+		if hashes, err = downloader.hashExisting(ctx, current.request, 0); err != nil {
+			return err
+		}
+	}
+
+	if total <= 0 && resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+
+		current.total.Store(total)
+	}
+
+	current.downloaded.Store(offset)
+
+	writers := []io.Writer{file}
+
+	for _, fileHash := range hashes {
+		writers = append(writers, fileHash)
+	}
+
+	if _, err = io.Copy(&countingWriter{writer: io.MultiWriter(writers...), counter: func(count int64) {
+		current.downloaded.Add(count)
+	}}, downloader.bodyReader(ctx, resp.Body)); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if total > 0 && current.downloaded.Load() != total {
+		return aoserrors.Errorf("downloaded size %d mismatch expected %d: %w",
+			current.downloaded.Load(), total, aoserrors.ErrInvalidChecksum)
+	}
+
+	return verifyHashes(current.request.Digests, hashes)
+}
+
+func (downloader *Downloader) downloadSegments(ctx context.Context, current *download, resumed bool) (err error) {
+	file, err := os.OpenFile(current.request.FileName, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	if !resumed {
+		if err = file.Truncate(0); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	if err = file.Truncate(current.total.Load()); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = saveState(current); err != nil {
+		return err
+	}
+
+	segmentCtx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	for _, fileSegment := range current.segments {
+		if fileSegment.offset.Load() >= fileSegment.end {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if segmentErr := downloader.downloadSegment(segmentCtx, current, file, fileSegment); segmentErr != nil {
+				errOnce.Do(func() {
+					firstErr = segmentErr
+
+					cancelFunc()
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		if stateErr := saveState(current); stateErr != nil {
+			log.Errorf("Can't save download state: %v", stateErr)
+		}
+
+		return firstErr
+	}
+
+	if err = file.Sync(); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = removeState(current); err != nil {
+		return err
+	}
+
+	hashes, err := downloader.hashExisting(ctx, current.request, current.total.Load())
+	if err != nil {
+		return err
+	}
+
+	return verifyHashes(current.request.Digests, hashes)
+}
+
+func (downloader *Downloader) downloadSegment(
+	ctx context.Context, current *download, file *os.File, fileSegment *segment,
+) error {
+	offset := fileSegment.offset.Load()
+
+	resp, err := downloader.get(ctx, current.request.URL, offset, fileSegment.end-1)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return aoserrors.Errorf("unexpected segment response status: %s", resp.Status)
+	}
+
+	if _, err = io.Copy(&countingWriter{writer: io.NewOffsetWriter(file, offset), counter: func(count int64) {
+		fileSegment.offset.Add(count)
+		current.downloaded.Add(count)
+	}}, io.LimitReader(downloader.bodyReader(ctx, resp.Body), fileSegment.end-offset)); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if fileSegment.offset.Load() != fileSegment.end {
+		return aoserrors.Errorf("segment %d-%d is incomplete", fileSegment.start, fileSegment.end)
+	}
+
+	return nil
+}
+
+func (downloader *Downloader) get(ctx context.Context, url string, from, to int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	switch {
+	case to >= 0:
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", from, to))
+
+	case from > 0:
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", from))
+	}
+
+	resp, err := downloader.client.Do(req)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+
+		return nil, aoserrors.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return resp, nil
+}
+
+func (downloader *Downloader) bodyReader(ctx context.Context, body io.Reader) io.Reader {
+	reader := contextreader.New(ctx, body)
+
+	if downloader.limiter != nil {
+		reader = &throttledReader{ctx: ctx, reader: reader, limiter: downloader.limiter}
+	}
+
+	return reader
+}
+
+func (downloader *Downloader) createSegments(current *download) {
+	total := current.total.Load()
+	count := min(int64(downloader.config.Segments), max(total/downloader.config.MinSegmentSize, 1))
+	segmentSize := total / count
+
+	current.segments = make([]*segment, 0, count)
+
+	for i := range count {
+		fileSegment := &segment{start: i * segmentSize, end: (i + 1) * segmentSize}
+
+		if i == count-1 {
+			fileSegment.end = total
+		}
+
+		fileSegment.offset.Store(fileSegment.start)
+
+		current.segments = append(current.segments, fileSegment)
+	}
+}
+
+func (downloader *Downloader) loadState(current *download) bool {
+	data, err := os.ReadFile(stateFileName(current.request.FileName))
+	if err != nil {
+		return false
+	}
+
+	var state downloadState
+
+	if err = json.Unmarshal(data, &state); err != nil {
+		log.Warnf("Can't parse download state: %v", err)
+
+		return false
+	}
+
+	if state.URL != current.request.URL || state.Size != current.total.Load() {
+		return false
+	}
+
+	if err = validateSegments(state.Segments, state.Size); err != nil {
+		log.Warnf("Invalid download state: %v", err)
+
+		return false
+	}
+
+	// Segmented download file is extended to the full size on start
+	if info, err := os.Stat(current.request.FileName); err != nil || info.Size() != state.Size {
+		return false
+	}
+
+	current.segments = make([]*segment, 0, len(state.Segments))
+
+	for _, segmentState := range state.Segments {
+		fileSegment := &segment{start: segmentState.Start, end: segmentState.End}
+
+		fileSegment.offset.Store(segmentState.Offset)
+		current.downloaded.Add(segmentState.Offset - segmentState.Start)
+
+		current.segments = append(current.segments, fileSegment)
+	}
+
+	log.WithFields(log.Fields{
+		"file": current.request.FileName, "downloaded": current.downloaded.Load(),
+	}).Debug("Resume segmented download")
+
+	return true
+}
+
+// validateSegments checks that segments are ordered, don't overlap and cover whole file.
+func validateSegments(segments []segmentState, size int64) error {
+	if len(segments) == 0 {
+		return aoserrors.New("no segments")
+	}
+
+	var expectedStart int64
+
+	for _, segment := range segments {
+		if segment.Start != expectedStart || segment.End <= segment.Start || segment.End > size {
+			return aoserrors.Errorf("wrong segment %d-%d", segment.Start, segment.End)
+		}
+
+		if segment.Offset < segment.Start || segment.Offset > segment.End {
+			return aoserrors.Errorf("wrong segment %d-%d offset %d", segment.Start, segment.End, segment.Offset)
+		}
+
+		expectedStart = segment.End
+	}
+
+	if expectedStart != size {
+		return aoserrors.Errorf("segments don't cover file size %d", size)
+	}
+
+	return nil
+}
+
+func (downloader *Downloader) hashExisting(
+	ctx context.Context, request Request, size int64,
+) (hashes []hash.Hash, err error) {
+	for _, requestDigest := range request.Digests {
+		hashes = append(hashes, requestDigest.Algorithm().Hash())
+	}
+
+	if size == 0 || len(hashes) == 0 {
+		return hashes, nil
+	}
+
+	file, err := os.Open(request.FileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	writers := make([]io.Writer, 0, len(hashes))
+
+	for _, fileHash := range hashes {
+		writers = append(writers, fileHash)
+	}
+
+	if _, err = io.CopyN(io.MultiWriter(writers...), contextreader.New(ctx, file), size); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return hashes, nil
+}
+
+func (downloader *Downloader) startProgress(current *download) (stop func()) {
+	ticker := time.NewTicker(downloader.config.ProgressInterval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-ticker.C:
+				downloader.sendProgress(current, "download in progress")
+
+				if current.segments != nil {
+					if err := saveState(current); err != nil {
+						log.Errorf("Can't save download state: %v", err)
+					}
+				}
+
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+		<-stopped
+	}
+}
+
+func (downloader *Downloader) sendProgress(current *download, message string) {
+	log.WithFields(log.Fields{
+		"complete": current.downloaded.Load(), "total": current.total.Load(), "url": current.request.URL,
+	}).Debug("Download progress")
+
+	if downloader.progress == nil {
+		return
+	}
+
+	downloader.progress(cloudprotocol.DownloadAlert{
+		AlertItem: cloudprotocol.AlertItem{
+			Timestamp: time.Now(), Tag: cloudprotocol.AlertTagDownloadProgress,
+		},
+		TargetType:      current.request.TargetType,
+		TargetID:        current.request.TargetID,
+		Version:         current.request.Version,
+		Message:         message,
+		URL:             current.request.URL,
+		DownloadedBytes: strconv.FormatInt(current.downloaded.Load(), 10),
+		TotalBytes:      strconv.FormatInt(current.total.Load(), 10),
+	})
+}
+
+func saveState(current *download) error {
+	current.stateMutex.Lock()
+	defer current.stateMutex.Unlock()
+
+	if current.stateDone {
+		return nil
+	}
+
+	state := downloadState{URL: current.request.URL, Size: current.total.Load()}
+
+	for _, fileSegment := range current.segments {
+		state.Segments = append(state.Segments, segmentState{
+			Start: fileSegment.start, End: fileSegment.end, Offset: fileSegment.offset.Load(),
+		})
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = os.WriteFile(stateFileName(current.request.FileName), data, 0o600); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func removeState(current *download) error {
+	current.stateMutex.Lock()
+	defer current.stateMutex.Unlock()
+
+	current.stateDone = true
+
+	return aoserrors.Wrap(os.Remove(stateFileName(current.request.FileName)))
+}
+
+func verifyHashes(digests []digest.Digest, hashes []hash.Hash) error {
+	for i, expected := range digests {
+		if digest.NewDigest(expected.Algorithm(), hashes[i]) != expected {
+			return aoserrors.Errorf("%s digest mismatch: %w", expected.Algorithm(), aoserrors.ErrInvalidChecksum)
+		}
+	}
+
+	return nil
+}
+
+func restartFile(file *os.File, fileName string) error {
+	if err := file.Truncate(0); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err := os.Remove(stateFileName(fileName)); err != nil && !os.IsNotExist(err) {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func removeFiles(fileName string) {
+	for _, name := range []string{fileName, stateFileName(fileName)} {
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			log.Errorf("Can't remove download file: %v", err)
+		}
+	}
+}
+
+func stateFileName(fileName string) string {
+	return fileName + stateFileSuffix
+}
+
+func (writer *countingWriter) Write(data []byte) (count int, err error) {
+	count, err = writer.writer.Write(data)
+
+	writer.counter(int64(count))
+
+	return count, err //nolint:wrapcheck // io.Writer errors should not be wrapped
+}
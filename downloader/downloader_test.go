@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package downloader_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/downloader"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testServer struct {
+	*httptest.Server
+	sync.Mutex
+	content []byte
+	ranges  []string
+}
+
+/***********************************************************************************************************************
+ * Init
+ **********************************************************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestDownload(t *testing.T) {
+	server := newTestServer(t, 256*1024)
+	defer server.Close()
+
+	var alerts []cloudprotocol.DownloadAlert
+
+	fileName := filepath.Join(t.TempDir(), "file")
+
+	size, err := downloader.New(downloader.Config{}, func(alert cloudprotocol.DownloadAlert) {
+		alerts = append(alerts, alert)
+	}).Download(context.Background(), downloader.Request{
+		URL: server.URL, FileName: fileName, Size: int64(len(server.content)),
+		Digests:    []digest.Digest{digest.FromBytes(server.content)},
+		TargetType: cloudprotocol.DownloadTargetService, TargetID: "service1", Version: "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Download error: %v", err)
+	}
+
+	if size != int64(len(server.content)) {
+		t.Errorf("Wrong download size: %d", size)
+	}
+
+	checkFile(t, fileName, server.content)
+
+	if len(alerts) < 2 {
+		t.Fatalf("Wrong alerts count: %d", len(alerts))
+	}
+
+	lastAlert := alerts[len(alerts)-1]
+
+	if lastAlert.Tag != cloudprotocol.AlertTagDownloadProgress || lastAlert.TargetID != "service1" ||
+		lastAlert.DownloadedBytes != lastAlert.TotalBytes || lastAlert.URL != server.URL {
+		t.Errorf("Wrong last alert: %v", lastAlert)
+	}
+}
+
+func TestResume(t *testing.T) {
+	server := newTestServer(t, 256*1024)
+	defer server.Close()
+
+	fileName := filepath.Join(t.TempDir(), "file")
+
+	if err := os.WriteFile(fileName, server.content[:100000], 0o600); err != nil {
+		t.Fatalf("Can't write partial file: %v", err)
+	}
+
+	if _, err := downloader.New(downloader.Config{}, nil).Download(context.Background(), downloader.Request{
+		URL: server.URL, FileName: fileName, Digests: []digest.Digest{digest.FromBytes(server.content)},
+	}); err != nil {
+		t.Fatalf("Download error: %v", err)
+	}
+
+	checkFile(t, fileName, server.content)
+
+	if ranges := server.getRanges(); len(ranges) != 1 || ranges[0] != "bytes=100000-" {
+		t.Errorf("Wrong requested ranges: %v", ranges)
+	}
+}
+
+func TestSegmentedDownload(t *testing.T) {
+	server := newTestServer(t, 1024*1024+17)
+	defer server.Close()
+
+	fileName := filepath.Join(t.TempDir(), "file")
+
+	if _, err := downloader.New(downloader.Config{Segments: 4, MinSegmentSize: 64 * 1024}, nil).Download(
+		context.Background(), downloader.Request{
+			URL: server.URL, FileName: fileName, Digests: []digest.Digest{digest.FromBytes(server.content)},
+		}); err != nil {
+		t.Fatalf("Download error: %v", err)
+	}
+
+	checkFile(t, fileName, server.content)
+
+	if ranges := server.getRanges(); len(ranges) != 4 {
+		t.Errorf("Wrong requested ranges: %v", ranges)
+	}
+
+	if _, err := os.Stat(fileName + ".segments"); !os.IsNotExist(err) {
+		t.Error("Segments state file should be removed")
+	}
+}
+
+func TestSegmentedResume(t *testing.T) {
+	server := newTestServer(t, 4000)
+	defer server.Close()
+
+	fileName := filepath.Join(t.TempDir(), "file")
+
+	partial := make([]byte, len(server.content))
+
+	copy(partial[:1500], server.content[:1500])
+	copy(partial[2000:3000], server.content[2000:3000])
+
+	if err := os.WriteFile(fileName, partial, 0o600); err != nil {
+		t.Fatalf("Can't write partial file: %v", err)
+	}
+
+	state, err := json.Marshal(map[string]any{
+		"url": server.URL, "size": len(server.content), "segments": []map[string]int{
+			{"start": 0, "end": 2000, "offset": 1500},
+			{"start": 2000, "end": 4000, "offset": 3000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Can't marshal state: %v", err)
+	}
+
+	if err = os.WriteFile(fileName+".segments", state, 0o600); err != nil {
+		t.Fatalf("Can't write state file: %v", err)
+	}
+
+	if _, err := downloader.New(downloader.Config{Segments: 2, MinSegmentSize: 1000}, nil).Download(
+		context.Background(), downloader.Request{
+			URL: server.URL, FileName: fileName, Digests: []digest.Digest{digest.FromBytes(server.content)},
+		}); err != nil {
+		t.Fatalf("Download error: %v", err)
+	}
+
+	checkFile(t, fileName, server.content)
+
+	ranges := server.getRanges()
+
+	if len(ranges) != 2 || !strings.Contains(strings.Join(ranges, ","), "bytes=1500-1999") ||
+		!strings.Contains(strings.Join(ranges, ","), "bytes=3000-3999") {
+		t.Errorf("Wrong requested ranges: %v", ranges)
+	}
+}
+
+func TestSegmentedResumeInvalidState(t *testing.T) {
+	invalidSegments := [][]map[string]int{
+		{{"start": 0, "end": 2500, "offset": 1500}, {"start": 2000, "end": 4000, "offset": 3000}},
+		{{"start": 2000, "end": 4000, "offset": 3000}, {"start": 0, "end": 2000, "offset": 1500}},
+		{{"start": 0, "end": 2000, "offset": 2500}, {"start": 2000, "end": 4000, "offset": 3000}},
+		{{"start": 0, "end": 2000, "offset": 1500}, {"start": 2000, "end": 8000, "offset": 3000}},
+		{{"start": 0, "end": 2000, "offset": 1500}},
+	}
+
+	for i, segments := range invalidSegments {
+		server := newTestServer(t, 4000)
+		defer server.Close()
+
+		fileName := filepath.Join(t.TempDir(), "file")
+
+		if err := os.WriteFile(fileName, make([]byte, len(server.content)), 0o600); err != nil {
+			t.Fatalf("Can't write partial file: %v", err)
+		}
+
+		state, err := json.Marshal(map[string]any{
+			"url": server.URL, "size": len(server.content), "segments": segments,
+		})
+		if err != nil {
+			t.Fatalf("Can't marshal state: %v", err)
+		}
+
+		if err = os.WriteFile(fileName+".segments", state, 0o600); err != nil {
+			t.Fatalf("Can't write state file: %v", err)
+		}
+
+		if _, err := downloader.New(downloader.Config{Segments: 2, MinSegmentSize: 1000}, nil).Download(
+			context.Background(), downloader.Request{
+				URL: server.URL, FileName: fileName, Digests: []digest.Digest{digest.FromBytes(server.content)},
+			}); err != nil {
+			t.Fatalf("Download error: %v", err)
+		}
+
+		checkFile(t, fileName, server.content)
+
+		if ranges := server.getRanges(); !strings.Contains(strings.Join(ranges, ","), "bytes=0-1999") {
+			t.Errorf("Download should be restarted for state %d: %v", i, ranges)
+		}
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	server := newTestServer(t, 1024)
+	defer server.Close()
+
+	fileName := filepath.Join(t.TempDir(), "file")
+
+	_, err := downloader.New(downloader.Config{}, nil).Download(context.Background(), downloader.Request{
+		URL: server.URL, FileName: fileName, Digests: []digest.Digest{digest.FromString("wrong")},
+	})
+	if !errors.Is(err, aoserrors.ErrInvalidChecksum) {
+		t.Fatalf("Wrong download error: %v", err)
+	}
+
+	if _, err := os.Stat(fileName); !os.IsNotExist(err) {
+		t.Error("File should be removed on checksum mismatch")
+	}
+}
+
+func TestBandwidthLimit(t *testing.T) {
+	server := newTestServer(t, 250*1024)
+	defer server.Close()
+
+	start := time.Now()
+
+	if _, err := downloader.New(downloader.Config{MaxBandwidth: 100 * 1024}, nil).Download(
+		context.Background(), downloader.Request{URL: server.URL, FileName: filepath.Join(t.TempDir(), "file")},
+	); err != nil {
+		t.Fatalf("Download error: %v", err)
+	}
+
+	if duration := time.Since(start); duration < time.Second {
+		t.Errorf("Download is not throttled: %v", duration)
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newTestServer(t *testing.T, size int) *testServer {
+	t.Helper()
+
+	server := &testServer{content: make([]byte, size)}
+
+	if _, err := rand.Read(server.content); err != nil {
+		t.Fatalf("Can't generate content: %v", err)
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.Header.Get("Range") != "" {
+			server.Lock()
+			server.ranges = append(server.ranges, r.Header.Get("Range"))
+			server.Unlock()
+		}
+
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(server.content))
+	}))
+
+	return server
+}
+
+func (server *testServer) getRanges() []string {
+	server.Lock()
+	defer server.Unlock()
+
+	return server.ranges
+}
+
+func checkFile(t *testing.T, fileName string, expected []byte) {
+	t.Helper()
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("Can't read file: %v", err)
+	}
+
+	if !bytes.Equal(data, expected) {
+		t.Error("Wrong file content")
+	}
+}
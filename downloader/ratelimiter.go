@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package downloader
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const maxThrottledChunk = 32 * 1024
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// rateLimiter token bucket bandwidth limiter shared by all downloads.
+type rateLimiter struct {
+	sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastUpdate time.Time
+}
+
+type throttledReader struct {
+	ctx     context.Context //nolint:containedctx // reader is bound to download context
+	reader  io.Reader
+	limiter *rateLimiter
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newRateLimiter(bytesPerSecond int64) (limiter *rateLimiter) {
+	return &rateLimiter{
+		rate: float64(bytesPerSecond), burst: float64(bytesPerSecond), tokens: float64(bytesPerSecond),
+		lastUpdate: time.Now(),
+	}
+}
+
+func (limiter *rateLimiter) chunkSize() int {
+	return int(min(limiter.burst, maxThrottledChunk))
+}
+
+// wait takes count tokens and waits until the bucket debt is repaid.
+func (limiter *rateLimiter) wait(ctx context.Context, count int) error {
+	limiter.Lock()
+
+	now := time.Now()
+
+	limiter.tokens += now.Sub(limiter.lastUpdate).Seconds() * limiter.rate
+	if limiter.tokens > limiter.burst {
+		limiter.tokens = limiter.burst
+	}
+
+	limiter.lastUpdate = now
+	limiter.tokens -= float64(count)
+	debt := -limiter.tokens
+
+	limiter.Unlock()
+
+	if debt <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(debt / limiter.rate * float64(time.Second)))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return aoserrors.Wrap(ctx.Err())
+
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (reader *throttledReader) Read(buffer []byte) (readCount int, err error) {
+	if chunkSize := reader.limiter.chunkSize(); len(buffer) > chunkSize {
+		buffer = buffer[:chunkSize]
+	}
+
+	readCount, err = reader.reader.Read(buffer)
+
+	if readCount > 0 {
+		if waitErr := reader.limiter.wait(reader.ctx, readCount); waitErr != nil {
+			return readCount, waitErr
+		}
+	}
+
+	return readCount, err //nolint:wrapcheck // io.Reader errors should not be wrapped
+}
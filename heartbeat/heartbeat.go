@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package heartbeat provides periodic sending of unit heartbeat messages.
+package heartbeat
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const defaultInterval = 1 * time.Minute
+
+const procUptimeFile = "/proc/uptime"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Config heartbeat configuration.
+type Config struct {
+	Interval aostypes.Duration `json:"interval"`
+}
+
+// Sender sends heartbeat messages.
+type Sender interface {
+	SendHeartbeat(heartbeat cloudprotocol.UnitHeartbeat) error
+}
+
+// StatusProvider provides unit status reported in heartbeat.
+type StatusProvider interface {
+	GetBootReason() string
+	GetConnectivityRTT() time.Duration
+}
+
+// Heartbeat heartbeat instance.
+type Heartbeat struct {
+	sync.Mutex
+
+	config         Config
+	nodeID         string
+	statusProvider StatusProvider
+	sender         Sender
+	sequence       uint64
+	cancelFunction context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// GetUptime is used to mock system uptime in unit tests.
+var GetUptime = getSystemUptime //nolint:gochecknoglobals
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// New creates heartbeat instance. Status provider is optional.
+func New(config Config, nodeID string, statusProvider StatusProvider, sender Sender) (*Heartbeat, error) {
+	log.WithFields(log.Fields{"nodeID": nodeID, "interval": config.Interval}).Debug("Create heartbeat")
+
+	if sender == nil {
+		return nil, aoserrors.New("heartbeat sender should be set")
+	}
+
+	if config.Interval.Duration <= 0 {
+		config.Interval.Duration = defaultInterval
+	}
+
+	heartbeat := &Heartbeat{config: config, nodeID: nodeID, statusProvider: statusProvider, sender: sender}
+
+	ctx, cancelFunction := context.WithCancel(context.Background())
+
+	heartbeat.cancelFunction = cancelFunction
+
+	heartbeat.wg.Add(1)
+
+	go heartbeat.run(ctx)
+
+	return heartbeat, nil
+}
+
+// Close closes heartbeat instance.
+func (heartbeat *Heartbeat) Close() {
+	log.Debug("Close heartbeat")
+
+	heartbeat.cancelFunction()
+	heartbeat.wg.Wait()
+}
+
+// SendNow sends heartbeat immediately, e.g. right after cloud connection is established.
+func (heartbeat *Heartbeat) SendNow() error {
+	heartbeat.Lock()
+	defer heartbeat.Unlock()
+
+	return heartbeat.send()
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (heartbeat *Heartbeat) run(ctx context.Context) {
+	defer heartbeat.wg.Done()
+
+	ticker := time.NewTicker(heartbeat.config.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			heartbeat.Lock()
+
+			if err := heartbeat.send(); err != nil {
+				log.Errorf("Can't send heartbeat: %v", err)
+			}
+
+			heartbeat.Unlock()
+		}
+	}
+}
+
+func (heartbeat *Heartbeat) send() error {
+	message := cloudprotocol.UnitHeartbeat{
+		MessageType:     cloudprotocol.UnitHeartbeatMessageType,
+		NodeID:          heartbeat.nodeID,
+		Timestamp:       time.Now().UTC(),
+		Sequence:        heartbeat.sequence,
+		ProtocolVersion: cloudprotocol.ProtocolVersion,
+	}
+
+	uptime, err := GetUptime()
+	if err != nil {
+		log.Warnf("Can't get uptime: %v", err)
+	}
+
+	message.Uptime.Duration = uptime
+
+	if heartbeat.statusProvider != nil {
+		message.BootReason = heartbeat.statusProvider.GetBootReason()
+		message.ConnectivityRTT.Duration = heartbeat.statusProvider.GetConnectivityRTT()
+	}
+
+	heartbeat.sequence++
+
+	if err := heartbeat.sender.SendHeartbeat(message); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func getSystemUptime() (time.Duration, error) {
+	data, err := os.ReadFile(procUptimeFile)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, aoserrors.Errorf("invalid uptime format: %s", string(data))
+	}
+
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
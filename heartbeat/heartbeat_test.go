@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heartbeat_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/heartbeat"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testSender struct {
+	heartbeats chan cloudprotocol.UnitHeartbeat
+}
+
+type testStatusProvider struct{}
+
+/***********************************************************************************************************************
+ * Init
+ **********************************************************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestHeartbeat(t *testing.T) {
+	const interval = 100 * time.Millisecond
+
+	heartbeat.GetUptime = func() (time.Duration, error) { return time.Hour, nil }
+
+	sender := &testSender{heartbeats: make(chan cloudprotocol.UnitHeartbeat, 10)}
+
+	instance, err := heartbeat.New(heartbeat.Config{Interval: aostypes.Duration{Duration: interval}}, "node1",
+		&testStatusProvider{}, sender)
+	if err != nil {
+		t.Fatalf("Can't create heartbeat: %v", err)
+	}
+	defer instance.Close()
+
+	if err = instance.SendNow(); err != nil {
+		t.Fatalf("Can't send heartbeat: %v", err)
+	}
+
+	for i := uint64(0); i < 3; i++ {
+		select {
+		case message := <-sender.heartbeats:
+			if message.MessageType != cloudprotocol.UnitHeartbeatMessageType || message.NodeID != "node1" ||
+				message.ProtocolVersion != cloudprotocol.ProtocolVersion {
+				t.Errorf("Wrong heartbeat header: %v", message)
+			}
+
+			if message.Sequence != i {
+				t.Errorf("Wrong heartbeat sequence: %d", message.Sequence)
+			}
+
+			if message.Uptime.Duration != time.Hour || message.BootReason != cloudprotocol.BootReasonWatchdog ||
+				message.ConnectivityRTT.Duration != 50*time.Millisecond {
+				t.Errorf("Wrong heartbeat status: %v", message)
+			}
+
+		case <-time.After(2 * interval):
+			t.Fatal("Wait heartbeat timeout")
+		}
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (sender *testSender) SendHeartbeat(heartbeat cloudprotocol.UnitHeartbeat) error {
+	sender.heartbeats <- heartbeat
+
+	return nil
+}
+
+func (provider *testStatusProvider) GetBootReason() string {
+	return cloudprotocol.BootReasonWatchdog
+}
+
+func (provider *testStatusProvider) GetConnectivityRTT() time.Duration {
+	return 50 * time.Millisecond
+}
@@ -289,9 +289,9 @@ func (client *Client) RenewCertificatesNotification(secrets cloudprotocol.UnitSe
 		ctx, cancel := context.WithTimeout(context.Background(), iamRequestTimeout)
 		defer cancel()
 
-		pwd, ok := secrets.Nodes[cert.NodeID]
-		if !ok {
-			return aoserrors.New("not found password for node: " + cert.NodeID)
+		pwd, err := secrets.GetNodePassword(cert.NodeID, time.Now())
+		if err != nil {
+			return err
 		}
 
 		request := &pb.CreateKeyRequest{Type: cert.Type, Password: pwd, NodeId: cert.NodeID}
@@ -522,9 +522,7 @@ func (client *Client) Deprovision(nodeID, password string) (err error) {
 
 	if client.isMainNode && nodeID == client.nodeID {
 		err = aoserrors.New("Can't deprovision main node")
-		errorInfo = &cloudprotocol.ErrorInfo{
-			Message: err.Error(),
-		}
+		errorInfo = cloudprotocol.NewErrorInfo(err)
 
 		return err
 	}
@@ -535,7 +533,7 @@ func (client *Client) Deprovision(nodeID, password string) (err error) {
 	response, err := client.provisioningService.Deprovision(
 		ctx, &pb.DeprovisionRequest{NodeId: nodeID, Password: password})
 	if err != nil {
-		errorInfo = &cloudprotocol.ErrorInfo{Message: err.Error()}
+		errorInfo = cloudprotocol.NewErrorInfo(err)
 
 		return aoserrors.Wrap(err)
 	}
@@ -1098,7 +1096,7 @@ func (client *Client) createKeys(nodeID, password string) (
 ) {
 	certTypes, err := client.getCertTypes(nodeID)
 	if err != nil {
-		return nil, &cloudprotocol.ErrorInfo{Message: err.Error()}
+		return nil, cloudprotocol.NewErrorInfo(err)
 	}
 
 	for _, certType := range certTypes {
@@ -1113,7 +1111,7 @@ func (client *Client) createKeys(nodeID, password string) (
 			Password: password,
 		})
 		if err != nil {
-			return nil, &cloudprotocol.ErrorInfo{Message: err.Error()}
+			return nil, cloudprotocol.NewErrorInfo(err)
 		}
 
 		if response.GetError() != nil {
@@ -1137,7 +1135,7 @@ func (client *Client) startProvisioning(nodeID, password string) (errorInfo *clo
 	response, err := client.provisioningService.StartProvisioning(
 		ctx, &pb.StartProvisioningRequest{NodeId: nodeID, Password: password})
 	if err != nil {
-		return &cloudprotocol.ErrorInfo{Message: err.Error()}
+		return cloudprotocol.NewErrorInfo(err)
 	}
 
 	return pbconvert.ErrorInfoFromPB(response.GetError())
@@ -1159,7 +1157,7 @@ func (client *Client) applyCertificates(
 				Cert:   certificate.CertificateChain,
 			})
 		if err != nil {
-			return &cloudprotocol.ErrorInfo{Message: err.Error()}
+			return cloudprotocol.NewErrorInfo(err)
 		}
 
 		if response.GetError() != nil {
@@ -1177,7 +1175,7 @@ func (client *Client) finishProvisioning(nodeID, password string) (errorInfo *cl
 	response, err := client.provisioningService.FinishProvisioning(
 		ctx, &pb.FinishProvisioningRequest{NodeId: nodeID, Password: password})
 	if err != nil {
-		return &cloudprotocol.ErrorInfo{Message: err.Error()}
+		return cloudprotocol.NewErrorInfo(err)
 	}
 
 	return pbconvert.ErrorInfoFromPB(response.GetError())
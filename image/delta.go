@@ -0,0 +1,822 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Delta formats.
+const (
+	// DeltaFormatBSDiff classic BSDIFF40 delta with bzip2 compressed blocks compatible with bsdiff/bspatch tools.
+	DeltaFormatBSDiff = "bsdiff"
+)
+
+const (
+	bsdiffMagic      = "BSDIFF40"
+	bsdiffHeaderLen  = 32
+	bsdiffFuzz       = 8
+	bsdiffSortLimit  = 16
+	bsdiffBufferSize = 32 * 1024
+	deltaFilePerm    = 0o600
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// bzip2Block compresses written data by bzip2 tool into temporary file.
+type bzip2Block struct {
+	file   *os.File
+	cmd    *exec.Cmd
+	input  io.WriteCloser
+	writer *bufio.Writer
+	stderr bytes.Buffer
+	closed bool
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals // supported delta formats in preference order
+var supportedDeltaFormats = []string{DeltaFormatBSDiff}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// SupportedDeltaFormats returns delta formats which can be applied in preference order.
+func SupportedDeltaFormats() []string {
+	return slices.Clone(supportedDeltaFormats)
+}
+
+// NegotiateDeltaFormat selects the most preferred supported format from formats offered by peer.
+func NegotiateDeltaFormat(offered []string) (format string, err error) {
+	for _, format = range supportedDeltaFormats {
+		if slices.Contains(offered, format) {
+			return format, nil
+		}
+	}
+
+	return "", aoserrors.Errorf("no common delta format in %v: %w", offered, aoserrors.ErrNotSupported)
+}
+
+// CreateDelta creates BSDIFF40 delta between old and new files. Old and new files are memory mapped and delta blocks
+// are compressed by bzip2 tool while streamed to temporary files next to delta file. The only allocated memory
+// proportional to input size is suffix array of old file: peak usage is 16 bytes per old file byte on 64-bit
+// platforms, mapped file pages are managed by kernel page cache.
+func CreateDelta(ctx context.Context, oldFile, newFile, deltaFile string) (err error) {
+	log.WithFields(log.Fields{"old": oldFile, "new": newFile, "delta": deltaFile}).Debug("Create delta")
+
+	oldData, releaseOld, err := mapFile(oldFile)
+	if err != nil {
+		return err
+	}
+	defer releaseOld()
+
+	newData, releaseNew, err := mapFile(newFile)
+	if err != nil {
+		return err
+	}
+	defer releaseNew()
+
+	blocks := make([]*bzip2Block, 0, 3) //nolint:mnd // control, diff and extra blocks
+
+	defer func() {
+		for _, block := range blocks {
+			block.remove()
+		}
+	}()
+
+	for range cap(blocks) {
+		block, err := newBZip2Block(ctx, filepath.Dir(deltaFile))
+		if err != nil {
+			return err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	if err = createBSDiff(ctx, oldData, newData, blocks[0].writer, blocks[1].writer, blocks[2].writer); err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		if err = block.close(); err != nil {
+			return err
+		}
+	}
+
+	deltaSize, err := writeBSDiff(deltaFile, blocks, len(newData))
+	if err != nil {
+		if removeErr := os.Remove(deltaFile); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+			log.Errorf("Can't remove delta file: %v", removeErr)
+		}
+
+		return err
+	}
+
+	log.WithFields(log.Fields{"newSize": len(newData), "deltaSize": deltaSize}).Debug("Delta created")
+
+	return nil
+}
+
+// ApplyDelta reconstructs new file from old file and delta. Delta format is detected automatically. Reconstructed
+// file is streamed to disk, verified against expected size and digests and removed on mismatch. Expected size is
+// required: delta declaring different size is rejected.
+func ApplyDelta(ctx context.Context, oldFile, deltaFile, newFile string, expected PartInfo) (err error) {
+	log.WithFields(log.Fields{"old": oldFile, "delta": deltaFile, "new": newFile}).Debug("Apply delta")
+
+	oldReader, oldSize, err := openSized(oldFile)
+	if err != nil {
+		return err
+	}
+	defer oldReader.Close()
+
+	deltaReader, deltaSize, err := openSized(deltaFile)
+	if err != nil {
+		return err
+	}
+	defer deltaReader.Close()
+
+	pipeReader, pipeWriter := io.Pipe()
+	applyDone := make(chan struct{})
+
+	go func() {
+		defer close(applyDone)
+
+		pipeWriter.CloseWithError(applyBSDiff(ctx, io.NewSectionReader(oldReader, 0, oldSize),
+			io.NewSectionReader(deltaReader, 0, deltaSize), expected.Size, pipeWriter))
+	}()
+
+	_, err = CopyVerified(ctx, newFile, pipeReader, expected)
+
+	pipeReader.Close()
+	<-applyDone
+
+	return err
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func createBSDiff(ctx context.Context, oldData, newData []byte, ctrlBlock, diffBlock, extraBlock io.Writer) error {
+	suffixes := qsufsort(oldData)
+
+	var (
+		scan, pos, length             int
+		lastScan, lastPos, lastOffset int
+	)
+
+	for scan < len(newData) {
+		if err := ctx.Err(); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		oldScore := 0
+		scan += length
+
+		for scsc := scan; scan < len(newData); scan++ {
+			length, pos = searchSuffix(suffixes, oldData, newData[scan:], 0, len(oldData))
+
+			for ; scsc < scan+length; scsc++ {
+				if scsc+lastOffset < len(oldData) && oldData[scsc+lastOffset] == newData[scsc] {
+					oldScore++
+				}
+			}
+
+			if (length == oldScore && length != 0) || length > oldScore+bsdiffFuzz {
+				break
+			}
+
+			if scan+lastOffset < len(oldData) && oldData[scan+lastOffset] == newData[scan] {
+				oldScore--
+			}
+		}
+
+		if length == oldScore && scan != len(newData) {
+			continue
+		}
+
+		lenF := forwardExtension(oldData, newData, lastScan, lastPos, scan)
+		lenB := 0
+
+		if scan < len(newData) {
+			lenB = backwardExtension(oldData, newData, lastScan, scan, pos)
+		}
+
+		if lastScan+lenF > scan-lenB {
+			overlap := (lastScan + lenF) - (scan - lenB)
+			score, bestScore, lenS := 0, 0, 0
+
+			for i := range overlap {
+				if newData[lastScan+lenF-overlap+i] == oldData[lastPos+lenF-overlap+i] {
+					score++
+				}
+
+				if newData[scan-lenB+i] == oldData[pos-lenB+i] {
+					score--
+				}
+
+				if score > bestScore {
+					bestScore = score
+					lenS = i + 1
+				}
+			}
+
+			lenF += lenS - overlap
+			lenB -= lenS
+		}
+
+		if err := writeDiff(diffBlock, oldData[lastPos:lastPos+lenF], newData[lastScan:lastScan+lenF]); err != nil {
+			return err
+		}
+
+		extraLen := (scan - lenB) - (lastScan + lenF)
+
+		if _, err := extraBlock.Write(newData[lastScan+lenF : lastScan+lenF+extraLen]); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		for _, value := range []int{lenF, extraLen, (pos - lenB) - (lastPos + lenF)} {
+			if _, err := ctrlBlock.Write(encodeOffset(value)); err != nil {
+				return aoserrors.Wrap(err)
+			}
+		}
+
+		lastScan = scan - lenB
+		lastPos = pos - lenB
+		lastOffset = pos - scan
+	}
+
+	return nil
+}
+
+func writeDiff(writer io.Writer, oldData, newData []byte) error {
+	diff := make([]byte, min(len(newData), bsdiffBufferSize))
+
+	for len(newData) > 0 {
+		chunk := diff[:min(len(newData), len(diff))]
+
+		for i := range chunk {
+			chunk[i] = newData[i] - oldData[i]
+		}
+
+		if _, err := writer.Write(chunk); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		oldData, newData = oldData[len(chunk):], newData[len(chunk):]
+	}
+
+	return nil
+}
+
+func forwardExtension(oldData, newData []byte, lastScan, lastPos, scan int) (lenF int) {
+	score, bestScore := 0, 0
+
+	for i := 0; lastScan+i < scan && lastPos+i < len(oldData); {
+		if oldData[lastPos+i] == newData[lastScan+i] {
+			score++
+		}
+
+		i++
+
+		if score*2-i > bestScore*2-lenF {
+			bestScore = score
+			lenF = i
+		}
+	}
+
+	return lenF
+}
+
+func backwardExtension(oldData, newData []byte, lastScan, scan, pos int) (lenB int) {
+	score, bestScore := 0, 0
+
+	for i := 1; scan >= lastScan+i && pos >= i; i++ {
+		if oldData[pos-i] == newData[scan-i] {
+			score++
+		}
+
+		if score*2-i > bestScore*2-lenB {
+			bestScore = score
+			lenB = i
+		}
+	}
+
+	return lenB
+}
+
+func writeBSDiff(deltaFile string, blocks []*bzip2Block, newSize int) (deltaSize int64, err error) {
+	file, err := os.OpenFile(deltaFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, deltaFilePerm)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = aoserrors.Wrap(closeErr)
+		}
+	}()
+
+	header := bytes.NewBufferString(bsdiffMagic)
+
+	for _, block := range blocks[:2] {
+		info, err := block.file.Stat()
+		if err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+
+		header.Write(encodeOffset(int(info.Size())))
+	}
+
+	header.Write(encodeOffset(newSize))
+
+	if deltaSize, err = header.WriteTo(file); err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	for _, block := range blocks {
+		if _, err = block.file.Seek(0, io.SeekStart); err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+
+		written, err := io.Copy(file, block.file)
+		if err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+
+		deltaSize += written
+	}
+
+	return deltaSize, nil
+}
+
+// mapFile maps file into memory read only. Returned release function unmaps it.
+func mapFile(fileName string) (data []byte, release func(), err error) {
+	file, size, err := openSized(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	if size == 0 {
+		return nil, func() {}, nil
+	}
+
+	if data, err = syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED); err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	return data, func() {
+		if err := syscall.Munmap(data); err != nil {
+			log.Errorf("Can't unmap file %s: %v", fileName, err)
+		}
+	}, nil
+}
+
+func newBZip2Block(ctx context.Context, dir string) (block *bzip2Block, err error) {
+	block = &bzip2Block{cmd: exec.CommandContext(ctx, "bzip2", "-c"), closed: true}
+
+	if block.file, err = os.CreateTemp(dir, ".delta-block-*"); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	block.cmd.Stdout = block.file
+	block.cmd.Stderr = &block.stderr
+
+	if block.input, err = block.cmd.StdinPipe(); err != nil {
+		block.remove()
+
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if err = block.cmd.Start(); err != nil {
+		block.remove()
+
+		return nil, aoserrors.Wrap(err)
+	}
+
+	block.writer = bufio.NewWriterSize(block.input, bsdiffBufferSize)
+	block.closed = false
+
+	return block, nil
+}
+
+// close flushes buffered data and waits for bzip2 to finish compression.
+func (block *bzip2Block) close() error {
+	if block.closed {
+		return nil
+	}
+
+	block.closed = true
+
+	flushErr := block.writer.Flush()
+
+	block.input.Close()
+
+	if err := block.cmd.Wait(); err != nil {
+		return aoserrors.Errorf("bzip2: %v: %s", err, strings.TrimSpace(block.stderr.String()))
+	}
+
+	return aoserrors.Wrap(flushErr)
+}
+
+func (block *bzip2Block) remove() {
+	_ = block.close()
+
+	block.file.Close()
+
+	if err := os.Remove(block.file.Name()); err != nil {
+		log.Errorf("Can't remove delta block file: %v", err)
+	}
+}
+
+func openSized(fileName string) (file *os.File, size int64, err error) {
+	if file, err = os.Open(fileName); err != nil {
+		return nil, 0, aoserrors.Wrap(err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return nil, 0, aoserrors.Wrap(err)
+	}
+
+	return file, info.Size(), nil
+}
+
+// applyBSDiff streams reconstructed data to writer. All header and control values are validated before use, so
+// crafted delta can't cause out of range access or unbounded allocation.
+func applyBSDiff(ctx context.Context, oldData, delta *io.SectionReader, expectedSize int64, writer io.Writer) error {
+	header := make([]byte, bsdiffHeaderLen)
+
+	if _, err := delta.ReadAt(header, 0); err != nil {
+		return aoserrors.Errorf("delta too short: %v", err)
+	}
+
+	if string(header[:len(bsdiffMagic)]) != bsdiffMagic {
+		return aoserrors.Errorf("unknown delta format: %w", aoserrors.ErrNotSupported)
+	}
+
+	ctrlLen, diffLen, newSize := decodeOffset(header[8:]), decodeOffset(header[16:]), decodeOffset(header[24:])
+	blocksLen := delta.Size() - bsdiffHeaderLen
+
+	if ctrlLen < 0 || diffLen < 0 || ctrlLen > blocksLen || diffLen > blocksLen-ctrlLen {
+		return aoserrors.New("corrupted delta header")
+	}
+
+	if newSize != expectedSize {
+		return aoserrors.Errorf("delta size %d doesn't match expected size %d", newSize, expectedSize)
+	}
+
+	ctrlReader := bzip2.NewReader(io.NewSectionReader(delta, bsdiffHeaderLen, ctrlLen))
+	diffReader := bzip2.NewReader(io.NewSectionReader(delta, bsdiffHeaderLen+ctrlLen, diffLen))
+	extraReader := bzip2.NewReader(io.NewSectionReader(
+		delta, bsdiffHeaderLen+ctrlLen+diffLen, blocksLen-ctrlLen-diffLen))
+
+	var err error
+
+	ctrl := make([]byte, 24) //nolint:mnd // 3 offsets
+	diffBuffer := make([]byte, bsdiffBufferSize)
+	oldBuffer := make([]byte, bsdiffBufferSize)
+
+	for oldPos, newPos := int64(0), int64(0); newPos < newSize; {
+		if err = ctx.Err(); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if _, err = io.ReadFull(ctrlReader, ctrl); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		diffSize, extraSize, seek := decodeOffset(ctrl), decodeOffset(ctrl[8:]), decodeOffset(ctrl[16:])
+
+		if diffSize < 0 || extraSize < 0 || diffSize > newSize-newPos || extraSize > newSize-newPos-diffSize {
+			return aoserrors.New("corrupted delta control block")
+		}
+
+		if err = applyDiff(diffReader, oldData, oldPos, diffSize, diffBuffer, oldBuffer, writer); err != nil {
+			return err
+		}
+
+		newPos += diffSize
+		oldPos += diffSize
+
+		if _, err = io.CopyN(writer, extraReader, extraSize); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		newPos += extraSize
+
+		// Old position stays within old data bounds for deltas produced by bsdiff.
+		if seek < -oldPos || seek > oldData.Size()-oldPos {
+			return aoserrors.New("corrupted delta control block")
+		}
+
+		oldPos += seek
+	}
+
+	// Reading blocks till the end verifies compressed stream integrity.
+	for _, reader := range []io.Reader{diffReader, extraReader} {
+		if _, err = io.ReadFull(reader, make([]byte, 1)); !errors.Is(err, io.EOF) {
+			return aoserrors.Errorf("corrupted delta data block: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// applyDiff writes size bytes of diff block added to old data starting from old position. Old data beyond its bounds
+// is treated as zero.
+func applyDiff(
+	diffReader io.Reader, oldData *io.SectionReader, oldPos, size int64, diffBuffer, oldBuffer []byte,
+	writer io.Writer,
+) error {
+	for size > 0 {
+		chunk := diffBuffer[:min(size, int64(len(diffBuffer)))]
+
+		if _, err := io.ReadFull(diffReader, chunk); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		oldChunk := oldBuffer[:len(chunk)]
+		clear(oldChunk)
+
+		if oldPos < oldData.Size() {
+			if _, err := oldData.ReadAt(oldChunk, oldPos); err != nil && !errors.Is(err, io.EOF) {
+				return aoserrors.Wrap(err)
+			}
+		}
+
+		for i := range chunk {
+			chunk[i] += oldChunk[i]
+		}
+
+		if _, err := writer.Write(chunk); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		oldPos += int64(len(chunk))
+		size -= int64(len(chunk))
+	}
+
+	return nil
+}
+
+// encodeOffset encodes offset in bsdiff sign-magnitude little endian format.
+func encodeOffset(value int) []byte {
+	data := make([]byte, 8) //nolint:mnd // int64 size
+
+	if value < 0 {
+		binary.LittleEndian.PutUint64(data, uint64(-value))
+		data[7] |= 0x80
+	} else {
+		binary.LittleEndian.PutUint64(data, uint64(value))
+	}
+
+	return data
+}
+
+func decodeOffset(data []byte) int64 {
+	value := int64(binary.LittleEndian.Uint64(data) &^ (1 << 63)) //nolint:gosec // sign bit is cleared
+
+	if data[7]&0x80 != 0 {
+		return -value
+	}
+
+	return value
+}
+
+// qsufsort builds suffix array with Larsson-Sadakane algorithm.
+func qsufsort(data []byte) []int {
+	suffixes := make([]int, len(data)+1)
+	ranks := make([]int, len(data)+1)
+
+	var buckets [256]int
+
+	for _, value := range data {
+		buckets[value]++
+	}
+
+	for i := 1; i < len(buckets); i++ {
+		buckets[i] += buckets[i-1]
+	}
+
+	copy(buckets[1:], buckets[:len(buckets)-1])
+	buckets[0] = 0
+
+	for i, value := range data {
+		buckets[value]++
+		suffixes[buckets[value]] = i
+	}
+
+	suffixes[0] = len(data)
+
+	for i, value := range data {
+		ranks[i] = buckets[value]
+	}
+
+	ranks[len(data)] = 0
+
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] == buckets[i-1]+1 {
+			suffixes[buckets[i]] = -1
+		}
+	}
+
+	suffixes[0] = -1
+
+	for h := 1; suffixes[0] != -(len(data) + 1); h += h {
+		length := 0
+		i := 0
+
+		for i < len(data)+1 {
+			if suffixes[i] < 0 {
+				length -= suffixes[i]
+				i -= suffixes[i]
+
+				continue
+			}
+
+			if length != 0 {
+				suffixes[i-length] = -length
+			}
+
+			length = ranks[suffixes[i]] + 1 - i
+			splitSuffixes(suffixes, ranks, i, length, h)
+			i += length
+			length = 0
+		}
+
+		if length != 0 {
+			suffixes[i-length] = -length
+		}
+	}
+
+	for i := range len(data) + 1 {
+		suffixes[ranks[i]] = i
+	}
+
+	return suffixes
+}
+
+//nolint:gocognit,cyclop // port of reference bsdiff implementation
+func splitSuffixes(suffixes, ranks []int, start, length, h int) {
+	if length < bsdiffSortLimit {
+		for k := start; k < start+length; {
+			j := 1
+			x := ranks[suffixes[k]+h]
+
+			for i := 1; k+i < start+length; i++ {
+				if ranks[suffixes[k+i]+h] < x {
+					x = ranks[suffixes[k+i]+h]
+					j = 0
+				}
+
+				if ranks[suffixes[k+i]+h] == x {
+					suffixes[k+j], suffixes[k+i] = suffixes[k+i], suffixes[k+j]
+					j++
+				}
+			}
+
+			for i := range j {
+				ranks[suffixes[k+i]] = k + j - 1
+			}
+
+			if j == 1 {
+				suffixes[k] = -1
+			}
+
+			k += j
+		}
+
+		return
+	}
+
+	x := ranks[suffixes[start+length/2]+h]
+	jj, kk := 0, 0
+
+	for i := start; i < start+length; i++ {
+		if ranks[suffixes[i]+h] < x {
+			jj++
+		}
+
+		if ranks[suffixes[i]+h] == x {
+			kk++
+		}
+	}
+
+	jj += start
+	kk += jj
+
+	i, j, k := start, 0, 0
+
+	for i < jj {
+		switch {
+		case ranks[suffixes[i]+h] < x:
+			i++
+
+		case ranks[suffixes[i]+h] == x:
+			suffixes[i], suffixes[jj+j] = suffixes[jj+j], suffixes[i]
+			j++
+
+		default:
+			suffixes[i], suffixes[kk+k] = suffixes[kk+k], suffixes[i]
+			k++
+		}
+	}
+
+	for jj+j < kk {
+		if ranks[suffixes[jj+j]+h] == x {
+			j++
+		} else {
+			suffixes[jj+j], suffixes[kk+k] = suffixes[kk+k], suffixes[jj+j]
+			k++
+		}
+	}
+
+	if jj > start {
+		splitSuffixes(suffixes, ranks, start, jj-start, h)
+	}
+
+	for i := range kk - jj {
+		ranks[suffixes[jj+i]] = kk - 1
+	}
+
+	if jj == kk-1 {
+		suffixes[jj] = -1
+	}
+
+	if start+length > kk {
+		splitSuffixes(suffixes, ranks, kk, start+length-kk, h)
+	}
+}
+
+func matchLen(oldData, newData []byte) (length int) {
+	for length < len(oldData) && length < len(newData) && oldData[length] == newData[length] {
+		length++
+	}
+
+	return length
+}
+
+func searchSuffix(suffixes []int, oldData, newData []byte, start, end int) (length, pos int) {
+	for end-start >= 2 {
+		middle := start + (end-start)/2
+		compareLen := min(len(oldData)-suffixes[middle], len(newData))
+
+		if bytes.Compare(oldData[suffixes[middle]:suffixes[middle]+compareLen], newData[:compareLen]) < 0 {
+			start = middle
+		} else {
+			end = middle
+		}
+	}
+
+	startLen := matchLen(oldData[suffixes[start]:], newData)
+	endLen := matchLen(oldData[suffixes[end]:], newData)
+
+	if startLen > endLen {
+		return startLen, suffixes[start]
+	}
+
+	return endLen, suffixes[end]
+}
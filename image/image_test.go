@@ -18,12 +18,17 @@
 package image_test
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"os/exec"
@@ -33,12 +38,14 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/aosedge/aos_common/aoserrors"
 	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/image"
+	"github.com/aosedge/aos_common/utils/cryptutils"
 	"github.com/aosedge/aos_common/utils/testtools"
 	"github.com/opencontainers/go-digest"
 	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -420,6 +427,259 @@ func TestCopyImageFromGzipArchiveToDevice(t *testing.T) {
 	}
 }
 
+func TestCopyVerified(t *testing.T) {
+	part1 := make([]byte, 3*1024*1024+17)
+	part2 := make([]byte, 1024)
+
+	if _, err := rand.Read(part1); err != nil {
+		t.Fatalf("Can't generate data: %v", err)
+	}
+
+	if _, err := rand.Read(part2); err != nil {
+		t.Fatalf("Can't generate data: %v", err)
+	}
+
+	parts := []image.PartInfo{
+		{Size: int64(len(part1)), Digests: []digest.Digest{digest.SHA256.FromBytes(part1), digest.SHA512.FromBytes(part1)}},
+		{Digests: []digest.Digest{digest.SHA512.FromBytes(part2)}},
+	}
+
+	data := append(append([]byte{}, part1...), part2...)
+	dst := filepath.Join(workDir, "verified.dat")
+
+	copied, err := image.CopyVerified(context.Background(), dst, bytes.NewReader(data), parts...)
+	if err != nil {
+		t.Fatalf("Can't copy verified data: %v", err)
+	}
+
+	if copied != int64(len(data)) {
+		t.Errorf("Wrong copied size: %d", copied)
+	}
+
+	// Corrupted first part should abort reading before the second part
+
+	corrupted := append([]byte{}, data...)
+	corrupted[0] ^= 0xff
+
+	reader, err := image.NewVerifyingReader(bytes.NewReader(corrupted), parts...)
+	if err != nil {
+		t.Fatalf("Can't create verifying reader: %v", err)
+	}
+
+	read, err := io.Copy(io.Discard, reader)
+	if !errors.Is(err, aoserrors.ErrInvalidChecksum) {
+		t.Errorf("Invalid checksum error expected: %v", err)
+	}
+
+	if read != int64(len(part1)) {
+		t.Errorf("Wrong read size: %d", read)
+	}
+
+	if _, err = image.CopyVerified(
+		context.Background(), dst, bytes.NewReader(data[:len(data)-1]), parts...); err == nil {
+		t.Error("Truncated data should fail")
+	}
+
+	if _, err = os.Stat(dst); !os.IsNotExist(err) {
+		t.Error("Destination file should be removed")
+	}
+}
+
+func TestDelta(t *testing.T) {
+	oldData := make([]byte, 256*1024)
+
+	if _, err := rand.Read(oldData); err != nil {
+		t.Fatalf("Can't generate data: %v", err)
+	}
+
+	newData := append(append([]byte{}, oldData[:1000]...), []byte("inserted data")...)
+	newData = append(newData, oldData[1000:100000]...)
+	newData = append(newData, oldData[120000:]...)
+
+	for i := 50000; i < 50100; i++ {
+		newData[i]++
+	}
+
+	oldFile := filepath.Join(workDir, "old.dat")
+	newFile := filepath.Join(workDir, "new.dat")
+	deltaFile := filepath.Join(workDir, "delta.dat")
+	resultFile := filepath.Join(workDir, "result.dat")
+
+	if err := os.WriteFile(oldFile, oldData, filePerm); err != nil {
+		t.Fatalf("Can't write file: %v", err)
+	}
+
+	if err := os.WriteFile(newFile, newData, filePerm); err != nil {
+		t.Fatalf("Can't write file: %v", err)
+	}
+
+	if err := image.CreateDelta(context.Background(), oldFile, newFile, deltaFile); err != nil {
+		t.Fatalf("Can't create delta: %v", err)
+	}
+
+	delta, err := os.ReadFile(deltaFile)
+	if err != nil {
+		t.Fatalf("Can't read delta: %v", err)
+	}
+
+	if !bytes.HasPrefix(delta, []byte("BSDIFF40")) {
+		t.Errorf("Wrong delta header: %q", delta[:min(len(delta), 8)])
+	}
+
+	if len(delta) > len(newData)/10 {
+		t.Errorf("Delta too big: %d", len(delta))
+	}
+
+	expected := image.PartInfo{Size: int64(len(newData)), Digests: []digest.Digest{digest.SHA256.FromBytes(newData)}}
+
+	if err = image.ApplyDelta(context.Background(), oldFile, deltaFile, resultFile, expected); err != nil {
+		t.Fatalf("Can't apply delta: %v", err)
+	}
+
+	if err = testtools.CompareFiles(resultFile, newFile); err != nil {
+		t.Errorf("Compare error: %v", err)
+	}
+
+	expected.Digests = []digest.Digest{digest.SHA256.FromBytes(oldData)}
+
+	if err = image.ApplyDelta(
+		context.Background(), oldFile, deltaFile, resultFile, expected); !errors.Is(err, aoserrors.ErrInvalidChecksum) {
+		t.Errorf("Invalid checksum error expected: %v", err)
+	}
+
+	// Classic bsdiff delta
+
+	bsdiffDelta, err := hex.DecodeString(
+		"42534449464634303b0000000000000027000000000000002c00000000000000425a68393141592653596e9b8b270000" +
+			"19f0407a3010000008400020003100301229934f492b24f4991215a6d80cd657c5dc914e14241ba6e2c9c0425a683931" +
+			"415926535926fe8b2400000050004000200020002100828317724538509026fe8b24425a6839314159265359fe1c0fc7" +
+			"0000029180200006001800200030cd00c1a190138bb9229c28487f0e07e380")
+	if err != nil {
+		t.Fatalf("Can't decode delta: %v", err)
+	}
+
+	if err = os.WriteFile(oldFile, []byte("The quick brown fox jumps over the lazy dog"), filePerm); err != nil {
+		t.Fatalf("Can't write file: %v", err)
+	}
+
+	if err = os.WriteFile(deltaFile, bsdiffDelta, filePerm); err != nil {
+		t.Fatalf("Can't write file: %v", err)
+	}
+
+	newData = []byte("The quick red fox jumped over the lazy dogs!")
+
+	if err = image.ApplyDelta(context.Background(), oldFile, deltaFile, resultFile, image.PartInfo{
+		Size: int64(len(newData)), Digests: []digest.Digest{digest.SHA512.FromBytes(newData)},
+	}); err != nil {
+		t.Errorf("Can't apply bsdiff delta: %v", err)
+	}
+
+	if format, err := image.NegotiateDeltaFormat(
+		[]string{"zchunk", image.DeltaFormatBSDiff}); err != nil || format != image.DeltaFormatBSDiff {
+		t.Errorf("Wrong negotiated format: %s, err: %v", format, err)
+	}
+
+	if _, err := image.NegotiateDeltaFormat([]string{"zchunk"}); !errors.Is(err, aoserrors.ErrNotSupported) {
+		t.Errorf("Not supported error expected: %v", err)
+	}
+}
+
+func TestMalformedDelta(t *testing.T) {
+	oldData := []byte("The quick brown fox jumps over the lazy dog")
+	newData := []byte("The quick red fox jumped over the lazy dogs!")
+
+	oldFile := filepath.Join(workDir, "old.dat")
+	newFile := filepath.Join(workDir, "new.dat")
+	deltaFile := filepath.Join(workDir, "delta.dat")
+	resultFile := filepath.Join(workDir, "result.dat")
+
+	if err := os.WriteFile(oldFile, oldData, filePerm); err != nil {
+		t.Fatalf("Can't write file: %v", err)
+	}
+
+	if err := os.WriteFile(newFile, newData, filePerm); err != nil {
+		t.Fatalf("Can't write file: %v", err)
+	}
+
+	if err := image.CreateDelta(context.Background(), oldFile, newFile, deltaFile); err != nil {
+		t.Fatalf("Can't create delta: %v", err)
+	}
+
+	validDelta, err := os.ReadFile(deltaFile)
+	if err != nil {
+		t.Fatalf("Can't read delta: %v", err)
+	}
+
+	expected := image.PartInfo{Size: int64(len(newData)), Digests: []digest.Digest{digest.SHA256.FromBytes(newData)}}
+	maxInt := int64(^uint64(0) >> 1)
+
+	type testData struct {
+		name  string
+		delta []byte
+	}
+
+	data := []testData{
+		{name: "empty", delta: []byte{}},
+		{name: "header only", delta: validDelta[:32]},
+		{name: "huge new size", delta: createTestDelta(t, 1<<62, []int64{10, 0, 0}, make([]byte, 10), nil)},
+		{name: "size mismatch", delta: createTestDelta(t, 10, []int64{10, 0, 0}, make([]byte, 10), nil)},
+		{
+			name: "diff size overflow",
+			delta: createTestDelta(t, int64(len(newData)), []int64{maxInt, maxInt, 0},
+				make([]byte, 10), nil),
+		},
+		{
+			name: "extra size overflow",
+			delta: createTestDelta(t, int64(len(newData)), []int64{4, maxInt - 2, 0},
+				make([]byte, 4), nil),
+		},
+		{name: "negative diff size", delta: createTestDelta(t, int64(len(newData)), []int64{-1, 0, 0}, nil, nil)},
+		{
+			name: "old position out of range",
+			delta: createTestDelta(t, int64(len(newData)), []int64{4, 0, maxInt, 4, 0, 0},
+				make([]byte, 8), nil),
+		},
+		{
+			name: "negative old position",
+			delta: createTestDelta(t, int64(len(newData)), []int64{4, 0, -5, 4, 0, 0},
+				make([]byte, 8), nil),
+		},
+		{name: "short diff block", delta: createTestDelta(t, int64(len(newData)), []int64{44, 0, 0}, nil, nil)},
+	}
+
+	for length := 1; length < len(validDelta); length += 7 {
+		data = append(data, testData{name: "truncated " + strconv.Itoa(length), delta: validDelta[:length]})
+	}
+
+	for _, item := range data {
+		if err = os.WriteFile(deltaFile, item.delta, filePerm); err != nil {
+			t.Fatalf("Can't write file: %v", err)
+		}
+
+		if err = image.ApplyDelta(context.Background(), oldFile, deltaFile, resultFile, expected); err == nil {
+			t.Errorf("Error expected for %s delta", item.name)
+		}
+
+		if _, err = os.Stat(resultFile); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Result file should be removed for %s delta", item.name)
+		}
+	}
+
+	if err = os.WriteFile(deltaFile, validDelta, filePerm); err != nil {
+		t.Fatalf("Can't write file: %v", err)
+	}
+
+	if err = image.ApplyDelta(context.Background(), oldFile, deltaFile, resultFile, image.PartInfo{
+		Size: int64(len(newData)) + 1,
+	}); err == nil {
+		t.Error("Error expected for wrong expected size")
+	}
+
+	if err = image.ApplyDelta(context.Background(), oldFile, deltaFile, resultFile, expected); err != nil {
+		t.Errorf("Can't apply delta: %v", err)
+	}
+}
+
 func TestImageManifest(t *testing.T) {
 	fileName := path.Join(workDir, "manifest.json")
 
@@ -489,6 +749,147 @@ func TestImageManifest(t *testing.T) {
 	}
 }
 
+func TestSignatureVerification(t *testing.T) {
+	codeSigning := testtools.WithKeyUsage(x509.KeyUsageDigitalSignature, x509.ExtKeyUsageCodeSigning)
+
+	pki, err := testtools.NewPKIBuilder().WithIntermediates(1).
+		WithLeaf("signer1", codeSigning).
+		WithLeaf("signer2", codeSigning).
+		WithLeaf("tlsServer").
+		WithLeaf("expired", codeSigning,
+			testtools.WithValidity(time.Now().Add(-48*time.Hour), time.Now().Add(-24*time.Hour))).
+		Build()
+	if err != nil {
+		t.Fatalf("Can't build PKI: %v", err)
+	}
+
+	untrustedPKI, err := testtools.NewPKIBuilder().WithLeaf("signer3", codeSigning).Build()
+	if err != nil {
+		t.Fatalf("Can't build PKI: %v", err)
+	}
+
+	payload := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json"}`)
+
+	signature1, err := createX509Signature(pki.Leaves["signer1"], payload)
+	if err != nil {
+		t.Fatalf("Can't create signature: %v", err)
+	}
+
+	signature2, err := createBundleSignature(pki.Leaves["signer2"], payload, time.Now())
+	if err != nil {
+		t.Fatalf("Can't create signature: %v", err)
+	}
+
+	tlsServerSignature, err := createX509Signature(pki.Leaves["tlsServer"], payload)
+	if err != nil {
+		t.Fatalf("Can't create signature: %v", err)
+	}
+
+	// Transparency log integrated time is not authenticated and must not extend certificate validity.
+	backdatedSignature, err := createBundleSignature(pki.Leaves["expired"], payload, time.Now().Add(-36*time.Hour))
+	if err != nil {
+		t.Fatalf("Can't create signature: %v", err)
+	}
+
+	untrustedSignature, err := createX509Signature(untrustedPKI.Leaves["signer3"], payload)
+	if err != nil {
+		t.Fatalf("Can't create signature: %v", err)
+	}
+
+	manifestFile := filepath.Join(workDir, "signed_manifest.json")
+
+	if err = os.WriteFile(manifestFile, payload, filePerm); err != nil {
+		t.Fatalf("Can't write manifest: %v", err)
+	}
+
+	testData := []struct {
+		name       string
+		policy     image.SignaturePolicy
+		payload    []byte
+		signatures []image.DetachedSignature
+		signers    []string
+		err        error
+	}{
+		{
+			name:       "x509 signature",
+			signatures: []image.DetachedSignature{signature1},
+			signers:    []string{"signer1"},
+		},
+		{
+			name:       "bundle signature",
+			policy:     image.SignaturePolicy{RequiredSigners: []string{"signer2"}},
+			signatures: []image.DetachedSignature{signature2},
+			signers:    []string{"signer2"},
+		},
+		{
+			name:       "threshold",
+			policy:     image.SignaturePolicy{Threshold: 2},
+			signatures: []image.DetachedSignature{signature1, untrustedSignature, signature2, signature1},
+			signers:    []string{"signer1", "signer2"},
+		},
+		{
+			name:       "threshold not met",
+			policy:     image.SignaturePolicy{Threshold: 2},
+			signatures: []image.DetachedSignature{signature1, signature1},
+			signers:    []string{"signer1"},
+			err:        image.ErrUntrustedSignature,
+		},
+		{
+			name:       "required signer missing",
+			policy:     image.SignaturePolicy{RequiredSigners: []string{"signer2"}},
+			signatures: []image.DetachedSignature{signature1},
+			signers:    []string{"signer1"},
+			err:        image.ErrUntrustedSignature,
+		},
+		{
+			name:       "untrusted signer",
+			signatures: []image.DetachedSignature{untrustedSignature},
+			err:        image.ErrUntrustedSignature,
+		},
+		{
+			name:       "not code signing certificate",
+			signatures: []image.DetachedSignature{tlsServerSignature},
+			err:        image.ErrUntrustedSignature,
+		},
+		{
+			name:       "backdated integrated time",
+			signatures: []image.DetachedSignature{backdatedSignature},
+			err:        image.ErrUntrustedSignature,
+		},
+		{
+			name:       "tampered payload",
+			payload:    append([]byte{}, payload[1:]...),
+			signatures: []image.DetachedSignature{signature1, signature2},
+			err:        image.ErrUntrustedSignature,
+		},
+	}
+
+	for _, item := range testData {
+		t.Run(item.name, func(t *testing.T) {
+			verifier, err := image.NewSignatureVerifier(pki.RootPool(), item.policy)
+			if err != nil {
+				t.Fatalf("Can't create verifier: %v", err)
+			}
+
+			var signers []string
+
+			if item.payload != nil {
+				signers, err = verifier.Verify(item.payload, item.signatures)
+			} else {
+				signers, err = verifier.VerifyFile(manifestFile, item.signatures)
+			}
+
+			if !errors.Is(err, item.err) {
+				t.Errorf("Wrong verification error: %v", err)
+			}
+
+			if !reflect.DeepEqual(signers, item.signers) {
+				t.Errorf("Wrong signers: %v", signers)
+			}
+		})
+	}
+}
+
 /*******************************************************************************
  * Private
  ******************************************************************************/
@@ -517,6 +918,57 @@ func generateAndSaveDigest(folder string, data []byte) (retDigest digest.Digest,
 	return retDigest, nil
 }
 
+// createTestDelta creates BSDIFF40 delta from raw control values and blocks.
+func createTestDelta(t *testing.T, newSize int64, ctrl []int64, diff, extra []byte) []byte {
+	t.Helper()
+
+	encodeOffset := func(value int64) []byte {
+		data := make([]byte, 8)
+
+		if value < 0 {
+			binary.LittleEndian.PutUint64(data, uint64(-value))
+			data[7] |= 0x80
+		} else {
+			binary.LittleEndian.PutUint64(data, uint64(value))
+		}
+
+		return data
+	}
+
+	var ctrlBlock bytes.Buffer
+
+	for _, value := range ctrl {
+		ctrlBlock.Write(encodeOffset(value))
+	}
+
+	blocks := make([][]byte, 0, 3)
+
+	for _, block := range [][]byte{ctrlBlock.Bytes(), diff, extra} {
+		cmd := exec.Command("bzip2", "-c")
+
+		cmd.Stdin = bytes.NewReader(block)
+
+		compressed, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("Can't compress block: %v", err)
+		}
+
+		blocks = append(blocks, compressed)
+	}
+
+	delta := bytes.NewBufferString("BSDIFF40")
+
+	delta.Write(encodeOffset(int64(len(blocks[0]))))
+	delta.Write(encodeOffset(int64(len(blocks[1]))))
+	delta.Write(encodeOffset(newSize))
+
+	for _, block := range blocks {
+		delta.Write(block)
+	}
+
+	return delta.Bytes()
+}
+
 func createTestDir(dirName string, sizeContent int64) (string, error) {
 	tmpFolder := filepath.Join(workDir, dirName)
 
@@ -577,3 +1029,56 @@ func gzipFile(dst, src string) error {
 
 	return nil
 }
+
+func createX509Signature(signer testtools.PKICert, payload []byte) (image.DetachedSignature, error) {
+	sum := sha256.Sum256(payload)
+
+	signature, err := signer.Key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	if err != nil {
+		return image.DetachedSignature{}, aoserrors.Wrap(err)
+	}
+
+	var chain []byte
+
+	for _, cert := range signer.Chain[:len(signer.Chain)-1] {
+		chain = append(chain, cryptutils.CertToPEM(cert)...)
+	}
+
+	return image.DetachedSignature{
+		Format: image.SignatureFormatX509, Data: signature, CertificateChain: chain,
+	}, nil
+}
+
+func createBundleSignature(
+	signer testtools.PKICert, payload []byte, integratedTime time.Time,
+) (image.DetachedSignature, error) {
+	sum := sha256.Sum256(payload)
+
+	signature, err := signer.Key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	if err != nil {
+		return image.DetachedSignature{}, aoserrors.Wrap(err)
+	}
+
+	certificates := make([]map[string][]byte, 0, len(signer.Chain)-1)
+
+	for _, cert := range signer.Chain[:len(signer.Chain)-1] {
+		certificates = append(certificates, map[string][]byte{"rawBytes": cert.Raw})
+	}
+
+	bundle, err := json.Marshal(map[string]any{
+		"mediaType": "application/vnd.dev.sigstore.bundle.v0.3+json",
+		"verificationMaterial": map[string]any{
+			"x509CertificateChain": map[string]any{"certificates": certificates},
+			"tlogEntries":          []map[string]string{{"integratedTime": strconv.FormatInt(integratedTime.Unix(), 10)}},
+		},
+		"messageSignature": map[string]any{
+			"messageDigest": map[string]any{"algorithm": "SHA2_256", "digest": sum[:]},
+			"signature":     signature,
+		},
+	})
+	if err != nil {
+		return image.DetachedSignature{}, aoserrors.Wrap(err)
+	}
+
+	return image.DetachedSignature{Format: image.SignatureFormatBundle, Data: bundle}, nil
+}
@@ -0,0 +1,405 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/cryptutils"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Signature formats.
+const (
+	// SignatureFormatX509 raw signature over payload with PEM signer certificate chain.
+	SignatureFormatX509 = "x509"
+	// SignatureFormatBundle Sigstore bundle with message signature.
+	SignatureFormatBundle = "sigstoreBundle"
+)
+
+const bundleMediaTypePrefix = "application/vnd.dev.sigstore.bundle"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// DetachedSignature detached signature of image manifest or artifact.
+type DetachedSignature struct {
+	// Format signature format: SignatureFormatX509 or SignatureFormatBundle.
+	Format string
+	// Data raw signature for x509 format or bundle JSON for bundle format.
+	Data []byte
+	// CertificateChain PEM signer certificate chain starting from the leaf certificate, used by x509 format.
+	CertificateChain []byte
+}
+
+// SignaturePolicy defines which signatures are required to trust payload.
+type SignaturePolicy struct {
+	// RequiredSigners identities which must be among valid signers. Signer identities are certificate email and URI
+	// SANs and subject common name.
+	RequiredSigners []string
+	// Threshold minimal number of distinct valid signers, at least one signer is always required.
+	Threshold int
+}
+
+// SignatureVerifier verifies detached signatures against trust roots and policy.
+type SignatureVerifier struct {
+	roots  *x509.CertPool
+	policy SignaturePolicy
+}
+
+type sigstoreBundle struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		Certificate *struct {
+			RawBytes []byte `json:"rawBytes"`
+		} `json:"certificate,omitempty"`
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes []byte `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain,omitempty"`
+	} `json:"verificationMaterial"`
+	MessageSignature *struct {
+		MessageDigest struct {
+			Algorithm string `json:"algorithm"`
+			Digest    []byte `json:"digest"`
+		} `json:"messageDigest"`
+		Signature []byte `json:"signature"`
+	} `json:"messageSignature,omitempty"`
+	DSSEEnvelope json.RawMessage `json:"dsseEnvelope,omitempty"`
+}
+
+type payloadDigests map[crypto.Hash][]byte
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// ErrUntrustedSignature returned when signatures don't satisfy signature policy.
+var ErrUntrustedSignature = errors.New("untrusted signature")
+
+//nolint:gochecknoglobals // supported payload digest algorithms
+var signatureHashes = []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewSignatureVerifier creates signature verifier with trust roots and policy.
+func NewSignatureVerifier(roots *x509.CertPool, policy SignaturePolicy) (*SignatureVerifier, error) {
+	if roots == nil {
+		return nil, aoserrors.New("trust roots are not set")
+	}
+
+	if policy.Threshold < 0 {
+		return nil, aoserrors.Errorf("invalid signature threshold: %d", policy.Threshold)
+	}
+
+	return &SignatureVerifier{roots: roots, policy: policy}, nil
+}
+
+// Verify verifies detached signatures over payload and returns identities of valid signers. Error wrapping
+// ErrUntrustedSignature is returned if valid signatures don't satisfy the policy.
+func (verifier *SignatureVerifier) Verify(payload []byte, signatures []DetachedSignature) ([]string, error) {
+	digests, err := calculatePayloadDigests(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	return verifier.verifyDigests(digests, signatures)
+}
+
+// VerifyFile verifies detached signatures over file content, e.g. image manifest, before installation.
+func (verifier *SignatureVerifier) VerifyFile(fileName string, signatures []DetachedSignature) ([]string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	digests, err := calculatePayloadDigests(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifier.verifyDigests(digests, signatures)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (verifier *SignatureVerifier) verifyDigests(
+	digests payloadDigests, signatures []DetachedSignature,
+) (signers []string, err error) {
+	var identities []string
+
+	for i, signature := range signatures {
+		var signerIdentities []string
+
+		switch signature.Format {
+		case SignatureFormatX509:
+			signerIdentities, err = verifier.verifyX509Signature(digests, signature)
+
+		case SignatureFormatBundle:
+			signerIdentities, err = verifier.verifyBundle(digests, signature.Data)
+
+		default:
+			err = aoserrors.Errorf("unsupported signature format: %s", signature.Format)
+		}
+
+		if err != nil {
+			log.WithFields(log.Fields{"index": i, "format": signature.Format}).Warnf("Invalid signature: %v", err)
+
+			continue
+		}
+
+		if slices.Contains(signers, signerIdentities[0]) {
+			continue
+		}
+
+		signers = append(signers, signerIdentities[0])
+		identities = append(identities, signerIdentities...)
+	}
+
+	threshold := max(verifier.policy.Threshold, 1)
+
+	if len(signers) < threshold {
+		return signers, aoserrors.Errorf("%w: %d valid signers, %d required",
+			ErrUntrustedSignature, len(signers), threshold)
+	}
+
+	for _, required := range verifier.policy.RequiredSigners {
+		if !slices.Contains(identities, required) {
+			return signers, aoserrors.Errorf("%w: required signer %s not found", ErrUntrustedSignature, required)
+		}
+	}
+
+	return signers, nil
+}
+
+func (verifier *SignatureVerifier) verifyX509Signature(
+	digests payloadDigests, signature DetachedSignature,
+) ([]string, error) {
+	certs, err := cryptutils.PEMToX509Cert(signature.CertificateChain)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if err = verifier.verifyCertificate(certs); err != nil {
+		return nil, err
+	}
+
+	if err = verifyDigestSignature(certs[0].PublicKey, digests, signature.Data); err != nil {
+		return nil, err
+	}
+
+	return getSignerIdentities(certs[0]), nil
+}
+
+func (verifier *SignatureVerifier) verifyBundle(digests payloadDigests, data []byte) ([]string, error) {
+	var bundle sigstoreBundle
+
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if !strings.HasPrefix(bundle.MediaType, bundleMediaTypePrefix) {
+		return nil, aoserrors.Errorf("unsupported bundle media type: %s", bundle.MediaType)
+	}
+
+	if bundle.MessageSignature == nil {
+		if bundle.DSSEEnvelope != nil {
+			return nil, aoserrors.Errorf("DSSE envelope bundle: %w", aoserrors.ErrNotSupported)
+		}
+
+		return nil, aoserrors.New("bundle has no message signature")
+	}
+
+	var rawCerts [][]byte
+
+	material := bundle.VerificationMaterial
+
+	switch {
+	case material.Certificate != nil:
+		rawCerts = append(rawCerts, material.Certificate.RawBytes)
+
+	case material.X509CertificateChain != nil:
+		for _, cert := range material.X509CertificateChain.Certificates {
+			rawCerts = append(rawCerts, cert.RawBytes)
+		}
+	}
+
+	if len(rawCerts) == 0 {
+		return nil, aoserrors.New("bundle has no signer certificate")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+
+	for _, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	// Transparency log entries are not verified, so their integrated time can't be trusted: certificate is verified at
+	// current time.
+	if err := verifier.verifyCertificate(certs); err != nil {
+		return nil, err
+	}
+
+	messageDigest := bundle.MessageSignature.MessageDigest
+
+	if messageDigest.Algorithm != "SHA2_256" {
+		return nil, aoserrors.Errorf("unsupported bundle digest algorithm: %s", messageDigest.Algorithm)
+	}
+
+	if !bytes.Equal(messageDigest.Digest, digests[crypto.SHA256]) {
+		return nil, aoserrors.Errorf("bundle message digest mismatch: %w", aoserrors.ErrInvalidChecksum)
+	}
+
+	if err := verifySignatureWithHash(
+		certs[0].PublicKey, crypto.SHA256, digests[crypto.SHA256], bundle.MessageSignature.Signature); err != nil {
+		return nil, err
+	}
+
+	return getSignerIdentities(certs[0]), nil
+}
+
+func (verifier *SignatureVerifier) verifyCertificate(certs []*x509.Certificate) error {
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots: verifier.roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func calculatePayloadDigests(reader io.Reader) (payloadDigests, error) {
+	hashes := make([]hash.Hash, 0, len(signatureHashes))
+	writers := make([]io.Writer, 0, len(signatureHashes))
+
+	for _, signatureHash := range signatureHashes {
+		hasher := signatureHash.New()
+
+		hashes = append(hashes, hasher)
+		writers = append(writers, hasher)
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), reader); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	digests := make(payloadDigests)
+
+	for i, signatureHash := range signatureHashes {
+		digests[signatureHash] = hashes[i].Sum(nil)
+	}
+
+	return digests, nil
+}
+
+// verifyDigestSignature verifies signature selecting hash by key type: SHA-256 for RSA and hash matching curve size
+// for ECDSA keys.
+func verifyDigestSignature(publicKey crypto.PublicKey, digests payloadDigests, signature []byte) error {
+	signatureHash := crypto.SHA256
+
+	if ecdsaKey, ok := publicKey.(*ecdsa.PublicKey); ok {
+		switch ecdsaKey.Curve {
+		case elliptic.P384():
+			signatureHash = crypto.SHA384
+
+		case elliptic.P521():
+			signatureHash = crypto.SHA512
+		}
+	}
+
+	return verifySignatureWithHash(publicKey, signatureHash, digests[signatureHash], signature)
+}
+
+func verifySignatureWithHash(
+	publicKey crypto.PublicKey, signatureHash crypto.Hash, digest, signature []byte,
+) error {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, signatureHash, digest, signature); err != nil {
+			if pssErr := rsa.VerifyPSS(key, signatureHash, digest, signature, nil); pssErr != nil {
+				return aoserrors.Wrap(err)
+			}
+		}
+
+		return nil
+
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return aoserrors.New("invalid signature")
+		}
+
+		return nil
+
+	default:
+		return aoserrors.Errorf("unsupported signer key type: %T", publicKey)
+	}
+}
+
+func getSignerIdentities(cert *x509.Certificate) (identities []string) {
+	identities = append(identities, cert.EmailAddresses...)
+
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+
+	if len(identities) == 0 {
+		identities = append(identities, cryptutils.CertFingerprint(cert))
+	}
+
+	return identities
+}
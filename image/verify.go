@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"context"
+	_ "crypto/sha256" // register sha256 digest algorithm
+	_ "crypto/sha512" // register sha512 digest algorithm
+	"errors"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/opencontainers/go-digest"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/contextreader"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// PartInfo expected size and digests of artifact part. Zero size means size is unknown, it is allowed for the last
+// part only.
+type PartInfo struct {
+	Size    int64
+	Digests []digest.Digest
+}
+
+type verifyingReader struct {
+	reader    io.Reader
+	parts     []PartInfo
+	partIndex int
+	partRead  int64
+	hashes    []hash.Hash
+	err       error
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewVerifyingReader creates reader which calculates digests of data read through it and verifies them against
+// expected parts. Each part is verified as soon as it is read: reading is aborted on the first mismatched part with
+// error wrapping aoserrors.ErrInvalidChecksum.
+func NewVerifyingReader(reader io.Reader, parts ...PartInfo) (io.Reader, error) {
+	if len(parts) == 0 {
+		return nil, aoserrors.New("no parts to verify")
+	}
+
+	for i, part := range parts {
+		if part.Size < 0 || (part.Size == 0 && i != len(parts)-1) {
+			return nil, aoserrors.Errorf("invalid part %d size: %d", i, part.Size)
+		}
+
+		for _, partDigest := range part.Digests {
+			if err := partDigest.Validate(); err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+		}
+	}
+
+	verifier := &verifyingReader{reader: reader, parts: parts}
+
+	verifier.startPart()
+
+	return verifier, nil
+}
+
+// CopyVerified copies source data to destination file verifying it on the fly. Destination file is removed if
+// verification fails.
+func CopyVerified(ctx context.Context, dst string, src io.Reader, parts ...PartInfo) (copied int64, err error) {
+	log.WithFields(log.Fields{"dst": dst, "parts": len(parts)}).Debug("Start verified copy")
+
+	verifier, err := NewVerifyingReader(contextreader.New(ctx, src), parts...)
+	if err != nil {
+		return 0, err
+	}
+
+	dstFile, err := os.Create(dst)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if closeErr := dstFile.Close(); closeErr != nil && err == nil {
+			err = aoserrors.Wrap(closeErr)
+		}
+
+		if err != nil {
+			if removeErr := os.Remove(dst); removeErr != nil {
+				log.Errorf("Can't remove destination file: %v", removeErr)
+			}
+		}
+	}()
+
+	copied, duration, err := copyData(dstFile, verifier)
+	if err != nil {
+		return copied, aoserrors.Wrap(err)
+	}
+
+	log.WithFields(log.Fields{"copied": copied, "duration": duration}).Debug("Verified copy finished")
+
+	return copied, nil
+}
+
+// Read reads data and verifies completed parts.
+func (verifier *verifyingReader) Read(buffer []byte) (readCount int, err error) {
+	if verifier.err != nil {
+		return 0, verifier.err
+	}
+
+	if verifier.partIndex < len(verifier.parts) {
+		if size := verifier.parts[verifier.partIndex].Size; size > 0 && int64(len(buffer)) > size-verifier.partRead {
+			buffer = buffer[:size-verifier.partRead]
+		}
+	}
+
+	readCount, err = verifier.reader.Read(buffer)
+
+	if readCount > 0 {
+		if verifier.partIndex >= len(verifier.parts) {
+			return 0, verifier.fail(aoserrors.Errorf("unexpected data after last part: %w", aoserrors.ErrOutOfRange))
+		}
+
+		for _, partHash := range verifier.hashes {
+			partHash.Write(buffer[:readCount])
+		}
+
+		verifier.partRead += int64(readCount)
+
+		if size := verifier.parts[verifier.partIndex].Size; size > 0 && verifier.partRead == size {
+			if verifyErr := verifier.finishPart(); verifyErr != nil {
+				return readCount, verifier.fail(verifyErr)
+			}
+		}
+	}
+
+	if errors.Is(err, io.EOF) {
+		if verifier.partIndex < len(verifier.parts) && verifier.parts[verifier.partIndex].Size == 0 {
+			if verifyErr := verifier.finishPart(); verifyErr != nil {
+				return readCount, verifier.fail(verifyErr)
+			}
+		}
+
+		if verifier.partIndex < len(verifier.parts) {
+			return readCount, verifier.fail(aoserrors.Errorf(
+				"part %d size mismatch: %w", verifier.partIndex, aoserrors.ErrInvalidChecksum))
+		}
+	}
+
+	return readCount, err //nolint:wrapcheck // io.Reader errors should not be wrapped
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (verifier *verifyingReader) startPart() {
+	verifier.partRead = 0
+	verifier.hashes = nil
+
+	if verifier.partIndex >= len(verifier.parts) {
+		return
+	}
+
+	for _, partDigest := range verifier.parts[verifier.partIndex].Digests {
+		verifier.hashes = append(verifier.hashes, partDigest.Algorithm().Hash())
+	}
+}
+
+func (verifier *verifyingReader) finishPart() error {
+	for i, partDigest := range verifier.parts[verifier.partIndex].Digests {
+		if digest.NewDigest(partDigest.Algorithm(), verifier.hashes[i]) != partDigest {
+			return aoserrors.Errorf("part %d %s digest mismatch: %w",
+				verifier.partIndex, partDigest.Algorithm(), aoserrors.ErrInvalidChecksum)
+		}
+	}
+
+	verifier.partIndex++
+	verifier.startPart()
+
+	return nil
+}
+
+func (verifier *verifyingReader) fail(err error) error {
+	verifier.err = err
+
+	return err
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// AlertsBatchSender sends grouped alerts. Sender should implement this interface to receive batched alerts.
+type AlertsBatchSender interface {
+	SendAlerts(alerts cloudprotocol.Alerts)
+}
+
+// BatchConfig alerts batching configuration.
+type BatchConfig struct {
+	// MaxCount max number of alerts in one batch.
+	MaxCount int `json:"maxCount"`
+	// MaxDelay max time alert waits in batch before sending.
+	MaxDelay aostypes.Duration `json:"maxDelay"`
+}
+
+type alertBatcher struct {
+	sync.Mutex
+	config BatchConfig
+	sender AlertsBatchSender
+	items  []interface{}
+	timer  *time.Timer
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func isBatchEnabled(config BatchConfig) bool {
+	return config.MaxCount > 1 || config.MaxDelay.Duration > 0
+}
+
+func newAlertBatcher(config BatchConfig, sender AlertSender) *alertBatcher {
+	if !isBatchEnabled(config) {
+		return nil
+	}
+
+	batchSender, ok := sender.(AlertsBatchSender)
+	if !ok {
+		log.Warn("Alert sender doesn't support batches, alerts will be sent one by one")
+
+		return nil
+	}
+
+	return &alertBatcher{config: config, sender: batchSender}
+}
+
+func (batcher *alertBatcher) add(alert interface{}) {
+	batcher.Lock()
+	defer batcher.Unlock()
+
+	batcher.items = append(batcher.items, alert)
+
+	if batcher.config.MaxCount > 0 && len(batcher.items) >= batcher.config.MaxCount {
+		batcher.sendItems()
+
+		return
+	}
+
+	if batcher.timer == nil && batcher.config.MaxDelay.Duration > 0 {
+		batcher.timer = time.AfterFunc(batcher.config.MaxDelay.Duration, batcher.flush)
+	}
+}
+
+func (batcher *alertBatcher) flush() {
+	batcher.Lock()
+	defer batcher.Unlock()
+
+	batcher.sendItems()
+}
+
+func (batcher *alertBatcher) sendItems() {
+	if batcher.timer != nil {
+		batcher.timer.Stop()
+		batcher.timer = nil
+	}
+
+	if len(batcher.items) == 0 {
+		return
+	}
+
+	batcher.sender.SendAlerts(cloudprotocol.Alerts{MessageType: cloudprotocol.AlertsMessageType, Items: batcher.items})
+
+	batcher.items = nil
+}
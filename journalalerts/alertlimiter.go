@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const rateLimitWindow = 1 * time.Minute
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// RateLimitConfig alerts rate limit configuration.
+type RateLimitConfig struct {
+	// MaxAlertsPerMinute max number of alerts sent per source (unit) per minute, 0 - unlimited.
+	MaxAlertsPerMinute int `json:"maxAlertsPerMinute"`
+	// Deduplicate suppresses identical consecutive messages from the same source and reports
+	// them as a single alert with "repeated X times" suffix.
+	Deduplicate bool `json:"deduplicate"`
+}
+
+type alertLimiter struct {
+	sync.Mutex
+	config  RateLimitConfig
+	sources map[string]*alertSourceState
+}
+
+type alertSourceState struct {
+	lastSeen     time.Time
+	windowStart  time.Time
+	windowCount  int
+	droppedCount int
+	lastMessage  string
+	lastAlert    interface{}
+	repeatCount  int
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newAlertLimiter(config RateLimitConfig) *alertLimiter {
+	return &alertLimiter{config: config, sources: make(map[string]*alertSourceState)}
+}
+
+func (limiter *alertLimiter) enabled() bool {
+	return limiter.config.MaxAlertsPerMinute > 0 || limiter.config.Deduplicate
+}
+
+// process returns list of alerts which should be sent for the new alert from the source.
+func (limiter *alertLimiter) process(source string, alert interface{}) (alerts []interface{}) {
+	if !limiter.enabled() {
+		return []interface{}{alert}
+	}
+
+	limiter.Lock()
+	defer limiter.Unlock()
+
+	state, ok := limiter.sources[source]
+	if !ok {
+		state = &alertSourceState{}
+		limiter.sources[source] = state
+	}
+
+	state.lastSeen = time.Now()
+
+	message := cloudprotocol.GetAlertMessage(alert)
+
+	if limiter.config.Deduplicate {
+		if state.lastAlert != nil && message == state.lastMessage {
+			state.repeatCount++
+
+			return nil
+		}
+
+		if repeated := state.takeRepeated(); repeated != nil {
+			alerts = append(alerts, repeated)
+		}
+
+		state.lastMessage = message
+		state.lastAlert = alert
+	}
+
+	if limiter.config.MaxAlertsPerMinute > 0 {
+		now := time.Now()
+
+		if now.Sub(state.windowStart) >= rateLimitWindow {
+			state.logDropped(source)
+
+			state.windowStart = now
+			state.windowCount = 0
+			state.droppedCount = 0
+		}
+
+		if state.windowCount >= limiter.config.MaxAlertsPerMinute {
+			state.droppedCount++
+
+			return alerts
+		}
+
+		state.windowCount++
+	}
+
+	return append(alerts, alert)
+}
+
+// flush returns pending repeated alerts of all sources and removes sources without alerts during rate limit window.
+func (limiter *alertLimiter) flush() (alerts []interface{}) {
+	if !limiter.enabled() {
+		return nil
+	}
+
+	limiter.Lock()
+	defer limiter.Unlock()
+
+	now := time.Now()
+
+	for source, state := range limiter.sources {
+		if repeated := state.takeRepeated(); repeated != nil {
+			alerts = append(alerts, repeated)
+		}
+
+		if now.Sub(state.lastSeen) >= rateLimitWindow {
+			state.logDropped(source)
+
+			delete(limiter.sources, source)
+		}
+	}
+
+	return alerts
+}
+
+func (state *alertSourceState) logDropped(source string) {
+	if state.droppedCount > 0 {
+		log.WithFields(log.Fields{
+			"source": source, "dropped": state.droppedCount,
+		}).Warn("Alerts dropped due to rate limit")
+	}
+}
+
+func (state *alertSourceState) takeRepeated() (alert interface{}) {
+	if state.repeatCount == 0 {
+		return nil
+	}
+
+	alert = cloudprotocol.SetAlertMessage(state.lastAlert,
+		fmt.Sprintf("%s (repeated %d times)", state.lastMessage, state.repeatCount))
+
+	state.repeatCount = 0
+
+	return alert
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestAlertLimiterEviction(t *testing.T) {
+	limiter := newAlertLimiter(RateLimitConfig{MaxAlertsPerMinute: 1, Deduplicate: true})
+
+	for i := 0; i < 10; i++ {
+		limiter.process(fmt.Sprintf("unit%d.service", i), cloudprotocol.SystemAlert{Message: "message"})
+	}
+
+	limiter.process("unit0.service", cloudprotocol.SystemAlert{Message: "message"})
+
+	if alerts := limiter.flush(); len(alerts) != 1 {
+		t.Errorf("Wrong repeated alerts count: %d", len(alerts))
+	}
+
+	if len(limiter.sources) != 10 {
+		t.Errorf("Active sources should not be evicted: %d", len(limiter.sources))
+	}
+
+	// Make all sources except unit0 idle for rate limit window
+	for source, state := range limiter.sources {
+		if source != "unit0.service" {
+			state.lastSeen = time.Now().Add(-rateLimitWindow)
+		}
+	}
+
+	limiter.flush()
+
+	if _, ok := limiter.sources["unit0.service"]; !ok || len(limiter.sources) != 1 {
+		t.Errorf("Idle sources should be evicted: %d", len(limiter.sources))
+	}
+
+	// Evicted source starts new rate limit window
+	if alerts := limiter.process("unit1.service", cloudprotocol.SystemAlert{Message: "message"}); len(alerts) != 1 {
+		t.Errorf("Alert of evicted source should be sent: %d", len(alerts))
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const auditTransport = "audit"
+
+const (
+	auditFieldType     = "_AUDIT_TYPE"
+	auditFieldTypeName = "_AUDIT_TYPE_NAME"
+)
+
+const auditResultFailed = "res=failed"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// AuditConfig audit records processing configuration.
+type AuditConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// InstanceUIDProvider optional interface of InstanceInfoProvider to attribute audit records to service instances
+// by user ID.
+type InstanceUIDProvider interface {
+	GetInstanceIDByUID(uid int) (instanceID string, err error)
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// auditTypeNames maps numeric audit record types to names for journals which don't provide _AUDIT_TYPE_NAME.
+//
+//nolint:gochecknoglobals
+var auditTypeNames = map[string]string{
+	"1100": "USER_AUTH",
+	"1101": "USER_ACCT",
+	"1107": "USER_AVC",
+	"1112": "USER_LOGIN",
+	"1326": "SECCOMP",
+	"1400": "AVC",
+	"1401": "SELINUX_ERR",
+	"1402": "AVC_PATH",
+	"2100": "ANOM_LOGIN_FAILURES",
+}
+
+var (
+	auditPIDRegexp = regexp.MustCompile(`\bpid=(\d+)`) //nolint:gochecknoglobals
+	auditUIDRegexp = regexp.MustCompile(`\buid=(\d+)`) //nolint:gochecknoglobals
+)
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func isAuditEntry(entry *sdjournal.JournalEntry) bool {
+	return entry.Fields[sdjournal.SD_JOURNAL_FIELD_TRANSPORT] == auditTransport
+}
+
+func getAuditCategory(typeName, message string) string {
+	switch typeName {
+	case "AVC", "USER_AVC":
+		if strings.Contains(message, "denied") || strings.Contains(message, "DENIED") {
+			return cloudprotocol.SecurityAlertCategoryAVCDenial
+		}
+
+	case "USER_AUTH", "USER_ACCT", "USER_LOGIN", "USER_ERR", "LOGIN":
+		if strings.Contains(message, auditResultFailed) {
+			return cloudprotocol.SecurityAlertCategoryLoginFailure
+		}
+
+	case "ANOM_LOGIN_FAILURES", "ANOM_LOGIN_TIME", "ANOM_LOGIN_SESSIONS", "ANOM_LOGIN_LOCATION":
+		return cloudprotocol.SecurityAlertCategoryLoginFailure
+
+	case "SELINUX_ERR", "USER_SELINUX_ERR", "SECCOMP", "INTEGRITY_DATA", "INTEGRITY_METADATA", "INTEGRITY_RULE":
+		return cloudprotocol.SecurityAlertCategoryPolicyViolation
+	}
+
+	return ""
+}
+
+func (instance *JournalAlerts) getSecurityAlert(entry *sdjournal.JournalEntry) *cloudprotocol.SecurityAlert {
+	message := entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE]
+
+	typeName := entry.Fields[auditFieldTypeName]
+	if typeName == "" {
+		typeName = auditTypeNames[entry.Fields[auditFieldType]]
+	}
+
+	category := getAuditCategory(typeName, message)
+	if category == "" {
+		return nil
+	}
+
+	alert := &cloudprotocol.SecurityAlert{
+		Category:  category,
+		AuditType: typeName,
+		PID:       getAuditField(entry, sdjournal.SD_JOURNAL_FIELD_PID, auditPIDRegexp),
+		UID:       getAuditField(entry, sdjournal.SD_JOURNAL_FIELD_UID, auditUIDRegexp),
+		Message:   message,
+	}
+
+	if instance.instanceProvider == nil {
+		return alert
+	}
+
+	instanceID, ok := instance.getAuditInstanceID(entry, alert.UID)
+	if !ok {
+		return alert
+	}
+
+	instanceIdent, _, err := instance.getInstanceInfo(instanceID)
+	if err != nil {
+		log.Errorf("Can't get instance info: %s", err)
+
+		return alert
+	}
+
+	alert.InstanceIdent = &instanceIdent
+
+	return alert
+}
+
+func (instance *JournalAlerts) getAuditInstanceID(entry *sdjournal.JournalEntry, uid string) (string, bool) {
+	for _, unitName := range []string{
+		entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT], entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_CGROUP],
+	} {
+		if instanceID, ok := instance.getInstanceUnitID(unitName); ok {
+			return instanceID, true
+		}
+
+		if instanceID, ok := getInstanceID(unitName); ok {
+			return instanceID, true
+		}
+	}
+
+	uidProvider, ok := instance.instanceProvider.(InstanceUIDProvider)
+	if !ok || uid == "" {
+		return "", false
+	}
+
+	uidValue, err := strconv.Atoi(uid)
+	if err != nil {
+		return "", false
+	}
+
+	instanceID, err := uidProvider.GetInstanceIDByUID(uidValue)
+	if err != nil || instanceID == "" {
+		return "", false
+	}
+
+	return instanceID, true
+}
+
+func getAuditField(entry *sdjournal.JournalEntry, field string, fieldRegexp *regexp.Regexp) string {
+	if value := entry.Fields[field]; value != "" {
+		return value
+	}
+
+	if match := fieldRegexp.FindStringSubmatch(entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE]); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
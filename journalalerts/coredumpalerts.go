@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// systemd-coredump "Process dumped core" message ID.
+const coredumpMessageID = "fc2e22bc6ee647b6b90729ab34a250b1"
+
+const (
+	coredumpFieldExe        = "COREDUMP_EXE"
+	coredumpFieldComm       = "COREDUMP_COMM"
+	coredumpFieldPID        = "COREDUMP_PID"
+	coredumpFieldSignal     = "COREDUMP_SIGNAL"
+	coredumpFieldSignalName = "COREDUMP_SIGNAL_NAME"
+	coredumpFieldUnit       = "COREDUMP_UNIT"
+	coredumpFieldCgroup     = "COREDUMP_CGROUP"
+)
+
+const stackTracePrefix = "Stack trace of thread"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// CoredumpConfig coredump alerts configuration.
+type CoredumpConfig struct {
+	Enabled bool `json:"enabled"`
+	// BacktraceFrames number of backtrace frames of the crashed thread to include into alert, 0 - no backtrace.
+	BacktraceFrames int `json:"backtraceFrames"`
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func isCoredumpEntry(entry *sdjournal.JournalEntry) bool {
+	return entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE_ID] == coredumpMessageID
+}
+
+func (instance *JournalAlerts) getCrashAlert(entry *sdjournal.JournalEntry) *cloudprotocol.CrashAlert {
+	alert := &cloudprotocol.CrashAlert{
+		Executable: entry.Fields[coredumpFieldExe],
+		Signal:     entry.Fields[coredumpFieldSignalName],
+		PID:        entry.Fields[coredumpFieldPID],
+		Unit:       entry.Fields[coredumpFieldUnit],
+		Message:    getCrashMessage(entry),
+	}
+
+	if alert.Executable == "" {
+		alert.Executable = entry.Fields[coredumpFieldComm]
+	}
+
+	if alert.Signal == "" {
+		alert.Signal = entry.Fields[coredumpFieldSignal]
+	}
+
+	if instance.config.Coredump.BacktraceFrames > 0 {
+		alert.Backtrace = getBacktraceSummary(
+			entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE], instance.config.Coredump.BacktraceFrames)
+	}
+
+	if instance.instanceProvider == nil {
+		return alert
+	}
+
+	for _, unitName := range []string{entry.Fields[coredumpFieldUnit], entry.Fields[coredumpFieldCgroup]} {
+		instanceID, ok := getInstanceID(unitName)
+		if !ok {
+			continue
+		}
+
+		instanceIdent, _, err := instance.getInstanceInfo(instanceID)
+		if err != nil {
+			log.Errorf("Can't get instance info: %s", err)
+
+			continue
+		}
+
+		alert.InstanceIdent = &instanceIdent
+
+		break
+	}
+
+	return alert
+}
+
+func getCrashMessage(entry *sdjournal.JournalEntry) string {
+	message, _, _ := strings.Cut(entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE], "\n")
+
+	if message == "" {
+		message = fmt.Sprintf("Process %s (%s) dumped core", entry.Fields[coredumpFieldPID],
+			entry.Fields[coredumpFieldComm])
+	}
+
+	return message
+}
+
+// getBacktraceSummary returns first frames of the first thread stack trace from systemd-coredump message.
+func getBacktraceSummary(message string, maxFrames int) string {
+	var (
+		frames       []string
+		inStackTrace bool
+	)
+
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, stackTracePrefix) {
+			if inStackTrace {
+				break
+			}
+
+			inStackTrace = true
+
+			continue
+		}
+
+		if !inStackTrace {
+			continue
+		}
+
+		if line == "" || len(frames) >= maxFrames {
+			break
+		}
+
+		frames = append(frames, line)
+	}
+
+	return strings.Join(frames, "\n")
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"sync"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Cursor commit policies.
+const (
+	// CursorCommitPeriodic journal cursor is committed right after entry is processed (default).
+	CursorCommitPeriodic = "periodic"
+	// CursorCommitAck journal cursor is committed only after all alerts of entry are acknowledged by sender.
+	CursorCommitAck = "ack"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// AckAlertSender alert sender which acknowledges alert delivery. Sender should call ack function once the alert
+// is delivered. It is required for CursorCommitAck policy.
+type AckAlertSender interface {
+	SendAlertWithAck(alert interface{}, ack func())
+}
+
+type pendingEntry struct {
+	reader  *journalReader
+	cursor  string
+	unacked int
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func getAckSender(policy string, sender AlertSender) (ackSender AckAlertSender, err error) {
+	switch policy {
+	case "", CursorCommitPeriodic:
+		return nil, nil
+
+	case CursorCommitAck:
+		ackSender, ok := sender.(AckAlertSender)
+		if !ok {
+			return nil, aoserrors.New("sender doesn't support alerts acknowledgement")
+		}
+
+		return ackSender, nil
+
+	default:
+		return nil, aoserrors.Errorf("unsupported cursor commit policy: %s", policy)
+	}
+}
+
+func (instance *JournalAlerts) processRecord(record journalRecord) {
+	if instance.ackSender == nil {
+		instance.processEntry(record.entry)
+
+		if record.entry.Cursor != "" {
+			instance.Lock()
+			record.reader.cursor = record.entry.Cursor
+			instance.Unlock()
+		}
+
+		return
+	}
+
+	// Entry holds one extra reference while processing to not commit the cursor before all alerts are sent.
+	entry := &pendingEntry{reader: record.reader, cursor: record.entry.Cursor, unacked: 1}
+
+	instance.Lock()
+	record.reader.pending = append(record.reader.pending, entry)
+	instance.Unlock()
+
+	instance.currentEntry = entry
+	instance.processEntry(record.entry)
+	instance.currentEntry = nil
+
+	instance.ackEntry(entry)
+}
+
+func (instance *JournalAlerts) sendAlertWithAck(alert interface{}) {
+	entry := instance.currentEntry
+	if entry == nil {
+		instance.ackSender.SendAlertWithAck(alert, func() {})
+
+		return
+	}
+
+	instance.Lock()
+	entry.unacked++
+	instance.Unlock()
+
+	var once sync.Once
+
+	instance.ackSender.SendAlertWithAck(alert, func() {
+		once.Do(func() { instance.ackEntry(entry) })
+	})
+}
+
+func (instance *JournalAlerts) ackEntry(entry *pendingEntry) {
+	instance.Lock()
+	defer instance.Unlock()
+
+	entry.unacked--
+
+	reader := entry.reader
+
+	for len(reader.pending) > 0 && reader.pending[0].unacked <= 0 {
+		if reader.pending[0].cursor != "" {
+			reader.cursor = reader.pending[0].cursor
+		}
+
+		reader.pending = reader.pending[1:]
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// EscalationRuleName rule name of escalated alerts.
+const EscalationRuleName = "escalation"
+
+const defaultEscalationPriority = 4 // LOG_WARNING
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// EscalationConfig escalates repeated messages which are not reported by priority (e.g. warnings) to error alert.
+type EscalationConfig struct {
+	Enabled bool `json:"enabled"`
+	// Priority max journal priority of messages to be counted, default is warning (4).
+	Priority int `json:"priority"`
+	// Count number of messages from the same unit within window to escalate.
+	Count int `json:"count"`
+	// Window time window messages are counted in.
+	Window aostypes.Duration `json:"window"`
+}
+
+// EscalationStateStorage stores escalation state together with journal cursor. It may be optionally implemented by
+// cursor storage to keep escalation counters across restarts.
+type EscalationStateStorage interface {
+	SetEscalationState(state []byte) error
+	GetEscalationState() (state []byte, err error)
+}
+
+type alertEscalator struct {
+	sync.Mutex
+	config      EscalationConfig
+	occurrences map[string][]time.Time
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newAlertEscalator(config EscalationConfig) *alertEscalator {
+	if !config.Enabled {
+		return nil
+	}
+
+	if config.Priority == 0 {
+		config.Priority = defaultEscalationPriority
+	}
+
+	if config.Count <= 0 || config.Window.Duration <= 0 {
+		log.Warning("Escalation count or window is not set, escalation is disabled")
+
+		return nil
+	}
+
+	return &alertEscalator{config: config, occurrences: make(map[string][]time.Time)}
+}
+
+// process counts entry of the unit and returns true if the unit should be escalated.
+func (escalator *alertEscalator) process(unit string, priority int, timestamp time.Time) (count int, escalate bool) {
+	if priority > escalator.config.Priority {
+		return 0, false
+	}
+
+	escalator.Lock()
+	defer escalator.Unlock()
+
+	occurrences := append(escalator.prune(escalator.occurrences[unit], timestamp), timestamp)
+
+	if len(occurrences) < escalator.config.Count {
+		escalator.occurrences[unit] = occurrences
+
+		return 0, false
+	}
+
+	delete(escalator.occurrences, unit)
+
+	return len(occurrences), true
+}
+
+func (escalator *alertEscalator) prune(occurrences []time.Time, now time.Time) []time.Time {
+	windowStart := now.Add(-escalator.config.Window.Duration)
+
+	for len(occurrences) > 0 && !occurrences[0].After(windowStart) {
+		occurrences = occurrences[1:]
+	}
+
+	return occurrences
+}
+
+func (escalator *alertEscalator) encodeState() ([]byte, error) {
+	escalator.Lock()
+	defer escalator.Unlock()
+
+	data, err := json.Marshal(escalator.occurrences)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return data, nil
+}
+
+func (escalator *alertEscalator) decodeState(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	occurrences := make(map[string][]time.Time)
+
+	if err := json.Unmarshal(data, &occurrences); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	escalator.Lock()
+	defer escalator.Unlock()
+
+	escalator.occurrences = occurrences
+
+	return nil
+}
+
+func (instance *JournalAlerts) loadEscalationState() error {
+	stateStorage, ok := instance.cursorStorage.(EscalationStateStorage)
+	if instance.escalator == nil || !ok {
+		return nil
+	}
+
+	state, err := stateStorage.GetEscalationState()
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return instance.escalator.decodeState(state)
+}
+
+func (instance *JournalAlerts) storeEscalationState() error {
+	stateStorage, ok := instance.cursorStorage.(EscalationStateStorage)
+	if instance.escalator == nil || !ok {
+		return nil
+	}
+
+	state, err := instance.escalator.encodeState()
+	if err != nil {
+		return err
+	}
+
+	return aoserrors.Wrap(stateStorage.SetEscalationState(state))
+}
+
+func (instance *JournalAlerts) processEscalation(entry *sdjournal.JournalEntry, unit string) {
+	if instance.escalator == nil {
+		return
+	}
+
+	priority, err := strconv.Atoi(entry.Fields[sdjournal.SD_JOURNAL_FIELD_PRIORITY])
+	if err != nil {
+		return
+	}
+
+	alertItem := instance.createAlertItem(entry, cloudprotocol.AlertTagSystemError)
+
+	count, escalate := instance.escalator.process(unit, priority, alertItem.Timestamp)
+	if !escalate {
+		return
+	}
+
+	unitName := filepath.Base(unit)
+
+	instance.sendAlert(unitName, cloudprotocol.RuleAlert{
+		AlertItem: alertItem,
+		Rule:      EscalationRuleName,
+		Severity:  cloudprotocol.AlertSeverityError,
+		Message: fmt.Sprintf("%s: %d messages in %s, last: %s", unitName, count,
+			instance.escalator.config.Window.Duration, entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE]),
+		Parameters: map[string]string{"unit": unitName, "count": strconv.Itoa(count)},
+	})
+}
@@ -23,15 +23,17 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
-	"syscall"
+	"sync/atomic"
 	"time"
 
 	"github.com/aosedge/aos_common/aoserrors"
 	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/utils/alertutils"
 	"github.com/coreos/go-systemd/v22/sdjournal"
 	log "github.com/sirupsen/logrus"
 )
@@ -45,6 +47,8 @@ const aosServicePrefix = "aos-service@"
 const (
 	waitJournalTimeout = 1 * time.Second
 	journalSavePeriod  = 10 * time.Second
+	mergeRecordsDelay  = 100 * time.Millisecond
+	recordChannelSize  = 64
 )
 
 const microSecondsInSecond = 1000000
@@ -85,9 +89,40 @@ type JournalInterface interface {
 
 // Config alerts configuration.
 type Config struct {
-	Filter               []string `json:"filter"`
-	ServiceAlertPriority int      `json:"serviceAlertPriority"`
-	SystemAlertPriority  int      `json:"systemAlertPriority"`
+	Filter               []string        `json:"filter"`
+	ServiceAlertPriority int             `json:"serviceAlertPriority"`
+	SystemAlertPriority  int             `json:"systemAlertPriority"`
+	Kernel               KernelConfig    `json:"kernel"`
+	RateLimit            RateLimitConfig `json:"rateLimit"`
+	// ExtractFields journal fields (e.g. CODE_FILE, ERRNO, MESSAGE_ID) to be added to alert item fields.
+	ExtractFields []string `json:"extractFields"`
+	// UnitPriorities overrides SystemAlertPriority for systemd units matching glob pattern.
+	UnitPriorities map[string]int `json:"unitPriorities"`
+	// Sources journal sources to read alerts from. If empty, local system journal is used.
+	Sources  []JournalSource `json:"sources"`
+	Coredump CoredumpConfig  `json:"coredump"`
+	// Batch alerts batching, not supported with ack cursor commit policy.
+	Batch BatchConfig `json:"batch"`
+	// Rules classification rules evaluated before priority based alerts processing.
+	Rules []ClassificationRule `json:"rules"`
+	// MuteRules initial mute rules, may be changed at runtime with SetMuteRules.
+	MuteRules []MuteRule `json:"muteRules"`
+	// CursorCommit journal cursor commit policy: periodic (default) or ack.
+	CursorCommit string `json:"cursorCommit"`
+	// Audit audit records processing configuration.
+	Audit AuditConfig `json:"audit"`
+	// Escalation repeated low priority messages escalation configuration.
+	Escalation EscalationConfig `json:"escalation"`
+	// Spool raw journal entries which triggered alerts.
+	Spool SpoolConfig `json:"spool"`
+	// InstancePathNameFallback enables parsing instance ident from path name encoded unit name for instances unknown
+	// to instance info provider.
+	InstancePathNameFallback bool `json:"instancePathNameFallback"`
+}
+
+type unitPriority struct {
+	pattern  string
+	priority int
 }
 
 // JournalAlerts instance.
@@ -97,8 +132,25 @@ type JournalAlerts struct {
 	cursorStorage         CursorStorage
 	instanceProvider      InstanceInfoProvider
 	sender                AlertSender
+	ackSender             AckAlertSender
+	currentEntry          *pendingEntry
 	filterRegexp          []*regexp.Regexp
-	journal               JournalInterface
+	kernelClassifiers     []kernelClassifier
+	rules                 []classificationRule
+	limiter               *alertLimiter
+	batcher               *alertBatcher
+	suppressor            alertSuppressor
+	escalator             *alertEscalator
+	spool                 *entrySpool
+	matchedEntry          *sdjournal.JournalEntry
+	alertPolicy           atomic.Pointer[alertutils.AlertPolicy]
+	unitPriorities        []unitPriority
+	readers               []*journalReader
+	instanceUnits         map[string]string
+	unitStates            *unitStateCache
+	recordChannel         chan journalRecord
+	healthChannel         chan cloudprotocol.CoreAlert
+	readersWG             sync.WaitGroup
 	journalCancelFunction context.CancelFunc
 }
 
@@ -118,6 +170,9 @@ var coreComponents = []string{ //nolint:gochecknoglobals
 // SDJournal is using to mock systemd journal in unit tests.
 var SDJournal JournalInterface //nolint:gochecknoglobals
 
+// SDJournalOpener is using to mock systemd journal sources in unit tests.
+var SDJournalOpener func(source JournalSource) (JournalInterface, error) //nolint:gochecknoglobals
+
 /***********************************************************************************************************************
  * Public
  **********************************************************************************************************************/
@@ -132,6 +187,23 @@ func New(
 		config: config, cursorStorage: cursorStorage,
 		instanceProvider: instanceProvider,
 		sender:           sender,
+		limiter:          newAlertLimiter(config.RateLimit),
+		recordChannel:    make(chan journalRecord, recordChannelSize),
+		healthChannel:    make(chan cloudprotocol.CoreAlert, 1),
+		instanceUnits:    make(map[string]string),
+		unitStates:       newUnitStateCache(),
+	}
+
+	if instance.ackSender, err = getAckSender(config.CursorCommit, sender); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if instance.ackSender != nil && isBatchEnabled(config.Batch) {
+		return nil, aoserrors.New("alerts batching is not supported with ack cursor commit policy")
+	}
+
+	if instance.ackSender == nil {
+		instance.batcher = newAlertBatcher(config.Batch, sender)
 	}
 
 	for _, substr := range instance.config.Filter {
@@ -151,7 +223,26 @@ func New(
 		instance.filterRegexp = append(instance.filterRegexp, tmpRegexp)
 	}
 
+	instance.unitPriorities = newUnitPriorities(instance.config.UnitPriorities)
+	instance.rules = newClassificationRules(instance.config.Rules)
+	instance.suppressor.muteRules = newMuteRules(instance.config.MuteRules)
+	instance.escalator = newAlertEscalator(instance.config.Escalation)
+
+	if err = instance.loadEscalationState(); err != nil {
+		log.Errorf("Can't load escalation state: %v", err)
+	}
+
+	if instance.spool, err = newEntrySpool(instance.config.Spool); err != nil {
+		log.Errorf("Can't create alerts spool: %v", err)
+	}
+
+	if instance.config.Kernel.Enabled {
+		instance.kernelClassifiers = newKernelClassifiers(instance.config.Kernel.Rules)
+	}
+
 	if err = instance.setupJournal(); err != nil {
+		instance.closeReaders()
+
 		return nil, aoserrors.Wrap(err)
 	}
 
@@ -164,75 +255,231 @@ func (instance *JournalAlerts) Close() {
 
 	if instance.journalCancelFunction != nil {
 		instance.journalCancelFunction()
+		instance.readersWG.Wait()
 
 		if err := instance.storeCurrentCursor(); err != nil {
 			log.Errorf("Can't store cursor: %s", err)
 		}
 
-		instance.journal.Close()
+		instance.closeReaders()
+	}
+
+	if instance.batcher != nil {
+		instance.batcher.flush()
+	}
+
+	if instance.spool != nil {
+		instance.spool.close()
 	}
+
+	instance.unitStates.close()
+}
+
+// AddInstanceUnit adds systemd unit of service instance to be monitored.
+func (instance *JournalAlerts) AddInstanceUnit(instanceID, unitName string) {
+	log.WithFields(log.Fields{"instanceID": instanceID, "unit": unitName}).Debug("Add instance unit")
+
+	instance.Lock()
+	instance.instanceUnits[unitName] = instanceID
+	instance.Unlock()
+
+	instance.notifyMatchesChanged()
+}
+
+// RemoveInstanceUnit removes systemd unit of service instance from monitoring.
+func (instance *JournalAlerts) RemoveInstanceUnit(unitName string) {
+	log.WithFields(log.Fields{"unit": unitName}).Debug("Remove instance unit")
+
+	instance.Lock()
+	delete(instance.instanceUnits, unitName)
+	instance.Unlock()
+
+	instance.notifyMatchesChanged()
 }
 
 /***********************************************************************************************************************
  * Private
  **********************************************************************************************************************/
 
+func (instance *JournalAlerts) notifyMatchesChanged() {
+	for _, reader := range instance.readers {
+		select {
+		case reader.matchesChanged <- struct{}{}:
+
+		default:
+		}
+	}
+}
+
+func (instance *JournalAlerts) getInstanceUnitID(unitName string) (instanceID string, ok bool) {
+	instance.Lock()
+	defer instance.Unlock()
+
+	instanceID, ok = instance.instanceUnits[filepath.Base(unitName)]
+
+	return instanceID, ok
+}
+
 func (instance *JournalAlerts) setupJournal() (err error) {
-	if instance.journal = SDJournal; instance.journal == nil {
-		if instance.journal, err = sdjournal.NewJournal(); err != nil {
+	sources := instance.config.Sources
+	if len(sources) == 0 {
+		sources = []JournalSource{{}}
+	}
+
+	cursors, err := instance.loadCursors()
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	for _, source := range sources {
+		reader := &journalReader{
+			name: source.name(), source: source, cursor: cursors[source.name()], matchesChanged: make(chan struct{}, 1),
+		}
+
+		if reader.journal, err = openJournal(source); err != nil {
 			return aoserrors.Wrap(err)
 		}
-	}
 
-	for priorityLevel := 0; priorityLevel <= instance.config.SystemAlertPriority; priorityLevel++ {
-		if err = instance.journal.AddMatch(fmt.Sprintf("PRIORITY=%d", priorityLevel)); err != nil {
+		instance.readers = append(instance.readers, reader)
+
+		if err = instance.setupReader(reader, cursors[reader.name]); err != nil {
 			return aoserrors.Wrap(err)
 		}
 	}
 
-	if err = instance.journal.AddDisjunction(); err != nil {
+	ctx, cancelFunction := context.WithCancel(context.Background())
+
+	instance.journalCancelFunction = cancelFunction
+
+	for _, reader := range instance.readers {
+		instance.readersWG.Add(1)
+
+		go instance.readJournal(ctx, reader)
+	}
+
+	go instance.handleChannels(ctx)
+
+	return nil
+}
+
+func (instance *JournalAlerts) setupReader(reader *journalReader, cursor string) (err error) {
+	if err = instance.addMatches(reader); err != nil {
 		return aoserrors.Wrap(err)
 	}
 
-	if err = instance.journal.AddMatch("_SYSTEMD_UNIT=init.scope"); err != nil {
+	if err = reader.journal.SeekTail(); err != nil {
 		return aoserrors.Wrap(err)
 	}
 
-	if err = instance.journal.SeekTail(); err != nil {
+	if _, err = reader.journal.Previous(); err != nil {
 		return aoserrors.Wrap(err)
 	}
 
-	if _, err = instance.journal.Previous(); err != nil {
+	if cursor != "" {
+		if err = reader.journal.SeekCursor(cursor); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if _, err = reader.journal.Next(); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (instance *JournalAlerts) addMatches(reader *journalReader) (err error) {
+	maxPriority := instance.config.SystemAlertPriority
+
+	for _, unitPriority := range instance.unitPriorities {
+		if unitPriority.priority > maxPriority {
+			maxPriority = unitPriority.priority
+		}
+	}
+
+	if instance.escalator != nil && instance.escalator.config.Priority > maxPriority {
+		maxPriority = instance.escalator.config.Priority
+	}
+
+	for priorityLevel := 0; priorityLevel <= maxPriority; priorityLevel++ {
+		if err = reader.journal.AddMatch(fmt.Sprintf("PRIORITY=%d", priorityLevel)); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	if err = reader.journal.AddDisjunction(); err != nil {
 		return aoserrors.Wrap(err)
 	}
 
-	cursor, err := instance.cursorStorage.GetJournalCursor()
-	if err != nil {
+	if err = reader.journal.AddMatch("_SYSTEMD_UNIT=init.scope"); err != nil {
 		return aoserrors.Wrap(err)
 	}
 
-	if cursor != "" {
-		if err = instance.journal.SeekCursor(cursor); err != nil {
+	if instance.config.Coredump.Enabled {
+		if err = reader.journal.AddDisjunction(); err != nil {
 			return aoserrors.Wrap(err)
 		}
 
-		if _, err = instance.journal.Next(); err != nil {
+		if err = reader.journal.AddMatch(sdjournal.SD_JOURNAL_FIELD_MESSAGE_ID + "=" + coredumpMessageID); err != nil {
 			return aoserrors.Wrap(err)
 		}
 	}
 
-	go instance.handleChannels()
+	if instance.config.Kernel.Enabled {
+		if err = reader.journal.AddDisjunction(); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if err = reader.journal.AddMatch(sdjournal.SD_JOURNAL_FIELD_TRANSPORT + "=" + kernelTransport); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	if instance.config.Audit.Enabled {
+		if err = reader.journal.AddDisjunction(); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if err = reader.journal.AddMatch(sdjournal.SD_JOURNAL_FIELD_TRANSPORT + "=" + auditTransport); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	instance.Lock()
+	defer instance.Unlock()
+
+	if len(instance.instanceUnits) != 0 {
+		if err = reader.journal.AddDisjunction(); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		for unitName := range instance.instanceUnits {
+			if err = reader.journal.AddMatch(sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT + "=" + unitName); err != nil {
+				return aoserrors.Wrap(err)
+			}
+		}
+	}
 
 	return nil
 }
 
-func (instance *JournalAlerts) handleChannels() {
-	result := sdjournal.SD_JOURNAL_APPEND
-	journalTicker := time.NewTicker(journalSavePeriod)
+func (instance *JournalAlerts) closeReaders() {
+	for _, reader := range instance.readers {
+		if reader.journal == nil {
+			continue
+		}
 
-	ctx, cancelFunction := context.WithCancel(context.Background())
+		if err := reader.journal.Close(); err != nil {
+			log.WithField("source", reader.name).Errorf("Can't close journal: %s", err)
+		}
+	}
 
-	instance.journalCancelFunction = cancelFunction
+	instance.readers = nil
+}
+
+func (instance *JournalAlerts) handleChannels(ctx context.Context) {
+	journalTicker := time.NewTicker(journalSavePeriod)
+	defer journalTicker.Stop()
 
 	for {
 		select {
@@ -241,88 +488,211 @@ func (instance *JournalAlerts) handleChannels() {
 				log.Error("Can't store journal cursor: ", err)
 			}
 
-		case <-ctx.Done():
-			journalTicker.Stop()
+			for _, alert := range instance.limiter.flush() {
+				instance.deliverAlert(alert)
+			}
 
+		case <-ctx.Done():
 			return
 
-		default:
-			if result != sdjournal.SD_JOURNAL_NOP {
-				if err := instance.processJournal(); err != nil {
-					log.Errorf("Journal process error: %s", err)
-				}
-			}
+		case alert := <-instance.healthChannel:
+			instance.sendAlert(alert.CoreComponent, alert)
 
-			if result = instance.journal.Wait(waitJournalTimeout); result < 0 {
-				log.Errorf("Wait journal error: %s", syscall.Errno(-result))
+		case record := <-instance.recordChannel:
+			for _, record := range instance.collectRecords(ctx, record) {
+				instance.processRecord(record)
 			}
 		}
 	}
 }
 
-func (instance *JournalAlerts) processJournal() (err error) {
-	for {
-		count, err := instance.journal.Next()
-		if err != nil {
-			return aoserrors.Wrap(err)
-		}
+func (instance *JournalAlerts) processEntry(entry *sdjournal.JournalEntry) {
+	instance.matchedEntry = entry
+	defer func() { instance.matchedEntry = nil }()
 
-		if count == 0 {
-			return nil
-		}
+	if instance.config.Coredump.Enabled && isCoredumpEntry(entry) {
+		alert := instance.getCrashAlert(entry)
+		alert.AlertItem = instance.createAlertItem(entry, cloudprotocol.AlertTagCrash)
+		instance.sendAlert(alert.Executable, *alert)
 
-		entry, err := instance.journal.GetEntry()
-		if err != nil {
-			return aoserrors.Wrap(err)
+		return
+	}
+
+	if alert := instance.getRuleAlert(entry); alert != nil {
+		tag := alert.Tag
+
+		alert.AlertItem = instance.createAlertItem(entry, tag)
+		instance.sendAlert(alert.Rule, *alert)
+
+		return
+	}
+
+	if instance.config.Kernel.Enabled && isKernelEntry(entry) {
+		if alert := instance.getKernelAlert(entry); alert != nil {
+			alert.AlertItem = instance.createAlertItem(entry, cloudprotocol.AlertTagKernel)
+			instance.sendAlert(kernelTransport, *alert)
+
+			return
 		}
 
-		if entry == nil {
-			return nil
+		// unclassified kernel entries are handled as system alerts below
+	}
+
+	if instance.config.Audit.Enabled && isAuditEntry(entry) {
+		if alert := instance.getSecurityAlert(entry); alert != nil {
+			alert.AlertItem = instance.createAlertItem(entry, cloudprotocol.AlertTagSecurity)
+			instance.sendAlert(auditTransport, *alert)
 		}
 
-		unit := entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT]
+		return
+	}
 
-		if unit == "init.scope" {
-			if priority, err := strconv.Atoi(entry.Fields[sdjournal.SD_JOURNAL_FIELD_PRIORITY]); err != nil ||
-				priority > instance.config.ServiceAlertPriority {
-				continue
-			}
+	unit := entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT]
+	initScope := unit == "init.scope"
 
-			unit = entry.Fields["UNIT"]
+	if initScope {
+		if priority, err := strconv.Atoi(entry.Fields[sdjournal.SD_JOURNAL_FIELD_PRIORITY]); err != nil ||
+			priority > instance.config.ServiceAlertPriority {
+			return
 		}
 
-		// with cgroup v2 logs from container do not contains _SYSTEMD_UNIT due to restrictions
-		// that's why id should be extracted from _SYSTEMD_CGROUP
-		// format: /system.slice/system-aos@service.slice/AOS_INSTANCE_ID
-		if len(unit) == 0 {
-			systemdCgroup := entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_CGROUP]
+		unit = entry.Fields["UNIT"]
+	}
 
-			if len(systemdCgroup) == 0 {
-				continue
-			}
+	// with cgroup v2 logs from container do not contains _SYSTEMD_UNIT due to restrictions
+	// that's why id should be extracted from _SYSTEMD_CGROUP
+	// format: /system.slice/system-aos@service.slice/AOS_INSTANCE_ID
+	if len(unit) == 0 {
+		systemdCgroup := entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_CGROUP]
 
+		switch {
+		case len(systemdCgroup) != 0:
 			// add prefix 'aos-service@' and postfix '.service'
 			// to service uuid and get proper seervice object from DB
 			unit = systemdCgroup
+
+		case isKernelEntry(entry):
+			// kernel entries don't belong to any unit
+			unit = kernelTransport
+
+		default:
+			return
 		}
+	}
+
+	if !initScope && !instance.isPriorityAllowed(entry, unit) {
+		instance.processEscalation(entry, unit)
+
+		return
+	}
+
+	if alert := instance.getServiceInstanceAlert(entry, unit); alert != nil {
+		alert.AlertItem = instance.createAlertItem(entry, cloudprotocol.AlertTagServiceInstance)
+		instance.sendAlert(unit, *alert)
+	} else if alert := instance.getCoreComponentAlert(entry, unit); alert != nil {
+		alert.AlertItem = instance.createAlertItem(entry, cloudprotocol.AlertTagAosCore)
+		instance.sendAlert(unit, *alert)
+	} else if alert := instance.getSystemAlert(entry); alert != nil {
+		alert.AlertItem = instance.createAlertItem(entry, cloudprotocol.AlertTagSystemError)
+		instance.sendAlert(unit, *alert)
+	}
+}
+
+func newUnitPriorities(priorities map[string]int) (unitPriorities []unitPriority) {
+	for pattern, priority := range priorities {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			log.Errorf("Incorrect unit pattern: %s, error is: %s", pattern, err)
 
-		if alert := instance.getServiceInstanceAlert(entry, unit); alert != nil {
-			alert.AlertItem = createAlertItem(entry, cloudprotocol.AlertTagServiceInstance)
-			instance.sender.SendAlert(*alert)
-		} else if alert := instance.getCoreComponentAlert(entry, unit); alert != nil {
-			alert.AlertItem = createAlertItem(entry, cloudprotocol.AlertTagAosCore)
-			instance.sender.SendAlert(*alert)
-		} else if alert := instance.getSystemAlert(entry); alert != nil {
-			alert.AlertItem = createAlertItem(entry, cloudprotocol.AlertTagSystemError)
-			instance.sender.SendAlert(*alert)
-		} else {
 			continue
 		}
+
+		unitPriorities = append(unitPriorities, unitPriority{pattern: pattern, priority: priority})
+	}
+
+	// More specific (longer) patterns are checked first
+	sort.Slice(unitPriorities, func(i, j int) bool {
+		if len(unitPriorities[i].pattern) != len(unitPriorities[j].pattern) {
+			return len(unitPriorities[i].pattern) > len(unitPriorities[j].pattern)
+		}
+
+		return unitPriorities[i].pattern < unitPriorities[j].pattern
+	})
+
+	return unitPriorities
+}
+
+func (instance *JournalAlerts) getUnitPriority(unit string) int {
+	unitName := filepath.Base(unit)
+
+	for _, unitPriority := range instance.unitPriorities {
+		if unitPriority.pattern == unitName {
+			return unitPriority.priority
+		}
+	}
+
+	for _, unitPriority := range instance.unitPriorities {
+		if matched, _ := filepath.Match(unitPriority.pattern, unitName); matched {
+			return unitPriority.priority
+		}
 	}
+
+	return instance.config.SystemAlertPriority
+}
+
+func (instance *JournalAlerts) isPriorityAllowed(entry *sdjournal.JournalEntry, unit string) bool {
+	priority, err := strconv.Atoi(entry.Fields[sdjournal.SD_JOURNAL_FIELD_PRIORITY])
+	if err != nil {
+		return true
+	}
+
+	if _, ok := instance.getInstanceUnitID(unit); ok {
+		return priority <= instance.config.ServiceAlertPriority
+	}
+
+	return priority <= instance.getUnitPriority(unit)
+}
+
+func (instance *JournalAlerts) sendAlert(source string, alert interface{}) {
+	if !instance.alertPolicy.Load().IsAllowed(alert) {
+		log.WithField("source", source).Debug("Alert muted by policy")
+
+		return
+	}
+
+	if instance.suppressor.isSuppressed(alert) {
+		log.WithField("source", source).Debug("Alert suppressed")
+
+		return
+	}
+
+	instance.spoolEntry()
+
+	for _, item := range instance.limiter.process(source, alert) {
+		instance.deliverAlert(item)
+	}
+}
+
+func (instance *JournalAlerts) deliverAlert(alert interface{}) {
+	if instance.ackSender != nil {
+		instance.sendAlertWithAck(alert)
+
+		return
+	}
+
+	if instance.batcher != nil {
+		instance.batcher.add(alert)
+
+		return
+	}
+
+	instance.sender.SendAlert(alert)
 }
 
 func (instance *JournalAlerts) storeCurrentCursor() (err error) {
-	cursor, err := instance.journal.GetCursor()
+	instance.Lock()
+	defer instance.Unlock()
+
+	cursor, err := instance.encodeCursors()
 	if err != nil {
 		return aoserrors.Wrap(err)
 	}
@@ -331,6 +701,10 @@ func (instance *JournalAlerts) storeCurrentCursor() (err error) {
 		return aoserrors.Wrap(err)
 	}
 
+	if err = instance.storeEscalationState(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -341,12 +715,17 @@ func (instance *JournalAlerts) getServiceInstanceAlert(
 		return nil
 	}
 
-	if strings.Contains(unitName, aosServicePrefix) {
-		instanceID := filepath.Base(unitName)
-		instanceID = strings.TrimPrefix(instanceID, aosServicePrefix)
-		instanceID = strings.TrimSuffix(instanceID, ".service")
+	systemdUnit := filepath.Base(unitName)
+
+	instanceID, ok := instance.getInstanceUnitID(unitName)
+	if !ok {
+		if instanceID, ok = getInstanceID(unitName); ok {
+			systemdUnit = aosServicePrefix + instanceID + ".service"
+		}
+	}
 
-		instanceIdent, version, err := instance.instanceProvider.GetInstanceInfoByID(instanceID)
+	if ok {
+		instanceIdent, version, err := instance.getInstanceInfo(instanceID)
 		if err != nil {
 			log.Errorf("Can't get instance info: %s", err)
 
@@ -358,17 +737,54 @@ func (instance *JournalAlerts) getServiceInstanceAlert(
 			Tag:       cloudprotocol.AlertTagServiceInstance,
 		}
 
-		return &cloudprotocol.ServiceInstanceAlert{
+		alert := &cloudprotocol.ServiceInstanceAlert{
 			AlertItem:      alertItem,
 			InstanceIdent:  instanceIdent,
 			Message:        entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE],
 			ServiceVersion: version,
 		}
+
+		unitState, err := instance.unitStates.getUnitState(systemdUnit)
+		if err != nil {
+			log.WithField("unit", systemdUnit).Debugf("Can't get unit state: %v", err)
+		} else {
+			alert.UnitState = &unitState
+		}
+
+		return alert
 	}
 
 	return nil
 }
 
+func (instance *JournalAlerts) getInstanceInfo(
+	instanceID string,
+) (ident aostypes.InstanceIdent, version string, err error) {
+	if ident, version, err = instance.instanceProvider.GetInstanceInfoByID(instanceID); err == nil ||
+		!instance.config.InstancePathNameFallback {
+		return ident, version, err
+	}
+
+	// Units of instances unknown to the provider may still carry path name encoded instance ident.
+	if pathIdent, parseErr := aostypes.ParseInstanceIdentPathName(instanceID); parseErr == nil {
+		return pathIdent, "", nil
+	}
+
+	return ident, version, aoserrors.Wrap(err)
+}
+
+func getInstanceID(unitName string) (instanceID string, ok bool) {
+	if !strings.Contains(unitName, aosServicePrefix) {
+		return "", false
+	}
+
+	instanceID = filepath.Base(unitName)
+	instanceID = strings.TrimPrefix(instanceID, aosServicePrefix)
+	instanceID = strings.TrimSuffix(instanceID, ".service")
+
+	return instanceID, true
+}
+
 func (instance *JournalAlerts) getCoreComponentAlert(
 	entry *sdjournal.JournalEntry, unitName string,
 ) *cloudprotocol.CoreAlert {
@@ -394,10 +810,25 @@ func (instance *JournalAlerts) getSystemAlert(entry *sdjournal.JournalEntry) *cl
 	return &cloudprotocol.SystemAlert{Message: entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE]}
 }
 
-func createAlertItem(entry *sdjournal.JournalEntry, tag string) cloudprotocol.AlertItem {
-	return cloudprotocol.AlertItem{
+func (instance *JournalAlerts) createAlertItem(entry *sdjournal.JournalEntry, tag string) cloudprotocol.AlertItem {
+	alertItem := cloudprotocol.AlertItem{
 		Tag: tag,
 		Timestamp: time.Unix(int64(entry.RealtimeTimestamp/microSecondsInSecond),
 			int64((entry.RealtimeTimestamp%microSecondsInSecond)*1000)),
 	}
+
+	for _, field := range instance.config.ExtractFields {
+		value, ok := entry.Fields[field]
+		if !ok {
+			continue
+		}
+
+		if alertItem.Fields == nil {
+			alertItem.Fields = make(map[string]string)
+		}
+
+		alertItem.Fields[field] = value
+	}
+
+	return alertItem
 }
@@ -18,11 +18,15 @@
 package journalalerts_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -30,6 +34,7 @@ import (
 	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/api/cloudprotocol"
 	"github.com/aosedge/aos_common/journalalerts"
+	"github.com/aosedge/aos_common/utils/alertutils"
 	"github.com/coreos/go-systemd/v22/sdjournal"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -47,6 +52,15 @@ func init() {
 	})
 	log.SetLevel(log.DebugLevel)
 	log.SetOutput(os.Stdout)
+
+	journalalerts.UnitStateGetter = func(unitName string) (cloudprotocol.UnitState, error) {
+		unitState, ok := unitStates.Load(unitName)
+		if !ok {
+			return cloudprotocol.UnitState{}, aoserrors.Errorf("unit %s not found", unitName)
+		}
+
+		return unitState.(cloudprotocol.UnitState), nil //nolint:forcetypeassert
+	}
 }
 
 /***********************************************************************************************************************
@@ -60,23 +74,47 @@ type instanceInfo struct {
 
 type testInstanceProvider struct {
 	instancesInfo map[string]instanceInfo
+	instanceUIDs  map[int]string
 }
 
 type testCursorStorage struct {
 	cursor string
 }
 
+type testEscalationStorage struct {
+	testCursorStorage
+	state []byte
+}
+
 type testSystemdJournal struct {
 	sync.RWMutex
 	messages       []*sdjournal.JournalEntry
 	currentMessage int
 	systemdMatches []string
+	nextError      error
+}
+
+// testNoFlushJournal journal without FlushMatches: matches are reset only by reopening journal.
+type testNoFlushJournal struct {
+	journalalerts.JournalInterface
+	journal *testSystemdJournal
+	opened  int32
 }
 
 type testSender struct {
 	alertsChannel chan interface{}
 }
 
+type testAckSender struct {
+	testSender
+	acks chan func()
+}
+
+type testBatchSender struct {
+	testSender
+	batchChannel chan cloudprotocol.Alerts
+}
+
 /***********************************************************************************************************************
  * Vars
  **********************************************************************************************************************/
@@ -87,8 +125,12 @@ var (
 )
 
 var (
-	instanceProvider = testInstanceProvider{instancesInfo: make(map[string]instanceInfo)}
-	cursorStorage    testCursorStorage
+	instanceProvider = testInstanceProvider{
+		instancesInfo: make(map[string]instanceInfo),
+		instanceUIDs:  make(map[int]string),
+	}
+	cursorStorage testCursorStorage
+	unitStates    sync.Map
 )
 
 /***********************************************************************************************************************
@@ -184,6 +226,34 @@ func TestGetServiceError(t *testing.T) {
 	}
 }
 
+func TestGetServiceErrorByPathName(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority:     4,
+		SystemAlertPriority:      3,
+		InstancePathNameFallback: true,
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	instanceIdent := aostypes.InstanceIdent{ServiceID: "unknown_service", SubjectID: "subject0", Instance: 1}
+	unitName := "aos-service@" + instanceIdent.PathName() + ".service"
+	message := unitName + ": Main process exited, code=exited, status=1/FAILURE"
+
+	testJournal.addMessage(message, unitName, "", "3")
+
+	if err = waitAlerts(testSender.alertsChannel, 5*time.Second,
+		cloudprotocol.AlertTagServiceInstance, instanceIdent, "", []string{message}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+}
+
 func TestGetServiceManagerAlerts(t *testing.T) {
 	testJournal := testSystemdJournal{}
 	testSender := newTestSender()
@@ -331,178 +401,1435 @@ matchLoop:
 	}
 }
 
-/***********************************************************************************************************************
- * Interfaces
- **********************************************************************************************************************/
+func TestKernelAlerts(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
 
-func (instanceProvider *testInstanceProvider) GetInstanceInfoByID(
-	id string,
-) (ident aostypes.InstanceIdent, version string, err error) {
-	instance, ok := instanceProvider.instancesInfo[id]
-	if !ok {
-		return ident, version, aoserrors.New("Instance does not exist")
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		Kernel:               journalalerts.KernelConfig{Enabled: true},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
 	}
+	defer alertsHandler.Close()
 
-	return instance.instanceIdent, instance.serviceVersion, nil
-}
-
-func (cursorStorage *testCursorStorage) SetJournalCursor(cursor string) (err error) {
-	cursorStorage.cursor = cursor
-
-	return nil
-}
-
-func (cursorStorage *testCursorStorage) GetJournalCursor() (cursor string, err error) {
-	return cursorStorage.cursor, nil
-}
+	found := false
 
-func (journal *testSystemdJournal) Next() (uint64, error) {
-	journal.Lock()
-	defer journal.Unlock()
+	for _, match := range testJournal.systemdMatches {
+		if match == "_TRANSPORT=kernel" {
+			found = true
+		}
+	}
 
-	if len(journal.messages) == 0 {
-		return uint64(sdjournal.SD_JOURNAL_NOP), nil
+	if !found {
+		t.Error("Journal filter doesn't contain kernel transport match")
 	}
 
-	if journal.currentMessage >= len(journal.messages)-1 {
-		return uint64(sdjournal.SD_JOURNAL_NOP), nil
+	expectedAlerts := []cloudprotocol.KernelAlert{
+		{
+			Category: cloudprotocol.KernelAlertCategoryOOM,
+			Message:  "Out of memory: Killed process 1234 (test) total-vm:1024kB",
+		},
+		{
+			Category: cloudprotocol.KernelAlertCategoryIOError,
+			Message:  "blk_update_request: I/O error, dev mmcblk0, sector 1024",
+		},
 	}
 
-	journal.currentMessage++
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE: expectedAlerts[0].Message, sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "kernel",
+	})
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE:   "usb 1-1: new high-speed USB device number 2",
+		sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "kernel",
+		sdjournal.SD_JOURNAL_FIELD_PRIORITY:  "6",
+	})
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE: expectedAlerts[1].Message, sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "kernel",
+	})
 
-	return uint64(sdjournal.SD_JOURNAL_APPEND), nil
-}
+	for _, expectedAlert := range expectedAlerts {
+		if err = waitResult(testSender.alertsChannel, 5*time.Second,
+			func(alert interface{}) (success bool, err error) {
+				kernelAlert, ok := alert.(cloudprotocol.KernelAlert)
+				if !ok {
+					return false, errIncorrectType
+				}
 
-func (journal *testSystemdJournal) GetEntry() (entry *sdjournal.JournalEntry, err error) {
-	journal.RLock()
-	defer journal.RUnlock()
+				if kernelAlert.Tag != cloudprotocol.AlertTagKernel {
+					return false, errIncorrectType
+				}
 
-	entry = journal.messages[journal.currentMessage]
+				if kernelAlert.Category != expectedAlert.Category || kernelAlert.Message != expectedAlert.Message {
+					return false, aoserrors.Errorf("unexpected kernel alert: %v", kernelAlert)
+				}
 
-	return entry, nil
+				return true, nil
+			}); err != nil {
+			t.Errorf("Result failed: %s", err)
+		}
+	}
 }
 
-func (journal *testSystemdJournal) Wait(timeout time.Duration) int {
-	time.Sleep(timeout)
+func TestUnclassifiedKernelAlerts(t *testing.T) {
+	for _, kernelEnabled := range []bool{true, false} {
+		testJournal := testSystemdJournal{}
+		testSender := newTestSender()
+		journalalerts.SDJournal = &testJournal
 
-	journal.RLock()
-	defer journal.RUnlock()
+		alertsHandler, err := journalalerts.New(journalalerts.Config{
+			ServiceAlertPriority: 4,
+			SystemAlertPriority:  3,
+			Kernel:               journalalerts.KernelConfig{Enabled: kernelEnabled},
+		},
+			&instanceProvider, &cursorStorage, testSender)
+		if err != nil {
+			t.Fatalf("Can't create alerts: %s", err)
+		}
 
-	if len(journal.messages) == 0 {
-		return sdjournal.SD_JOURNAL_NOP
-	}
+		expectedMessage := "mmc0: card never left busy state"
 
-	if journal.currentMessage >= len(journal.messages)-1 {
-		return sdjournal.SD_JOURNAL_NOP
-	}
+		testJournal.addEntry(map[string]string{
+			sdjournal.SD_JOURNAL_FIELD_MESSAGE:   "usb 1-1: new high-speed USB device number 2",
+			sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "kernel",
+			sdjournal.SD_JOURNAL_FIELD_PRIORITY:  "6",
+		})
+		testJournal.addEntry(map[string]string{
+			sdjournal.SD_JOURNAL_FIELD_MESSAGE:   expectedMessage,
+			sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "kernel",
+			sdjournal.SD_JOURNAL_FIELD_PRIORITY:  "3",
+		})
 
-	return sdjournal.SD_JOURNAL_APPEND
-}
+		if err = waitResult(testSender.alertsChannel, 5*time.Second,
+			func(alert interface{}) (success bool, err error) {
+				systemAlert, ok := alert.(cloudprotocol.SystemAlert)
+				if !ok {
+					return false, errIncorrectType
+				}
 
-func (journal *testSystemdJournal) Close() error { return nil }
+				if systemAlert.Tag != cloudprotocol.AlertTagSystemError || systemAlert.Message != expectedMessage {
+					return false, aoserrors.Errorf("unexpected system alert: %v", systemAlert)
+				}
 
-func (journal *testSystemdJournal) AddMatch(match string) error {
-	journal.systemdMatches = append(journal.systemdMatches, match)
+				return true, nil
+			}); err != nil {
+			t.Errorf("Result failed, kernel enabled %v: %s", kernelEnabled, err)
+		}
 
-	return nil
+		alertsHandler.Close()
+	}
 }
 
-func (journal *testSystemdJournal) AddDisjunction() error { return nil }
+func TestAlertRateLimit(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
 
-func (journal *testSystemdJournal) SeekTail() error { return nil }
+	const maxAlerts = 2
 
-func (journal *testSystemdJournal) Previous() (uint64, error) {
-	journal.Lock()
-	defer journal.Unlock()
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		RateLimit:            journalalerts.RateLimitConfig{MaxAlertsPerMinute: maxAlerts},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
 
-	journal.currentMessage = -1
+	for i := 0; i < 5; i++ {
+		testJournal.addMessage(uuid.New().String(), "crashloop.service", "", "3")
+	}
 
-	return uint64(sdjournal.SD_JOURNAL_NOP), nil
-}
+	testJournal.addMessage("other unit message", "other.service", "", "3")
 
-func (journal *testSystemdJournal) SeekCursor(cursor string) error { return nil }
+	receivedCount := make(map[string]int)
 
-func (journal *testSystemdJournal) GetCursor() (string, error) { return "", nil }
+	for {
+		if err := waitResult(testSender.alertsChannel, 2*time.Second,
+			func(alert interface{}) (success bool, err error) {
+				systemAlert, ok := alert.(cloudprotocol.SystemAlert)
+				if !ok {
+					return false, errIncorrectType
+				}
 
-func (journal *testSystemdJournal) addMessage(message, systemdUnit, cgroupUnit, priority string) {
-	journal.Lock()
-	defer journal.Unlock()
+				receivedCount[systemAlert.Message]++
 
-	journalEntry := sdjournal.JournalEntry{Fields: make(map[string]string)}
+				return true, nil
+			}); err != nil {
+			if !errors.Is(err, errTimeout) {
+				t.Errorf("Result failed: %s", err)
+			}
 
-	journalEntry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE] = message
-	journalEntry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT] = systemdUnit
-	journalEntry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_CGROUP] = cgroupUnit
-	journalEntry.Fields[sdjournal.SD_JOURNAL_FIELD_PRIORITY] = priority
+			break
+		}
+	}
 
-	journal.messages = append(journal.messages, &journalEntry)
-}
+	if len(receivedCount) != maxAlerts+1 {
+		t.Errorf("Wrong received alerts count: %d", len(receivedCount))
+	}
 
-func (sender *testSender) SendAlert(alert interface{}) {
-	sender.alertsChannel <- alert
+	if receivedCount["other unit message"] != 1 {
+		t.Error("Alert from other unit should not be rate limited")
+	}
 }
 
-/***********************************************************************************************************************
- * Private
- **********************************************************************************************************************/
+func TestAlertDeduplication(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
 
-func newTestSender() (sender *testSender) {
-	sender = &testSender{
-		alertsChannel: make(chan interface{}, 1),
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		RateLimit:            journalalerts.RateLimitConfig{Deduplicate: true},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
 	}
+	defer alertsHandler.Close()
 
-	return sender
-}
+	for i := 0; i < 4; i++ {
+		testJournal.addMessage("process crashed", "crashloop.service", "", "3")
+	}
 
-func waitResult(alertsChannel <-chan interface{}, timeout time.Duration,
-	checkAlert func(alert interface{}) (success bool, err error),
-) (err error) {
-	for {
-		select {
-		case alert := <-alertsChannel:
-			success, err := checkAlert(alert)
-			if err != nil {
-				return aoserrors.Wrap(err)
-			}
+	testJournal.addMessage("process stopped", "crashloop.service", "", "3")
 
-			if success {
-				return nil
-			}
+	expectedMessages := []string{"process crashed", "process crashed (repeated 3 times)", "process stopped"}
 
-		case <-time.After(timeout):
-			return errTimeout
+	for _, expectedMessage := range expectedMessages {
+		if err = waitResult(testSender.alertsChannel, 5*time.Second,
+			func(alert interface{}) (success bool, err error) {
+				systemAlert, ok := alert.(cloudprotocol.SystemAlert)
+				if !ok {
+					return false, errIncorrectType
+				}
+
+				if systemAlert.Message != expectedMessage {
+					return false, aoserrors.Errorf("unexpected alert message: %s", systemAlert.Message)
+				}
+
+				return true, nil
+			}); err != nil {
+			t.Errorf("Result failed: %s", err)
 		}
 	}
 }
 
-func waitAlerts(alertsChannel <-chan interface{}, timeout time.Duration,
-	tag string, instance aostypes.InstanceIdent, serviceVersion string, data []string,
-) (err error) {
-	return waitResult(alertsChannel, timeout, func(alert interface{}) (success bool, err error) {
-		for i, message := range data {
-			var alertMsg string
+func TestExtractFields(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
 
-			switch alertItem := alert.(type) {
-			case cloudprotocol.CoreAlert:
-				if alertItem.Tag != tag {
-					return false, errIncorrectType
-				}
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		ExtractFields: []string{
+			sdjournal.SD_JOURNAL_FIELD_CODE_FILE, sdjournal.SD_JOURNAL_FIELD_ERRNO, sdjournal.SD_JOURNAL_FIELD_MESSAGE_ID,
+		},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
 
-				alertMsg = alertItem.Message
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE:      "can't open file",
+		sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT: "test.service",
+		sdjournal.SD_JOURNAL_FIELD_PRIORITY:     "3",
+		sdjournal.SD_JOURNAL_FIELD_CODE_FILE:    "src/main.c",
+		sdjournal.SD_JOURNAL_FIELD_ERRNO:        "2",
+	})
 
-			case cloudprotocol.ServiceInstanceAlert:
-				if alertItem.Tag != tag {
-					return false, errIncorrectType
-				}
+	expectedFields := map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_CODE_FILE: "src/main.c",
+		sdjournal.SD_JOURNAL_FIELD_ERRNO:     "2",
+	}
 
-				if alertItem.InstanceIdent != instance || serviceVersion != alertItem.ServiceVersion {
-					continue
-				}
+	if err = waitResult(testSender.alertsChannel, 5*time.Second,
+		func(alert interface{}) (success bool, err error) {
+			systemAlert, ok := alert.(cloudprotocol.SystemAlert)
+			if !ok {
+				return false, errIncorrectType
+			}
 
-				alertMsg = alertItem.Message
+			if !reflect.DeepEqual(systemAlert.Fields, expectedFields) {
+				return false, aoserrors.Errorf("wrong alert fields: %v", systemAlert.Fields)
+			}
 
-			case cloudprotocol.SystemAlert:
-				if alertItem.Tag != tag {
+			return true, nil
+		}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+}
+
+func TestUnitPriorities(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		UnitPriorities:       map[string]int{"chatty-*.service": 2, "aos-*.service": 4, "[": 1},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	found := false
+
+	for _, match := range testJournal.systemdMatches {
+		if match == "PRIORITY=4" {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Error("Journal filter doesn't contain overridden priority match")
+	}
+
+	testJournal.addMessage("chatty error", "chatty-app.service", "", "3")
+	testJournal.addMessage("chatty critical", "chatty-app.service", "", "2")
+	testJournal.addMessage("core warning", "aos-servicemanager.service", "", "4")
+	testJournal.addMessage("other warning", "other.service", "", "4")
+	testJournal.addMessage("other error", "other.service", "", "3")
+
+	expectedMessages := map[string]bool{"chatty critical": true, "core warning": true, "other error": true}
+	receivedMessages := make(map[string]bool)
+
+	for {
+		if err := waitResult(testSender.alertsChannel, 2*time.Second,
+			func(alert interface{}) (success bool, err error) {
+				switch alertItem := alert.(type) {
+				case cloudprotocol.SystemAlert:
+					receivedMessages[alertItem.Message] = true
+
+				case cloudprotocol.CoreAlert:
+					receivedMessages[alertItem.Message] = true
+
+				default:
+					return false, errIncorrectType
+				}
+
+				return true, nil
+			}); err != nil {
+			if !errors.Is(err, errTimeout) {
+				t.Errorf("Result failed: %s", err)
+			}
+
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(receivedMessages, expectedMessages) {
+		t.Errorf("Wrong received messages: %v", receivedMessages)
+	}
+}
+
+func TestMultipleSources(t *testing.T) {
+	journals := map[journalalerts.JournalSource]*testSystemdJournal{
+		{Namespace: "containers"}:         {},
+		{Path: "/var/log/journal/remote"}: {},
+	}
+
+	journalalerts.SDJournalOpener = func(source journalalerts.JournalSource) (journalalerts.JournalInterface, error) {
+		journal, ok := journals[source]
+		if !ok {
+			return nil, aoserrors.New("unknown source")
+		}
+
+		return journal, nil
+	}
+	defer func() { journalalerts.SDJournalOpener = nil }()
+
+	testSender := newTestSender()
+	storage := testCursorStorage{}
+
+	journals[journalalerts.JournalSource{Namespace: "containers"}].addTimedMessage("message 1", 1)
+	journals[journalalerts.JournalSource{Path: "/var/log/journal/remote"}].addTimedMessage("message 2", 2)
+	journals[journalalerts.JournalSource{Namespace: "containers"}].addTimedMessage("message 3", 3)
+	journals[journalalerts.JournalSource{Path: "/var/log/journal/remote"}].addTimedMessage("message 4", 4)
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		Sources: []journalalerts.JournalSource{
+			{Namespace: "containers"}, {Path: "/var/log/journal/remote"},
+		},
+	},
+		&instanceProvider, &storage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+
+	for _, expectedMessage := range []string{"message 1", "message 2", "message 3", "message 4"} {
+		if err = waitResult(testSender.alertsChannel, 5*time.Second,
+			func(alert interface{}) (success bool, err error) {
+				systemAlert, ok := alert.(cloudprotocol.SystemAlert)
+				if !ok {
+					return false, errIncorrectType
+				}
+
+				if systemAlert.Message != expectedMessage {
+					return false, aoserrors.Errorf("unexpected alert message: %s", systemAlert.Message)
+				}
+
+				return true, nil
+			}); err != nil {
+			t.Errorf("Result failed: %s", err)
+		}
+	}
+
+	alertsHandler.Close()
+
+	var cursors map[string]string
+
+	if err = json.Unmarshal([]byte(storage.cursor), &cursors); err != nil {
+		t.Fatalf("Can't parse stored cursor: %s", err)
+	}
+
+	if !reflect.DeepEqual(cursors, map[string]string{
+		"namespace:containers": "cursor-3", "path:/var/log/journal/remote": "cursor-4",
+	}) {
+		t.Errorf("Wrong stored cursors: %v", cursors)
+	}
+}
+
+func TestCoredumpAlerts(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		Coredump:             journalalerts.CoredumpConfig{Enabled: true, BacktraceFrames: 2},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	instanceIdent := aostypes.InstanceIdent{ServiceID: "crashservice", SubjectID: "subject0", Instance: 1}
+	instanceID := "crashservice_subject0_1"
+
+	instanceProvider.instancesInfo[instanceID] = instanceInfo{instanceIdent: instanceIdent, serviceVersion: "1.0.0"}
+
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE_ID: "fc2e22bc6ee647b6b90729ab34a250b1",
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE: "Process 1234 (crasher) of user 0 dumped core.\n\n" +
+			"Stack trace of thread 1234:\n" +
+			"#0  0x00007f0000000001 raise (libc.so.6 + 0x1)\n" +
+			"#1  0x00007f0000000002 abort (libc.so.6 + 0x2)\n" +
+			"#2  0x00007f0000000003 main (crasher + 0x3)\n\n" +
+			"Stack trace of thread 1235:\n" +
+			"#0  0x00007f0000000004 poll (libc.so.6 + 0x4)",
+		sdjournal.SD_JOURNAL_FIELD_PRIORITY: "2",
+		"COREDUMP_EXE":                      "/usr/bin/crasher",
+		"COREDUMP_PID":                      "1234",
+		"COREDUMP_SIGNAL":                   "6",
+		"COREDUMP_SIGNAL_NAME":              "SIGABRT",
+		"COREDUMP_UNIT":                     "aos-service@" + instanceID + ".service",
+	})
+
+	expectedAlert := cloudprotocol.CrashAlert{
+		AlertItem:     cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagCrash},
+		InstanceIdent: &instanceIdent,
+		Executable:    "/usr/bin/crasher",
+		Signal:        "SIGABRT",
+		PID:           "1234",
+		Unit:          "aos-service@" + instanceID + ".service",
+		Backtrace: "#0  0x00007f0000000001 raise (libc.so.6 + 0x1)\n" +
+			"#1  0x00007f0000000002 abort (libc.so.6 + 0x2)",
+		Message: "Process 1234 (crasher) of user 0 dumped core.",
+	}
+
+	if err = waitResult(testSender.alertsChannel, 5*time.Second,
+		func(alert interface{}) (success bool, err error) {
+			crashAlert, ok := alert.(cloudprotocol.CrashAlert)
+			if !ok {
+				return false, errIncorrectType
+			}
+
+			if !alertutils.AlertsPayloadEqual(crashAlert, expectedAlert) {
+				return false, aoserrors.Errorf("unexpected crash alert: %v", crashAlert)
+			}
+
+			return true, nil
+		}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+}
+
+func TestInstanceUnits(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	instanceIdent := aostypes.InstanceIdent{ServiceID: "customservice", SubjectID: "subject0", Instance: 0}
+	instanceID := "customservice_subject0_0"
+	unitName := "custom-app.service"
+
+	instanceProvider.instancesInfo[instanceID] = instanceInfo{instanceIdent: instanceIdent, serviceVersion: "1.0.0"}
+
+	alertsHandler.AddInstanceUnit(instanceID, unitName)
+
+	if err = waitCondition(5*time.Second, func() bool {
+		return testJournal.hasMatch("_SYSTEMD_UNIT=" + unitName)
+	}); err != nil {
+		t.Errorf("Journal filter doesn't contain instance unit match: %s", err)
+	}
+
+	testJournal.addMessage("instance warning", unitName, "", "4")
+
+	if err = waitAlerts(testSender.alertsChannel, 5*time.Second,
+		cloudprotocol.AlertTagServiceInstance, instanceIdent, "1.0.0", []string{"instance warning"}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+
+	alertsHandler.RemoveInstanceUnit(unitName)
+
+	if err = waitCondition(5*time.Second, func() bool {
+		return !testJournal.hasMatch("_SYSTEMD_UNIT=" + unitName)
+	}); err != nil {
+		t.Errorf("Journal filter contains removed instance unit match: %s", err)
+	}
+
+	testJournal.addMessage("unit warning", unitName, "", "4")
+
+	if err = waitResult(testSender.alertsChannel, 2*time.Second,
+		func(alert interface{}) (success bool, err error) {
+			return false, aoserrors.Errorf("unexpected alert: %v", alert)
+		}); !errors.Is(err, errTimeout) {
+		t.Errorf("Unexpected result: %v", err)
+	}
+}
+
+func TestInstanceUnitsWithoutFlushMatches(t *testing.T) {
+	testJournal := &testNoFlushJournal{journal: &testSystemdJournal{}}
+	testJournal.JournalInterface = testJournal.journal
+	testSender := newTestSender()
+
+	journalalerts.SDJournal = nil
+	journalalerts.SDJournalOpener = func(journalalerts.JournalSource) (journalalerts.JournalInterface, error) {
+		atomic.AddInt32(&testJournal.opened, 1)
+
+		return testJournal, nil
+	}
+	defer func() { journalalerts.SDJournalOpener = nil }()
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	unitName := "custom-app.service"
+
+	alertsHandler.AddInstanceUnit("customservice_subject0_0", unitName)
+
+	if err = waitCondition(5*time.Second, func() bool {
+		return testJournal.journal.hasMatch("_SYSTEMD_UNIT=" + unitName)
+	}); err != nil {
+		t.Errorf("Journal filter doesn't contain instance unit match: %s", err)
+	}
+
+	alertsHandler.RemoveInstanceUnit(unitName)
+
+	if err = waitCondition(5*time.Second, func() bool {
+		return !testJournal.journal.hasMatch("_SYSTEMD_UNIT=" + unitName)
+	}); err != nil {
+		t.Errorf("Journal filter contains removed instance unit match: %s", err)
+	}
+
+	if opened := atomic.LoadInt32(&testJournal.opened); opened != 3 {
+		t.Errorf("Journal should be reopened on each matches change: %d", opened)
+	}
+}
+
+func TestAlertBatching(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := &testBatchSender{
+		testSender:   *newTestSender(),
+		batchChannel: make(chan cloudprotocol.Alerts, 1),
+	}
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		Batch:                journalalerts.BatchConfig{MaxCount: 3, MaxDelay: aostypes.Duration{Duration: time.Second}},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	for i := 1; i <= 4; i++ {
+		testJournal.addTimedMessage(fmt.Sprintf("message %d", i), uint64(i*1000000))
+	}
+
+	for _, expectedCount := range []int{3, 1} {
+		select {
+		case alerts := <-testSender.batchChannel:
+			if alerts.MessageType != cloudprotocol.AlertsMessageType {
+				t.Errorf("Wrong message type: %s", alerts.MessageType)
+			}
+
+			if len(alerts.Items) != expectedCount {
+				t.Fatalf("Wrong batch size: %d", len(alerts.Items))
+			}
+
+			for _, item := range alerts.Items {
+				systemAlert, ok := item.(cloudprotocol.SystemAlert)
+				if !ok {
+					t.Fatalf("Wrong alert type: %T", item)
+				}
+
+				if systemAlert.Message != fmt.Sprintf("message %d", systemAlert.Timestamp.Unix()) {
+					t.Errorf("Wrong alert timestamp: %v", systemAlert.Timestamp)
+				}
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatal("Wait alerts batch timeout")
+		}
+	}
+}
+
+func TestClassificationRules(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		Kernel:               journalalerts.KernelConfig{Enabled: true},
+		Rules: []journalalerts.ClassificationRule{
+			{Name: "wrong", Pattern: "(wrong"},
+			{
+				Name:     "storage",
+				Pattern:  `I/O error on (?P<device>\w+) sector (?P<sector>\d+)`,
+				Tag:      "storageAlert",
+				Severity: cloudprotocol.AlertSeverityCritical,
+			},
+		},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	expectedAlert := cloudprotocol.RuleAlert{
+		AlertItem:  cloudprotocol.AlertItem{Tag: "storageAlert"},
+		Rule:       "storage",
+		Severity:   cloudprotocol.AlertSeverityCritical,
+		Parameters: map[string]string{"device": "mmcblk0", "sector": "1024"},
+		Message:    "I/O error on mmcblk0 sector 1024",
+	}
+
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE: expectedAlert.Message, sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "kernel",
+	})
+
+	if err = waitResult(testSender.alertsChannel, 5*time.Second,
+		func(alert interface{}) (success bool, err error) {
+			ruleAlert, ok := alert.(cloudprotocol.RuleAlert)
+			if !ok {
+				return false, errIncorrectType
+			}
+
+			if !alertutils.AlertsPayloadEqual(expectedAlert, ruleAlert) {
+				return false, aoserrors.Errorf("unexpected rule alert: %v", ruleAlert)
+			}
+
+			return true, nil
+		}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+
+	testJournal.addMessage("not matched message", "test.service", "", "3")
+
+	if err = waitResult(testSender.alertsChannel, 5*time.Second,
+		func(alert interface{}) (success bool, err error) {
+			systemAlert, ok := alert.(cloudprotocol.SystemAlert)
+			if !ok {
+				return false, errIncorrectType
+			}
+
+			if systemAlert.Message != "not matched message" {
+				return false, aoserrors.Errorf("unexpected system alert: %v", systemAlert)
+			}
+
+			return true, nil
+		}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+}
+
+func TestEscalation(t *testing.T) {
+	storage := &testEscalationStorage{}
+	testSender := newTestSender()
+	config := journalalerts.Config{
+		ServiceAlertPriority: 3,
+		SystemAlertPriority:  3,
+		Escalation: journalalerts.EscalationConfig{
+			Enabled: true, Count: 3, Window: aostypes.Duration{Duration: time.Minute},
+		},
+	}
+
+	testJournal := testSystemdJournal{}
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(config, &instanceProvider, storage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+
+	if !testJournal.hasMatch("PRIORITY=4") {
+		t.Error("Warning priority match expected")
+	}
+
+	testJournal.addMessage("warning 1", "test.service", "", "4")
+	testJournal.addMessage("warning 2", "test.service", "", "4")
+	testJournal.addMessage("info", "test.service", "", "6")
+	testJournal.addMessage("error", "other.service", "", "3")
+
+	if err = waitAlerts(testSender.alertsChannel, 5*time.Second, cloudprotocol.AlertTagSystemError,
+		aostypes.InstanceIdent{}, "", []string{"error"}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+
+	alertsHandler.Close()
+
+	if len(storage.state) == 0 {
+		t.Fatal("Escalation state is not stored")
+	}
+
+	// Escalation state should be restored after restart
+	testJournal = testSystemdJournal{}
+	journalalerts.SDJournal = &testJournal
+
+	if alertsHandler, err = journalalerts.New(config, &instanceProvider, storage, testSender); err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	testJournal.addMessage("warning 3", "test.service", "", "4")
+
+	if err = waitResult(testSender.alertsChannel, 5*time.Second,
+		func(alert interface{}) (success bool, err error) {
+			ruleAlert, ok := alert.(cloudprotocol.RuleAlert)
+			if !ok {
+				return false, errIncorrectType
+			}
+
+			if ruleAlert.Rule != journalalerts.EscalationRuleName ||
+				ruleAlert.Severity != cloudprotocol.AlertSeverityError ||
+				ruleAlert.Parameters["unit"] != "test.service" || ruleAlert.Parameters["count"] != "3" {
+				return false, aoserrors.Errorf("unexpected escalation alert: %v", ruleAlert)
+			}
+
+			return true, nil
+		}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+}
+
+func TestUnitState(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{ServiceAlertPriority: 4, SystemAlertPriority: 3},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	instanceIdent := aostypes.InstanceIdent{ServiceID: "stateservice0", SubjectID: "subject0", Instance: 0}
+	instanceID := "stateservice0_subject0_0"
+	unitName := "aos-service@" + instanceID + ".service"
+
+	instanceProvider.instancesInfo[instanceID] = instanceInfo{instanceIdent: instanceIdent, serviceVersion: "1.0.0"}
+
+	unitState := cloudprotocol.UnitState{ActiveState: "activating", SubState: "auto-restart", ExecMainStatus: 11}
+
+	unitStates.Store(unitName, unitState)
+	defer unitStates.Delete(unitName)
+
+	testJournal.addMessage("Main process exited", "", "/system.slice/system-aos@service.slice/"+unitName, "3")
+
+	if err = waitResult(testSender.alertsChannel, 5*time.Second, func(alert interface{}) (bool, error) {
+		instanceAlert, ok := alert.(cloudprotocol.ServiceInstanceAlert)
+		if !ok || instanceAlert.InstanceIdent != instanceIdent {
+			return false, nil
+		}
+
+		if instanceAlert.UnitState == nil || *instanceAlert.UnitState != unitState {
+			return false, aoserrors.Errorf("wrong unit state: %v", instanceAlert.UnitState)
+		}
+
+		return true, nil
+	}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+}
+
+func TestSuppression(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		MuteRules:            []journalalerts.MuteRule{{Pattern: "^noisy"}},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	if err = alertsHandler.SetSuppressionWindows([]journalalerts.SuppressionWindow{
+		{From: time.Unix(20, 0), Till: time.Unix(10, 0)},
+	}); err == nil {
+		t.Error("Error expected for wrong suppression window")
+	}
+
+	if err = alertsHandler.SetMuteRules([]journalalerts.MuteRule{{Pattern: "(wrong"}}); err == nil {
+		t.Error("Error expected for wrong mute rule")
+	}
+
+	if err = alertsHandler.SetMuteRules([]journalalerts.MuteRule{{Pattern: ""}}); err == nil {
+		t.Error("Error expected for empty mute rule")
+	}
+
+	if err = alertsHandler.SetSuppressionWindows([]journalalerts.SuppressionWindow{
+		{From: time.Unix(10, 0), Till: time.Unix(20, 0)},
+		{From: time.Unix(40, 0), Till: time.Unix(50, 0), Tags: []string{cloudprotocol.AlertTagAosCore}},
+	}); err != nil {
+		t.Fatalf("Can't set suppression windows: %s", err)
+	}
+
+	if err = alertsHandler.SetMuteRules([]journalalerts.MuteRule{
+		{Pattern: "^install", Till: time.Unix(35, 0)},
+	}); err != nil {
+		t.Fatalf("Can't set mute rules: %s", err)
+	}
+
+	messages := []struct {
+		message   string
+		timestamp int64
+		expected  bool
+	}{
+		{"message 5", 5, true},
+		{"message 15", 15, false},
+		{"noisy message 25", 25, true},
+		{"install message 30", 30, false},
+		{"install message 36", 36, true},
+		{"message 45", 45, true},
+	}
+
+	for _, item := range messages {
+		testJournal.addTimedMessage(item.message, uint64(item.timestamp*1000000))
+	}
+
+	for _, item := range messages {
+		if !item.expected {
+			continue
+		}
+
+		if err = waitResult(testSender.alertsChannel, 5*time.Second,
+			func(alert interface{}) (success bool, err error) {
+				systemAlert, ok := alert.(cloudprotocol.SystemAlert)
+				if !ok {
+					return false, errIncorrectType
+				}
+
+				if systemAlert.Message != item.message {
+					return false, aoserrors.Errorf("unexpected alert: %s", systemAlert.Message)
+				}
+
+				return true, nil
+			}); err != nil {
+			t.Errorf("Result failed: %s", err)
+		}
+	}
+}
+
+func TestCursorCommitAck(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	journalalerts.SDJournal = &testJournal
+
+	if _, err := journalalerts.New(journalalerts.Config{CursorCommit: journalalerts.CursorCommitAck},
+		&instanceProvider, &cursorStorage, newTestSender()); err == nil {
+		t.Error("Error expected for sender without acknowledgement")
+	}
+
+	testSender := &testAckSender{testSender: *newTestSender(), acks: make(chan func(), 10)}
+
+	if _, err := journalalerts.New(journalalerts.Config{
+		CursorCommit: journalalerts.CursorCommitAck, Batch: journalalerts.BatchConfig{MaxCount: 10},
+	}, &instanceProvider, &cursorStorage, testSender); err == nil {
+		t.Error("Error expected for batching with ack cursor commit policy")
+	}
+	storage := testCursorStorage{}
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		CursorCommit:         journalalerts.CursorCommitAck,
+	},
+		&instanceProvider, &storage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		testJournal.addTimedMessage(fmt.Sprintf("message %d", i), uint64(i))
+	}
+
+	acks := make([]func(), 0, 3)
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case ack := <-testSender.acks:
+			acks = append(acks, ack)
+
+		case <-time.After(5 * time.Second):
+			t.Fatal("Wait alert timeout")
+		}
+	}
+
+	// Acknowledge first and last alerts only: cursor should not pass unacknowledged second one.
+	acks[0]()
+	acks[2]()
+	acks[2]()
+
+	alertsHandler.Close()
+
+	if storage.cursor != "cursor-1" {
+		t.Errorf("Wrong stored cursor: %s", storage.cursor)
+	}
+}
+
+func TestAuditAlerts(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	instanceID := uuid.New().String()
+	instanceIdent := aostypes.InstanceIdent{ServiceID: "service4", SubjectID: "subject1", Instance: 0}
+
+	instanceProvider.instancesInfo[instanceID] = instanceInfo{instanceIdent: instanceIdent, serviceVersion: "1.0.0"}
+	instanceProvider.instanceUIDs[5001] = instanceID
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		Audit:                journalalerts.AuditConfig{Enabled: true},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	if !testJournal.hasMatch("_TRANSPORT=audit") {
+		t.Error("Journal filter doesn't contain audit transport match")
+	}
+
+	avcMessage := `avc:  denied  { write } for  pid=1234 comm="app" name="data" dev="mmcblk0p3" ` +
+		`scontext=system_u:system_r:app_t:s0 tcontext=system_u:object_r:etc_t:s0 tclass=dir permissive=0`
+	loginMessage := `pid=456 uid=0 auid=4294967295 ses=4294967295 msg='op=login acct="root" exe="/usr/sbin/sshd" ` +
+		`hostname=? addr=10.0.0.1 terminal=ssh res=failed'`
+
+	expectedAlerts := []cloudprotocol.SecurityAlert{
+		{
+			InstanceIdent: &instanceIdent,
+			Category:      cloudprotocol.SecurityAlertCategoryAVCDenial,
+			AuditType:     "AVC",
+			PID:           "1234",
+			UID:           "5001",
+			Message:       avcMessage,
+		},
+		{
+			Category:  cloudprotocol.SecurityAlertCategoryLoginFailure,
+			AuditType: "USER_LOGIN",
+			PID:       "456",
+			UID:       "0",
+			Message:   loginMessage,
+		},
+	}
+
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE: avcMessage, sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "audit",
+		"_AUDIT_TYPE": "1400", sdjournal.SD_JOURNAL_FIELD_UID: "5001",
+	})
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE:   "pid=789 uid=0 msg='op=login res=success'",
+		sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "audit", "_AUDIT_TYPE_NAME": "USER_LOGIN",
+	})
+	testJournal.addEntry(map[string]string{
+		sdjournal.SD_JOURNAL_FIELD_MESSAGE: loginMessage, sdjournal.SD_JOURNAL_FIELD_TRANSPORT: "audit",
+		"_AUDIT_TYPE_NAME": "USER_LOGIN",
+	})
+
+	for _, expectedAlert := range expectedAlerts {
+		if err = waitResult(testSender.alertsChannel, 5*time.Second,
+			func(alert interface{}) (success bool, err error) {
+				securityAlert, ok := alert.(cloudprotocol.SecurityAlert)
+				if !ok {
+					return false, errIncorrectType
+				}
+
+				expectedAlert.Tag = cloudprotocol.AlertTagSecurity
+
+				if !alertutils.AlertsPayloadEqual(expectedAlert, securityAlert) {
+					return false, aoserrors.Errorf("unexpected security alert: %v", securityAlert)
+				}
+
+				return true, nil
+			}); err != nil {
+			t.Errorf("Result failed: %s", err)
+		}
+	}
+}
+
+func TestJournalRecovery(t *testing.T) {
+	journals := []*testSystemdJournal{{nextError: aoserrors.New("journal corrupted")}, {}}
+	openCount := 0
+
+	journals[1].addTimedMessage("message after reopen", 1)
+
+	journalalerts.SDJournalOpener = func(source journalalerts.JournalSource) (journalalerts.JournalInterface, error) {
+		if openCount >= len(journals) {
+			return nil, aoserrors.New("journal open error")
+		}
+
+		journal := journals[openCount]
+		openCount++
+
+		return journal, nil
+	}
+	defer func() { journalalerts.SDJournalOpener = nil }()
+
+	testSender := newTestSender()
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		Sources:              []journalalerts.JournalSource{{Path: "/var/log/journal/remote"}},
+	},
+		&instanceProvider, &testCursorStorage{}, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	if err = waitResult(testSender.alertsChannel, 10*time.Second,
+		func(alert interface{}) (success bool, err error) {
+			coreAlert, ok := alert.(cloudprotocol.CoreAlert)
+			if !ok {
+				return false, errIncorrectType
+			}
+
+			if coreAlert.CoreComponent != "journalalerts" || coreAlert.Tag != cloudprotocol.AlertTagAosCore {
+				return false, aoserrors.Errorf("unexpected core alert: %v", coreAlert)
+			}
+
+			return true, nil
+		}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+
+	if err = waitResult(testSender.alertsChannel, 10*time.Second,
+		func(alert interface{}) (success bool, err error) {
+			systemAlert, ok := alert.(cloudprotocol.SystemAlert)
+			if !ok {
+				return false, errIncorrectType
+			}
+
+			if systemAlert.Message != "message after reopen" {
+				return false, aoserrors.Errorf("unexpected alert message: %s", systemAlert.Message)
+			}
+
+			return true, nil
+		}); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+}
+
+func TestSpool(t *testing.T) {
+	testJournal := testSystemdJournal{}
+	testSender := newTestSender()
+	journalalerts.SDJournal = &testJournal
+
+	spoolDir := t.TempDir()
+
+	alertsHandler, err := journalalerts.New(journalalerts.Config{
+		ServiceAlertPriority: 4,
+		SystemAlertPriority:  3,
+		MuteRules:            []journalalerts.MuteRule{{Pattern: "^noisy"}},
+		Spool:                journalalerts.SpoolConfig{Path: spoolDir, MaxFileSize: 1, MaxFiles: 3},
+	},
+		&instanceProvider, &cursorStorage, testSender)
+	if err != nil {
+		t.Fatalf("Can't create alerts: %s", err)
+	}
+	defer alertsHandler.Close()
+
+	var messages []string
+
+	for i := 1; i <= 6; i++ {
+		message := fmt.Sprintf("spool message %d", i)
+
+		if i == 2 {
+			message = "noisy " + message
+		} else {
+			messages = append(messages, message)
+		}
+
+		testJournal.addTimedMessage(message, uint64(i*1000000))
+	}
+
+	if err = waitAlerts(testSender.alertsChannel, 5*time.Second,
+		cloudprotocol.AlertTagSystemError, aostypes.InstanceIdent{}, "", messages); err != nil {
+		t.Errorf("Result failed: %s", err)
+	}
+
+	// Each record exceeds max file size, so the spool keeps one record per file and only last 3 records.
+	for index, message := range []string{"spool message 6", "spool message 5", "spool message 4"} {
+		fileName := filepath.Join(spoolDir, journalalerts.SpoolFileName)
+		if index > 0 {
+			fileName += "." + strconv.Itoa(index)
+		}
+
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatalf("Can't read spool file: %v", err)
+		}
+
+		var record journalalerts.SpoolRecord
+
+		if err = json.Unmarshal(data, &record); err != nil {
+			t.Fatalf("Can't parse spool record: %v", err)
+		}
+
+		if record.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE] != message {
+			t.Errorf("Wrong spooled message: %s", record.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE])
+		}
+
+		if record.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT] != "test.service" {
+			t.Errorf("Wrong spooled unit: %s", record.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT])
+		}
+
+		if record.Cursor == "" || record.Timestamp.IsZero() {
+			t.Errorf("Wrong spool record: %v", record)
+		}
+	}
+
+	if _, err = os.Stat(filepath.Join(spoolDir, journalalerts.SpoolFileName+".3")); !os.IsNotExist(err) {
+		t.Error("Spool files should be limited")
+	}
+}
+
+/***********************************************************************************************************************
+ * Interfaces
+ **********************************************************************************************************************/
+
+func (instanceProvider *testInstanceProvider) GetInstanceInfoByID(
+	id string,
+) (ident aostypes.InstanceIdent, version string, err error) {
+	instance, ok := instanceProvider.instancesInfo[id]
+	if !ok {
+		return ident, version, aoserrors.New("Instance does not exist")
+	}
+
+	return instance.instanceIdent, instance.serviceVersion, nil
+}
+
+func (instanceProvider *testInstanceProvider) GetInstanceIDByUID(uid int) (instanceID string, err error) {
+	instanceID, ok := instanceProvider.instanceUIDs[uid]
+	if !ok {
+		return "", aoserrors.New("instance UID does not exist")
+	}
+
+	return instanceID, nil
+}
+
+func (cursorStorage *testCursorStorage) SetJournalCursor(cursor string) (err error) {
+	cursorStorage.cursor = cursor
+
+	return nil
+}
+
+func (cursorStorage *testCursorStorage) GetJournalCursor() (cursor string, err error) {
+	return cursorStorage.cursor, nil
+}
+
+func (storage *testEscalationStorage) SetEscalationState(state []byte) (err error) {
+	storage.state = state
+
+	return nil
+}
+
+func (storage *testEscalationStorage) GetEscalationState() (state []byte, err error) {
+	return storage.state, nil
+}
+
+func (journal *testSystemdJournal) Next() (uint64, error) {
+	journal.Lock()
+	defer journal.Unlock()
+
+	if journal.nextError != nil {
+		return 0, journal.nextError
+	}
+
+	if len(journal.messages) == 0 {
+		return uint64(sdjournal.SD_JOURNAL_NOP), nil
+	}
+
+	if journal.currentMessage >= len(journal.messages)-1 {
+		return uint64(sdjournal.SD_JOURNAL_NOP), nil
+	}
+
+	journal.currentMessage++
+
+	return uint64(sdjournal.SD_JOURNAL_APPEND), nil
+}
+
+func (journal *testSystemdJournal) GetEntry() (entry *sdjournal.JournalEntry, err error) {
+	journal.RLock()
+	defer journal.RUnlock()
+
+	entry = journal.messages[journal.currentMessage]
+
+	return entry, nil
+}
+
+func (journal *testSystemdJournal) Wait(timeout time.Duration) int {
+	time.Sleep(timeout)
+
+	journal.RLock()
+	defer journal.RUnlock()
+
+	if len(journal.messages) == 0 {
+		return sdjournal.SD_JOURNAL_NOP
+	}
+
+	if journal.currentMessage >= len(journal.messages)-1 {
+		return sdjournal.SD_JOURNAL_NOP
+	}
+
+	return sdjournal.SD_JOURNAL_APPEND
+}
+
+func (journal *testSystemdJournal) Close() error { return nil }
+
+func (journal *testSystemdJournal) AddMatch(match string) error {
+	journal.Lock()
+	defer journal.Unlock()
+
+	journal.systemdMatches = append(journal.systemdMatches, match)
+
+	return nil
+}
+
+func (journal *testSystemdJournal) FlushMatches() {
+	journal.Lock()
+	defer journal.Unlock()
+
+	journal.systemdMatches = nil
+}
+
+func (journal *testSystemdJournal) hasMatch(match string) bool {
+	journal.RLock()
+	defer journal.RUnlock()
+
+	for _, journalMatch := range journal.systemdMatches {
+		if journalMatch == match {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (journal *testSystemdJournal) AddDisjunction() error { return nil }
+
+func (journal *testNoFlushJournal) Close() error {
+	journal.journal.Lock()
+	defer journal.journal.Unlock()
+
+	journal.journal.systemdMatches = nil
+
+	return nil
+}
+
+func (journal *testSystemdJournal) SeekTail() error { return nil }
+
+func (journal *testSystemdJournal) Previous() (uint64, error) {
+	journal.Lock()
+	defer journal.Unlock()
+
+	journal.currentMessage = -1
+
+	return uint64(sdjournal.SD_JOURNAL_NOP), nil
+}
+
+func (journal *testSystemdJournal) SeekCursor(cursor string) error { return nil }
+
+func (journal *testSystemdJournal) GetCursor() (string, error) { return "", nil }
+
+func (journal *testSystemdJournal) addMessage(message, systemdUnit, cgroupUnit, priority string) {
+	journal.Lock()
+	defer journal.Unlock()
+
+	journalEntry := sdjournal.JournalEntry{Fields: make(map[string]string)}
+
+	journalEntry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE] = message
+	journalEntry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT] = systemdUnit
+	journalEntry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_CGROUP] = cgroupUnit
+	journalEntry.Fields[sdjournal.SD_JOURNAL_FIELD_PRIORITY] = priority
+
+	journal.messages = append(journal.messages, &journalEntry)
+}
+
+func (journal *testSystemdJournal) addEntry(fields map[string]string) {
+	journal.Lock()
+	defer journal.Unlock()
+
+	journalEntry := sdjournal.JournalEntry{Fields: make(map[string]string)}
+
+	for name, value := range fields {
+		journalEntry.Fields[name] = value
+	}
+
+	journal.messages = append(journal.messages, &journalEntry)
+}
+
+func (journal *testSystemdJournal) addTimedMessage(message string, timestamp uint64) {
+	journal.Lock()
+	defer journal.Unlock()
+
+	journalEntry := sdjournal.JournalEntry{
+		Fields: map[string]string{
+			sdjournal.SD_JOURNAL_FIELD_MESSAGE:      message,
+			sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT: "test.service",
+			sdjournal.SD_JOURNAL_FIELD_PRIORITY:     "3",
+		},
+		Cursor:            fmt.Sprintf("cursor-%d", timestamp),
+		RealtimeTimestamp: timestamp,
+	}
+
+	journal.messages = append(journal.messages, &journalEntry)
+}
+
+func (sender *testSender) SendAlert(alert interface{}) {
+	sender.alertsChannel <- alert
+}
+
+func (sender *testAckSender) SendAlertWithAck(alert interface{}, ack func()) {
+	sender.acks <- ack
+}
+
+func (sender *testBatchSender) SendAlerts(alerts cloudprotocol.Alerts) {
+	sender.batchChannel <- alerts
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newTestSender() (sender *testSender) {
+	sender = &testSender{
+		alertsChannel: make(chan interface{}, 1),
+	}
+
+	return sender
+}
+
+func waitResult(alertsChannel <-chan interface{}, timeout time.Duration,
+	checkAlert func(alert interface{}) (success bool, err error),
+) (err error) {
+	for {
+		select {
+		case alert := <-alertsChannel:
+			success, err := checkAlert(alert)
+			if err != nil {
+				return aoserrors.Wrap(err)
+			}
+
+			if success {
+				return nil
+			}
+
+		case <-time.After(timeout):
+			return errTimeout
+		}
+	}
+}
+
+func waitAlerts(alertsChannel <-chan interface{}, timeout time.Duration,
+	tag string, instance aostypes.InstanceIdent, serviceVersion string, data []string,
+) (err error) {
+	return waitResult(alertsChannel, timeout, func(alert interface{}) (success bool, err error) {
+		for i, message := range data {
+			var alertMsg string
+
+			switch alertItem := alert.(type) {
+			case cloudprotocol.CoreAlert:
+				if alertItem.Tag != tag {
+					return false, errIncorrectType
+				}
+
+				alertMsg = alertItem.Message
+
+			case cloudprotocol.ServiceInstanceAlert:
+				if alertItem.Tag != tag {
+					return false, errIncorrectType
+				}
+
+				if alertItem.InstanceIdent != instance || serviceVersion != alertItem.ServiceVersion {
+					continue
+				}
+
+				alertMsg = alertItem.Message
+
+			case cloudprotocol.SystemAlert:
+				if alertItem.Tag != tag {
 					return false, errIncorrectType
 				}
 
@@ -523,3 +1850,13 @@ func waitAlerts(alertsChannel <-chan interface{}, timeout time.Duration,
 		return false, nil
 	})
 }
+
+func waitCondition(timeout time.Duration, condition func() bool) error {
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(100 * time.Millisecond) {
+		if condition() {
+			return nil
+		}
+	}
+
+	return errTimeout
+}
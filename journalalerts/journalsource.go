@@ -0,0 +1,393 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	machineIDFile         = "/etc/machine-id"
+	persistentJournalPath = "/var/log/journal"
+	volatileJournalPath   = "/run/log/journal"
+)
+
+const (
+	// maxJournalErrors number of consecutive journal errors after which journal is reopened.
+	maxJournalErrors     = 3
+	reopenInitialDelay   = 1 * time.Second
+	reopenMaxDelay       = 1 * time.Minute
+	healthAlertComponent = "journalalerts"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// JournalSource journal source configuration.
+type JournalSource struct {
+	// Namespace systemd journal namespace (see systemd-journald@.service).
+	Namespace string `json:"namespace"`
+	// Path journal directory (e.g. systemd-journal-remote output directory).
+	Path string `json:"path"`
+}
+
+type journalReader struct {
+	name           string
+	source         JournalSource
+	journal        JournalInterface
+	cursor         string
+	pending        []*pendingEntry
+	matchesChanged chan struct{}
+}
+
+type matchesFlusher interface {
+	FlushMatches()
+}
+
+type journalRecord struct {
+	reader *journalReader
+	entry  *sdjournal.JournalEntry
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (source JournalSource) name() string {
+	switch {
+	case source.Path != "":
+		return "path:" + source.Path
+
+	case source.Namespace != "":
+		return "namespace:" + source.Namespace
+
+	default:
+		return ""
+	}
+}
+
+func openJournal(source JournalSource) (journal JournalInterface, err error) {
+	if source.Path == "" && source.Namespace == "" && SDJournal != nil {
+		return SDJournal, nil
+	}
+
+	if SDJournalOpener != nil {
+		return SDJournalOpener(source)
+	}
+
+	switch {
+	case source.Path != "":
+		if journal, err = sdjournal.NewJournalFromDir(source.Path); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+	case source.Namespace != "":
+		namespacePath, err := getNamespacePath(source.Namespace)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		if journal, err = sdjournal.NewJournalFromDir(namespacePath); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+	default:
+		if journal, err = sdjournal.NewJournal(); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+	}
+
+	return journal, nil
+}
+
+func getNamespacePath(namespace string) (namespacePath string, err error) {
+	machineID, err := os.ReadFile(machineIDFile)
+	if err != nil {
+		return "", aoserrors.Wrap(err)
+	}
+
+	namespaceDir := strings.TrimSpace(string(machineID)) + "." + namespace
+
+	for _, journalPath := range []string{persistentJournalPath, volatileJournalPath} {
+		namespacePath = filepath.Join(journalPath, namespaceDir)
+
+		if _, err = os.Stat(namespacePath); err == nil {
+			return namespacePath, nil
+		}
+	}
+
+	return "", aoserrors.Errorf("journal namespace %s not found", namespace)
+}
+
+func (instance *JournalAlerts) readJournal(ctx context.Context, reader *journalReader) {
+	defer instance.readersWG.Done()
+
+	errorCount := 0
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case <-reader.matchesChanged:
+			if err := instance.updateMatches(reader); err != nil {
+				log.WithField("source", reader.name).Errorf("Can't update journal matches: %s", err)
+
+				instance.recoverReader(ctx, reader, err)
+			}
+
+		default:
+		}
+
+		entry, err := instance.nextEntry(reader)
+
+		if entry == nil {
+			if result := reader.journal.Wait(waitJournalTimeout); result < 0 && err == nil {
+				err = aoserrors.Errorf("wait journal error: %s", syscall.Errno(-result))
+			}
+		}
+
+		if err != nil {
+			log.WithField("source", reader.name).Errorf("Journal process error: %s", err)
+
+			if errorCount++; errorCount >= maxJournalErrors {
+				instance.recoverReader(ctx, reader, err)
+
+				errorCount = 0
+			}
+
+			continue
+		}
+
+		errorCount = 0
+
+		if entry == nil {
+			continue
+		}
+
+		select {
+		case instance.recordChannel <- journalRecord{reader: reader, entry: entry}:
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// recoverReader reports journal failure and reopens the journal with backoff until success or cancel.
+func (instance *JournalAlerts) recoverReader(ctx context.Context, reader *journalReader, cause error) {
+	message := fmt.Sprintf("Journal read failure: %s", cause)
+	if reader.name != "" {
+		message = fmt.Sprintf("Journal %s read failure: %s", reader.name, cause)
+	}
+
+	select {
+	case instance.healthChannel <- cloudprotocol.CoreAlert{
+		AlertItem:     cloudprotocol.AlertItem{Timestamp: time.Now(), Tag: cloudprotocol.AlertTagAosCore},
+		CoreComponent: healthAlertComponent,
+		Message:       message,
+	}:
+
+	case <-ctx.Done():
+		return
+	}
+
+	delay := reopenInitialDelay
+
+	for {
+		select {
+		case <-time.After(delay):
+
+		case <-ctx.Done():
+			return
+		}
+
+		err := instance.reopenReader(reader)
+		if err == nil {
+			log.WithField("source", reader.name).Info("Journal reopened")
+
+			return
+		}
+
+		log.WithField("source", reader.name).Errorf("Can't reopen journal: %s", err)
+
+		if delay *= 2; delay > reopenMaxDelay {
+			delay = reopenMaxDelay
+		}
+	}
+}
+
+func (instance *JournalAlerts) reopenReader(reader *journalReader) (err error) {
+	log.WithField("source", reader.name).Debug("Reopen journal")
+
+	if reader.journal != nil {
+		if err := reader.journal.Close(); err != nil {
+			log.WithField("source", reader.name).Errorf("Can't close journal: %s", err)
+		}
+
+		reader.journal = nil
+	}
+
+	journal, err := openJournal(reader.source)
+	if err != nil {
+		return err
+	}
+
+	instance.Lock()
+	cursor := reader.cursor
+	instance.Unlock()
+
+	reader.journal = journal
+
+	if err = instance.setupReader(reader, cursor); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (instance *JournalAlerts) updateMatches(reader *journalReader) error {
+	flusher, ok := reader.journal.(matchesFlusher)
+	if !ok {
+		// matches can't be removed from opened journal, reopen it to apply new matches
+		return instance.reopenReader(reader)
+	}
+
+	flusher.FlushMatches()
+
+	return instance.addMatches(reader)
+}
+
+func (instance *JournalAlerts) nextEntry(reader *journalReader) (entry *sdjournal.JournalEntry, err error) {
+	count, err := reader.journal.Next()
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if count == 0 {
+		return nil, nil
+	}
+
+	if entry, err = reader.journal.GetEntry(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return entry, nil
+}
+
+// collectRecords collects records available from all readers and sorts them by timestamp.
+func (instance *JournalAlerts) collectRecords(ctx context.Context, record journalRecord) []journalRecord {
+	records := []journalRecord{record}
+
+	if len(instance.readers) <= 1 {
+		return records
+	}
+
+	mergeTimer := time.NewTimer(mergeRecordsDelay)
+	defer mergeTimer.Stop()
+
+	for {
+		select {
+		case record := <-instance.recordChannel:
+			records = append(records, record)
+
+		case <-mergeTimer.C:
+			sort.SliceStable(records, func(i, j int) bool {
+				return records[i].entry.RealtimeTimestamp < records[j].entry.RealtimeTimestamp
+			})
+
+			return records
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (instance *JournalAlerts) loadCursors() (cursors map[string]string, err error) {
+	cursor, err := instance.cursorStorage.GetJournalCursor()
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	cursors = make(map[string]string)
+
+	if cursor == "" {
+		return cursors, nil
+	}
+
+	// Multiple sources cursors are stored as JSON object, single source cursor is stored as is
+	if strings.HasPrefix(cursor, "{") {
+		if err = json.Unmarshal([]byte(cursor), &cursors); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return cursors, nil
+	}
+
+	if len(instance.config.Sources) <= 1 {
+		cursors[JournalSource{}.name()] = cursor
+
+		if len(instance.config.Sources) == 1 {
+			cursors[instance.config.Sources[0].name()] = cursor
+		}
+	}
+
+	return cursors, nil
+}
+
+func (instance *JournalAlerts) encodeCursors() (cursor string, err error) {
+	if len(instance.readers) == 1 {
+		return instance.readers[0].cursor, nil
+	}
+
+	cursors := make(map[string]string)
+
+	for _, reader := range instance.readers {
+		if reader.cursor != "" {
+			cursors[reader.name] = reader.cursor
+		}
+	}
+
+	data, err := json.Marshal(cursors)
+	if err != nil {
+		return "", aoserrors.Wrap(err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"regexp"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const kernelTransport = "kernel"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// KernelConfig kernel alerts configuration.
+type KernelConfig struct {
+	Enabled bool         `json:"enabled"`
+	Rules   []KernelRule `json:"rules"`
+}
+
+// KernelRule kernel message classification rule.
+type KernelRule struct {
+	Category string `json:"category"`
+	Pattern  string `json:"pattern"`
+}
+
+type kernelClassifier struct {
+	category string
+	regexp   *regexp.Regexp
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// DefaultKernelRules default kernel message classification rules used if no rules are configured.
+var DefaultKernelRules = []KernelRule{ //nolint:gochecknoglobals
+	{
+		Category: cloudprotocol.KernelAlertCategoryOops,
+		Pattern:  `(?i)(kernel BUG|Oops:|general protection fault|Kernel panic|Unable to handle kernel)`,
+	},
+	{
+		Category: cloudprotocol.KernelAlertCategoryOOM,
+		Pattern:  `(?i)(invoked oom-killer|Out of memory|oom-kill:|oom_reaper)`,
+	},
+	{
+		Category: cloudprotocol.KernelAlertCategoryIOError,
+		Pattern:  `(?i)(I/O error|blk_update_request|critical medium error|EXT4-fs error|journal commit I/O error)`,
+	},
+	{
+		Category: cloudprotocol.KernelAlertCategoryWatchdog,
+		Pattern:  `(?i)(watchdog|soft lockup|hard LOCKUP|blocked for more than \d+ seconds|rcu_sched self-detected stall)`,
+	},
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newKernelClassifiers(rules []KernelRule) (classifiers []kernelClassifier) {
+	if len(rules) == 0 {
+		rules = DefaultKernelRules
+	}
+
+	for _, rule := range rules {
+		if rule.Category == "" || rule.Pattern == "" {
+			log.Warningf("Kernel rule has an empty category or pattern: %v", rule)
+
+			continue
+		}
+
+		ruleRegexp, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			log.Errorf("Regexp compile error. Incorrect kernel rule regexp: %s, error is: %s", rule.Pattern, err)
+
+			continue
+		}
+
+		classifiers = append(classifiers, kernelClassifier{category: rule.Category, regexp: ruleRegexp})
+	}
+
+	return classifiers
+}
+
+func isKernelEntry(entry *sdjournal.JournalEntry) bool {
+	return entry.Fields[sdjournal.SD_JOURNAL_FIELD_TRANSPORT] == kernelTransport
+}
+
+func (instance *JournalAlerts) getKernelAlert(entry *sdjournal.JournalEntry) *cloudprotocol.KernelAlert {
+	message := entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE]
+
+	for _, classifier := range instance.kernelClassifiers {
+		if classifier.regexp.MatchString(message) {
+			return &cloudprotocol.KernelAlert{Category: classifier.category, Message: message}
+		}
+	}
+
+	return nil
+}
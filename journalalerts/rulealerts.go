@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"regexp"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// ClassificationRule maps messages matching pattern to alert with specified tag and severity.
+// Named capture groups of the pattern are added to alert parameters.
+type ClassificationRule struct {
+	Name     string `json:"name"`
+	Pattern  string `json:"pattern"`
+	Tag      string `json:"tag"`
+	Severity string `json:"severity"`
+}
+
+type classificationRule struct {
+	ClassificationRule
+	regexp *regexp.Regexp
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newClassificationRules(configRules []ClassificationRule) (rules []classificationRule) {
+	for _, configRule := range configRules {
+		if configRule.Pattern == "" {
+			log.Warningf("Classification rule %s has an empty pattern", configRule.Name)
+
+			continue
+		}
+
+		ruleRegexp, err := regexp.Compile(configRule.Pattern)
+		if err != nil {
+			log.Errorf("Regexp compile error. Incorrect rule regexp: %s, error is: %s", configRule.Pattern, err)
+
+			continue
+		}
+
+		rule := classificationRule{ClassificationRule: configRule, regexp: ruleRegexp}
+
+		if rule.Name == "" {
+			rule.Name = rule.Pattern
+		}
+
+		if rule.Tag == "" {
+			rule.Tag = cloudprotocol.AlertTagSystemError
+		}
+
+		if rule.Severity == "" {
+			rule.Severity = cloudprotocol.AlertSeverityError
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func (instance *JournalAlerts) getRuleAlert(entry *sdjournal.JournalEntry) *cloudprotocol.RuleAlert {
+	message := entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE]
+
+	for _, rule := range instance.rules {
+		match := rule.regexp.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+
+		alert := &cloudprotocol.RuleAlert{
+			AlertItem: cloudprotocol.AlertItem{Tag: rule.Tag},
+			Rule:      rule.Name,
+			Severity:  rule.Severity,
+			Message:   message,
+		}
+
+		for i, name := range rule.regexp.SubexpNames() {
+			if i == 0 || name == "" {
+				continue
+			}
+
+			if alert.Parameters == nil {
+				alert.Parameters = make(map[string]string)
+			}
+
+			alert.Parameters[name] = match[i]
+		}
+
+		return alert
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// SpoolFileName name of current spool file. Rotated files have numeric suffix: alerts.jsonl.1, alerts.jsonl.2 etc.
+const SpoolFileName = "alerts.jsonl"
+
+const (
+	defaultSpoolMaxFileSize = 1024 * 1024
+	defaultSpoolMaxFiles    = 4
+)
+
+const spoolDirPerm = 0o755
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// SpoolConfig spool configuration of raw journal entries which triggered alerts. Spooled entries keep complete
+// alert context available after journald rotation.
+type SpoolConfig struct {
+	// Path spool directory, spool is disabled if not set.
+	Path string `json:"path"`
+	// MaxFileSize max spool file size in bytes before rotation, default is 1MB.
+	MaxFileSize int64 `json:"maxFileSize"`
+	// MaxFiles max number of spool files including current one, default is 4.
+	MaxFiles int `json:"maxFiles"`
+}
+
+// SpoolRecord spooled journal entry.
+type SpoolRecord struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Cursor    string            `json:"cursor,omitempty"`
+	Fields    map[string]string `json:"fields"`
+}
+
+type entrySpool struct {
+	sync.Mutex
+	config SpoolConfig
+	file   *os.File
+	size   int64
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newEntrySpool(config SpoolConfig) (spool *entrySpool, err error) {
+	if config.Path == "" {
+		return nil, nil
+	}
+
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = defaultSpoolMaxFileSize
+	}
+
+	if config.MaxFiles <= 0 {
+		config.MaxFiles = defaultSpoolMaxFiles
+	}
+
+	if err = os.MkdirAll(config.Path, spoolDirPerm); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	spool = &entrySpool{config: config}
+
+	if err = spool.openFile(); err != nil {
+		return nil, err
+	}
+
+	return spool, nil
+}
+
+func (spool *entrySpool) close() {
+	spool.Lock()
+	defer spool.Unlock()
+
+	if spool.file == nil {
+		return
+	}
+
+	if err := spool.file.Close(); err != nil {
+		log.Errorf("Can't close spool file: %v", err)
+	}
+
+	spool.file = nil
+}
+
+func (spool *entrySpool) write(entry *sdjournal.JournalEntry) error {
+	spool.Lock()
+	defer spool.Unlock()
+
+	if spool.file == nil {
+		return aoserrors.New("spool is closed")
+	}
+
+	data, err := json.Marshal(SpoolRecord{
+		Timestamp: time.UnixMicro(int64(entry.RealtimeTimestamp)),
+		Cursor:    entry.Cursor,
+		Fields:    entry.Fields,
+	})
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	data = append(data, '\n')
+
+	if spool.size > 0 && spool.size+int64(len(data)) > spool.config.MaxFileSize {
+		if err = spool.rotate(); err != nil {
+			return err
+		}
+	}
+
+	written, err := spool.file.Write(data)
+	spool.size += int64(written)
+
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func (spool *entrySpool) openFile() error {
+	file, err := os.OpenFile(spool.filePath(0), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return aoserrors.Wrap(err)
+	}
+
+	spool.file = file
+	spool.size = info.Size()
+
+	return nil
+}
+
+func (spool *entrySpool) rotate() (err error) {
+	if closeErr := spool.file.Close(); closeErr != nil {
+		log.Errorf("Can't close spool file: %v", closeErr)
+	}
+
+	spool.file = nil
+
+	for index := spool.config.MaxFiles - 1; index >= 0 && err == nil; index-- {
+		if index == spool.config.MaxFiles-1 {
+			err = os.Remove(spool.filePath(index))
+		} else {
+			err = os.Rename(spool.filePath(index), spool.filePath(index+1))
+		}
+
+		if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+
+	// Reopen spool file even if rotation failed to keep spooling new entries.
+	if openErr := spool.openFile(); openErr != nil && err == nil {
+		err = openErr
+	}
+
+	return aoserrors.Wrap(err)
+}
+
+func (spool *entrySpool) filePath(index int) string {
+	fileName := filepath.Join(spool.config.Path, SpoolFileName)
+
+	if index == 0 {
+		return fileName
+	}
+
+	return fileName + "." + strconv.Itoa(index)
+}
+
+func (instance *JournalAlerts) spoolEntry() {
+	entry := instance.matchedEntry
+	if instance.spool == nil || entry == nil {
+		return
+	}
+
+	// Entry is spooled once even if it produces several alerts.
+	instance.matchedEntry = nil
+
+	if err := instance.spool.write(entry); err != nil {
+		log.Errorf("Can't spool journal entry: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/utils/alertutils"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// SuppressionWindow time window (e.g. maintenance) during which alerts are suppressed.
+// Empty tags suppress all alerts.
+type SuppressionWindow struct {
+	From time.Time `json:"from"`
+	Till time.Time `json:"till"`
+	Tags []string  `json:"tags,omitempty"`
+}
+
+// MuteRule suppresses alerts which message matches pattern. Empty tags mute alerts with any tag,
+// zero till time mutes alerts forever.
+type MuteRule struct {
+	Pattern string    `json:"pattern"`
+	Tags    []string  `json:"tags,omitempty"`
+	Till    time.Time `json:"till,omitempty"`
+}
+
+type muteRule struct {
+	MuteRule
+	regexp *regexp.Regexp
+}
+
+type alertSuppressor struct {
+	sync.Mutex
+	windows   []SuppressionWindow
+	muteRules []muteRule
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// SetSuppressionWindows replaces alerts suppression windows.
+func (instance *JournalAlerts) SetSuppressionWindows(windows []SuppressionWindow) error {
+	for _, window := range windows {
+		if !window.Till.After(window.From) {
+			return aoserrors.Errorf("wrong suppression window: %s - %s", window.From, window.Till)
+		}
+	}
+
+	log.WithField("count", len(windows)).Debug("Set suppression windows")
+
+	instance.suppressor.Lock()
+	defer instance.suppressor.Unlock()
+
+	instance.suppressor.windows = windows
+
+	return nil
+}
+
+// SetMuteRules replaces alerts mute rules.
+func (instance *JournalAlerts) SetMuteRules(rules []MuteRule) error {
+	muteRules := make([]muteRule, 0, len(rules))
+
+	for _, rule := range rules {
+		muteRule, err := newMuteRule(rule)
+		if err != nil {
+			return err
+		}
+
+		muteRules = append(muteRules, muteRule)
+	}
+
+	log.WithField("count", len(rules)).Debug("Set mute rules")
+
+	instance.suppressor.Lock()
+	defer instance.suppressor.Unlock()
+
+	instance.suppressor.muteRules = muteRules
+
+	return nil
+}
+
+// SetAlertPolicy sets shared alert verbosity policy. Alerts not allowed by the policy are dropped.
+func (instance *JournalAlerts) SetAlertPolicy(policy *alertutils.AlertPolicy) {
+	instance.alertPolicy.Store(policy)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newMuteRules(configRules []MuteRule) (rules []muteRule) {
+	for _, configRule := range configRules {
+		rule, err := newMuteRule(configRule)
+		if err != nil {
+			log.Errorf("Incorrect mute rule: %s", err)
+
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func newMuteRule(rule MuteRule) (muteRule, error) {
+	// empty pattern matches all alerts
+	if rule.Pattern == "" {
+		return muteRule{}, aoserrors.New("mute rule has an empty pattern")
+	}
+
+	ruleRegexp, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		return muteRule{}, aoserrors.Wrap(err)
+	}
+
+	return muteRule{MuteRule: rule, regexp: ruleRegexp}, nil
+}
+
+func (suppressor *alertSuppressor) isSuppressed(alert interface{}) bool {
+	suppressor.Lock()
+	defer suppressor.Unlock()
+
+	if len(suppressor.windows) == 0 && len(suppressor.muteRules) == 0 {
+		return false
+	}
+
+	alertItem := cloudprotocol.GetAlertItem(alert)
+
+	for _, window := range suppressor.windows {
+		if !alertItem.Timestamp.Before(window.From) && alertItem.Timestamp.Before(window.Till) &&
+			matchTags(window.Tags, alertItem.Tag) {
+			return true
+		}
+	}
+
+	message := cloudprotocol.GetAlertMessage(alert)
+
+	for _, rule := range suppressor.muteRules {
+		if !rule.Till.IsZero() && !alertItem.Timestamp.Before(rule.Till) {
+			continue
+		}
+
+		if matchTags(rule.Tags, alertItem.Tag) && rule.regexp.MatchString(message) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchTags(tags []string, tag string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+
+	for _, item := range tags {
+		if item == tag {
+			return true
+		}
+	}
+
+	return false
+}
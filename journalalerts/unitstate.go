@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/coreos/go-systemd/v22/dbus"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	unitStateTimeout   = 2 * time.Second
+	unitStateCacheTTL  = time.Second
+	unitStateCacheSize = 256
+)
+
+const (
+	unitPropertyActiveState    = "ActiveState"
+	unitPropertySubState       = "SubState"
+	unitPropertyExecMainStatus = "ExecMainStatus"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// systemdConnection systemd D-Bus connection methods used to query unit state.
+type systemdConnection interface {
+	GetUnitPropertiesContext(ctx context.Context, unit string) (map[string]interface{}, error)
+	GetServicePropertyContext(ctx context.Context, service string, propertyName string) (*dbus.Property, error)
+	Connected() bool
+	Close()
+}
+
+// unitStateCache queries unit states over one shared systemd connection and caches them for a short time to not
+// query systemd for each alert of failing unit.
+type unitStateCache struct {
+	sync.Mutex
+
+	conn   systemdConnection
+	states map[string]cachedUnitState
+}
+
+type cachedUnitState struct {
+	state   cloudprotocol.UnitState
+	expires time.Time
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// UnitStateGetter is using to mock systemd unit state query in unit tests.
+var UnitStateGetter func(unitName string) (cloudprotocol.UnitState, error) //nolint:gochecknoglobals
+
+//nolint:gochecknoglobals // used to mock systemd connection in unit tests
+var newSystemdConnection = func(ctx context.Context) (systemdConnection, error) {
+	return dbus.NewSystemConnectionContext(ctx)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newUnitStateCache() *unitStateCache {
+	return &unitStateCache{states: make(map[string]cachedUnitState)}
+}
+
+func (cache *unitStateCache) getUnitState(unitName string) (unitState cloudprotocol.UnitState, err error) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	now := time.Now()
+
+	if cached, ok := cache.states[unitName]; ok && now.Before(cached.expires) {
+		return cached.state, nil
+	}
+
+	if unitState, err = cache.queryUnitState(unitName); err != nil {
+		return unitState, err
+	}
+
+	if len(cache.states) >= unitStateCacheSize {
+		for name, cached := range cache.states {
+			if !now.Before(cached.expires) {
+				delete(cache.states, name)
+			}
+		}
+	}
+
+	cache.states[unitName] = cachedUnitState{state: unitState, expires: now.Add(unitStateCacheTTL)}
+
+	return unitState, nil
+}
+
+func (cache *unitStateCache) queryUnitState(unitName string) (unitState cloudprotocol.UnitState, err error) {
+	if UnitStateGetter != nil {
+		return UnitStateGetter(unitName)
+	}
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), unitStateTimeout)
+	defer cancelFunc()
+
+	if cache.conn == nil {
+		if cache.conn, err = newSystemdConnection(ctx); err != nil {
+			cache.conn = nil
+
+			return unitState, aoserrors.Wrap(err)
+		}
+	}
+
+	if unitState, err = getUnitState(ctx, cache.conn, unitName); err != nil {
+		// Error reply doesn't break the connection: reconnect on next query only if connection is lost.
+		if !cache.conn.Connected() {
+			cache.conn.Close()
+			cache.conn = nil
+		}
+
+		return unitState, err
+	}
+
+	return unitState, nil
+}
+
+func (cache *unitStateCache) close() {
+	cache.Lock()
+	defer cache.Unlock()
+
+	if cache.conn != nil {
+		cache.conn.Close()
+		cache.conn = nil
+	}
+}
+
+func getUnitState(
+	ctx context.Context, conn systemdConnection, unitName string,
+) (unitState cloudprotocol.UnitState, err error) {
+	properties, err := conn.GetUnitPropertiesContext(ctx, unitName)
+	if err != nil {
+		return unitState, aoserrors.Wrap(err)
+	}
+
+	var ok bool
+
+	if unitState.ActiveState, ok = properties[unitPropertyActiveState].(string); !ok {
+		return unitState, aoserrors.Errorf("wrong %s property type", unitPropertyActiveState)
+	}
+
+	if unitState.SubState, ok = properties[unitPropertySubState].(string); !ok {
+		return unitState, aoserrors.Errorf("wrong %s property type", unitPropertySubState)
+	}
+
+	property, err := conn.GetServicePropertyContext(ctx, unitName, unitPropertyExecMainStatus)
+	if err != nil {
+		return unitState, aoserrors.Wrap(err)
+	}
+
+	status, ok := property.Value.Value().(int32)
+	if !ok {
+		return unitState, aoserrors.Errorf("wrong %s property type", unitPropertyExecMainStatus)
+	}
+
+	unitState.ExecMainStatus = int(status)
+
+	return unitState, nil
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journalalerts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/coreos/go-systemd/v22/dbus"
+	godbus "github.com/godbus/dbus/v5"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testSystemdConnection struct {
+	properties    map[string]map[string]interface{}
+	connected     bool
+	propertyCalls int
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestUnitStateCache(t *testing.T) {
+	unitStateGetter := UnitStateGetter
+	UnitStateGetter = nil
+
+	defer func() { UnitStateGetter = unitStateGetter }()
+
+	var connections []*testSystemdConnection
+
+	connectionFunc := newSystemdConnection
+	newSystemdConnection = func(ctx context.Context) (systemdConnection, error) {
+		conn := &testSystemdConnection{
+			connected: true,
+			properties: map[string]map[string]interface{}{
+				"test.service": {
+					unitPropertyActiveState: "failed", unitPropertySubState: "failed",
+					unitPropertyExecMainStatus: int32(11),
+				},
+				"wrong.service": {unitPropertyActiveState: 1, unitPropertySubState: "failed"},
+			},
+		}
+
+		connections = append(connections, conn)
+
+		return conn, nil
+	}
+
+	defer func() { newSystemdConnection = connectionFunc }()
+
+	cache := newUnitStateCache()
+	defer cache.close()
+
+	expectedState := cloudprotocol.UnitState{ActiveState: "failed", SubState: "failed", ExecMainStatus: 11}
+
+	for range 3 {
+		unitState, err := cache.getUnitState("test.service")
+		if err != nil {
+			t.Fatalf("Can't get unit state: %v", err)
+		}
+
+		if unitState != expectedState {
+			t.Errorf("Wrong unit state: %v", unitState)
+		}
+	}
+
+	if calls := connections[0].propertyCalls; calls != 1 {
+		t.Errorf("Unit state should be cached, properties calls: %d", calls)
+	}
+
+	if _, err := cache.getUnitState("unknown.service"); err == nil {
+		t.Error("Error expected for unknown unit")
+	}
+
+	if _, err := cache.getUnitState("wrong.service"); err == nil {
+		t.Error("Error expected for wrong property type")
+	}
+
+	if len(connections) != 1 {
+		t.Errorf("Systemd connection should be shared, connections: %d", len(connections))
+	}
+
+	// Lost connection should be reestablished on next query.
+
+	connections[0].connected = false
+
+	if _, err := cache.getUnitState("unknown.service"); err == nil {
+		t.Error("Error expected for unknown unit")
+	}
+
+	if _, err := cache.getUnitState("wrong.service"); err == nil {
+		t.Error("Error expected for wrong property type")
+	}
+
+	if len(connections) != 2 {
+		t.Errorf("Systemd connection should be reestablished, connections: %d", len(connections))
+	}
+}
+
+/***********************************************************************************************************************
+ * testSystemdConnection
+ **********************************************************************************************************************/
+
+func (conn *testSystemdConnection) GetUnitPropertiesContext(
+	ctx context.Context, unit string,
+) (map[string]interface{}, error) {
+	conn.propertyCalls++
+
+	properties, ok := conn.properties[unit]
+	if !ok {
+		return nil, godbus.Error{Name: "org.freedesktop.systemd1.NoSuchUnit"}
+	}
+
+	return properties, nil
+}
+
+func (conn *testSystemdConnection) GetServicePropertyContext(
+	ctx context.Context, service string, propertyName string,
+) (*dbus.Property, error) {
+	value, ok := conn.properties[service][propertyName]
+	if !ok {
+		return nil, errors.New("property not found")
+	}
+
+	return &dbus.Property{Name: propertyName, Value: godbus.MakeVariant(value)}, nil
+}
+
+func (conn *testSystemdConnection) Connected() bool {
+	return conn.connected
+}
+
+func (conn *testSystemdConnection) Close() {
+	conn.connected = false
+}
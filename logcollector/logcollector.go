@@ -0,0 +1,445 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logcollector provides collecting of journal logs on cloud log request.
+package logcollector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	defaultMaxPartSize  = 512 * 1024
+	defaultMaxPartCount = 80
+)
+
+const (
+	microSecondsInSecond = 1000000
+	aosServiceUnitFormat = "aos-service@%s.service"
+	coredumpMessageID    = "fc2e22bc6ee647b6b90729ab34a250b1"
+	coredumpUnitField    = "COREDUMP_UNIT"
+	syslogIDField        = "SYSLOG_IDENTIFIER"
+)
+
+const (
+	// gzip header, trailer and flush/final block markers.
+	gzipOverhead = 32
+	// deflate stored block size and its header size.
+	deflateBlockSize     = 65535
+	deflateBlockOverhead = 5
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Config log collector configuration.
+type Config struct {
+	// MaxPartSize max size of compressed log part in bytes.
+	MaxPartSize uint64 `json:"maxPartSize"`
+	// MaxPartCount max number of log parts. Logs exceeding this limit are truncated.
+	MaxPartCount uint64 `json:"maxPartCount"`
+}
+
+// InstanceIDProvider provides service instance IDs.
+type InstanceIDProvider interface {
+	GetInstanceIDs(filter cloudprotocol.InstanceFilter) (instanceIDs []string, err error)
+}
+
+// LogSender sends log parts.
+type LogSender interface {
+	SendLog(serviceLog cloudprotocol.PushLog)
+}
+
+// JournalInterface systemd journal interface.
+type JournalInterface interface {
+	Close() error
+	AddMatch(match string) error
+	AddDisjunction() error
+	SeekHead() error
+	SeekRealtimeUsec(usec uint64) error
+	Next() (uint64, error)
+	GetEntry() (*sdjournal.JournalEntry, error)
+}
+
+// LogCollector log collector instance.
+type LogCollector struct {
+	config           Config
+	nodeID           string
+	instanceProvider InstanceIDProvider
+	sender           LogSender
+	ctx              context.Context //nolint:containedctx
+	cancelFunction   context.CancelFunc
+	wg               sync.WaitGroup
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// SDJournalOpener is using to mock systemd journal in unit tests.
+var SDJournalOpener = func() (JournalInterface, error) { //nolint:gochecknoglobals
+	journal, err := sdjournal.NewJournal()
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return journal, nil
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// New creates new log collector.
+func New(
+	config Config, nodeID string, instanceProvider InstanceIDProvider, sender LogSender,
+) (collector *LogCollector, err error) {
+	log.WithField("nodeID", nodeID).Debug("Create log collector")
+
+	if sender == nil {
+		return nil, aoserrors.New("log sender should be set")
+	}
+
+	if config.MaxPartSize == 0 {
+		config.MaxPartSize = defaultMaxPartSize
+	}
+
+	if config.MaxPartCount == 0 {
+		config.MaxPartCount = defaultMaxPartCount
+	}
+
+	collector = &LogCollector{
+		config:           config,
+		nodeID:           nodeID,
+		instanceProvider: instanceProvider,
+		sender:           sender,
+	}
+
+	collector.ctx, collector.cancelFunction = context.WithCancel(context.Background())
+
+	return collector, nil
+}
+
+// Close closes log collector and waits for pending requests.
+func (collector *LogCollector) Close() {
+	log.Debug("Close log collector")
+
+	collector.cancelFunction()
+	collector.wg.Wait()
+}
+
+// GetLog handles log request. Log is collected asynchronously and sent by parts with log sender.
+func (collector *LogCollector) GetLog(request cloudprotocol.RequestLog) (err error) {
+	log.WithFields(log.Fields{
+		"logID": request.LogID, "logType": request.LogType,
+	}).Debug("Get log")
+
+	if request.Filter.From != nil && request.Filter.Till != nil && request.Filter.Till.Before(*request.Filter.From) {
+		return aoserrors.New("wrong log time filter")
+	}
+
+	if !collector.isNodeRequested(request.Filter.NodeIDs) {
+		log.WithField("logID", request.LogID).Debug("Log is not requested for this node")
+
+		return nil
+	}
+
+	collector.wg.Add(1)
+
+	go func() {
+		defer collector.wg.Done()
+
+		if err := collector.processRequest(request); err != nil {
+			log.WithField("logID", request.LogID).Errorf("Can't get log: %s", err)
+
+			collector.sendError(request.LogID, err)
+		}
+	}()
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (collector *LogCollector) isNodeRequested(nodeIDs []string) bool {
+	if len(nodeIDs) == 0 {
+		return true
+	}
+
+	for _, nodeID := range nodeIDs {
+		if nodeID == collector.nodeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (collector *LogCollector) processRequest(request cloudprotocol.RequestLog) (err error) {
+	units, err := collector.getUnits(request)
+	if err != nil {
+		return err
+	}
+
+	if units != nil && len(units) == 0 {
+		collector.sendStatus(request.LogID, cloudprotocol.LogStatusAbsent)
+
+		return nil
+	}
+
+	journal, err := SDJournalOpener()
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer journal.Close()
+
+	if err = addMatches(journal, request.LogType, units); err != nil {
+		return err
+	}
+
+	if request.Filter.From != nil {
+		err = journal.SeekRealtimeUsec(uint64(request.Filter.From.UnixNano() / 1000))
+	} else {
+		err = journal.SeekHead()
+	}
+
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	content, err := collector.collectLog(journal, request.Filter.Till)
+	if err != nil {
+		return err
+	}
+
+	if len(content) == 0 {
+		collector.sendStatus(request.LogID, cloudprotocol.LogStatusEmpty)
+
+		return nil
+	}
+
+	collector.sendParts(request.LogID, content)
+
+	return nil
+}
+
+// getUnits returns nil for system log and instance units for service and crash logs. Crash log without instance
+// filter is collected for all units.
+func (collector *LogCollector) getUnits(request cloudprotocol.RequestLog) (units []string, err error) {
+	switch request.LogType {
+	case cloudprotocol.SystemLog:
+		return nil, nil
+
+	case cloudprotocol.ServiceLog, cloudprotocol.CrashLog:
+		filter := request.Filter.InstanceFilter.Normalize()
+
+		if request.LogType == cloudprotocol.CrashLog && filter.IsEmpty() {
+			return nil, nil
+		}
+
+		if collector.instanceProvider == nil {
+			return nil, aoserrors.New("instance provider is not set")
+		}
+
+		instanceIDs, err := collector.instanceProvider.GetInstanceIDs(filter)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		units = make([]string, 0, len(instanceIDs))
+
+		for _, instanceID := range instanceIDs {
+			units = append(units, fmt.Sprintf(aosServiceUnitFormat, instanceID))
+		}
+
+		return units, nil
+
+	default:
+		return nil, aoserrors.Errorf("unsupported log type: %s", request.LogType)
+	}
+}
+
+func addMatches(journal JournalInterface, logType string, units []string) (err error) {
+	unitField := sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT
+
+	if logType == cloudprotocol.CrashLog {
+		if err = journal.AddMatch(sdjournal.SD_JOURNAL_FIELD_MESSAGE_ID + "=" + coredumpMessageID); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		unitField = coredumpUnitField
+	}
+
+	for _, unit := range units {
+		if err = journal.AddMatch(unitField + "=" + unit); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (collector *LogCollector) collectLog(journal JournalInterface, till *time.Time) (content []byte, err error) {
+	var (
+		buffer     bytes.Buffer
+		hasEntries bool
+		truncated  bool
+		pending    int
+		maxSize    = int(collector.config.MaxPartSize * collector.config.MaxPartCount)
+	)
+
+	zipWriter := gzip.NewWriter(&buffer)
+
+	for {
+		if collector.ctx.Err() != nil {
+			return nil, aoserrors.Wrap(collector.ctx.Err())
+		}
+
+		count, err := journal.Next()
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		if count == 0 {
+			break
+		}
+
+		entry, err := journal.GetEntry()
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		timestamp := time.Unix(int64(entry.RealtimeTimestamp/microSecondsInSecond),
+			int64((entry.RealtimeTimestamp%microSecondsInSecond)*1000))
+
+		if till != nil && timestamp.After(*till) {
+			break
+		}
+
+		line := []byte(formatEntry(entry, timestamp))
+
+		// Compressed size of not flushed data is unknown. Flush it only if its worst case size doesn't fit.
+		if buffer.Len()+deflateBound(pending+len(line))+gzipOverhead > maxSize {
+			if err = zipWriter.Flush(); err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+
+			pending = 0
+
+			if buffer.Len()+deflateBound(len(line))+gzipOverhead > maxSize {
+				truncated = true
+
+				break
+			}
+		}
+
+		if _, err = zipWriter.Write(line); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		pending += len(line)
+		hasEntries = true
+	}
+
+	if truncated {
+		log.Warning("Log size exceeds max size and is truncated")
+	}
+
+	if err = zipWriter.Close(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if !hasEntries {
+		return nil, nil
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// deflateBound returns max compressed size of data with specified size: in worst case deflate stores data as is.
+func deflateBound(size int) int {
+	return size + (size/deflateBlockSize+1)*deflateBlockOverhead
+}
+
+func formatEntry(entry *sdjournal.JournalEntry, timestamp time.Time) string {
+	source := entry.Fields[sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT]
+	if source == "" {
+		source = entry.Fields[syslogIDField]
+	}
+
+	return fmt.Sprintf("%s %s %s\n", timestamp.UTC().Format(time.RFC3339Nano), source,
+		entry.Fields[sdjournal.SD_JOURNAL_FIELD_MESSAGE])
+}
+
+func (collector *LogCollector) sendParts(logID string, content []byte) {
+	partSize := int(collector.config.MaxPartSize)
+	partsCount := (len(content) + partSize - 1) / partSize
+
+	for part := 0; part < partsCount; part++ {
+		end := (part + 1) * partSize
+		if end > len(content) {
+			end = len(content)
+		}
+
+		collector.sender.SendLog(cloudprotocol.PushLog{
+			MessageType: cloudprotocol.PushLogMessageType,
+			NodeID:      collector.nodeID,
+			LogID:       logID,
+			PartsCount:  uint64(partsCount),
+			Part:        uint64(part + 1),
+			Content:     content[part*partSize : end],
+			Status:      cloudprotocol.LogStatusOk,
+		})
+	}
+}
+
+func (collector *LogCollector) sendStatus(logID, status string) {
+	collector.sender.SendLog(cloudprotocol.PushLog{
+		MessageType: cloudprotocol.PushLogMessageType,
+		NodeID:      collector.nodeID,
+		LogID:       logID,
+		Status:      status,
+	})
+}
+
+func (collector *LogCollector) sendError(logID string, err error) {
+	collector.sender.SendLog(cloudprotocol.PushLog{
+		MessageType: cloudprotocol.PushLogMessageType,
+		NodeID:      collector.nodeID,
+		LogID:       logID,
+		Status:      cloudprotocol.LogStatusError,
+		ErrorInfo:   cloudprotocol.NewErrorInfo(err),
+	})
+}
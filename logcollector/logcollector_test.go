@@ -0,0 +1,491 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logcollector_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/logcollector"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const testNodeID = "node0"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testInstanceProvider struct {
+	instanceIDs map[string][]string
+}
+
+type testSender struct {
+	logChannel chan cloudprotocol.PushLog
+	partsCount uint64
+	size       int
+}
+
+type testJournal struct {
+	entries []*sdjournal.JournalEntry
+	matches map[string][]string
+	current int
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+var (
+	errTimeout = errors.New("timeout")
+	errAbsent  = errors.New("log absent")
+	errEmpty   = errors.New("log empty")
+	errStatus  = errors.New("log error")
+)
+
+/***********************************************************************************************************************
+ * Init
+ **********************************************************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestSystemLog(t *testing.T) {
+	setTestJournal([]*sdjournal.JournalEntry{
+		newEntry(1, "init.scope", "system message 1"),
+		newEntry(2, "aos-service@id1.service", "service message"),
+		newEntry(3, "init.scope", "system message 2"),
+	})
+
+	sender := newTestSender()
+
+	collector, err := logcollector.New(logcollector.Config{}, testNodeID, nil, sender)
+	if err != nil {
+		t.Fatalf("Can't create log collector: %s", err)
+	}
+	defer collector.Close()
+
+	if err = collector.GetLog(cloudprotocol.RequestLog{
+		LogID: "log0", LogType: cloudprotocol.SystemLog,
+	}); err != nil {
+		t.Fatalf("Can't get log: %s", err)
+	}
+
+	content, err := sender.waitLog("log0")
+	if err != nil {
+		t.Fatalf("Can't wait log: %s", err)
+	}
+
+	for _, message := range []string{"system message 1", "service message", "system message 2"} {
+		if !strings.Contains(content, message) {
+			t.Errorf("Log doesn't contain message: %s", message)
+		}
+	}
+}
+
+func TestServiceLog(t *testing.T) {
+	setTestJournal([]*sdjournal.JournalEntry{
+		newEntry(1, "aos-service@id1.service", "service 1 message"),
+		newEntry(2, "aos-service@id2.service", "service 2 message"),
+		newEntry(3, "init.scope", "system message"),
+		newEntry(4, "aos-service@id1.service", "service 1 message late"),
+	})
+
+	sender := newTestSender()
+	provider := &testInstanceProvider{instanceIDs: map[string][]string{"service1": {"id1"}}}
+
+	collector, err := logcollector.New(logcollector.Config{}, testNodeID, provider, sender)
+	if err != nil {
+		t.Fatalf("Can't create log collector: %s", err)
+	}
+	defer collector.Close()
+
+	till := time.Unix(3, 0)
+
+	if err = collector.GetLog(cloudprotocol.RequestLog{
+		LogID: "log0", LogType: cloudprotocol.ServiceLog,
+		Filter: cloudprotocol.LogFilter{
+			Till:           &till,
+			InstanceFilter: cloudprotocol.NewInstanceFilter("service1", "", -1),
+		},
+	}); err != nil {
+		t.Fatalf("Can't get log: %s", err)
+	}
+
+	content, err := sender.waitLog("log0")
+	if err != nil {
+		t.Fatalf("Can't wait log: %s", err)
+	}
+
+	if !strings.Contains(content, "service 1 message") {
+		t.Error("Log doesn't contain service message")
+	}
+
+	for _, message := range []string{"service 2 message", "system message", "service 1 message late"} {
+		if strings.Contains(content, message) {
+			t.Errorf("Log contains unexpected message: %s", message)
+		}
+	}
+
+	if err = collector.GetLog(cloudprotocol.RequestLog{
+		LogID: "log1", LogType: cloudprotocol.ServiceLog,
+		Filter: cloudprotocol.LogFilter{InstanceFilter: cloudprotocol.NewInstanceFilter("service2", "", -1)},
+	}); err != nil {
+		t.Fatalf("Can't get log: %s", err)
+	}
+
+	if _, err = sender.waitLog("log1"); !errors.Is(err, errAbsent) {
+		t.Errorf("Absent status expected: %v", err)
+	}
+}
+
+func TestLogStatuses(t *testing.T) {
+	setTestJournal([]*sdjournal.JournalEntry{newEntry(1, "init.scope", "system message")})
+
+	sender := newTestSender()
+
+	collector, err := logcollector.New(logcollector.Config{}, testNodeID, nil, sender)
+	if err != nil {
+		t.Fatalf("Can't create log collector: %s", err)
+	}
+	defer collector.Close()
+
+	from := time.Unix(10, 0)
+
+	if err = collector.GetLog(cloudprotocol.RequestLog{
+		LogID: "log0", LogType: cloudprotocol.SystemLog, Filter: cloudprotocol.LogFilter{From: &from},
+	}); err != nil {
+		t.Fatalf("Can't get log: %s", err)
+	}
+
+	if _, err = sender.waitLog("log0"); !errors.Is(err, errEmpty) {
+		t.Errorf("Empty status expected: %v", err)
+	}
+
+	if err = collector.GetLog(cloudprotocol.RequestLog{
+		LogID: "log1", LogType: "unknownLog",
+	}); err != nil {
+		t.Fatalf("Can't get log: %s", err)
+	}
+
+	if _, err = sender.waitLog("log1"); !errors.Is(err, errStatus) {
+		t.Errorf("Error status expected: %v", err)
+	}
+
+	if err = collector.GetLog(cloudprotocol.RequestLog{
+		LogID: "log2", LogType: cloudprotocol.SystemLog, Filter: cloudprotocol.LogFilter{NodeIDs: []string{"node1"}},
+	}); err != nil {
+		t.Fatalf("Can't get log: %s", err)
+	}
+
+	if _, err = sender.waitLog("log2"); !errors.Is(err, errTimeout) {
+		t.Errorf("No log expected for other node: %v", err)
+	}
+}
+
+func TestLogParts(t *testing.T) {
+	entries := make([]*sdjournal.JournalEntry, 0, 100)
+
+	for i := 1; i <= 100; i++ {
+		entries = append(entries,
+			newEntry(uint64(i), "init.scope", fmt.Sprintf("message %d %x", i, time.Now().UnixNano())))
+	}
+
+	setTestJournal(entries)
+
+	sender := newTestSender()
+
+	collector, err := logcollector.New(logcollector.Config{MaxPartSize: 256}, testNodeID, nil, sender)
+	if err != nil {
+		t.Fatalf("Can't create log collector: %s", err)
+	}
+	defer collector.Close()
+
+	if err = collector.GetLog(cloudprotocol.RequestLog{LogID: "log0", LogType: cloudprotocol.SystemLog}); err != nil {
+		t.Fatalf("Can't get log: %s", err)
+	}
+
+	content, err := sender.waitLog("log0")
+	if err != nil {
+		t.Fatalf("Can't wait log: %s", err)
+	}
+
+	if sender.partsCount < 2 {
+		t.Errorf("Log is not split into parts: %d", sender.partsCount)
+	}
+
+	if strings.Count(content, "\n") != len(entries) {
+		t.Errorf("Wrong log lines count: %d", strings.Count(content, "\n"))
+	}
+}
+
+func TestLogMaxSize(t *testing.T) {
+	const (
+		maxPartSize  = 512
+		maxPartCount = 3
+	)
+
+	entries := make([]*sdjournal.JournalEntry, 0, 1000)
+
+	for i := 1; i <= 1000; i++ {
+		random := make([]byte, 32)
+
+		if _, err := rand.Read(random); err != nil {
+			t.Fatalf("Can't generate message: %s", err)
+		}
+
+		entries = append(entries, newEntry(uint64(i), "init.scope", hex.EncodeToString(random)))
+	}
+
+	setTestJournal(entries)
+
+	sender := newTestSender()
+
+	collector, err := logcollector.New(logcollector.Config{MaxPartSize: maxPartSize, MaxPartCount: maxPartCount},
+		testNodeID, nil, sender)
+	if err != nil {
+		t.Fatalf("Can't create log collector: %s", err)
+	}
+	defer collector.Close()
+
+	if err = collector.GetLog(cloudprotocol.RequestLog{LogID: "log0", LogType: cloudprotocol.SystemLog}); err != nil {
+		t.Fatalf("Can't get log: %s", err)
+	}
+
+	content, err := sender.waitLog("log0")
+	if err != nil {
+		t.Fatalf("Can't wait log: %s", err)
+	}
+
+	if sender.partsCount > maxPartCount {
+		t.Errorf("Log parts count exceeds max count: %d", sender.partsCount)
+	}
+
+	if sender.size > maxPartSize*maxPartCount {
+		t.Errorf("Log size exceeds max size: %d", sender.size)
+	}
+
+	linesCount := strings.Count(content, "\n")
+
+	if linesCount == 0 || linesCount == len(entries) {
+		t.Errorf("Log is not truncated: %d", linesCount)
+	}
+}
+
+/***********************************************************************************************************************
+ * Interfaces
+ **********************************************************************************************************************/
+
+func (provider *testInstanceProvider) GetInstanceIDs(
+	filter cloudprotocol.InstanceFilter,
+) (instanceIDs []string, err error) {
+	if filter.ServiceID == nil {
+		return nil, aoserrors.New("service ID is not set")
+	}
+
+	return provider.instanceIDs[*filter.ServiceID], nil
+}
+
+func (sender *testSender) SendLog(serviceLog cloudprotocol.PushLog) {
+	sender.logChannel <- serviceLog
+}
+
+func (journal *testJournal) Close() error { return nil }
+
+func (journal *testJournal) AddMatch(match string) error {
+	fields := strings.SplitN(match, "=", 2)
+	if len(fields) != 2 {
+		return aoserrors.New("wrong match")
+	}
+
+	journal.matches[fields[0]] = append(journal.matches[fields[0]], fields[1])
+
+	return nil
+}
+
+func (journal *testJournal) AddDisjunction() error { return nil }
+
+func (journal *testJournal) SeekHead() error {
+	journal.current = 0
+
+	return nil
+}
+
+func (journal *testJournal) SeekRealtimeUsec(usec uint64) error {
+	journal.current = len(journal.entries)
+
+	for i, entry := range journal.entries {
+		if entry.RealtimeTimestamp >= usec {
+			journal.current = i
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func (journal *testJournal) Next() (uint64, error) {
+	for ; journal.current < len(journal.entries); journal.current++ {
+		if journal.isMatched(journal.entries[journal.current]) {
+			journal.current++
+
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func (journal *testJournal) GetEntry() (*sdjournal.JournalEntry, error) {
+	return journal.entries[journal.current-1], nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func setTestJournal(entries []*sdjournal.JournalEntry) {
+	logcollector.SDJournalOpener = func() (logcollector.JournalInterface, error) {
+		return &testJournal{entries: entries, matches: make(map[string][]string)}, nil
+	}
+}
+
+func newEntry(timestamp uint64, unit, message string) *sdjournal.JournalEntry {
+	return &sdjournal.JournalEntry{
+		Fields: map[string]string{
+			sdjournal.SD_JOURNAL_FIELD_SYSTEMD_UNIT: unit,
+			sdjournal.SD_JOURNAL_FIELD_MESSAGE:      message,
+		},
+		RealtimeTimestamp: timestamp * 1000000,
+	}
+}
+
+func newTestSender() *testSender {
+	return &testSender{logChannel: make(chan cloudprotocol.PushLog, 1)}
+}
+
+func (journal *testJournal) isMatched(entry *sdjournal.JournalEntry) bool {
+	for field, values := range journal.matches {
+		matched := false
+
+		for _, value := range values {
+			if entry.Fields[field] == value {
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (sender *testSender) waitLog(logID string) (content string, err error) {
+	var (
+		data       []byte
+		partsCount uint64
+		part       uint64
+	)
+
+	for {
+		select {
+		case pushLog := <-sender.logChannel:
+			if pushLog.LogID != logID || pushLog.NodeID != testNodeID {
+				return "", aoserrors.Errorf("unexpected log: %s, node: %s", pushLog.LogID, pushLog.NodeID)
+			}
+
+			switch pushLog.Status {
+			case cloudprotocol.LogStatusAbsent:
+				return "", errAbsent
+
+			case cloudprotocol.LogStatusEmpty:
+				return "", errEmpty
+
+			case cloudprotocol.LogStatusError:
+				return "", errStatus
+			}
+
+			part++
+
+			if pushLog.Part != part {
+				return "", aoserrors.Errorf("wrong log part: %d", pushLog.Part)
+			}
+
+			if partsCount == 0 {
+				partsCount = pushLog.PartsCount
+			}
+
+			data = append(data, pushLog.Content...)
+
+			if part < partsCount {
+				continue
+			}
+
+			zipReader, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return "", aoserrors.Wrap(err)
+			}
+
+			unzipped, err := io.ReadAll(zipReader)
+			if err != nil {
+				return "", aoserrors.Wrap(err)
+			}
+
+			sender.partsCount = partsCount
+			sender.size = len(data)
+
+			return string(unzipped), nil
+
+		case <-time.After(time.Second):
+			return "", errTimeout
+		}
+	}
+}
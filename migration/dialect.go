@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hash/crc32"
+	"io"
+	"sync/atomic"
+
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/lib/pq"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const defaultMigrationsTable = "schema_migrations"
+
+const pgUndefinedTable = "42P01"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Dialect database dialect used by migration engine.
+type Dialect interface {
+	Name() string
+	NewDriver(db *sql.DB) (database.Driver, error)
+}
+
+// SQLiteDialect SQLite database dialect.
+type SQLiteDialect struct {
+	MigrationsTable string
+}
+
+// PostgresDialect PostgreSQL database dialect. Each migration step runs in a transaction.
+type PostgresDialect struct {
+	MigrationsTable string
+}
+
+type postgresDriver struct {
+	conn     *sql.Conn
+	table    string
+	lockID   int64
+	isLocked atomic.Bool
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Name returns dialect name.
+func (dialect SQLiteDialect) Name() string {
+	return "sqlite3"
+}
+
+// NewDriver creates migration driver for SQLite database.
+func (dialect SQLiteDialect) NewDriver(db *sql.DB) (database.Driver, error) {
+	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{MigrationsTable: dialect.MigrationsTable})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return driver, nil
+}
+
+// Name returns dialect name.
+func (dialect PostgresDialect) Name() string {
+	return "postgres"
+}
+
+// NewDriver creates migration driver for PostgreSQL database.
+func (dialect PostgresDialect) NewDriver(db *sql.DB) (database.Driver, error) {
+	table := dialect.MigrationsTable
+	if table == "" {
+		table = defaultMigrationsTable
+	}
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	driver := &postgresDriver{
+		conn: conn, table: table, lockID: int64(crc32.ChecksumIEEE([]byte(table))),
+	}
+
+	if _, err = conn.ExecContext(context.Background(), "CREATE TABLE IF NOT EXISTS "+
+		pq.QuoteIdentifier(table)+" (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)"); err != nil {
+		conn.Close()
+
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return driver, nil
+}
+
+// Open is not supported: driver is created from existing database instance.
+func (driver *postgresDriver) Open(url string) (database.Driver, error) {
+	return nil, aoserrors.Wrap(aoserrors.ErrNotSupported)
+}
+
+// Close closes driver connection.
+func (driver *postgresDriver) Close() error {
+	return aoserrors.Wrap(driver.conn.Close())
+}
+
+// Lock acquires advisory migration lock.
+func (driver *postgresDriver) Lock() error {
+	if !driver.isLocked.CompareAndSwap(false, true) {
+		return aoserrors.Wrap(database.ErrLocked)
+	}
+
+	if _, err := driver.conn.ExecContext(
+		context.Background(), "SELECT pg_advisory_lock($1)", driver.lockID); err != nil {
+		driver.isLocked.Store(false)
+
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+// Unlock releases advisory migration lock.
+func (driver *postgresDriver) Unlock() error {
+	if !driver.isLocked.Load() {
+		return aoserrors.Wrap(database.ErrNotLocked)
+	}
+
+	if _, err := driver.conn.ExecContext(
+		context.Background(), "SELECT pg_advisory_unlock($1)", driver.lockID); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	driver.isLocked.Store(false)
+
+	return nil
+}
+
+// Run runs migration in a transaction.
+func (driver *postgresDriver) Run(migration io.Reader) error {
+	query, err := io.ReadAll(migration)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return driver.inTransaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(string(query))
+
+		return aoserrors.Wrap(err)
+	})
+}
+
+// SetVersion sets migration version.
+func (driver *postgresDriver) SetVersion(version int, dirty bool) error {
+	return driver.inTransaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("TRUNCATE " + pq.QuoteIdentifier(driver.table)); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if version >= 0 || (version == database.NilVersion && dirty) {
+			if _, err := tx.Exec("INSERT INTO "+pq.QuoteIdentifier(driver.table)+
+				" (version, dirty) VALUES ($1, $2)", version, dirty); err != nil {
+				return aoserrors.Wrap(err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Version returns current migration version.
+func (driver *postgresDriver) Version() (version int, dirty bool, err error) {
+	err = driver.conn.QueryRowContext(context.Background(),
+		"SELECT version, dirty FROM "+pq.QuoteIdentifier(driver.table)+" LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		var pqErr *pq.Error
+
+		if errors.Is(err, sql.ErrNoRows) || (errors.As(err, &pqErr) && pqErr.Code == pgUndefinedTable) {
+			return database.NilVersion, false, nil
+		}
+
+		return 0, false, aoserrors.Wrap(err)
+	}
+
+	return version, dirty, nil
+}
+
+// Drop drops all tables in current schema.
+func (driver *postgresDriver) Drop() error {
+	rows, err := driver.conn.QueryContext(context.Background(),
+		"SELECT table_name FROM information_schema.tables "+
+			"WHERE table_schema = (SELECT current_schema()) AND table_type = 'BASE TABLE'")
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	var tables []string
+
+	for rows.Next() {
+		var table string
+
+		if err = rows.Scan(&table); err != nil {
+			rows.Close()
+
+			return aoserrors.Wrap(err)
+		}
+
+		tables = append(tables, table)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	for _, table := range tables {
+		if _, err = driver.conn.ExecContext(
+			context.Background(), "DROP TABLE IF EXISTS "+pq.QuoteIdentifier(table)+" CASCADE"); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (driver *postgresDriver) inTransaction(handler func(tx *sql.Tx) error) error {
+	tx, err := driver.conn.BeginTx(context.Background(), nil)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = handler(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return aoserrors.Errorf("%v: rollback failed: %v", err, rollbackErr)
+		}
+
+		return err
+	}
+
+	return aoserrors.Wrap(tx.Commit())
+}
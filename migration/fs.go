@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"bytes"
+	"database/sql"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type mergedFS struct {
+	files map[string][]byte
+}
+
+type mergedFile struct {
+	*bytes.Reader
+	info mergedFileInfo
+}
+
+type mergedDir struct {
+	entries []fs.DirEntry
+	offset  int
+}
+
+type mergedFileInfo struct {
+	name  string
+	size  int64
+	isDir bool
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// MigrateFS migrates database up or down to the version using migrations from the file system root.
+// It can be used with embed.FS (use fs.Sub to select the migration directory).
+func MigrateFS(sql *sql.DB, migrations fs.FS, migrateVersion uint, options Options) (steps []Step, err error) {
+	sourceDriver, err := iofs.New(migrations, ".")
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer sourceDriver.Close()
+
+	return migrateWithSource(sql, sourceDriver, migrateVersion, options)
+}
+
+// ForceVersionFS sets the database version using migrations from the file system root.
+func ForceVersionFS(sql *sql.DB, migrations fs.FS, version uint, options Options) (err error) {
+	sourceDriver, err := iofs.New(migrations, ".")
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer sourceDriver.Close()
+
+	return forceVersionWithSource(sql, sourceDriver, version, options)
+}
+
+// MergeMigrations merges migrations from several file systems into one. Usually it is used to combine built-in
+// migrations with external ones. Migrations with the same version and direction are allowed only if their content is
+// identical, otherwise an error is returned. Files that are not migrations are ignored.
+func MergeMigrations(sources ...fs.FS) (merged fs.FS, err error) {
+	type migrationKey struct {
+		version   uint
+		direction source.Direction
+	}
+
+	result := &mergedFS{files: make(map[string][]byte)}
+	keys := make(map[migrationKey]string)
+
+	for _, fsys := range sources {
+		entries, err := fs.ReadDir(fsys, ".")
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			migration, err := source.Parse(entry.Name())
+			if err != nil {
+				continue
+			}
+
+			content, err := fs.ReadFile(fsys, entry.Name())
+			if err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+
+			key := migrationKey{version: migration.Version, direction: migration.Direction}
+
+			if existingName, ok := keys[key]; ok {
+				if !bytes.Equal(result.files[existingName], content) {
+					return nil, aoserrors.Errorf("migration conflict: %s and %s", existingName, entry.Name())
+				}
+
+				continue
+			}
+
+			keys[key] = entry.Name()
+			result.files[entry.Name()] = content
+		}
+	}
+
+	return result, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (mfs *mergedFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if name == "." {
+		entries, err := mfs.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &mergedDir{entries: entries}, nil
+	}
+
+	content, ok := mfs.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return &mergedFile{
+		Reader: bytes.NewReader(content),
+		info:   mergedFileInfo{name: name, size: int64(len(content))},
+	}, nil
+}
+
+func (mfs *mergedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name != "." {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(mfs.files))
+
+	for fileName, content := range mfs.files {
+		entries = append(entries, fs.FileInfoToDirEntry(mergedFileInfo{name: fileName, size: int64(len(content))}))
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
+
+func (file *mergedFile) Stat() (fs.FileInfo, error) {
+	return file.info, nil
+}
+
+func (file *mergedFile) Close() error {
+	return nil
+}
+
+func (dir *mergedDir) Stat() (fs.FileInfo, error) {
+	return mergedFileInfo{name: ".", isDir: true}, nil
+}
+
+func (dir *mergedDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: ".", Err: fs.ErrInvalid}
+}
+
+func (dir *mergedDir) Close() error {
+	return nil
+}
+
+func (dir *mergedDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	entries := dir.entries[dir.offset:]
+
+	if count > 0 && len(entries) > count {
+		entries = entries[:count]
+	}
+
+	dir.offset += len(entries)
+
+	return entries, nil
+}
+
+func (info mergedFileInfo) Name() string {
+	return path.Base(info.name)
+}
+
+func (info mergedFileInfo) Size() int64 {
+	return info.size
+}
+
+func (info mergedFileInfo) Mode() fs.FileMode {
+	if info.isDir {
+		return fs.ModeDir | 0o555
+	}
+
+	return 0o444
+}
+
+func (info mergedFileInfo) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (info mergedFileInfo) IsDir() bool {
+	return info.isDir
+}
+
+func (info mergedFileInfo) Sys() interface{} {
+	return nil
+}
@@ -26,7 +26,7 @@ import (
 	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file" // use blank import to init migrate
 	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
@@ -41,34 +41,143 @@ import (
 
 const folderPerm = 0o755
 
+// Migration directions.
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Options migration options.
+type Options struct {
+	// Dialect database dialect. SQLite is used if not set.
+	Dialect Dialect
+	// DryRun only returns migration steps without applying them.
+	DryRun bool
+}
+
+// Step migration step.
+type Step struct {
+	Version    uint
+	Direction  string
+	Identifier string
+}
+
 /***********************************************************************************************************************
  * Public
  **********************************************************************************************************************/
 
 // DoMigrate does migration on provided database.
 func DoMigrate(sql *sql.DB, migrationPath string, migrateVersion uint) (err error) {
-	log.Debugf("Db Migration start migration to %d", int(migrateVersion))
+	_, err = Migrate(sql, migrationPath, migrateVersion, Options{})
+
+	return err
+}
+
+// Migrate migrates database up or down to the version and returns performed migration steps. Each step is applied
+// in a transaction. In dry run mode, steps are only calculated.
+func Migrate(sql *sql.DB, migrationPath string, migrateVersion uint, options Options) (steps []Step, err error) {
+	sourceDriver, err := openFileSource(migrationPath)
+	if err != nil {
+		return nil, err
+	}
+	defer sourceDriver.Close()
+
+	return migrateWithSource(sql, sourceDriver, migrateVersion, options)
+}
+
+// SetDatabaseVersion sets the database version.
+func SetDatabaseVersion(sql *sql.DB, migrationPath string, version uint) (err error) {
+	return ForceVersion(sql, migrationPath, version, Options{})
+}
+
+// ForceVersion sets the database version without running migrations.
+func ForceVersion(sql *sql.DB, migrationPath string, version uint, options Options) (err error) {
+	sourceDriver, err := openFileSource(migrationPath)
+	if err != nil {
+		return err
+	}
+	defer sourceDriver.Close()
+
+	return forceVersionWithSource(sql, sourceDriver, version, options)
+}
+
+// MergeMigrationFiles merged the migration files with the previous state.
+func MergeMigrationFiles(migrationPath string, mergedMigrationPath string) (err error) {
+	absMigrationPath, err := filepath.Abs(migrationPath)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
 
-	m, err := getMigrationFromInstance(sql, migrationPath)
+	absMergedMigrationPath, err := filepath.Abs(mergedMigrationPath)
 	if err != nil {
 		return aoserrors.Wrap(err)
 	}
 
+	if _, err = os.Stat(absMigrationPath); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	// Create target directories if needed
+	if err = os.MkdirAll(absMergedMigrationPath, folderPerm); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = copyFiles(absMigrationPath, absMergedMigrationPath); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func openFileSource(migrationPath string) (source.Driver, error) {
+	absMigrationPath, err := filepath.Abs(migrationPath)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	sourceDriver, err := source.Open("file://" + absMigrationPath)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return sourceDriver, nil
+}
+
+func migrateWithSource(
+	sql *sql.DB, sourceDriver source.Driver, migrateVersion uint, options Options,
+) (steps []Step, err error) {
+	log.WithFields(log.Fields{"version": migrateVersion, "dryRun": options.DryRun}).Debug("Db migration start")
+
+	m, err := getMigrationFromInstance(sql, sourceDriver, options.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
 	version, dirty, err := m.Version()
 	if errors.Is(err, migrate.ErrNilVersion) {
 		version = 0
 
 		log.Debugf("Migration version was not set. Setting initial version %d", version)
 
-		if err = m.Force(int(version)); err != nil {
-			return aoserrors.Wrap(err)
+		if !options.DryRun {
+			if err = m.Force(int(version)); err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
 		}
 	} else if err != nil {
-		return aoserrors.Wrap(err)
+		return nil, aoserrors.Wrap(err)
 	}
 
 	if dirty {
-		return aoserrors.New("can't update, db is dirty")
+		return nil, aoserrors.New("can't update, db is dirty")
 	}
 
 	log.Debugf("Got database version: %d", version)
@@ -76,68 +185,55 @@ func DoMigrate(sql *sql.DB, migrationPath string, migrateVersion uint) (err erro
 	if version == migrateVersion {
 		log.Debugf("No migration needed. db version is: %d", int(migrateVersion))
 
-		return nil
+		return nil, nil
 	}
 
-	if err = m.Migrate(migrateVersion); errors.Is(err, migrate.ErrNoChange) {
-		log.Debugf("No migration needed. db version is: %d", int(migrateVersion))
-
-		return nil
+	if steps, err = getMigrationSteps(sourceDriver, version, migrateVersion); err != nil {
+		return nil, err
 	}
 
-	if err == nil {
-		log.Debugf("Migration successful, db version is: %d", int(migrateVersion))
+	if options.DryRun {
+		for _, step := range steps {
+			log.WithFields(log.Fields{
+				"version": step.Version, "direction": step.Direction, "identifier": step.Identifier,
+			}).Debug("Dry run migration step")
+		}
+
+		return steps, nil
 	}
 
-	return aoserrors.Wrap(err)
-}
+	if err = m.Migrate(migrateVersion); errors.Is(err, migrate.ErrNoChange) {
+		log.Debugf("No migration needed. db version is: %d", int(migrateVersion))
 
-// SetDatabaseVersion sets the database version.
-func SetDatabaseVersion(sql *sql.DB, migrationPath string, version uint) (err error) {
-	m, err := getMigrationFromInstance(sql, migrationPath)
-	if err != nil {
-		return aoserrors.Wrap(err)
+		return nil, nil
 	}
 
-	if err = m.Force(int(version)); err != nil {
-		return aoserrors.Wrap(err)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
 	}
 
-	return nil
-}
+	log.Debugf("Migration successful, db version is: %d", int(migrateVersion))
 
-// MergeMigrationFiles merged the migration files with the previous state.
-func MergeMigrationFiles(migrationPath string, mergedMigrationPath string) (err error) {
-	absMigrationPath, err := filepath.Abs(migrationPath)
-	if err != nil {
-		return aoserrors.Wrap(err)
-	}
+	return steps, nil
+}
 
-	absMergedMigrationPath, err := filepath.Abs(mergedMigrationPath)
+func forceVersionWithSource(sql *sql.DB, sourceDriver source.Driver, version uint, options Options) error {
+	m, err := getMigrationFromInstance(sql, sourceDriver, options.Dialect)
 	if err != nil {
-		return aoserrors.Wrap(err)
+		return err
 	}
 
-	if _, err = os.Stat(absMigrationPath); err != nil {
-		return aoserrors.Wrap(err)
-	}
-
-	// Create target directories if needed
-	if err = os.MkdirAll(absMergedMigrationPath, folderPerm); err != nil {
-		return aoserrors.Wrap(err)
+	if options.DryRun {
+		return nil
 	}
 
-	if err = copyFiles(absMigrationPath, absMergedMigrationPath); err != nil {
+	if err = m.Force(int(version)); err != nil {
 		return aoserrors.Wrap(err)
 	}
 
 	return nil
 }
 
-/***********************************************************************************************************************
- * Private
- **********************************************************************************************************************/
-
 func copyFiles(source, destination string) (err error) {
 	err = filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
 		relPath := strings.Replace(path, source, "", 1)
@@ -187,23 +283,74 @@ func copyFile(src string, dst string) (err error) {
 	return aoserrors.Wrap(err)
 }
 
-func getMigrationFromInstance(sql *sql.DB, mergedMigrationPath string) (migration *migrate.Migrate, err error) {
-	absMergedMigrationPath, err := filepath.Abs(mergedMigrationPath)
-	if err != nil {
-		return nil, aoserrors.Wrap(err)
+func getMigrationFromInstance(
+	sql *sql.DB, sourceDriver source.Driver, dialect Dialect,
+) (migration *migrate.Migrate, err error) {
+	if dialect == nil {
+		dialect = SQLiteDialect{}
 	}
 
-	driver, err := sqlite3.WithInstance(sql, &sqlite3.Config{})
+	driver, err := dialect.NewDriver(sql)
 	if err != nil {
-		return nil, aoserrors.Wrap(err)
+		return nil, err
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://"+absMergedMigrationPath,
-		"sqlite3", driver)
+	m, err := migrate.NewWithInstance("source", sourceDriver, dialect.Name(), driver)
 	if err != nil {
 		return nil, aoserrors.Wrap(err)
 	}
 
 	return m, nil
 }
+
+func getMigrationSteps(sourceDriver source.Driver, fromVersion, toVersion uint) (steps []Step, err error) {
+	for version := fromVersion; version < toVersion; {
+		if version, err = sourceDriver.Next(version); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		if version > toVersion {
+			return nil, aoserrors.Errorf("migration %d not found", toVersion)
+		}
+
+		identifier, err := readMigrationIdentifier(sourceDriver.ReadUp, version)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, Step{Version: version, Direction: DirectionUp, Identifier: identifier})
+	}
+
+	for version := fromVersion; version > toVersion; {
+		identifier, err := readMigrationIdentifier(sourceDriver.ReadDown, version)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, Step{Version: version, Direction: DirectionDown, Identifier: identifier})
+
+		if version, err = sourceDriver.Prev(version); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+	}
+
+	return steps, nil
+}
+
+func readMigrationIdentifier(
+	read func(version uint) (io.ReadCloser, string, error), version uint,
+) (identifier string, err error) {
+	reader, identifier, err := read(version)
+	if err != nil {
+		// Missing migration file is applied as no-op migration
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", aoserrors.Wrap(err)
+	}
+
+	reader.Close()
+
+	return identifier, nil
+}
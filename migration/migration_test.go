@@ -213,6 +213,163 @@ func TestSetDatabaseVersion(t *testing.T) {
 	}
 }
 
+func TestMigrateDryRunAndDown(t *testing.T) {
+	if err := os.MkdirAll(testFolder, folderPerm); err != nil {
+		t.Fatalf("Error creating directory: %s", err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(testFolder); err != nil {
+			t.Fatalf("Error cleaning up: %s", err)
+		}
+	}()
+
+	name := path.Join(testFolder, "test.db")
+	migrationPath := path.Join(testFolder, "migration")
+
+	if err := createTestDB(name, 0); err != nil {
+		t.Fatalf("Can't create test database: %s", err)
+	}
+
+	if err := generateMigrationFiles(3, migrationPath); err != nil {
+		t.Fatalf("Can't generate migration files: %s", err)
+	}
+
+	sqlite, err := getSQLConnection(name)
+	if err != nil {
+		t.Fatalf("Can't create database connection: %s", err)
+	}
+	defer sqlite.Close()
+
+	options := migration.Options{Dialect: migration.SQLiteDialect{}, DryRun: true}
+
+	steps, err := migration.Migrate(sqlite, migrationPath, 3, options)
+	if err != nil {
+		t.Fatalf("Can't do dry run migration: %s", err)
+	}
+
+	if len(steps) != 3 || steps[0].Version != 1 || steps[2].Version != 3 ||
+		steps[0].Direction != migration.DirectionUp || steps[0].Identifier != "update" {
+		t.Errorf("Wrong migration steps: %v", steps)
+	}
+
+	if version, err := getOperationVersion(sqlite); err != nil || version != 0 {
+		t.Errorf("Dry run should not change database: %d, err: %v", version, err)
+	}
+
+	options.DryRun = false
+
+	if _, err = migration.Migrate(sqlite, migrationPath, 3, options); err != nil {
+		t.Fatalf("Can't migrate database: %s", err)
+	}
+
+	options.DryRun = true
+
+	if steps, err = migration.Migrate(sqlite, migrationPath, 1, options); err != nil {
+		t.Fatalf("Can't do dry run migration: %s", err)
+	}
+
+	if len(steps) != 2 || steps[0].Version != 3 || steps[1].Version != 2 ||
+		steps[0].Direction != migration.DirectionDown {
+		t.Errorf("Wrong migration steps: %v", steps)
+	}
+
+	options.DryRun = false
+
+	if _, err = migration.Migrate(sqlite, migrationPath, 1, options); err != nil {
+		t.Fatalf("Can't migrate database: %s", err)
+	}
+
+	// Test down migration N sets operation version to N
+	if version, err := getOperationVersion(sqlite); err != nil || version != 2 {
+		t.Errorf("Wrong operation version: %d, err: %v", version, err)
+	}
+
+	if err = compareDBVersions(1, name); err != nil {
+		t.Errorf("Compare error: %s", err)
+	}
+}
+
+func TestMigrateFS(t *testing.T) {
+	if err := os.MkdirAll(testFolder, folderPerm); err != nil {
+		t.Fatalf("Error creating directory: %s", err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(testFolder); err != nil {
+			t.Fatalf("Error cleaning up: %s", err)
+		}
+	}()
+
+	name := path.Join(testFolder, "test.db")
+	builtinPath := path.Join(testFolder, "builtin")
+	externalPath := path.Join(testFolder, "external")
+	conflictPath := path.Join(testFolder, "conflict")
+
+	if err := createTestDB(name, 0); err != nil {
+		t.Fatalf("Can't create test database: %s", err)
+	}
+
+	if err := generateMigrationFiles(2, builtinPath); err != nil {
+		t.Fatalf("Can't generate migration files: %s", err)
+	}
+
+	if err := generateMigrationFiles(3, externalPath); err != nil {
+		t.Fatalf("Can't generate migration files: %s", err)
+	}
+
+	if err := os.MkdirAll(conflictPath, folderPerm); err != nil {
+		t.Fatalf("Error creating directory: %s", err)
+	}
+
+	if err := writeToFile(path.Join(conflictPath, "2_other.up.sql"), "UPDATE testing SET version = 20;"); err != nil {
+		t.Fatalf("Can't write migration file: %s", err)
+	}
+
+	if _, err := migration.MergeMigrations(
+		os.DirFS(builtinPath), os.DirFS(conflictPath)); err == nil {
+		t.Error("Migration conflict expected")
+	}
+
+	merged, err := migration.MergeMigrations(os.DirFS(builtinPath), os.DirFS(externalPath))
+	if err != nil {
+		t.Fatalf("Can't merge migrations: %s", err)
+	}
+
+	sqlite, err := getSQLConnection(name)
+	if err != nil {
+		t.Fatalf("Can't create database connection: %s", err)
+	}
+	defer sqlite.Close()
+
+	options := migration.Options{Dialect: migration.SQLiteDialect{}}
+
+	steps, err := migration.MigrateFS(sqlite, merged, 3, options)
+	if err != nil {
+		t.Fatalf("Can't migrate database: %s", err)
+	}
+
+	if len(steps) != 3 {
+		t.Errorf("Wrong migration steps: %v", steps)
+	}
+
+	if version, err := getOperationVersion(sqlite); err != nil || version != 3 {
+		t.Errorf("Wrong operation version: %d, err: %v", version, err)
+	}
+
+	if err = compareDBVersions(3, name); err != nil {
+		t.Errorf("Compare error: %s", err)
+	}
+
+	if err = migration.ForceVersionFS(sqlite, merged, 2, options); err != nil {
+		t.Fatalf("Can't force version: %s", err)
+	}
+
+	if err = compareDBVersions(2, name); err != nil {
+		t.Errorf("Compare error: %s", err)
+	}
+}
+
 func TestMergeMigrationFiles(t *testing.T) {
 	if err := os.MkdirAll(testFolder, folderPerm); err != nil {
 		t.Errorf("Error creating directory: %s", err)
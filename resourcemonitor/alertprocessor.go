@@ -53,6 +53,8 @@ type alertProcessor struct {
 	minThresholdTime time.Time
 	maxThresholdTime time.Time
 	alertCondition   bool
+	// inverted processor detects low values: source and thresholds are inverted to reuse max threshold detection.
+	inverted bool
 }
 
 // createAlertProcessorPercents creates alert processor based on percents configuration.
@@ -66,8 +68,8 @@ func createAlertProcessorPercents(name string, source *uint64, maxValue uint64,
 		source:       source,
 		callback:     callback,
 		minTimeout:   rule.MinTimeout.Duration,
-		minThreshold: uint64(math.Round(float64(maxValue) * rule.MinThreshold / 100.0)),
-		maxThreshold: uint64(math.Round(float64(maxValue) * rule.MaxThreshold / 100.0)),
+		minThreshold: uint64(math.Round(float64(maxValue) * float64(rule.MinThreshold) / 100.0)),
+		maxThreshold: uint64(math.Round(float64(maxValue) * float64(rule.MaxThreshold) / 100.0)),
 	}
 }
 
@@ -77,6 +79,22 @@ func createAlertProcessorPoints(name string, source *uint64,
 ) (alert *alertProcessor) {
 	log.WithFields(log.Fields{"rule": rule, "name": name}).Debugf("Create alert points processor")
 
+	return &alertProcessor{
+		name:         name,
+		source:       source,
+		callback:     callback,
+		minTimeout:   rule.MinTimeout.Duration,
+		minThreshold: uint64(rule.MinThreshold),
+		maxThreshold: uint64(rule.MaxThreshold),
+	}
+}
+
+// createAlertProcessorCount creates alert processor based on count configuration.
+func createAlertProcessorCount(name string, source *uint64,
+	callback alertCallback, rule aostypes.AlertRuleCount,
+) (alert *alertProcessor) {
+	log.WithFields(log.Fields{"rule": rule, "name": name}).Debugf("Create alert count processor")
+
 	return &alertProcessor{
 		name:         name,
 		source:       source,
@@ -87,10 +105,41 @@ func createAlertProcessorPoints(name string, source *uint64,
 	}
 }
 
+// createAlertProcessorCountLow creates alert processor which raises alert when value stays below min threshold and
+// falls it when value rises above max threshold.
+func createAlertProcessorCountLow(name string, source *uint64,
+	callback alertCallback, rule aostypes.AlertRuleCount,
+) (alert *alertProcessor) {
+	log.WithFields(log.Fields{"rule": rule, "name": name}).Debugf("Create alert low count processor")
+
+	return &alertProcessor{
+		name:   name,
+		source: source,
+		callback: func(time time.Time, value uint64, status string) {
+			callback(time, math.MaxUint64-value, status)
+		},
+		minTimeout:   rule.MinTimeout.Duration,
+		minThreshold: math.MaxUint64 - rule.MaxThreshold,
+		maxThreshold: math.MaxUint64 - rule.MinThreshold,
+		inverted:     true,
+	}
+}
+
+// restoreState restores alert detection state from previous processor.
+func (alert *alertProcessor) restoreState(prevAlert *alertProcessor) {
+	alert.minThresholdTime = prevAlert.minThresholdTime
+	alert.maxThresholdTime = prevAlert.maxThresholdTime
+	alert.alertCondition = prevAlert.alertCondition
+}
+
 // checkAlertDetection checks if alert was detected.
 func (alert *alertProcessor) checkAlertDetection(currentTime time.Time) {
 	value := *alert.source
 
+	if alert.inverted {
+		value = math.MaxUint64 - value
+	}
+
 	if !alert.alertCondition {
 		alert.handleMaxThreshold(currentTime, value)
 	} else {
@@ -36,16 +36,30 @@ import (
  **********************************************************************************************************************/
 
 const (
-	cgroupsPath  = "/sys/fs/cgroup/system.slice/system-aos\\x2dservice.slice"
-	cpuUsageFile = "cpu.stat"
-	memUsageFile = "memory.current"
+	cgroupsPath   = "/sys/fs/cgroup/system.slice/system-aos\\x2dservice.slice"
+	cpuUsageFile  = "cpu.stat"
+	memUsageFile  = "memory.current"
+	cpuMaxFile    = "cpu.max"
+	cfsQuotaFile  = "cpu.cfs_quota_us"
+	cfsPeriodFile = "cpu.cfs_period_us"
+	procsFile     = "cgroup.procs"
+	pidsFile      = "pids.current"
+)
+
+const (
+	procPath        = "/proc"
+	smapsRollupFile = "smaps_rollup"
+	fdDir           = "fd"
+	smapsUnitSize   = 1024
 )
 
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
 
-type cgroupsSystemUsage struct{}
+type cgroupsSystemUsage struct {
+	ramAccounting string
+}
 
 /***********************************************************************************************************************
  * Public
@@ -81,16 +95,197 @@ func (usageInstance *cgroupsSystemUsage) FillSystemInfo(instanceID string, insta
 	return nil
 }
 
+// GetCPULimit returns instance CPU quota in cores from cgroup v2 cpu.max or cgroup v1 CFS quota files.
+func (usageInstance *cgroupsSystemUsage) GetCPULimit(instanceID string) (cores float64, err error) {
+	data, err := os.ReadFile(filepath.Join(cgroupsPath, instanceID, cpuMaxFile))
+	if err == nil {
+		return parseCPUMax(string(data))
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	quotaData, err := os.ReadFile(filepath.Join(cgroupsPath, instanceID, cfsQuotaFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, aoserrors.Wrap(err)
+	}
+
+	periodData, err := os.ReadFile(filepath.Join(cgroupsPath, instanceID, cfsPeriodFile))
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return parseCPUQuota(strings.TrimSpace(string(quotaData)), strings.TrimSpace(string(periodData)))
+}
+
+// GetProcessCounts returns instance open file descriptors count and tasks count from pids.current. If pids
+// controller is not enabled, tasks count is number of instance processes.
+func (usageInstance *cgroupsSystemUsage) GetProcessCounts(instanceID string) (fileDescriptors, pids uint64, err error) {
+	processes, err := getInstanceProcesses(instanceID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, pid := range processes {
+		entries, err := os.ReadDir(filepath.Join(procPath, pid, fdDir))
+		if err != nil {
+			// Process may exit after cgroup processes are read
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return 0, 0, aoserrors.Wrap(err)
+		}
+
+		fileDescriptors += uint64(len(entries))
+	}
+
+	if pids, err = getLineFromFile(filepath.Join(cgroupsPath, instanceID, pidsFile), 0); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return 0, 0, err
+		}
+
+		pids = uint64(len(processes))
+	}
+
+	return fileDescriptors, pids, nil
+}
+
 /***********************************************************************************************************************
  * Private
  **********************************************************************************************************************/
 
+func getInstanceProcesses(instanceID string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(cgroupsPath, instanceID, procsFile))
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return strings.Fields(string(data)), nil
+}
+
+// parseCPUMax parses cgroup v2 cpu.max content: "$MAX $PERIOD" where $MAX is "max" for unlimited quota.
+func parseCPUMax(content string) (cores float64, err error) {
+	fields := strings.Fields(content)
+	if len(fields) != 2 { //nolint:mnd
+		return 0, aoserrors.Errorf("invalid cpu.max content: %s", content)
+	}
+
+	return parseCPUQuota(fields[0], fields[1])
+}
+
+func parseCPUQuota(quotaStr, periodStr string) (cores float64, err error) {
+	if quotaStr == "max" || quotaStr == "-1" {
+		return 0, nil
+	}
+
+	quota, err := strconv.ParseUint(quotaStr, 10, 64)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	period, err := strconv.ParseUint(periodStr, 10, 64)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	if period == 0 {
+		return 0, aoserrors.New("invalid CPU quota period")
+	}
+
+	return float64(quota) / float64(period), nil
+}
+
 func (usageInstance *cgroupsSystemUsage) getCPUUsage(instanceID string) (uint64, error) {
 	return getFieldFromFile(filepath.Join(cgroupsPath, instanceID, cpuUsageFile), "usage_usec")
 }
 
 func (usageInstance *cgroupsSystemUsage) getRAMUsage(instanceID string) (uint64, error) {
-	return getLineFromFile(filepath.Join(cgroupsPath, instanceID, memUsageFile), 0)
+	switch usageInstance.ramAccounting {
+	case RAMAccountingPSS, RAMAccountingUSS:
+		return usageInstance.getProcessesRAMUsage(instanceID)
+
+	default:
+		return getLineFromFile(filepath.Join(cgroupsPath, instanceID, memUsageFile), 0)
+	}
+}
+
+func (usageInstance *cgroupsSystemUsage) getProcessesRAMUsage(instanceID string) (ram uint64, err error) {
+	processes, err := getInstanceProcesses(instanceID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, pid := range processes {
+		usage, err := getProcessRAMUsage(filepath.Join(procPath, pid, smapsRollupFile), usageInstance.ramAccounting)
+		if err != nil {
+			// Process may exit after cgroup processes are read
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return 0, err
+		}
+
+		ram += usage
+	}
+
+	return ram, nil
+}
+
+func getProcessRAMUsage(fileName, ramAccounting string) (uint64, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	return parseSmapsRollup(file, ramAccounting)
+}
+
+// parseSmapsRollup returns process PSS or USS (private clean and dirty pages) in bytes from smaps_rollup content.
+func parseSmapsRollup(reader io.Reader, ramAccounting string) (usage uint64, err error) {
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 { //nolint:mnd
+			continue
+		}
+
+		switch fields[0] {
+		case "Pss:":
+			if ramAccounting != RAMAccountingPSS {
+				continue
+			}
+
+		case "Private_Clean:", "Private_Dirty:":
+			if ramAccounting != RAMAccountingUSS {
+				continue
+			}
+
+		default:
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+
+		usage += value * smapsUnitSize
+	}
+
+	if err = scanner.Err(); err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return usage, nil
 }
 
 func getFieldFromFile(fileName, field string) (uint64, error) {
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcemonitor
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const defaultCollectorFailureThreshold = 3
+
+// Monitoring collector parameters.
+const (
+	collectorCPU     = "cpu"
+	collectorRAM     = "ram"
+	collectorUsage   = "usage"
+	collectorTraffic = "traffic"
+
+	collectorFileDescriptors = "fileDescriptors"
+	collectorPIDs            = "pids"
+	collectorEntropy         = "entropy"
+	collectorProcesses       = "processes"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type collectorFailure struct {
+	count    int
+	degraded bool
+}
+
+type collectorHealth struct {
+	threshold int
+	failures  map[string]*collectorFailure
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newCollectorHealth(threshold int) *collectorHealth {
+	if threshold <= 0 {
+		threshold = defaultCollectorFailureThreshold
+	}
+
+	return &collectorHealth{threshold: threshold, failures: make(map[string]*collectorFailure)}
+}
+
+// update updates collector failures and returns monitoring alert status if collector becomes degraded or recovers.
+func (health *collectorHealth) update(key string, err error) (status string) {
+	failure, ok := health.failures[key]
+
+	if err == nil {
+		if !ok {
+			return ""
+		}
+
+		delete(health.failures, key)
+
+		if failure.degraded {
+			return cloudprotocol.MonitoringStatusRecovered
+		}
+
+		return ""
+	}
+
+	if !ok {
+		failure = &collectorFailure{}
+		health.failures[key] = failure
+	}
+
+	failure.count++
+
+	if !failure.degraded && failure.count >= health.threshold {
+		failure.degraded = true
+
+		return cloudprotocol.MonitoringStatusDegraded
+	}
+
+	return ""
+}
+
+func (health *collectorHealth) removeInstance(instanceID string) {
+	for key := range health.failures {
+		if strings.HasPrefix(key, instanceID+"/") {
+			delete(health.failures, key)
+		}
+	}
+}
+
+func (monitor *ResourceMonitor) checkSystemCollector(parameter string, err error) {
+	monitor.checkCollector(parameter, nil, parameter, err)
+}
+
+func (monitor *ResourceMonitor) checkInstanceCollector(
+	instanceID string, instanceIdent aostypes.InstanceIdent, parameter string, err error,
+) {
+	monitor.checkCollector(instanceID+"/"+parameter, &instanceIdent, parameter, err)
+}
+
+func (monitor *ResourceMonitor) checkCollector(
+	key string, instanceIdent *aostypes.InstanceIdent, parameter string, err error,
+) {
+	status := monitor.collectorHealth.update(key, err)
+	if status == "" {
+		return
+	}
+
+	log.WithFields(log.Fields{"parameter": parameter, "status": status}).Warn("Monitoring collector status changed")
+
+	if monitor.alertSender == nil {
+		return
+	}
+
+	monitor.sendAlert(cloudprotocol.MonitoringAlert{
+		AlertItem:     cloudprotocol.AlertItem{Timestamp: monitor.clock.Now(), Tag: cloudprotocol.AlertTagMonitoring},
+		InstanceIdent: instanceIdent,
+		NodeID:        monitor.nodeInfo.NodeID,
+		Parameter:     parameter,
+		Status:        status,
+		ErrorInfo:     cloudprotocol.NewErrorInfo(err),
+	})
+}
@@ -22,14 +22,20 @@ import (
 	"container/list"
 	"context"
 	"math"
+	"os"
 	"runtime"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aosedge/aos_common/aoserrors"
 	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/utils/alertutils"
+	"github.com/aosedge/aos_common/utils/clock"
 	"github.com/aosedge/aos_common/utils/fs"
 	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/disk"
@@ -50,8 +56,21 @@ const (
 	YearPeriod
 )
 
+// RAM accounting modes.
+const (
+	RAMAccountingCgroups = "cgroups"
+	RAMAccountingPSS     = "pss"
+	RAMAccountingUSS     = "uss"
+)
+
 const monitoringChannelSize = 16
 
+const (
+	fileNrPath       = "/proc/sys/fs/file-nr"
+	loadAvgPath      = "/proc/loadavg"
+	entropyAvailPath = "/proc/sys/kernel/random/entropy_avail"
+)
+
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
@@ -61,6 +80,18 @@ type SystemUsageProvider interface {
 	FillSystemInfo(instanceID string, instance *instanceMonitoring) error
 }
 
+// CPULimitProvider provides instance CPU quota. It may be optionally implemented by system usage provider.
+type CPULimitProvider interface {
+	// GetCPULimit returns instance CPU limit in number of cores. Zero means instance CPU is not limited.
+	GetCPULimit(instanceID string) (cores float64, err error)
+}
+
+// ProcessCountProvider provides instance open file descriptors and tasks counts. It may be optionally implemented by
+// system usage provider.
+type ProcessCountProvider interface {
+	GetProcessCounts(instanceID string) (fileDescriptors, pids uint64, err error)
+}
+
 // QuotaAlert quota alert structure.
 type QuotaAlert struct {
 	Timestamp time.Time
@@ -79,6 +110,11 @@ type NodeInfoProvider interface {
 	GetCurrentNodeInfo() (cloudprotocol.NodeInfo, error)
 }
 
+// NodeInfoChangeProvider optional node info provider interface to get node info changes.
+type NodeInfoChangeProvider interface {
+	NodeInfoChangedChannel() <-chan cloudprotocol.NodeInfo
+}
+
 // NodeConfigProvider interface to get node config.
 type NodeConfigProvider interface {
 	GetCurrentNodeConfig() (cloudprotocol.NodeConfig, error)
@@ -96,6 +132,27 @@ type Config struct {
 	PollPeriod    aostypes.Duration `json:"pollPeriod"`
 	AverageWindow aostypes.Duration `json:"averageWindow"`
 	Source        string            `json:"source"`
+	// RAMAccounting instance RAM accounting mode of cgroups source: cgroups (default), pss or uss. PSS and USS are
+	// calculated from smaps_rollup of instance processes, so shared pages are not counted fully per instance.
+	RAMAccounting string `json:"ramAccounting"`
+	// TrafficAccounting enables built-in traffic accounting if traffic monitoring is not provided.
+	TrafficAccounting *TrafficAccountingConfig `json:"trafficAccounting,omitempty"`
+	// SendPeriod period of sending monitoring data. If not set, data is sent on each poll.
+	SendPeriod aostypes.Duration `json:"sendPeriod"`
+	// SendOnChange sends monitoring data before send period expires on significant changes.
+	SendOnChange SendOnChangeConfig `json:"sendOnChange"`
+	// CollectorFailureThreshold number of consecutive collector failures to send monitoring degraded alert.
+	CollectorFailureThreshold int `json:"collectorFailureThreshold"`
+	// Clock time source used for polling, averaging and alerts detection. If nil, system clock is used.
+	Clock clock.Clock `json:"-"`
+}
+
+// SendOnChangeConfig conditions to send monitoring data immediately.
+type SendOnChangeConfig struct {
+	// AlertStatus sends data when any resource alert is raised or falls.
+	AlertStatus bool `json:"alertStatus"`
+	// ValueChangePercent sends data when any monitoring value changes more than specified percent since last send.
+	ValueChangePercent float64 `json:"valueChangePercent"`
 }
 
 // ResourceMonitor instance.
@@ -105,18 +162,28 @@ type ResourceMonitor struct {
 	nodeInfoProvider   NodeInfoProvider
 	nodeConfigProvider NodeConfigProvider
 	alertSender        AlertSender
+	alertPolicy        atomic.Pointer[alertutils.AlertPolicy]
 	trafficMonitoring  TrafficMonitoring
+	trafficAccounting  *TrafficAccounting
 	sourceSystemUsage  SystemUsageProvider
 
 	monitoringChannel     chan aostypes.NodeMonitoring
-	pollTimer             *time.Ticker
+	clock                 clock.Clock
+	pollTimer             clock.Ticker
+	sendPeriod            time.Duration
+	sendOnChange          SendOnChangeConfig
+	collectorHealth       *collectorHealth
+	lastSendTime          time.Time
+	lastSentValues        map[string][]uint64
 	averageWindowCount    uint64
 	nodeInfo              cloudprotocol.NodeInfo
+	nodeConfig            cloudprotocol.NodeConfig
 	nodeMonitoring        aostypes.MonitoringData
 	nodeAverageData       averageMonitoring
 	instanceMonitoringMap map[string]*instanceMonitoring
 	alertProcessors       *list.List
 	curNodeConfigListener <-chan cloudprotocol.NodeConfig
+	nodeInfoListener      <-chan cloudprotocol.NodeInfo
 
 	cancelFunction context.CancelFunc
 }
@@ -134,12 +201,18 @@ type ResourceMonitorParams struct {
 	GID        int
 	AlertRules *aostypes.AlertRules
 	Partitions []PartitionParam
+	// CPULimit instance CPU limit in DMIPs. If zero, limit is taken from system usage provider CPU quota.
+	CPULimit uint64
+	// NetClassID instance net_cls cgroup class ID used for traffic accounting. If zero, traffic is accounted by UID.
+	NetClassID uint32
 }
 
 type instanceMonitoring struct {
 	uid                    uint32
 	gid                    uint32
 	partitions             []PartitionParam
+	cpuLimit               uint64
+	alertRules             *aostypes.AlertRules
 	monitoring             aostypes.InstanceMonitoring
 	averageData            averageMonitoring
 	alertProcessorElements []*list.Element
@@ -148,11 +221,14 @@ type instanceMonitoring struct {
 }
 
 type averageMonitoring struct {
-	ram      *averageCalc
-	cpu      *averageCalc
-	download *averageCalc
-	upload   *averageCalc
-	disks    map[string]*averageCalc
+	ram             *averageCalc
+	cpu             *averageCalc
+	download        *averageCalc
+	upload          *averageCalc
+	fileDescriptors *averageCalc
+	pids            *averageCalc
+	entropy         *averageCalc
+	disks           map[string]*averageCalc
 }
 
 /***********************************************************************************************************************
@@ -169,6 +245,10 @@ var (
 	getUserFSQuotaUsage                     = fs.GetUserFSQuotaUsage
 	cpuCount                                = runtime.NumCPU()
 	instanceUsage       SystemUsageProvider = nil
+
+	systemFileDescriptors = getSystemFileDescriptors
+	systemPIDs            = getSystemPIDs
+	systemEntropy         = getSystemEntropy
 )
 
 /***********************************************************************************************************************
@@ -188,16 +268,43 @@ func New(
 		nodeConfigProvider:    nodeConfigProvider,
 		alertSender:           alertsSender,
 		trafficMonitoring:     trafficMonitoring,
-		sourceSystemUsage:     getSourceSystemUsage(config.Source),
+		sourceSystemUsage:     getSourceSystemUsage(config.Source, config.RAMAccounting),
 		monitoringChannel:     make(chan aostypes.NodeMonitoring, monitoringChannelSize),
+		sendPeriod:            config.SendPeriod.Duration,
+		sendOnChange:          config.SendOnChange,
+		collectorHealth:       newCollectorHealth(config.CollectorFailureThreshold),
+		clock:                 config.Clock,
 		curNodeConfigListener: nodeConfigProvider.SubscribeCurrentNodeConfigChange(),
 	}
 
+	if monitor.clock == nil {
+		monitor.clock = clock.New()
+	}
+
+	switch config.RAMAccounting {
+	case "", RAMAccountingCgroups, RAMAccountingPSS, RAMAccountingUSS:
+
+	default:
+		return nil, aoserrors.Errorf("unsupported RAM accounting mode: %s", config.RAMAccounting)
+	}
+
 	nodeInfo, err := nodeInfoProvider.GetCurrentNodeInfo()
 	if err != nil {
 		return nil, aoserrors.Wrap(err)
 	}
 
+	if changeProvider, ok := nodeInfoProvider.(NodeInfoChangeProvider); ok {
+		monitor.nodeInfoListener = changeProvider.NodeInfoChangedChannel()
+	}
+
+	if trafficMonitoring == nil && config.TrafficAccounting != nil {
+		if monitor.trafficAccounting, err = NewTrafficAccounting(*config.TrafficAccounting); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		monitor.trafficMonitoring = monitor.trafficAccounting
+	}
+
 	monitor.averageWindowCount = uint64(config.AverageWindow.Duration.Nanoseconds()) /
 		uint64(config.PollPeriod.Duration.Nanoseconds())
 	if monitor.averageWindowCount == 0 {
@@ -222,7 +329,7 @@ func New(
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	monitor.cancelFunction = cancelFunc
 
-	monitor.pollTimer = time.NewTicker(config.PollPeriod.Duration)
+	monitor.pollTimer = monitor.clock.NewTicker(config.PollPeriod.Duration)
 
 	go monitor.run(ctx)
 
@@ -241,28 +348,42 @@ func (monitor *ResourceMonitor) Close() {
 		monitor.cancelFunction()
 	}
 
+	if monitor.trafficAccounting != nil {
+		if err := monitor.trafficAccounting.Close(); err != nil {
+			log.Errorf("Can't close traffic accounting: %v", err)
+		}
+	}
+
 	close(monitor.monitoringChannel)
 }
 
-// StartInstanceMonitor starts monitoring service.
+// StartInstanceMonitor starts monitoring service. If instanceID is empty, instance ident path name is used as ID.
 func (monitor *ResourceMonitor) StartInstanceMonitor(
 	instanceID string, monitoringConfig ResourceMonitorParams,
 ) error {
 	monitor.Lock()
 	defer monitor.Unlock()
 
+	if instanceID == "" {
+		instanceID = monitoringConfig.InstanceIdent.PathName()
+	}
+
 	if _, ok := monitor.instanceMonitoringMap[instanceID]; ok {
 		log.WithField("id", instanceID).Warning("Service already under monitoring")
 
 		return nil
 	}
 
-	log.WithFields(log.Fields{"id": instanceID}).Debug("Start instance monitoring")
+	log.WithFields(log.Fields{
+		"id": instanceID, "ident": monitoringConfig.InstanceIdent.Key(),
+	}).Debug("Start instance monitoring")
 
 	instanceMonitoring := &instanceMonitoring{
 		uid:        uint32(monitoringConfig.UID),
 		gid:        uint32(monitoringConfig.GID),
 		partitions: monitoringConfig.Partitions,
+		cpuLimit:   monitoringConfig.CPULimit,
+		alertRules: monitoringConfig.AlertRules,
 		monitoring: aostypes.InstanceMonitoring{InstanceIdent: monitoringConfig.InstanceIdent},
 	}
 
@@ -278,6 +399,13 @@ func (monitor *ResourceMonitor) StartInstanceMonitor(
 	instanceMonitoring.averageData = *newAverageMonitoring(
 		monitor.averageWindowCount, instanceMonitoring.monitoring.Partitions)
 
+	if accounting, ok := monitor.trafficMonitoring.(InstanceTrafficAccounting); ok {
+		if err := accounting.StartInstanceAccounting(
+			instanceID, monitoringConfig.UID, monitoringConfig.NetClassID); err != nil {
+			log.Errorf("Can't start instance traffic accounting: %v", err)
+		}
+	}
+
 	if monitoringConfig.AlertRules != nil && monitor.alertSender != nil {
 		if err := monitor.setupInstanceAlerts(
 			instanceID, instanceMonitoring, *monitoringConfig.AlertRules); err != nil {
@@ -304,6 +432,13 @@ func (monitor *ResourceMonitor) StopInstanceMonitor(instanceID string) error {
 	}
 
 	delete(monitor.instanceMonitoringMap, instanceID)
+	monitor.collectorHealth.removeInstance(instanceID)
+
+	if accounting, ok := monitor.trafficMonitoring.(InstanceTrafficAccounting); ok {
+		if err := accounting.StopInstanceAccounting(instanceID); err != nil {
+			log.Errorf("Can't stop instance traffic accounting: %v", err)
+		}
+	}
 
 	return nil
 }
@@ -315,7 +450,7 @@ func (monitor *ResourceMonitor) GetAverageMonitoring() (aostypes.NodeMonitoring,
 
 	log.Debug("Get average monitoring data")
 
-	timestamp := time.Now()
+	timestamp := monitor.clock.Now()
 
 	averageMonitoringData := aostypes.NodeMonitoring{
 		NodeID:        monitor.nodeInfo.NodeID,
@@ -324,11 +459,15 @@ func (monitor *ResourceMonitor) GetAverageMonitoring() (aostypes.NodeMonitoring,
 	}
 
 	for _, instanceMonitoring := range monitor.instanceMonitoringMap {
-		averageMonitoringData.InstancesData = append(averageMonitoringData.InstancesData,
-			aostypes.InstanceMonitoring{
-				InstanceIdent:  instanceMonitoring.monitoring.InstanceIdent,
-				MonitoringData: instanceMonitoring.averageData.toMonitoringData(timestamp),
-			})
+		averageData := aostypes.InstanceMonitoring{
+			InstanceIdent:  instanceMonitoring.monitoring.InstanceIdent,
+			MonitoringData: instanceMonitoring.averageData.toMonitoringData(timestamp),
+			CPULimit:       instanceMonitoring.monitoring.CPULimit,
+		}
+
+		averageData.CPULimitPercent = monitor.cpuLimitPercent(averageData.CPU, averageData.CPULimit)
+
+		averageMonitoringData.InstancesData = append(averageMonitoringData.InstancesData, averageData)
 	}
 
 	return averageMonitoringData, nil
@@ -339,6 +478,11 @@ func (monitor *ResourceMonitor) GetNodeMonitoringChannel() <-chan aostypes.NodeM
 	return monitor.monitoringChannel
 }
 
+// SetAlertPolicy sets shared alert verbosity policy. Alerts not allowed by the policy are dropped.
+func (monitor *ResourceMonitor) SetAlertPolicy(policy *alertutils.AlertPolicy) {
+	monitor.alertPolicy.Store(policy)
+}
+
 /***********************************************************************************************************************
  * Private
  **********************************************************************************************************************/
@@ -370,10 +514,101 @@ func (monitor *ResourceMonitor) setupSystemAlerts(nodeConfig cloudprotocol.NodeC
 	monitor.Lock()
 	defer monitor.Unlock()
 
-	nodeID := monitor.nodeInfo.NodeID
+	monitor.nodeConfig = nodeConfig
+	monitor.alertProcessors = list.New()
+
+	return monitor.createSystemAlerts()
+}
+
+func (monitor *ResourceMonitor) updateNodeInfo(nodeInfo cloudprotocol.NodeInfo) error {
+	monitor.Lock()
+	defer monitor.Unlock()
+
+	if nodeInfo.MaxDMIPs == 0 {
+		return aoserrors.Errorf("max DMIPs is 0")
+	}
+
+	log.WithFields(log.Fields{
+		"maxDMIPs": nodeInfo.MaxDMIPs, "totalRAM": nodeInfo.TotalRAM,
+	}).Debug("Update node info")
+
+	monitor.nodeInfo = nodeInfo
+
+	monitor.updateNodePartitions()
+
+	return monitor.rebuildAlertProcessors()
+}
+
+func (monitor *ResourceMonitor) updateNodePartitions() {
+	partitions := make([]aostypes.PartitionUsage, len(monitor.nodeInfo.Partitions))
+	disks := make(map[string]*averageCalc)
+
+	for i, partitionInfo := range monitor.nodeInfo.Partitions {
+		partitions[i].Name = partitionInfo.Name
+
+		if index := slices.IndexFunc(monitor.nodeMonitoring.Partitions, func(partition aostypes.PartitionUsage) bool {
+			return partition.Name == partitionInfo.Name
+		}); index != -1 {
+			partitions[i].UsedSize = monitor.nodeMonitoring.Partitions[index].UsedSize
+		}
+
+		if averageCalc, ok := monitor.nodeAverageData.disks[partitionInfo.Name]; ok {
+			disks[partitionInfo.Name] = averageCalc
+		} else {
+			disks[partitionInfo.Name] = newAverageCalc(monitor.averageWindowCount)
+		}
+	}
+
+	monitor.nodeMonitoring.Partitions = partitions
+	monitor.nodeAverageData.disks = disks
+}
+
+// rebuildAlertProcessors recreates system and instance alert processors with current node info.
+// Alert state is preserved for processors which remain after rebuild.
+func (monitor *ResourceMonitor) rebuildAlertProcessors() (err error) {
+	prevProcessors := make(map[string]*alertProcessor)
+
+	for e := monitor.alertProcessors.Front(); e != nil; e = e.Next() {
+		if processor, ok := e.Value.(*alertProcessor); ok {
+			prevProcessors[processor.name] = processor
+		}
+	}
 
 	monitor.alertProcessors = list.New()
 
+	err = monitor.createSystemAlerts()
+
+	for instanceID, instanceMonitoring := range monitor.instanceMonitoringMap {
+		instanceMonitoring.alertProcessorElements = nil
+
+		if instanceMonitoring.alertRules == nil || monitor.alertSender == nil {
+			continue
+		}
+
+		if instanceErr := monitor.setupInstanceAlerts(
+			instanceID, instanceMonitoring, *instanceMonitoring.alertRules); instanceErr != nil && err == nil {
+			err = instanceErr
+		}
+	}
+
+	for e := monitor.alertProcessors.Front(); e != nil; e = e.Next() {
+		processor, ok := e.Value.(*alertProcessor)
+		if !ok {
+			continue
+		}
+
+		if prevProcessor, ok := prevProcessors[processor.name]; ok {
+			processor.restoreState(prevProcessor)
+		}
+	}
+
+	return err
+}
+
+func (monitor *ResourceMonitor) createSystemAlerts() (err error) {
+	nodeID := monitor.nodeInfo.NodeID
+	nodeConfig := monitor.nodeConfig
+
 	if nodeConfig.AlertRules == nil || monitor.alertSender == nil {
 		return nil
 	}
@@ -384,7 +619,7 @@ func (monitor *ResourceMonitor) setupSystemAlerts(nodeConfig cloudprotocol.NodeC
 			&monitor.nodeMonitoring.CPU,
 			monitor.nodeInfo.MaxDMIPs,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(prepareSystemAlertItem(nodeID, "cpu", time, value, status))
+				monitor.sendAlert(prepareSystemAlertItem(nodeID, "cpu", time, value, status))
 			},
 			*nodeConfig.AlertRules.CPU))
 	}
@@ -395,7 +630,7 @@ func (monitor *ResourceMonitor) setupSystemAlerts(nodeConfig cloudprotocol.NodeC
 			&monitor.nodeMonitoring.RAM,
 			monitor.nodeInfo.TotalRAM,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(prepareSystemAlertItem(nodeID, "ram", time, value, status))
+				monitor.sendAlert(prepareSystemAlertItem(nodeID, "ram", time, value, status))
 			},
 			*nodeConfig.AlertRules.RAM))
 	}
@@ -413,7 +648,7 @@ func (monitor *ResourceMonitor) setupSystemAlerts(nodeConfig cloudprotocol.NodeC
 			diskUsageValue,
 			diskTotalSize,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(prepareSystemAlertItem(nodeID, diskRule.Name, time, value, status))
+				monitor.sendAlert(prepareSystemAlertItem(nodeID, diskRule.Name, time, value, status))
 			},
 			diskRule.AlertRulePercents))
 	}
@@ -423,7 +658,7 @@ func (monitor *ResourceMonitor) setupSystemAlerts(nodeConfig cloudprotocol.NodeC
 			"Download traffic",
 			&monitor.nodeMonitoring.Download,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(prepareSystemAlertItem(nodeID, "download", time, value, status))
+				monitor.sendAlert(prepareSystemAlertItem(nodeID, "download", time, value, status))
 			},
 			*nodeConfig.AlertRules.Download))
 	}
@@ -433,11 +668,41 @@ func (monitor *ResourceMonitor) setupSystemAlerts(nodeConfig cloudprotocol.NodeC
 			"Upload traffic",
 			&monitor.nodeMonitoring.Upload,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(prepareSystemAlertItem(nodeID, "upload", time, value, status))
+				monitor.sendAlert(prepareSystemAlertItem(nodeID, "upload", time, value, status))
 			},
 			*nodeConfig.AlertRules.Upload))
 	}
 
+	if nodeConfig.AlertRules.FileDescriptors != nil {
+		monitor.alertProcessors.PushBack(createAlertProcessorCount(
+			"System file descriptors",
+			&monitor.nodeMonitoring.FileDescriptors,
+			func(time time.Time, value uint64, status string) {
+				monitor.sendAlert(prepareSystemAlertItem(nodeID, "fileDescriptors", time, value, status))
+			},
+			*nodeConfig.AlertRules.FileDescriptors))
+	}
+
+	if nodeConfig.AlertRules.PIDs != nil {
+		monitor.alertProcessors.PushBack(createAlertProcessorCount(
+			"System PIDs",
+			&monitor.nodeMonitoring.PIDs,
+			func(time time.Time, value uint64, status string) {
+				monitor.sendAlert(prepareSystemAlertItem(nodeID, "pids", time, value, status))
+			},
+			*nodeConfig.AlertRules.PIDs))
+	}
+
+	if nodeConfig.AlertRules.Entropy != nil {
+		monitor.alertProcessors.PushBack(createAlertProcessorCountLow(
+			"System entropy",
+			&monitor.nodeMonitoring.Entropy,
+			func(time time.Time, value uint64, status string) {
+				monitor.sendAlert(prepareSystemAlertItem(nodeID, "entropy", time, value, status))
+			},
+			*nodeConfig.AlertRules.Entropy))
+	}
+
 	return err
 }
 
@@ -480,13 +745,29 @@ func (monitor *ResourceMonitor) run(ctx context.Context) {
 				log.Errorf("Can't setup system alerts: %v", err)
 			}
 
-		case <-monitor.pollTimer.C:
+		case nodeInfo, ok := <-monitor.nodeInfoListener:
+			if !ok {
+				monitor.nodeInfoListener = nil
+
+				continue
+			}
+
+			if err := monitor.updateNodeInfo(nodeInfo); err != nil {
+				log.Errorf("Can't update node info: %v", err)
+			}
+
+		case <-monitor.pollTimer.C():
 			monitor.Lock()
 			monitor.sourceSystemUsage.CacheSystemInfos()
 			monitor.getCurrentSystemData()
 			monitor.getCurrentInstancesData()
-			monitor.processAlerts()
-			monitor.sendMonitoringData()
+
+			alertStatusChanged := monitor.processAlerts()
+
+			if monitor.isSendRequired(monitor.clock.Now(), alertStatusChanged) {
+				monitor.sendMonitoringData()
+			}
+
 			monitor.Unlock()
 		}
 	}
@@ -498,14 +779,22 @@ func (monitor *ResourceMonitor) setupInstanceAlerts(instanceID string, instanceM
 	instanceMonitoring.alertProcessorElements = make([]*list.Element, 0)
 
 	if rules.CPU != nil {
+		cpuSource, cpuMaxValue := &instanceMonitoring.monitoring.CPU, monitor.nodeInfo.MaxDMIPs
+
+		// Limit basis rule thresholds are applied to percent of instance own limit, alert value is reported in DMIPs.
+		if rules.CPU.Basis == aostypes.AlertBasisLimit {
+			cpuSource, cpuMaxValue = &instanceMonitoring.monitoring.CPULimitPercent, 100
+		}
+
 		e := monitor.alertProcessors.PushBack(createAlertProcessorPercents(
 			instanceID+" CPU",
-			&instanceMonitoring.monitoring.CPU,
-			monitor.nodeInfo.MaxDMIPs,
-			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(
+			cpuSource,
+			cpuMaxValue,
+			func(time time.Time, _ uint64, status string) {
+				monitor.sendAlert(
 					prepareInstanceAlertItem(
-						instanceMonitoring.monitoring.InstanceIdent, "cpu", time, value, status))
+						instanceMonitoring.monitoring.InstanceIdent, "cpu", time, instanceMonitoring.monitoring.CPU,
+						status))
 			}, *rules.CPU))
 
 		instanceMonitoring.alertProcessorElements = append(instanceMonitoring.alertProcessorElements, e)
@@ -517,7 +806,7 @@ func (monitor *ResourceMonitor) setupInstanceAlerts(instanceID string, instanceM
 			&instanceMonitoring.monitoring.RAM,
 			monitor.nodeInfo.TotalRAM,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(
+				monitor.sendAlert(
 					prepareInstanceAlertItem(
 						instanceMonitoring.monitoring.InstanceIdent, "ram", time, value, status))
 			}, *rules.RAM))
@@ -538,7 +827,7 @@ func (monitor *ResourceMonitor) setupInstanceAlerts(instanceID string, instanceM
 			diskUsageValue,
 			diskTotalSize,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(
+				monitor.sendAlert(
 					prepareInstanceAlertItem(
 						instanceMonitoring.monitoring.InstanceIdent, diskRule.Name, time, value, status))
 			}, diskRule.AlertRulePercents))
@@ -551,7 +840,7 @@ func (monitor *ResourceMonitor) setupInstanceAlerts(instanceID string, instanceM
 			instanceID+" download traffic",
 			&instanceMonitoring.monitoring.Download,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(
+				monitor.sendAlert(
 					prepareInstanceAlertItem(
 						instanceMonitoring.monitoring.InstanceIdent, "download", time, value, status))
 			}, *rules.Download))
@@ -564,7 +853,7 @@ func (monitor *ResourceMonitor) setupInstanceAlerts(instanceID string, instanceM
 			instanceID+" upload traffic",
 			&instanceMonitoring.monitoring.Upload,
 			func(time time.Time, value uint64, status string) {
-				monitor.alertSender.SendAlert(
+				monitor.sendAlert(
 					prepareInstanceAlertItem(
 						instanceMonitoring.monitoring.InstanceIdent, "upload", time, value, status))
 			}, *rules.Upload))
@@ -572,6 +861,32 @@ func (monitor *ResourceMonitor) setupInstanceAlerts(instanceID string, instanceM
 		instanceMonitoring.alertProcessorElements = append(instanceMonitoring.alertProcessorElements, e)
 	}
 
+	if rules.FileDescriptors != nil {
+		e := monitor.alertProcessors.PushBack(createAlertProcessorCount(
+			instanceID+" file descriptors",
+			&instanceMonitoring.monitoring.FileDescriptors,
+			func(time time.Time, value uint64, status string) {
+				monitor.sendAlert(
+					prepareInstanceAlertItem(
+						instanceMonitoring.monitoring.InstanceIdent, "fileDescriptors", time, value, status))
+			}, *rules.FileDescriptors))
+
+		instanceMonitoring.alertProcessorElements = append(instanceMonitoring.alertProcessorElements, e)
+	}
+
+	if rules.PIDs != nil {
+		e := monitor.alertProcessors.PushBack(createAlertProcessorCount(
+			instanceID+" PIDs",
+			&instanceMonitoring.monitoring.PIDs,
+			func(time time.Time, value uint64, status string) {
+				monitor.sendAlert(
+					prepareInstanceAlertItem(
+						instanceMonitoring.monitoring.InstanceIdent, "pids", time, value, status))
+			}, *rules.PIDs))
+
+		instanceMonitoring.alertProcessorElements = append(instanceMonitoring.alertProcessorElements, e)
+	}
+
 	return err
 }
 
@@ -587,17 +902,90 @@ func (monitor *ResourceMonitor) sendMonitoringData() {
 			instanceMonitoring.monitoring)
 	}
 
+	monitor.lastSendTime = monitor.clock.Now()
+	monitor.lastSentValues = monitor.getMonitoringValues()
+
 	monitor.monitoringChannel <- nodeMonitoringData
 }
 
+func (monitor *ResourceMonitor) isSendRequired(currentTime time.Time, alertStatusChanged bool) bool {
+	if monitor.sendPeriod == 0 || currentTime.Sub(monitor.lastSendTime) >= monitor.sendPeriod {
+		return true
+	}
+
+	if monitor.sendOnChange.AlertStatus && alertStatusChanged {
+		log.Debug("Send monitoring data on alert status change")
+
+		return true
+	}
+
+	if monitor.sendOnChange.ValueChangePercent > 0 && monitor.isValueChanged() {
+		log.Debug("Send monitoring data on value change")
+
+		return true
+	}
+
+	return false
+}
+
+// getMonitoringValues returns node (empty key) and instances monitoring values to detect changes.
+func (monitor *ResourceMonitor) getMonitoringValues() (values map[string][]uint64) {
+	values = make(map[string][]uint64, len(monitor.instanceMonitoringMap)+1)
+
+	values[""] = monitoringDataValues(monitor.nodeMonitoring)
+
+	for instanceID, instanceMonitoring := range monitor.instanceMonitoringMap {
+		values[instanceID] = monitoringDataValues(instanceMonitoring.monitoring.MonitoringData)
+	}
+
+	return values
+}
+
+func (monitor *ResourceMonitor) isValueChanged() bool {
+	currentValues := monitor.getMonitoringValues()
+
+	if len(currentValues) != len(monitor.lastSentValues) {
+		return true
+	}
+
+	for id, values := range currentValues {
+		lastValues, ok := monitor.lastSentValues[id]
+		if !ok || len(lastValues) != len(values) {
+			return true
+		}
+
+		for i, value := range values {
+			if math.Abs(float64(value)-float64(lastValues[i])) >
+				float64(lastValues[i])*monitor.sendOnChange.ValueChangePercent/100.0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func monitoringDataValues(data aostypes.MonitoringData) (values []uint64) {
+	values = append(make([]uint64, 0, 7+len(data.Partitions)), //nolint:mnd
+		data.CPU, data.RAM, data.Download, data.Upload, data.FileDescriptors, data.PIDs, data.Entropy)
+
+	for _, partition := range data.Partitions {
+		values = append(values, partition.UsedSize)
+	}
+
+	return values
+}
+
 func (monitor *ResourceMonitor) getCurrentSystemData() {
-	monitor.nodeMonitoring.Timestamp = time.Now()
+	monitor.nodeMonitoring.Timestamp = monitor.clock.Now()
 
 	cpu, err := getSystemCPUUsage()
 	if err != nil {
 		log.Errorf("Can't get system CPU: %s", err)
 	}
 
+	monitor.checkSystemCollector(collectorCPU, err)
+
 	monitor.nodeMonitoring.CPU = monitor.cpuToDMIPs(cpu)
 
 	monitor.nodeMonitoring.RAM, err = getSystemRAMUsage()
@@ -605,6 +993,8 @@ func (monitor *ResourceMonitor) getCurrentSystemData() {
 		log.Errorf("Can't get system RAM: %s", err)
 	}
 
+	monitor.checkSystemCollector(collectorRAM, err)
+
 	for i, disk := range monitor.nodeMonitoring.Partitions {
 		mountPoint, err := getDiskPath(monitor.nodeInfo.Partitions, disk.Name)
 		if err != nil {
@@ -617,6 +1007,8 @@ func (monitor *ResourceMonitor) getCurrentSystemData() {
 		if err != nil {
 			log.Errorf("Can't get system Disk usage: %v", err)
 		}
+
+		monitor.checkSystemCollector(disk.Name, err)
 	}
 
 	if monitor.trafficMonitoring != nil {
@@ -625,23 +1017,46 @@ func (monitor *ResourceMonitor) getCurrentSystemData() {
 			log.Errorf("Can't get system traffic value: %s", err)
 		}
 
+		monitor.checkSystemCollector(collectorTraffic, err)
+
 		monitor.nodeMonitoring.Download = download
 		monitor.nodeMonitoring.Upload = upload
 	}
 
+	if monitor.nodeMonitoring.FileDescriptors, err = systemFileDescriptors(); err != nil {
+		log.Errorf("Can't get system file descriptors: %v", err)
+	}
+
+	monitor.checkSystemCollector(collectorFileDescriptors, err)
+
+	if monitor.nodeMonitoring.PIDs, err = systemPIDs(); err != nil {
+		log.Errorf("Can't get system PIDs: %v", err)
+	}
+
+	monitor.checkSystemCollector(collectorPIDs, err)
+
+	if monitor.nodeMonitoring.Entropy, err = systemEntropy(); err != nil {
+		log.Errorf("Can't get system entropy: %v", err)
+	}
+
+	monitor.checkSystemCollector(collectorEntropy, err)
+
 	monitor.nodeAverageData.updateMonitoringData(monitor.nodeMonitoring)
 
 	log.WithFields(log.Fields{
-		"CPU":        monitor.nodeMonitoring.CPU,
-		"RAM":        monitor.nodeMonitoring.RAM,
-		"Partitions": monitor.nodeMonitoring.Partitions,
-		"Download":   monitor.nodeMonitoring.Download,
-		"Upload":     monitor.nodeMonitoring.Upload,
+		"CPU":             monitor.nodeMonitoring.CPU,
+		"RAM":             monitor.nodeMonitoring.RAM,
+		"Partitions":      monitor.nodeMonitoring.Partitions,
+		"Download":        monitor.nodeMonitoring.Download,
+		"Upload":          monitor.nodeMonitoring.Upload,
+		"FileDescriptors": monitor.nodeMonitoring.FileDescriptors,
+		"PIDs":            monitor.nodeMonitoring.PIDs,
+		"Entropy":         monitor.nodeMonitoring.Entropy,
 	}).Debug("Monitoring data")
 }
 
 func (monitor *ResourceMonitor) getCurrentInstancesData() {
-	timestamp := time.Now()
+	timestamp := monitor.clock.Now()
 
 	for instanceID, value := range monitor.instanceMonitoringMap {
 		value.monitoring.Timestamp = timestamp
@@ -651,7 +1066,11 @@ func (monitor *ResourceMonitor) getCurrentInstancesData() {
 			log.Errorf("Can't fill system usage info: %v", err)
 		}
 
+		monitor.checkInstanceCollector(instanceID, value.monitoring.InstanceIdent, collectorUsage, err)
+
 		value.monitoring.CPU = monitor.cpuToDMIPs(float64(value.monitoring.CPU))
+		value.monitoring.CPULimit = monitor.getInstanceCPULimit(instanceID, value)
+		value.monitoring.CPULimitPercent = monitor.cpuLimitPercent(value.monitoring.CPU, value.monitoring.CPULimit)
 
 		for i, partitionParam := range value.partitions {
 			value.monitoring.Partitions[i].UsedSize, err = getInstanceDiskUsage(partitionParam.Path,
@@ -659,6 +1078,8 @@ func (monitor *ResourceMonitor) getCurrentInstancesData() {
 			if err != nil {
 				log.Errorf("Can't get service disk usage: %v", err)
 			}
+
+			monitor.checkInstanceCollector(instanceID, value.monitoring.InstanceIdent, partitionParam.Name, err)
 		}
 
 		if monitor.trafficMonitoring != nil {
@@ -667,35 +1088,60 @@ func (monitor *ResourceMonitor) getCurrentInstancesData() {
 				log.Errorf("Can't get service traffic: %s", err)
 			}
 
+			monitor.checkInstanceCollector(instanceID, value.monitoring.InstanceIdent, collectorTraffic, err)
+
 			value.monitoring.Download = download
 			value.monitoring.Upload = upload
 		}
 
+		if countProvider, ok := monitor.sourceSystemUsage.(ProcessCountProvider); ok {
+			fileDescriptors, pids, err := countProvider.GetProcessCounts(instanceID)
+			if err != nil {
+				log.Errorf("Can't get instance process counts: %v", err)
+			}
+
+			monitor.checkInstanceCollector(instanceID, value.monitoring.InstanceIdent, collectorProcesses, err)
+
+			value.monitoring.FileDescriptors = fileDescriptors
+			value.monitoring.PIDs = pids
+		}
+
 		value.averageData.updateMonitoringData(value.monitoring.MonitoringData)
 
 		log.WithFields(log.Fields{
-			"id":         instanceID,
-			"CPU":        value.monitoring.CPU,
-			"RAM":        value.monitoring.RAM,
-			"Partitions": value.monitoring.Partitions,
-			"Download":   value.monitoring.Download,
-			"Upload":     value.monitoring.Upload,
+			"id":              instanceID,
+			"CPU":             value.monitoring.CPU,
+			"CPULimit":        value.monitoring.CPULimit,
+			"RAM":             value.monitoring.RAM,
+			"Partitions":      value.monitoring.Partitions,
+			"Download":        value.monitoring.Download,
+			"Upload":          value.monitoring.Upload,
+			"FileDescriptors": value.monitoring.FileDescriptors,
+			"PIDs":            value.monitoring.PIDs,
 		}).Debug("Instance monitoring data")
 	}
 }
 
-func (monitor *ResourceMonitor) processAlerts() {
-	currentTime := time.Now()
+func (monitor *ResourceMonitor) processAlerts() (statusChanged bool) {
+	currentTime := monitor.clock.Now()
 
 	for e := monitor.alertProcessors.Front(); e != nil; e = e.Next() {
 		alertProcessor, ok := e.Value.(*alertProcessor)
 		if !ok {
 			log.Error("Unexpected alert processors type")
-			return
+			return statusChanged
 		}
 
+		prevCondition := alertProcessor.alertCondition
+
 		alertProcessor.checkAlertDetection(currentTime)
+
+		if alertProcessor.alertCondition != prevCondition {
+			statusChanged = true
+		}
 	}
+
+	return statusChanged
 }
 
 // getSystemCPUUsage returns CPU usage in percent.
@@ -739,6 +1185,84 @@ func getInstanceDiskUsage(path string, uid, gid uint32) (diskUse uint64, err err
 	return diskUse, nil
 }
 
+// getSystemFileDescriptors returns number of allocated file handles.
+func getSystemFileDescriptors() (fileDescriptors uint64, err error) {
+	data, err := os.ReadFile(fileNrPath)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return parseFileNr(string(data))
+}
+
+// parseFileNr parses file-nr content: "$ALLOCATED $FREE $MAX".
+func parseFileNr(content string) (fileDescriptors uint64, err error) {
+	fields := strings.Fields(content)
+	if len(fields) != 3 { //nolint:mnd
+		return 0, aoserrors.Errorf("invalid file-nr content: %s", content)
+	}
+
+	allocated, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	free, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	if free > allocated {
+		return 0, nil
+	}
+
+	return allocated - free, nil
+}
+
+// getSystemPIDs returns number of existing tasks.
+func getSystemPIDs() (pids uint64, err error) {
+	data, err := os.ReadFile(loadAvgPath)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return parseLoadAvgTasks(string(data))
+}
+
+// parseLoadAvgTasks parses number of tasks from loadavg content: "$AVG1 $AVG5 $AVG15 $RUNNING/$TOTAL $LASTPID".
+func parseLoadAvgTasks(content string) (tasks uint64, err error) {
+	fields := strings.Fields(content)
+	if len(fields) != 5 { //nolint:mnd
+		return 0, aoserrors.Errorf("invalid loadavg content: %s", content)
+	}
+
+	_, total, ok := strings.Cut(fields[3], "/")
+	if !ok {
+		return 0, aoserrors.Errorf("invalid loadavg content: %s", content)
+	}
+
+	if tasks, err = strconv.ParseUint(total, 10, 64); err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return tasks, nil
+}
+
+// getSystemEntropy returns kernel available entropy in bits.
+func getSystemEntropy() (entropy uint64, err error) {
+	return getLineFromFile(entropyAvailPath, 0)
+}
+
+func (monitor *ResourceMonitor) sendAlert(alert interface{}) {
+	if !monitor.alertPolicy.Load().IsAllowed(alert) {
+		log.Debug("Alert muted by policy")
+
+		return
+	}
+
+	monitor.alertSender.SendAlert(alert)
+}
+
 func prepareSystemAlertItem(
 	nodeID, parameter string, timestamp time.Time, value uint64, status string,
 ) cloudprotocol.SystemQuotaAlert {
@@ -763,7 +1287,7 @@ func prepareInstanceAlertItem(
 	}
 }
 
-func getSourceSystemUsage(source string) SystemUsageProvider {
+func getSourceSystemUsage(source, ramAccounting string) SystemUsageProvider {
 	if source == "xentop" {
 		return &xenSystemUsage{}
 	}
@@ -772,20 +1296,59 @@ func getSourceSystemUsage(source string) SystemUsageProvider {
 		return instanceUsage
 	}
 
-	return &cgroupsSystemUsage{}
+	return &cgroupsSystemUsage{ramAccounting: ramAccounting}
 }
 
 func (monitor *ResourceMonitor) cpuToDMIPs(cpu float64) uint64 {
 	return uint64(math.Round(float64(cpu) * float64(monitor.nodeInfo.MaxDMIPs) / 100.0))
 }
 
+func (monitor *ResourceMonitor) getInstanceCPULimit(instanceID string, instance *instanceMonitoring) uint64 {
+	if instance.cpuLimit != 0 {
+		return instance.cpuLimit
+	}
+
+	limitProvider, ok := monitor.sourceSystemUsage.(CPULimitProvider)
+	if !ok {
+		return 0
+	}
+
+	cores, err := limitProvider.GetCPULimit(instanceID)
+	if err != nil {
+		log.Errorf("Can't get instance CPU limit: %v", err)
+
+		return 0
+	}
+
+	if cores <= 0 || cores >= float64(cpuCount) {
+		return 0
+	}
+
+	return monitor.cpuToDMIPs(cores * 100.0 / float64(cpuCount))
+}
+
+func (monitor *ResourceMonitor) cpuLimitPercent(cpu, cpuLimit uint64) uint64 {
+	if cpuLimit == 0 {
+		cpuLimit = monitor.nodeInfo.MaxDMIPs
+	}
+
+	if cpuLimit == 0 {
+		return 0
+	}
+
+	return uint64(math.Round(float64(cpu) * 100.0 / float64(cpuLimit)))
+}
+
 func newAverageMonitoring(windowCount uint64, partitions []aostypes.PartitionUsage) *averageMonitoring {
 	averageMonitoring := &averageMonitoring{
-		ram:      newAverageCalc(windowCount),
-		cpu:      newAverageCalc(windowCount),
-		download: newAverageCalc(windowCount),
-		upload:   newAverageCalc(windowCount),
-		disks:    make(map[string]*averageCalc),
+		ram:             newAverageCalc(windowCount),
+		cpu:             newAverageCalc(windowCount),
+		download:        newAverageCalc(windowCount),
+		upload:          newAverageCalc(windowCount),
+		fileDescriptors: newAverageCalc(windowCount),
+		pids:            newAverageCalc(windowCount),
+		entropy:         newAverageCalc(windowCount),
+		disks:           make(map[string]*averageCalc),
 	}
 
 	for _, partition := range partitions {
@@ -803,6 +1366,10 @@ func (average *averageMonitoring) toMonitoringData(timestamp time.Time) aostypes
 		Upload:     average.upload.getIntValue(),
 		Partitions: make([]aostypes.PartitionUsage, 0, len(average.disks)),
 		Timestamp:  timestamp,
+
+		FileDescriptors: average.fileDescriptors.getIntValue(),
+		PIDs:            average.pids.getIntValue(),
+		Entropy:         average.entropy.getIntValue(),
 	}
 
 	for name, diskUsage := range average.disks {
@@ -819,6 +1386,9 @@ func (average *averageMonitoring) updateMonitoringData(data aostypes.MonitoringD
 	average.ram.calculate(float64(data.RAM))
 	average.download.calculate(float64(data.Download))
 	average.upload.calculate(float64(data.Upload))
+	average.fileDescriptors.calculate(float64(data.FileDescriptors))
+	average.pids.calculate(float64(data.PIDs))
+	average.entropy.calculate(float64(data.Entropy))
 
 	for _, partition := range data.Partitions {
 		averageCalc, ok := average.disks[partition.Name]
@@ -23,6 +23,8 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +32,8 @@ import (
 	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/api/cloudprotocol"
 	"github.com/aosedge/aos_common/utils/alertutils"
+	"github.com/aosedge/aos_common/utils/clock"
+	"github.com/aosedge/aos_common/utils/testtools"
 	"github.com/shirou/gopsutil/disk"
 	"github.com/shirou/gopsutil/mem"
 	log "github.com/sirupsen/logrus"
@@ -67,11 +71,14 @@ type testTrafficMonitoring struct {
 }
 
 type testUsageData struct {
-	cpu       float64
-	ram       uint64
-	totalRAM  uint64
-	disk      uint64
-	totalDisk uint64
+	cpu             float64
+	ram             uint64
+	totalRAM        uint64
+	disk            uint64
+	totalDisk       uint64
+	fileDescriptors uint64
+	pids            uint64
+	entropy         uint64
 }
 
 type testData struct {
@@ -112,6 +119,10 @@ var (
 func TestMain(m *testing.M) {
 	numCPU = 2
 
+	systemFileDescriptors = func() (uint64, error) { return systemUsageData.fileDescriptors, nil }
+	systemPIDs = func() (uint64, error) { return systemUsageData.pids, nil }
+	systemEntropy = func() (uint64, error) { return systemUsageData.entropy, nil }
+
 	ret := m.Run()
 
 	os.Exit(ret)
@@ -451,6 +462,7 @@ func TestInstances(t *testing.T) {
 							Download: 150,
 							Upload:   150,
 						},
+						CPULimitPercent: 35,
 					},
 				},
 			},
@@ -526,6 +538,7 @@ func TestInstances(t *testing.T) {
 							Download: 250,
 							Upload:   150,
 						},
+						CPULimitPercent: 25,
 					},
 				},
 			},
@@ -613,6 +626,7 @@ func TestInstances(t *testing.T) {
 							Download: 150,
 							Upload:   250,
 						},
+						CPULimitPercent: 90,
 					},
 				},
 			},
@@ -705,6 +719,7 @@ func TestInstances(t *testing.T) {
 							Download: 150,
 							Upload:   250,
 						},
+						CPULimitPercent: 90,
 					},
 				},
 			},
@@ -810,6 +825,273 @@ func TestInstances(t *testing.T) {
 	}
 }
 
+func TestInstanceCPULimit(t *testing.T) {
+	duration := 100 * time.Millisecond
+
+	nodeInfoProvider := &testNodeInfoProvider{
+		nodeInfo: cloudprotocol.NodeInfo{NodeID: "testNode", NodeType: "testNode", MaxDMIPs: 10000, TotalRAM: 10000},
+	}
+	alertSender := &testAlertsSender{}
+	testInstancesUsage := newTestInstancesUsage()
+
+	instanceUsage = testInstancesUsage
+	defer func() {
+		instanceUsage = nil
+	}()
+
+	monitor, err := New(Config{PollPeriod: aostypes.Duration{Duration: duration}},
+		nodeInfoProvider, &testNodeConfigProvider{}, nil, alertSender)
+	if err != nil {
+		t.Fatalf("Can't create monitoring instance: %s", err)
+	}
+	defer monitor.Close()
+
+	limitIdent := aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1", Instance: 1}
+	nodeIdent := aostypes.InstanceIdent{ServiceID: "service2", SubjectID: "subject1", Instance: 1}
+
+	for instanceID, params := range map[string]ResourceMonitorParams{
+		"limited": {
+			InstanceIdent: limitIdent, CPULimit: 2000,
+			AlertRules: &aostypes.AlertRules{CPU: &aostypes.AlertRulePercents{
+				MinThreshold: 60, MaxThreshold: 70, Basis: aostypes.AlertBasisLimit,
+			}},
+		},
+		"unlimited": {
+			InstanceIdent: nodeIdent,
+			AlertRules: &aostypes.AlertRules{CPU: &aostypes.AlertRulePercents{
+				MinThreshold: 60, MaxThreshold: 70,
+			}},
+		},
+	} {
+		testInstancesUsage.instances[instanceID] = testUsageData{cpu: 15}
+
+		if err := monitor.StartInstanceMonitor(instanceID, params); err != nil {
+			t.Fatalf("Can't start monitoring instance: %s", err)
+		}
+	}
+
+	select {
+	case monitoringData := <-monitor.GetNodeMonitoringChannel():
+		for _, instanceData := range monitoringData.InstancesData {
+			expectedLimit, expectedPercent := uint64(2000), uint64(75)
+
+			if instanceData.InstanceIdent == nodeIdent {
+				expectedLimit, expectedPercent = 0, 15
+			}
+
+			if instanceData.CPU != 1500 || instanceData.CPULimit != expectedLimit ||
+				instanceData.CPULimitPercent != expectedPercent {
+				t.Errorf("Wrong instance CPU data: %v", instanceData)
+			}
+		}
+
+	case <-time.After(duration * 2):
+		t.Fatal("Monitoring data timeout")
+	}
+
+	expectedAlerts := []interface{}{prepareInstanceAlertItem(limitIdent, "cpu", time.Time{}, 1500, "raise")}
+
+	if !AlertSlicesEqual(alertSender.alerts, expectedAlerts) {
+		t.Errorf("Wrong alerts: %v", alertSender.alerts)
+	}
+}
+
+func TestParseCPUMax(t *testing.T) {
+	testData := []struct {
+		content string
+		cores   float64
+		err     bool
+	}{
+		{content: "max 100000\n", cores: 0},
+		{content: "50000 100000\n", cores: 0.5},
+		{content: "200000 100000", cores: 2},
+		{content: "invalid", err: true},
+		{content: "100 0", err: true},
+	}
+
+	for _, item := range testData {
+		cores, err := parseCPUMax(item.content)
+		if (err != nil) != item.err {
+			t.Errorf("Wrong parse error for %q: %v", item.content, err)
+		}
+
+		if cores != item.cores {
+			t.Errorf("Wrong cores for %q: %f", item.content, cores)
+		}
+	}
+}
+
+func TestParseSmapsRollup(t *testing.T) {
+	const smapsRollup = `55d5c8a4e000-7ffd2b9fe000 ---p 00000000 00:00 0                          [rollup]
+Rss:               10240 kB
+Pss:                4096 kB
+Pss_Anon:           1024 kB
+Shared_Clean:       6144 kB
+Shared_Dirty:          0 kB
+Private_Clean:      1024 kB
+Private_Dirty:      2048 kB
+Referenced:        10240 kB
+`
+
+	testData := []struct {
+		content       string
+		ramAccounting string
+		usage         uint64
+		err           bool
+	}{
+		{content: smapsRollup, ramAccounting: RAMAccountingPSS, usage: 4096 * 1024},
+		{content: smapsRollup, ramAccounting: RAMAccountingUSS, usage: 3072 * 1024},
+		{content: "Pss: invalid kB", ramAccounting: RAMAccountingPSS, err: true},
+		{content: "", ramAccounting: RAMAccountingUSS, usage: 0},
+	}
+
+	for _, item := range testData {
+		usage, err := parseSmapsRollup(strings.NewReader(item.content), item.ramAccounting)
+		if (err != nil) != item.err {
+			t.Errorf("Wrong parse error for %s: %v", item.ramAccounting, err)
+		}
+
+		if usage != item.usage {
+			t.Errorf("Wrong %s usage: %d", item.ramAccounting, usage)
+		}
+	}
+
+	if _, err := New(Config{PollPeriod: aostypes.Duration{Duration: time.Second}, RAMAccounting: "rss"},
+		&testNodeInfoProvider{nodeInfo: cloudprotocol.NodeInfo{MaxDMIPs: 10000}}, &testNodeConfigProvider{},
+		nil, nil); err == nil {
+		t.Error("Error expected for unsupported RAM accounting mode")
+	}
+}
+
+func TestTrafficAccounting(t *testing.T) {
+	var commands []string
+
+	listings := map[string]string{
+		"iptables -w -t mangle -L AOS_TRAFFIC_IN -v -x -n": `Chain AOS_TRAFFIC_IN (1 references)
+    pkts      bytes target     prot opt in     out     source               destination
+     100     5000            all  --  *      *       0.0.0.0/0            0.0.0.0/0            /* aos-system */
+      10     1000            all  --  *      *       0.0.0.0/0            0.0.0.0/0            connmark match  0xa0500000 /* instance0 */
+`,
+		"iptables -w -t mangle -L AOS_TRAFFIC_OUT -v -x -n": `Chain AOS_TRAFFIC_OUT (1 references)
+    pkts      bytes target     prot opt in     out     source               destination
+      50     3000            all  --  *      *       0.0.0.0/0            0.0.0.0/0            /* aos-system */
+       5      300 CONNMARK   all  --  *      *       0.0.0.0/0            0.0.0.0/0            owner UID match 5000 /* instance0 */ CONNMARK set 0xa0500000
+`,
+		"nft list chain inet aos_traffic AOS_TRAFFIC_IN": `table inet aos_traffic {
+	chain AOS_TRAFFIC_IN {
+		type filter hook input priority -150; policy accept;
+		counter packets 12 bytes 1200 comment "aos-system"
+		ct mark 0xa0500000 counter packets 2 bytes 200 comment "instance0"
+	}
+}
+`,
+		"nft list chain inet aos_traffic AOS_TRAFFIC_OUT": `table inet aos_traffic {
+	chain AOS_TRAFFIC_OUT {
+		type filter hook output priority -150; policy accept;
+		counter packets 6 bytes 600 comment "aos-system"
+		meta cgroup 1048577 ct mark set 0xa0500000 counter packets 1 bytes 100 comment "instance0"
+	}
+}
+`,
+		"nft -a list chain inet aos_traffic AOS_TRAFFIC_OUT": `table inet aos_traffic {
+	chain AOS_TRAFFIC_OUT { # handle 2
+		counter packets 6 bytes 600 comment "aos-system" # handle 4
+		meta cgroup 1048577 ct mark set 0xa0500000 counter packets 1 bytes 100 comment "instance0" # handle 7
+	}
+}
+`,
+	}
+
+	runTrafficCommand = func(name string, args ...string) (string, error) {
+		command := strings.Join(append([]string{name}, args...), " ")
+		commands = append(commands, command)
+
+		return listings[command], nil
+	}
+
+	data := []struct {
+		config                  TrafficAccountingConfig
+		classID                 uint32
+		systemIn, systemOut     uint64
+		instanceIn, instanceOut uint64
+		expectedCommands        []string
+	}{
+		{
+			config:   TrafficAccountingConfig{},
+			systemIn: 5000, systemOut: 3000, instanceIn: 1000, instanceOut: 300,
+			expectedCommands: []string{
+				"iptables -w -t mangle -A AOS_TRAFFIC_OUT -m owner --uid-owner 5000 -m comment --comment instance0 " +
+					"-j CONNMARK --set-mark 0xa0500000",
+				"iptables -w -t mangle -A AOS_TRAFFIC_IN -m connmark --mark 0xa0500000 -m comment --comment instance0",
+				"iptables -w -t mangle -D AOS_TRAFFIC_IN -m connmark --mark 0xa0500000 -m comment --comment instance0",
+				"iptables -w -t mangle -D AOS_TRAFFIC_OUT -m owner --uid-owner 5000 -m comment --comment instance0 " +
+					"-j CONNMARK --set-mark 0xa0500000",
+			},
+		},
+		{
+			config:   TrafficAccountingConfig{Backend: TrafficBackendNFTables},
+			classID:  0x100001,
+			systemIn: 1200, systemOut: 600, instanceIn: 200, instanceOut: 100,
+			expectedCommands: []string{
+				"nft add rule inet aos_traffic AOS_TRAFFIC_OUT meta cgroup 1048577 ct mark set 0xa0500000 counter " +
+					"comment \"instance0\"",
+				"nft add rule inet aos_traffic AOS_TRAFFIC_IN ct mark 0xa0500000 counter comment \"instance0\"",
+				"nft delete rule inet aos_traffic AOS_TRAFFIC_OUT handle 7",
+				"nft delete table inet aos_traffic",
+			},
+		},
+	}
+
+	for i, item := range data {
+		accounting, err := NewTrafficAccounting(item.config)
+		if err != nil {
+			t.Fatalf("Can't create traffic accounting: %v", err)
+		}
+
+		if err = accounting.StartInstanceAccounting("instance0", 5000, item.classID); err != nil {
+			t.Fatalf("Can't start instance accounting: %v", err)
+		}
+
+		if _, _, err = accounting.GetInstanceTraffic("instance1"); err == nil {
+			t.Error("Error expected for not accounted instance")
+		}
+
+		systemIn, systemOut, err := accounting.GetSystemTraffic()
+		if err != nil {
+			t.Fatalf("Can't get system traffic: %v", err)
+		}
+
+		if systemIn != item.systemIn || systemOut != item.systemOut {
+			t.Errorf("Wrong system traffic [%d]: %d, %d", i, systemIn, systemOut)
+		}
+
+		instanceIn, instanceOut, err := accounting.GetInstanceTraffic("instance0")
+		if err != nil {
+			t.Fatalf("Can't get instance traffic: %v", err)
+		}
+
+		if instanceIn != item.instanceIn || instanceOut != item.instanceOut {
+			t.Errorf("Wrong instance traffic [%d]: %d, %d", i, instanceIn, instanceOut)
+		}
+
+		if err = accounting.StopInstanceAccounting("instance0"); err != nil {
+			t.Fatalf("Can't stop instance accounting: %v", err)
+		}
+
+		if err = accounting.Close(); err != nil {
+			t.Fatalf("Can't close traffic accounting: %v", err)
+		}
+
+		for _, expectedCommand := range item.expectedCommands {
+			if !slices.Contains(commands, expectedCommand) {
+				t.Errorf("Command [%d] not executed: %s", i, expectedCommand)
+			}
+		}
+
+		commands = nil
+	}
+}
+
 func TestSystemAveraging(t *testing.T) {
 	duration := 100 * time.Millisecond
 
@@ -1164,6 +1446,539 @@ func TestInstanceAveraging(t *testing.T) {
 	}
 }
 
+func TestTimeSeriesBuilder(t *testing.T) {
+	timestamp := time.Now().UTC()
+	instanceIdent := aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1", Instance: 1}
+
+	samples := []aostypes.NodeMonitoring{
+		{
+			NodeID: "node1",
+			NodeData: aostypes.MonitoringData{
+				Timestamp: timestamp, RAM: 1000, CPU: 10, Download: 1, Upload: 2,
+				Partitions:      []aostypes.PartitionUsage{{Name: "disk1", UsedSize: 100}},
+				FileDescriptors: 500, PIDs: 50, Entropy: 256,
+			},
+			InstancesData: []aostypes.InstanceMonitoring{
+				{
+					InstanceIdent: instanceIdent,
+					MonitoringData: aostypes.MonitoringData{
+						Timestamp: timestamp, RAM: 10, FileDescriptors: 20, PIDs: 2,
+					},
+					CPULimit: 1000, CPULimitPercent: 15,
+				},
+			},
+		},
+		{
+			NodeID: "node1",
+			NodeData: aostypes.MonitoringData{
+				Timestamp: timestamp.Add(time.Second), RAM: 2000, CPU: 20, Download: 3, Upload: 4,
+				Partitions: []aostypes.PartitionUsage{
+					{Name: "disk1", UsedSize: 200}, {Name: "disk2", UsedSize: 300},
+				},
+				FileDescriptors: 600, PIDs: 60, Entropy: 128,
+			},
+		},
+	}
+
+	builder := NewTimeSeriesBuilder()
+
+	for _, sample := range samples {
+		builder.Add(sample)
+	}
+
+	if builder.Len() != len(samples) {
+		t.Errorf("Wrong samples count: %d", builder.Len())
+	}
+
+	monitoring := builder.Build()
+
+	if monitoring.MessageType != cloudprotocol.TimeSeriesMonitoringMessageType {
+		t.Errorf("Wrong message type: %s", monitoring.MessageType)
+	}
+
+	if len(monitoring.Nodes) != 1 || monitoring.Nodes[0].NodeID != "node1" {
+		t.Fatalf("Wrong nodes: %v", monitoring.Nodes)
+	}
+
+	expectedNodeSeries := cloudprotocol.MonitoringSeries{
+		Timestamps: []time.Time{timestamp, timestamp.Add(time.Second)},
+		RAM:        []uint64{1000, 2000},
+		CPU:        []uint64{10, 20},
+		Download:   []uint64{1, 3},
+		Upload:     []uint64{2, 4},
+		Partitions: []cloudprotocol.PartitionUsageSeries{
+			{Name: "disk1", UsedSize: []uint64{100, 200}},
+			{Name: "disk2", UsedSize: []uint64{0, 300}},
+		},
+		FileDescriptors: []uint64{500, 600},
+		PIDs:            []uint64{50, 60},
+		Entropy:         []uint64{256, 128},
+	}
+
+	if !reflect.DeepEqual(monitoring.Nodes[0].MonitoringSeries, expectedNodeSeries) {
+		t.Errorf("Wrong node series: %v", monitoring.Nodes[0].MonitoringSeries)
+	}
+
+	if len(monitoring.ServiceInstances) != 1 || monitoring.ServiceInstances[0].InstanceIdent != instanceIdent {
+		t.Fatalf("Wrong service instances: %v", monitoring.ServiceInstances)
+	}
+
+	expectedInstanceSamples := []aostypes.InstanceMonitoring{samples[0].InstancesData[0]}
+
+	if instanceSamples := monitoring.ServiceInstances[0].InstanceSamples(); !reflect.DeepEqual(
+		instanceSamples, expectedInstanceSamples) {
+		t.Errorf("Wrong instance samples: %v", instanceSamples)
+	}
+
+	if builder.Len() != 0 || len(builder.Build().Nodes) != 0 {
+		t.Error("Builder is not reset after build")
+	}
+}
+
+func TestSendOnChange(t *testing.T) {
+	monitor := &ResourceMonitor{
+		sendPeriod:   time.Minute,
+		sendOnChange: SendOnChangeConfig{AlertStatus: true, ValueChangePercent: 10},
+		nodeMonitoring: aostypes.MonitoringData{
+			CPU: 100, RAM: 1000, Partitions: []aostypes.PartitionUsage{{Name: "disk", UsedSize: 100}},
+		},
+		instanceMonitoringMap: map[string]*instanceMonitoring{
+			"instance0": {monitoring: aostypes.InstanceMonitoring{MonitoringData: aostypes.MonitoringData{RAM: 100}}},
+		},
+		monitoringChannel: make(chan aostypes.NodeMonitoring, 1),
+		clock:             clock.New(),
+	}
+
+	monitor.sendMonitoringData()
+	<-monitor.monitoringChannel
+
+	now := monitor.lastSendTime
+
+	if monitor.isSendRequired(now, false) {
+		t.Error("Send should not be required")
+	}
+
+	if !monitor.isSendRequired(now, true) {
+		t.Error("Send should be required on alert status change")
+	}
+
+	if !monitor.isSendRequired(now.Add(time.Minute), false) {
+		t.Error("Send should be required on send period")
+	}
+
+	monitor.nodeMonitoring.CPU = 109
+	monitor.nodeMonitoring.Partitions[0].UsedSize = 91
+
+	if monitor.isSendRequired(now, false) {
+		t.Error("Send should not be required on small value change")
+	}
+
+	monitor.instanceMonitoringMap["instance0"].monitoring.RAM = 111
+
+	if !monitor.isSendRequired(now, false) {
+		t.Error("Send should be required on instance value change")
+	}
+
+	monitor.sendMonitoringData()
+	<-monitor.monitoringChannel
+
+	monitor.instanceMonitoringMap["instance1"] = &instanceMonitoring{}
+
+	if !monitor.isSendRequired(monitor.lastSendTime, false) {
+		t.Error("Send should be required on new instance")
+	}
+
+	monitor.sendPeriod = 0
+
+	if !monitor.isSendRequired(monitor.lastSendTime, false) {
+		t.Error("Send should be required on each poll if send period is not set")
+	}
+}
+
+func TestCollectorFailureAlerts(t *testing.T) {
+	alertSender := &testAlertsSender{}
+	monitor := &ResourceMonitor{
+		alertSender:     alertSender,
+		nodeInfo:        cloudprotocol.NodeInfo{NodeID: "node0"},
+		collectorHealth: newCollectorHealth(2),
+		clock:           clock.New(),
+	}
+
+	instanceIdent := aostypes.InstanceIdent{ServiceID: "service0", SubjectID: "subject0", Instance: 0}
+	errCollector := aoserrors.Errorf("xentop not found: %w", aoserrors.ErrNotFound)
+
+	monitor.checkSystemCollector(collectorCPU, errCollector)
+	monitor.checkInstanceCollector("instance0", instanceIdent, collectorUsage, errCollector)
+	monitor.checkSystemCollector(collectorCPU, nil)
+	monitor.checkSystemCollector(collectorCPU, errCollector)
+
+	if len(alertSender.alerts) != 0 {
+		t.Fatalf("Unexpected alerts: %v", alertSender.alerts)
+	}
+
+	monitor.checkInstanceCollector("instance0", instanceIdent, collectorUsage, errCollector)
+	monitor.checkInstanceCollector("instance0", instanceIdent, collectorUsage, errCollector)
+	monitor.checkInstanceCollector("instance0", instanceIdent, collectorUsage, nil)
+
+	expectedAlerts := []interface{}{
+		cloudprotocol.MonitoringAlert{
+			AlertItem:     cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagMonitoring},
+			InstanceIdent: &instanceIdent, NodeID: "node0", Parameter: collectorUsage,
+			Status:    cloudprotocol.MonitoringStatusDegraded,
+			ErrorInfo: cloudprotocol.NewErrorInfo(errCollector),
+		},
+		cloudprotocol.MonitoringAlert{
+			AlertItem:     cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagMonitoring},
+			InstanceIdent: &instanceIdent, NodeID: "node0", Parameter: collectorUsage,
+			Status: cloudprotocol.MonitoringStatusRecovered,
+		},
+	}
+
+	if !AlertSlicesEqual(alertSender.alerts, expectedAlerts) {
+		t.Errorf("Wrong alerts: %v", alertSender.alerts)
+	}
+
+	monitor.checkInstanceCollector("instance0", instanceIdent, collectorTraffic, errCollector)
+	monitor.collectorHealth.removeInstance("instance0")
+
+	if len(monitor.collectorHealth.failures) != 1 {
+		t.Errorf("Wrong collector failures: %v", monitor.collectorHealth.failures)
+	}
+}
+
+func TestAlertTimeoutWithFakeClock(t *testing.T) {
+	fakeClock := testtools.NewFakeClock(time.Unix(0, 0))
+	alertSender := &testAlertsSender{}
+	nodeInfoProvider := &testNodeInfoProvider{
+		nodeInfo: cloudprotocol.NodeInfo{NodeID: "testNode", NodeType: "testNode", MaxDMIPs: 10000, TotalRAM: 10000},
+	}
+	nodeConfigProvider := &testNodeConfigProvider{
+		nodeConfig: cloudprotocol.NodeConfig{
+			AlertRules: &aostypes.AlertRules{
+				CPU: &aostypes.AlertRulePercents{
+					MinTimeout:   aostypes.Duration{Duration: 10 * time.Second},
+					MinThreshold: 80,
+					MaxThreshold: 90,
+				},
+			},
+		},
+	}
+
+	systemCPUPercent = getSystemCPUPercent
+	systemVirtualMemory = getSystemRAM
+	systemDiskUsage = getSystemDisk
+	systemUsageData = testUsageData{cpu: 95, totalRAM: 10000}
+
+	monitor, err := New(Config{
+		PollPeriod:    aostypes.Duration{Duration: time.Second},
+		AverageWindow: aostypes.Duration{Duration: 3 * time.Second},
+		Clock:         fakeClock,
+	}, nodeInfoProvider, nodeConfigProvider, &testTrafficMonitoring{}, alertSender)
+	if err != nil {
+		t.Fatalf("Can't create monitoring instance: %s", err)
+	}
+	defer monitor.Close()
+
+	// Max threshold is crossed on the first poll, alert is raised once min timeout is elapsed
+
+	for i := int64(1); i <= 12; i++ {
+		fakeClock.Advance(time.Second)
+
+		select {
+		case monitoringData := <-monitor.GetNodeMonitoringChannel():
+			if !monitoringData.NodeData.Timestamp.Equal(time.Unix(i, 0)) {
+				t.Errorf("Wrong monitoring timestamp: %v", monitoringData.NodeData.Timestamp)
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatal("Monitoring data timeout")
+		}
+
+		expectedAlerts := 0
+		if i >= 11 {
+			expectedAlerts = 1
+		}
+
+		if len(alertSender.alerts) != expectedAlerts {
+			t.Fatalf("Wrong alerts count at %d sec: %d", i, len(alertSender.alerts))
+		}
+	}
+
+	alert, ok := alertSender.alerts[0].(cloudprotocol.SystemQuotaAlert)
+	if !ok {
+		t.Fatalf("Wrong alert type: %T", alertSender.alerts[0])
+	}
+
+	if !alert.Timestamp.Equal(time.Unix(11, 0)) || alert.Status != AlertStatusRaise {
+		t.Errorf("Wrong alert: %v", alert)
+	}
+}
+
+func TestAlertProcessorLow(t *testing.T) {
+	type AlertItem struct {
+		time   time.Time
+		value  uint64
+		status string
+	}
+
+	var (
+		sourceValue    uint64
+		receivedAlerts []AlertItem
+	)
+
+	alert := createAlertProcessorCountLow(
+		"Test",
+		&sourceValue,
+		func(time time.Time, value uint64, status string) {
+			receivedAlerts = append(receivedAlerts, AlertItem{time, value, status})
+		},
+		aostypes.AlertRuleCount{
+			MinTimeout:   aostypes.Duration{Duration: 3 * time.Second},
+			MinThreshold: 100,
+			MaxThreshold: 200,
+		})
+
+	values := []uint64{500, 90, 150, 80, 70, 60, 50, 150, 210, 220, 230, 240}
+
+	currentTime := time.Time{}
+
+	expectedAlerts := []AlertItem{
+		{currentTime.Add(6 * time.Second), 50, AlertStatusRaise},
+		{currentTime.Add(9 * time.Second), 220, AlertStatusContinue},
+		{currentTime.Add(11 * time.Second), 240, AlertStatusFall},
+	}
+
+	for _, value := range values {
+		sourceValue = value
+
+		alert.checkAlertDetection(currentTime)
+
+		currentTime = currentTime.Add(time.Second)
+	}
+
+	if !reflect.DeepEqual(receivedAlerts, expectedAlerts) {
+		t.Errorf("Incorrect alerts received: %v, expected: %v", receivedAlerts, expectedAlerts)
+	}
+}
+
+func TestParseProcCounters(t *testing.T) {
+	fileDescriptors, err := parseFileNr("2048\t16\t9223372036854775807\n")
+	if err != nil {
+		t.Fatalf("Can't parse file-nr: %v", err)
+	}
+
+	if fileDescriptors != 2032 {
+		t.Errorf("Wrong file descriptors count: %d", fileDescriptors)
+	}
+
+	if _, err = parseFileNr("2048 16"); err == nil {
+		t.Error("Error expected for invalid file-nr")
+	}
+
+	tasks, err := parseLoadAvgTasks("0.15 0.10 0.05 2/345 12345\n")
+	if err != nil {
+		t.Fatalf("Can't parse loadavg: %v", err)
+	}
+
+	if tasks != 345 {
+		t.Errorf("Wrong tasks count: %d", tasks)
+	}
+
+	if _, err = parseLoadAvgTasks("0.15 0.10 0.05 345 12345"); err == nil {
+		t.Error("Error expected for invalid loadavg")
+	}
+}
+
+func TestProcessCountMonitoring(t *testing.T) {
+	fakeClock := testtools.NewFakeClock(time.Unix(0, 0))
+	alertSender := &testAlertsSender{}
+	nodeInfoProvider := &testNodeInfoProvider{
+		nodeInfo: cloudprotocol.NodeInfo{NodeID: "testNode", NodeType: "testNode", MaxDMIPs: 10000, TotalRAM: 10000},
+	}
+	nodeConfigProvider := &testNodeConfigProvider{
+		nodeConfig: cloudprotocol.NodeConfig{
+			AlertRules: &aostypes.AlertRules{
+				FileDescriptors: &aostypes.AlertRuleCount{MinThreshold: 1000, MaxThreshold: 2000},
+				PIDs:            &aostypes.AlertRuleCount{MinThreshold: 1000, MaxThreshold: 2000},
+				Entropy:         &aostypes.AlertRuleCount{MinThreshold: 128, MaxThreshold: 256},
+			},
+		},
+	}
+	testInstancesUsage := newTestInstancesUsage()
+
+	instanceUsage = testInstancesUsage
+	defer func() {
+		instanceUsage = nil
+	}()
+
+	systemCPUPercent = getSystemCPUPercent
+	systemVirtualMemory = getSystemRAM
+	systemDiskUsage = getSystemDisk
+	systemUsageData = testUsageData{totalRAM: 10000, fileDescriptors: 3000, pids: 500, entropy: 64}
+
+	defer func() {
+		systemUsageData = testUsageData{}
+	}()
+
+	monitor, err := New(Config{
+		PollPeriod:    aostypes.Duration{Duration: time.Second},
+		AverageWindow: aostypes.Duration{Duration: time.Second},
+		Clock:         fakeClock,
+	}, nodeInfoProvider, nodeConfigProvider, &testTrafficMonitoring{}, alertSender)
+	if err != nil {
+		t.Fatalf("Can't create monitoring instance: %s", err)
+	}
+	defer monitor.Close()
+
+	instanceIdent := aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1", Instance: 1}
+
+	testInstancesUsage.instances["instance1"] = testUsageData{fileDescriptors: 50, pids: 20}
+
+	if err = monitor.StartInstanceMonitor("instance1", ResourceMonitorParams{
+		InstanceIdent: instanceIdent,
+		AlertRules: &aostypes.AlertRules{
+			FileDescriptors: &aostypes.AlertRuleCount{MinThreshold: 10, MaxThreshold: 40},
+		},
+	}); err != nil {
+		t.Fatalf("Can't start instance monitoring: %v", err)
+	}
+
+	fakeClock.Advance(time.Second)
+
+	select {
+	case monitoringData := <-monitor.GetNodeMonitoringChannel():
+		if monitoringData.NodeData.FileDescriptors != 3000 || monitoringData.NodeData.PIDs != 500 ||
+			monitoringData.NodeData.Entropy != 64 {
+			t.Errorf("Wrong node monitoring data: %v", monitoringData.NodeData)
+		}
+
+		if len(monitoringData.InstancesData) != 1 || monitoringData.InstancesData[0].FileDescriptors != 50 ||
+			monitoringData.InstancesData[0].PIDs != 20 {
+			t.Errorf("Wrong instances monitoring data: %v", monitoringData.InstancesData)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("Monitoring data timeout")
+	}
+
+	expectedAlerts := []interface{}{
+		prepareSystemAlertItem("testNode", "fileDescriptors", time.Unix(1, 0), 3000, AlertStatusRaise),
+		prepareSystemAlertItem("testNode", "entropy", time.Unix(1, 0), 64, AlertStatusRaise),
+		prepareInstanceAlertItem(instanceIdent, "fileDescriptors", time.Unix(1, 0), 50, AlertStatusRaise),
+	}
+
+	if !AlertSlicesEqual(alertSender.alerts, expectedAlerts) {
+		t.Errorf("Wrong alerts: %v, expected: %v", alertSender.alerts, expectedAlerts)
+	}
+}
+
+func TestNodeInfoChange(t *testing.T) {
+	alertSender := &testAlertsSender{}
+	monitor := &ResourceMonitor{
+		alertSender:           alertSender,
+		averageWindowCount:    1,
+		collectorHealth:       newCollectorHealth(0),
+		instanceMonitoringMap: make(map[string]*instanceMonitoring),
+	}
+
+	alertRule := aostypes.AlertRulePercents{MinThreshold: 80, MaxThreshold: 90}
+
+	if err := monitor.setupNodeMonitoring(cloudprotocol.NodeInfo{
+		NodeID: "testNode", MaxDMIPs: 10000, TotalRAM: 10000,
+		Partitions: []cloudprotocol.PartitionInfo{
+			{Name: cloudprotocol.GenericPartition, Path: ".", TotalSize: 10000},
+			{Name: cloudprotocol.ServicesPartition, Path: ".", TotalSize: 10000},
+		},
+	}); err != nil {
+		t.Fatalf("Can't setup node monitoring: %v", err)
+	}
+
+	if err := monitor.setupSystemAlerts(cloudprotocol.NodeConfig{
+		AlertRules: &aostypes.AlertRules{
+			CPU: &alertRule,
+			Partitions: []aostypes.PartitionAlertRule{
+				{AlertRulePercents: alertRule, Name: cloudprotocol.GenericPartition},
+				{AlertRulePercents: alertRule, Name: cloudprotocol.ServicesPartition},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Can't setup system alerts: %v", err)
+	}
+
+	if err := monitor.StartInstanceMonitor("instance0", ResourceMonitorParams{
+		AlertRules: &aostypes.AlertRules{RAM: &alertRule},
+	}); err != nil {
+		t.Fatalf("Can't start instance monitor: %v", err)
+	}
+
+	monitor.nodeMonitoring.Partitions[0].UsedSize = 5000
+
+	for _, name := range []string{"System CPU", "instance0 RAM"} {
+		getAlertProcessor(t, monitor, name).alertCondition = true
+	}
+
+	if err := monitor.updateNodeInfo(cloudprotocol.NodeInfo{NodeID: "testNode"}); err == nil {
+		t.Error("Error expected for zero max DMIPs")
+	}
+
+	if err := monitor.updateNodeInfo(cloudprotocol.NodeInfo{
+		NodeID: "testNode", MaxDMIPs: 20000, TotalRAM: 20000,
+		Partitions: []cloudprotocol.PartitionInfo{
+			{Name: cloudprotocol.GenericPartition, Path: ".", TotalSize: 20000},
+			{Name: cloudprotocol.StatesPartition, Path: ".", TotalSize: 10000},
+		},
+	}); err == nil {
+		t.Error("Error expected for missing services partition")
+	}
+
+	expectedPartitions := []aostypes.PartitionUsage{
+		{Name: cloudprotocol.GenericPartition, UsedSize: 5000},
+		{Name: cloudprotocol.StatesPartition},
+	}
+
+	if !reflect.DeepEqual(monitor.nodeMonitoring.Partitions, expectedPartitions) {
+		t.Errorf("Wrong node partitions: %v", monitor.nodeMonitoring.Partitions)
+	}
+
+	if _, ok := monitor.nodeAverageData.disks[cloudprotocol.StatesPartition]; !ok {
+		t.Error("States partition average data not found")
+	}
+
+	if _, ok := monitor.nodeAverageData.disks[cloudprotocol.ServicesPartition]; ok {
+		t.Error("Services partition average data should be removed")
+	}
+
+	if monitor.alertProcessors.Len() != 3 {
+		t.Errorf("Wrong alert processors count: %d", monitor.alertProcessors.Len())
+	}
+
+	for _, name := range []string{"System CPU", "instance0 RAM"} {
+		processor := getAlertProcessor(t, monitor, name)
+
+		if processor.maxThreshold != 18000 {
+			t.Errorf("Wrong %s max threshold: %d", name, processor.maxThreshold)
+		}
+
+		if !processor.alertCondition {
+			t.Errorf("%s alert condition should be preserved", name)
+		}
+	}
+
+	processor := getAlertProcessor(t, monitor, "Partition "+cloudprotocol.GenericPartition)
+
+	if processor.source != &monitor.nodeMonitoring.Partitions[0].UsedSize {
+		t.Error("Wrong partition alert source")
+	}
+
+	if processor.alertCondition {
+		t.Error("Unexpected partition alert condition")
+	}
+
+	if len(monitor.instanceMonitoringMap["instance0"].alertProcessorElements) != 1 {
+		t.Error("Wrong instance alert processor elements")
+	}
+}
+
 /***********************************************************************************************************************
  * Interfaces
  **********************************************************************************************************************/
@@ -1228,6 +2043,20 @@ func testUserFSQuotaUsage(path string, uid, gid uint32) (byteUsed uint64, err er
 	return usageData.disk, nil
 }
 
+func getAlertProcessor(t *testing.T, monitor *ResourceMonitor, name string) *alertProcessor {
+	t.Helper()
+
+	for e := monitor.alertProcessors.Front(); e != nil; e = e.Next() {
+		if processor, ok := e.Value.(*alertProcessor); ok && processor.name == name {
+			return processor
+		}
+	}
+
+	t.Fatalf("Alert processor %s not found", name)
+
+	return nil
+}
+
 func newTestInstancesUsage() *testInstancesUsage {
 	return &testInstancesUsage{instances: map[string]testUsageData{}}
 }
@@ -1247,6 +2076,15 @@ func (host *testInstancesUsage) FillSystemInfo(instanceID string, instance *inst
 	return nil
 }
 
+func (host *testInstancesUsage) GetProcessCounts(instanceID string) (fileDescriptors, pids uint64, err error) {
+	data, ok := host.instances[instanceID]
+	if !ok {
+		return 0, 0, aoserrors.Errorf("instance %s not found", instanceID)
+	}
+
+	return data.fileDescriptors, data.pids, nil
+}
+
 func AlertSlicesEqual(alerts1, alerts2 []interface{}) bool {
 	if len(alerts1) != len(alerts2) {
 		return false
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcemonitor
+
+import (
+	"sync"
+
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// TimeSeriesBuilder accumulates node monitoring samples into time-series monitoring message.
+type TimeSeriesBuilder struct {
+	sync.Mutex
+
+	nodes     []cloudprotocol.NodeMonitoringSeries
+	instances []cloudprotocol.InstanceMonitoringSeries
+	samples   int
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewTimeSeriesBuilder creates time-series builder.
+func NewTimeSeriesBuilder() *TimeSeriesBuilder {
+	return &TimeSeriesBuilder{}
+}
+
+// Add adds node monitoring sample.
+func (builder *TimeSeriesBuilder) Add(monitoring aostypes.NodeMonitoring) {
+	builder.Lock()
+	defer builder.Unlock()
+
+	builder.getNodeSeries(monitoring.NodeID).Append(monitoring.NodeData)
+
+	for _, instance := range monitoring.InstancesData {
+		builder.getInstanceSeries(monitoring.NodeID, instance.InstanceIdent).AppendInstance(instance)
+	}
+
+	builder.samples++
+}
+
+// Len returns number of added samples.
+func (builder *TimeSeriesBuilder) Len() int {
+	builder.Lock()
+	defer builder.Unlock()
+
+	return builder.samples
+}
+
+// Build returns time-series monitoring message with all added samples and resets the builder.
+func (builder *TimeSeriesBuilder) Build() cloudprotocol.TimeSeriesMonitoring {
+	builder.Lock()
+	defer builder.Unlock()
+
+	monitoring := cloudprotocol.TimeSeriesMonitoring{
+		MessageType:      cloudprotocol.TimeSeriesMonitoringMessageType,
+		Nodes:            builder.nodes,
+		ServiceInstances: builder.instances,
+	}
+
+	builder.nodes = nil
+	builder.instances = nil
+	builder.samples = 0
+
+	return monitoring
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (builder *TimeSeriesBuilder) getNodeSeries(nodeID string) *cloudprotocol.MonitoringSeries {
+	for i := range builder.nodes {
+		if builder.nodes[i].NodeID == nodeID {
+			return &builder.nodes[i].MonitoringSeries
+		}
+	}
+
+	builder.nodes = append(builder.nodes, cloudprotocol.NodeMonitoringSeries{NodeID: nodeID})
+
+	return &builder.nodes[len(builder.nodes)-1].MonitoringSeries
+}
+
+func (builder *TimeSeriesBuilder) getInstanceSeries(
+	nodeID string, instanceIdent aostypes.InstanceIdent,
+) *cloudprotocol.InstanceMonitoringSeries {
+	for i := range builder.instances {
+		if builder.instances[i].NodeID == nodeID && builder.instances[i].InstanceIdent == instanceIdent {
+			return &builder.instances[i]
+		}
+	}
+
+	builder.instances = append(builder.instances, cloudprotocol.InstanceMonitoringSeries{
+		InstanceIdent: instanceIdent, NodeID: nodeID,
+	})
+
+	return &builder.instances[len(builder.instances)-1]
+}
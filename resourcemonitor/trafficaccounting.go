@@ -0,0 +1,514 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcemonitor
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	log "github.com/sirupsen/logrus"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Traffic accounting backends.
+const (
+	TrafficBackendIPTables = "iptables"
+	TrafficBackendNFTables = "nftables"
+)
+
+const (
+	defaultIPTablesTable  = "mangle"
+	defaultNFTablesTable  = "aos_traffic"
+	defaultTrafficChain   = "AOS_TRAFFIC"
+	systemTrafficComment  = "aos-system"
+	trafficMarkBase       = 0xa0500000
+	nftablesChainPriority = -150
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// TrafficAccountingConfig built-in traffic accounting configuration.
+type TrafficAccountingConfig struct {
+	// Backend accounting backend: iptables or nftables. Default is iptables.
+	Backend string `json:"backend"`
+	// Table table name. Default is mangle for iptables and aos_traffic for nftables.
+	Table string `json:"table"`
+	// Chain accounting chain name prefix. Input and output chains are created with _IN and _OUT suffixes.
+	Chain string `json:"chain"`
+}
+
+// InstanceTrafficAccounting sets up per-instance traffic accounting. It may be optionally implemented by traffic
+// monitoring.
+type InstanceTrafficAccounting interface {
+	StartInstanceAccounting(instanceID string, uid int, classID uint32) error
+	StopInstanceAccounting(instanceID string) error
+}
+
+// TrafficAccounting traffic monitoring based on iptables or nftables counters. Instance traffic is matched by owner UID
+// or by net_cls cgroup class ID for outgoing packets, incoming packets are matched by connection mark. It works only
+// for instances running in host network namespace. Returned values are bytes counted since accounting start.
+type TrafficAccounting struct {
+	sync.Mutex
+
+	backend   trafficBackend
+	instances map[string]instanceTrafficRule
+	nextMark  uint32
+}
+
+type instanceTrafficRule struct {
+	uid     int
+	classID uint32
+	mark    uint32
+}
+
+type trafficBackend interface {
+	setup() error
+	cleanup() error
+	addInstance(instanceID string, rule instanceTrafficRule) error
+	removeInstance(instanceID string, rule instanceTrafficRule) error
+	readCounters(output bool) (counters map[string]uint64, err error)
+}
+
+type iptablesBackend struct {
+	table    string
+	inChain  string
+	outChain string
+}
+
+type nftablesBackend struct {
+	table    string
+	inChain  string
+	outChain string
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// runTrafficCommand global variable is used to be able to mock traffic accounting commands in tests.
+//
+//nolint:gochecknoglobals
+var runTrafficCommand = func(name string, args ...string) (string, error) {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return string(output), aoserrors.Errorf("%s %s: %v: %s", name, strings.Join(args, " "), err,
+			strings.TrimSpace(string(output)))
+	}
+
+	return string(output), nil
+}
+
+//nolint:gochecknoglobals
+var (
+	iptablesCommentRegexp = regexp.MustCompile(`/\* (\S+) \*/`)
+	nftablesBytesRegexp   = regexp.MustCompile(`counter packets \d+ bytes (\d+)`)
+	nftablesCommentRegexp = regexp.MustCompile(`comment "([^"]*)"`)
+	nftablesHandleRegexp  = regexp.MustCompile(`# handle (\d+)`)
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewTrafficAccounting creates traffic accounting and installs accounting chains.
+func NewTrafficAccounting(config TrafficAccountingConfig) (accounting *TrafficAccounting, err error) {
+	chain := config.Chain
+	if chain == "" {
+		chain = defaultTrafficChain
+	}
+
+	accounting = &TrafficAccounting{instances: make(map[string]instanceTrafficRule)}
+
+	switch config.Backend {
+	case "", TrafficBackendIPTables:
+		table := config.Table
+		if table == "" {
+			table = defaultIPTablesTable
+		}
+
+		accounting.backend = &iptablesBackend{table: table, inChain: chain + "_IN", outChain: chain + "_OUT"}
+
+	case TrafficBackendNFTables:
+		table := config.Table
+		if table == "" {
+			table = defaultNFTablesTable
+		}
+
+		accounting.backend = &nftablesBackend{table: table, inChain: chain + "_IN", outChain: chain + "_OUT"}
+
+	default:
+		return nil, aoserrors.Errorf("unsupported traffic accounting backend: %s", config.Backend)
+	}
+
+	log.WithFields(log.Fields{
+		"backend": config.Backend, "table": config.Table, "chain": chain,
+	}).Debug("Create traffic accounting")
+
+	if err = accounting.backend.setup(); err != nil {
+		if cleanupErr := accounting.backend.cleanup(); cleanupErr != nil {
+			log.Errorf("Can't cleanup traffic accounting: %v", cleanupErr)
+		}
+
+		return nil, err
+	}
+
+	return accounting, nil
+}
+
+// Close removes accounting chains.
+func (accounting *TrafficAccounting) Close() error {
+	accounting.Lock()
+	defer accounting.Unlock()
+
+	log.Debug("Close traffic accounting")
+
+	return accounting.backend.cleanup()
+}
+
+// StartInstanceAccounting starts instance traffic accounting. If class ID is not zero, instance is matched by net_cls
+// cgroup class ID, otherwise by UID.
+func (accounting *TrafficAccounting) StartInstanceAccounting(instanceID string, uid int, classID uint32) error {
+	accounting.Lock()
+	defer accounting.Unlock()
+
+	if _, ok := accounting.instances[instanceID]; ok {
+		return nil
+	}
+
+	rule := instanceTrafficRule{uid: uid, classID: classID, mark: trafficMarkBase + accounting.nextMark}
+
+	if err := accounting.backend.addInstance(instanceID, rule); err != nil {
+		return err
+	}
+
+	accounting.nextMark++
+	accounting.instances[instanceID] = rule
+
+	return nil
+}
+
+// StopInstanceAccounting stops instance traffic accounting.
+func (accounting *TrafficAccounting) StopInstanceAccounting(instanceID string) error {
+	accounting.Lock()
+	defer accounting.Unlock()
+
+	rule, ok := accounting.instances[instanceID]
+	if !ok {
+		return nil
+	}
+
+	delete(accounting.instances, instanceID)
+
+	return accounting.backend.removeInstance(instanceID, rule)
+}
+
+// GetSystemTraffic returns system input and output traffic.
+func (accounting *TrafficAccounting) GetSystemTraffic() (inputTraffic, outputTraffic uint64, err error) {
+	return accounting.getTraffic(systemTrafficComment)
+}
+
+// GetInstanceTraffic returns instance input and output traffic.
+func (accounting *TrafficAccounting) GetInstanceTraffic(instanceID string) (inputTraffic, outputTraffic uint64,
+	err error,
+) {
+	accounting.Lock()
+	_, ok := accounting.instances[instanceID]
+	accounting.Unlock()
+
+	if !ok {
+		return 0, 0, aoserrors.Errorf("instance %s traffic is not accounted", instanceID)
+	}
+
+	return accounting.getTraffic(instanceID)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (accounting *TrafficAccounting) getTraffic(comment string) (inputTraffic, outputTraffic uint64, err error) {
+	accounting.Lock()
+	defer accounting.Unlock()
+
+	inCounters, err := accounting.backend.readCounters(false)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	outCounters, err := accounting.backend.readCounters(true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return inCounters[comment], outCounters[comment], nil
+}
+
+/***********************************************************************************************************************
+ * iptables
+ **********************************************************************************************************************/
+
+func (backend *iptablesBackend) run(args ...string) (string, error) {
+	return runTrafficCommand("iptables", append([]string{"-w", "-t", backend.table}, args...)...)
+}
+
+func (backend *iptablesBackend) setup() error {
+	for _, chains := range [][2]string{{"INPUT", backend.inChain}, {"OUTPUT", backend.outChain}} {
+		if _, err := backend.run("-N", chains[1]); err != nil {
+			// Chain may exist after unclean shutdown
+			if _, err := backend.run("-F", chains[1]); err != nil {
+				return err
+			}
+		}
+
+		if _, err := backend.run("-C", chains[0], "-j", chains[1]); err != nil {
+			if _, err := backend.run("-I", chains[0], "-j", chains[1]); err != nil {
+				return err
+			}
+		}
+
+		if _, err := backend.run("-A", chains[1], "-m", "comment", "--comment", systemTrafficComment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (backend *iptablesBackend) cleanup() (err error) {
+	for _, chains := range [][2]string{{"INPUT", backend.inChain}, {"OUTPUT", backend.outChain}} {
+		for _, args := range [][]string{{"-D", chains[0], "-j", chains[1]}, {"-F", chains[1]}, {"-X", chains[1]}} {
+			if _, cmdErr := backend.run(args...); cmdErr != nil && err == nil {
+				err = cmdErr
+			}
+		}
+	}
+
+	return err
+}
+
+func (backend *iptablesBackend) instanceRules(instanceID string, rule instanceTrafficRule) (inRule, outRule []string) {
+	mark := fmt.Sprintf("0x%x", rule.mark)
+
+	outRule = []string{backend.outChain}
+
+	if rule.classID != 0 {
+		outRule = append(outRule, "-m", "cgroup", "--cgroup", strconv.FormatUint(uint64(rule.classID), 10))
+	} else {
+		outRule = append(outRule, "-m", "owner", "--uid-owner", strconv.Itoa(rule.uid))
+	}
+
+	outRule = append(outRule, "-m", "comment", "--comment", instanceID, "-j", "CONNMARK", "--set-mark", mark)
+	inRule = []string{backend.inChain, "-m", "connmark", "--mark", mark, "-m", "comment", "--comment", instanceID}
+
+	return inRule, outRule
+}
+
+func (backend *iptablesBackend) addInstance(instanceID string, rule instanceTrafficRule) error {
+	inRule, outRule := backend.instanceRules(instanceID, rule)
+
+	if _, err := backend.run(append([]string{"-A"}, outRule...)...); err != nil {
+		return err
+	}
+
+	if _, err := backend.run(append([]string{"-A"}, inRule...)...); err != nil {
+		if _, deleteErr := backend.run(append([]string{"-D"}, outRule...)...); deleteErr != nil {
+			log.Errorf("Can't delete instance traffic rule: %v", deleteErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (backend *iptablesBackend) removeInstance(instanceID string, rule instanceTrafficRule) (err error) {
+	inRule, outRule := backend.instanceRules(instanceID, rule)
+
+	for _, ruleArgs := range [][]string{inRule, outRule} {
+		if _, cmdErr := backend.run(append([]string{"-D"}, ruleArgs...)...); cmdErr != nil && err == nil {
+			err = cmdErr
+		}
+	}
+
+	return err
+}
+
+func (backend *iptablesBackend) readCounters(output bool) (counters map[string]uint64, err error) {
+	chain := backend.inChain
+	if output {
+		chain = backend.outChain
+	}
+
+	listing, err := backend.run("-L", chain, "-v", "-x", "-n")
+	if err != nil {
+		return nil, err
+	}
+
+	counters = make(map[string]uint64)
+
+	for _, line := range strings.Split(listing, "\n") {
+		fields := strings.Fields(line)
+
+		matches := iptablesCommentRegexp.FindStringSubmatch(line)
+		if len(fields) < 2 || matches == nil {
+			continue
+		}
+
+		bytes, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, aoserrors.Errorf("wrong iptables counter: %s", line)
+		}
+
+		counters[matches[1]] += bytes
+	}
+
+	return counters, nil
+}
+
+/***********************************************************************************************************************
+ * nftables
+ **********************************************************************************************************************/
+
+func (backend *nftablesBackend) run(args ...string) (string, error) {
+	return runTrafficCommand("nft", args...)
+}
+
+func (backend *nftablesBackend) setup() error {
+	if _, err := backend.run("add", "table", "inet", backend.table); err != nil {
+		return err
+	}
+
+	for _, chains := range [][2]string{{"input", backend.inChain}, {"output", backend.outChain}} {
+		if _, err := backend.run("add", "chain", "inet", backend.table, chains[1], fmt.Sprintf(
+			"{ type filter hook %s priority %d ; }", chains[0], nftablesChainPriority)); err != nil {
+			return err
+		}
+
+		if _, err := backend.run("flush", "chain", "inet", backend.table, chains[1]); err != nil {
+			return err
+		}
+
+		if _, err := backend.run("add", "rule", "inet", backend.table, chains[1], "counter", "comment",
+			strconv.Quote(systemTrafficComment)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (backend *nftablesBackend) cleanup() error {
+	_, err := backend.run("delete", "table", "inet", backend.table)
+
+	return err
+}
+
+func (backend *nftablesBackend) addInstance(instanceID string, rule instanceTrafficRule) error {
+	outRule := []string{"add", "rule", "inet", backend.table, backend.outChain}
+
+	if rule.classID != 0 {
+		outRule = append(outRule, "meta", "cgroup", strconv.FormatUint(uint64(rule.classID), 10))
+	} else {
+		outRule = append(outRule, "meta", "skuid", strconv.Itoa(rule.uid))
+	}
+
+	outRule = append(outRule, "ct", "mark", "set", fmt.Sprintf("0x%x", rule.mark), "counter", "comment",
+		strconv.Quote(instanceID))
+
+	if _, err := backend.run(outRule...); err != nil {
+		return err
+	}
+
+	if _, err := backend.run("add", "rule", "inet", backend.table, backend.inChain, "ct", "mark",
+		fmt.Sprintf("0x%x", rule.mark), "counter", "comment", strconv.Quote(instanceID)); err != nil {
+		if removeErr := backend.removeInstance(instanceID, rule); removeErr != nil {
+			log.Errorf("Can't delete instance traffic rule: %v", removeErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (backend *nftablesBackend) removeInstance(instanceID string, rule instanceTrafficRule) (err error) {
+	for _, chain := range []string{backend.inChain, backend.outChain} {
+		listing, listErr := backend.run("-a", "list", "chain", "inet", backend.table, chain)
+		if listErr != nil {
+			return listErr
+		}
+
+		for _, line := range strings.Split(listing, "\n") {
+			comment := nftablesCommentRegexp.FindStringSubmatch(line)
+			handle := nftablesHandleRegexp.FindStringSubmatch(line)
+
+			if comment == nil || handle == nil || comment[1] != instanceID {
+				continue
+			}
+
+			if _, cmdErr := backend.run("delete", "rule", "inet", backend.table, chain, "handle",
+				handle[1]); cmdErr != nil && err == nil {
+				err = cmdErr
+			}
+		}
+	}
+
+	return err
+}
+
+func (backend *nftablesBackend) readCounters(output bool) (counters map[string]uint64, err error) {
+	chain := backend.inChain
+	if output {
+		chain = backend.outChain
+	}
+
+	listing, err := backend.run("list", "chain", "inet", backend.table, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	counters = make(map[string]uint64)
+
+	for _, line := range strings.Split(listing, "\n") {
+		comment := nftablesCommentRegexp.FindStringSubmatch(line)
+		counter := nftablesBytesRegexp.FindStringSubmatch(line)
+
+		if comment == nil || counter == nil {
+			continue
+		}
+
+		bytes, err := strconv.ParseUint(counter[1], 10, 64)
+		if err != nil {
+			return nil, aoserrors.Errorf("wrong nftables counter: %s", line)
+		}
+
+		counters[comment[1]] += bytes
+	}
+
+	return counters, nil
+}
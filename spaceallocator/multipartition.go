@@ -0,0 +1,423 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaceallocator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/fs"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Placement strategies.
+const (
+	// StrategyFillFirst places items on the first partition in configuration order which has enough space.
+	StrategyFillFirst PlacementStrategy = iota
+	// StrategyMostFree places items on the partition with the most available space.
+	StrategyMostFree
+	// StrategyAffinity places items on partitions assigned to the item type first and overflows onto other
+	// partitions in configuration order.
+	StrategyAffinity
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// PlacementStrategy defines how partition is selected for new item.
+type PlacementStrategy int
+
+// PartitionConfig multi partition allocator partition configuration.
+type PartitionConfig struct {
+	// Path items storage path on the partition.
+	Path string
+	// PartLimit partition limit in percents, 0 - no limit.
+	PartLimit uint
+	// ItemTypes item types preferred on this partition, used by StrategyAffinity.
+	ItemTypes []string
+}
+
+// MultiPartAllocator allocates space for items across multiple partitions.
+type MultiPartAllocator interface {
+	AllocateItemSpace(id, itemType string, size uint64) (ItemSpace, error)
+	ReserveItemSpace(id, itemType string, size uint64, timeout time.Duration) (ItemSpace, error)
+	FreeItemSpace(id string, size uint64) error
+	AddOutdatedItem(id string, size uint64, timestamp time.Time) error
+	RestoreOutdatedItem(id string)
+	ItemPath(id string) (string, error)
+	Close() error
+}
+
+// ItemSpace space allocated for item on one of partitions.
+type ItemSpace interface {
+	Space
+	// Path returns storage path of the partition the space is allocated on.
+	Path() string
+}
+
+type multiPartAllocator struct {
+	sync.Mutex
+
+	strategy   PlacementStrategy
+	remover    ItemRemover
+	partitions []multiPartition
+	items      map[string]*allocatorInstance
+}
+
+type multiPartition struct {
+	PartitionConfig
+
+	allocator *allocatorInstance
+}
+
+type itemSpaceInstance struct {
+	Space
+
+	id        string
+	allocator *allocatorInstance
+	multiPart *multiPartAllocator
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewMultiPart creates space allocator which manages multiple partitions. Items are placed on partitions according
+// to the strategy and overflow onto next partition if selected one has not enough space.
+func NewMultiPart(
+	partitions []PartitionConfig, strategy PlacementStrategy, remover ItemRemover,
+) (_ MultiPartAllocator, err error) {
+	if len(partitions) == 0 {
+		return nil, aoserrors.New("no partitions configured")
+	}
+
+	multiPart := &multiPartAllocator{
+		strategy: strategy,
+		remover:  remover,
+		items:    make(map[string]*allocatorInstance),
+	}
+
+	defer func() {
+		if err != nil {
+			if closeErr := multiPart.Close(); closeErr != nil {
+				log.Errorf("Can't close multi partition allocator: %v", closeErr)
+			}
+		}
+	}()
+
+	for _, config := range partitions {
+		var itemRemover ItemRemover
+
+		if remover != nil {
+			itemRemover = multiPart.removeItem
+		}
+
+		var allocator *allocatorInstance
+
+		if allocator, err = newAllocator(config.Path, config.PartLimit, itemRemover); err != nil {
+			return nil, err
+		}
+
+		multiPart.partitions = append(multiPart.partitions, multiPartition{PartitionConfig: config, allocator: allocator})
+	}
+
+	return multiPart, nil
+}
+
+// Close closes all partition allocators.
+func (multiPart *multiPartAllocator) Close() (err error) {
+	for _, partition := range multiPart.partitions {
+		if closeErr := partition.allocator.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// AllocateItemSpace allocates space for item on partition selected by placement strategy.
+func (multiPart *multiPartAllocator) AllocateItemSpace(id, itemType string, size uint64) (ItemSpace, error) {
+	return multiPart.allocateItemSpace(id, itemType, func(allocator *allocatorInstance) (Space, error) {
+		return allocator.AllocateSpace(size)
+	})
+}
+
+// ReserveItemSpace reserves space for item on partition selected by placement strategy. See ReserveSpace for
+// reservation details.
+func (multiPart *multiPartAllocator) ReserveItemSpace(
+	id, itemType string, size uint64, timeout time.Duration,
+) (ItemSpace, error) {
+	return multiPart.allocateItemSpace(id, itemType, func(allocator *allocatorInstance) (Space, error) {
+		return allocator.ReserveSpace(id, size, timeout)
+	})
+}
+
+// FreeItemSpace frees space of item removed by owner.
+func (multiPart *multiPartAllocator) FreeItemSpace(id string, size uint64) error {
+	allocator, err := multiPart.findItem(id)
+	if err != nil {
+		return err
+	}
+
+	allocator.FreeSpace(size)
+
+	multiPart.Lock()
+	delete(multiPart.items, id)
+	multiPart.Unlock()
+
+	return nil
+}
+
+// AddOutdatedItem adds outdated item to the partition which stores it.
+func (multiPart *multiPartAllocator) AddOutdatedItem(id string, size uint64, timestamp time.Time) error {
+	allocator, err := multiPart.findItem(id)
+	if err != nil {
+		return err
+	}
+
+	return allocator.AddOutdatedItem(id, size, timestamp)
+}
+
+// RestoreOutdatedItem removes item from outdated item list.
+func (multiPart *multiPartAllocator) RestoreOutdatedItem(id string) {
+	allocator, err := multiPart.findItem(id)
+	if err != nil {
+		log.WithField("id", id).Warnf("Can't restore outdated item: %v", err)
+
+		return
+	}
+
+	allocator.RestoreOutdatedItem(id)
+}
+
+// ItemPath returns path of item. If item is not allocated by this allocator instance, e.g. it was stored in previous
+// run, partitions are searched for the item.
+func (multiPart *multiPartAllocator) ItemPath(id string) (string, error) {
+	allocator, err := multiPart.findItem(id)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(allocator.path, id), nil
+}
+
+// Path returns storage path of the partition the space is allocated on.
+func (space *itemSpaceInstance) Path() string {
+	return space.allocator.path
+}
+
+// Release releases item space.
+func (space *itemSpaceInstance) Release() error {
+	space.multiPart.Lock()
+
+	if space.multiPart.items[space.id] == space.allocator {
+		delete(space.multiPart.items, space.id)
+	}
+
+	space.multiPart.Unlock()
+
+	return space.Space.Release()
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (multiPart *multiPartAllocator) allocateItemSpace(
+	id, itemType string, allocate func(allocator *allocatorInstance) (Space, error),
+) (ItemSpace, error) {
+	multiPart.Lock()
+	_, ok := multiPart.items[id]
+	multiPart.Unlock()
+
+	if ok {
+		return nil, aoserrors.Errorf("item %s: %w", id, aoserrors.ErrAlreadyExist)
+	}
+
+	allocators, err := multiPart.getPlacement(itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, allocator := range allocators {
+		space, err := allocate(allocator)
+		if err != nil {
+			if errors.Is(err, ErrNoSpace) {
+				log.WithFields(log.Fields{"id": id, "path": allocator.path}).Debug("No space on partition")
+
+				continue
+			}
+
+			return nil, err
+		}
+
+		log.WithFields(log.Fields{
+			"id": id, "itemType": itemType, "path": allocator.path,
+		}).Debug("Item space allocated")
+
+		multiPart.Lock()
+		multiPart.items[id] = allocator
+		multiPart.Unlock()
+
+		return &itemSpaceInstance{Space: space, id: id, allocator: allocator, multiPart: multiPart}, nil
+	}
+
+	return nil, aoserrors.Wrap(ErrNoSpace)
+}
+
+func (multiPart *multiPartAllocator) getPlacement(itemType string) ([]*allocatorInstance, error) {
+	allocators := make([]*allocatorInstance, 0, len(multiPart.partitions))
+
+	switch multiPart.strategy {
+	case StrategyFillFirst:
+		for _, partition := range multiPart.partitions {
+			allocators = append(allocators, partition.allocator)
+		}
+
+	case StrategyMostFree:
+		availableSizes := make(map[*allocatorInstance]uint64)
+
+		for _, partition := range multiPart.partitions {
+			availableSize, err := partition.allocator.availableSpace()
+			if err != nil {
+				return nil, err
+			}
+
+			availableSizes[partition.allocator] = availableSize
+			allocators = append(allocators, partition.allocator)
+		}
+
+		sort.SliceStable(allocators, func(i, j int) bool {
+			return availableSizes[allocators[i]] > availableSizes[allocators[j]]
+		})
+
+	case StrategyAffinity:
+		var overflow []*allocatorInstance
+
+		for _, partition := range multiPart.partitions {
+			if slices.Contains(partition.ItemTypes, itemType) {
+				allocators = append(allocators, partition.allocator)
+			} else {
+				overflow = append(overflow, partition.allocator)
+			}
+		}
+
+		allocators = append(allocators, overflow...)
+
+	default:
+		return nil, aoserrors.Errorf("unsupported placement strategy: %d", multiPart.strategy)
+	}
+
+	return allocators, nil
+}
+
+func (multiPart *multiPartAllocator) findItem(id string) (*allocatorInstance, error) {
+	multiPart.Lock()
+	defer multiPart.Unlock()
+
+	if allocator, ok := multiPart.items[id]; ok {
+		return allocator, nil
+	}
+
+	for _, partition := range multiPart.partitions {
+		if _, err := os.Stat(filepath.Join(partition.Path, id)); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return nil, aoserrors.Wrap(err)
+		}
+
+		multiPart.items[id] = partition.allocator
+
+		return partition.allocator, nil
+	}
+
+	return nil, aoserrors.Errorf("item %s: %w", id, aoserrors.ErrNotFound)
+}
+
+func (multiPart *multiPartAllocator) removeItem(id string) error {
+	if err := multiPart.remover(id); err != nil {
+		return err
+	}
+
+	multiPart.Lock()
+	delete(multiPart.items, id)
+	multiPart.Unlock()
+
+	return nil
+}
+
+func (allocator *allocatorInstance) availableSpace() (uint64, error) {
+	availableSize, err := allocator.part.availableSpace()
+	if err != nil {
+		return 0, err
+	}
+
+	if allocator.sizeLimit == 0 {
+		return availableSize, nil
+	}
+
+	allocator.Lock()
+	defer allocator.Unlock()
+
+	allocatedSize := allocator.allocatedSize
+
+	if allocator.allocationCount == 0 {
+		dirSize, err := fs.GetDirSize(allocator.path)
+		if err != nil {
+			return 0, aoserrors.Wrap(err)
+		}
+
+		allocatedSize = uint64(dirSize)
+	}
+
+	if allocatedSize >= allocator.sizeLimit {
+		return 0, nil
+	}
+
+	return min(availableSize, allocator.sizeLimit-allocatedSize), nil
+}
+
+func (part *partition) availableSpace() (uint64, error) {
+	part.Lock()
+	defer part.Unlock()
+
+	if part.allocationCount > 0 {
+		return part.availableSize, nil
+	}
+
+	availableSize, err := fs.GetAvailableSize(part.mountPoint)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return uint64(availableSize), nil
+}
@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaceallocator
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const reservationsFileName = ".reservations.json"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type reservationInstance struct {
+	sync.Mutex
+	spaceInstance
+
+	id       string
+	deadline time.Time
+	timer    *time.Timer
+	done     bool
+	expired  bool
+}
+
+type reservationInfo struct {
+	ID       string    `json:"id"`
+	Size     uint64    `json:"size"`
+	Deadline time.Time `json:"deadline"`
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ReserveSpace allocates space for item identified by id. If the space is not accepted or released within timeout,
+// it is released automatically and ItemRemover is called for the item to remove partially stored data.
+// Outstanding reservations are persisted: on the next allocator creation, ItemRemover is called for reservations
+// left from previous run.
+func (allocator *allocatorInstance) ReserveSpace(id string, size uint64, timeout time.Duration) (Space, error) {
+	log.WithFields(log.Fields{
+		"path": allocator.path, "id": id, "size": size, "timeout": timeout,
+	}).Debug("Reserve space")
+
+	allocator.reservationsMutex.Lock()
+	defer allocator.reservationsMutex.Unlock()
+
+	if _, ok := allocator.reservations[id]; ok {
+		return nil, aoserrors.Errorf("reservation %s: %w", id, aoserrors.ErrAlreadyExist)
+	}
+
+	space, err := allocator.AllocateSpace(size)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := &reservationInstance{
+		spaceInstance: *space.(*spaceInstance), //nolint:forcetypeassert // AllocateSpace returns spaceInstance
+		id:            id,
+		deadline:      time.Now().Add(timeout),
+	}
+
+	allocator.reservations[id] = reservation
+
+	if err = allocator.saveReservations(); err != nil {
+		delete(allocator.reservations, id)
+
+		if releaseErr := reservation.spaceInstance.Release(); releaseErr != nil {
+			log.Errorf("Can't release space: %v", releaseErr)
+		}
+
+		return nil, err
+	}
+
+	reservation.timer = time.AfterFunc(timeout, reservation.expire)
+
+	return reservation, nil
+}
+
+// Accept accepts reserved space.
+func (reservation *reservationInstance) Accept() error {
+	if err := reservation.finish(); err != nil {
+		return err
+	}
+
+	return reservation.spaceInstance.Accept()
+}
+
+// Release releases reserved space.
+func (reservation *reservationInstance) Release() error {
+	if err := reservation.finish(); err != nil {
+		return err
+	}
+
+	return reservation.spaceInstance.Release()
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (reservation *reservationInstance) finish() error {
+	reservation.Lock()
+	defer reservation.Unlock()
+
+	if reservation.done {
+		if reservation.expired {
+			return aoserrors.Wrap(ErrExpired)
+		}
+
+		return aoserrors.Wrap(ErrNoAllocation)
+	}
+
+	reservation.done = true
+	reservation.timer.Stop()
+
+	reservation.allocator.removeReservation(reservation.id)
+
+	return nil
+}
+
+func (reservation *reservationInstance) expire() {
+	reservation.Lock()
+
+	if reservation.done {
+		reservation.Unlock()
+
+		return
+	}
+
+	reservation.done = true
+	reservation.expired = true
+
+	reservation.Unlock()
+
+	log.WithFields(log.Fields{
+		"path": reservation.path, "id": reservation.id, "size": reservation.size,
+	}).Warn("Space reservation expired")
+
+	reservation.allocator.removeReservation(reservation.id)
+
+	if err := reservation.spaceInstance.Release(); err != nil {
+		log.Errorf("Can't release expired reservation: %v", err)
+	}
+
+	if reservation.allocator.remover != nil {
+		if err := reservation.allocator.remover(reservation.id); err != nil {
+			log.Errorf("Can't remove expired reservation item: %v", err)
+		}
+	}
+}
+
+func (allocator *allocatorInstance) removeReservation(id string) {
+	allocator.reservationsMutex.Lock()
+	defer allocator.reservationsMutex.Unlock()
+
+	delete(allocator.reservations, id)
+
+	if err := allocator.saveReservations(); err != nil {
+		log.Errorf("Can't save reservations: %v", err)
+	}
+}
+
+func (allocator *allocatorInstance) stopReservations() {
+	allocator.reservationsMutex.Lock()
+	defer allocator.reservationsMutex.Unlock()
+
+	for _, reservation := range allocator.reservations {
+		reservation.timer.Stop()
+	}
+}
+
+// saveReservations atomically writes outstanding reservations. Should be called with reservations mutex locked.
+func (allocator *allocatorInstance) saveReservations() error {
+	fileName := filepath.Join(allocator.path, reservationsFileName)
+
+	if len(allocator.reservations) == 0 {
+		if err := os.Remove(fileName); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return aoserrors.Wrap(err)
+		}
+
+		return nil
+	}
+
+	infos := make([]reservationInfo, 0, len(allocator.reservations))
+
+	for _, reservation := range allocator.reservations {
+		infos = append(infos, reservationInfo{
+			ID: reservation.id, Size: reservation.size, Deadline: reservation.deadline,
+		})
+	}
+
+	data, err := json.Marshal(infos)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	tmpFileName := fileName + ".tmp"
+
+	file, err := os.OpenFile(tmpFileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if _, err = file.Write(data); err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return aoserrors.Wrap(os.Rename(tmpFileName, fileName))
+}
+
+// cleanupStaleReservations calls item remover for reservations left from previous run.
+func (allocator *allocatorInstance) cleanupStaleReservations() {
+	fileName := filepath.Join(allocator.path, reservationsFileName)
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Errorf("Can't read reservations: %v", err)
+		}
+
+		return
+	}
+
+	var infos []reservationInfo
+
+	if err = json.Unmarshal(data, &infos); err != nil {
+		log.Errorf("Can't parse reservations: %v", err)
+	}
+
+	for _, info := range infos {
+		log.WithFields(log.Fields{
+			"path": allocator.path, "id": info.ID, "size": info.Size,
+		}).Warn("Remove stale space reservation")
+
+		if allocator.remover == nil {
+			continue
+		}
+
+		if err = allocator.remover(info.ID); err != nil {
+			log.Errorf("Can't remove stale reservation item: %v", err)
+		}
+	}
+
+	if err = os.Remove(fileName); err != nil {
+		log.Errorf("Can't remove reservations: %v", err)
+	}
+}
@@ -36,6 +36,7 @@ import (
 // Allocator space allocator interface.
 type Allocator interface {
 	AllocateSpace(size uint64) (Space, error)
+	ReserveSpace(id string, size uint64, timeout time.Duration) (Space, error)
 	FreeSpace(size uint64)
 	AddOutdatedItem(id string, size uint64, timestamp time.Time) error
 	RestoreOutdatedItem(id string)
@@ -61,6 +62,9 @@ type allocatorInstance struct {
 	sizeLimit       uint64
 	allocationCount uint
 	allocatedSize   uint64
+
+	reservationsMutex sync.Mutex
+	reservations      map[string]*reservationInstance
 }
 
 type spaceInstance struct {
@@ -103,6 +107,7 @@ var (
 var (
 	ErrNoAllocation = errors.New("no allocation in progress")
 	ErrNoSpace      = errors.New("not enough space")
+	ErrExpired      = errors.New("reservation expired")
 )
 
 /***********************************************************************************************************************
@@ -111,46 +116,11 @@ var (
 
 // New creates new space allocator.
 func New(path string, partLimit uint, remover ItemRemover) (Allocator, error) {
-	partsMutex.Lock()
-	defer partsMutex.Unlock()
-
-	mountPoint, err := fs.GetMountPoint(path)
+	allocator, err := newAllocator(path, partLimit, remover)
 	if err != nil {
-		return nil, aoserrors.Wrap(err)
-	}
-
-	log.WithFields(log.Fields{"path": path, "partLimit": partLimit, "mountPoint": mountPoint}).Debug("Create allocator")
-
-	part, ok := partsMap[mountPoint]
-	if !ok {
-		if partsMap == nil {
-			partsMap = make(map[string]*partition)
-		}
-
-		if part, err = newPart(mountPoint); err != nil {
-			return nil, err
-		}
-
-		partsMap[mountPoint] = part
-	}
-
-	if err := part.addPartLimit(partLimit); err != nil {
 		return nil, err
 	}
 
-	part.allocatorCount++
-
-	allocator := &allocatorInstance{
-		path:      path,
-		partLimit: partLimit,
-		part:      part,
-		remover:   remover,
-	}
-
-	if partLimit != 0 {
-		allocator.sizeLimit = part.totalSize * uint64(partLimit) / 100
-	}
-
 	return allocator, nil
 }
 
@@ -161,6 +131,8 @@ func (allocator *allocatorInstance) Close() (err error) {
 
 	log.WithFields(log.Fields{"path": allocator.path}).Debug("Close allocator")
 
+	allocator.stopReservations()
+
 	if partLimitErr := allocator.part.removePartLimit(allocator.partLimit); partLimitErr != nil {
 		err = partLimitErr
 	}
@@ -185,6 +157,12 @@ func (allocator *allocatorInstance) AllocateSpace(size uint64) (Space, error) {
 	}
 
 	if err := allocator.part.allocateSpace(size); err != nil {
+		allocator.freeSpace(size)
+
+		if doneErr := allocator.allocateDone(); doneErr != nil {
+			log.Errorf("Can't finish allocation: %v", doneErr)
+		}
+
 		return nil, err
 	}
 
@@ -402,6 +380,53 @@ func (allocator *allocatorInstance) removeOutdatedItems(requiredSize uint64) (fr
 	return freedSize, nil
 }
 
+func newAllocator(path string, partLimit uint, remover ItemRemover) (*allocatorInstance, error) {
+	partsMutex.Lock()
+	defer partsMutex.Unlock()
+
+	mountPoint, err := fs.GetMountPoint(path)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	log.WithFields(log.Fields{"path": path, "partLimit": partLimit, "mountPoint": mountPoint}).Debug("Create allocator")
+
+	part, ok := partsMap[mountPoint]
+	if !ok {
+		if partsMap == nil {
+			partsMap = make(map[string]*partition)
+		}
+
+		if part, err = newPart(mountPoint); err != nil {
+			return nil, err
+		}
+
+		partsMap[mountPoint] = part
+	}
+
+	if err := part.addPartLimit(partLimit); err != nil {
+		return nil, err
+	}
+
+	part.allocatorCount++
+
+	allocator := &allocatorInstance{
+		path:         path,
+		partLimit:    partLimit,
+		part:         part,
+		remover:      remover,
+		reservations: make(map[string]*reservationInstance),
+	}
+
+	allocator.cleanupStaleReservations()
+
+	if partLimit != 0 {
+		allocator.sizeLimit = part.totalSize * uint64(partLimit) / 100
+	}
+
+	return allocator, nil
+}
+
 func newPart(mountPoint string) (*partition, error) {
 	totalSize, err := fs.GetTotalSize(mountPoint)
 	if err != nil {
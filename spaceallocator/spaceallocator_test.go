@@ -30,6 +30,7 @@ import (
 
 	"github.com/aosedge/aos_common/aoserrors"
 	"github.com/aosedge/aos_common/spaceallocator"
+	"github.com/aosedge/aos_common/utils/fs"
 	"github.com/aosedge/aos_common/utils/testtools"
 	log "github.com/sirupsen/logrus"
 )
@@ -443,6 +444,246 @@ func TestPartLimit(t *testing.T) {
 	}
 }
 
+func TestReservation(t *testing.T) {
+	path := filepath.Join(tmpDir, "reservation")
+
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatalf("Can't create dir: %v", err)
+	}
+
+	removedItems := make(chan string, 1)
+	remover := func(id string) error {
+		removedItems <- id
+
+		return nil
+	}
+
+	allocator, err := spaceallocator.New(path, 0, remover)
+	if err != nil {
+		t.Fatalf("Can't create allocator: %v", err)
+	}
+
+	// Expired reservation
+
+	expiredSpace, err := allocator.ReserveSpace("item1", kilobyte, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Can't reserve space: %v", err)
+	}
+
+	select {
+	case id := <-removedItems:
+		if id != "item1" {
+			t.Errorf("Wrong removed item: %s", id)
+		}
+
+	case <-time.After(time.Second):
+		t.Error("Wait expired reservation timeout")
+	}
+
+	if err = expiredSpace.Accept(); !errors.Is(err, spaceallocator.ErrExpired) {
+		t.Errorf("Expired error expected: %v", err)
+	}
+
+	// Accepted reservation
+
+	space, err := allocator.ReserveSpace("item2", kilobyte, time.Hour)
+	if err != nil {
+		t.Fatalf("Can't reserve space: %v", err)
+	}
+
+	if _, err = allocator.ReserveSpace("item2", kilobyte, time.Hour); err == nil {
+		t.Error("Duplicated reservation should fail")
+	}
+
+	if err = space.Accept(); err != nil {
+		t.Errorf("Can't accept space: %v", err)
+	}
+
+	if err = space.Release(); !errors.Is(err, spaceallocator.ErrNoAllocation) {
+		t.Errorf("No allocation error expected: %v", err)
+	}
+
+	// Stale reservation
+
+	if _, err = allocator.ReserveSpace("item3", kilobyte, time.Hour); err != nil {
+		t.Fatalf("Can't reserve space: %v", err)
+	}
+
+	if err = allocator.Close(); err != nil {
+		t.Errorf("Can't close allocator: %v", err)
+	}
+
+	if allocator, err = spaceallocator.New(path, 0, remover); err != nil {
+		t.Fatalf("Can't create allocator: %v", err)
+	}
+	defer allocator.Close()
+
+	select {
+	case id := <-removedItems:
+		if id != "item3" {
+			t.Errorf("Wrong removed item: %s", id)
+		}
+
+	default:
+		t.Error("Stale reservation should be removed")
+	}
+}
+
+func TestMultiPartition(t *testing.T) {
+	mountPoint1 := filepath.Join(tmpDir, "part1")
+	mountPoint2 := filepath.Join(tmpDir, "part2")
+
+	disk1, err := newTestDisk(mountPoint1, 1, nil)
+	if err != nil {
+		t.Fatalf("Can't create test disk: %v", err)
+	}
+
+	defer disk1.close()
+
+	disk2, err := newTestDisk(mountPoint2, 1, func(mountPoint string) error {
+		return aoserrors.Wrap(os.WriteFile(filepath.Join(mountPoint, "stored"), make([]byte, 32*kilobyte), 0o600))
+	})
+	if err != nil {
+		t.Fatalf("Can't create test disk: %v", err)
+	}
+
+	defer disk2.close()
+
+	removedItems := make([]string, 0)
+	remover := func(id string) error {
+		removedItems = append(removedItems, id)
+
+		return aoserrors.Wrap(os.RemoveAll(filepath.Join(mountPoint2, id)))
+	}
+
+	partitions := []spaceallocator.PartitionConfig{
+		{Path: mountPoint1},
+		{Path: mountPoint2, ItemTypes: []string{"layer"}},
+	}
+
+	// Fill first: item overflows onto second partition
+
+	allocator, err := spaceallocator.NewMultiPart(partitions, spaceallocator.StrategyFillFirst, remover)
+	if err != nil {
+		t.Fatalf("Can't create allocator: %v", err)
+	}
+
+	space1, err := allocator.AllocateItemSpace("item1", "service", 512*kilobyte)
+	if err != nil {
+		t.Fatalf("Can't allocate space: %v", err)
+	}
+
+	if space1.Path() != mountPoint1 {
+		t.Errorf("Wrong item partition: %s", space1.Path())
+	}
+
+	space2, err := allocator.AllocateItemSpace("item2", "service", 512*kilobyte)
+	if err != nil {
+		t.Fatalf("Can't allocate space: %v", err)
+	}
+
+	if space2.Path() != mountPoint2 {
+		t.Errorf("Wrong item partition: %s", space2.Path())
+	}
+
+	if _, err = allocator.AllocateItemSpace("item2", "service", kilobyte); !errors.Is(err, aoserrors.ErrAlreadyExist) {
+		t.Errorf("Already exist error expected: %v", err)
+	}
+
+	if _, err = allocator.AllocateItemSpace("item3", "service", 512*kilobyte); !errors.Is(
+		err, spaceallocator.ErrNoSpace) {
+		t.Errorf("Wrong allocator error: %v", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(mountPoint1, "item1"), make([]byte, 512*kilobyte), 0o600); err != nil {
+		t.Fatalf("Can't write item: %v", err)
+	}
+
+	if err = space1.Accept(); err != nil {
+		t.Errorf("Can't accept space: %v", err)
+	}
+
+	if err = space2.Release(); err != nil {
+		t.Errorf("Can't release space: %v", err)
+	}
+
+	if path, err := allocator.ItemPath("item1"); err != nil || path != filepath.Join(mountPoint1, "item1") {
+		t.Errorf("Wrong item path: %s, err: %v", path, err)
+	}
+
+	if _, err = allocator.ItemPath("item2"); !errors.Is(err, aoserrors.ErrNotFound) {
+		t.Errorf("Not found error expected: %v", err)
+	}
+
+	// Transparent lookup of item stored in previous run
+
+	if path, err := allocator.ItemPath("stored"); err != nil || path != filepath.Join(mountPoint2, "stored") {
+		t.Errorf("Wrong item path: %s, err: %v", path, err)
+	}
+
+	if err = allocator.Close(); err != nil {
+		t.Errorf("Can't close allocator: %v", err)
+	}
+
+	// Most free: first partition has accepted item1, so second one is selected
+
+	if allocator, err = spaceallocator.NewMultiPart(
+		partitions, spaceallocator.StrategyMostFree, remover); err != nil {
+		t.Fatalf("Can't create allocator: %v", err)
+	}
+
+	space, err := allocator.AllocateItemSpace("item4", "service", 128*kilobyte)
+	if err != nil {
+		t.Fatalf("Can't allocate space: %v", err)
+	}
+
+	if space.Path() != mountPoint2 {
+		t.Errorf("Wrong item partition: %s", space.Path())
+	}
+
+	if err = space.Release(); err != nil {
+		t.Errorf("Can't release space: %v", err)
+	}
+
+	if err = allocator.Close(); err != nil {
+		t.Errorf("Can't close allocator: %v", err)
+	}
+
+	// Affinity: layer is placed on second partition, outdated item is removed to free space
+
+	if allocator, err = spaceallocator.NewMultiPart(
+		partitions, spaceallocator.StrategyAffinity, remover); err != nil {
+		t.Fatalf("Can't create allocator: %v", err)
+	}
+	defer allocator.Close()
+
+	if err = allocator.AddOutdatedItem("stored", 32*kilobyte, time.Now()); err != nil {
+		t.Fatalf("Can't add outdated item: %v", err)
+	}
+
+	availableSize, err := fs.GetAvailableSize(mountPoint2)
+	if err != nil {
+		t.Fatalf("Can't get available size: %v", err)
+	}
+
+	space, err = allocator.AllocateItemSpace("layer1", "layer", uint64(availableSize)+16*kilobyte)
+	if err != nil {
+		t.Fatalf("Can't allocate space: %v", err)
+	}
+
+	if space.Path() != mountPoint2 {
+		t.Errorf("Wrong item partition: %s", space.Path())
+	}
+
+	if !reflect.DeepEqual(removedItems, []string{"stored"}) {
+		t.Errorf("Wrong removed items: %v", removedItems)
+	}
+
+	if err = space.Accept(); err != nil {
+		t.Errorf("Can't accept space: %v", err)
+	}
+}
+
 /***********************************************************************************************************************
  * Private
  **********************************************************************************************************************/
@@ -19,6 +19,7 @@ package tpmkey
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"io"
 
 	"github.com/google/go-tpm/legacy/tpm2"
@@ -52,3 +53,10 @@ func (key *eccKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) (sig
 
 	return signature, aoserrors.Wrap(err)
 }
+
+// ECDH computes shared secret with peer public key.
+func (key *eccKey) ECDH(peerPublicKey *ecdsa.PublicKey) (sharedSecret []byte, err error) {
+	sharedSecret, err = ecdhZGen(key.tpmKey, peerPublicKey)
+
+	return sharedSecret, aoserrors.Wrap(err)
+}
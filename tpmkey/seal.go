@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tpmkey
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	sizeOfPCRSelect = 3
+	nonceSize       = 16
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// SealedData data sealed by TPM and bound to PCR policy.
+type SealedData struct {
+	PrivateBlob  []byte            `json:"privateBlob"`
+	PublicBlob   []byte            `json:"publicBlob"`
+	PCRSelection tpm2.PCRSelection `json:"pcrSelection"`
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Seal seals data under parent key bound to current values of selected PCRs.
+func Seal(
+	device io.ReadWriter, parentHandle tpmutil.Handle, parentPassword string, pcrSelection tpm2.PCRSelection,
+	data []byte,
+) (sealed SealedData, err error) {
+	pcrValues, err := tpm2.ReadPCRs(device, pcrSelection)
+	if err != nil {
+		return sealed, aoserrors.Wrap(err)
+	}
+
+	return SealWithPCRValues(device, parentHandle, parentPassword, pcrSelection, pcrValues, data)
+}
+
+// SealWithPCRValues seals data under parent key bound to expected PCR values. It is used to seal data for the
+// platform state expected after firmware update.
+func SealWithPCRValues(
+	device io.ReadWriter, parentHandle tpmutil.Handle, parentPassword string, pcrSelection tpm2.PCRSelection,
+	pcrValues map[int][]byte, data []byte,
+) (sealed SealedData, err error) {
+	policy, err := ComputePCRPolicy(pcrSelection, pcrValues)
+	if err != nil {
+		return sealed, err
+	}
+
+	privateBlob, publicBlob, err := tpm2.Seal(device, parentHandle, parentPassword, "", policy, data)
+	if err != nil {
+		return sealed, aoserrors.Wrap(err)
+	}
+
+	return SealedData{PrivateBlob: privateBlob, PublicBlob: publicBlob, PCRSelection: pcrSelection}, nil
+}
+
+// Unseal unseals data if current PCR values satisfy sealing policy.
+func Unseal(
+	device io.ReadWriter, parentHandle tpmutil.Handle, parentPassword string, sealed SealedData,
+) (data []byte, err error) {
+	objectHandle, _, err := tpm2.Load(device, parentHandle, parentPassword, sealed.PublicBlob, sealed.PrivateBlob)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if flushErr := tpm2.FlushContext(device, objectHandle); flushErr != nil {
+			if err == nil {
+				err = aoserrors.Wrap(flushErr)
+			}
+		}
+	}()
+
+	session, _, err := tpm2.StartAuthSession(device, tpm2.HandleNull, tpm2.HandleNull, make([]byte, nonceSize),
+		nil, tpm2.SessionPolicy, tpm2.AlgNull, tpm2.AlgSHA256)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if flushErr := tpm2.FlushContext(device, session); flushErr != nil {
+			if err == nil {
+				err = aoserrors.Wrap(flushErr)
+			}
+		}
+	}()
+
+	if err = tpm2.PolicyPCR(device, session, nil, sealed.PCRSelection); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if data, err = tpm2.UnsealWithSession(device, session, objectHandle, ""); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return data, nil
+}
+
+// Reseal unseals data with current platform state and seals it again bound to new PCR values. It should be called
+// before firmware update with PCR values predicted for the updated firmware. PCRs absent in newPCRValues keep
+// their current values.
+func Reseal(
+	device io.ReadWriter, parentHandle tpmutil.Handle, parentPassword string, sealed SealedData,
+	newPCRValues map[int][]byte,
+) (resealed SealedData, err error) {
+	data, err := Unseal(device, parentHandle, parentPassword, sealed)
+	if err != nil {
+		return resealed, err
+	}
+
+	pcrValues, err := tpm2.ReadPCRs(device, sealed.PCRSelection)
+	if err != nil {
+		return resealed, aoserrors.Wrap(err)
+	}
+
+	for pcr, value := range newPCRValues {
+		if _, ok := pcrValues[pcr]; !ok {
+			return resealed, aoserrors.Errorf("PCR %d is not in sealing selection", pcr)
+		}
+
+		pcrValues[pcr] = value
+	}
+
+	return SealWithPCRValues(device, parentHandle, parentPassword, sealed.PCRSelection, pcrValues, data)
+}
+
+// ComputePCRPolicy computes SHA256 PolicyPCR authorization digest for expected PCR values.
+func ComputePCRPolicy(pcrSelection tpm2.PCRSelection, pcrValues map[int][]byte) (policy []byte, err error) {
+	pcrHash, err := pcrSelection.Hash.Hash()
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if len(pcrSelection.PCRs) == 0 {
+		return nil, aoserrors.New("PCR selection is empty")
+	}
+
+	pcrs := append([]int(nil), pcrSelection.PCRs...)
+	sort.Ints(pcrs)
+
+	selectBitmap := make([]byte, sizeOfPCRSelect)
+	pcrDigest := sha256.New()
+
+	for _, pcr := range pcrs {
+		if pcr < 0 || pcr >= 8*sizeOfPCRSelect {
+			return nil, aoserrors.Errorf("PCR index %d is out of range", pcr)
+		}
+
+		value, ok := pcrValues[pcr]
+		if !ok {
+			return nil, aoserrors.Errorf("PCR %d value is not set", pcr)
+		}
+
+		if len(value) != pcrHash.Size() {
+			return nil, aoserrors.Errorf("wrong PCR %d value size: %d", pcr, len(value))
+		}
+
+		selectBitmap[pcr/8] |= 1 << (pcr % 8)
+
+		pcrDigest.Write(value)
+	}
+
+	// TPML_PCR_SELECTION with single TPMS_PCR_SELECTION
+	var selection bytes.Buffer
+
+	_ = binary.Write(&selection, binary.BigEndian, uint32(1))
+	_ = binary.Write(&selection, binary.BigEndian, uint16(pcrSelection.Hash))
+	selection.WriteByte(sizeOfPCRSelect)
+	selection.Write(selectBitmap)
+
+	// policyDigest = H(policyDigestOld || TPM_CC_PolicyPCR || pcrs || pcrDigest)
+	policyDigest := sha256.New()
+
+	policyDigest.Write(make([]byte, policyDigest.Size()))
+	_ = binary.Write(policyDigest, binary.BigEndian, uint32(tpm2.CmdPolicyPCR))
+	policyDigest.Write(selection.Bytes())
+	policyDigest.Write(pcrDigest.Sum(nil))
+
+	return policyDigest.Sum(nil), nil
+}
+
+// PredictPCRValue predicts PCR value after extending it with measurements.
+func PredictPCRValue(pcrHash tpm2.Algorithm, pcrValue []byte, measurements ...[]byte) ([]byte, error) {
+	hash, err := pcrHash.Hash()
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	value := append([]byte(nil), pcrValue...)
+
+	for _, measurement := range measurements {
+		extend := hash.New()
+
+		extend.Write(value)
+		extend.Write(measurement)
+
+		value = extend.Sum(nil)
+	}
+
+	return value, nil
+}
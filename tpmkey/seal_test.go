@@ -0,0 +1,322 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tpmkey_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+
+	"github.com/aosedge/aos_common/tpmkey"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// tpmDeviceEnv sets TPM device or TPM simulator socket (e.g. swtpm with unixio server) used by tests. TPM tests are
+// skipped if it is not set.
+const tpmDeviceEnv = "AOS_TEST_TPM_DEVICE"
+
+// debugPCR PCR resettable from locality 0 used in tests.
+const debugPCR = 16
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestPredictPCRValue(t *testing.T) {
+	abc := sha256.Sum256([]byte("abc"))
+	def := sha256.Sum256([]byte("def"))
+
+	testData := []struct {
+		hash          tpm2.Algorithm
+		pcrValue      []byte
+		measurements  [][]byte
+		expectedValue string
+	}{
+		{
+			hash:          tpm2.AlgSHA256,
+			pcrValue:      make([]byte, sha256.Size),
+			expectedValue: "0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			hash:          tpm2.AlgSHA256,
+			pcrValue:      make([]byte, sha256.Size),
+			measurements:  [][]byte{abc[:]},
+			expectedValue: "589f9ffed4c477966bfb8d41f37895b08c69047df8f911d6f3b57fbe08faee8d",
+		},
+		{
+			hash:          tpm2.AlgSHA256,
+			pcrValue:      make([]byte, sha256.Size),
+			measurements:  [][]byte{abc[:], def[:]},
+			expectedValue: "f191db04b526f1e7a178d5da326687c0b27b531fbabde4f555ca7fdd6a239964",
+		},
+		{
+			hash:          tpm2.AlgSHA1,
+			pcrValue:      make([]byte, 20),
+			measurements:  [][]byte{mustDecodeHex(t, "a9993e364706816aba3e25717850c26c9cd0d89d")},
+			expectedValue: "ccd5bd41458de644ac34a2478b58ff819bef5acf",
+		},
+	}
+
+	for _, data := range testData {
+		value, err := tpmkey.PredictPCRValue(data.hash, data.pcrValue, data.measurements...)
+		if err != nil {
+			t.Fatalf("Can't predict PCR value: %v", err)
+		}
+
+		if hex.EncodeToString(value) != data.expectedValue {
+			t.Errorf("Wrong PCR value: %x", value)
+		}
+	}
+
+	if _, err := tpmkey.PredictPCRValue(tpm2.AlgNull, nil); err == nil {
+		t.Error("Error expected for unsupported hash")
+	}
+}
+
+func TestComputePCRPolicy(t *testing.T) {
+	zero := make([]byte, sha256.Size)
+	pcr7 := mustDecodeHex(t, "589f9ffed4c477966bfb8d41f37895b08c69047df8f911d6f3b57fbe08faee8d")
+
+	testData := []struct {
+		pcrs           []int
+		pcrValues      map[int][]byte
+		expectedPolicy string
+		expectError    bool
+	}{
+		{
+			pcrs:           []int{debugPCR},
+			pcrValues:      map[int][]byte{debugPCR: zero},
+			expectedPolicy: "bff2d58e9813f97cefc14f72ad8133bc7092d652b7c877959254af140c841f36",
+		},
+		{
+			pcrs:           []int{0, 7},
+			pcrValues:      map[int][]byte{0: zero, 7: pcr7},
+			expectedPolicy: "151179cc536e02a8ef2643d079485422180145ed142e40387b0bac313d685a90",
+		},
+		{
+			pcrs:           []int{7, 0},
+			pcrValues:      map[int][]byte{0: zero, 7: pcr7},
+			expectedPolicy: "151179cc536e02a8ef2643d079485422180145ed142e40387b0bac313d685a90",
+		},
+		{pcrs: []int{}, pcrValues: map[int][]byte{}, expectError: true},
+		{pcrs: []int{0, 7}, pcrValues: map[int][]byte{0: zero}, expectError: true},
+		{pcrs: []int{0}, pcrValues: map[int][]byte{0: zero[:20]}, expectError: true},
+		{pcrs: []int{24}, pcrValues: map[int][]byte{24: zero}, expectError: true},
+	}
+
+	for _, data := range testData {
+		policy, err := tpmkey.ComputePCRPolicy(
+			tpm2.PCRSelection{Hash: tpm2.AlgSHA256, PCRs: data.pcrs}, data.pcrValues)
+		if data.expectError {
+			if err == nil {
+				t.Errorf("Error expected for PCRs %v", data.pcrs)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Can't compute PCR policy: %v", err)
+		}
+
+		if hex.EncodeToString(policy) != data.expectedPolicy {
+			t.Errorf("Wrong PCR policy: %x", policy)
+		}
+	}
+}
+
+func TestComputePCRPolicyMatchesTPM(t *testing.T) {
+	device := openTestTPM(t)
+
+	pcrSelection := tpm2.PCRSelection{Hash: tpm2.AlgSHA256, PCRs: []int{0, 7, debugPCR}}
+
+	pcrValues, err := tpm2.ReadPCRs(device, pcrSelection)
+	if err != nil {
+		t.Fatalf("Can't read PCRs: %v", err)
+	}
+
+	expectedPolicy, err := tpmkey.ComputePCRPolicy(pcrSelection, pcrValues)
+	if err != nil {
+		t.Fatalf("Can't compute PCR policy: %v", err)
+	}
+
+	session, _, err := tpm2.StartAuthSession(device, tpm2.HandleNull, tpm2.HandleNull, make([]byte, 16),
+		nil, tpm2.SessionTrial, tpm2.AlgNull, tpm2.AlgSHA256)
+	if err != nil {
+		t.Fatalf("Can't start trial session: %v", err)
+	}
+	defer flushContext(t, device, session)
+
+	if err = tpm2.PolicyPCR(device, session, nil, pcrSelection); err != nil {
+		t.Fatalf("Can't apply PCR policy: %v", err)
+	}
+
+	policy, err := tpm2.PolicyGetDigest(device, session)
+	if err != nil {
+		t.Fatalf("Can't get policy digest: %v", err)
+	}
+
+	if !bytes.Equal(policy, expectedPolicy) {
+		t.Errorf("Wrong PCR policy: %x, expected: %x", policy, expectedPolicy)
+	}
+}
+
+func TestSealUnseal(t *testing.T) {
+	device := openTestTPM(t)
+
+	if err := tpm2.PCRReset(device, tpmutil.Handle(debugPCR)); err != nil {
+		t.Fatalf("Can't reset PCR: %v", err)
+	}
+
+	parentHandle := createTestPrimary(t, device)
+	pcrSelection := tpm2.PCRSelection{Hash: tpm2.AlgSHA256, PCRs: []int{debugPCR}}
+	secret := []byte("secret data")
+
+	sealed, err := tpmkey.Seal(device, parentHandle, "", pcrSelection, secret)
+	if err != nil {
+		t.Fatalf("Can't seal data: %v", err)
+	}
+
+	data, err := tpmkey.Unseal(device, parentHandle, "", sealed)
+	if err != nil {
+		t.Fatalf("Can't unseal data: %v", err)
+	}
+
+	if !bytes.Equal(data, secret) {
+		t.Errorf("Wrong unsealed data: %s", data)
+	}
+
+	// Reseal to the state expected after measuring new firmware
+
+	measurement := sha256.Sum256([]byte("new firmware"))
+
+	pcrValues, err := tpm2.ReadPCRs(device, pcrSelection)
+	if err != nil {
+		t.Fatalf("Can't read PCRs: %v", err)
+	}
+
+	predictedValue, err := tpmkey.PredictPCRValue(tpm2.AlgSHA256, pcrValues[debugPCR], measurement[:])
+	if err != nil {
+		t.Fatalf("Can't predict PCR value: %v", err)
+	}
+
+	resealed, err := tpmkey.Reseal(device, parentHandle, "", sealed, map[int][]byte{debugPCR: predictedValue})
+	if err != nil {
+		t.Fatalf("Can't reseal data: %v", err)
+	}
+
+	if _, err = tpmkey.Unseal(device, parentHandle, "", resealed); err == nil {
+		t.Error("Resealed data should not be unsealed before PCR extend")
+	}
+
+	if err = tpm2.PCRExtend(device, tpmutil.Handle(debugPCR), tpm2.AlgSHA256, measurement[:], ""); err != nil {
+		t.Fatalf("Can't extend PCR: %v", err)
+	}
+
+	if _, err = tpmkey.Unseal(device, parentHandle, "", sealed); err == nil {
+		t.Error("Sealed data should not be unsealed after PCR extend")
+	}
+
+	if data, err = tpmkey.Unseal(device, parentHandle, "", resealed); err != nil {
+		t.Fatalf("Can't unseal resealed data: %v", err)
+	}
+
+	if !bytes.Equal(data, secret) {
+		t.Errorf("Wrong unsealed data: %s", data)
+	}
+
+	if _, err = tpmkey.Reseal(device, parentHandle, "", resealed, map[int][]byte{0: predictedValue}); err == nil {
+		t.Error("Error expected for PCR not in sealing selection")
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func openTestTPM(t *testing.T) io.ReadWriter {
+	t.Helper()
+
+	path := os.Getenv(tpmDeviceEnv)
+	if path == "" {
+		t.Skipf("TPM device is not set: %s", tpmDeviceEnv)
+	}
+
+	device, err := tpm2.OpenTPM(path)
+	if err != nil {
+		t.Fatalf("Can't open TPM: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := device.Close(); err != nil {
+			t.Errorf("Can't close TPM: %v", err)
+		}
+	})
+
+	return device
+}
+
+func createTestPrimary(t *testing.T, device io.ReadWriter) tpmutil.Handle {
+	t.Helper()
+
+	primaryHandle, _, err := tpm2.CreatePrimary(device, tpm2.HandleOwner, tpm2.PCRSelection{}, "", "", tpm2.Public{
+		Type:    tpm2.AlgRSA,
+		NameAlg: tpm2.AlgSHA256,
+		Attributes: tpm2.FlagFixedTPM | tpm2.FlagFixedParent | tpm2.FlagSensitiveDataOrigin |
+			tpm2.FlagUserWithAuth | tpm2.FlagRestricted | tpm2.FlagDecrypt,
+		RSAParameters: &tpm2.RSAParams{
+			Symmetric: &tpm2.SymScheme{Alg: tpm2.AlgAES, KeyBits: 128, Mode: tpm2.AlgCFB},
+			KeyBits:   2048,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Can't create primary key: %v", err)
+	}
+
+	t.Cleanup(func() { flushContext(t, device, primaryHandle) })
+
+	return primaryHandle
+}
+
+func flushContext(t *testing.T, device io.ReadWriter, handle tpmutil.Handle) {
+	t.Helper()
+
+	if err := tpm2.FlushContext(device, handle); err != nil {
+		t.Errorf("Can't flush context: %v", err)
+	}
+}
+
+func mustDecodeHex(t *testing.T, value string) []byte {
+	t.Helper()
+
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		t.Fatalf("Can't decode hex: %v", err)
+	}
+
+	return data
+}
@@ -19,6 +19,7 @@ package tpmkey
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"encoding/asn1"
 	"errors"
 	"io"
@@ -34,6 +35,8 @@ import (
  * Consts
  **********************************************************************************************************************/
 
+const innerWrapperKeyBits = 128
+
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
@@ -44,6 +47,23 @@ type TPMKey interface {
 	MakePersistent(persistentHandle tpmutil.Handle) (err error)
 }
 
+// ECDHKey TPM key which supports ECDH key agreement.
+type ECDHKey interface {
+	ECDH(peerPublicKey *ecdsa.PublicKey) (sharedSecret []byte, err error)
+}
+
+// WrappedKey externally generated key wrapped to TPM parent key for import (TPM2_Import parameters).
+type WrappedKey struct {
+	// PublicBlob encoded public area of the key.
+	PublicBlob []byte
+	// DuplicateBlob key sensitive area protected by outer and optional inner wrapper.
+	DuplicateBlob []byte
+	// EncryptedSeed seed of outer wrapper encrypted with parent public key.
+	EncryptedSeed []byte
+	// EncryptionKey optional AES-128-CFB inner wrapper key.
+	EncryptionKey []byte
+}
+
 type tpmKey struct {
 	device           io.ReadWriter
 	primaryHandle    tpmutil.Handle
@@ -124,6 +144,28 @@ func CreateFromBlobs(device io.ReadWriter, primaryHandle tpmutil.Handle,
 	})
 }
 
+// ImportKey imports externally generated wrapped key under parent key and creates key from resulting blobs.
+//
+//nolint:ireturn // we return different key types
+func ImportKey(device io.ReadWriter, parentHandle tpmutil.Handle, parentPassword string,
+	wrappedKey WrappedKey,
+) (key TPMKey, err error) {
+	var symScheme *tpm2.SymScheme
+
+	if len(wrappedKey.EncryptionKey) != 0 {
+		symScheme = &tpm2.SymScheme{Alg: tpm2.AlgAES, KeyBits: innerWrapperKeyBits, Mode: tpm2.AlgCFB}
+	}
+
+	privateBlob, err := tpm2.Import(device, parentHandle, tpm2.AuthCommand{
+		Session: tpm2.HandlePasswordSession, Attributes: tpm2.AttrContinueSession, Auth: []byte(parentPassword),
+	}, wrappedKey.PublicBlob, wrappedKey.DuplicateBlob, wrappedKey.EncryptedSeed, wrappedKey.EncryptionKey, symScheme)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return CreateFromBlobs(device, parentHandle, parentPassword, privateBlob, wrappedKey.PublicBlob)
+}
+
 /***********************************************************************************************************************
  * Private
  **********************************************************************************************************************/
@@ -264,3 +306,46 @@ func decryptRSA(key tpmKey, msg []byte, scheme tpm2.AsymScheme, label string) (p
 
 	return plaintext, aoserrors.Wrap(err)
 }
+
+func ecdhZGen(key tpmKey, peerPublicKey *ecdsa.PublicKey) (sharedSecret []byte, err error) {
+	publicKey, ok := key.publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, aoserrors.New("key is not ECC key")
+	}
+
+	if peerPublicKey.Curve != publicKey.Curve {
+		return nil, aoserrors.New("peer key curve mismatch")
+	}
+
+	var keyHandle tpmutil.Handle
+
+	if key.persistentHandle == 0 {
+		if keyHandle, _, err = tpm2.Load(key.device, key.primaryHandle, key.password,
+			key.publicBlob, key.privateBlob); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		defer func() {
+			if flushErr := tpm2.FlushContext(key.device, keyHandle); flushErr != nil {
+				if err == nil {
+					err = aoserrors.Wrap(flushErr)
+				}
+			}
+		}()
+	} else {
+		keyHandle = key.persistentHandle
+	}
+
+	coordinateSize := (publicKey.Curve.Params().BitSize + 7) / 8 //nolint:mnd
+
+	zPoint, err := tpm2.ECDHZGen(key.device, keyHandle, "", tpm2.ECPoint{
+		XRaw: peerPublicKey.X.FillBytes(make([]byte, coordinateSize)),
+		YRaw: peerPublicKey.Y.FillBytes(make([]byte, coordinateSize)),
+	})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	// Shared secret is X coordinate of the resulting point
+	return zPoint.X().FillBytes(make([]byte, coordinateSize)), nil
+}
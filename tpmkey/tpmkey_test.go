@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tpmkey_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+
+	"github.com/aosedge/aos_common/tpmkey"
+)
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestECDH(t *testing.T) {
+	device := openTestTPM(t)
+	parentHandle := createTestPrimary(t, device)
+
+	privateBlob, publicBlob, _, _, _, err := tpm2.CreateKey(device, parentHandle, tpm2.PCRSelection{}, "", "",
+		tpm2.Public{
+			Type:    tpm2.AlgECC,
+			NameAlg: tpm2.AlgSHA256,
+			Attributes: tpm2.FlagFixedTPM | tpm2.FlagFixedParent | tpm2.FlagSensitiveDataOrigin |
+				tpm2.FlagUserWithAuth | tpm2.FlagDecrypt,
+			ECCParameters: &tpm2.ECCParams{CurveID: tpm2.CurveNISTP256},
+		})
+	if err != nil {
+		t.Fatalf("Can't create ECC key: %v", err)
+	}
+
+	key, err := tpmkey.CreateFromBlobs(device, parentHandle, "", privateBlob, publicBlob)
+	if err != nil {
+		t.Fatalf("Can't create key from blobs: %v", err)
+	}
+
+	ecdhKey, ok := key.(tpmkey.ECDHKey)
+	if !ok {
+		t.Fatal("Key doesn't support ECDH")
+	}
+
+	tpmPublicKey, ok := key.(crypto.Signer).Public().(*ecdsa.PublicKey)
+	if !ok {
+		t.Fatal("Key is not ECC key")
+	}
+
+	peerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate peer key: %v", err)
+	}
+
+	sharedSecret, err := ecdhKey.ECDH(&peerKey.PublicKey)
+	if err != nil {
+		t.Fatalf("Can't compute shared secret: %v", err)
+	}
+
+	peerECDHKey, err := peerKey.ECDH()
+	if err != nil {
+		t.Fatalf("Can't convert peer key: %v", err)
+	}
+
+	tpmECDHPublicKey, err := tpmPublicKey.ECDH()
+	if err != nil {
+		t.Fatalf("Can't convert TPM public key: %v", err)
+	}
+
+	expectedSecret, err := peerECDHKey.ECDH(tpmECDHPublicKey)
+	if err != nil {
+		t.Fatalf("Can't compute expected shared secret: %v", err)
+	}
+
+	if !bytes.Equal(sharedSecret, expectedSecret) {
+		t.Errorf("Wrong shared secret: %x, expected: %x", sharedSecret, expectedSecret)
+	}
+
+	otherCurveKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate peer key: %v", err)
+	}
+
+	if _, err = ecdhKey.ECDH(&otherCurveKey.PublicKey); err == nil {
+		t.Error("Error expected for peer key with other curve")
+	}
+}
+
+func TestImportKey(t *testing.T) {
+	device := openTestTPM(t)
+	parentHandle := createTestPrimary(t, device)
+
+	parentPublic, _, _, err := tpm2.ReadPublic(device, parentHandle)
+	if err != nil {
+		t.Fatalf("Can't read parent public: %v", err)
+	}
+
+	parentPublicKey, err := parentPublic.Key()
+	if err != nil {
+		t.Fatalf("Can't get parent public key: %v", err)
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate key: %v", err)
+	}
+
+	wrappedKey, err := wrapECCKey(privateKey, parentPublicKey.(*rsa.PublicKey))
+	if err != nil {
+		t.Fatalf("Can't wrap key: %v", err)
+	}
+
+	key, err := tpmkey.ImportKey(device, parentHandle, "", wrappedKey)
+	if err != nil {
+		t.Fatalf("Can't import key: %v", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		t.Fatal("Key is not signer")
+	}
+
+	if !privateKey.PublicKey.Equal(signer.Public()) {
+		t.Error("Wrong imported public key")
+	}
+
+	digest := sha256.Sum256([]byte("test data"))
+
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("Can't sign: %v", err)
+	}
+
+	if !ecdsa.VerifyASN1(&privateKey.PublicKey, digest[:], signature) {
+		t.Error("Wrong signature")
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+// wrapECCKey creates TPM2_Import parameters for ECC signing key with outer wrapper only (TPM 2.0 part 1, 23.3).
+func wrapECCKey(privateKey *ecdsa.PrivateKey, parentKey *rsa.PublicKey) (wrappedKey tpmkey.WrappedKey, err error) {
+	const coordinateSize = 32
+
+	public := tpm2.Public{
+		Type:       tpm2.AlgECC,
+		NameAlg:    tpm2.AlgSHA256,
+		Attributes: tpm2.FlagSign | tpm2.FlagUserWithAuth,
+		ECCParameters: &tpm2.ECCParams{
+			Sign:    &tpm2.SigScheme{Alg: tpm2.AlgECDSA, Hash: tpm2.AlgSHA256},
+			CurveID: tpm2.CurveNISTP256,
+			Point: tpm2.ECPoint{
+				XRaw: privateKey.X.FillBytes(make([]byte, coordinateSize)),
+				YRaw: privateKey.Y.FillBytes(make([]byte, coordinateSize)),
+			},
+		},
+	}
+
+	if wrappedKey.PublicBlob, err = public.Encode(); err != nil {
+		return wrappedKey, err
+	}
+
+	publicDigest := sha256.Sum256(wrappedKey.PublicBlob)
+	name := binary.BigEndian.AppendUint16(nil, uint16(tpm2.AlgSHA256))
+	name = append(name, publicDigest[:]...)
+
+	// TPMT_SENSITIVE: sensitiveType, authValue, seedValue, sensitive
+	var sensitive bytes.Buffer
+
+	_ = binary.Write(&sensitive, binary.BigEndian, uint16(tpm2.AlgECC))
+	_ = binary.Write(&sensitive, binary.BigEndian, uint16(0))
+	_ = binary.Write(&sensitive, binary.BigEndian, uint16(0))
+	_ = binary.Write(&sensitive, binary.BigEndian, uint16(coordinateSize))
+	sensitive.Write(privateKey.D.FillBytes(make([]byte, coordinateSize)))
+
+	encSensitive := binary.BigEndian.AppendUint16(nil, uint16(sensitive.Len()))
+	encSensitive = append(encSensitive, sensitive.Bytes()...)
+
+	seed := make([]byte, sha256.Size)
+
+	if _, err = io.ReadFull(rand.Reader, seed); err != nil {
+		return wrappedKey, err
+	}
+
+	if wrappedKey.EncryptedSeed, err = rsa.EncryptOAEP(
+		sha256.New(), rand.Reader, parentKey, seed, []byte("DUPLICATE\x00")); err != nil {
+		return wrappedKey, err
+	}
+
+	// Parent key symmetric algorithm is AES-128-CFB
+	symKey := tpm2.KDFaHash(crypto.SHA256, seed, "STORAGE", name, nil, 128)
+	hmacKey := tpm2.KDFaHash(crypto.SHA256, seed, "INTEGRITY", nil, nil, 8*sha256.Size)
+
+	block, err := aes.NewCipher(symKey)
+	if err != nil {
+		return wrappedKey, err
+	}
+
+	dupSensitive := make([]byte, len(encSensitive))
+
+	//nolint:staticcheck // TPM outer wrapper uses CFB mode
+	cipher.NewCFBEncrypter(block, make([]byte, aes.BlockSize)).XORKeyStream(dupSensitive, encSensitive)
+
+	integrity := hmac.New(sha256.New, hmacKey)
+
+	integrity.Write(dupSensitive)
+	integrity.Write(name)
+
+	wrappedKey.DuplicateBlob = binary.BigEndian.AppendUint16(nil, uint16(sha256.Size))
+	wrappedKey.DuplicateBlob = append(wrappedKey.DuplicateBlob, integrity.Sum(nil)...)
+	wrappedKey.DuplicateBlob = append(wrappedKey.DuplicateBlob, dupSensitive...)
+
+	return wrappedKey, nil
+}
@@ -0,0 +1 @@
+-- Base version of unit statistics database.
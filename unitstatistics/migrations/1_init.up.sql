@@ -0,0 +1,27 @@
+CREATE TABLE monitoring (
+    nodeID TEXT NOT NULL,
+    serviceID TEXT NOT NULL,
+    subjectID TEXT NOT NULL,
+    instance INTEGER NOT NULL,
+    timestamp INTEGER NOT NULL,
+    ram INTEGER NOT NULL,
+    cpu INTEGER NOT NULL,
+    download INTEGER NOT NULL,
+    upload INTEGER NOT NULL,
+    partitions TEXT NOT NULL,
+    downsampled INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX monitoring_source ON monitoring (nodeID, serviceID, subjectID, instance, timestamp);
+
+CREATE TABLE alerts (
+    timestamp INTEGER NOT NULL,
+    tag TEXT NOT NULL,
+    nodeID TEXT NOT NULL,
+    serviceID TEXT NOT NULL,
+    subjectID TEXT NOT NULL,
+    instance INTEGER NOT NULL,
+    payload TEXT NOT NULL
+);
+
+CREATE INDEX alerts_timestamp ON alerts (timestamp);
@@ -0,0 +1,5 @@
+ALTER TABLE monitoring DROP COLUMN cpuLimitPercent;
+ALTER TABLE monitoring DROP COLUMN cpuLimit;
+ALTER TABLE monitoring DROP COLUMN entropy;
+ALTER TABLE monitoring DROP COLUMN pids;
+ALTER TABLE monitoring DROP COLUMN fileDescriptors;
@@ -0,0 +1,5 @@
+ALTER TABLE monitoring ADD COLUMN fileDescriptors INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE monitoring ADD COLUMN pids INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE monitoring ADD COLUMN entropy INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE monitoring ADD COLUMN cpuLimit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE monitoring ADD COLUMN cpuLimitPercent INTEGER NOT NULL DEFAULT 0;
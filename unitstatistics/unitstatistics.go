@@ -0,0 +1,695 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unitstatistics provides embedded time-series storage for monitoring samples and alerts.
+package unitstatistics
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // register sqlite3 driver
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/migration"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	dbVersion   = 2
+	busyTimeout = 60000
+	journalMode = "WAL"
+	syncMode    = "NORMAL"
+	folderPerm  = 0o755
+)
+
+// Monitoring parameters. Any other parameter is treated as partition name.
+const (
+	ParameterRAM      = "ram"
+	ParameterCPU      = "cpu"
+	ParameterDownload = "download"
+	ParameterUpload   = "upload"
+
+	ParameterFileDescriptors = "fileDescriptors"
+	ParameterPIDs            = "pids"
+	ParameterEntropy         = "entropy"
+	ParameterCPULimitPercent = "cpuLimitPercent"
+)
+
+const monitoringColumns = "timestamp, ram, cpu, download, upload, partitions, fileDescriptors, pids, entropy, " +
+	"cpuLimit, cpuLimitPercent"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Config statistics database configuration. Zero durations disable corresponding policy.
+type Config struct {
+	// Retention monitoring samples retention.
+	Retention time.Duration
+	// AlertsRetention alerts retention.
+	AlertsRetention time.Duration
+	// DownsampleAfter age of monitoring samples after which they are downsampled.
+	DownsampleAfter time.Duration
+	// DownsampleInterval interval of downsampled samples.
+	DownsampleInterval time.Duration
+	// MaintenanceInterval interval of applying retention and downsampling policies in background.
+	MaintenanceInterval time.Duration
+}
+
+// Filter monitoring and alerts query filter.
+type Filter struct {
+	NodeID string
+	// InstanceIdent instance to query. If nil, node samples are queried.
+	InstanceIdent *aostypes.InstanceIdent
+	// From and Till define time range. Zero value means unbounded.
+	From time.Time
+	Till time.Time
+}
+
+// Sample monitoring parameter sample.
+type Sample struct {
+	Timestamp time.Time
+	Value     uint64
+}
+
+// Database statistics database.
+type Database struct {
+	sync.Mutex
+
+	sql        *sql.DB
+	config     Config
+	cancelFunc context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+type sampleKey struct {
+	nodeID string
+	ident  aostypes.InstanceIdent
+	bucket int64
+}
+
+type sampleBucket struct {
+	count      uint64
+	ram        uint64
+	cpu        uint64
+	download   uint64
+	upload     uint64
+	partitions map[string]uint64
+
+	fileDescriptors uint64
+	pids            uint64
+	entropy         uint64
+	cpuLimit        uint64
+	cpuLimitPercent uint64
+}
+
+type alertSource struct {
+	Tag       string `json:"tag"`
+	NodeID    string `json:"nodeId"`
+	ServiceID string `json:"serviceId"`
+	SubjectID string `json:"subjectId"`
+	Instance  uint64 `json:"instance"`
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// New opens statistics database.
+func New(dbPath string, config Config) (db *Database, err error) {
+	log.WithField("path", dbPath).Debug("Open unit statistics database")
+
+	if err = os.MkdirAll(filepath.Dir(dbPath), folderPerm); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	sqlite, err := sql.Open("sqlite3", fmt.Sprintf("%s?_busy_timeout=%d&_journal_mode=%s&_sync=%s",
+		dbPath, busyTimeout, journalMode, syncMode))
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if err != nil {
+			sqlite.Close()
+		}
+	}()
+
+	migrations, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if _, err = migration.MigrateFS(sqlite, migrations, dbVersion, migration.Options{}); err != nil {
+		return nil, err
+	}
+
+	db = &Database{sql: sqlite, config: config}
+
+	if config.MaintenanceInterval > 0 {
+		ctx, cancelFunc := context.WithCancel(context.Background())
+
+		db.cancelFunc = cancelFunc
+
+		db.wg.Add(1)
+
+		go db.runMaintenance(ctx)
+	}
+
+	return db, nil
+}
+
+// Close closes statistics database.
+func (db *Database) Close() {
+	if db.cancelFunc != nil {
+		db.cancelFunc()
+		db.wg.Wait()
+	}
+
+	if err := db.sql.Close(); err != nil {
+		log.Errorf("Can't close unit statistics database: %v", err)
+	}
+}
+
+// AddNodeMonitoring stores node and instances monitoring samples.
+func (db *Database) AddNodeMonitoring(monitoring aostypes.NodeMonitoring) (err error) {
+	tx, err := db.sql.Begin()
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = insertSample(
+		tx, monitoring.NodeID, aostypes.InstanceMonitoring{MonitoringData: monitoring.NodeData}, false); err != nil {
+		return err
+	}
+
+	for _, instanceData := range monitoring.InstancesData {
+		if err = insertSample(tx, monitoring.NodeID, instanceData, false); err != nil {
+			return err
+		}
+	}
+
+	return aoserrors.Wrap(tx.Commit())
+}
+
+// AddAlert stores alert.
+func (db *Database) AddAlert(alert interface{}) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	var (
+		item   cloudprotocol.AlertItem
+		source alertSource
+	)
+
+	if err = json.Unmarshal(payload, &item); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = json.Unmarshal(payload, &source); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if _, err = db.sql.Exec("INSERT INTO alerts VALUES(?, ?, ?, ?, ?, ?, ?)", item.Timestamp.UnixNano(), item.Tag,
+		source.NodeID, source.ServiceID, source.SubjectID, source.Instance, string(payload)); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+// GetMonitoring returns monitoring samples matching filter ordered by timestamp.
+func (db *Database) GetMonitoring(filter Filter) (samples []aostypes.MonitoringData, err error) {
+	instanceSamples, err := db.GetInstanceMonitoring(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sample := range instanceSamples {
+		samples = append(samples, sample.MonitoringData)
+	}
+
+	return samples, nil
+}
+
+// GetInstanceMonitoring returns monitoring samples matching filter ordered by timestamp including instance CPU limit
+// values. For node samples, instance ident and CPU limit values are empty.
+func (db *Database) GetInstanceMonitoring(filter Filter) (samples []aostypes.InstanceMonitoring, err error) {
+	where, args := monitoringFilter(filter)
+
+	rows, err := db.sql.Query("SELECT "+monitoringColumns+" FROM monitoring"+where+" ORDER BY timestamp", args...)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			data       aostypes.InstanceMonitoring
+			timestamp  int64
+			partitions string
+		)
+
+		if err = rows.Scan(&timestamp, &data.RAM, &data.CPU, &data.Download, &data.Upload, &partitions,
+			&data.FileDescriptors, &data.PIDs, &data.Entropy, &data.CPULimit, &data.CPULimitPercent); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		data.Timestamp = time.Unix(0, timestamp)
+
+		if err = json.Unmarshal([]byte(partitions), &data.Partitions); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		if filter.InstanceIdent != nil {
+			data.InstanceIdent = *filter.InstanceIdent
+		}
+
+		samples = append(samples, data)
+	}
+
+	return samples, aoserrors.Wrap(rows.Err())
+}
+
+// GetMonitoringSeries returns monitoring samples matching filter as series.
+func (db *Database) GetMonitoringSeries(filter Filter) (series cloudprotocol.MonitoringSeries, err error) {
+	samples, err := db.GetMonitoring(filter)
+	if err != nil {
+		return series, err
+	}
+
+	for _, sample := range samples {
+		series.Append(sample)
+	}
+
+	return series, nil
+}
+
+// GetInstanceMonitoringSeries returns instance monitoring samples matching filter as series.
+func (db *Database) GetInstanceMonitoringSeries(
+	filter Filter,
+) (series cloudprotocol.InstanceMonitoringSeries, err error) {
+	samples, err := db.GetInstanceMonitoring(filter)
+	if err != nil {
+		return series, err
+	}
+
+	series.NodeID = filter.NodeID
+
+	if filter.InstanceIdent != nil {
+		series.InstanceIdent = *filter.InstanceIdent
+	}
+
+	for _, sample := range samples {
+		series.AppendInstance(sample)
+	}
+
+	return series, nil
+}
+
+// GetParameter returns samples of monitoring parameter matching filter. Parameter is one of Parameter* constants or
+// partition name.
+func (db *Database) GetParameter(filter Filter, parameter string) (samples []Sample, err error) {
+	data, err := db.GetInstanceMonitoring(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range data {
+		sample := Sample{Timestamp: item.Timestamp}
+
+		switch parameter {
+		case ParameterRAM:
+			sample.Value = item.RAM
+
+		case ParameterCPU:
+			sample.Value = item.CPU
+
+		case ParameterDownload:
+			sample.Value = item.Download
+
+		case ParameterUpload:
+			sample.Value = item.Upload
+
+		case ParameterFileDescriptors:
+			sample.Value = item.FileDescriptors
+
+		case ParameterPIDs:
+			sample.Value = item.PIDs
+
+		case ParameterEntropy:
+			sample.Value = item.Entropy
+
+		case ParameterCPULimitPercent:
+			sample.Value = item.CPULimitPercent
+
+		default:
+			index := slices.IndexFunc(item.Partitions, func(partition aostypes.PartitionUsage) bool {
+				return partition.Name == parameter
+			})
+			if index < 0 {
+				continue
+			}
+
+			sample.Value = item.Partitions[index].UsedSize
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples, nil
+}
+
+// GetAlerts returns alerts matching filter and tags ordered by timestamp. If tags are not specified, alerts with any
+// tag are returned. Alerts are decoded to registered payload types.
+func (db *Database) GetAlerts(filter Filter, tags ...string) (alerts []interface{}, err error) {
+	conditions, args := timeConditions(filter)
+
+	if filter.NodeID != "" {
+		conditions = append(conditions, "nodeID = ?")
+		args = append(args, filter.NodeID)
+	}
+
+	if filter.InstanceIdent != nil {
+		conditions = append(conditions, "serviceID = ?", "subjectID = ?", "instance = ?")
+		args = append(args,
+			filter.InstanceIdent.ServiceID, filter.InstanceIdent.SubjectID, filter.InstanceIdent.Instance)
+	}
+
+	if len(tags) != 0 {
+		conditions = append(conditions, "tag IN (?"+strings.Repeat(", ?", len(tags)-1)+")")
+
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+	}
+
+	rows, err := db.sql.Query("SELECT payload FROM alerts"+whereClause(conditions)+" ORDER BY timestamp", args...)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payload string
+
+		if err = rows.Scan(&payload); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		alert, err := cloudprotocol.DecodeAlertItem([]byte(payload))
+		if err != nil {
+			return nil, err
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, aoserrors.Wrap(rows.Err())
+}
+
+// Maintain applies retention and downsampling policies relative to now.
+func (db *Database) Maintain(now time.Time) (err error) {
+	db.Lock()
+	defer db.Unlock()
+
+	if db.config.Retention > 0 {
+		if _, err = db.sql.Exec("DELETE FROM monitoring WHERE timestamp < ?",
+			now.Add(-db.config.Retention).UnixNano()); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	if db.config.AlertsRetention > 0 {
+		if _, err = db.sql.Exec("DELETE FROM alerts WHERE timestamp < ?",
+			now.Add(-db.config.AlertsRetention).UnixNano()); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	if db.config.DownsampleAfter > 0 && db.config.DownsampleInterval > 0 {
+		if err = db.downsample(now.Add(-db.config.DownsampleAfter)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (db *Database) runMaintenance(ctx context.Context) {
+	defer db.wg.Done()
+
+	ticker := time.NewTicker(db.config.MaintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			if err := db.Maintain(time.Now()); err != nil {
+				log.Errorf("Can't maintain unit statistics database: %v", err)
+			}
+		}
+	}
+}
+
+// downsample replaces raw samples older than threshold with averages over downsample interval.
+func (db *Database) downsample(threshold time.Time) (err error) {
+	// Align threshold to interval boundary to not split interval between raw and downsampled samples.
+	interval := int64(db.config.DownsampleInterval)
+	threshold = time.Unix(0, threshold.UnixNano()/interval*interval)
+
+	tx, err := db.sql.Begin()
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	buckets, keys, err := readBuckets(tx, threshold, db.config.DownsampleInterval)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return aoserrors.Wrap(tx.Rollback())
+	}
+
+	if _, err = tx.Exec("DELETE FROM monitoring WHERE downsampled = 0 AND timestamp < ?",
+		threshold.UnixNano()); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	for _, key := range keys {
+		bucket := buckets[key]
+
+		data := aostypes.InstanceMonitoring{
+			InstanceIdent: key.ident,
+			MonitoringData: aostypes.MonitoringData{
+				Timestamp:       time.Unix(0, key.bucket*int64(db.config.DownsampleInterval)),
+				RAM:             bucket.ram / bucket.count,
+				CPU:             bucket.cpu / bucket.count,
+				Download:        bucket.download / bucket.count,
+				Upload:          bucket.upload / bucket.count,
+				FileDescriptors: bucket.fileDescriptors / bucket.count,
+				PIDs:            bucket.pids / bucket.count,
+				Entropy:         bucket.entropy / bucket.count,
+			},
+			CPULimit:        bucket.cpuLimit / bucket.count,
+			CPULimitPercent: bucket.cpuLimitPercent / bucket.count,
+		}
+
+		for name, usedSize := range bucket.partitions {
+			data.Partitions = append(data.Partitions, aostypes.PartitionUsage{Name: name, UsedSize: usedSize / bucket.count})
+		}
+
+		slices.SortFunc(data.Partitions, func(partition1, partition2 aostypes.PartitionUsage) int {
+			return strings.Compare(partition1.Name, partition2.Name)
+		})
+
+		if err = insertSample(tx, key.nodeID, data, true); err != nil {
+			return err
+		}
+	}
+
+	log.WithField("samples", len(keys)).Debug("Monitoring samples downsampled")
+
+	return aoserrors.Wrap(tx.Commit())
+}
+
+func readBuckets(
+	tx *sql.Tx, threshold time.Time, interval time.Duration,
+) (buckets map[sampleKey]*sampleBucket, keys []sampleKey, err error) {
+	rows, err := tx.Query("SELECT nodeID, serviceID, subjectID, instance, "+monitoringColumns+
+		" FROM monitoring WHERE downsampled = 0 AND timestamp < ? ORDER BY timestamp", threshold.UnixNano())
+	if err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	buckets = make(map[sampleKey]*sampleBucket)
+
+	for rows.Next() {
+		var (
+			key        sampleKey
+			timestamp  int64
+			data       aostypes.InstanceMonitoring
+			partitions string
+		)
+
+		if err = rows.Scan(&key.nodeID, &key.ident.ServiceID, &key.ident.SubjectID, &key.ident.Instance, &timestamp,
+			&data.RAM, &data.CPU, &data.Download, &data.Upload, &partitions, &data.FileDescriptors, &data.PIDs,
+			&data.Entropy, &data.CPULimit, &data.CPULimitPercent); err != nil {
+			return nil, nil, aoserrors.Wrap(err)
+		}
+
+		if err = json.Unmarshal([]byte(partitions), &data.Partitions); err != nil {
+			return nil, nil, aoserrors.Wrap(err)
+		}
+
+		key.bucket = timestamp / int64(interval)
+
+		bucket, ok := buckets[key]
+		if !ok {
+			bucket = &sampleBucket{partitions: make(map[string]uint64)}
+			buckets[key] = bucket
+			keys = append(keys, key)
+		}
+
+		bucket.count++
+		bucket.ram += data.RAM
+		bucket.cpu += data.CPU
+		bucket.download += data.Download
+		bucket.upload += data.Upload
+		bucket.fileDescriptors += data.FileDescriptors
+		bucket.pids += data.PIDs
+		bucket.entropy += data.Entropy
+		bucket.cpuLimit += data.CPULimit
+		bucket.cpuLimitPercent += data.CPULimitPercent
+
+		for _, partition := range data.Partitions {
+			bucket.partitions[partition.Name] += partition.UsedSize
+		}
+	}
+
+	return buckets, keys, aoserrors.Wrap(rows.Err())
+}
+
+func insertSample(tx *sql.Tx, nodeID string, data aostypes.InstanceMonitoring, downsampled bool) error {
+	partitions := data.Partitions
+	if partitions == nil {
+		partitions = []aostypes.PartitionUsage{}
+	}
+
+	partitionsJSON, err := json.Marshal(partitions)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if _, err = tx.Exec("INSERT INTO monitoring (nodeID, serviceID, subjectID, instance, downsampled, "+
+		monitoringColumns+") VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		nodeID, data.ServiceID, data.SubjectID, data.Instance, downsampled, data.Timestamp.UnixNano(),
+		data.RAM, data.CPU, data.Download, data.Upload, string(partitionsJSON),
+		data.FileDescriptors, data.PIDs, data.Entropy, data.CPULimit, data.CPULimitPercent); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func monitoringFilter(filter Filter) (where string, args []interface{}) {
+	conditions, args := timeConditions(filter)
+
+	ident := aostypes.InstanceIdent{}
+	if filter.InstanceIdent != nil {
+		ident = *filter.InstanceIdent
+	}
+
+	conditions = append(conditions, "serviceID = ?", "subjectID = ?", "instance = ?")
+	args = append(args, ident.ServiceID, ident.SubjectID, ident.Instance)
+
+	if filter.NodeID != "" {
+		conditions = append(conditions, "nodeID = ?")
+		args = append(args, filter.NodeID)
+	}
+
+	return whereClause(conditions), args
+}
+
+func timeConditions(filter Filter) (conditions []string, args []interface{}) {
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, filter.From.UnixNano())
+	}
+
+	if !filter.Till.IsZero() {
+		conditions = append(conditions, "timestamp <= ?")
+		args = append(args, filter.Till.UnixNano())
+	}
+
+	return conditions, args
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unitstatistics_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/migration"
+	"github.com/aosedge/aos_common/unitstatistics"
+)
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestMonitoring(t *testing.T) {
+	db, err := unitstatistics.New(filepath.Join(t.TempDir(), "stats.db"), unitstatistics.Config{})
+	if err != nil {
+		t.Fatalf("Can't create database: %v", err)
+	}
+	defer db.Close()
+
+	start := time.Unix(1000, 0)
+	ident := aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1", Instance: 1}
+
+	for i := range 10 {
+		timestamp := start.Add(time.Duration(i) * time.Second)
+
+		if err := db.AddNodeMonitoring(aostypes.NodeMonitoring{
+			NodeID: "node1",
+			NodeData: aostypes.MonitoringData{
+				Timestamp: timestamp, RAM: uint64(i), CPU: uint64(i * 10),
+				Partitions: []aostypes.PartitionUsage{{Name: "storage", UsedSize: uint64(i * 100)}},
+			},
+			InstancesData: []aostypes.InstanceMonitoring{
+				{InstanceIdent: ident, MonitoringData: aostypes.MonitoringData{Timestamp: timestamp, RAM: uint64(i + 1)}},
+			},
+		}); err != nil {
+			t.Fatalf("Can't add monitoring: %v", err)
+		}
+	}
+
+	nodeSamples, err := db.GetMonitoring(unitstatistics.Filter{
+		NodeID: "node1", From: start.Add(2 * time.Second), Till: start.Add(5 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("Can't get monitoring: %v", err)
+	}
+
+	if len(nodeSamples) != 4 || nodeSamples[0].RAM != 2 || !nodeSamples[0].Timestamp.Equal(start.Add(2*time.Second)) ||
+		nodeSamples[0].Partitions[0].UsedSize != 200 {
+		t.Errorf("Wrong node samples: %v", nodeSamples)
+	}
+
+	instanceSamples, err := db.GetParameter(unitstatistics.Filter{InstanceIdent: &ident}, unitstatistics.ParameterRAM)
+	if err != nil {
+		t.Fatalf("Can't get parameter: %v", err)
+	}
+
+	if len(instanceSamples) != 10 || instanceSamples[9].Value != 10 {
+		t.Errorf("Wrong instance samples: %v", instanceSamples)
+	}
+
+	partitionSamples, err := db.GetParameter(unitstatistics.Filter{NodeID: "node1"}, "storage")
+	if err != nil {
+		t.Fatalf("Can't get parameter: %v", err)
+	}
+
+	if len(partitionSamples) != 10 || partitionSamples[3].Value != 300 {
+		t.Errorf("Wrong partition samples: %v", partitionSamples)
+	}
+
+	series, err := db.GetMonitoringSeries(unitstatistics.Filter{NodeID: "node1"})
+	if err != nil {
+		t.Fatalf("Can't get series: %v", err)
+	}
+
+	if series.Len() != 10 || series.CPU[9] != 90 {
+		t.Errorf("Wrong series: %v", series)
+	}
+}
+
+func TestProcessCounters(t *testing.T) {
+	db, err := unitstatistics.New(filepath.Join(t.TempDir(), "stats.db"), unitstatistics.Config{})
+	if err != nil {
+		t.Fatalf("Can't create database: %v", err)
+	}
+	defer db.Close()
+
+	start := time.Unix(1000, 0)
+	ident := aostypes.InstanceIdent{ServiceID: "service1", SubjectID: "subject1", Instance: 1}
+
+	var expectedInstance []aostypes.InstanceMonitoring
+
+	for i := range 3 {
+		timestamp := start.Add(time.Duration(i) * time.Second)
+		instanceData := aostypes.InstanceMonitoring{
+			InstanceIdent: ident,
+			MonitoringData: aostypes.MonitoringData{
+				Timestamp: timestamp, RAM: uint64(i),
+				FileDescriptors: uint64(10 + i), PIDs: uint64(i + 1),
+			},
+			CPULimit: 1000, CPULimitPercent: uint64(i * 20),
+		}
+
+		if err := db.AddNodeMonitoring(aostypes.NodeMonitoring{
+			NodeID: "node1",
+			NodeData: aostypes.MonitoringData{
+				Timestamp: timestamp, FileDescriptors: uint64(100 + i), PIDs: uint64(50 + i), Entropy: 256,
+			},
+			InstancesData: []aostypes.InstanceMonitoring{instanceData},
+		}); err != nil {
+			t.Fatalf("Can't add monitoring: %v", err)
+		}
+
+		expectedInstance = append(expectedInstance, instanceData)
+	}
+
+	nodeSeries, err := db.GetMonitoringSeries(unitstatistics.Filter{NodeID: "node1"})
+	if err != nil {
+		t.Fatalf("Can't get series: %v", err)
+	}
+
+	if !reflect.DeepEqual(nodeSeries.FileDescriptors, []uint64{100, 101, 102}) ||
+		!reflect.DeepEqual(nodeSeries.PIDs, []uint64{50, 51, 52}) ||
+		!reflect.DeepEqual(nodeSeries.Entropy, []uint64{256, 256, 256}) {
+		t.Errorf("Wrong node series: %v", nodeSeries)
+	}
+
+	instanceSeries, err := db.GetInstanceMonitoringSeries(unitstatistics.Filter{NodeID: "node1", InstanceIdent: &ident})
+	if err != nil {
+		t.Fatalf("Can't get series: %v", err)
+	}
+
+	if instanceSeries.InstanceIdent != ident || instanceSeries.NodeID != "node1" {
+		t.Errorf("Wrong instance series source: %v", instanceSeries)
+	}
+
+	if samples := instanceSeries.InstanceSamples(); !reflect.DeepEqual(samples, expectedInstance) {
+		t.Errorf("Wrong instance samples: %v", samples)
+	}
+
+	cpuLimitSamples, err := db.GetParameter(
+		unitstatistics.Filter{InstanceIdent: &ident}, unitstatistics.ParameterCPULimitPercent)
+	if err != nil {
+		t.Fatalf("Can't get parameter: %v", err)
+	}
+
+	if len(cpuLimitSamples) != 3 || cpuLimitSamples[2].Value != 40 {
+		t.Errorf("Wrong CPU limit percent samples: %v", cpuLimitSamples)
+	}
+}
+
+func TestMigrateProcessCounters(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "stats.db")
+
+	sqlite, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Can't open database: %v", err)
+	}
+
+	if _, err = migration.MigrateFS(sqlite, os.DirFS("migrations"), 1, migration.Options{}); err != nil {
+		t.Fatalf("Can't migrate database: %v", err)
+	}
+
+	if _, err = sqlite.Exec("INSERT INTO monitoring VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"node1", "", "", 0, time.Unix(1000, 0).UnixNano(), 1, 2, 3, 4, "[]", false); err != nil {
+		t.Fatalf("Can't insert sample: %v", err)
+	}
+
+	sqlite.Close()
+
+	db, err := unitstatistics.New(dbPath, unitstatistics.Config{})
+	if err != nil {
+		t.Fatalf("Can't create database: %v", err)
+	}
+	defer db.Close()
+
+	samples, err := db.GetMonitoring(unitstatistics.Filter{NodeID: "node1"})
+	if err != nil {
+		t.Fatalf("Can't get monitoring: %v", err)
+	}
+
+	if len(samples) != 1 || samples[0].RAM != 1 || samples[0].Upload != 4 || samples[0].PIDs != 0 {
+		t.Errorf("Wrong migrated samples: %v", samples)
+	}
+}
+
+func TestRetentionAndDownsampling(t *testing.T) {
+	db, err := unitstatistics.New(filepath.Join(t.TempDir(), "stats.db"), unitstatistics.Config{
+		Retention: time.Hour, AlertsRetention: time.Hour, DownsampleAfter: 10 * time.Minute,
+		DownsampleInterval: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Can't create database: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Unix(100000*60, 0)
+
+	// Samples every 10 seconds for last 2 hours.
+	for i := range 720 {
+		timestamp := now.Add(-time.Duration(i) * 10 * time.Second)
+
+		if err := db.AddNodeMonitoring(aostypes.NodeMonitoring{
+			NodeID: "node1", NodeData: aostypes.MonitoringData{Timestamp: timestamp, CPU: uint64(i % 6 * 10)},
+		}); err != nil {
+			t.Fatalf("Can't add monitoring: %v", err)
+		}
+	}
+
+	for _, timestamp := range []time.Time{now.Add(-2 * time.Hour), now} {
+		if err := db.AddAlert(cloudprotocol.SystemAlert{
+			AlertItem: cloudprotocol.AlertItem{Timestamp: timestamp, Tag: cloudprotocol.AlertTagSystemError},
+			NodeID:    "node1", Message: "alert",
+		}); err != nil {
+			t.Fatalf("Can't add alert: %v", err)
+		}
+	}
+
+	if err := db.Maintain(now); err != nil {
+		t.Fatalf("Can't maintain database: %v", err)
+	}
+
+	samples, err := db.GetMonitoring(unitstatistics.Filter{NodeID: "node1"})
+	if err != nil {
+		t.Fatalf("Can't get monitoring: %v", err)
+	}
+
+	// 50 downsampled samples for [now-60m, now-10m) and 61 raw samples for [now-10m, now].
+	if len(samples) != 111 {
+		t.Fatalf("Wrong samples count: %d", len(samples))
+	}
+
+	if samples[0].Timestamp.Before(now.Add(-time.Hour)) {
+		t.Errorf("Sample should be removed by retention: %v", samples[0].Timestamp)
+	}
+
+	if samples[0].CPU != 25 || samples[1].Timestamp.Sub(samples[0].Timestamp) != time.Minute {
+		t.Errorf("Wrong downsampled sample: %v", samples[0])
+	}
+
+	alerts, err := db.GetAlerts(unitstatistics.Filter{NodeID: "node1"}, cloudprotocol.AlertTagSystemError)
+	if err != nil {
+		t.Fatalf("Can't get alerts: %v", err)
+	}
+
+	if len(alerts) != 1 {
+		t.Fatalf("Wrong alerts count: %d", len(alerts))
+	}
+
+	if alert, ok := alerts[0].(cloudprotocol.SystemAlert); !ok || !alert.Timestamp.Equal(now) {
+		t.Errorf("Wrong alert: %v", alerts[0])
+	}
+}
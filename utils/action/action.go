@@ -19,11 +19,20 @@ package action
 
 import (
 	"container/list"
+	"context"
+	"errors"
 	"sync"
 
 	"github.com/aosedge/aos_common/aoserrors"
 )
 
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// ErrDependencyFailed returned when action is not executed because one of its dependencies failed.
+var ErrDependencyFailed = errors.New("dependency failed")
+
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
@@ -36,15 +45,35 @@ type Handler struct {
 	wg                   sync.WaitGroup
 	waitQueue            *list.List
 	workQueue            *list.List
+	pendingActions       map[string][]*action
 }
 
 // Func action function type.
 type Func func(id string) (err error)
 
+// ContextFunc action function type with context.
+type ContextFunc func(ctx context.Context, id string) (err error)
+
+// Options action options.
+type Options struct {
+	// Priority actions with higher priority are started first. Actions with the same priority are started in FIFO
+	// order.
+	Priority int
+	// DependsOn action is started only after all previously submitted actions with specified IDs are completed. If any
+	// of them fails, the action is not executed and ErrDependencyFailed is returned.
+	DependsOn []string
+}
+
 type action struct {
-	id       string
-	doAction Func
-	channel  chan error
+	id           string
+	ctx          context.Context //nolint:containedctx // action context
+	doAction     ContextFunc
+	priority     int
+	dependencies []*action
+	channel      chan error
+	done         chan struct{}
+	err          error
+	item         *list.Element
 }
 
 /***********************************************************************************************************************
@@ -57,6 +86,7 @@ func New(maxConcurrentActions int) (handler *Handler) {
 		maxConcurrentActions: maxConcurrentActions,
 		workQueue:            list.New(),
 		waitQueue:            list.New(),
+		pendingActions:       make(map[string][]*action),
 	}
 
 	return handler
@@ -64,24 +94,44 @@ func New(maxConcurrentActions int) (handler *Handler) {
 
 // Execute executes action.
 func (handler *Handler) Execute(id string, doAction Func) (channel <-chan error) {
+	return handler.ExecuteContext(context.Background(), id, func(ctx context.Context, id string) error {
+		return doAction(id)
+	}, Options{})
+}
+
+// ExecuteContext executes action with context and options. Actions with the same ID are executed sequentially. If
+// the context is canceled while the action is waiting, the action is not executed and the context error is returned.
+func (handler *Handler) ExecuteContext(
+	ctx context.Context, id string, doAction ContextFunc, options Options,
+) (channel <-chan error) {
 	handler.Lock()
 	defer handler.Unlock()
 
 	handler.wg.Add(1)
 
-	newAction := action{
+	newAction := &action{
 		id:       id,
+		ctx:      ctx,
 		doAction: doAction,
+		priority: options.Priority,
 		channel:  make(chan error, 1),
+		done:     make(chan struct{}),
 	}
 
-	if handler.isIDInWorkQueue(newAction.id) ||
-		(handler.workQueue.Len() >= handler.maxConcurrentActions && handler.maxConcurrentActions != 0) {
-		handler.waitQueue.PushBack(newAction)
-	} else {
-		go handler.processAction(handler.workQueue.PushBack(newAction))
+	for _, dependencyID := range options.DependsOn {
+		newAction.dependencies = append(newAction.dependencies, handler.pendingActions[dependencyID]...)
 	}
 
+	handler.pendingActions[id] = append(handler.pendingActions[id], newAction)
+
+	newAction.item = handler.waitQueue.PushBack(newAction)
+
+	if ctx.Done() != nil {
+		go handler.watchContext(newAction)
+	}
+
+	handler.schedule()
+
 	return newAction.channel
 }
 
@@ -94,51 +144,151 @@ func (handler *Handler) Wait() {
  * Private
  **********************************************************************************************************************/
 
-func (handler *Handler) isIDInWorkQueue(id string) (result bool) {
-	for item := handler.workQueue.Front(); item != nil; item = item.Next() {
-		action, ok := item.Value.(action)
-		if ok {
-			if action.id == id {
-				return true
-			}
+func (handler *Handler) schedule() {
+	handler.completeFailedDependencies()
+
+	for handler.maxConcurrentActions == 0 || handler.workQueue.Len() < handler.maxConcurrentActions {
+		nextAction := handler.selectNextAction()
+		if nextAction == nil {
+			return
 		}
+
+		handler.waitQueue.Remove(nextAction.item)
+		nextAction.item = handler.workQueue.PushBack(nextAction)
+
+		go handler.processAction(nextAction)
 	}
+}
 
-	return false
+func (handler *Handler) completeFailedDependencies() {
+	for item := handler.waitQueue.Front(); item != nil; {
+		waitAction := item.Value.(*action) //nolint:forcetypeassert // only actions are stored in queue
+		item = item.Next()
+
+		// Completing action may fail its dependants which could be already passed, so restart from the beginning.
+		if err := waitAction.dependencyError(); err != nil {
+			handler.waitQueue.Remove(waitAction.item)
+			handler.completeAction(waitAction, err)
+
+			item = handler.waitQueue.Front()
+		}
+	}
 }
 
-func (handler *Handler) processAction(item *list.Element) {
-	defer handler.wg.Done()
+func (handler *Handler) selectNextAction() (nextAction *action) {
+	for item := handler.waitQueue.Front(); item != nil; item = item.Next() {
+		waitAction := item.Value.(*action) //nolint:forcetypeassert // only actions are stored in queue
+
+		if nextAction != nil && waitAction.priority <= nextAction.priority {
+			continue
+		}
 
-	var actionError error
+		// Actions with the same ID are executed in submission order one by one.
+		if handler.pendingActions[waitAction.id][0] != waitAction {
+			continue
+		}
 
-	currentAction, ok := item.Value.(action)
-	if ok {
-		actionError = currentAction.doAction(currentAction.id)
-	} else {
-		actionError = aoserrors.New("wrong action type")
+		if !waitAction.dependenciesCompleted() {
+			continue
+		}
+
+		nextAction = waitAction
 	}
 
-	currentAction.channel <- actionError
-	close(currentAction.channel)
+	return nextAction
+}
+
+func (handler *Handler) processAction(currentAction *action) {
+	actionError := currentAction.doAction(currentAction.ctx, currentAction.id)
 
 	handler.Lock()
 	defer handler.Unlock()
 
-	handler.workQueue.Remove(item)
+	handler.workQueue.Remove(currentAction.item)
+	handler.completeAction(currentAction, actionError)
+	handler.schedule()
+}
 
-	for waitItem := handler.waitQueue.Front(); waitItem != nil; waitItem = waitItem.Next() {
-		waitAction, ok := waitItem.Value.(action)
-		if !ok {
-			continue
+func (handler *Handler) watchContext(waitAction *action) {
+	select {
+	case <-waitAction.ctx.Done():
+
+	case <-waitAction.done:
+		return
+	}
+
+	handler.Lock()
+	defer handler.Unlock()
+
+	if !waitAction.isWaiting(handler.waitQueue) {
+		return
+	}
+
+	handler.waitQueue.Remove(waitAction.item)
+	handler.completeAction(waitAction, aoserrors.Wrap(waitAction.ctx.Err()))
+	handler.schedule()
+}
+
+func (handler *Handler) completeAction(completedAction *action, err error) {
+	defer handler.wg.Done()
+
+	completedAction.err = err
+	close(completedAction.done)
+
+	completedAction.channel <- err
+	close(completedAction.channel)
+
+	pending := handler.pendingActions[completedAction.id]
+
+	for i, pendingAction := range pending {
+		if pendingAction == completedAction {
+			pending = append(pending[:i], pending[i+1:]...)
+
+			break
 		}
+	}
 
-		if handler.isIDInWorkQueue(waitAction.id) {
-			continue
+	if len(pending) == 0 {
+		delete(handler.pendingActions, completedAction.id)
+	} else {
+		handler.pendingActions[completedAction.id] = pending
+	}
+}
+
+func (currentAction *action) isWaiting(waitQueue *list.List) bool {
+	for item := waitQueue.Front(); item != nil; item = item.Next() {
+		if item.Value == currentAction {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (currentAction *action) dependenciesCompleted() bool {
+	for _, dependency := range currentAction.dependencies {
+		select {
+		case <-dependency.done:
+
+		default:
+			return false
 		}
+	}
 
-		go handler.processAction(handler.workQueue.PushBack(handler.waitQueue.Remove(waitItem)))
+	return true
+}
 
-		break
+func (currentAction *action) dependencyError() error {
+	for _, dependency := range currentAction.dependencies {
+		select {
+		case <-dependency.done:
+			if dependency.err != nil {
+				return aoserrors.Errorf("%w: %s: %w", ErrDependencyFailed, dependency.id, dependency.err)
+			}
+
+		default:
+		}
 	}
+
+	return nil
 }
@@ -18,7 +18,9 @@
 package action_test
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -97,3 +99,72 @@ func TestExecuteChannel(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestPriorityAndDependencies(t *testing.T) {
+	actionHandler := action.New(1)
+
+	var (
+		mutex sync.Mutex
+		order []string
+	)
+
+	release := make(chan struct{})
+	failErr := aoserrors.New("install failed")
+
+	doAction := func(ctx context.Context, id string) error {
+		mutex.Lock()
+		order = append(order, id)
+		mutex.Unlock()
+
+		if id == "fail" {
+			return failErr
+		}
+
+		return nil
+	}
+
+	actionHandler.ExecuteContext(context.Background(), "block", func(ctx context.Context, id string) error {
+		<-release
+
+		return nil
+	}, action.Options{})
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+
+	actionHandler.ExecuteContext(context.Background(), "install", doAction, action.Options{})
+	startChannel := actionHandler.ExecuteContext(context.Background(), "start", doAction,
+		action.Options{Priority: 10, DependsOn: []string{"install"}})
+	actionHandler.ExecuteContext(context.Background(), "high", doAction, action.Options{Priority: 5})
+	failChannel := actionHandler.ExecuteContext(context.Background(), "fail", doAction, action.Options{})
+	dependentChannel := actionHandler.ExecuteContext(context.Background(), "dependent", doAction,
+		action.Options{Priority: 20, DependsOn: []string{"fail"}})
+	canceledChannel := actionHandler.ExecuteContext(ctx, "canceled", doAction, action.Options{Priority: 30})
+
+	cancelFunc()
+
+	if err := <-canceledChannel; !errors.Is(err, context.Canceled) {
+		t.Errorf("Wrong canceled action error: %v", err)
+	}
+
+	close(release)
+
+	actionHandler.Wait()
+
+	if err := <-startChannel; err != nil {
+		t.Errorf("Wrong start action error: %v", err)
+	}
+
+	if err := <-failChannel; !errors.Is(err, failErr) {
+		t.Errorf("Wrong fail action error: %v", err)
+	}
+
+	if err := <-dependentChannel; !errors.Is(err, action.ErrDependencyFailed) || !errors.Is(err, failErr) {
+		t.Errorf("Wrong dependent action error: %v", err)
+	}
+
+	expectedOrder := []string{"high", "install", "start", "fail"}
+
+	if !reflect.DeepEqual(order, expectedOrder) {
+		t.Errorf("Wrong execution order: %v", order)
+	}
+}
@@ -18,104 +18,380 @@
 package alertutils
 
 import (
-	"reflect"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
 
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/api/cloudprotocol"
 )
 
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// AlertPayload type constraint for all cloudprotocol alert payloads.
+type AlertPayload interface {
+	cloudprotocol.SystemAlert | cloudprotocol.CoreAlert | cloudprotocol.DownloadAlert |
+		cloudprotocol.SystemQuotaAlert | cloudprotocol.InstanceQuotaAlert | cloudprotocol.DeviceAllocateAlert |
+		cloudprotocol.ResourceValidateAlert | cloudprotocol.ServiceInstanceAlert | cloudprotocol.KernelAlert |
+		cloudprotocol.CrashAlert | cloudprotocol.SecurityAlert | cloudprotocol.RuleAlert | cloudprotocol.MonitoringAlert
+}
+
+type hashContent struct {
+	Type    string      `json:"type"`
+	Payload interface{} `json:"payload"`
+	Status  string      `json:"status,omitempty"`
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
 // AlertsPayloadEqual compares alerts ignoring timestamp.
+// Empty and nil maps are equal, resource validate errors are compared regardless of order.
 //
-//nolint:funlen
+//nolint:funlen,cyclop
 func AlertsPayloadEqual(alert1, alert2 interface{}) bool {
 	switch alert1casted := alert1.(type) {
 	case cloudprotocol.SystemAlert:
 		alert2casted, ok := alert2.(cloudprotocol.SystemAlert)
-		if !ok {
-			return false
-		}
-
-		newAlert1 := alert1casted
-		newAlert1.Timestamp = alert2casted.Timestamp
 
-		return reflect.DeepEqual(newAlert1, alert2casted)
+		return ok && systemAlertsEqual(alert1casted, alert2casted)
 
 	case cloudprotocol.CoreAlert:
 		alert2casted, ok := alert2.(cloudprotocol.CoreAlert)
-		if !ok {
-			return false
-		}
 
-		newAlert1 := alert1casted
-		newAlert1.Timestamp = alert2casted.Timestamp
-
-		return reflect.DeepEqual(newAlert1, alert2casted)
+		return ok && coreAlertsEqual(alert1casted, alert2casted)
 
 	case cloudprotocol.DownloadAlert:
 		alert2casted, ok := alert2.(cloudprotocol.DownloadAlert)
-		if !ok {
-			return false
-		}
-
-		newAlert1 := alert1casted
-		newAlert1.Timestamp = alert2casted.Timestamp
 
-		return reflect.DeepEqual(newAlert1, alert2casted)
+		return ok && downloadAlertsEqual(alert1casted, alert2casted)
 
 	case cloudprotocol.SystemQuotaAlert:
 		alert2casted, ok := alert2.(cloudprotocol.SystemQuotaAlert)
-		if !ok {
-			return false
-		}
 
-		newAlert1 := alert1casted
-		newAlert1.Timestamp = alert2casted.Timestamp
-
-		return reflect.DeepEqual(newAlert1, alert2casted)
+		return ok && systemQuotaAlertsEqual(alert1casted, alert2casted)
 
 	case cloudprotocol.InstanceQuotaAlert:
 		alert2casted, ok := alert2.(cloudprotocol.InstanceQuotaAlert)
-		if !ok {
-			return false
-		}
-
-		newAlert1 := alert1casted
-		newAlert1.Timestamp = alert2casted.Timestamp
 
-		return reflect.DeepEqual(newAlert1, alert2casted)
+		return ok && instanceQuotaAlertsEqual(alert1casted, alert2casted)
 
 	case cloudprotocol.DeviceAllocateAlert:
 		alert2casted, ok := alert2.(cloudprotocol.DeviceAllocateAlert)
-		if !ok {
-			return false
-		}
 
-		newAlert1 := alert1casted
-		newAlert1.Timestamp = alert2casted.Timestamp
-
-		return reflect.DeepEqual(newAlert1, alert2casted)
+		return ok && deviceAllocateAlertsEqual(alert1casted, alert2casted)
 
 	case cloudprotocol.ResourceValidateAlert:
 		alert2casted, ok := alert2.(cloudprotocol.ResourceValidateAlert)
-		if !ok {
-			return false
-		}
-
-		newAlert1 := alert1casted
-		newAlert1.Timestamp = alert2casted.Timestamp
 
-		return reflect.DeepEqual(newAlert1, alert2casted)
+		return ok && resourceValidateAlertsEqual(alert1casted, alert2casted)
 
 	case cloudprotocol.ServiceInstanceAlert:
 		alert2casted, ok := alert2.(cloudprotocol.ServiceInstanceAlert)
-		if !ok {
-			return false
-		}
 
-		newAlert1 := alert1casted
-		newAlert1.Timestamp = alert2casted.Timestamp
+		return ok && serviceInstanceAlertsEqual(alert1casted, alert2casted)
+
+	case cloudprotocol.KernelAlert:
+		alert2casted, ok := alert2.(cloudprotocol.KernelAlert)
+
+		return ok && kernelAlertsEqual(alert1casted, alert2casted)
+
+	case cloudprotocol.CrashAlert:
+		alert2casted, ok := alert2.(cloudprotocol.CrashAlert)
+
+		return ok && crashAlertsEqual(alert1casted, alert2casted)
 
-		return reflect.DeepEqual(newAlert1, alert2casted)
+	case cloudprotocol.SecurityAlert:
+		alert2casted, ok := alert2.(cloudprotocol.SecurityAlert)
+
+		return ok && securityAlertsEqual(alert1casted, alert2casted)
+
+	case cloudprotocol.RuleAlert:
+		alert2casted, ok := alert2.(cloudprotocol.RuleAlert)
+
+		return ok && ruleAlertsEqual(alert1casted, alert2casted)
+
+	case cloudprotocol.MonitoringAlert:
+		alert2casted, ok := alert2.(cloudprotocol.MonitoringAlert)
+
+		return ok && monitoringAlertsEqual(alert1casted, alert2casted)
 	}
 
 	return false
 }
+
+// PayloadEqual compares alerts of the same type ignoring timestamp.
+func PayloadEqual[T AlertPayload](alert1, alert2 T) bool {
+	return AlertsPayloadEqual(alert1, alert2)
+}
+
+// NormalizeAlert returns canonical copy of alert: timestamp is cleared, empty maps and slices are set to nil and
+// resource validate errors are sorted.
+//
+//nolint:cyclop
+func NormalizeAlert(alert interface{}) (interface{}, error) {
+	switch casted := alert.(type) {
+	case cloudprotocol.SystemAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+
+		return casted, nil
+
+	case cloudprotocol.CoreAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+
+		return casted, nil
+
+	case cloudprotocol.DownloadAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+
+		return casted, nil
+
+	case cloudprotocol.SystemQuotaAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+
+		return casted, nil
+
+	case cloudprotocol.InstanceQuotaAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+
+		return casted, nil
+
+	case cloudprotocol.DeviceAllocateAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+
+		return casted, nil
+
+	case cloudprotocol.ResourceValidateAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+		casted.Errors = normalizeErrors(casted.Errors)
+
+		return casted, nil
+
+	case cloudprotocol.ServiceInstanceAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+
+		return casted, nil
+
+	case cloudprotocol.KernelAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+
+		return casted, nil
+
+	case cloudprotocol.CrashAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+		casted.InstanceIdent = cloneInstanceIdent(casted.InstanceIdent)
+
+		return casted, nil
+
+	case cloudprotocol.SecurityAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+		casted.InstanceIdent = cloneInstanceIdent(casted.InstanceIdent)
+
+		return casted, nil
+
+	case cloudprotocol.RuleAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+		casted.Parameters = normalizeMap(casted.Parameters)
+
+		return casted, nil
+
+	case cloudprotocol.MonitoringAlert:
+		casted.AlertItem = normalizeAlertItem(casted.AlertItem)
+		casted.InstanceIdent = cloneInstanceIdent(casted.InstanceIdent)
+
+		return casted, nil
+	}
+
+	return nil, aoserrors.Errorf("unsupported alert type %T", alert)
+}
+
+// AlertHash returns stable content hash of alert which ignores timestamp. Alerts with equal payload have equal
+// hashes, so the hash can be used for deduplication and as storage key.
+func AlertHash(alert interface{}) (string, error) {
+	normalized, err := NormalizeAlert(alert)
+	if err != nil {
+		return "", err
+	}
+
+	content := hashContent{Type: fmt.Sprintf("%T", normalized), Payload: normalized}
+
+	// Quota status is not serialized to JSON but distinguishes quota alerts.
+	switch casted := normalized.(type) {
+	case cloudprotocol.SystemQuotaAlert:
+		content.Status = casted.Status
+
+	case cloudprotocol.InstanceQuotaAlert:
+		content.Status = casted.Status
+	}
+
+	// JSON encoder sorts map keys, so the encoding is deterministic.
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", aoserrors.Wrap(err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func alertItemsEqual(item1, item2 cloudprotocol.AlertItem) bool {
+	return item1.Tag == item2.Tag && maps.Equal(item1.Fields, item2.Fields)
+}
+
+func systemAlertsEqual(alert1, alert2 cloudprotocol.SystemAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.NodeID == alert2.NodeID && alert1.Message == alert2.Message
+}
+
+func coreAlertsEqual(alert1, alert2 cloudprotocol.CoreAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.NodeID == alert2.NodeID && alert1.CoreComponent == alert2.CoreComponent &&
+		alert1.Message == alert2.Message
+}
+
+func downloadAlertsEqual(alert1, alert2 cloudprotocol.DownloadAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.TargetType == alert2.TargetType && alert1.TargetID == alert2.TargetID &&
+		alert1.Version == alert2.Version && alert1.Message == alert2.Message && alert1.URL == alert2.URL &&
+		alert1.DownloadedBytes == alert2.DownloadedBytes && alert1.TotalBytes == alert2.TotalBytes
+}
+
+func systemQuotaAlertsEqual(alert1, alert2 cloudprotocol.SystemQuotaAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.NodeID == alert2.NodeID && alert1.Parameter == alert2.Parameter &&
+		alert1.Value == alert2.Value && alert1.Status == alert2.Status
+}
+
+func instanceQuotaAlertsEqual(alert1, alert2 cloudprotocol.InstanceQuotaAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.InstanceIdent == alert2.InstanceIdent && alert1.Parameter == alert2.Parameter &&
+		alert1.Value == alert2.Value && alert1.Status == alert2.Status
+}
+
+func deviceAllocateAlertsEqual(alert1, alert2 cloudprotocol.DeviceAllocateAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.InstanceIdent == alert2.InstanceIdent && alert1.NodeID == alert2.NodeID &&
+		alert1.Device == alert2.Device && alert1.Message == alert2.Message
+}
+
+func resourceValidateAlertsEqual(alert1, alert2 cloudprotocol.ResourceValidateAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.NodeID == alert2.NodeID && alert1.Name == alert2.Name &&
+		slices.Equal(normalizeErrors(alert1.Errors), normalizeErrors(alert2.Errors))
+}
+
+func serviceInstanceAlertsEqual(alert1, alert2 cloudprotocol.ServiceInstanceAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.InstanceIdent == alert2.InstanceIdent && alert1.ServiceVersion == alert2.ServiceVersion &&
+		alert1.Message == alert2.Message && unitStatesEqual(alert1.UnitState, alert2.UnitState)
+}
+
+func kernelAlertsEqual(alert1, alert2 cloudprotocol.KernelAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.NodeID == alert2.NodeID && alert1.Category == alert2.Category && alert1.Message == alert2.Message
+}
+
+func crashAlertsEqual(alert1, alert2 cloudprotocol.CrashAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		instanceIdentsEqual(alert1.InstanceIdent, alert2.InstanceIdent) && alert1.NodeID == alert2.NodeID &&
+		alert1.Executable == alert2.Executable && alert1.Signal == alert2.Signal && alert1.PID == alert2.PID &&
+		alert1.Unit == alert2.Unit && alert1.Backtrace == alert2.Backtrace && alert1.Message == alert2.Message
+}
+
+func securityAlertsEqual(alert1, alert2 cloudprotocol.SecurityAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		instanceIdentsEqual(alert1.InstanceIdent, alert2.InstanceIdent) && alert1.NodeID == alert2.NodeID &&
+		alert1.Category == alert2.Category && alert1.AuditType == alert2.AuditType && alert1.PID == alert2.PID &&
+		alert1.UID == alert2.UID && alert1.Message == alert2.Message
+}
+
+func ruleAlertsEqual(alert1, alert2 cloudprotocol.RuleAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		alert1.NodeID == alert2.NodeID && alert1.Rule == alert2.Rule && alert1.Severity == alert2.Severity &&
+		maps.Equal(alert1.Parameters, alert2.Parameters) && alert1.Message == alert2.Message
+}
+
+func monitoringAlertsEqual(alert1, alert2 cloudprotocol.MonitoringAlert) bool {
+	return alertItemsEqual(alert1.AlertItem, alert2.AlertItem) &&
+		instanceIdentsEqual(alert1.InstanceIdent, alert2.InstanceIdent) && alert1.NodeID == alert2.NodeID &&
+		alert1.Parameter == alert2.Parameter && alert1.Status == alert2.Status &&
+		errorInfosEqual(alert1.ErrorInfo, alert2.ErrorInfo)
+}
+
+func errorInfosEqual(info1, info2 *cloudprotocol.ErrorInfo) bool {
+	if info1 == nil || info2 == nil {
+		return info1 == info2
+	}
+
+	return *info1 == *info2
+}
+
+func instanceIdentsEqual(ident1, ident2 *aostypes.InstanceIdent) bool {
+	if ident1 == nil || ident2 == nil {
+		return ident1 == ident2
+	}
+
+	return *ident1 == *ident2
+}
+
+func unitStatesEqual(state1, state2 *cloudprotocol.UnitState) bool {
+	if state1 == nil || state2 == nil {
+		return state1 == state2
+	}
+
+	return *state1 == *state2
+}
+
+func normalizeAlertItem(item cloudprotocol.AlertItem) cloudprotocol.AlertItem {
+	item.Timestamp = time.Time{}
+	item.Fields = normalizeMap(item.Fields)
+
+	return item
+}
+
+func normalizeMap(values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return maps.Clone(values)
+}
+
+func normalizeErrors(errorInfos []cloudprotocol.ErrorInfo) []cloudprotocol.ErrorInfo {
+	if len(errorInfos) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(errorInfos)
+
+	slices.SortFunc(sorted, func(info1, info2 cloudprotocol.ErrorInfo) int {
+		return cmp.Or(cmp.Compare(info1.AosCode, info2.AosCode), cmp.Compare(info1.ExitCode, info2.ExitCode),
+			cmp.Compare(info1.Message, info2.Message))
+	})
+
+	return sorted
+}
+
+func cloneInstanceIdent(ident *aostypes.InstanceIdent) *aostypes.InstanceIdent {
+	if ident == nil {
+		return nil
+	}
+
+	cloned := *ident
+
+	return &cloned
+}
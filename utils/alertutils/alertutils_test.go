@@ -139,6 +139,19 @@ func TestSameAlerts(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			alert1: cloudprotocol.KernelAlert{
+				AlertItem: cloudprotocol.AlertItem{Timestamp: time.Now(), Tag: cloudprotocol.AlertTagKernel},
+				Category:  cloudprotocol.KernelAlertCategoryOOM,
+				Message:   "Out of memory: Killed process 123",
+			},
+			alert2: cloudprotocol.KernelAlert{
+				AlertItem: cloudprotocol.AlertItem{Timestamp: time.Now(), Tag: cloudprotocol.AlertTagKernel},
+				Category:  cloudprotocol.KernelAlertCategoryOOM,
+				Message:   "Out of memory: Killed process 123",
+			},
+			result: true,
+		},
 	}
 
 	for _, testCase := range alerts {
@@ -250,3 +263,166 @@ func TestCompareNotAlerts(t *testing.T) {
 		t.Fatal("Alerts comparison wrong")
 	}
 }
+
+func TestNormalizeAndHash(t *testing.T) {
+	alert1 := cloudprotocol.ResourceValidateAlert{
+		AlertItem: cloudprotocol.AlertItem{
+			Timestamp: time.Now(), Tag: cloudprotocol.AlertTagResourceValidate, Fields: map[string]string{},
+		},
+		NodeID: "mainNode",
+		Name:   "resource",
+		Errors: []cloudprotocol.ErrorInfo{{AosCode: 2, Message: "second"}, {AosCode: 1, Message: "first"}},
+	}
+	alert2 := cloudprotocol.ResourceValidateAlert{
+		AlertItem: cloudprotocol.AlertItem{Timestamp: time.Now().Add(time.Hour), Tag: cloudprotocol.AlertTagResourceValidate},
+		NodeID:    "mainNode",
+		Name:      "resource",
+		Errors:    []cloudprotocol.ErrorInfo{{AosCode: 1, Message: "first"}, {AosCode: 2, Message: "second"}},
+	}
+
+	if !alertutils.PayloadEqual(alert1, alert2) {
+		t.Error("Alerts should be equal")
+	}
+
+	normalized, err := alertutils.NormalizeAlert(alert1)
+	if err != nil {
+		t.Fatalf("Can't normalize alert: %v", err)
+	}
+
+	normalizedAlert, ok := normalized.(cloudprotocol.ResourceValidateAlert)
+	if !ok {
+		t.Fatalf("Wrong normalized alert type: %T", normalized)
+	}
+
+	if !normalizedAlert.Timestamp.IsZero() || normalizedAlert.Fields != nil || normalizedAlert.Errors[0].AosCode != 1 {
+		t.Errorf("Wrong normalized alert: %v", normalizedAlert)
+	}
+
+	if alert1.Errors[0].AosCode != 2 {
+		t.Error("Original alert should not be modified")
+	}
+
+	hash1, err := alertutils.AlertHash(alert1)
+	if err != nil {
+		t.Fatalf("Can't calculate alert hash: %v", err)
+	}
+
+	hash2, err := alertutils.AlertHash(alert2)
+	if err != nil {
+		t.Fatalf("Can't calculate alert hash: %v", err)
+	}
+
+	if hash1 != hash2 {
+		t.Errorf("Hashes of equal alerts differ: %s != %s", hash1, hash2)
+	}
+
+	quotaAlert := cloudprotocol.SystemQuotaAlert{
+		AlertItem: cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagSystemQuota},
+		NodeID:    "mainNode", Parameter: "cpu", Value: 90, Status: "raise",
+	}
+
+	hashRaise, err := alertutils.AlertHash(quotaAlert)
+	if err != nil {
+		t.Fatalf("Can't calculate alert hash: %v", err)
+	}
+
+	quotaAlert.Status = "fall"
+
+	hashFall, err := alertutils.AlertHash(quotaAlert)
+	if err != nil {
+		t.Fatalf("Can't calculate alert hash: %v", err)
+	}
+
+	if hashRaise == hashFall {
+		t.Error("Hashes of alerts with different status should differ")
+	}
+
+	crashAlert := cloudprotocol.CrashAlert{
+		AlertItem: cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagCrash}, NodeID: "mainNode",
+	}
+
+	hashCrash, err := alertutils.AlertHash(crashAlert)
+	if err != nil {
+		t.Fatalf("Can't calculate alert hash: %v", err)
+	}
+
+	kernelAlert := cloudprotocol.KernelAlert{
+		AlertItem: cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagCrash}, NodeID: "mainNode",
+	}
+
+	hashKernel, err := alertutils.AlertHash(kernelAlert)
+	if err != nil {
+		t.Fatalf("Can't calculate alert hash: %v", err)
+	}
+
+	if hashCrash == hashKernel {
+		t.Error("Hashes of alerts with different types should differ")
+	}
+
+	if _, err := alertutils.AlertHash("not alert"); err == nil {
+		t.Error("Error expected for unsupported alert")
+	}
+}
+
+func TestAlertPolicy(t *testing.T) {
+	kernelAlert := cloudprotocol.KernelAlert{AlertItem: cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagKernel}}
+	quotaAlert := cloudprotocol.SystemQuotaAlert{
+		AlertItem: cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagSystemQuota},
+	}
+	crashAlert := cloudprotocol.CrashAlert{AlertItem: cloudprotocol.AlertItem{Tag: cloudprotocol.AlertTagCrash}}
+	ruleAlert := cloudprotocol.RuleAlert{
+		AlertItem: cloudprotocol.AlertItem{Tag: "storageRule"}, Severity: cloudprotocol.AlertSeverityInfo,
+	}
+
+	var nilPolicy *alertutils.AlertPolicy
+
+	if !nilPolicy.IsAllowed(kernelAlert) {
+		t.Error("Nil policy should allow all alerts")
+	}
+
+	policy := alertutils.NewAlertPolicy()
+
+	if err := policy.SetVerbosity(cloudprotocol.AlertsVerbosity{MinSeverity: "verbose"}); err == nil {
+		t.Error("Error expected for wrong severity")
+	}
+
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	testData := []struct {
+		verbosity cloudprotocol.AlertsVerbosity
+		allowed   []bool
+	}{
+		{verbosity: cloudprotocol.AlertsVerbosity{}, allowed: []bool{true, true, true, true}},
+		{
+			verbosity: cloudprotocol.AlertsVerbosity{MutedTags: []string{cloudprotocol.AlertTagKernel}},
+			allowed:   []bool{false, true, true, true},
+		},
+		{
+			verbosity: cloudprotocol.AlertsVerbosity{MinSeverity: cloudprotocol.AlertSeverityError, Till: &future},
+			allowed:   []bool{true, false, true, false},
+		},
+		{
+			verbosity: cloudprotocol.AlertsVerbosity{
+				MutedTags: []string{cloudprotocol.AlertTagCrash}, MinSeverity: cloudprotocol.AlertSeverityCritical,
+			},
+			allowed: []bool{false, false, false, false},
+		},
+		{
+			verbosity: cloudprotocol.AlertsVerbosity{MinSeverity: cloudprotocol.AlertSeverityCritical, Till: &past},
+			allowed:   []bool{true, true, true, true},
+		},
+	}
+
+	for i, item := range testData {
+		if err := policy.SetVerbosity(item.verbosity); err != nil {
+			t.Fatalf("Can't set verbosity: %v", err)
+		}
+
+		for j, alert := range []interface{}{kernelAlert, quotaAlert, crashAlert, ruleAlert} {
+			if allowed := policy.IsAllowed(alert); allowed != item.allowed[j] {
+				t.Errorf("Wrong allowed state for verbosity %d alert %T: %v", i, alert, allowed)
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertutils
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// AlertPolicy alerts verbosity policy shared by alert producers. It is adjusted by cloud alerts verbosity messages
+// and should be checked by producers before sending alert.
+type AlertPolicy struct {
+	sync.RWMutex
+
+	verbosity cloudprotocol.AlertsVerbosity
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals // severities in ascending order
+var severityLevels = []string{
+	cloudprotocol.AlertSeverityInfo, cloudprotocol.AlertSeverityWarning, cloudprotocol.AlertSeverityError,
+	cloudprotocol.AlertSeverityCritical,
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewAlertPolicy creates alert policy which allows all alerts.
+func NewAlertPolicy() *AlertPolicy {
+	return &AlertPolicy{}
+}
+
+// SetVerbosity applies cloud alerts verbosity request.
+func (policy *AlertPolicy) SetVerbosity(verbosity cloudprotocol.AlertsVerbosity) error {
+	if err := verbosity.Validate(); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"mutedTags": verbosity.MutedTags, "minSeverity": verbosity.MinSeverity, "till": verbosity.Till,
+	}).Debug("Set alerts verbosity")
+
+	policy.Lock()
+	defer policy.Unlock()
+
+	policy.verbosity = verbosity
+
+	return nil
+}
+
+// Verbosity returns current alerts verbosity.
+func (policy *AlertPolicy) Verbosity() cloudprotocol.AlertsVerbosity {
+	policy.RLock()
+	defer policy.RUnlock()
+
+	return policy.verbosity
+}
+
+// IsAllowed returns true if alert should be sent according to current verbosity. Nil policy allows all alerts.
+func (policy *AlertPolicy) IsAllowed(alert interface{}) bool {
+	if policy == nil {
+		return true
+	}
+
+	policy.RLock()
+	defer policy.RUnlock()
+
+	if policy.verbosity.Till != nil && !time.Now().Before(*policy.verbosity.Till) {
+		return true
+	}
+
+	if slices.Contains(policy.verbosity.MutedTags, cloudprotocol.GetAlertItem(alert).Tag) {
+		return false
+	}
+
+	if policy.verbosity.MinSeverity != "" &&
+		severityLevel(GetAlertSeverity(alert)) < severityLevel(policy.verbosity.MinSeverity) {
+		return false
+	}
+
+	return true
+}
+
+// GetAlertSeverity returns alert severity. Rule alerts have own severity, other alerts have predefined severity
+// by type.
+func GetAlertSeverity(alert interface{}) string {
+	switch casted := alert.(type) {
+	case cloudprotocol.RuleAlert:
+		if casted.Severity != "" {
+			return casted.Severity
+		}
+
+		return cloudprotocol.AlertSeverityError
+
+	case cloudprotocol.CrashAlert, cloudprotocol.SecurityAlert:
+		return cloudprotocol.AlertSeverityCritical
+
+	case cloudprotocol.SystemQuotaAlert, cloudprotocol.InstanceQuotaAlert, cloudprotocol.MonitoringAlert:
+		return cloudprotocol.AlertSeverityWarning
+
+	case cloudprotocol.DownloadAlert:
+		return cloudprotocol.AlertSeverityInfo
+
+	default:
+		return cloudprotocol.AlertSeverityError
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func severityLevel(severity string) int {
+	return slices.Index(severityLevels, severity)
+}
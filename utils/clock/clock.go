@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clock provides time source abstraction to be able to inject deterministic clock in tests.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Clock time source.
+type Clock interface {
+	Now() time.Time
+	After(duration time.Duration) <-chan time.Time
+	NewTimer(duration time.Duration) Timer
+	NewTicker(period time.Duration) Ticker
+	AfterFunc(duration time.Duration, callback func()) Timer
+}
+
+// Timer timer created by clock. C returns nil for timers created by AfterFunc.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(duration time.Duration) bool
+}
+
+// Ticker ticker created by clock.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(period time.Duration)
+}
+
+type systemClock struct{}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+type clockContextKey struct{}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// New returns system clock.
+func New() Clock {
+	return systemClock{}
+}
+
+// ContextWithClock returns context with clock.
+func ContextWithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockContextKey{}, clock)
+}
+
+// FromContext returns clock from context or system clock if context has no clock.
+func FromContext(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockContextKey{}).(Clock); ok {
+		return clock
+	}
+
+	return systemClock{}
+}
+
+// Now returns current time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (systemClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
+
+// NewTimer creates new timer.
+func (systemClock) NewTimer(duration time.Duration) Timer {
+	return systemTimer{time.NewTimer(duration)}
+}
+
+// NewTicker creates new ticker.
+func (systemClock) NewTicker(period time.Duration) Ticker {
+	return systemTicker{time.NewTicker(period)}
+}
+
+// AfterFunc waits for the duration to elapse and then calls callback in its own goroutine.
+func (systemClock) AfterFunc(duration time.Duration, callback func()) Timer {
+	return systemTimer{time.AfterFunc(duration, callback)}
+}
+
+// C returns timer channel.
+func (timer systemTimer) C() <-chan time.Time {
+	return timer.Timer.C
+}
+
+// C returns ticker channel.
+func (ticker systemTicker) C() <-chan time.Time {
+	return ticker.Ticker.C
+}
@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	defaultMaxChainLength  = 10
+	defaultAIAFetchTimeout = 30 * time.Second
+	maxAIACertSize         = 1 << 20
+	aiaCacheFilePerm       = 0o600
+	aiaCacheDirPerm        = 0o755
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// ChainBuilderConfig certificate chain builder configuration.
+type ChainBuilderConfig struct {
+	// FetchAIA enables fetching of missing intermediate certificates by AIA CA issuers URLs.
+	FetchAIA bool
+	// AIACacheDir directory to cache fetched intermediate certificates. Cached certificates are used offline.
+	AIACacheDir string
+	// AIAFetchTimeout timeout of fetching single AIA certificate.
+	AIAFetchTimeout time.Duration
+	// MaxChainLength maximum number of certificates in the chain.
+	MaxChainLength int
+}
+
+// ChainBuilder builds ordered certificate chains from unordered certificate bundles.
+type ChainBuilder struct {
+	roots      *x509.CertPool
+	config     ChainBuilderConfig
+	httpClient *http.Client
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewChainBuilder creates certificate chain builder. If roots is nil, built chain is not validated.
+func NewChainBuilder(roots *x509.CertPool, config ChainBuilderConfig) *ChainBuilder {
+	if config.MaxChainLength == 0 {
+		config.MaxChainLength = defaultMaxChainLength
+	}
+
+	if config.AIAFetchTimeout == 0 {
+		config.AIAFetchTimeout = defaultAIAFetchTimeout
+	}
+
+	return &ChainBuilder{
+		roots: roots, config: config, httpClient: &http.Client{Timeout: config.AIAFetchTimeout},
+	}
+}
+
+// NewChainBuilder creates certificate chain builder which validates chains against crypto context root certificates.
+func (cryptoContext *CryptoContext) NewChainBuilder(config ChainBuilderConfig) *ChainBuilder {
+	return NewChainBuilder(cryptoContext.rootCertPool, config)
+}
+
+// BuildChainFromPEM orders PEM encoded certificate bundle from leaf to root.
+func (builder *ChainBuilder) BuildChainFromPEM(data []byte) ([]*x509.Certificate, error) {
+	certs, err := PEMToX509Cert(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.BuildChain(certs)
+}
+
+// BuildChain orders certificates from leaf to root, fetches missing intermediates if enabled and validates the
+// result against root certificates. Certificates which are not part of the leaf chain are ignored.
+func (builder *ChainBuilder) BuildChain(certs []*x509.Certificate) (chain []*x509.Certificate, err error) {
+	leaf, err := findLeafCertificate(certs)
+	if err != nil {
+		return nil, err
+	}
+
+	chain = []*x509.Certificate{leaf}
+
+	for current := leaf; !isSelfSigned(current); {
+		if len(chain) >= builder.config.MaxChainLength {
+			return nil, aoserrors.Errorf("certificate chain exceeds max length %d", builder.config.MaxChainLength)
+		}
+
+		issuer := findIssuer(current, certs, chain)
+
+		if issuer == nil && builder.config.FetchAIA {
+			issuer = builder.fetchIssuer(current)
+		}
+
+		if issuer == nil {
+			break
+		}
+
+		chain = append(chain, issuer)
+		current = issuer
+	}
+
+	if builder.roots != nil {
+		if err = verifyChain(chain, builder.roots); err != nil {
+			return nil, err
+		}
+	}
+
+	return chain, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func findLeafCertificate(certs []*x509.Certificate) (*x509.Certificate, error) {
+	var leaves []*x509.Certificate
+
+	for _, cert := range certs {
+		if !isIssuerOfAny(cert, certs) {
+			leaves = append(leaves, cert)
+		}
+	}
+
+	switch len(leaves) {
+	case 0:
+		return nil, aoserrors.New("leaf certificate not found")
+
+	case 1:
+		return leaves[0], nil
+	}
+
+	// Bundle may contain unrelated CA certificates: prefer single non CA certificate as leaf.
+	var leaf *x509.Certificate
+
+	for _, cert := range leaves {
+		if cert.IsCA {
+			continue
+		}
+
+		if leaf != nil {
+			return nil, aoserrors.New("multiple leaf certificates found")
+		}
+
+		leaf = cert
+	}
+
+	if leaf == nil {
+		return nil, aoserrors.New("multiple leaf certificates found")
+	}
+
+	return leaf, nil
+}
+
+func isIssuerOfAny(issuer *x509.Certificate, certs []*x509.Certificate) bool {
+	for _, cert := range certs {
+		if cert != issuer && isIssuedBy(cert, issuer) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIssuedBy(cert, issuer *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, issuer.RawSubject) && cert.CheckSignatureFrom(issuer) == nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignature(
+		cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+func findIssuer(cert *x509.Certificate, certs, chain []*x509.Certificate) *x509.Certificate {
+	for _, candidate := range certs {
+		if isInChain(candidate, chain) {
+			continue
+		}
+
+		if isIssuedBy(cert, candidate) {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+func isInChain(cert *x509.Certificate, chain []*x509.Certificate) bool {
+	for _, chainCert := range chain {
+		if chainCert.Equal(cert) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func verifyChain(chain []*x509.Certificate, roots *x509.CertPool) error {
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := chain[0].Verify(x509.VerifyOptions{
+		Roots: roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func (builder *ChainBuilder) fetchIssuer(cert *x509.Certificate) *x509.Certificate {
+	for _, issuerURL := range cert.IssuingCertificateURL {
+		issuers, err := builder.getAIACertificates(issuerURL)
+		if err != nil {
+			log.WithField("url", issuerURL).Warnf("Can't get AIA certificate: %v", err)
+
+			continue
+		}
+
+		for _, issuer := range issuers {
+			if isIssuedBy(cert, issuer) {
+				return issuer
+			}
+		}
+	}
+
+	return nil
+}
+
+func (builder *ChainBuilder) getAIACertificates(issuerURL string) ([]*x509.Certificate, error) {
+	cacheFile := ""
+
+	if builder.config.AIACacheDir != "" {
+		sum := sha256.Sum256([]byte(issuerURL))
+		cacheFile = filepath.Join(builder.config.AIACacheDir, hex.EncodeToString(sum[:])+".crt")
+
+		if data, err := os.ReadFile(cacheFile); err == nil {
+			if certs, err := parseAIACertificates(data); err == nil {
+				return certs, nil
+			}
+
+			log.WithField("file", cacheFile).Warn("Invalid cached AIA certificate")
+		}
+	}
+
+	data, err := builder.downloadAIACertificate(issuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := parseAIACertificates(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheFile != "" {
+		if err := os.MkdirAll(builder.config.AIACacheDir, aiaCacheDirPerm); err != nil {
+			log.Errorf("Can't create AIA cache dir: %v", err)
+		} else if err := os.WriteFile(cacheFile, data, aiaCacheFilePerm); err != nil {
+			log.Errorf("Can't cache AIA certificate: %v", err)
+		}
+	}
+
+	return certs, nil
+}
+
+func (builder *ChainBuilder) downloadAIACertificate(issuerURL string) ([]byte, error) {
+	resp, err := builder.httpClient.Get(issuerURL) //nolint:noctx
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, aoserrors.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAIACertSize))
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return data, nil
+}
+
+// parseAIACertificates parses DER or PEM encoded AIA certificates.
+func parseAIACertificates(data []byte) ([]*x509.Certificate, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		return PEMToX509Cert(data)
+	}
+
+	certs, err := x509.ParseCertificates(data)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return certs, nil
+}
@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Certificate event types.
+const (
+	CertEventExpiring = "expiring"
+	CertEventExpired  = "expired"
+	CertEventRenewed  = "renewed"
+)
+
+const (
+	defaultCertCheckInterval = 1 * time.Hour
+	certEventsChannelSize    = 16
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// CertWatcherConfig certificate watcher configuration.
+type CertWatcherConfig struct {
+	// CheckInterval interval between certificate checks.
+	CheckInterval time.Duration
+	// LeadTimes times before expiration when expiring event is emitted.
+	LeadTimes []time.Duration
+}
+
+// WatchedCert certificate watched for expiration.
+type WatchedCert struct {
+	Type   string
+	NodeID string
+	URL    string
+}
+
+// CertEvent certificate event.
+type CertEvent struct {
+	EventType string
+	Cert      WatchedCert
+	Serial    string
+	ValidTill time.Time
+	LeadTime  time.Duration
+}
+
+// CertWatcher monitors certificates and emits events before expiration and after renewal.
+type CertWatcher struct {
+	sync.Mutex
+
+	cryptoContext  *CryptoContext
+	config         CertWatcherConfig
+	certs          map[string]*watchedCertState
+	events         chan CertEvent
+	cancelFunction context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+type watchedCertState struct {
+	cert          WatchedCert
+	serial        string
+	notifiedLeads map[time.Duration]bool
+	expired       bool
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewCertWatcher creates certificate watcher. Certificates are loaded with crypto context, so any supported
+// certificate URL can be watched.
+func NewCertWatcher(cryptoContext *CryptoContext, config CertWatcherConfig) *CertWatcher {
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = defaultCertCheckInterval
+	}
+
+	config.LeadTimes = append([]time.Duration(nil), config.LeadTimes...)
+
+	sort.Slice(config.LeadTimes, func(i, j int) bool { return config.LeadTimes[i] > config.LeadTimes[j] })
+
+	watcher := &CertWatcher{
+		cryptoContext: cryptoContext,
+		config:        config,
+		certs:         make(map[string]*watchedCertState),
+		events:        make(chan CertEvent, certEventsChannelSize),
+	}
+
+	ctx, cancelFunction := context.WithCancel(context.Background())
+
+	watcher.cancelFunction = cancelFunction
+
+	watcher.wg.Add(1)
+
+	go watcher.run(ctx)
+
+	return watcher
+}
+
+// Close stops certificate watcher and closes events channel.
+func (watcher *CertWatcher) Close() {
+	watcher.cancelFunction()
+	watcher.wg.Wait()
+
+	close(watcher.events)
+}
+
+// Events returns certificate events channel.
+func (watcher *CertWatcher) Events() <-chan CertEvent {
+	return watcher.events
+}
+
+// Add adds certificate to watch and checks it immediately.
+func (watcher *CertWatcher) Add(cert WatchedCert) {
+	watcher.Lock()
+	defer watcher.Unlock()
+
+	state := &watchedCertState{cert: cert, notifiedLeads: make(map[time.Duration]bool)}
+
+	watcher.certs[getWatchedCertKey(cert.Type, cert.NodeID)] = state
+
+	watcher.checkCert(state, time.Now())
+}
+
+// Remove stops watching certificate.
+func (watcher *CertWatcher) Remove(certType, nodeID string) {
+	watcher.Lock()
+	defer watcher.Unlock()
+
+	delete(watcher.certs, getWatchedCertKey(certType, nodeID))
+}
+
+// Check checks all watched certificates immediately, e.g. after certificates are installed.
+func (watcher *CertWatcher) Check() {
+	watcher.Lock()
+	defer watcher.Unlock()
+
+	now := time.Now()
+
+	for _, state := range watcher.certs {
+		watcher.checkCert(state, now)
+	}
+}
+
+// RenewCertData converts certificate event to renew certificate data used by renew certificates flow.
+func (event CertEvent) RenewCertData() cloudprotocol.RenewCertData {
+	return cloudprotocol.RenewCertData{
+		Type: event.Cert.Type, NodeID: event.Cert.NodeID, Serial: event.Serial, ValidTill: event.ValidTill,
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func getWatchedCertKey(certType, nodeID string) string {
+	return fmt.Sprintf("%s:%s", nodeID, certType)
+}
+
+func (watcher *CertWatcher) run(ctx context.Context) {
+	defer watcher.wg.Done()
+
+	ticker := time.NewTicker(watcher.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			watcher.Check()
+		}
+	}
+}
+
+func (watcher *CertWatcher) checkCert(state *watchedCertState, now time.Time) {
+	certs, err := watcher.cryptoContext.LoadCertificateByURL(state.cert.URL)
+	if err != nil {
+		log.WithFields(log.Fields{"type": state.cert.Type, "nodeID": state.cert.NodeID}).Errorf(
+			"Can't load watched certificate: %v", err)
+
+		return
+	}
+
+	event := CertEvent{Cert: state.cert, Serial: fmt.Sprintf("%X", certs[0].SerialNumber), ValidTill: certs[0].NotAfter}
+
+	if state.serial != event.Serial {
+		renewed := state.serial != ""
+
+		state.serial = event.Serial
+		state.notifiedLeads = make(map[time.Duration]bool)
+		state.expired = false
+
+		if renewed {
+			event.EventType = CertEventRenewed
+			watcher.sendEvent(event)
+		}
+	}
+
+	remaining := event.ValidTill.Sub(now)
+
+	if remaining <= 0 {
+		if !state.expired {
+			state.expired = true
+			event.EventType = CertEventExpired
+			watcher.sendEvent(event)
+		}
+
+		return
+	}
+
+	// Notify only the nearest reached lead time and mark all farther ones as notified
+	notify := false
+
+	for _, leadTime := range watcher.config.LeadTimes {
+		if remaining > leadTime || state.notifiedLeads[leadTime] {
+			continue
+		}
+
+		state.notifiedLeads[leadTime] = true
+		event.LeadTime = leadTime
+		notify = true
+	}
+
+	if notify {
+		event.EventType = CertEventExpiring
+		watcher.sendEvent(event)
+	}
+}
+
+func (watcher *CertWatcher) sendEvent(event CertEvent) {
+	log.WithFields(log.Fields{
+		"type": event.Cert.Type, "nodeID": event.Cert.NodeID, "serial": event.Serial, "event": event.EventType,
+	}).Debug("Certificate event")
+
+	select {
+	case watcher.events <- event:
+
+	default:
+		log.WithFields(log.Fields{"type": event.Cert.Type, "event": event.EventType}).Warn(
+			"Certificate events channel is full, event dropped")
+	}
+}
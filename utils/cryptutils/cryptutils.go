@@ -76,10 +76,13 @@ const (
 type CryptoContext struct {
 	sync.Mutex
 
-	rootCertPool  *x509.CertPool
-	tpmDevices    map[string]io.ReadWriteCloser
-	pkcs11Ctx     map[pkcs11Descriptor]*crypto11.Context
-	pkcs11Library string
+	rootCertPool       *x509.CertPool
+	tpmDevices         map[string]io.ReadWriteCloser
+	pkcs11Ctx          map[pkcs11Descriptor]*crypto11.Context
+	pkcs11Library      string
+	pkcs11PINProvider  PKCS11PINProvider
+	passphraseProvider PassphraseProvider
+	tlsProfile         string
 }
 
 type pkcs11Descriptor struct {
@@ -148,36 +151,27 @@ func (cryptoContext *CryptoContext) GetCACertPool() *x509.CertPool {
 	return cryptoContext.rootCertPool
 }
 
-// LoadCertificateByURL loads certificate by URL.
+// LoadCertificateByURL loads certificate by URL using key loader registered for URL scheme.
 func (cryptoContext *CryptoContext) LoadCertificateByURL(certURLStr string) ([]*x509.Certificate, error) {
 	certURL, err := url.Parse(certURLStr)
 	if err != nil {
 		return nil, aoserrors.Wrap(err)
 	}
 
-	switch certURL.Scheme {
-	case SchemeFile:
-		certs, err := LoadCertificateFromFile(certURL.Path)
-		if err != nil {
-			return nil, aoserrors.Wrap(err)
-		}
-
-		return certs, nil
-
-	case SchemePKCS11:
-		certs, err := cryptoContext.loadCertificateFromPKCS11(certURL.String())
-		if err != nil {
-			return nil, aoserrors.Wrap(err)
-		}
-
-		return certs, aoserrors.Wrap(err)
-
-	default:
+	loader, ok := getKeyLoader(certURL.Scheme)
+	if !ok {
 		return nil, aoserrors.Errorf("unsupported schema %s for certificate", certURL.Scheme)
 	}
+
+	certs, err := loader.LoadCertificate(cryptoContext, certURL)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return certs, nil
 }
 
-// LoadPrivateKeyByURL loads private key by URL.
+// LoadPrivateKeyByURL loads private key by URL using key loader registered for URL scheme.
 func (cryptoContext *CryptoContext) LoadPrivateKeyByURL(keyURLStr string) (privKey crypto.PrivateKey,
 	supportPKCS1v15SessionKey bool, err error,
 ) {
@@ -186,77 +180,67 @@ func (cryptoContext *CryptoContext) LoadPrivateKeyByURL(keyURLStr string) (privK
 		return nil, false, aoserrors.Wrap(err)
 	}
 
-	switch keyURL.Scheme {
-	case SchemeFile:
-		if privKey, err = LoadPrivateKeyFromFile(keyURL.Path); err != nil {
-			return nil, false, aoserrors.Wrap(err)
-		}
-
-		supportPKCS1v15SessionKey = true
-
-	case SchemeTPM:
-		if privKey, err = cryptoContext.loadPrivateKeyFromTPM(keyURL); err != nil {
-			return nil, false, aoserrors.Wrap(err)
-		}
-
-	case SchemePKCS11:
-		if privKey, err = cryptoContext.loadPrivateKeyFromPKCS11(keyURL.String()); err != nil {
-			return nil, false, aoserrors.Wrap(err)
-		}
-
-	default:
+	loader, ok := getKeyLoader(keyURL.Scheme)
+	if !ok {
 		return nil, false, aoserrors.Errorf("unsupported schema %s for private key", keyURL.Scheme)
 	}
 
+	if privKey, supportPKCS1v15SessionKey, err = loader.LoadPrivateKey(cryptoContext, keyURL); err != nil {
+		return nil, false, aoserrors.Wrap(err)
+	}
+
 	return privKey, supportPKCS1v15SessionKey, nil
 }
 
 // GetServerMutualTLSConfig returns server mutual TLS configuration.
 func (cryptoContext *CryptoContext) GetServerMutualTLSConfig(certURLStr, keyURLStr string) (*tls.Config, error) {
-	tlsCertificate, err := cryptoContext.getTLSCertificate(certURLStr, keyURLStr)
+	tlsCertificate, err := cryptoContext.GetTLSCertificate(certURLStr, keyURLStr)
 	if err != nil {
 		return nil, aoserrors.Wrap(err)
 	}
 
-	return &tls.Config{
+	return cryptoContext.applyTLSProfile(&tls.Config{
 		Certificates: []tls.Certificate{tlsCertificate},
 		ClientAuth:   tls.RequireAndVerifyClientCert,
 		ClientCAs:    cryptoContext.rootCertPool,
 		MinVersion:   tls.VersionTLS12,
-	}, nil
+	}), nil
 }
 
 // GetServerTLSConfig returns server TLS configuration.
 func (cryptoContext *CryptoContext) GetServerTLSConfig(certURLStr, keyURLStr string) (*tls.Config, error) {
-	tlsCertificate, err := cryptoContext.getTLSCertificate(certURLStr, keyURLStr)
+	tlsCertificate, err := cryptoContext.GetTLSCertificate(certURLStr, keyURLStr)
 	if err != nil {
 		return nil, aoserrors.Wrap(err)
 	}
 
-	return &tls.Config{
+	return cryptoContext.applyTLSProfile(&tls.Config{
 		Certificates: []tls.Certificate{tlsCertificate},
 		ClientAuth:   tls.NoClientCert,
 		MinVersion:   tls.VersionTLS12,
-	}, nil
+	}), nil
 }
 
 // GetClientMutualTLSConfig returns client mTLS config.
 func (cryptoContext *CryptoContext) GetClientMutualTLSConfig(certURLStr, keyURLStr string) (*tls.Config, error) {
-	tlsCertificate, err := cryptoContext.getTLSCertificate(certURLStr, keyURLStr)
+	tlsCertificate, err := cryptoContext.GetTLSCertificate(certURLStr, keyURLStr)
 	if err != nil {
 		return nil, aoserrors.Wrap(err)
 	}
 
-	return &tls.Config{
+	return cryptoContext.applyTLSProfile(&tls.Config{
 		RootCAs:      cryptoContext.rootCertPool,
 		Certificates: []tls.Certificate{tlsCertificate},
 		MinVersion:   tls.VersionTLS12,
-	}, nil
+	}), nil
 }
 
 // GetClientTLSConfig returns client TLS config.
 func (cryptoContext *CryptoContext) GetClientTLSConfig() (*tls.Config, error) {
-	return &tls.Config{RootCAs: cryptoContext.rootCertPool, MinVersion: tls.VersionTLS12}, nil
+	return cryptoContext.applyTLSProfile(&tls.Config{
+		RootCAs:    cryptoContext.rootCertPool,
+		MinVersion: tls.VersionTLS12,
+	}), nil
 }
 
 // CertToPEM is a utility function returns a PEM encoded x509 Certificate.
@@ -521,20 +505,6 @@ func getRawCertificate(certs []*x509.Certificate) [][]byte {
 	return rawCerts
 }
 
-func (cryptoContext *CryptoContext) getTLSCertificate(certURLStr, keyURLStr string) (tls.Certificate, error) {
-	certs, err := cryptoContext.LoadCertificateByURL(certURLStr)
-	if err != nil {
-		return tls.Certificate{}, aoserrors.Wrap(err)
-	}
-
-	key, _, err := cryptoContext.LoadPrivateKeyByURL(keyURLStr)
-	if err != nil {
-		return tls.Certificate{}, aoserrors.Wrap(err)
-	}
-
-	return tls.Certificate{Certificate: getRawCertificate(certs), PrivateKey: key}, nil
-}
-
 func (cryptoContext *CryptoContext) getPKCS11Context(library, token, userPin string) (*crypto11.Context, error) {
 	cryptoContext.Lock()
 	defer cryptoContext.Unlock()
@@ -553,6 +523,12 @@ func (cryptoContext *CryptoContext) getPKCS11Context(library, token, userPin str
 	if !ok {
 		var err error
 
+		if userPin == "" && cryptoContext.pkcs11PINProvider != nil {
+			if userPin, err = cryptoContext.pkcs11PINProvider(library, token); err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+		}
+
 		if pkcs11Ctx, err = crypto11.Configure(&crypto11.Config{
 			Path: library, TokenLabel: token, Pin: userPin,
 		}); err != nil {
@@ -24,12 +24,22 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"os/exec"
@@ -38,12 +48,23 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
 	"github.com/aosedge/aos_common/utils/cryptutils"
 	"github.com/aosedge/aos_common/utils/testtools"
 )
 
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testKeyLoader struct {
+	certs map[string][]*x509.Certificate
+	keys  map[string]crypto.PrivateKey
+}
+
 /***********************************************************************************************************************
  * Vars
  **********************************************************************************************************************/
@@ -452,6 +473,1021 @@ func TestGetTLSConfig(t *testing.T) {
 	if _, err = cryptoContext.GetServerTLSConfig(certURL.String(), keyURL.String()); err != nil {
 		t.Errorf("Can't get server TLS config: %s", err)
 	}
+
+	tlsCertificate, err := cryptoContext.GetTLSCertificate(certURL.String(), keyURL.String())
+	if err != nil {
+		t.Fatalf("Can't get TLS certificate: %s", err)
+	}
+
+	if tlsCertificate.Leaf == nil || !tlsCertificate.Leaf.Equal(cert) {
+		t.Error("Wrong TLS certificate leaf")
+	}
+
+	signer, err := cryptoContext.LoadSignerByURL(keyURL.String())
+	if err != nil {
+		t.Fatalf("Can't load signer: %s", err)
+	}
+
+	digest := sha256.Sum256([]byte("test message"))
+
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("Can't sign digest: %s", err)
+	}
+
+	if err = cert.CheckSignature(x509.SHA256WithRSA, []byte("test message"), signature); err != nil {
+		t.Errorf("Wrong signature: %s", err)
+	}
+}
+
+func TestSignMessage(t *testing.T) {
+	rootCert, rootKey, err := testtools.GenerateDefaultCARootCertAndKey()
+	if err != nil {
+		t.Fatalf("Can't generate root CA: %v", err)
+	}
+
+	rsaCert, rsaKey, err := testtools.GenerateCertAndKeyWithSubject(
+		pkix.Name{CommonName: "RSA signer"}, rootCert, rootKey)
+	if err != nil {
+		t.Fatalf("Can't generate RSA signer: %v", err)
+	}
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate ECDSA key: %v", err)
+	}
+
+	ecdsaTemplate := testtools.DefaultCertificateTemplate
+	ecdsaTemplate.SerialNumber = big.NewInt(2)
+	ecdsaTemplate.Subject = pkix.Name{CommonName: "ECDSA signer"}
+
+	ecdsaCert, err := testtools.GenerateCert(&ecdsaTemplate, rootCert, rootKey, ecdsaKey.Public())
+	if err != nil {
+		t.Fatalf("Can't generate ECDSA signer: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+
+	message := cloudprotocol.Message{
+		Header: cloudprotocol.MessageHeader{Version: cloudprotocol.ProtocolVersion, SystemID: "system1"},
+		Data:   cloudprotocol.RequestLog{MessageType: cloudprotocol.RequestLogMessageType, LogID: "log1"},
+	}
+
+	signers := []struct {
+		signer crypto.Signer
+		cert   *x509.Certificate
+		alg    string
+	}{
+		{rsaKey.(crypto.Signer), rsaCert, cloudprotocol.SignatureAlgRS256},
+		{ecdsaKey, ecdsaCert, cloudprotocol.SignatureAlgES256},
+	}
+
+	for _, item := range signers {
+		signedMessage, err := cryptutils.SignMessage(message, item.signer, []*x509.Certificate{item.cert})
+		if err != nil {
+			t.Fatalf("Can't sign message: %v", err)
+		}
+
+		if signedMessage.Signature.Alg != item.alg {
+			t.Errorf("Wrong signature algorithm: %s", signedMessage.Signature.Alg)
+		}
+
+		receivedMessage, err := cryptutils.VerifyMessage(*signedMessage, roots)
+		if err != nil {
+			t.Errorf("Can't verify message: %v", err)
+		}
+
+		if !bytes.Equal(receivedMessage.Data, signedMessage.Data) || receivedMessage.Header != message.Header {
+			t.Error("Wrong received message")
+		}
+
+		tamperedMessage := *signedMessage
+		tamperedMessage.Data = []byte(`{"messageType":"requestLog","logId":"log2"}`)
+
+		if _, err = cryptutils.VerifyMessage(tamperedMessage, roots); err == nil {
+			t.Error("Error expected for tampered message")
+		}
+
+		if _, err = cryptutils.VerifyMessage(*signedMessage, x509.NewCertPool()); err == nil {
+			t.Error("Error expected for untrusted signer")
+		}
+	}
+}
+
+func TestBuildCertificateChain(t *testing.T) {
+	rootCert, rootKey, err := testtools.GenerateDefaultCARootCertAndKey()
+	if err != nil {
+		t.Fatalf("Can't generate root certificate: %v", err)
+	}
+
+	intermCert, intermKey, err := testtools.GenerateCACertAndKey(
+		rootCert, rootKey, pkix.Name{CommonName: "Intermediate CA"})
+	if err != nil {
+		t.Fatalf("Can't generate intermediate certificate: %v", err)
+	}
+
+	aiaRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		aiaRequests++
+
+		_, _ = w.Write(intermCert.Raw)
+	}))
+	defer server.Close()
+
+	template := testtools.DefaultCertificateTemplate
+	template.SerialNumber = big.NewInt(2)
+	template.Subject = pkix.Name{CommonName: "Unit"}
+	template.IssuingCertificateURL = []string{server.URL + "/intermediate.crt"}
+
+	leafCert, _, err := testtools.GenerateCertAndKey(&template, intermCert, intermKey)
+	if err != nil {
+		t.Fatalf("Can't generate leaf certificate: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+
+	cacheDir := filepath.Join(tmpDir, "aiacache")
+
+	type testData struct {
+		bundle        []*x509.Certificate
+		config        cryptutils.ChainBuilderConfig
+		roots         *x509.CertPool
+		expectedChain []*x509.Certificate
+		expectError   bool
+	}
+
+	testItems := []testData{
+		{
+			bundle:        []*x509.Certificate{rootCert, leafCert, intermCert},
+			roots:         roots,
+			expectedChain: []*x509.Certificate{leafCert, intermCert, rootCert},
+		},
+		{
+			bundle:        []*x509.Certificate{intermCert, leafCert},
+			roots:         roots,
+			expectedChain: []*x509.Certificate{leafCert, intermCert},
+		},
+		{
+			bundle:      []*x509.Certificate{leafCert},
+			roots:       roots,
+			expectError: true,
+		},
+		{
+			bundle:        []*x509.Certificate{leafCert},
+			config:        cryptutils.ChainBuilderConfig{FetchAIA: true, AIACacheDir: cacheDir},
+			roots:         roots,
+			expectedChain: []*x509.Certificate{leafCert, intermCert},
+		},
+		{
+			bundle:      []*x509.Certificate{intermCert, leafCert},
+			roots:       x509.NewCertPool(),
+			expectError: true,
+		},
+		{
+			bundle:        []*x509.Certificate{intermCert, leafCert},
+			expectedChain: []*x509.Certificate{leafCert, intermCert},
+		},
+		{
+			bundle:      []*x509.Certificate{leafCert, intermCert},
+			config:      cryptutils.ChainBuilderConfig{MaxChainLength: 1},
+			expectError: true,
+		},
+	}
+
+	for i, item := range testItems {
+		var bundle []byte
+
+		for _, cert := range item.bundle {
+			bundle = append(bundle, cryptutils.CertToPEM(cert)...)
+		}
+
+		chain, err := cryptutils.NewChainBuilder(item.roots, item.config).BuildChainFromPEM(bundle)
+		if item.expectError {
+			if err == nil {
+				t.Errorf("Error expected for item %d", i)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Can't build chain for item %d: %v", i, err)
+
+			continue
+		}
+
+		if len(chain) != len(item.expectedChain) {
+			t.Errorf("Wrong chain length for item %d: %d", i, len(chain))
+
+			continue
+		}
+
+		for j, cert := range chain {
+			if !cert.Equal(item.expectedChain[j]) {
+				t.Errorf("Wrong certificate %d in chain for item %d: %s", j, i, cert.Subject)
+			}
+		}
+	}
+
+	// Check offline cache
+
+	server.Close()
+
+	if _, err = cryptutils.NewChainBuilder(roots, cryptutils.ChainBuilderConfig{
+		FetchAIA: true, AIACacheDir: cacheDir,
+	}).BuildChain([]*x509.Certificate{leafCert}); err != nil {
+		t.Errorf("Can't build chain from AIA cache: %v", err)
+	}
+
+	if aiaRequests != 1 {
+		t.Errorf("Wrong AIA requests count: %d", aiaRequests)
+	}
+}
+
+func TestEncryptedPrivateKey(t *testing.T) {
+	keyDir, err := os.MkdirTemp(tmpDir, "encryptedkey")
+	if err != nil {
+		t.Fatalf("Can't create key dir: %v", err)
+	}
+
+	defer os.RemoveAll(keyDir)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Can't generate key: %v", err)
+	}
+
+	passphrase := []byte("secret passphrase")
+	keyFile := filepath.Join(keyDir, "key.pem")
+
+	if err = cryptutils.SaveEncryptedPrivateKeyToFile(keyFile, key, passphrase); err != nil {
+		t.Fatalf("Can't save encrypted key: %v", err)
+	}
+
+	// Check interoperability with openssl
+
+	if out, err := exec.Command("openssl", "pkey", "-noout", "-in", keyFile,
+		"-passin", "pass:"+string(passphrase)).CombinedOutput(); err != nil {
+		t.Errorf("Can't read encrypted key with openssl: %s", out)
+	}
+
+	opensslKeyFile := filepath.Join(keyDir, "openssl.pem")
+
+	if out, err := exec.Command("openssl", "pkcs8", "-topk8", "-v2", "aes-128-cbc", "-in", keyFile,
+		"-passin", "pass:"+string(passphrase), "-passout", "pass:"+string(passphrase),
+		"-out", opensslKeyFile).CombinedOutput(); err != nil {
+		t.Fatalf("Can't encrypt key with openssl: %s", out)
+	}
+
+	data, err := os.ReadFile(opensslKeyFile)
+	if err != nil {
+		t.Fatalf("Can't read key file: %v", err)
+	}
+
+	if !cryptutils.IsEncryptedPEMKey(data) {
+		t.Error("Key should be encrypted")
+	}
+
+	decryptedKey, err := cryptutils.DecryptPEMPrivateKey(data, passphrase)
+	if err != nil {
+		t.Fatalf("Can't decrypt key: %v", err)
+	}
+
+	if !key.Equal(decryptedKey) {
+		t.Error("Wrong decrypted key")
+	}
+
+	if _, err = cryptutils.DecryptPEMPrivateKey(data, []byte("wrong passphrase")); err == nil {
+		t.Error("Error expected for wrong passphrase")
+	}
+
+	// Check loading by URL
+
+	cryptoContext, err := cryptutils.NewCryptoContext("")
+	if err != nil {
+		t.Fatalf("Can't create crypto context: %v", err)
+	}
+	defer cryptoContext.Close()
+
+	keyURL := url.URL{Scheme: cryptutils.SchemeFile, Path: keyFile}
+
+	if _, _, err = cryptoContext.LoadPrivateKeyByURL(keyURL.String()); err == nil {
+		t.Error("Error expected without passphrase provider")
+	}
+
+	passphraseFile := filepath.Join(keyDir, "passphrase")
+
+	if err = os.WriteFile(passphraseFile, append(passphrase, '\n'), 0o600); err != nil {
+		t.Fatalf("Can't write passphrase file: %v", err)
+	}
+
+	cryptoContext.SetPassphraseProvider(cryptutils.NewFilePassphraseProvider(passphraseFile))
+
+	loadedKey, _, err := cryptoContext.LoadPrivateKeyByURL(keyURL.String())
+	if err != nil {
+		t.Fatalf("Can't load encrypted key: %v", err)
+	}
+
+	if !key.Equal(loadedKey) {
+		t.Error("Wrong loaded key")
+	}
+
+	// Check re-encryption
+
+	newPassphrase := []byte("new passphrase")
+
+	if err = cryptutils.ReencryptPrivateKeyFile(keyFile, passphrase, newPassphrase); err != nil {
+		t.Fatalf("Can't re-encrypt key: %v", err)
+	}
+
+	if data, err = os.ReadFile(keyFile); err != nil {
+		t.Fatalf("Can't read key file: %v", err)
+	}
+
+	if _, err = cryptutils.DecryptPEMPrivateKey(data, passphrase); err == nil {
+		t.Error("Error expected for old passphrase")
+	}
+
+	if decryptedKey, err = cryptutils.DecryptPEMPrivateKey(data, newPassphrase); err != nil || !key.Equal(decryptedKey) {
+		t.Errorf("Can't decrypt re-encrypted key: %v", err)
+	}
+
+	if err = cryptutils.ReencryptPrivateKeyFile(keyFile, newPassphrase, nil); err != nil {
+		t.Fatalf("Can't decrypt key file: %v", err)
+	}
+
+	if loadedKey, err = cryptutils.LoadPrivateKeyFromFile(keyFile); err != nil || !key.Equal(loadedKey) {
+		t.Errorf("Can't load decrypted key: %v", err)
+	}
+}
+
+func TestCreateCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate key: %v", err)
+	}
+
+	customExtension := pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Value: []byte{0x05, 0x00}}
+	uri, _ := url.Parse("urn:aos:unit:unit1")
+
+	csrPEM, err := cryptutils.CreateCSR(key, cryptutils.CSRParams{
+		Subject:         pkix.Name{CommonName: "Aos Core", Organization: []string{"EPAM"}},
+		DNSNames:        []string{"unit1.local"},
+		IPAddresses:     []net.IP{net.IPv4(10, 0, 0, 1).To4()},
+		URIs:            []*url.URL{uri},
+		ExtKeyUsages:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{customExtension},
+	})
+	if err != nil {
+		t.Fatalf("Can't create CSR: %v", err)
+	}
+
+	csr, err := cryptutils.PEMToX509CSR(csrPEM)
+	if err != nil {
+		t.Fatalf("Can't parse CSR: %v", err)
+	}
+
+	if csr.Subject.CommonName != "Aos Core" {
+		t.Errorf("Wrong CSR subject: %s", csr.Subject)
+	}
+
+	if !reflect.DeepEqual(csr.DNSNames, []string{"unit1.local"}) {
+		t.Errorf("Wrong CSR DNS names: %v", csr.DNSNames)
+	}
+
+	if len(csr.IPAddresses) != 1 || !csr.IPAddresses[0].Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("Wrong CSR IP addresses: %v", csr.IPAddresses)
+	}
+
+	if len(csr.URIs) != 1 || csr.URIs[0].String() != uri.String() {
+		t.Errorf("Wrong CSR URIs: %v", csr.URIs)
+	}
+
+	extKeyUsages, err := cryptutils.GetCSRExtKeyUsages(csr)
+	if err != nil {
+		t.Fatalf("Can't get CSR ext key usages: %v", err)
+	}
+
+	if !reflect.DeepEqual(extKeyUsages, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth}) {
+		t.Errorf("Wrong CSR ext key usages: %v", extKeyUsages)
+	}
+
+	found := false
+
+	for _, extension := range csr.Extensions {
+		if extension.Id.Equal(customExtension.Id) && bytes.Equal(extension.Value, customExtension.Value) {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Error("Custom extension not found")
+	}
+
+	if _, err = cryptutils.CreateCSR(key, cryptutils.CSRParams{
+		ExtKeyUsages:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{2, 5, 29, 37}}},
+	}); err == nil {
+		t.Error("Error expected for duplicated ext key usage extension")
+	}
+}
+
+func TestEnvelopeEncryption(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Can't generate RSA key: %v", err)
+	}
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate ECDSA key: %v", err)
+	}
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate ECDSA key: %v", err)
+	}
+
+	for _, algorithm := range []string{cryptutils.EnvelopeAlgAES256GCM, cryptutils.EnvelopeAlgChaCha20Poly1305} {
+		for _, size := range []int{
+			0, 100, cryptutils.DefaultEnvelopeChunkSize, 3*cryptutils.DefaultEnvelopeChunkSize + 17,
+		} {
+			data := make([]byte, size)
+
+			if _, err = rand.Read(data); err != nil {
+				t.Fatalf("Can't generate data: %v", err)
+			}
+
+			var envelope bytes.Buffer
+
+			encrypter, err := cryptutils.NewEnvelopeEncrypterWithAlgorithm(
+				&envelope, algorithm, &rsaKey.PublicKey, &ecdsaKey.PublicKey)
+			if err != nil {
+				t.Fatalf("Can't create envelope encrypter: %v", err)
+			}
+
+			if _, err = encrypter.Write(data); err != nil {
+				t.Fatalf("Can't encrypt data: %v", err)
+			}
+
+			if err = encrypter.Close(); err != nil {
+				t.Fatalf("Can't close envelope encrypter: %v", err)
+			}
+
+			for _, key := range []crypto.PrivateKey{rsaKey, ecdsaKey} {
+				decrypter, err := cryptutils.NewEnvelopeDecrypter(bytes.NewReader(envelope.Bytes()), key)
+				if err != nil {
+					t.Fatalf("Can't create envelope decrypter: %v", err)
+				}
+
+				decrypted, err := io.ReadAll(decrypter)
+				if err != nil {
+					t.Fatalf("Can't decrypt envelope: %v", err)
+				}
+
+				if !bytes.Equal(decrypted, data) {
+					t.Errorf("Wrong decrypted %s data for size %d", algorithm, size)
+				}
+			}
+
+			if _, err = cryptutils.NewEnvelopeDecrypter(bytes.NewReader(envelope.Bytes()), otherKey); err == nil {
+				t.Error("Error expected for wrong recipient key")
+			}
+
+			truncated := envelope.Bytes()[:envelope.Len()-1]
+
+			if decrypter, err := cryptutils.NewEnvelopeDecrypter(bytes.NewReader(truncated), rsaKey); err == nil {
+				if _, err = io.ReadAll(decrypter); err == nil {
+					t.Errorf("Error expected for truncated %s envelope of size %d", algorithm, size)
+				}
+			}
+
+			tampered := bytes.Clone(envelope.Bytes())
+			tampered[len(tampered)-size/2-1] ^= 1
+
+			if decrypter, err := cryptutils.NewEnvelopeDecrypter(bytes.NewReader(tampered), rsaKey); err == nil {
+				if _, err = io.ReadAll(decrypter); err == nil {
+					t.Errorf("Error expected for tampered %s envelope of size %d", algorithm, size)
+				}
+			}
+		}
+	}
+
+	if _, err = cryptutils.NewEnvelopeEncrypterWithAlgorithm(
+		&bytes.Buffer{}, "unknown", &ecdsaKey.PublicKey); err == nil {
+		t.Error("Error expected for unsupported envelope algorithm")
+	}
+
+	// Check chunk size limit
+
+	var envelope bytes.Buffer
+
+	if _, err = cryptutils.NewEnvelopeEncrypter(&envelope, &ecdsaKey.PublicKey); err != nil {
+		t.Fatalf("Can't create envelope encrypter: %v", err)
+	}
+
+	var header cryptutils.EnvelopeHeader
+
+	if err = json.Unmarshal(envelope.Bytes()[4:], &header); err != nil {
+		t.Fatalf("Can't unmarshal envelope header: %v", err)
+	}
+
+	header.ChunkSize = 1 << 40
+
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		t.Fatalf("Can't marshal envelope header: %v", err)
+	}
+
+	crafted := binary.BigEndian.AppendUint32(nil, uint32(len(headerData))) //nolint:gosec
+	crafted = append(crafted, headerData...)
+
+	if _, err = cryptutils.NewEnvelopeDecrypter(bytes.NewReader(crafted), ecdsaKey); err == nil {
+		t.Error("Error expected for too big envelope chunk size")
+	}
+
+	// Check file helpers
+
+	envelopeDir, err := os.MkdirTemp(tmpDir, "envelope")
+	if err != nil {
+		t.Fatalf("Can't create envelope dir: %v", err)
+	}
+
+	defer os.RemoveAll(envelopeDir)
+
+	srcFile := filepath.Join(envelopeDir, "plain")
+	encryptedFile := filepath.Join(envelopeDir, "encrypted")
+	decryptedFile := filepath.Join(envelopeDir, "decrypted")
+
+	if err = os.WriteFile(srcFile, []byte("state file content"), 0o600); err != nil {
+		t.Fatalf("Can't write file: %v", err)
+	}
+
+	if err = cryptutils.EncryptFile(srcFile, encryptedFile, &ecdsaKey.PublicKey); err != nil {
+		t.Fatalf("Can't encrypt file: %v", err)
+	}
+
+	if err = cryptutils.DecryptFile(encryptedFile, decryptedFile, ecdsaKey); err != nil {
+		t.Fatalf("Can't decrypt file: %v", err)
+	}
+
+	decrypted, err := os.ReadFile(decryptedFile)
+	if err != nil {
+		t.Fatalf("Can't read file: %v", err)
+	}
+
+	if string(decrypted) != "state file content" {
+		t.Errorf("Wrong decrypted file content: %s", decrypted)
+	}
+}
+
+func TestCertWatcher(t *testing.T) {
+	watcherDir, err := os.MkdirTemp(tmpDir, "certwatcher")
+	if err != nil {
+		t.Fatalf("Can't create watcher dir: %v", err)
+	}
+
+	defer os.RemoveAll(watcherDir)
+
+	rootCert, rootKey, err := testtools.GenerateDefaultCARootCertAndKey()
+	if err != nil {
+		t.Fatalf("Can't generate root certificate: %v", err)
+	}
+
+	saveCert := func(fileName string, serial int64, validFor time.Duration) string {
+		template := testtools.DefaultCertificateTemplate
+		template.SerialNumber = big.NewInt(serial)
+		template.NotBefore = time.Now().Add(-2 * time.Hour)
+		template.NotAfter = time.Now().Add(validFor)
+
+		cert, _, err := testtools.GenerateCertAndKey(&template, rootCert, rootKey)
+		if err != nil {
+			t.Fatalf("Can't generate certificate: %v", err)
+		}
+
+		certURL := url.URL{Scheme: cryptutils.SchemeFile, Path: filepath.Join(watcherDir, fileName)}
+
+		if err = cryptutils.SaveCertificateToFile(certURL.Path, []*x509.Certificate{cert}); err != nil {
+			t.Fatalf("Can't save certificate: %v", err)
+		}
+
+		return certURL.String()
+	}
+
+	cryptoContext, err := cryptutils.NewCryptoContext("")
+	if err != nil {
+		t.Fatalf("Can't create crypto context: %v", err)
+	}
+	defer cryptoContext.Close()
+
+	watcher := cryptutils.NewCertWatcher(cryptoContext, cryptutils.CertWatcherConfig{
+		LeadTimes: []time.Duration{30 * time.Minute, 24 * time.Hour, 2 * time.Hour},
+	})
+	defer watcher.Close()
+
+	checkEvent := func(eventType, serial string, leadTime time.Duration) {
+		t.Helper()
+
+		select {
+		case event := <-watcher.Events():
+			if event.EventType != eventType || event.Serial != serial || event.LeadTime != leadTime {
+				t.Errorf("Wrong certificate event: %+v", event)
+			}
+
+		case <-time.After(time.Second):
+			t.Errorf("Wait certificate event %s timeout", eventType)
+		}
+	}
+
+	onlineURL := saveCert("online.pem", 1, time.Hour)
+
+	watcher.Add(cryptutils.WatchedCert{Type: "online", URL: onlineURL})
+
+	checkEvent(cryptutils.CertEventExpiring, "1", 2*time.Hour)
+
+	watcher.Check()
+
+	select {
+	case event := <-watcher.Events():
+		t.Errorf("Unexpected certificate event: %+v", event)
+
+	default:
+	}
+
+	saveCert("online.pem", 2, 48*time.Hour)
+
+	watcher.Check()
+
+	checkEvent(cryptutils.CertEventRenewed, "2", 0)
+
+	watcher.Add(cryptutils.WatchedCert{Type: "offline", URL: saveCert("offline.pem", 3, -time.Hour)})
+
+	event := <-watcher.Events()
+
+	if event.EventType != cryptutils.CertEventExpired || event.Cert.Type != "offline" {
+		t.Errorf("Wrong certificate event: %+v", event)
+	}
+
+	if renewData := event.RenewCertData(); renewData.Type != "offline" || renewData.Serial != "3" {
+		t.Errorf("Wrong renew cert data: %+v", renewData)
+	}
+}
+
+func TestDestroyPrivateKey(t *testing.T) {
+	keyDir, err := os.MkdirTemp(tmpDir, "shred")
+	if err != nil {
+		t.Fatalf("Can't create key dir: %v", err)
+	}
+
+	defer os.RemoveAll(keyDir)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate key: %v", err)
+	}
+
+	keyURL := url.URL{Scheme: cryptutils.SchemeFile, Path: filepath.Join(keyDir, "key.pem")}
+
+	if err = cryptutils.SavePrivateKeyToFile(keyURL.Path, key); err != nil {
+		t.Fatalf("Can't save key: %v", err)
+	}
+
+	// Hard link keeps inode alive to check that content is wiped
+	linkFile := filepath.Join(keyDir, "link")
+
+	if err = os.Link(keyURL.Path, linkFile); err != nil {
+		t.Fatalf("Can't create link: %v", err)
+	}
+
+	cryptoContext, err := cryptutils.NewCryptoContext("")
+	if err != nil {
+		t.Fatalf("Can't create crypto context: %v", err)
+	}
+	defer cryptoContext.Close()
+
+	if err = cryptoContext.DestroyPrivateKeyByURL(keyURL.String()); err != nil {
+		t.Fatalf("Can't destroy key: %v", err)
+	}
+
+	if _, err = os.Stat(keyURL.Path); !os.IsNotExist(err) {
+		t.Error("Key file should be removed")
+	}
+
+	if data, err := os.ReadFile(linkFile); err != nil || len(data) != 0 {
+		t.Errorf("Key content should be wiped: %v", err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Can't generate key: %v", err)
+	}
+
+	// Keep underlying words to check that key material is overwritten, not only detached.
+	keyWords := [][]big.Word{
+		key.D.Bits(), rsaKey.D.Bits(), rsaKey.Primes[0].Bits(), rsaKey.Primes[1].Bits(),
+		rsaKey.Precomputed.Dp.Bits(), rsaKey.Precomputed.Dq.Bits(), rsaKey.Precomputed.Qinv.Bits(),
+	}
+
+	cryptutils.ZeroizePrivateKey(key)
+	cryptutils.ZeroizePrivateKey(rsaKey)
+
+	for i, words := range keyWords {
+		for _, word := range words {
+			if word != 0 {
+				t.Errorf("Key material %d should be zeroized", i)
+
+				break
+			}
+		}
+	}
+
+	if key.D.Sign() != 0 || rsaKey.D.Sign() != 0 || rsaKey.Precomputed.Dp != nil {
+		t.Error("Key should be zeroized")
+	}
+
+	secret := []byte("secret")
+
+	cryptutils.ZeroizeBytes(secret)
+
+	if !bytes.Equal(secret, make([]byte, len(secret))) {
+		t.Error("Secret should be zeroized")
+	}
+}
+
+func TestSPIFFEID(t *testing.T) {
+	for _, item := range []struct {
+		id    string
+		valid bool
+	}{
+		{id: "spiffe://aos.example/node/node0/sm", valid: true},
+		{id: "spiffe://aos.example", valid: true},
+		{id: "spiffe://Aos.example/node", valid: false},
+		{id: "spiffe://aos.example/node//sm", valid: false},
+		{id: "spiffe://aos.example/node/../sm", valid: false},
+		{id: "spiffe://aos.example/", valid: false},
+		{id: "spiffe://aos.example:8080/node", valid: false},
+		{id: "https://aos.example/node", valid: false},
+	} {
+		spiffeID, err := cryptutils.ParseSPIFFEID(item.id)
+		if item.valid != (err == nil) {
+			t.Errorf("Wrong SPIFFE ID %s validation result: %v", item.id, err)
+		}
+
+		if err == nil && spiffeID.String() != item.id {
+			t.Errorf("Wrong SPIFFE ID string: %s", spiffeID)
+		}
+	}
+
+	spiffeID := cryptutils.SPIFFEID{TrustDomain: "aos.example", Path: "/node/node0/sm"}
+
+	for _, item := range []struct {
+		pattern string
+		match   bool
+	}{
+		{pattern: "spiffe://aos.example/node/node0/sm", match: true},
+		{pattern: "spiffe://aos.example/node/*/sm", match: true},
+		{pattern: "spiffe://aos.example/node/...", match: true},
+		{pattern: "spiffe://aos.example/...", match: true},
+		{pattern: "spiffe://aos.example/node/*", match: false},
+		{pattern: "spiffe://other.example/...", match: false},
+		{pattern: "spiffe://aos.example/cm/...", match: false},
+	} {
+		if cryptutils.MatchSPIFFEID(item.pattern, spiffeID) != item.match {
+			t.Errorf("Wrong SPIFFE ID match result for pattern %s", item.pattern)
+		}
+	}
+
+	rootCert, rootKey, err := testtools.GenerateDefaultCARootCertAndKey()
+	if err != nil {
+		t.Fatalf("Can't generate root certificate: %v", err)
+	}
+
+	template := testtools.DefaultCertificateTemplate
+	template.URIs = []*url.URL{{Scheme: cryptutils.SchemeSPIFFE, Host: "aos.example", Path: "/node/node0/sm"}}
+
+	cert, _, err := testtools.GenerateCertAndKey(&template, rootCert, rootKey)
+	if err != nil {
+		t.Fatalf("Can't generate certificate: %v", err)
+	}
+
+	if certID, err := cryptutils.GetSPIFFEID(cert); err != nil || certID != spiffeID {
+		t.Errorf("Wrong certificate SPIFFE ID: %v, %v", certID, err)
+	}
+
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	if err = cryptutils.VerifySPIFFEPeer("spiffe://aos.example/node/*/sm")(state); err != nil {
+		t.Errorf("Peer should be authorized: %v", err)
+	}
+
+	if err = cryptutils.VerifySPIFFEPeer("spiffe://aos.example/cm")(state); err == nil {
+		t.Error("Peer should not be authorized")
+	}
+}
+
+func TestKeyLoaderRegistry(t *testing.T) {
+	rootCert, rootKey, err := testtools.GenerateDefaultCARootCertAndKey()
+	if err != nil {
+		t.Fatalf("Can't generate root certificate: %v", err)
+	}
+
+	template := testtools.DefaultCertificateTemplate
+
+	cert, key, err := testtools.GenerateCertAndKey(&template, rootCert, rootKey)
+	if err != nil {
+		t.Fatalf("Can't generate certificate: %v", err)
+	}
+
+	cryptoContext, err := cryptutils.NewCryptoContext("")
+	if err != nil {
+		t.Fatalf("Can't create crypto context: %v", err)
+	}
+	defer cryptoContext.Close()
+
+	if _, err = cryptoContext.LoadCertificateByURL("mem://cert"); err == nil {
+		t.Error("Error expected for unregistered scheme")
+	}
+
+	if err = cryptutils.RegisterKeyLoader("mem", &testKeyLoader{
+		certs: map[string][]*x509.Certificate{"cert": {cert}},
+		keys:  map[string]crypto.PrivateKey{"key": key},
+	}); err != nil {
+		t.Fatalf("Can't register key loader: %v", err)
+	}
+	defer cryptutils.UnregisterKeyLoader("mem")
+
+	if err = cryptutils.RegisterKeyLoader("", &testKeyLoader{}); err == nil {
+		t.Error("Error expected for empty scheme")
+	}
+
+	tlsCert, err := cryptoContext.GetTLSCertificate("mem://cert", "mem://key")
+	if err != nil {
+		t.Fatalf("Can't get TLS certificate: %v", err)
+	}
+
+	if !tlsCert.Leaf.Equal(cert) {
+		t.Error("Wrong TLS certificate")
+	}
+
+	signer, err := cryptoContext.LoadSignerByURL("mem://key")
+	if err != nil {
+		t.Fatalf("Can't load signer: %v", err)
+	}
+
+	if err = cryptutils.CheckCertificate(cert, signer); err != nil {
+		t.Errorf("Wrong signer: %v", err)
+	}
+
+	if _, _, err = cryptoContext.LoadPrivateKeyByURL("mem://unknown"); err == nil {
+		t.Error("Error expected for unknown key")
+	}
+
+	cryptutils.UnregisterKeyLoader("mem")
+
+	if _, err = cryptoContext.LoadSignerByURL("mem://key"); err == nil {
+		t.Error("Error expected for unregistered scheme")
+	}
+}
+
+func TestTLSProfiles(t *testing.T) {
+	type testData struct {
+		profile    string
+		minVersion uint16
+		maxVersion uint16
+		tickets    bool
+	}
+
+	data := []testData{
+		{profile: cryptutils.TLSProfileDefault, minVersion: tls.VersionTLS12, tickets: true},
+		{profile: cryptutils.TLSProfileStrict, minVersion: tls.VersionTLS13, maxVersion: tls.VersionTLS13},
+		{profile: cryptutils.TLSProfileLegacyInterop, minVersion: tls.VersionTLS12},
+		{profile: cryptutils.TLSProfileFIPSLike, minVersion: tls.VersionTLS12, maxVersion: tls.VersionTLS13},
+	}
+
+	for _, item := range data {
+		tlsConfig, err := cryptutils.NewTLSProfileConfig(item.profile)
+		if err != nil {
+			t.Errorf("Can't create TLS config for profile %s: %v", item.profile, err)
+
+			continue
+		}
+
+		if tlsConfig.MinVersion != item.minVersion || tlsConfig.MaxVersion != item.maxVersion {
+			t.Errorf("Wrong TLS versions for profile %s: %x, %x", item.profile, tlsConfig.MinVersion,
+				tlsConfig.MaxVersion)
+		}
+
+		if tlsConfig.SessionTicketsDisabled == item.tickets {
+			t.Errorf("Wrong session tickets for profile %s", item.profile)
+		}
+	}
+
+	fipsConfig, err := cryptutils.NewTLSProfileConfig(cryptutils.TLSProfileFIPSLike)
+	if err != nil {
+		t.Fatalf("Can't create TLS config: %v", err)
+	}
+
+	for _, curve := range fipsConfig.CurvePreferences {
+		if curve == tls.X25519 {
+			t.Error("Unexpected X25519 curve in fips-like profile")
+		}
+	}
+
+	for _, cipherSuite := range fipsConfig.CipherSuites {
+		if !strings.Contains(tls.CipherSuiteName(cipherSuite), "GCM") {
+			t.Errorf("Unexpected cipher suite in fips-like profile: %s", tls.CipherSuiteName(cipherSuite))
+		}
+	}
+
+	if _, err = cryptutils.NewTLSProfileConfig("unknown"); err == nil {
+		t.Error("Error expected for unknown profile")
+	}
+
+	cryptoContext, err := cryptutils.NewCryptoContext("")
+	if err != nil {
+		t.Fatalf("Can't create crypto context: %v", err)
+	}
+	defer cryptoContext.Close()
+
+	if err = cryptoContext.SetTLSProfile("unknown"); err == nil {
+		t.Error("Error expected for unknown profile")
+	}
+
+	if err = cryptoContext.SetTLSProfile(cryptutils.TLSProfileStrict); err != nil {
+		t.Fatalf("Can't set TLS profile: %v", err)
+	}
+
+	tlsConfig, err := cryptoContext.GetClientTLSConfig()
+	if err != nil {
+		t.Fatalf("Can't get client TLS config: %v", err)
+	}
+
+	if tlsConfig.MinVersion != tls.VersionTLS13 || !tlsConfig.SessionTicketsDisabled {
+		t.Error("TLS profile is not applied to client TLS config")
+	}
+}
+
+func TestCertificatePinning(t *testing.T) {
+	rootCert, rootKey, err := testtools.GenerateDefaultCARootCertAndKey()
+	if err != nil {
+		t.Fatalf("Can't generate root certificate: %v", err)
+	}
+
+	template := testtools.DefaultCertificateTemplate
+
+	oldCert, _, err := testtools.GenerateCertAndKey(&template, rootCert, rootKey)
+	if err != nil {
+		t.Fatalf("Can't generate certificate: %v", err)
+	}
+
+	newCert, _, err := testtools.GenerateCertAndKey(&template, rootCert, rootKey)
+	if err != nil {
+		t.Fatalf("Can't generate certificate: %v", err)
+	}
+
+	sum := sha256.Sum256(oldCert.Raw)
+
+	if fingerprint := cryptutils.CertFingerprint(oldCert); fingerprint != hex.EncodeToString(sum[:]) {
+		t.Errorf("Wrong certificate fingerprint: %s", fingerprint)
+	}
+
+	if cryptutils.SPKIPin(oldCert) == cryptutils.SPKIPin(newCert) {
+		t.Error("Different keys should have different pins")
+	}
+
+	pinSet := cryptutils.PinSet{
+		Pins:         []string{cryptutils.SPKIPinPrefix + cryptutils.SPKIPin(newCert)},
+		PreviousPins: []string{cryptutils.SPKIPin(oldCert)},
+		GraceUntil:   time.Now().Add(time.Hour),
+	}
+
+	// Previous pin is accepted during grace period
+
+	if err = cryptutils.VerifyPinnedPeer(pinSet)([][]byte{oldCert.Raw}, nil); err != nil {
+		t.Errorf("Old certificate should be accepted: %v", err)
+	}
+
+	pinSet.GraceUntil = time.Now().Add(-time.Hour)
+
+	if err = cryptutils.VerifyPinnedPeer(pinSet)([][]byte{oldCert.Raw}, nil); err == nil {
+		t.Error("Old certificate should not be accepted after grace period")
+	}
+
+	if err = cryptutils.VerifyPinnedPeer(pinSet)(nil, [][]*x509.Certificate{{newCert, rootCert}}); err != nil {
+		t.Errorf("New certificate should be accepted: %v", err)
+	}
+
+	// Pinning root CA accepts any certificate issued by it
+
+	pinSet = cryptutils.PinSet{Pins: []string{cryptutils.SPKIPin(rootCert)}}
+
+	if !pinSet.Matches([]*x509.Certificate{oldCert, rootCert}, time.Now()) ||
+		pinSet.Matches([]*x509.Certificate{oldCert}, time.Now()) {
+		t.Error("Wrong root CA pin matching")
+	}
 }
 
 func TestParsePKCS11URL(t *testing.T) {
@@ -536,6 +1572,28 @@ func TestParsePKCS11URL(t *testing.T) {
  * Private
  **********************************************************************************************************************/
 
+func (loader *testKeyLoader) LoadCertificate(
+	cryptoContext *cryptutils.CryptoContext, certURL *url.URL,
+) ([]*x509.Certificate, error) {
+	certs, ok := loader.certs[certURL.Host]
+	if !ok {
+		return nil, aoserrors.New("certificate not found")
+	}
+
+	return certs, nil
+}
+
+func (loader *testKeyLoader) LoadPrivateKey(
+	cryptoContext *cryptutils.CryptoContext, keyURL *url.URL,
+) (privKey crypto.PrivateKey, supportPKCS1v15SessionKey bool, err error) {
+	privKey, ok := loader.keys[keyURL.Host]
+	if !ok {
+		return nil, false, aoserrors.New("key not found")
+	}
+
+	return privKey, false, nil
+}
+
 func savePEMFile(data []byte) (fileName string, err error) {
 	file, err := os.CreateTemp(tmpDir, "*."+cryptutils.PEMExt)
 	if err != nil {
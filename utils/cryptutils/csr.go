@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"net"
+	"net/url"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// CSRParams certificate signing request parameters.
+type CSRParams struct {
+	Subject         pkix.Name
+	DNSNames        []string
+	IPAddresses     []net.IP
+	URIs            []*url.URL
+	EmailAddresses  []string
+	ExtKeyUsages    []x509.ExtKeyUsage
+	ExtraExtensions []pkix.Extension
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var (
+	oidExtensionExtKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37}
+
+	extKeyUsageOIDs = map[x509.ExtKeyUsage]asn1.ObjectIdentifier{
+		x509.ExtKeyUsageAny:             {2, 5, 29, 37, 0},
+		x509.ExtKeyUsageServerAuth:      {1, 3, 6, 1, 5, 5, 7, 3, 1},
+		x509.ExtKeyUsageClientAuth:      {1, 3, 6, 1, 5, 5, 7, 3, 2},
+		x509.ExtKeyUsageCodeSigning:     {1, 3, 6, 1, 5, 5, 7, 3, 3},
+		x509.ExtKeyUsageEmailProtection: {1, 3, 6, 1, 5, 5, 7, 3, 4},
+		x509.ExtKeyUsageTimeStamping:    {1, 3, 6, 1, 5, 5, 7, 3, 8},
+		x509.ExtKeyUsageOCSPSigning:     {1, 3, 6, 1, 5, 5, 7, 3, 9},
+	}
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// CreateCSR creates PEM encoded certificate signing request signed by key.
+func CreateCSR(key crypto.Signer, params CSRParams) (csr []byte, err error) {
+	template := &x509.CertificateRequest{
+		Subject:         params.Subject,
+		DNSNames:        params.DNSNames,
+		IPAddresses:     params.IPAddresses,
+		URIs:            params.URIs,
+		EmailAddresses:  params.EmailAddresses,
+		ExtraExtensions: params.ExtraExtensions,
+	}
+
+	if len(params.ExtKeyUsages) != 0 {
+		extension, err := marshalExtKeyUsages(params.ExtKeyUsages)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, extraExtension := range params.ExtraExtensions {
+			if extraExtension.Id.Equal(oidExtensionExtKeyUsage) {
+				return nil, aoserrors.New("extended key usage extension is specified twice")
+			}
+		}
+
+		template.ExtraExtensions = append(append([]pkix.Extension{}, params.ExtraExtensions...), extension)
+	}
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PEMBlockCertificateRequest, Bytes: csrDER}), nil
+}
+
+// PEMToX509CSR parses PEM encoded certificate signing request and checks its signature.
+func PEMToX509CSR(data []byte) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != PEMBlockCertificateRequest {
+		return nil, aoserrors.New("wrong CSR PEM format")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if err = csr.CheckSignature(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return csr, nil
+}
+
+// GetCSRExtKeyUsages returns extended key usages requested by certificate signing request.
+func GetCSRExtKeyUsages(csr *x509.CertificateRequest) (extKeyUsages []x509.ExtKeyUsage, err error) {
+	for _, extension := range csr.Extensions {
+		if !extension.Id.Equal(oidExtensionExtKeyUsage) {
+			continue
+		}
+
+		var oids []asn1.ObjectIdentifier
+
+		if _, err = asn1.Unmarshal(extension.Value, &oids); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+	nextOID:
+		for _, oid := range oids {
+			for usage, usageOID := range extKeyUsageOIDs {
+				if oid.Equal(usageOID) {
+					extKeyUsages = append(extKeyUsages, usage)
+
+					continue nextOID
+				}
+			}
+
+			return nil, aoserrors.Errorf("unsupported extended key usage: %s", oid)
+		}
+	}
+
+	return extKeyUsages, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func marshalExtKeyUsages(extKeyUsages []x509.ExtKeyUsage) (pkix.Extension, error) {
+	oids := make([]asn1.ObjectIdentifier, 0, len(extKeyUsages))
+
+	for _, usage := range extKeyUsages {
+		oid, ok := extKeyUsageOIDs[usage]
+		if !ok {
+			return pkix.Extension{}, aoserrors.Errorf("unsupported extended key usage: %d", usage)
+		}
+
+		oids = append(oids, oid)
+	}
+
+	value, err := asn1.Marshal(oids)
+	if err != nil {
+		return pkix.Extension{}, aoserrors.Wrap(err)
+	}
+
+	return pkix.Extension{Id: oidExtensionExtKeyUsage, Value: value}, nil
+}
@@ -0,0 +1,438 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // required by PKCS#5 default PRF
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"hash"
+	"os"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// PEMBlockEncryptedPrivateKey PEM block type of encrypted PKCS#8 private key.
+const PEMBlockEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+
+const (
+	pbkdf2Iterations = 100000
+	pbkdf2SaltSize   = 16
+	aes256KeySize    = 32
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// PassphraseProvider provides passphrase to decrypt private key identified by URL. Provider may ask user
+// interactively, read passphrase from file or unseal it from TPM.
+type PassphraseProvider func(keyURL string) (passphrase []byte, err error)
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// SetPassphraseProvider sets provider of passphrases for encrypted private key files.
+func (cryptoContext *CryptoContext) SetPassphraseProvider(passphraseProvider PassphraseProvider) {
+	cryptoContext.Lock()
+	defer cryptoContext.Unlock()
+
+	cryptoContext.passphraseProvider = passphraseProvider
+}
+
+// NewFilePassphraseProvider creates passphrase provider which reads passphrase from file.
+func NewFilePassphraseProvider(fileName string) PassphraseProvider {
+	return func(keyURL string) ([]byte, error) {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return []byte(strings.TrimRight(string(data), "\r\n")), nil
+	}
+}
+
+// IsEncryptedPEMKey checks if PEM data contains encrypted PKCS#8 or legacy encrypted PKCS#1 private key.
+func IsEncryptedPEMKey(data []byte) bool {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return false
+	}
+
+	//nolint:staticcheck // legacy PEM encryption is supported for reading only
+	return block.Type == PEMBlockEncryptedPrivateKey || x509.IsEncryptedPEMBlock(block)
+}
+
+// DecryptPEMPrivateKey decrypts and parses encrypted PKCS#8 or legacy encrypted PKCS#1 PEM private key.
+func DecryptPEMPrivateKey(data, passphrase []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, aoserrors.New("wrong private key PEM format")
+	}
+
+	if block.Type == PEMBlockEncryptedPrivateKey {
+		return decryptPKCS8PrivateKey(block.Bytes, passphrase)
+	}
+
+	//nolint:staticcheck // legacy PEM encryption is supported for reading only
+	if !x509.IsEncryptedPEMBlock(block) {
+		return nil, aoserrors.New("private key is not encrypted")
+	}
+
+	//nolint:staticcheck // legacy PEM encryption is supported for reading only
+	der, err := x509.DecryptPEMBlock(block, passphrase)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	key, err := parseKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// EncryptPrivateKeyToPEM encrypts private key with passphrase to PKCS#8 PEM format using PBES2 with
+// PBKDF2-HMAC-SHA256 and AES-256-CBC.
+func EncryptPrivateKeyToPEM(key crypto.PrivateKey, passphrase []byte) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	salt := make([]byte, pbkdf2SaltSize)
+	iv := make([]byte, aes.BlockSize)
+
+	if _, err = rand.Read(salt); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if _, err = rand.Read(iv); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	encryptionKey := pbkdf2Key(passphrase, salt, pbkdf2Iterations, aes256KeySize, sha256.New)
+
+	blockCipher, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	padding := aes.BlockSize - len(der)%aes.BlockSize
+	encrypted := append(der, bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	cipher.NewCBCEncrypter(blockCipher, iv).CryptBlocks(encrypted, encrypted)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt: salt, IterationCount: pbkdf2Iterations,
+		PRF: pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	ivParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	schemeParams, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParams}},
+	})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	data, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: schemeParams}},
+		EncryptedData: encrypted,
+	})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PEMBlockEncryptedPrivateKey, Bytes: data}), nil
+}
+
+// SaveEncryptedPrivateKeyToFile encrypts private key with passphrase and saves it to file.
+func SaveEncryptedPrivateKeyToFile(fileName string, key crypto.PrivateKey, passphrase []byte) error {
+	keyPem, err := EncryptPrivateKeyToPEM(key, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(fileName, keyPem, 0o600); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+// ReencryptPrivateKeyFile re-encrypts private key file with new passphrase. If old passphrase is nil, key file is
+// expected to be unencrypted. If new passphrase is nil, key is stored unencrypted.
+func ReencryptPrivateKeyFile(fileName string, oldPassphrase, newPassphrase []byte) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	var key crypto.PrivateKey
+
+	if oldPassphrase != nil {
+		key, err = DecryptPEMPrivateKey(data, oldPassphrase)
+	} else {
+		key, err = PEMToX509PrivateKey(data)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if newPassphrase == nil {
+		return SavePrivateKeyToFile(fileName, key)
+	}
+
+	return SaveEncryptedPrivateKeyToFile(fileName, key, newPassphrase)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (cryptoContext *CryptoContext) loadPrivateKeyFromFile(keyURL, fileName string) (crypto.PrivateKey, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if !IsEncryptedPEMKey(data) {
+		return PEMToX509PrivateKey(data)
+	}
+
+	cryptoContext.Lock()
+	passphraseProvider := cryptoContext.passphraseProvider
+	cryptoContext.Unlock()
+
+	if passphraseProvider == nil {
+		return nil, aoserrors.New("private key is encrypted but passphrase provider is not set")
+	}
+
+	passphrase, err := passphraseProvider(keyURL)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return DecryptPEMPrivateKey(data, passphrase)
+}
+
+func decryptPKCS8PrivateKey(data, passphrase []byte) (crypto.PrivateKey, error) {
+	var keyInfo encryptedPrivateKeyInfo
+
+	if _, err := asn1.Unmarshal(data, &keyInfo); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if !keyInfo.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, aoserrors.Errorf("unsupported key encryption algorithm: %s", keyInfo.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+
+	if _, err := asn1.Unmarshal(keyInfo.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	encryptionKey, err := getPBES2Key(params, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var iv []byte
+
+	if _, err = asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	blockCipher, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if len(iv) != aes.BlockSize || len(keyInfo.EncryptedData) == 0 ||
+		len(keyInfo.EncryptedData)%aes.BlockSize != 0 {
+		return nil, aoserrors.New("invalid encrypted key data")
+	}
+
+	decrypted := make([]byte, len(keyInfo.EncryptedData))
+
+	cipher.NewCBCDecrypter(blockCipher, iv).CryptBlocks(decrypted, keyInfo.EncryptedData)
+
+	padding := int(decrypted[len(decrypted)-1])
+	if padding == 0 || padding > aes.BlockSize ||
+		!bytes.Equal(decrypted[len(decrypted)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, aoserrors.New("invalid passphrase")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(decrypted[:len(decrypted)-padding])
+	if err != nil {
+		return nil, aoserrors.New("invalid passphrase")
+	}
+
+	return key, nil
+}
+
+func getPBES2Key(params pbes2Params, passphrase []byte) ([]byte, error) {
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, aoserrors.Errorf("unsupported key derivation function: %s", params.KeyDerivationFunc.Algorithm)
+	}
+
+	var keySize int
+
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keySize = 16
+
+	case params.EncryptionScheme.Algorithm.Equal(oidAES192CBC):
+		keySize = 24
+
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keySize = aes256KeySize
+
+	default:
+		return nil, aoserrors.Errorf("unsupported encryption scheme: %s", params.EncryptionScheme.Algorithm)
+	}
+
+	var kdfParams pbkdf2Params
+
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if kdfParams.KeyLength != 0 && kdfParams.KeyLength != keySize {
+		return nil, aoserrors.Errorf("wrong key length: %d", kdfParams.KeyLength)
+	}
+
+	var hashFunc func() hash.Hash
+
+	switch {
+	case len(kdfParams.PRF.Algorithm) == 0, kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		hashFunc = sha1.New
+
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		hashFunc = sha256.New
+
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA384):
+		hashFunc = sha512.New384
+
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		hashFunc = sha512.New
+
+	default:
+		return nil, aoserrors.Errorf("unsupported PBKDF2 PRF: %s", kdfParams.PRF.Algorithm)
+	}
+
+	return pbkdf2Key(passphrase, kdfParams.Salt, kdfParams.IterationCount, keySize, hashFunc), nil
+}
+
+// pbkdf2Key derives key as defined in RFC 8018 section 5.2.
+func pbkdf2Key(password, salt []byte, iterations, keyLen int, hashFunc func() hash.Hash) []byte {
+	prf := hmac.New(hashFunc, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	key := make([]byte, 0, numBlocks*hashLen)
+	counter := make([]byte, 4) //nolint:mnd
+
+	for block := 1; block <= numBlocks; block++ {
+		binary.BigEndian.PutUint32(counter, uint32(block))
+
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter)
+
+		u := prf.Sum(nil)
+		t := append([]byte(nil), u...)
+
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		key = append(key, t...)
+	}
+
+	return key[:keyLen]
+}
@@ -0,0 +1,627 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"bufio"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/tpmkey"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Envelope algorithms.
+const (
+	EnvelopeAlgAES256GCM        = "AES-256-GCM"
+	EnvelopeAlgChaCha20Poly1305 = "CHACHA20-POLY1305"
+	EnvelopeAlgRSAOAEP256       = "RSA-OAEP-256"
+	EnvelopeAlgECIESSHA256      = "ECIES-SHA256-AES-256-GCM"
+	EnvelopeVersion             = 1
+	DefaultEnvelopeChunkSize    = 64 * 1024
+)
+
+const (
+	dekSize               = 32
+	envelopeNoncePrefix   = 7
+	maxEnvelopeHeaderSize = 64 * 1024
+	maxEnvelopeChunkSize  = 1024 * 1024
+	lastChunkFlag         = 1
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// EnvelopeRecipient data encryption key wrapped for recipient.
+type EnvelopeRecipient struct {
+	KeyID        string `json:"keyId"`
+	Algorithm    string `json:"algorithm"`
+	EphemeralKey []byte `json:"ephemeralKey,omitempty"`
+	WrappedKey   []byte `json:"wrappedKey"`
+}
+
+// EnvelopeHeader envelope header stored in front of encrypted data.
+type EnvelopeHeader struct {
+	Version     int                 `json:"version"`
+	Algorithm   string              `json:"algorithm"`
+	ChunkSize   int                 `json:"chunkSize"`
+	NoncePrefix []byte              `json:"noncePrefix"`
+	Recipients  []EnvelopeRecipient `json:"recipients"`
+}
+
+type envelopeEncrypter struct {
+	writer   io.Writer
+	aead     cipher.AEAD
+	header   EnvelopeHeader
+	headerAD []byte
+	buffer   []byte
+	counter  uint32
+	closed   bool
+}
+
+type envelopeDecrypter struct {
+	reader    *bufio.Reader
+	aead      cipher.AEAD
+	header    EnvelopeHeader
+	headerAD  []byte
+	encrypted []byte
+	chunk     []byte
+	counter   uint32
+	finished  bool
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// GenerateDEK generates random data encryption key.
+func GenerateDEK() ([]byte, error) {
+	dek := make([]byte, dekSize)
+
+	if _, err := rand.Read(dek); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return dek, nil
+}
+
+// GetKeyID returns key identifier: hex encoded SHA256 of PKIX encoded public key.
+func GetKeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", aoserrors.Wrap(err)
+	}
+
+	sum := sha256.Sum256(der)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// WrapDEK wraps data encryption key for recipient public key using RSA-OAEP or ECIES.
+func WrapDEK(dek []byte, publicKey crypto.PublicKey) (recipient EnvelopeRecipient, err error) {
+	if recipient.KeyID, err = GetKeyID(publicKey); err != nil {
+		return recipient, err
+	}
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		recipient.Algorithm = EnvelopeAlgRSAOAEP256
+
+		if recipient.WrappedKey, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, key, dek, nil); err != nil {
+			return recipient, aoserrors.Wrap(err)
+		}
+
+	case *ecdsa.PublicKey:
+		recipient.Algorithm = EnvelopeAlgECIESSHA256
+
+		if recipient.EphemeralKey, recipient.WrappedKey, err = wrapECIES(dek, key); err != nil {
+			return recipient, err
+		}
+
+	default:
+		return recipient, aoserrors.Errorf("unsupported recipient key type: %T", publicKey)
+	}
+
+	return recipient, nil
+}
+
+// UnwrapDEK unwraps data encryption key with recipient private key. Private key should be crypto.Decrypter for
+// RSA-OAEP and *ecdsa.PrivateKey or tpmkey.ECDHKey for ECIES.
+func UnwrapDEK(recipient EnvelopeRecipient, privateKey crypto.PrivateKey) (dek []byte, err error) {
+	switch recipient.Algorithm {
+	case EnvelopeAlgRSAOAEP256:
+		decrypter, ok := privateKey.(crypto.Decrypter)
+		if !ok {
+			return nil, aoserrors.New("private key doesn't support decryption")
+		}
+
+		if dek, err = decrypter.Decrypt(
+			rand.Reader, recipient.WrappedKey, &rsa.OAEPOptions{Hash: crypto.SHA256}); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		return dek, nil
+
+	case EnvelopeAlgECIESSHA256:
+		return unwrapECIES(recipient.EphemeralKey, recipient.WrappedKey, privateKey)
+
+	default:
+		return nil, aoserrors.Errorf("unsupported key wrap algorithm: %s", recipient.Algorithm)
+	}
+}
+
+// NewEnvelopeEncrypter creates writer which encrypts data in chunks with random data encryption key wrapped for
+// each recipient. AES-256-GCM is used for data encryption. Close must be called to write the final chunk.
+func NewEnvelopeEncrypter(writer io.Writer, recipients ...crypto.PublicKey) (io.WriteCloser, error) {
+	return NewEnvelopeEncrypterWithAlgorithm(writer, EnvelopeAlgAES256GCM, recipients...)
+}
+
+// NewEnvelopeEncrypterWithAlgorithm creates envelope encrypter which uses specified data encryption algorithm:
+// AES-256-GCM or ChaCha20-Poly1305. ChaCha20-Poly1305 is preferable on platforms without AES hardware support.
+func NewEnvelopeEncrypterWithAlgorithm(
+	writer io.Writer, algorithm string, recipients ...crypto.PublicKey,
+) (io.WriteCloser, error) {
+	if len(recipients) == 0 {
+		return nil, aoserrors.New("no envelope recipients")
+	}
+
+	dek, err := GenerateDEK()
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newEnvelopeAEAD(algorithm, dek)
+	if err != nil {
+		return nil, err
+	}
+
+	header := EnvelopeHeader{
+		Version: EnvelopeVersion, Algorithm: algorithm, ChunkSize: DefaultEnvelopeChunkSize,
+		NoncePrefix: make([]byte, envelopeNoncePrefix),
+	}
+
+	if _, err = rand.Read(header.NoncePrefix); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	for _, publicKey := range recipients {
+		recipient, err := WrapDEK(dek, publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		header.Recipients = append(header.Recipients, recipient)
+	}
+
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if err = binary.Write(writer, binary.BigEndian, uint32(len(headerData))); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if _, err = writer.Write(headerData); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return &envelopeEncrypter{writer: writer, aead: aead, header: header, headerAD: headerData}, nil
+}
+
+// NewEnvelopeDecrypter creates reader which decrypts envelope with recipient private key.
+func NewEnvelopeDecrypter(reader io.Reader, privateKey crypto.PrivateKey) (io.Reader, error) {
+	bufReader := bufio.NewReader(reader)
+
+	var headerSize uint32
+
+	if err := binary.Read(bufReader, binary.BigEndian, &headerSize); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if headerSize > maxEnvelopeHeaderSize {
+		return nil, aoserrors.Errorf("envelope header too big: %d", headerSize)
+	}
+
+	headerData := make([]byte, headerSize)
+
+	if _, err := io.ReadFull(bufReader, headerData); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	var header EnvelopeHeader
+
+	if err := json.Unmarshal(headerData, &header); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if header.Version != EnvelopeVersion ||
+		(header.Algorithm != EnvelopeAlgAES256GCM && header.Algorithm != EnvelopeAlgChaCha20Poly1305) {
+		return nil, aoserrors.Errorf("unsupported envelope version %d algorithm %s", header.Version, header.Algorithm)
+	}
+
+	if header.ChunkSize <= 0 || header.ChunkSize > maxEnvelopeChunkSize ||
+		len(header.NoncePrefix) != envelopeNoncePrefix {
+		return nil, aoserrors.New("invalid envelope header")
+	}
+
+	dek, err := unwrapRecipientDEK(header.Recipients, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newEnvelopeAEAD(header.Algorithm, dek)
+	if err != nil {
+		return nil, err
+	}
+
+	return &envelopeDecrypter{
+		reader: bufReader, aead: aead, header: header, headerAD: headerData,
+		encrypted: make([]byte, header.ChunkSize+aead.Overhead()),
+	}, nil
+}
+
+// EncryptFile encrypts source file to destination envelope file for recipients.
+func EncryptFile(srcFile, dstFile string, recipients ...crypto.PublicKey) (err error) {
+	src, err := os.Open(srcFile)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(dstFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if closeErr := dst.Close(); closeErr != nil && err == nil {
+			err = aoserrors.Wrap(closeErr)
+		}
+	}()
+
+	encrypter, err := NewEnvelopeEncrypter(dst, recipients...)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(encrypter, src); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return aoserrors.Wrap(encrypter.Close())
+}
+
+// DecryptFile decrypts source envelope file to destination file with recipient private key.
+func DecryptFile(srcFile, dstFile string, privateKey crypto.PrivateKey) (err error) {
+	src, err := os.Open(srcFile)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer src.Close()
+
+	decrypter, err := NewEnvelopeDecrypter(src, privateKey)
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(dstFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if closeErr := dst.Close(); closeErr != nil && err == nil {
+			err = aoserrors.Wrap(closeErr)
+		}
+	}()
+
+	if _, err = io.Copy(dst, decrypter); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+// Write encrypts data. Full chunks are written when the next data arrives to detect the final chunk on Close.
+func (encrypter *envelopeEncrypter) Write(data []byte) (n int, err error) {
+	if encrypter.closed {
+		return 0, aoserrors.New("envelope is closed")
+	}
+
+	encrypter.buffer = append(encrypter.buffer, data...)
+
+	for len(encrypter.buffer) > encrypter.header.ChunkSize {
+		if err = encrypter.writeChunk(encrypter.buffer[:encrypter.header.ChunkSize], false); err != nil {
+			return 0, err
+		}
+
+		encrypter.buffer = encrypter.buffer[encrypter.header.ChunkSize:]
+	}
+
+	return len(data), nil
+}
+
+// Close writes the final chunk.
+func (encrypter *envelopeEncrypter) Close() error {
+	if encrypter.closed {
+		return nil
+	}
+
+	encrypter.closed = true
+
+	return encrypter.writeChunk(encrypter.buffer, true)
+}
+
+// Read reads decrypted data.
+func (decrypter *envelopeDecrypter) Read(data []byte) (n int, err error) {
+	for len(decrypter.chunk) == 0 {
+		if decrypter.finished {
+			return 0, io.EOF
+		}
+
+		if err = decrypter.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+
+	n = copy(data, decrypter.chunk)
+	decrypter.chunk = decrypter.chunk[n:]
+
+	return n, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newEnvelopeAEAD(algorithm string, key []byte) (aead cipher.AEAD, err error) {
+	switch algorithm {
+	case EnvelopeAlgAES256GCM:
+		var block cipher.Block
+
+		if block, err = aes.NewCipher(key); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		if aead, err = cipher.NewGCM(block); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+	case EnvelopeAlgChaCha20Poly1305:
+		if aead, err = chacha20poly1305.New(key); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+	default:
+		return nil, aoserrors.Errorf("unsupported envelope algorithm: %s", algorithm)
+	}
+
+	return aead, nil
+}
+
+// getChunkNonce returns STREAM nonce: prefix || chunk counter || last chunk flag.
+func getChunkNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, len(prefix)+5) //nolint:mnd
+
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+
+	if last {
+		return append(nonce, lastChunkFlag)
+	}
+
+	return append(nonce, 0)
+}
+
+func (encrypter *envelopeEncrypter) writeChunk(data []byte, last bool) error {
+	nonce := getChunkNonce(encrypter.header.NoncePrefix, encrypter.counter, last)
+
+	encrypter.counter++
+
+	if _, err := encrypter.writer.Write(encrypter.aead.Seal(nil, nonce, data, encrypter.headerAD)); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func (decrypter *envelopeDecrypter) readChunk() error {
+	n, err := io.ReadFull(decrypter.reader, decrypter.encrypted)
+
+	switch {
+	case err == nil:
+		if _, peekErr := decrypter.reader.Peek(1); errors.Is(peekErr, io.EOF) {
+			decrypter.finished = true
+		}
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		decrypter.finished = true
+
+	case errors.Is(err, io.EOF):
+		return aoserrors.New("envelope is truncated")
+
+	default:
+		return aoserrors.Wrap(err)
+	}
+
+	nonce := getChunkNonce(decrypter.header.NoncePrefix, decrypter.counter, decrypter.finished)
+
+	decrypter.counter++
+
+	if decrypter.chunk, err = decrypter.aead.Open(
+		decrypter.encrypted[:0], nonce, decrypter.encrypted[:n], decrypter.headerAD); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func unwrapRecipientDEK(recipients []EnvelopeRecipient, privateKey crypto.PrivateKey) ([]byte, error) {
+	var keyID string
+
+	if signer, ok := privateKey.(crypto.Signer); ok {
+		keyID, _ = GetKeyID(signer.Public())
+	}
+
+	for _, recipient := range recipients {
+		if keyID != "" && recipient.KeyID != keyID {
+			continue
+		}
+
+		if dek, err := UnwrapDEK(recipient, privateKey); err == nil {
+			return dek, nil
+		}
+	}
+
+	return nil, aoserrors.New("no matching envelope recipient")
+}
+
+func getECDHCurve(curve elliptic.Curve) (ecdh.Curve, error) {
+	switch curve {
+	case elliptic.P256():
+		return ecdh.P256(), nil
+
+	case elliptic.P384():
+		return ecdh.P384(), nil
+
+	case elliptic.P521():
+		return ecdh.P521(), nil
+
+	default:
+		return nil, aoserrors.New("unsupported curve")
+	}
+}
+
+// getECIESKEK derives key encryption key with ANSI X9.63 KDF using ephemeral public key as shared info.
+func getECIESKEK(sharedSecret, ephemeralKey []byte) []byte {
+	hash := sha256.New()
+
+	hash.Write(sharedSecret)
+	_ = binary.Write(hash, binary.BigEndian, uint32(1))
+	hash.Write(ephemeralKey)
+
+	return hash.Sum(nil)
+}
+
+func wrapECIES(dek []byte, publicKey *ecdsa.PublicKey) (ephemeralKey, wrappedKey []byte, err error) {
+	curve, err := getECDHCurve(publicKey.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recipientKey, err := publicKey.ECDH()
+	if err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	ephemeralPrivKey, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	sharedSecret, err := ephemeralPrivKey.ECDH(recipientKey)
+	if err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+
+	ephemeralKey = ephemeralPrivKey.PublicKey().Bytes()
+
+	aead, err := newEnvelopeAEAD(EnvelopeAlgAES256GCM, getECIESKEK(sharedSecret, ephemeralKey))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// KEK is unique for each ephemeral key, so zero nonce is safe
+	return ephemeralKey, aead.Seal(nil, make([]byte, aead.NonceSize()), dek, nil), nil
+}
+
+func unwrapECIES(ephemeralKey, wrappedKey []byte, privateKey crypto.PrivateKey) (dek []byte, err error) {
+	var sharedSecret []byte
+
+	switch key := privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		ecdhKey, err := key.ECDH()
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		ephemeralPubKey, err := ecdhKey.Curve().NewPublicKey(ephemeralKey)
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		if sharedSecret, err = ecdhKey.ECDH(ephemeralPubKey); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+	case tpmkey.ECDHKey:
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, aoserrors.New("can't get ECDH key public key")
+		}
+
+		publicKey, ok := signer.Public().(*ecdsa.PublicKey)
+		if !ok {
+			return nil, aoserrors.New("ECDH key is not ECC key")
+		}
+
+		x, y := elliptic.Unmarshal(publicKey.Curve, ephemeralKey) //nolint:staticcheck
+		if x == nil {
+			return nil, aoserrors.New("invalid ephemeral key")
+		}
+
+		if sharedSecret, err = key.ECDH(&ecdsa.PublicKey{Curve: publicKey.Curve, X: x, Y: y}); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+	default:
+		return nil, aoserrors.Errorf("unsupported ECIES private key type: %T", privateKey)
+	}
+
+	aead, err := newEnvelopeAEAD(EnvelopeAlgAES256GCM, getECIESKEK(sharedSecret, ephemeralKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if dek, err = aead.Open(nil, make([]byte, aead.NonceSize()), wrappedKey, nil); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return dek, nil
+}
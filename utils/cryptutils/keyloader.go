@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto"
+	"crypto/x509"
+	"net/url"
+	"sync"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// KeyLoader loads certificates and private keys referenced by URL of specific scheme.
+type KeyLoader interface {
+	LoadCertificate(cryptoContext *CryptoContext, certURL *url.URL) ([]*x509.Certificate, error)
+	LoadPrivateKey(cryptoContext *CryptoContext, keyURL *url.URL) (
+		privKey crypto.PrivateKey, supportPKCS1v15SessionKey bool, err error)
+}
+
+// SignerLoader may be optionally implemented by key loader to provide own crypto signer implementation.
+type SignerLoader interface {
+	LoadSigner(cryptoContext *CryptoContext, keyURL *url.URL) (crypto.Signer, error)
+}
+
+type fileKeyLoader struct{}
+
+type tpmKeyLoader struct{}
+
+type pkcs11KeyLoader struct{}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var (
+	keyLoadersMutex sync.RWMutex
+	keyLoaders      = map[string]KeyLoader{
+		SchemeFile:   fileKeyLoader{},
+		SchemeTPM:    tpmKeyLoader{},
+		SchemePKCS11: pkcs11KeyLoader{},
+	}
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// RegisterKeyLoader registers key loader for URL scheme. Loader of already registered scheme is replaced.
+func RegisterKeyLoader(scheme string, loader KeyLoader) error {
+	if scheme == "" || loader == nil {
+		return aoserrors.New("wrong key loader")
+	}
+
+	keyLoadersMutex.Lock()
+	defer keyLoadersMutex.Unlock()
+
+	keyLoaders[scheme] = loader
+
+	return nil
+}
+
+// UnregisterKeyLoader removes key loader of URL scheme.
+func UnregisterKeyLoader(scheme string) {
+	keyLoadersMutex.Lock()
+	defer keyLoadersMutex.Unlock()
+
+	delete(keyLoaders, scheme)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func getKeyLoader(scheme string) (loader KeyLoader, ok bool) {
+	keyLoadersMutex.RLock()
+	defer keyLoadersMutex.RUnlock()
+
+	loader, ok = keyLoaders[scheme]
+
+	return loader, ok
+}
+
+func (fileKeyLoader) LoadCertificate(cryptoContext *CryptoContext, certURL *url.URL) ([]*x509.Certificate, error) {
+	return LoadCertificateFromFile(certURL.Path)
+}
+
+func (fileKeyLoader) LoadPrivateKey(cryptoContext *CryptoContext, keyURL *url.URL) (
+	privKey crypto.PrivateKey, supportPKCS1v15SessionKey bool, err error,
+) {
+	if privKey, err = cryptoContext.loadPrivateKeyFromFile(keyURL.String(), keyURL.Path); err != nil {
+		return nil, false, err
+	}
+
+	return privKey, true, nil
+}
+
+func (tpmKeyLoader) LoadCertificate(cryptoContext *CryptoContext, certURL *url.URL) ([]*x509.Certificate, error) {
+	return nil, aoserrors.Errorf("unsupported schema %s for certificate", certURL.Scheme)
+}
+
+func (tpmKeyLoader) LoadPrivateKey(cryptoContext *CryptoContext, keyURL *url.URL) (
+	privKey crypto.PrivateKey, supportPKCS1v15SessionKey bool, err error,
+) {
+	if privKey, err = cryptoContext.loadPrivateKeyFromTPM(keyURL); err != nil {
+		return nil, false, err
+	}
+
+	return privKey, false, nil
+}
+
+func (pkcs11KeyLoader) LoadCertificate(cryptoContext *CryptoContext, certURL *url.URL) ([]*x509.Certificate, error) {
+	return cryptoContext.loadCertificateFromPKCS11(certURL.String())
+}
+
+func (pkcs11KeyLoader) LoadPrivateKey(cryptoContext *CryptoContext, keyURL *url.URL) (
+	privKey crypto.PrivateKey, supportPKCS1v15SessionKey bool, err error,
+) {
+	if privKey, err = cryptoContext.loadPrivateKeyFromPKCS11(keyURL.String()); err != nil {
+		return nil, false, err
+	}
+
+	return privKey, false, nil
+}
+
+func (pkcs11KeyLoader) LoadSigner(cryptoContext *CryptoContext, keyURL *url.URL) (crypto.Signer, error) {
+	signer := &pkcs11Signer{cryptoContext: cryptoContext, keyURL: keyURL.String()}
+
+	if err := signer.load(); err != nil {
+		return nil, err
+	}
+
+	return signer, nil
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// SignMessage signs cloud message with signer key. Certs is signer certificate chain starting from the leaf
+// certificate.
+func SignMessage(
+	message cloudprotocol.Message, signer crypto.Signer, certs []*x509.Certificate,
+) (signedMessage *cloudprotocol.SignedMessage, err error) {
+	if len(certs) == 0 {
+		return nil, aoserrors.New("signer certificate chain is empty")
+	}
+
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	signedMessage = &cloudprotocol.SignedMessage{Header: message.Header, Data: data}
+
+	switch signer.Public().(type) {
+	case *rsa.PublicKey:
+		signedMessage.Signature.Alg = cloudprotocol.SignatureAlgRS256
+
+	case *ecdsa.PublicKey:
+		signedMessage.Signature.Alg = cloudprotocol.SignatureAlgES256
+
+	default:
+		return nil, aoserrors.New("unsupported signer key type")
+	}
+
+	digest, err := getSignedMessageDigest(signedMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if signedMessage.Signature.Value, err = signer.Sign(rand.Reader, digest, crypto.SHA256); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	for _, cert := range certs {
+		signedMessage.Signature.CertificateChain += string(CertToPEM(cert))
+	}
+
+	return signedMessage, nil
+}
+
+// VerifyMessage verifies signed cloud message against root certificates and returns received message.
+func VerifyMessage(
+	signedMessage cloudprotocol.SignedMessage, roots *x509.CertPool,
+) (message cloudprotocol.ReceivedMessage, err error) {
+	certs, err := PEMToX509Cert([]byte(signedMessage.Signature.CertificateChain))
+	if err != nil {
+		return message, err
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err = certs[0].Verify(x509.VerifyOptions{
+		Roots: roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return message, aoserrors.Wrap(err)
+	}
+
+	digest, err := getSignedMessageDigest(&signedMessage)
+	if err != nil {
+		return message, err
+	}
+
+	if err = verifySignature(certs[0].PublicKey, signedMessage.Signature, digest); err != nil {
+		return message, err
+	}
+
+	return cloudprotocol.ReceivedMessage{Header: signedMessage.Header, Data: signedMessage.Data}, nil
+}
+
+// VerifyMessage verifies signed cloud message against crypto context root certificates.
+func (cryptoContext *CryptoContext) VerifyMessage(
+	signedMessage cloudprotocol.SignedMessage,
+) (message cloudprotocol.ReceivedMessage, err error) {
+	return VerifyMessage(signedMessage, cryptoContext.rootCertPool)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+// getSignedMessageDigest returns digest of message header and compacted data.
+func getSignedMessageDigest(signedMessage *cloudprotocol.SignedMessage) (digest []byte, err error) {
+	signingInput, err := json.Marshal(cloudprotocol.Message{
+		Header: signedMessage.Header, Data: signedMessage.Data,
+	})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	sum := sha256.Sum256(signingInput)
+
+	return sum[:], nil
+}
+
+func verifySignature(publicKey crypto.PublicKey, signature cloudprotocol.MessageSignature, digest []byte) error {
+	switch signature.Alg {
+	case cloudprotocol.SignatureAlgRS256:
+		rsaKey, ok := publicKey.(*rsa.PublicKey)
+		if !ok {
+			return aoserrors.New("signature algorithm doesn't match certificate key")
+		}
+
+		return aoserrors.Wrap(rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest, signature.Value))
+
+	case cloudprotocol.SignatureAlgES256:
+		ecdsaKey, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return aoserrors.New("signature algorithm doesn't match certificate key")
+		}
+
+		if !ecdsa.VerifyASN1(ecdsaKey, digest, signature.Value) {
+			return aoserrors.New("invalid message signature")
+		}
+
+		return nil
+
+	default:
+		return aoserrors.Errorf("unsupported signature algorithm: %s", signature.Alg)
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// SPKIPinPrefix optional prefix of SPKI pin.
+const SPKIPinPrefix = "sha256/"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// PinSet set of SPKI pins (see SPKIPin) accepted for peer certificate chain. PreviousPins are accepted together
+// with Pins until GraceUntil to allow keys rotation, without time limit if GraceUntil is not set.
+type PinSet struct {
+	Pins         []string  `json:"pins"`
+	PreviousPins []string  `json:"previousPins,omitempty"`
+	GraceUntil   time.Time `json:"graceUntil,omitempty"`
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// CertFingerprint returns hex encoded SHA-256 fingerprint of DER encoded certificate.
+func CertFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// SPKIPin returns base64 encoded SHA-256 hash of certificate subject public key info.
+func SPKIPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Matches checks if any certificate of chain matches pin set at specified time.
+func (pinSet PinSet) Matches(chain []*x509.Certificate, now time.Time) bool {
+	pins := pinSet.Pins
+
+	if pinSet.GraceUntil.IsZero() || now.Before(pinSet.GraceUntil) {
+		pins = append(pins[:len(pins):len(pins)], pinSet.PreviousPins...)
+	}
+
+	for _, cert := range chain {
+		certPin := SPKIPin(cert)
+
+		for _, pin := range pins {
+			if strings.TrimPrefix(pin, SPKIPinPrefix) == certPin {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// VerifyPinnedPeer returns tls.Config VerifyPeerCertificate function which rejects peers without certificate matching
+// pin set. Verified chains are checked if available, raw peer certificates otherwise.
+func VerifyPinnedPeer(pinSet PinSet) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		now := time.Now()
+
+		if len(verifiedChains) == 0 {
+			chain := make([]*x509.Certificate, 0, len(rawCerts))
+
+			for _, rawCert := range rawCerts {
+				cert, err := x509.ParseCertificate(rawCert)
+				if err != nil {
+					return aoserrors.Wrap(err)
+				}
+
+				chain = append(chain, cert)
+			}
+
+			verifiedChains = [][]*x509.Certificate{chain}
+		}
+
+		for _, chain := range verifiedChains {
+			if pinSet.Matches(chain, now) {
+				return nil
+			}
+		}
+
+		return aoserrors.New("peer certificate does not match pin set")
+	}
+}
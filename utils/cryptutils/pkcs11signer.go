@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/url"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// PKCS11PINProvider provides user PIN for PKCS11 token if PIN is not set in the URL.
+type PKCS11PINProvider func(library, token string) (userPIN string, err error)
+
+// pkcs11Signer PKCS11 key which reopens token session and reloads key when token is reset.
+type pkcs11Signer struct {
+	sync.Mutex
+
+	cryptoContext *CryptoContext
+	keyURL        string
+	key           crypto.Signer
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// PKCS11 return codes which mean that token session is lost and should be reopened.
+//
+//nolint:gochecknoglobals
+var pkcs11ResetErrors = []pkcs11.Error{
+	pkcs11.CKR_SESSION_HANDLE_INVALID,
+	pkcs11.CKR_SESSION_CLOSED,
+	pkcs11.CKR_DEVICE_REMOVED,
+	pkcs11.CKR_DEVICE_ERROR,
+	pkcs11.CKR_TOKEN_NOT_PRESENT,
+	pkcs11.CKR_TOKEN_NOT_RECOGNIZED,
+	pkcs11.CKR_USER_NOT_LOGGED_IN,
+}
+
+// loadPKCS11PrivateKey loads PKCS11 private key, replaced in tests to emulate token reset.
+//
+//nolint:gochecknoglobals
+var loadPKCS11PrivateKey = (*CryptoContext).loadPrivateKeyFromPKCS11
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// SetPKCS11PINProvider sets provider of PKCS11 user PIN used when PIN is not specified in the URL.
+func (cryptoContext *CryptoContext) SetPKCS11PINProvider(pinProvider PKCS11PINProvider) {
+	cryptoContext.Lock()
+	defer cryptoContext.Unlock()
+
+	cryptoContext.pkcs11PINProvider = pinProvider
+}
+
+// LoadSignerByURL loads private key by URL as crypto signer. PKCS11 signer transparently reopens token session
+// and reloads the key if token is reset.
+func (cryptoContext *CryptoContext) LoadSignerByURL(keyURLStr string) (crypto.Signer, error) {
+	keyURL, err := url.Parse(keyURLStr)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if loader, ok := getKeyLoader(keyURL.Scheme); ok {
+		if signerLoader, ok := loader.(SignerLoader); ok {
+			signer, err := signerLoader.LoadSigner(cryptoContext, keyURL)
+			if err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+
+			return signer, nil
+		}
+	}
+
+	privKey, _, err := cryptoContext.LoadPrivateKeyByURL(keyURLStr)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := privKey.(crypto.Signer)
+	if !ok {
+		return nil, aoserrors.New("private key doesn't implement signer")
+	}
+
+	return signer, nil
+}
+
+// GetTLSCertificate returns TLS certificate for certificate and key URLs.
+func (cryptoContext *CryptoContext) GetTLSCertificate(certURLStr, keyURLStr string) (tls.Certificate, error) {
+	certs, err := cryptoContext.LoadCertificateByURL(certURLStr)
+	if err != nil {
+		return tls.Certificate{}, aoserrors.Wrap(err)
+	}
+
+	key, err := cryptoContext.LoadSignerByURL(keyURLStr)
+	if err != nil {
+		return tls.Certificate{}, aoserrors.Wrap(err)
+	}
+
+	return tls.Certificate{Certificate: getRawCertificate(certs), PrivateKey: key, Leaf: certs[0]}, nil
+}
+
+// Public returns public key.
+func (signer *pkcs11Signer) Public() crypto.PublicKey {
+	signer.Lock()
+	defer signer.Unlock()
+
+	return signer.key.Public()
+}
+
+// Sign signs digest with the key.
+func (signer *pkcs11Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var signature []byte
+
+	err := signer.doWithReopen(func(key crypto.Signer) (err error) {
+		signature, err = key.Sign(rand, digest, opts)
+
+		return aoserrors.Wrap(err)
+	})
+
+	return signature, err
+}
+
+// Decrypt decrypts message with the key if underlying key supports decryption.
+func (signer *pkcs11Signer) Decrypt(rand io.Reader, msg []byte, opts crypto.DecrypterOpts) ([]byte, error) {
+	var plaintext []byte
+
+	err := signer.doWithReopen(func(key crypto.Signer) error {
+		decrypter, ok := key.(crypto.Decrypter)
+		if !ok {
+			return aoserrors.New("key doesn't support decryption")
+		}
+
+		var err error
+
+		plaintext, err = decrypter.Decrypt(rand, msg, opts)
+
+		return aoserrors.Wrap(err)
+	})
+
+	return plaintext, err
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (signer *pkcs11Signer) load() error {
+	privKey, err := loadPKCS11PrivateKey(signer.cryptoContext, signer.keyURL)
+	if err != nil {
+		return err
+	}
+
+	key, ok := privKey.(crypto.Signer)
+	if !ok {
+		return aoserrors.New("PKCS11 key doesn't implement signer")
+	}
+
+	signer.key = key
+
+	return nil
+}
+
+func (signer *pkcs11Signer) doWithReopen(operation func(key crypto.Signer) error) error {
+	signer.Lock()
+	defer signer.Unlock()
+
+	err := operation(signer.key)
+	if err == nil || !isPKCS11ResetError(err) {
+		return err
+	}
+
+	log.Warnf("PKCS11 token session is lost, reopen: %v", err)
+
+	library, token, _, _, _, parseErr := ParsePKCS11URL(signer.keyURL)
+	if parseErr != nil {
+		return parseErr
+	}
+
+	if resetErr := signer.cryptoContext.resetPKCS11Context(library, token); resetErr != nil {
+		log.Warnf("Can't close PKCS11 context: %v", resetErr)
+	}
+
+	if loadErr := signer.load(); loadErr != nil {
+		return loadErr
+	}
+
+	return operation(signer.key)
+}
+
+func (cryptoContext *CryptoContext) resetPKCS11Context(library, token string) error {
+	cryptoContext.Lock()
+	defer cryptoContext.Unlock()
+
+	if library == "" {
+		library = cryptoContext.pkcs11Library
+	}
+
+	pkcs11Desc := pkcs11Descriptor{library: library, token: token}
+
+	pkcs11Ctx, ok := cryptoContext.pkcs11Ctx[pkcs11Desc]
+	if !ok {
+		return nil
+	}
+
+	delete(cryptoContext.pkcs11Ctx, pkcs11Desc)
+
+	return aoserrors.Wrap(pkcs11Ctx.Close())
+}
+
+func isPKCS11ResetError(err error) bool {
+	var pkcs11Err pkcs11.Error
+
+	if !errors.As(err, &pkcs11Err) {
+		return false
+	}
+
+	for _, resetErr := range pkcs11ResetErrors {
+		if pkcs11Err == resetErr {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testPKCS11Key struct {
+	*ecdsa.PrivateKey
+	signErr error
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestPKCS11SignerReopen(t *testing.T) {
+	defer func() { loadPKCS11PrivateKey = (*CryptoContext).loadPrivateKeyFromPKCS11 }()
+
+	testData := []struct {
+		signErr     error
+		expectLoads int
+		expectError bool
+	}{
+		{signErr: pkcs11.Error(pkcs11.CKR_SESSION_HANDLE_INVALID), expectLoads: 2},
+		{signErr: pkcs11.Error(pkcs11.CKR_TOKEN_NOT_PRESENT), expectLoads: 2},
+		{signErr: pkcs11.Error(pkcs11.CKR_GENERAL_ERROR), expectLoads: 1, expectError: true},
+	}
+
+	cryptoContext, err := NewCryptoContext("")
+	if err != nil {
+		t.Fatalf("Can't create crypto context: %v", err)
+	}
+	defer cryptoContext.Close()
+
+	for _, data := range testData {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("Can't generate key: %v", err)
+		}
+
+		loads := 0
+
+		// First loaded key fails as token is reset, reloaded key works.
+		loadPKCS11PrivateKey = func(*CryptoContext, string) (crypto.PrivateKey, error) {
+			loads++
+
+			if loads == 1 {
+				return &testPKCS11Key{PrivateKey: privateKey, signErr: data.signErr}, nil
+			}
+
+			return &testPKCS11Key{PrivateKey: privateKey}, nil
+		}
+
+		signer, err := cryptoContext.LoadSignerByURL("pkcs11:token=test;object=key")
+		if err != nil {
+			t.Fatalf("Can't load signer: %v", err)
+		}
+
+		digest := sha256.Sum256([]byte("test data"))
+
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		if loads != data.expectLoads {
+			t.Errorf("Wrong key loads count: %d, expected: %d", loads, data.expectLoads)
+		}
+
+		if data.expectError {
+			if !errors.Is(err, data.signErr) {
+				t.Errorf("Wrong sign error: %v", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Can't sign: %v", err)
+		}
+
+		if !ecdsa.VerifyASN1(&privateKey.PublicKey, digest[:], signature) {
+			t.Error("Wrong signature")
+		}
+	}
+}
+
+/***********************************************************************************************************************
+ * Interfaces
+ **********************************************************************************************************************/
+
+func (key *testPKCS11Key) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if key.signErr != nil {
+		return nil, key.signErr
+	}
+
+	return key.PrivateKey.Sign(rand, digest, opts)
+}
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	defaultShredPasses = 1
+	shredBufferSize    = 64 * 1024
+	fallocPunchHole    = 0x02
+	fallocKeepSize     = 0x01
+	sysBlockDevPath    = "/sys/dev/block"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// KeyDestroyer destroys private keys when they are rotated or unit is decommissioned.
+type KeyDestroyer interface {
+	DestroyPrivateKeyByURL(keyURL string) error
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ShredFile overwrites file content with random data, syncs it to storage and removes the file. On non-rotational
+// storage (flash, SSD) overwrite is not guaranteed to reach the same physical blocks, so file blocks are
+// additionally discarded by punching a hole.
+func ShredFile(fileName string, passes int) (err error) {
+	if passes <= 0 {
+		passes = defaultShredPasses
+	}
+
+	file, err := os.OpenFile(fileName, os.O_WRONLY, 0)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		if file == nil {
+			return
+		}
+
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = aoserrors.Wrap(closeErr)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return aoserrors.Errorf("%s is not a regular file", fileName)
+	}
+
+	for range passes {
+		if err = overwriteFile(file, info.Size()); err != nil {
+			return err
+		}
+	}
+
+	if isNonRotationalStorage(info) {
+		log.WithField("file", fileName).Debug("Non-rotational storage, discard file blocks")
+
+		if err := syscall.Fallocate(
+			int(file.Fd()), fallocPunchHole|fallocKeepSize, 0, info.Size()); err != nil {
+			log.WithField("file", fileName).Warnf("Can't discard file blocks: %v", err)
+		}
+	}
+
+	if err = file.Truncate(0); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = file.Sync(); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	closeErr := file.Close()
+	file = nil
+
+	if closeErr != nil {
+		return aoserrors.Wrap(closeErr)
+	}
+
+	if err = os.Remove(fileName); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return syncDir(filepath.Dir(fileName))
+}
+
+// ZeroizeBytes overwrites in-memory secret with zeros.
+func ZeroizeBytes(data []byte) {
+	clear(data)
+	runtime.KeepAlive(data)
+}
+
+// ZeroizePrivateKey overwrites in-memory private key material with zeros. Hardware backed keys are not affected.
+// Only key fields reachable through public API are wiped: internal copies created by Go crypto packages (FIPS module
+// key of RSA precomputed values and cached ECDSA keys) can't be accessed and are left for garbage collector. Because
+// of this RSA and ECDSA keys are not guaranteed to be fully removed from memory.
+func ZeroizePrivateKey(key crypto.PrivateKey) {
+	switch privateKey := key.(type) {
+	case *rsa.PrivateKey:
+		zeroizeBigInt(privateKey.D)
+
+		for _, prime := range privateKey.Primes {
+			zeroizeBigInt(prime)
+		}
+
+		zeroizeBigInt(privateKey.Precomputed.Dp)
+		zeroizeBigInt(privateKey.Precomputed.Dq)
+		zeroizeBigInt(privateKey.Precomputed.Qinv)
+
+		for _, crtValue := range privateKey.Precomputed.CRTValues {
+			zeroizeBigInt(crtValue.Exp)
+			zeroizeBigInt(crtValue.Coeff)
+			zeroizeBigInt(crtValue.R)
+		}
+
+		// drop reference to internal FIPS key copy
+		privateKey.Precomputed = rsa.PrecomputedValues{}
+
+	case *ecdsa.PrivateKey:
+		zeroizeBigInt(privateKey.D)
+
+	case ed25519.PrivateKey:
+		ZeroizeBytes(privateKey)
+
+	case *ed25519.PrivateKey:
+		ZeroizeBytes(*privateKey)
+	}
+}
+
+// DestroyPrivateKeyByURL destroys private key: key file is shredded, PKCS11 key pair is deleted from token and
+// TPM persistent key is evicted.
+func (cryptoContext *CryptoContext) DestroyPrivateKeyByURL(keyURLStr string) error {
+	keyURL, err := url.Parse(keyURLStr)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	switch keyURL.Scheme {
+	case SchemeFile:
+		return ShredFile(keyURL.Path, defaultShredPasses)
+
+	case SchemeTPM:
+		return cryptoContext.evictTPMKey(keyURL)
+
+	case SchemePKCS11:
+		return cryptoContext.deletePKCS11Key(keyURLStr)
+
+	default:
+		return aoserrors.Errorf("unsupported schema %s for private key", keyURL.Scheme)
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func overwriteFile(file *os.File, size int64) error {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if _, err := io.CopyBuffer(file, io.LimitReader(rand.Reader, size), make([]byte, shredBufferSize)); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return aoserrors.Wrap(file.Sync())
+}
+
+func syncDir(dirName string) error {
+	dir, err := os.Open(dirName)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer dir.Close()
+
+	return aoserrors.Wrap(dir.Sync())
+}
+
+func isNonRotationalStorage(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false
+	}
+
+	//nolint:mnd
+	major := ((stat.Dev >> 8) & 0xfff) | ((stat.Dev >> 32) & ^uint64(0xfff))
+	//nolint:mnd
+	minor := (stat.Dev & 0xff) | ((stat.Dev >> 12) & ^uint64(0xff))
+
+	devPath := filepath.Join(sysBlockDevPath, fmt.Sprintf("%d:%d", major, minor))
+
+	// Partitions don't have queue, check parent device then
+	for _, rotationalFile := range []string{
+		filepath.Join(devPath, "queue", "rotational"), filepath.Join(devPath, "..", "queue", "rotational"),
+	} {
+		data, err := os.ReadFile(rotationalFile)
+		if err != nil {
+			continue
+		}
+
+		return strings.TrimSpace(string(data)) == "0"
+	}
+
+	return false
+}
+
+func zeroizeBigInt(value *big.Int) {
+	if value == nil {
+		return
+	}
+
+	clear(value.Bits())
+	value.SetInt64(0)
+}
+
+func (cryptoContext *CryptoContext) evictTPMKey(keyURL *url.URL) error {
+	tpmDevice, err := cryptoContext.getTPMDevice(keyURL.Hostname())
+	if err != nil {
+		return err
+	}
+
+	handle, err := strconv.ParseUint(keyURL.Opaque, 0, 32)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = tpm2.EvictControl(tpmDevice, "", tpm2.HandleOwner,
+		tpmutil.Handle(handle), tpmutil.Handle(handle)); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func (cryptoContext *CryptoContext) deletePKCS11Key(keyURL string) error {
+	key, err := cryptoContext.loadPrivateKeyFromPKCS11(keyURL)
+	if err != nil {
+		return err
+	}
+
+	deleter, ok := key.(interface{ Delete() error })
+	if !ok {
+		return aoserrors.New("PKCS11 key can't be deleted")
+	}
+
+	if err = deleter.Delete(); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// SchemeSPIFFE SPIFFE ID URI scheme.
+const SchemeSPIFFE = "spiffe"
+
+const maxSPIFFEIDLength = 2048
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// SPIFFEID SPIFFE workload identifier.
+type SPIFFEID struct {
+	TrustDomain string
+	Path        string
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ParseSPIFFEID parses and validates SPIFFE ID according to SPIFFE ID specification.
+func ParseSPIFFEID(id string) (spiffeID SPIFFEID, err error) {
+	if len(id) > maxSPIFFEIDLength {
+		return spiffeID, aoserrors.New("SPIFFE ID is too long")
+	}
+
+	if !strings.HasPrefix(id, SchemeSPIFFE+"://") {
+		return spiffeID, aoserrors.Errorf("invalid SPIFFE ID scheme: %s", id)
+	}
+
+	trustDomain, idPath, _ := strings.Cut(strings.TrimPrefix(id, SchemeSPIFFE+"://"), "/")
+
+	if err = validateTrustDomain(trustDomain); err != nil {
+		return spiffeID, err
+	}
+
+	if idPath != "" || strings.HasSuffix(id, "/") {
+		idPath = "/" + idPath
+
+		if err = validateSPIFFEPath(idPath); err != nil {
+			return spiffeID, err
+		}
+	}
+
+	return SPIFFEID{TrustDomain: trustDomain, Path: idPath}, nil
+}
+
+// String returns SPIFFE ID string representation.
+func (spiffeID SPIFFEID) String() string {
+	return SchemeSPIFFE + "://" + spiffeID.TrustDomain + spiffeID.Path
+}
+
+// GetSPIFFEID returns SPIFFE ID of X509-SVID certificate. Certificate should contain exactly one SPIFFE URI SAN.
+func GetSPIFFEID(cert *x509.Certificate) (spiffeID SPIFFEID, err error) {
+	var spiffeURIs []*url.URL
+
+	for _, uri := range cert.URIs {
+		if uri.Scheme == SchemeSPIFFE {
+			spiffeURIs = append(spiffeURIs, uri)
+		}
+	}
+
+	if len(spiffeURIs) != 1 {
+		return spiffeID, aoserrors.Errorf("certificate should have exactly one SPIFFE ID, found %d", len(spiffeURIs))
+	}
+
+	return ParseSPIFFEID(spiffeURIs[0].String())
+}
+
+// MatchSPIFFEID checks if SPIFFE ID matches pattern. Pattern is a SPIFFE ID where path may contain path.Match
+// wildcards, e.g. spiffe://aos.example/node/*/sm. Pattern path "/..." matches any path in the trust domain.
+func MatchSPIFFEID(pattern string, spiffeID SPIFFEID) bool {
+	if !strings.HasPrefix(pattern, SchemeSPIFFE+"://") {
+		return false
+	}
+
+	trustDomain, patternPath, _ := strings.Cut(strings.TrimPrefix(pattern, SchemeSPIFFE+"://"), "/")
+
+	if trustDomain != spiffeID.TrustDomain {
+		return false
+	}
+
+	patternPath = "/" + patternPath
+
+	if patternPath == "/..." {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(patternPath, "/..."); ok {
+		for idPath := spiffeID.Path; idPath != "/" && idPath != "."; idPath = path.Dir(idPath) {
+			if matched, err := path.Match(prefix, idPath); err == nil && matched {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if patternPath == "/" {
+		return spiffeID.Path == ""
+	}
+
+	matched, err := path.Match(patternPath, spiffeID.Path)
+
+	return err == nil && matched
+}
+
+// VerifySPIFFEPeer returns TLS connection verifier which authorizes peer by SPIFFE ID patterns.
+func VerifySPIFFEPeer(patterns ...string) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return aoserrors.New("no peer certificate")
+		}
+
+		spiffeID, err := GetSPIFFEID(state.PeerCertificates[0])
+		if err != nil {
+			return err
+		}
+
+		for _, pattern := range patterns {
+			if MatchSPIFFEID(pattern, spiffeID) {
+				return nil
+			}
+		}
+
+		return aoserrors.Errorf("peer SPIFFE ID %s is not authorized", spiffeID)
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func validateTrustDomain(trustDomain string) error {
+	if trustDomain == "" {
+		return aoserrors.New("SPIFFE ID trust domain is empty")
+	}
+
+	for _, c := range trustDomain {
+		if !isSPIFFELowerAlphaNum(c) && c != '.' && c != '-' && c != '_' {
+			return aoserrors.Errorf("invalid SPIFFE ID trust domain: %s", trustDomain)
+		}
+	}
+
+	return nil
+}
+
+func validateSPIFFEPath(idPath string) error {
+	for _, segment := range strings.Split(idPath, "/")[1:] {
+		if segment == "" || segment == "." || segment == ".." {
+			return aoserrors.Errorf("invalid SPIFFE ID path: %s", idPath)
+		}
+
+		for _, c := range segment {
+			if !isSPIFFELowerAlphaNum(c) && (c < 'A' || c > 'Z') && c != '.' && c != '-' && c != '_' {
+				return aoserrors.Errorf("invalid SPIFFE ID path: %s", idPath)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isSPIFFELowerAlphaNum(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cryptutils
+
+import (
+	"crypto/tls"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// TLS profiles.
+const (
+	// TLSProfileDefault keeps Go defaults with TLS 1.2 as min version.
+	TLSProfileDefault = ""
+	// TLSProfileStrict allows TLS 1.3 only.
+	TLSProfileStrict = "strict"
+	// TLSProfileLegacyInterop allows TLS 1.2 with ECDHE CBC cipher suites for old peers.
+	TLSProfileLegacyInterop = "legacy-interop"
+	// TLSProfileFIPSLike allows NIST curves and AES-GCM cipher suites only.
+	TLSProfileFIPSLike = "fips-like"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type tlsProfile struct {
+	minVersion             uint16
+	maxVersion             uint16
+	curves                 []tls.CurveID
+	cipherSuites           []uint16
+	sessionTicketsDisabled bool
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var tlsProfiles = map[string]tlsProfile{
+	TLSProfileDefault: {minVersion: tls.VersionTLS12},
+	TLSProfileStrict: {
+		minVersion:             tls.VersionTLS13,
+		maxVersion:             tls.VersionTLS13,
+		curves:                 []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+		sessionTicketsDisabled: true,
+	},
+	TLSProfileLegacyInterop: {
+		minVersion: tls.VersionTLS12,
+		curves:     []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384, tls.CurveP521},
+		cipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+		},
+		sessionTicketsDisabled: true,
+	},
+	TLSProfileFIPSLike: {
+		minVersion: tls.VersionTLS12,
+		maxVersion: tls.VersionTLS13,
+		curves:     []tls.CurveID{tls.CurveP256, tls.CurveP384},
+		cipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		},
+		sessionTicketsDisabled: true,
+	},
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewTLSProfileConfig creates TLS config for named profile.
+func NewTLSProfileConfig(profile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{} //nolint:gosec // min version is set by profile
+
+	if err := ApplyTLSProfile(tlsConfig, profile); err != nil {
+		return nil, err
+	}
+
+	return tlsConfig, nil
+}
+
+// ApplyTLSProfile sets versions, curves, cipher suites and session tickets of TLS config according to named profile.
+// Certificates and verification settings of TLS config are not changed.
+func ApplyTLSProfile(tlsConfig *tls.Config, profile string) error {
+	params, ok := tlsProfiles[profile]
+	if !ok {
+		return aoserrors.Errorf("unsupported TLS profile: %s", profile)
+	}
+
+	tlsConfig.MinVersion = params.minVersion
+	tlsConfig.MaxVersion = params.maxVersion
+	tlsConfig.CurvePreferences = append([]tls.CurveID(nil), params.curves...)
+	tlsConfig.CipherSuites = append([]uint16(nil), params.cipherSuites...)
+	tlsConfig.SessionTicketsDisabled = params.sessionTicketsDisabled
+
+	return nil
+}
+
+// SetTLSProfile sets profile of TLS configs returned by crypto context.
+func (cryptoContext *CryptoContext) SetTLSProfile(profile string) error {
+	if _, ok := tlsProfiles[profile]; !ok {
+		return aoserrors.Errorf("unsupported TLS profile: %s", profile)
+	}
+
+	cryptoContext.Lock()
+	defer cryptoContext.Unlock()
+
+	cryptoContext.tlsProfile = profile
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (cryptoContext *CryptoContext) applyTLSProfile(tlsConfig *tls.Config) *tls.Config {
+	cryptoContext.Lock()
+	profile := cryptoContext.tlsProfile
+	cryptoContext.Unlock()
+
+	// Profile is validated by SetTLSProfile.
+	_ = ApplyTLSProfile(tlsConfig, profile)
+
+	return tlsConfig
+}
@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filetransfer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"sync"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	log "github.com/sirupsen/logrus"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Sender sends file transfer messages.
+type Sender interface {
+	SendMessage(message interface{}) error
+}
+
+// File file interface used by downloader to store and verify received data.
+type File interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// Uploader drives transfer of local file to the remote side.
+type Uploader struct {
+	sync.Mutex
+
+	request cloudprotocol.FileTransferRequest
+	reader  io.ReaderAt
+	size    uint64
+	sender  Sender
+	offset  uint64
+	status  string
+}
+
+// Downloader drives receiving of remote file.
+type Downloader struct {
+	sync.Mutex
+
+	request cloudprotocol.FileTransferRequest
+	file    File
+	sender  Sender
+	offset  uint64
+	status  string
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewUploader creates file uploader.
+func NewUploader(
+	request cloudprotocol.FileTransferRequest, reader io.ReaderAt, size uint64, sender Sender,
+) (*Uploader, error) {
+	if request.ChunkSize == 0 {
+		return nil, aoserrors.New("chunk size is not set")
+	}
+
+	if request.Offset > size {
+		return nil, aoserrors.Errorf("resume offset %d exceeds file size %d", request.Offset, size)
+	}
+
+	return &Uploader{request: request, reader: reader, size: size, sender: sender, offset: request.Offset}, nil
+}
+
+// Start starts or resumes upload from requested offset.
+func (uploader *Uploader) Start() error {
+	uploader.Lock()
+	defer uploader.Unlock()
+
+	if uploader.status != "" {
+		return aoserrors.Errorf("wrong transfer status: %s", uploader.status)
+	}
+
+	log.WithFields(log.Fields{
+		"transferID": uploader.request.TransferID, "offset": uploader.offset, "size": uploader.size,
+	}).Debug("Start file upload")
+
+	uploader.status = cloudprotocol.FileTransferStatusInProgress
+
+	return uploader.sendChunk()
+}
+
+// HandleAck handles chunk acknowledgement from the remote side.
+func (uploader *Uploader) HandleAck(ack cloudprotocol.FileChunkAck) error {
+	uploader.Lock()
+	defer uploader.Unlock()
+
+	if ack.TransferID != uploader.request.TransferID {
+		return aoserrors.Errorf("wrong transfer ID: %s", ack.TransferID)
+	}
+
+	if uploader.status != cloudprotocol.FileTransferStatusInProgress {
+		return aoserrors.Errorf("wrong transfer status: %s", uploader.status)
+	}
+
+	if ack.ErrorInfo != nil {
+		uploader.status = cloudprotocol.FileTransferStatusFailed
+
+		return aoserrors.Errorf("remote side error: %s", ack.ErrorInfo.Message)
+	}
+
+	if ack.Offset > uploader.size {
+		return uploader.fail(aoserrors.Errorf("acknowledged offset %d exceeds file size %d", ack.Offset, uploader.size))
+	}
+
+	// Remote side may request to resend data from earlier offset.
+	uploader.offset = ack.Offset
+
+	if uploader.offset == uploader.size {
+		uploader.status = cloudprotocol.FileTransferStatusCompleted
+
+		return sendStatus(uploader.sender, uploader.request.TransferID, uploader.status, uploader.offset, nil)
+	}
+
+	return uploader.sendChunk()
+}
+
+// Cancel cancels upload.
+func (uploader *Uploader) Cancel() error {
+	uploader.Lock()
+	defer uploader.Unlock()
+
+	uploader.status = cloudprotocol.FileTransferStatusCanceled
+
+	return sendStatus(uploader.sender, uploader.request.TransferID, uploader.status, uploader.offset, nil)
+}
+
+// Status returns current transfer status and offset.
+func (uploader *Uploader) Status() (status string, offset uint64) {
+	uploader.Lock()
+	defer uploader.Unlock()
+
+	return uploader.status, uploader.offset
+}
+
+// NewDownloader creates file downloader.
+func NewDownloader(request cloudprotocol.FileTransferRequest, file File, sender Sender) (*Downloader, error) {
+	if request.Size != 0 && request.Offset > request.Size {
+		return nil, aoserrors.Errorf("resume offset %d exceeds file size %d", request.Offset, request.Size)
+	}
+
+	return &Downloader{request: request, file: file, sender: sender, offset: request.Offset}, nil
+}
+
+// Start starts or resumes download: notifies remote side from which offset data is expected.
+func (downloader *Downloader) Start() error {
+	downloader.Lock()
+	defer downloader.Unlock()
+
+	if downloader.status != "" {
+		return aoserrors.Errorf("wrong transfer status: %s", downloader.status)
+	}
+
+	log.WithFields(log.Fields{
+		"transferID": downloader.request.TransferID, "offset": downloader.offset, "size": downloader.request.Size,
+	}).Debug("Start file download")
+
+	downloader.status = cloudprotocol.FileTransferStatusInProgress
+
+	return sendStatus(downloader.sender, downloader.request.TransferID, downloader.status, downloader.offset, nil)
+}
+
+// HandleChunk handles received file chunk.
+func (downloader *Downloader) HandleChunk(chunk cloudprotocol.FileChunk) error {
+	downloader.Lock()
+	defer downloader.Unlock()
+
+	if chunk.TransferID != downloader.request.TransferID {
+		return aoserrors.Errorf("wrong transfer ID: %s", chunk.TransferID)
+	}
+
+	if downloader.status != cloudprotocol.FileTransferStatusInProgress {
+		return aoserrors.Errorf("wrong transfer status: %s", downloader.status)
+	}
+
+	if chunk.Offset != downloader.offset {
+		log.WithFields(log.Fields{
+			"transferID": chunk.TransferID, "offset": chunk.Offset, "expectedOffset": downloader.offset,
+		}).Warn("Unexpected chunk offset")
+
+		return downloader.sendAck()
+	}
+
+	if checksum := sha256.Sum256(chunk.Data); !bytes.Equal(checksum[:], chunk.Sha256) {
+		if err := downloader.sendAck(); err != nil {
+			return err
+		}
+
+		return aoserrors.Errorf("chunk checksum mismatch at offset %d", chunk.Offset)
+	}
+
+	if _, err := downloader.file.WriteAt(chunk.Data, int64(chunk.Offset)); err != nil {
+		return downloader.fail(aoserrors.Wrap(err))
+	}
+
+	downloader.offset += uint64(len(chunk.Data))
+
+	if err := downloader.sendAck(); err != nil {
+		return err
+	}
+
+	if !chunk.Last && (downloader.request.Size == 0 || downloader.offset < downloader.request.Size) {
+		return nil
+	}
+
+	if err := downloader.verifyFile(); err != nil {
+		return downloader.fail(err)
+	}
+
+	downloader.status = cloudprotocol.FileTransferStatusCompleted
+
+	return sendStatus(downloader.sender, downloader.request.TransferID, downloader.status, downloader.offset, nil)
+}
+
+// Cancel cancels download.
+func (downloader *Downloader) Cancel() error {
+	downloader.Lock()
+	defer downloader.Unlock()
+
+	downloader.status = cloudprotocol.FileTransferStatusCanceled
+
+	return sendStatus(downloader.sender, downloader.request.TransferID, downloader.status, downloader.offset, nil)
+}
+
+// Status returns current transfer status and offset.
+func (downloader *Downloader) Status() (status string, offset uint64) {
+	downloader.Lock()
+	defer downloader.Unlock()
+
+	return downloader.status, downloader.offset
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (uploader *Uploader) sendChunk() error {
+	size := uploader.request.ChunkSize
+	if remaining := uploader.size - uploader.offset; remaining < size {
+		size = remaining
+	}
+
+	data := make([]byte, size)
+
+	if _, err := uploader.reader.ReadAt(data, int64(uploader.offset)); err != nil && err != io.EOF {
+		return uploader.fail(aoserrors.Wrap(err))
+	}
+
+	checksum := sha256.Sum256(data)
+
+	if err := uploader.sender.SendMessage(cloudprotocol.FileChunk{
+		MessageType: cloudprotocol.FileChunkMessageType,
+		TransferID:  uploader.request.TransferID,
+		Offset:      uploader.offset,
+		Data:        data,
+		Sha256:      checksum[:],
+		Last:        uploader.offset+size == uploader.size,
+	}); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func (uploader *Uploader) fail(err error) error {
+	uploader.status = cloudprotocol.FileTransferStatusFailed
+
+	if sendErr := sendStatus(uploader.sender, uploader.request.TransferID, uploader.status, uploader.offset,
+		&cloudprotocol.ErrorInfo{Message: err.Error()}); sendErr != nil {
+		log.Errorf("Can't send file transfer status: %v", sendErr)
+	}
+
+	return err
+}
+
+func (downloader *Downloader) sendAck() error {
+	if err := downloader.sender.SendMessage(cloudprotocol.FileChunkAck{
+		MessageType: cloudprotocol.FileChunkAckMessageType,
+		TransferID:  downloader.request.TransferID,
+		Offset:      downloader.offset,
+	}); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+func (downloader *Downloader) verifyFile() error {
+	if downloader.request.Size != 0 && downloader.offset != downloader.request.Size {
+		return aoserrors.Errorf("wrong file size: %d", downloader.offset)
+	}
+
+	if len(downloader.request.Sha256) == 0 {
+		return nil
+	}
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(hash, io.NewSectionReader(downloader.file, 0, int64(downloader.offset))); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if !bytes.Equal(hash.Sum(nil), downloader.request.Sha256) {
+		return aoserrors.New("file checksum mismatch")
+	}
+
+	return nil
+}
+
+func (downloader *Downloader) fail(err error) error {
+	downloader.status = cloudprotocol.FileTransferStatusFailed
+
+	if sendErr := sendStatus(downloader.sender, downloader.request.TransferID, downloader.status, downloader.offset,
+		&cloudprotocol.ErrorInfo{Message: err.Error()}); sendErr != nil {
+		log.Errorf("Can't send file transfer status: %v", sendErr)
+	}
+
+	return err
+}
+
+func sendStatus(
+	sender Sender, transferID, status string, offset uint64, errorInfo *cloudprotocol.ErrorInfo,
+) error {
+	if err := sender.SendMessage(cloudprotocol.FileTransferStatus{
+		MessageType: cloudprotocol.FileTransferStatusMessageType,
+		TransferID:  transferID,
+		Status:      status,
+		Offset:      offset,
+		ErrorInfo:   errorInfo,
+	}); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filetransfer_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	"github.com/aosedge/aos_common/utils/filetransfer"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testSender struct {
+	messages []interface{}
+}
+
+type testFile struct {
+	data []byte
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestTransfer(t *testing.T) {
+	data := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	checksum := sha256.Sum256(data)
+
+	testData := []struct {
+		name          string
+		offset        uint64
+		corruptOffset int64
+	}{
+		{name: "full transfer", corruptOffset: -1},
+		{name: "resume transfer", offset: 16, corruptOffset: -1},
+		{name: "corrupted chunk", corruptOffset: 8},
+	}
+
+	for _, testItem := range testData {
+		t.Run(testItem.name, func(t *testing.T) {
+			request := cloudprotocol.FileTransferRequest{
+				TransferID: "transfer1", Direction: cloudprotocol.FileTransferDownload, FileName: "file.bin",
+				Size: uint64(len(data)), Sha256: checksum[:], ChunkSize: 8, Offset: testItem.offset,
+			}
+
+			file := &testFile{data: make([]byte, len(data))}
+			copy(file.data, data[:testItem.offset])
+
+			uploaderSender := &testSender{}
+			downloaderSender := &testSender{}
+
+			uploader, err := filetransfer.NewUploader(request, bytes.NewReader(data), uint64(len(data)), uploaderSender)
+			if err != nil {
+				t.Fatalf("Can't create uploader: %v", err)
+			}
+
+			downloader, err := filetransfer.NewDownloader(request, file, downloaderSender)
+			if err != nil {
+				t.Fatalf("Can't create downloader: %v", err)
+			}
+
+			if err = downloader.Start(); err != nil {
+				t.Fatalf("Can't start downloader: %v", err)
+			}
+
+			if err = uploader.Start(); err != nil {
+				t.Fatalf("Can't start uploader: %v", err)
+			}
+
+			corruptOffset := testItem.corruptOffset
+
+			for uploaderSender.hasMessages() || downloaderSender.hasMessages() {
+				for _, message := range uploaderSender.pop() {
+					chunk, ok := message.(cloudprotocol.FileChunk)
+					if !ok {
+						continue
+					}
+
+					if int64(chunk.Offset) == corruptOffset {
+						chunk.Sha256 = make([]byte, sha256.Size)
+						corruptOffset = -1
+
+						if err := downloader.HandleChunk(chunk); err == nil {
+							t.Error("Error expected for corrupted chunk")
+						}
+
+						continue
+					}
+
+					if err := downloader.HandleChunk(chunk); err != nil {
+						t.Errorf("Can't handle chunk: %v", err)
+					}
+				}
+
+				for _, message := range downloaderSender.pop() {
+					if ack, ok := message.(cloudprotocol.FileChunkAck); ok {
+						if err := uploader.HandleAck(ack); err != nil {
+							t.Errorf("Can't handle ack: %v", err)
+						}
+					}
+				}
+			}
+
+			if status, offset := downloader.Status(); status != cloudprotocol.FileTransferStatusCompleted ||
+				offset != uint64(len(data)) {
+				t.Errorf("Wrong downloader status: %s, %d", status, offset)
+			}
+
+			if status, _ := uploader.Status(); status != cloudprotocol.FileTransferStatusCompleted {
+				t.Errorf("Wrong uploader status: %s", status)
+			}
+
+			if !bytes.Equal(file.data, data) {
+				t.Errorf("Wrong received data: %s", string(file.data))
+			}
+		})
+	}
+}
+
+func TestTransferChecksumMismatch(t *testing.T) {
+	data := []byte("0123456789")
+
+	request := cloudprotocol.FileTransferRequest{
+		TransferID: "transfer1", Size: uint64(len(data)), Sha256: make([]byte, sha256.Size), ChunkSize: 16,
+	}
+
+	sender := &testSender{}
+
+	downloader, err := filetransfer.NewDownloader(request, &testFile{}, sender)
+	if err != nil {
+		t.Fatalf("Can't create downloader: %v", err)
+	}
+
+	if err = downloader.Start(); err != nil {
+		t.Fatalf("Can't start downloader: %v", err)
+	}
+
+	chunkChecksum := sha256.Sum256(data)
+
+	if err = downloader.HandleChunk(cloudprotocol.FileChunk{
+		TransferID: "transfer1", Data: data, Sha256: chunkChecksum[:], Last: true,
+	}); err == nil {
+		t.Error("Error expected for file checksum mismatch")
+	}
+
+	messages := sender.pop()
+
+	status, ok := messages[len(messages)-1].(cloudprotocol.FileTransferStatus)
+	if !ok || status.Status != cloudprotocol.FileTransferStatusFailed || status.ErrorInfo == nil {
+		t.Errorf("Wrong transfer status: %v", messages[len(messages)-1])
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (sender *testSender) SendMessage(message interface{}) error {
+	sender.messages = append(sender.messages, message)
+
+	return nil
+}
+
+func (sender *testSender) hasMessages() bool {
+	return len(sender.messages) != 0
+}
+
+func (sender *testSender) pop() []interface{} {
+	messages := sender.messages
+	sender.messages = nil
+
+	return messages
+}
+
+func (file *testFile) ReadAt(data []byte, offset int64) (int, error) {
+	if offset >= int64(len(file.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(data, file.data[offset:])
+	if n < len(data) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (file *testFile) WriteAt(data []byte, offset int64) (int, error) {
+	if end := int(offset) + len(data); end > len(file.data) {
+		file.data = append(file.data, make([]byte, end-len(file.data))...)
+	}
+
+	return copy(file.data[offset:], data), nil
+}
@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const mountInfoPath = "/proc/self/mountinfo"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// IDMapping user or group ID mapping of idmapped mount.
+type IDMapping struct {
+	ContainerID uint32 `json:"containerId"`
+	HostID      uint32 `json:"hostId"`
+	Size        uint32 `json:"size"`
+}
+
+// MountInfo mount table entry.
+type MountInfo struct {
+	// Root root of the mount within source filesystem, differs from "/" for bind mounts of subdirectories.
+	Root       string
+	MountPoint string
+	FSType     string
+	Source     string
+	Options    []string
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// BindMount creates mount point and recursively bind mounts source to it.
+func BindMount(source, mountPoint string, readOnly bool) error {
+	log.WithFields(log.Fields{"source": source, "mountPoint": mountPoint, "readOnly": readOnly}).Debug("Bind mount")
+
+	if err := os.MkdirAll(mountPoint, folderPerm); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err := syscall.Mount(source, mountPoint, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if !readOnly {
+		return nil
+	}
+
+	if err := syscall.Mount("", mountPoint, "", syscall.MS_BIND|syscall.MS_REMOUNT|syscall.MS_RDONLY, ""); err != nil {
+		_ = syscall.Unmount(mountPoint, syscall.MNT_DETACH)
+
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+// IDMappedMount creates mount point and mounts source to it with files ownership mapped by uid and gid mappings.
+// Source filesystem should support idmapped mounts (kernel 5.12+).
+func IDMappedMount(source, mountPoint string, uidMappings, gidMappings []IDMapping, readOnly bool) (err error) {
+	log.WithFields(log.Fields{
+		"source": source, "mountPoint": mountPoint, "uidMappings": uidMappings, "gidMappings": gidMappings,
+	}).Debug("ID mapped mount")
+
+	userNSFile, err := openUserNS(uidMappings, gidMappings)
+	if err != nil {
+		return err
+	}
+	defer userNSFile.Close()
+
+	treeFd, err := unix.OpenTree(unix.AT_FDCWD, source, unix.OPEN_TREE_CLONE|unix.OPEN_TREE_CLOEXEC|unix.AT_RECURSIVE)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer unix.Close(treeFd)
+
+	attr := unix.MountAttr{Attr_set: unix.MOUNT_ATTR_IDMAP, Userns_fd: uint64(userNSFile.Fd())}
+
+	if readOnly {
+		attr.Attr_set |= unix.MOUNT_ATTR_RDONLY
+	}
+
+	if err = unix.MountSetattr(treeFd, "", unix.AT_EMPTY_PATH|unix.AT_RECURSIVE, &attr); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = os.MkdirAll(mountPoint, folderPerm); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = unix.MoveMount(treeFd, "", unix.AT_FDCWD, mountPoint, unix.MOVE_MOUNT_F_EMPTY_PATH); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+// GetMounts returns mounts located in dir or its subdirectories, dir itself included.
+func GetMounts(dir string) (mounts []MountInfo, err error) {
+	dir = filepath.Clean(dir)
+
+	file, err := os.Open(mountInfoPath)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		mount, err := parseMountInfo(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+
+		if mount.MountPoint == dir || isSubDir(dir, mount.MountPoint) {
+			mounts = append(mounts, mount)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return mounts, nil
+}
+
+// UmountAll unmounts all mounts located in dir or its subdirectories starting from the deepest ones. Mount points
+// are not removed.
+func UmountAll(dir string) error {
+	mounts, err := GetMounts(dir)
+	if err != nil {
+		return err
+	}
+
+	return umountMounts(mounts)
+}
+
+// FindStaleMounts returns mounts located in dir subdirectories which mount points are not in active list, e.g.
+// mounts left after crash.
+func FindStaleMounts(dir string, activeMountPoints []string) (staleMounts []MountInfo, err error) {
+	mounts, err := GetMounts(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[string]struct{}, len(activeMountPoints))
+
+	for _, mountPoint := range activeMountPoints {
+		active[filepath.Clean(mountPoint)] = struct{}{}
+	}
+
+	for _, mount := range mounts {
+		if mount.MountPoint == filepath.Clean(dir) {
+			continue
+		}
+
+		if _, ok := active[mount.MountPoint]; ok || isUnderMountPoints(mount.MountPoint, active) {
+			continue
+		}
+
+		staleMounts = append(staleMounts, mount)
+	}
+
+	return staleMounts, nil
+}
+
+// CleanupStaleMounts unmounts stale mounts found by FindStaleMounts.
+func CleanupStaleMounts(dir string, activeMountPoints []string) error {
+	staleMounts, err := FindStaleMounts(dir, activeMountPoints)
+	if err != nil {
+		return err
+	}
+
+	for _, mount := range staleMounts {
+		log.WithField("mountPoint", mount.MountPoint).Warn("Cleanup stale mount")
+	}
+
+	return umountMounts(staleMounts)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+// parseMountInfo parses /proc/self/mountinfo line:
+// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue.
+func parseMountInfo(line string) (mount MountInfo, err error) {
+	fields := strings.Fields(line)
+
+	separator := slices.Index(fields, "-")
+	if separator < 6 || len(fields) < separator+3 { //nolint:mnd
+		return mount, aoserrors.Errorf("invalid mountinfo line: %s", line)
+	}
+
+	return MountInfo{
+		Root:       unescapeMountInfo(fields[3]),
+		MountPoint: unescapeMountInfo(fields[4]),
+		Options:    strings.Split(fields[5], ","),
+		FSType:     fields[separator+1],
+		Source:     unescapeMountInfo(fields[separator+2]),
+	}, nil
+}
+
+// unescapeMountInfo decodes octal escaped characters (space, tab, newline, backslash).
+func unescapeMountInfo(value string) string {
+	if !strings.Contains(value, "\\") {
+		return value
+	}
+
+	var builder strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+3 < len(value) {
+			if code, err := strconv.ParseUint(value[i+1:i+4], 8, 8); err == nil {
+				builder.WriteByte(byte(code))
+
+				i += 3
+
+				continue
+			}
+		}
+
+		builder.WriteByte(value[i])
+	}
+
+	return builder.String()
+}
+
+func isUnderMountPoints(mountPoint string, mountPoints map[string]struct{}) bool {
+	for parent := filepath.Dir(mountPoint); parent != filepath.Dir(parent); parent = filepath.Dir(parent) {
+		if _, ok := mountPoints[parent]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func umountMounts(mounts []MountInfo) (err error) {
+	slices.SortStableFunc(mounts, func(mount1, mount2 MountInfo) int {
+		return strings.Count(mount2.MountPoint, "/") - strings.Count(mount1.MountPoint, "/")
+	})
+
+	for _, mount := range mounts {
+		log.WithField("mountPoint", mount.MountPoint).Debug("Umount")
+
+		if umountErr := syscall.Unmount(mount.MountPoint, 0); umountErr != nil {
+			if errors.Is(umountErr, syscall.EINVAL) {
+				// already unmounted as part of parent mount
+				continue
+			}
+
+			log.WithField("mountPoint", mount.MountPoint).Warnf("Can't umount, detach: %v", umountErr)
+
+			if umountErr = syscall.Unmount(mount.MountPoint, syscall.MNT_DETACH); umountErr != nil &&
+				!errors.Is(umountErr, syscall.EINVAL) {
+				err = aoserrors.Append(err, umountErr)
+			}
+		}
+	}
+
+	return err
+}
+
+// openUserNS creates process in new user namespace with specified mappings and returns namespace file. The process
+// is traced, so it stops right after exec without running any code, and is killed once namespace is opened.
+func openUserNS(uidMappings, gidMappings []IDMapping) (userNSFile *os.File, err error) {
+	// ptrace tracer is the thread which started the process
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	cmd := exec.Command("/proc/self/exe")
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags:  unix.CLONE_NEWUSER,
+		UidMappings: toSysProcIDMaps(uidMappings),
+		GidMappings: toSysProcIDMaps(gidMappings),
+		Ptrace:      true,
+		Pdeathsig:   unix.SIGKILL,
+	}
+
+	if err = cmd.Start(); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	if userNSFile, err = os.Open(fmt.Sprintf("/proc/%d/ns/user", cmd.Process.Pid)); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return userNSFile, nil
+}
+
+func toSysProcIDMaps(mappings []IDMapping) (idMaps []syscall.SysProcIDMap) {
+	for _, mapping := range mappings {
+		idMaps = append(idMaps, syscall.SysProcIDMap{
+			ContainerID: int(mapping.ContainerID), HostID: int(mapping.HostID), Size: int(mapping.Size),
+		})
+	}
+
+	return idMaps
+}
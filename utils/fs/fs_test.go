@@ -18,12 +18,15 @@
 package fs_test
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
@@ -357,6 +360,255 @@ func TestOverlayMount(t *testing.T) {
 	}
 }
 
+func TestOverlayStack(t *testing.T) {
+	lowerDirs := []string{filepath.Join(tmpDir, "stackLower0"), filepath.Join(tmpDir, "stackLower1")}
+
+	if err := createDirContent(lowerDirs[0], []string{"file0"}); err != nil {
+		t.Fatalf("Can't create lower dir content: %s", err)
+	}
+
+	if err := createDirContent(lowerDirs[1], []string{"file1"}); err != nil {
+		t.Fatalf("Can't create lower dir content: %s", err)
+	}
+
+	upperDir := filepath.Join(tmpDir, "stackUpper")
+
+	if err := os.MkdirAll(upperDir, 0o755); err != nil {
+		t.Fatalf("Can't create upper dir: %s", err)
+	}
+
+	if err := fs.ValidateOverlayConfig(fs.OverlayConfig{
+		LowerDirs: lowerDirs, UpperDir: upperDir, WorkDir: filepath.Join(upperDir, "work"),
+	}); err == nil {
+		t.Error("Overlapped upper and work dirs should fail")
+	}
+
+	if _, err := fs.MountOverlay(mountPoint, fs.OverlayConfig{
+		LowerDirs: append(lowerDirs, filepath.Join(tmpDir, "notExist")),
+	}); err == nil {
+		t.Error("Not existing lower dir should fail")
+	}
+
+	overlay, err := fs.MountOverlay(mountPoint, fs.OverlayConfig{
+		LowerDirs: lowerDirs, UpperDir: upperDir, WorkDir: filepath.Join(tmpDir, "stackWork"), Index: true,
+	})
+	if err != nil {
+		t.Fatalf("Can't mount overlay: %s", err)
+	}
+
+	if err := checkContent(mountPoint, []string{"file0", "file1"}); err != nil {
+		t.Errorf("Overlay content mismatch: %s", err)
+	}
+
+	if err := overlay.Remount(true); err != nil {
+		t.Errorf("Can't remount overlay: %s", err)
+	}
+
+	if err := createDirContent(mountPoint, []string{"newFile"}); err == nil {
+		t.Error("Read-only overlay write should fail")
+	}
+
+	if err := overlay.Remount(false); err != nil {
+		t.Errorf("Can't remount overlay: %s", err)
+	}
+
+	if err := createDirContent(mountPoint, []string{"newFile"}); err != nil {
+		t.Errorf("Can't create new content: %s", err)
+	}
+
+	if err := overlay.Umount(); err != nil {
+		t.Fatalf("Can't unmount overlay: %s", err)
+	}
+
+	if err := checkContent(upperDir, []string{"newFile"}); err != nil {
+		t.Errorf("Upper dir content mismatch: %s", err)
+	}
+}
+
+func TestBindMount(t *testing.T) {
+	storageDir := filepath.Join(tmpDir, "storage")
+	instancesDir := filepath.Join(tmpDir, "instances")
+	mountPoints := []string{filepath.Join(instancesDir, "instance0"), filepath.Join(instancesDir, "instance1")}
+
+	if err := createDirContent(storageDir, []string{"file0"}); err != nil {
+		t.Fatalf("Can't create storage content: %v", err)
+	}
+
+	if err := fs.BindMount(storageDir, mountPoints[0], false); err != nil {
+		t.Fatalf("Can't bind mount: %v", err)
+	}
+
+	if err := fs.BindMount(storageDir, mountPoints[1], true); err != nil {
+		t.Fatalf("Can't bind mount: %v", err)
+	}
+
+	defer func() {
+		if err := fs.UmountAll(instancesDir); err != nil {
+			t.Errorf("Can't umount all: %v", err)
+		}
+	}()
+
+	if err := checkContent(mountPoints[0], []string{"file0"}); err != nil {
+		t.Errorf("Bind mount content mismatch: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(mountPoints[1], "file1"), nil, 0o600); !errors.Is(err, syscall.EROFS) {
+		t.Errorf("Read-only bind mount expected: %v", err)
+	}
+
+	mounts, err := fs.GetMounts(instancesDir)
+	if err != nil {
+		t.Fatalf("Can't get mounts: %v", err)
+	}
+
+	if len(mounts) != len(mountPoints) || mounts[0].MountPoint != mountPoints[0] ||
+		mounts[1].MountPoint != mountPoints[1] || !slices.Contains(mounts[1].Options, "ro") {
+		t.Errorf("Wrong mounts: %v", mounts)
+	}
+
+	// Instance1 mount is stale
+
+	staleMounts, err := fs.FindStaleMounts(instancesDir, mountPoints[:1])
+	if err != nil {
+		t.Fatalf("Can't find stale mounts: %v", err)
+	}
+
+	if len(staleMounts) != 1 || staleMounts[0].MountPoint != mountPoints[1] {
+		t.Errorf("Wrong stale mounts: %v", staleMounts)
+	}
+
+	if err = fs.CleanupStaleMounts(instancesDir, mountPoints[:1]); err != nil {
+		t.Fatalf("Can't cleanup stale mounts: %v", err)
+	}
+
+	if mounts, err = fs.GetMounts(instancesDir); err != nil || len(mounts) != 1 {
+		t.Errorf("Wrong mounts after cleanup: %v, %v", mounts, err)
+	}
+}
+
+func TestIDMappedMount(t *testing.T) {
+	sourceDir := filepath.Join(tmpDir, "idmapSource")
+	idMappedDir := filepath.Join(tmpDir, "idmapped")
+
+	if err := createDirContent(sourceDir, []string{"file0"}); err != nil {
+		t.Fatalf("Can't create source content: %v", err)
+	}
+
+	// Mount on tmpfs as it supports idmapped mounts on recent kernels
+
+	if err := fs.Mount("tmpfs", sourceDir, "tmpfs", 0, ""); err != nil {
+		t.Fatalf("Can't mount tmpfs: %v", err)
+	}
+	defer fs.Umount(sourceDir) //nolint:errcheck
+
+	if err := os.WriteFile(filepath.Join(sourceDir, "file0"), nil, 0o600); err != nil {
+		t.Fatalf("Can't create file: %v", err)
+	}
+
+	mappings := []fs.IDMapping{{ContainerID: 0, HostID: 5000, Size: 1000}}
+
+	if err := fs.IDMappedMount(sourceDir, idMappedDir, mappings, mappings, false); err != nil {
+		if errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOSYS) || errors.Is(err, syscall.EPERM) {
+			t.Skipf("ID mapped mounts are not supported: %v", err)
+		}
+
+		t.Fatalf("Can't create ID mapped mount: %v", err)
+	}
+	defer fs.Umount(idMappedDir) //nolint:errcheck
+
+	info, err := os.Stat(filepath.Join(idMappedDir, "file0"))
+	if err != nil {
+		t.Fatalf("Can't stat file: %v", err)
+	}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); !ok || stat.Uid != 5000 || stat.Gid != 5000 {
+		t.Errorf("Wrong file owner: %v", info.Sys())
+	}
+}
+
+func TestProjectQuota(t *testing.T) {
+	const (
+		projectID  = 1000
+		byteLimit  = 1024 * 1024
+		inodeLimit = 100
+	)
+
+	for _, part := range disk.Partitions {
+		if part.Type != "ext4" {
+			continue
+		}
+
+		if output, err := exec.Command("tune2fs", "-O", "project,quota", part.Device).CombinedOutput(); err != nil {
+			t.Skipf("Can't enable project quota feature: %s", output)
+		}
+
+		if err := fs.Mount(part.Device, mountPoint, part.Type, 0, "prjquota"); err != nil {
+			t.Skipf("Can't mount partition with project quota: %v", err)
+		}
+
+		defer func() {
+			if err := fs.Umount(mountPoint); err != nil {
+				t.Errorf("Can't umount partition: %v", err)
+			}
+		}()
+
+		if supported, err := fs.ProjectQuotasSupported(mountPoint); err != nil || !supported {
+			t.Fatalf("Project quota should be supported: %v", err)
+		}
+
+		projectDir := filepath.Join(mountPoint, "project")
+
+		if err := os.MkdirAll(projectDir, 0o755); err != nil {
+			t.Fatalf("Can't create directory: %v", err)
+		}
+
+		if err := fs.CreateProjectQuota(projectDir, projectID, byteLimit, inodeLimit); err != nil {
+			t.Fatalf("Can't create project quota: %v", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(projectDir, "file"), make([]byte, 4096), 0o600); err != nil {
+			t.Fatalf("Can't write file: %v", err)
+		}
+
+		if id, err := fs.GetProjectID(filepath.Join(projectDir, "file")); err != nil || id != projectID {
+			t.Errorf("Wrong project ID: %d, err: %v", id, err)
+		}
+
+		syncFS(t)
+
+		info, err := fs.GetProjectQuota(projectDir, projectID)
+		if err != nil {
+			t.Fatalf("Can't get project quota: %v", err)
+		}
+
+		if info.BytesLimit != byteLimit || info.InodesLimit != inodeLimit || info.BytesUsed < 4096 {
+			t.Errorf("Wrong project quota info: %+v", info)
+		}
+
+		if err := os.WriteFile(
+			filepath.Join(projectDir, "big"), make([]byte, 2*byteLimit), 0o600); err == nil {
+			t.Error("Quota exceeded error expected")
+		}
+
+		infos, err := fs.GetProjectQuotas(mountPoint)
+		if err != nil {
+			t.Fatalf("Can't get project quotas: %v", err)
+		}
+
+		if len(infos) != 1 || infos[0].ProjectID != projectID {
+			t.Errorf("Wrong project quotas: %+v", infos)
+		}
+
+		if err := fs.RemoveProjectQuota(projectDir, projectID); err != nil {
+			t.Fatalf("Can't remove project quota: %v", err)
+		}
+
+		if id, err := fs.GetProjectID(projectDir); err != nil || id != 0 {
+			t.Errorf("Wrong project ID: %d, err: %v", id, err)
+		}
+	}
+}
+
 /***********************************************************************************************************************
  * Private
  **********************************************************************************************************************/
@@ -493,3 +745,11 @@ func getTotalSize(path string) (int64, error) {
 
 	return size, nil
 }
+
+func syncFS(t *testing.T) {
+	t.Helper()
+
+	if output, err := exec.Command("sync").CombinedOutput(); err != nil {
+		t.Errorf("Can't sync: %s", output)
+	}
+}
@@ -0,0 +1,401 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const maxOverlayOptsLen = 4096
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// OverlayConfig overlay FS stack configuration.
+type OverlayConfig struct {
+	// LowerDirs lower layers, the top-most layer first.
+	LowerDirs []string
+	// UpperDir writable layer. Read-only overlay is mounted if empty.
+	UpperDir string
+	// WorkDir overlay working dir. Should be on the same file system as UpperDir.
+	WorkDir string
+	// Index enables overlay inodes index.
+	Index bool
+	// MetaCopy enables metadata only copy up.
+	MetaCopy bool
+	// ReadOnly mounts overlay read-only.
+	ReadOnly bool
+	// AllowFUSE allows to fall back to fuse-overlayfs if kernel overlay FS is not available.
+	AllowFUSE bool
+}
+
+// Overlay mounted overlay FS stack.
+type Overlay struct {
+	mountPoint string
+	config     OverlayConfig
+	fuse       bool
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var (
+	fuseOverlayBin = "fuse-overlayfs"
+	fusermountBins = []string{"fusermount3", "fusermount"}
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ValidateOverlayConfig checks overlay configuration: lower dirs exist, upper and work dirs are on the same file
+// system and don't overlap.
+func ValidateOverlayConfig(config OverlayConfig) error {
+	if len(config.LowerDirs) == 0 {
+		return aoserrors.Wrap(aoserrors.ErrInvalidArgument)
+	}
+
+	usedDirs := make(map[string]struct{})
+
+	for _, lowerDir := range config.LowerDirs {
+		if err := checkOverlayDir(lowerDir); err != nil {
+			return err
+		}
+
+		cleanDir := filepath.Clean(lowerDir)
+
+		if _, ok := usedDirs[cleanDir]; ok {
+			return aoserrors.Errorf("duplicated lower dir %s", lowerDir)
+		}
+
+		usedDirs[cleanDir] = struct{}{}
+	}
+
+	if config.UpperDir == "" {
+		return nil
+	}
+
+	if config.WorkDir == "" {
+		return aoserrors.New("working dir path should be set")
+	}
+
+	if err := checkOverlayDir(config.UpperDir); err != nil {
+		return err
+	}
+
+	if isSubDir(config.UpperDir, config.WorkDir) || isSubDir(config.WorkDir, config.UpperDir) {
+		return aoserrors.New("upper and working dirs should not overlap")
+	}
+
+	upperDev, err := getDirDevice(config.UpperDir)
+	if err != nil {
+		return err
+	}
+
+	workDev, err := getDirDevice(getExistingDir(config.WorkDir))
+	if err != nil {
+		return err
+	}
+
+	if upperDev != workDev {
+		return aoserrors.New("upper and working dirs should be on the same file system")
+	}
+
+	return nil
+}
+
+// MountOverlay validates configuration and mounts overlay FS stack. Kernel overlay FS is used if available,
+// otherwise fuse-overlayfs is used if allowed. Created dirs are removed on failure.
+func MountOverlay(mountPoint string, config OverlayConfig) (overlay *Overlay, err error) {
+	log.WithFields(log.Fields{
+		"mountPoint": mountPoint, "lowerDirs": config.LowerDirs, "upperDir": config.UpperDir,
+	}).Debug("Mount overlay")
+
+	if err = ValidateOverlayConfig(config); err != nil {
+		return nil, err
+	}
+
+	overlay = &Overlay{mountPoint: mountPoint, config: config}
+
+	if !isKernelOverlaySupported() {
+		if !config.AllowFUSE {
+			return nil, aoserrors.Errorf("overlay FS is not supported: %w", aoserrors.ErrNotSupported)
+		}
+
+		overlay.fuse = true
+	}
+
+	var cleanupDirs []string
+
+	defer func() {
+		if err != nil {
+			for _, dir := range cleanupDirs {
+				if removeErr := os.RemoveAll(dir); removeErr != nil {
+					log.Errorf("Can't remove dir: %v", removeErr)
+				}
+			}
+		}
+	}()
+
+	if config.WorkDir != "" {
+		if err = os.RemoveAll(config.WorkDir); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		if err = os.MkdirAll(config.WorkDir, folderPerm); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		cleanupDirs = append(cleanupDirs, config.WorkDir)
+	}
+
+	if _, statErr := os.Stat(mountPoint); os.IsNotExist(statErr) {
+		cleanupDirs = append(cleanupDirs, mountPoint)
+	}
+
+	opts, err := overlay.options()
+	if err != nil {
+		return nil, err
+	}
+
+	if overlay.fuse {
+		err = overlay.mountFUSE(opts)
+	} else {
+		var flags uintptr
+
+		if config.ReadOnly {
+			flags |= syscall.MS_RDONLY
+		}
+
+		err = Mount("overlay", mountPoint, "overlay", flags, opts)
+	}
+
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return overlay, nil
+}
+
+// MountPoint returns overlay mount point.
+func (overlay *Overlay) MountPoint() string {
+	return overlay.mountPoint
+}
+
+// IsFUSE returns true if overlay is mounted with fuse-overlayfs.
+func (overlay *Overlay) IsFUSE() bool {
+	return overlay.fuse
+}
+
+// Remount remounts overlay FS read-only or read-write.
+func (overlay *Overlay) Remount(readOnly bool) error {
+	log.WithFields(log.Fields{"mountPoint": overlay.mountPoint, "readOnly": readOnly}).Debug("Remount overlay")
+
+	if overlay.fuse {
+		return aoserrors.Errorf("remount of FUSE overlay: %w", aoserrors.ErrNotSupported)
+	}
+
+	if !readOnly && overlay.config.UpperDir == "" {
+		return aoserrors.New("overlay without upper dir can't be mounted read-write")
+	}
+
+	flags := uintptr(syscall.MS_REMOUNT)
+
+	if readOnly {
+		flags |= syscall.MS_RDONLY
+	}
+
+	if err := syscall.Mount("overlay", overlay.mountPoint, "overlay", flags, ""); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	overlay.config.ReadOnly = readOnly
+
+	return nil
+}
+
+// Umount unmounts overlay FS, removes mount point and clears working dir.
+func (overlay *Overlay) Umount() (err error) {
+	if overlay.fuse {
+		err = overlay.umountFUSE()
+	} else {
+		err = Umount(overlay.mountPoint)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if overlay.config.WorkDir != "" {
+		if err = os.RemoveAll(overlay.config.WorkDir); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (overlay *Overlay) options() (string, error) {
+	opts := "lowerdir=" + strings.Join(overlay.config.LowerDirs, ":")
+
+	if overlay.config.UpperDir != "" {
+		opts = opts + ",upperdir=" + overlay.config.UpperDir + ",workdir=" + overlay.config.WorkDir
+	}
+
+	if !overlay.fuse {
+		if overlay.config.Index {
+			opts += ",index=on"
+		}
+
+		if overlay.config.MetaCopy {
+			opts += ",metacopy=on"
+		}
+	}
+
+	if len(opts) >= maxOverlayOptsLen {
+		return "", aoserrors.Errorf("overlay options too long: %d", len(opts))
+	}
+
+	return opts, nil
+}
+
+func (overlay *Overlay) mountFUSE(opts string) error {
+	if err := os.MkdirAll(overlay.mountPoint, folderPerm); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if overlay.config.ReadOnly {
+		opts += ",ro"
+	}
+
+	if output, err := exec.Command(fuseOverlayBin, "-o", opts, overlay.mountPoint).CombinedOutput(); err != nil {
+		return aoserrors.Errorf("%v (%s)", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func (overlay *Overlay) umountFUSE() (err error) {
+	for _, fusermountBin := range fusermountBins {
+		if _, lookErr := exec.LookPath(fusermountBin); lookErr != nil {
+			continue
+		}
+
+		output, cmdErr := exec.Command(fusermountBin, "-u", overlay.mountPoint).CombinedOutput()
+		if cmdErr != nil {
+			return aoserrors.Errorf("%v (%s)", cmdErr, strings.TrimSpace(string(output)))
+		}
+
+		return aoserrors.Wrap(os.RemoveAll(overlay.mountPoint))
+	}
+
+	return aoserrors.New("fusermount not found")
+}
+
+func isKernelOverlaySupported() bool {
+	file, err := os.Open("/proc/filesystems")
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) > 0 && fields[len(fields)-1] == "overlay" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkOverlayDir(dir string) error {
+	if strings.ContainsAny(dir, ":,") {
+		return aoserrors.Errorf("invalid overlay dir %s", dir)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if !info.IsDir() {
+		return aoserrors.Errorf("%s is not a dir", dir)
+	}
+
+	return nil
+}
+
+func isSubDir(parent, dir string) bool {
+	relPath, err := filepath.Rel(filepath.Clean(parent), filepath.Clean(dir))
+	if err != nil {
+		return false
+	}
+
+	return relPath == "." || (relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator)))
+}
+
+func getExistingDir(dir string) string {
+	dir = filepath.Clean(dir)
+
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return dir
+		}
+
+		dir = parentDir
+	}
+}
+
+func getDirDevice(dir string) (uint64, error) {
+	var stat syscall.Stat_t
+
+	if err := syscall.Stat(dir, &stat); err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return stat.Dev, nil
+}
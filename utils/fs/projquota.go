@@ -0,0 +1,351 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	qGetFmt       = 0x800004
+	qGetQuota     = 0x800007
+	qSetQuota     = 0x800008
+	qGetNextQuota = 0x800009
+	prjQuota      = 2
+	qifBLimits    = 1
+	qifILimits    = 4
+	qifLimits     = qifBLimits | qifILimits
+	quotaBlock    = 1024
+)
+
+const (
+	fsIocFSGetXAttr    = 0x801c581f
+	fsIocFSSetXAttr    = 0x401c5820
+	fsXFlagProjInherit = 0x200
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// ProjectQuotaInfo project quota limits and usage.
+type ProjectQuotaInfo struct {
+	ProjectID   uint32
+	BytesUsed   uint64
+	BytesLimit  uint64
+	InodesUsed  uint64
+	InodesLimit uint64
+}
+
+type ifDqblk struct {
+	bHardLimit uint64
+	bSoftLimit uint64
+	curSpace   uint64
+	iHardLimit uint64
+	iSoftLimit uint64
+	curInodes  uint64
+	bTime      uint64
+	iTime      uint64
+	valid      uint32
+}
+
+type ifNextDqblk struct {
+	bHardLimit uint64
+	bSoftLimit uint64
+	curSpace   uint64
+	iHardLimit uint64
+	iSoftLimit uint64
+	curInodes  uint64
+	bTime      uint64
+	iTime      uint64
+	valid      uint32
+	id         uint32
+}
+
+type fsXAttr struct {
+	xFlags     uint32
+	extSize    uint32
+	nExtents   uint32
+	projID     uint32
+	cowExtSize uint32
+	pad        [8]byte
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ProjectQuotasSupported checks if project quotas are enabled on file system containing path.
+// Project quotas are supported by XFS (prjquota mount option) and ext4 (project feature and prjquota mount option).
+func ProjectQuotasSupported(path string) (supported bool, err error) {
+	device, err := getMountDevice(path)
+	if err != nil {
+		return false, err
+	}
+
+	var format uint32
+
+	if err = quotactl(qGetFmt, device, 0, unsafe.Pointer(&format)); err != nil {
+		if errors.Is(err, syscall.ESRCH) || errors.Is(err, syscall.ENOSYS) || errors.Is(err, syscall.EINVAL) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CreateProjectQuota assigns project ID to directory tree and sets project quota limits.
+// Zero limit means no limit.
+func CreateProjectQuota(dir string, projectID uint32, byteLimit, inodeLimit uint64) error {
+	log.WithFields(log.Fields{
+		"dir": dir, "projectID": projectID, "byteLimit": byteLimit, "inodeLimit": inodeLimit,
+	}).Debug("Create project quota")
+
+	if err := SetProjectID(dir, projectID); err != nil {
+		return err
+	}
+
+	return SetProjectQuota(dir, projectID, byteLimit, inodeLimit)
+}
+
+// RemoveProjectQuota clears project quota limits and resets project ID of directory tree.
+func RemoveProjectQuota(dir string, projectID uint32) error {
+	log.WithFields(log.Fields{"dir": dir, "projectID": projectID}).Debug("Remove project quota")
+
+	if err := SetProjectQuota(dir, projectID, 0, 0); err != nil {
+		return err
+	}
+
+	return SetProjectID(dir, 0)
+}
+
+// SetProjectID assigns project ID to directory tree. New files created in directory inherit project ID.
+// Zero project ID resets assignment.
+func SetProjectID(dir string, projectID uint32) error {
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if !entry.IsDir() && !entry.Type().IsRegular() {
+			return nil
+		}
+
+		return setFileProjectID(path, projectID, entry.IsDir())
+	})
+
+	return aoserrors.Wrap(err)
+}
+
+// GetProjectID returns project ID assigned to file or directory.
+func GetProjectID(path string) (projectID uint32, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	var attr fsXAttr
+
+	if err = ioctl(file.Fd(), fsIocFSGetXAttr, unsafe.Pointer(&attr)); err != nil {
+		return 0, err
+	}
+
+	return attr.projID, nil
+}
+
+// SetProjectQuota sets project quota limits on file system containing path. Zero limit means no limit.
+func SetProjectQuota(path string, projectID uint32, byteLimit, inodeLimit uint64) error {
+	device, err := getMountDevice(path)
+	if err != nil {
+		return err
+	}
+
+	quota := ifDqblk{
+		bHardLimit: (byteLimit + quotaBlock - 1) / quotaBlock,
+		bSoftLimit: (byteLimit + quotaBlock - 1) / quotaBlock,
+		iHardLimit: inodeLimit,
+		iSoftLimit: inodeLimit,
+		valid:      qifLimits,
+	}
+
+	return quotactl(qSetQuota, device, projectID, unsafe.Pointer(&quota))
+}
+
+// GetProjectQuota returns project quota limits and usage.
+func GetProjectQuota(path string, projectID uint32) (info ProjectQuotaInfo, err error) {
+	device, err := getMountDevice(path)
+	if err != nil {
+		return info, err
+	}
+
+	var quota ifDqblk
+
+	if err = quotactl(qGetQuota, device, projectID, unsafe.Pointer(&quota)); err != nil {
+		return info, err
+	}
+
+	return quota.toInfo(projectID), nil
+}
+
+// GetProjectQuotas enumerates all projects having quota on file system containing path.
+func GetProjectQuotas(path string) (infos []ProjectQuotaInfo, err error) {
+	device, err := getMountDevice(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextID uint32
+
+	for {
+		var quota ifNextDqblk
+
+		if err = quotactl(qGetNextQuota, device, nextID, unsafe.Pointer(&quota)); err != nil {
+			if errors.Is(err, syscall.ENOENT) {
+				return infos, nil
+			}
+
+			return nil, err
+		}
+
+		if quota.id != 0 {
+			infos = append(infos, ProjectQuotaInfo{
+				ProjectID:   quota.id,
+				BytesUsed:   quota.curSpace,
+				BytesLimit:  quota.bHardLimit * quotaBlock,
+				InodesUsed:  quota.curInodes,
+				InodesLimit: quota.iHardLimit,
+			})
+		}
+
+		if quota.id == ^uint32(0) {
+			return infos, nil
+		}
+
+		nextID = quota.id + 1
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (quota *ifDqblk) toInfo(projectID uint32) ProjectQuotaInfo {
+	return ProjectQuotaInfo{
+		ProjectID:   projectID,
+		BytesUsed:   quota.curSpace,
+		BytesLimit:  quota.bHardLimit * quotaBlock,
+		InodesUsed:  quota.curInodes,
+		InodesLimit: quota.iHardLimit,
+	}
+}
+
+func setFileProjectID(path string, projectID uint32, isDir bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	var attr fsXAttr
+
+	if err = ioctl(file.Fd(), fsIocFSGetXAttr, unsafe.Pointer(&attr)); err != nil {
+		return err
+	}
+
+	attr.projID = projectID
+
+	if isDir && projectID != 0 {
+		attr.xFlags |= fsXFlagProjInherit
+	} else {
+		attr.xFlags &^= fsXFlagProjInherit
+	}
+
+	return ioctl(file.Fd(), fsIocFSSetXAttr, unsafe.Pointer(&attr))
+}
+
+func getMountDevice(path string) (device string, err error) {
+	mountPoint, err := GetMountPoint(path)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open("/proc/mounts")
+	if err != nil {
+		return "", aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		if fields[1] == mountPoint {
+			device = fields[0]
+		}
+	}
+
+	if device == "" {
+		return "", aoserrors.Errorf("failed to find device for %s", path)
+	}
+
+	return device, nil
+}
+
+func quotactl(cmd int, device string, id uint32, addr unsafe.Pointer) error {
+	devicePtr, err := syscall.BytePtrFromString(device)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if _, _, errno := syscall.Syscall6(syscall.SYS_QUOTACTL, uintptr(cmd<<8|prjQuota), //nolint:gosec
+		uintptr(unsafe.Pointer(devicePtr)), uintptr(id), uintptr(addr), 0, 0); errno != 0 {
+		return aoserrors.Wrap(errno)
+	}
+
+	return nil
+}
+
+func ioctl(fd uintptr, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(arg)); errno != 0 {
+		return aoserrors.Wrap(errno)
+	}
+
+	return nil
+}
@@ -0,0 +1,307 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package securestorage provides dm-crypt and dm-verity volume helpers.
+package securestorage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	dmControlPath = "/dev/mapper/control"
+	dmMapperDir   = "/dev/mapper"
+)
+
+const (
+	dmVersionMajor = 4
+	dmNameLen      = 128
+	dmUUIDLen      = 129
+	dmIoctlSize    = 312
+	dmTargetSize   = 40
+	dmTargetType   = 16
+	dmBufferSize   = 16 * 1024
+	dmAlign        = 8
+)
+
+const (
+	dmDevCreate  = 3
+	dmDevRemove  = 4
+	dmDevSuspend = 6
+	dmDevStatus  = 7
+	dmTableLoad  = 9
+)
+
+const (
+	dmReadOnlyFlag = 1 << 0
+	dmSuspendFlag  = 1 << 1
+)
+
+const (
+	sectorSize = 512
+	devicePerm = 0o600
+	mapperPerm = 0o755
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type dmIoctl struct {
+	Version     [3]uint32
+	DataSize    uint32
+	DataStart   uint32
+	TargetCount uint32
+	OpenCount   int32
+	Flags       uint32
+	EventNr     uint32
+	Padding     uint32
+	Dev         uint64
+	Name        [dmNameLen]byte
+	UUID        [dmUUIDLen]byte
+	Data        [7]byte
+}
+
+type dmTargetSpec struct {
+	SectorStart uint64
+	Length      uint64
+	Status      int32
+	Next        uint32
+	TargetType  [dmTargetType]byte
+}
+
+type dmTarget struct {
+	start      uint64
+	length     uint64
+	targetType string
+	params     string
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+// createDevice creates device mapper device with single target and returns mapped device path.
+func createDevice(name string, target dmTarget, readOnly bool) (devicePath string, err error) {
+	log.WithFields(log.Fields{"name": name, "type": target.targetType}).Debug("Create device mapper device")
+
+	control, err := os.OpenFile(dmControlPath, os.O_RDWR, 0)
+	if err != nil {
+		return "", aoserrors.Wrap(err)
+	}
+	defer control.Close()
+
+	var flags uint32
+
+	if readOnly {
+		flags |= dmReadOnlyFlag
+	}
+
+	if _, err = dmCommand(control, dmDevCreate, name, 0, nil); err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err != nil {
+			if _, removeErr := dmCommand(control, dmDevRemove, name, 0, nil); removeErr != nil {
+				log.Errorf("Can't remove device mapper device: %v", removeErr)
+			}
+		}
+	}()
+
+	if _, err = dmCommand(control, dmTableLoad, name, flags, []dmTarget{target}); err != nil {
+		return "", err
+	}
+
+	result, err := dmCommand(control, dmDevSuspend, name, 0, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if devicePath, err = createDeviceNode(name, result.Dev); err != nil {
+		return "", err
+	}
+
+	return devicePath, nil
+}
+
+// removeDevice removes device mapper device.
+func removeDevice(name string) error {
+	log.WithField("name", name).Debug("Remove device mapper device")
+
+	control, err := os.OpenFile(dmControlPath, os.O_RDWR, 0)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer control.Close()
+
+	if _, err = dmCommand(control, dmDevRemove, name, 0, nil); err != nil {
+		return err
+	}
+
+	if err = os.Remove(filepath.Join(dmMapperDir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
+
+// deviceExists checks if device mapper device with name exists.
+func deviceExists(name string) (bool, error) {
+	control, err := os.OpenFile(dmControlPath, os.O_RDWR, 0)
+	if err != nil {
+		return false, aoserrors.Wrap(err)
+	}
+	defer control.Close()
+
+	if _, err = dmCommand(control, dmDevStatus, name, 0, nil); err != nil {
+		if errors.Is(err, syscall.ENXIO) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func dmCommand(control *os.File, command uintptr, name string, flags uint32, targets []dmTarget) (
+	result dmIoctl, err error,
+) {
+	if len(name) >= dmNameLen {
+		return result, aoserrors.Errorf("device name too long: %s", name)
+	}
+
+	header := dmIoctl{
+		Version: [3]uint32{dmVersionMajor, 0, 0}, DataStart: dmIoctlSize, Flags: flags,
+		TargetCount: uint32(len(targets)), //nolint:gosec // targets count is small
+	}
+
+	copy(header.Name[:], name)
+
+	payload, err := encodeTargets(targets)
+	if err != nil {
+		return result, err
+	}
+
+	buffer := make([]byte, dmBufferSize)
+
+	if dmIoctlSize+len(payload) > len(buffer) {
+		return result, aoserrors.New("device mapper table too big")
+	}
+
+	header.DataSize = uint32(len(buffer))
+
+	var headerBuffer bytes.Buffer
+
+	if err = binary.Write(&headerBuffer, binary.NativeEndian, header); err != nil {
+		return result, aoserrors.Wrap(err)
+	}
+
+	copy(buffer, headerBuffer.Bytes())
+	copy(buffer[dmIoctlSize:], payload)
+
+	request := uintptr(0xc0000000|dmIoctlSize<<16|0xfd<<8) | command
+
+	if _, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL, control.Fd(), request, uintptr(unsafe.Pointer(&buffer[0]))); errno != 0 {
+		return result, aoserrors.Wrap(errno)
+	}
+
+	if err = binary.Read(bytes.NewReader(buffer[:dmIoctlSize]), binary.NativeEndian, &result); err != nil {
+		return result, aoserrors.Wrap(err)
+	}
+
+	return result, nil
+}
+
+func encodeTargets(targets []dmTarget) ([]byte, error) {
+	var payload bytes.Buffer
+
+	for _, target := range targets {
+		params := append([]byte(target.params), 0)
+
+		for (dmTargetSize+len(params))%dmAlign != 0 {
+			params = append(params, 0)
+		}
+
+		spec := dmTargetSpec{
+			SectorStart: target.start, Length: target.length,
+			Next: uint32(dmTargetSize + len(params)), //nolint:gosec // params size is limited by buffer size
+		}
+
+		copy(spec.TargetType[:], target.targetType)
+
+		if err := binary.Write(&payload, binary.NativeEndian, spec); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		payload.Write(params)
+	}
+
+	return payload.Bytes(), nil
+}
+
+func createDeviceNode(name string, dev uint64) (string, error) {
+	devicePath := filepath.Join(dmMapperDir, name)
+
+	if _, err := os.Stat(devicePath); err == nil {
+		return devicePath, nil
+	}
+
+	if err := os.MkdirAll(dmMapperDir, mapperPerm); err != nil {
+		return "", aoserrors.Wrap(err)
+	}
+
+	if err := syscall.Mknod(devicePath, syscall.S_IFBLK|devicePerm, int(dev)); err != nil { //nolint:gosec
+		if !errors.Is(err, os.ErrExist) {
+			return "", aoserrors.Wrap(err)
+		}
+	}
+
+	return devicePath, nil
+}
+
+func getDeviceSize(device string) (size uint64, err error) {
+	file, err := os.Open(device)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	end, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	return uint64(end), nil
+}
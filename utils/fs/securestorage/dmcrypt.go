@@ -0,0 +1,424 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securestorage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/cryptutils"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Default dm-crypt parameters.
+const (
+	DefaultCryptCipher  = "aes-xts-plain64"
+	DefaultCryptKeySize = 64
+)
+
+const (
+	cryptPassphraseSize = 64
+	cryptKeyslot        = "0"
+	cryptTokenID        = "0"
+	cryptTokenType      = "aos-protected-key"
+	// Passphrase is random full entropy key, so slow KDF doesn't add security and minimal PBKDF2 is used.
+	cryptPBKDFIterations = 1000
+)
+
+// LUKS2 binary header layout, see LUKS2 On-Disk Format Specification.
+const (
+	luks2Magic              = "LUKS\xba\xbe"
+	luks2Version            = 2
+	luks2BinaryHeaderSize   = 4096
+	luks2MinHeaderSize      = 16 * 1024
+	luks2MaxHeaderSize      = 4 * 1024 * 1024
+	luks2VersionOffset      = 6
+	luks2HeaderSizeOffset   = 8
+	luks2ChecksumAlgOffset  = 72
+	luks2ChecksumAlgSize    = 32
+	luks2UUIDOffset         = 168
+	luks2UUIDSize           = 40
+	luks2ChecksumOffset     = 448
+	luks2ChecksumSize       = 64
+	luks2ChecksumAlgorithm  = "sha256"
+	luks2SegmentTypeCrypt   = "crypt"
+	luks2KeyslotTypeLUKS2   = "luks2"
+	luks2DynamicSegmentSize = "dynamic"
+)
+
+// supportedCryptCiphers contains allowed dm-crypt ciphers with their valid key sizes.
+var supportedCryptCiphers = map[string][]int{ //nolint:gochecknoglobals
+	"aes-xts-plain64":      {32, 64},
+	"aes-cbc-essiv:sha256": {16, 24, 32},
+}
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// CryptConfig dm-crypt volume configuration.
+type CryptConfig struct {
+	Cipher  string
+	KeySize int
+}
+
+// CryptHeader dm-crypt volume parameters read from LUKS2 header.
+type CryptHeader struct {
+	Version       int
+	UUID          string
+	Cipher        string
+	KeySize       int
+	PayloadOffset uint64
+	ProtectedKey  []byte
+}
+
+type luks2Metadata struct {
+	Keyslots map[string]luks2Keyslot `json:"keyslots"`
+	Tokens   map[string]luks2Token   `json:"tokens"`
+	Segments map[string]luks2Segment `json:"segments"`
+}
+
+type luks2Keyslot struct {
+	Type    string `json:"type"`
+	KeySize int    `json:"key_size"`
+}
+
+type luks2Token struct {
+	Type         string   `json:"type"`
+	Keyslots     []string `json:"keyslots"`
+	ProtectedKey []byte   `json:"protectedKey,omitempty"`
+}
+
+type luks2Segment struct {
+	Type       string `json:"type"`
+	Offset     string `json:"offset"`
+	Size       string `json:"size"`
+	Encryption string `json:"encryption"`
+	SectorSize int    `json:"sector_size"`
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// FormatCrypt formats device as LUKS2 volume with cryptsetup. Random passphrase is generated for the volume keyslot
+// and stored in the volume header token protected by key protector. All data on the device become inaccessible.
+func FormatCrypt(device string, protector KeyProtector, config CryptConfig) (err error) {
+	log.WithFields(log.Fields{"device": device, "cipher": config.Cipher}).Debug("Format crypt volume")
+
+	if config.Cipher == "" {
+		config.Cipher = DefaultCryptCipher
+	}
+
+	if config.KeySize == 0 {
+		config.KeySize = DefaultCryptKeySize
+	}
+
+	if err = validateCryptParams(config.Cipher, config.KeySize); err != nil {
+		return err
+	}
+
+	passphrase := make([]byte, cryptPassphraseSize)
+	defer cryptutils.ZeroizeBytes(passphrase)
+
+	if _, err = rand.Read(passphrase); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	protectedKey, err := protector.ProtectKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err = runCryptsetup(passphrase, "luksFormat", "--batch-mode", "--type", "luks2",
+		"--cipher", config.Cipher, "--key-size", strconv.Itoa(config.KeySize*8), "--key-slot", cryptKeyslot,
+		"--pbkdf", "pbkdf2", "--pbkdf-force-iterations", strconv.Itoa(cryptPBKDFIterations),
+		"--key-file", "-", device); err != nil {
+		return err
+	}
+
+	return importCryptToken(device, protectedKey, false)
+}
+
+// IsCryptFormatted checks if device is formatted as LUKS volume.
+func IsCryptFormatted(device string) (bool, error) {
+	file, err := os.Open(device)
+	if err != nil {
+		return false, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	magic := make([]byte, len(luks2Magic))
+
+	if _, err = io.ReadFull(file, magic); err != nil {
+		return false, nil //nolint:nilerr // short device is not formatted
+	}
+
+	return string(magic) == luks2Magic, nil
+}
+
+// ReadCryptHeader reads and verifies LUKS2 volume header.
+func ReadCryptHeader(device string) (header CryptHeader, err error) {
+	file, err := os.Open(device)
+	if err != nil {
+		return header, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	binaryHeader := make([]byte, luks2BinaryHeaderSize)
+
+	if _, err = io.ReadFull(file, binaryHeader); err != nil {
+		return header, aoserrors.Wrap(err)
+	}
+
+	if string(binaryHeader[:len(luks2Magic)]) != luks2Magic {
+		return header, aoserrors.Errorf("device %s is not crypt volume", device)
+	}
+
+	header.Version = int(binary.BigEndian.Uint16(binaryHeader[luks2VersionOffset:]))
+
+	if header.Version != luks2Version {
+		return header, aoserrors.Errorf("unsupported crypt header version: %d", header.Version)
+	}
+
+	headerSize := binary.BigEndian.Uint64(binaryHeader[luks2HeaderSizeOffset:])
+
+	if headerSize < luks2MinHeaderSize || headerSize > luks2MaxHeaderSize {
+		return header, aoserrors.New("invalid crypt header size")
+	}
+
+	data := make([]byte, headerSize)
+
+	copy(data, binaryHeader)
+
+	if _, err = io.ReadFull(file, data[luks2BinaryHeaderSize:]); err != nil {
+		return header, aoserrors.Wrap(err)
+	}
+
+	if err = verifyLUKS2Checksum(data); err != nil {
+		return header, err
+	}
+
+	header.UUID = getLUKS2String(data[luks2UUIDOffset : luks2UUIDOffset+luks2UUIDSize])
+
+	var metadata luks2Metadata
+
+	if err = json.Unmarshal(bytes.TrimRight(data[luks2BinaryHeaderSize:], "\x00"), &metadata); err != nil {
+		return header, aoserrors.Wrap(err)
+	}
+
+	if err = parseLUKS2Metadata(metadata, &header); err != nil {
+		return header, err
+	}
+
+	return header, nil
+}
+
+// ChangeCryptKeyProtector re-protects volume passphrase with new key protector.
+func ChangeCryptKeyProtector(device string, oldProtector, newProtector KeyProtector) error {
+	header, err := ReadCryptHeader(device)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := oldProtector.UnprotectKey(header.ProtectedKey)
+	if err != nil {
+		return err
+	}
+	defer cryptutils.ZeroizeBytes(passphrase)
+
+	if err = runCryptsetup(passphrase, "open", "--test-passphrase", "--type", "luks2",
+		"--key-slot", cryptKeyslot, "--key-file", "-", device); err != nil {
+		return err
+	}
+
+	protectedKey, err := newProtector.ProtectKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	return importCryptToken(device, protectedKey, true)
+}
+
+// OpenCrypt opens LUKS2 volume and returns mapped device path.
+func OpenCrypt(device, name string, protector KeyProtector) (devicePath string, err error) {
+	log.WithFields(log.Fields{"device": device, "name": name}).Debug("Open crypt volume")
+
+	header, err := ReadCryptHeader(device)
+	if err != nil {
+		return "", err
+	}
+
+	passphrase, err := protector.UnprotectKey(header.ProtectedKey)
+	if err != nil {
+		return "", err
+	}
+	defer cryptutils.ZeroizeBytes(passphrase)
+
+	exists, err := deviceExists(name)
+	if err != nil {
+		return "", err
+	}
+
+	if exists {
+		return "", aoserrors.Errorf("device %s: %w", name, aoserrors.ErrAlreadyExist)
+	}
+
+	if err = runCryptsetup(passphrase, "open", "--type", "luks2", "--key-slot", cryptKeyslot,
+		"--key-file", "-", device, name); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dmMapperDir, name), nil
+}
+
+// CloseCrypt closes LUKS2 volume.
+func CloseCrypt(name string) error {
+	return runCryptsetup(nil, "close", name)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func runCryptsetup(input []byte, args ...string) error {
+	cmd := exec.Command("cryptsetup", args...)
+
+	cmd.Stdin = bytes.NewReader(input)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return aoserrors.Errorf("cryptsetup %s: %v: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func importCryptToken(device string, protectedKey []byte, replace bool) error {
+	token, err := json.Marshal(luks2Token{
+		Type: cryptTokenType, Keyslots: []string{cryptKeyslot}, ProtectedKey: protectedKey,
+	})
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	args := []string{"token", "import", "--json-file", "-", "--token-id", cryptTokenID}
+
+	if replace {
+		args = append(args, "--token-replace")
+	}
+
+	return runCryptsetup(token, append(args, device)...)
+}
+
+func verifyLUKS2Checksum(data []byte) error {
+	algorithm := getLUKS2String(data[luks2ChecksumAlgOffset : luks2ChecksumAlgOffset+luks2ChecksumAlgSize])
+
+	if algorithm != luks2ChecksumAlgorithm {
+		return aoserrors.Errorf("unsupported crypt header checksum algorithm: %s", algorithm)
+	}
+
+	checksum := bytes.Clone(data[luks2ChecksumOffset : luks2ChecksumOffset+sha256.Size])
+
+	clear(data[luks2ChecksumOffset : luks2ChecksumOffset+luks2ChecksumSize])
+
+	calculated := sha256.Sum256(data)
+
+	if subtle.ConstantTimeCompare(checksum, calculated[:]) != 1 {
+		return aoserrors.New("invalid crypt header checksum")
+	}
+
+	return nil
+}
+
+func parseLUKS2Metadata(metadata luks2Metadata, header *CryptHeader) error {
+	keyslot, ok := metadata.Keyslots[cryptKeyslot]
+	if !ok || keyslot.Type != luks2KeyslotTypeLUKS2 {
+		return aoserrors.New("crypt keyslot not found")
+	}
+
+	token, ok := metadata.Tokens[cryptTokenID]
+	if !ok || token.Type != cryptTokenType || len(token.ProtectedKey) == 0 {
+		return aoserrors.New("crypt protected key token not found")
+	}
+
+	if len(metadata.Segments) != 1 {
+		return aoserrors.Errorf("unsupported crypt segments count: %d", len(metadata.Segments))
+	}
+
+	for _, segment := range metadata.Segments {
+		if segment.Type != luks2SegmentTypeCrypt || segment.Size != luks2DynamicSegmentSize {
+			return aoserrors.Errorf("unsupported crypt segment: %s", segment.Type)
+		}
+
+		offset, err := strconv.ParseUint(segment.Offset, 10, 64)
+		if err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if offset == 0 || offset%sectorSize != 0 {
+			return aoserrors.Errorf("invalid crypt payload offset: %d", offset)
+		}
+
+		header.Cipher = segment.Encryption
+		header.PayloadOffset = offset / sectorSize
+	}
+
+	header.KeySize = keyslot.KeySize
+	header.ProtectedKey = token.ProtectedKey
+
+	return validateCryptParams(header.Cipher, header.KeySize)
+}
+
+func getLUKS2String(data []byte) string {
+	if index := bytes.IndexByte(data, 0); index >= 0 {
+		data = data[:index]
+	}
+
+	return string(data)
+}
+
+func validateCryptParams(cipher string, keySize int) error {
+	keySizes, ok := supportedCryptCiphers[cipher]
+	if !ok {
+		return aoserrors.Errorf("unsupported crypt cipher: %s", cipher)
+	}
+
+	for _, size := range keySizes {
+		if size == keySize {
+			return nil
+		}
+	}
+
+	return aoserrors.Errorf("unsupported crypt key size %d for cipher %s", keySize, cipher)
+}
@@ -0,0 +1,412 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securestorage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Default dm-verity parameters.
+const (
+	DefaultVerityHashAlgorithm = "sha256"
+	DefaultVerityBlockSize     = 4096
+	DefaultVeritySaltSize      = 32
+)
+
+const (
+	veritySignature     = "verity\x00\x00"
+	veritySuperblockVer = 1
+	verityHashType      = 1
+	veritySuperblock    = 512
+	verityMaxSalt       = 256
+	verityAlgorithmLen  = 32
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// VerityParams dm-verity hash tree parameters.
+type VerityParams struct {
+	HashAlgorithm string `json:"hashAlgorithm"`
+	DataBlockSize uint32 `json:"dataBlockSize"`
+	HashBlockSize uint32 `json:"hashBlockSize"`
+	DataBlocks    uint64 `json:"dataBlocks"`
+	HashOffset    uint64 `json:"hashOffset"`
+	Salt          []byte `json:"salt"`
+	RootHash      []byte `json:"rootHash"`
+}
+
+// verity superblock compatible with veritysetup.
+type veritySuperblockData struct {
+	Signature     [8]byte
+	Version       uint32
+	HashType      uint32
+	UUID          [16]byte
+	Algorithm     [verityAlgorithmLen]byte
+	DataBlockSize uint32
+	HashBlockSize uint32
+	DataBlocks    uint64
+	SaltSize      uint16
+	Pad1          [6]byte
+	Salt          [verityMaxSalt]byte
+	Pad2          [168]byte
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// FormatVerity creates dm-verity hash tree of data device and writes it with veritysetup compatible superblock to
+// hash device at params.HashOffset. Data and hash devices may be the same device if hash offset is behind data.
+// Zero params fields are set to default values. Resulting params with root hash are returned.
+func FormatVerity(dataDevice, hashDevice string, params VerityParams) (result VerityParams, err error) {
+	log.WithFields(log.Fields{"dataDevice": dataDevice, "hashDevice": hashDevice}).Debug("Format verity")
+
+	if params, err = prepareVerityParams(dataDevice, params); err != nil {
+		return result, err
+	}
+
+	levels, rootHash, err := createHashTree(dataDevice, params)
+	if err != nil {
+		return result, err
+	}
+
+	params.RootHash = rootHash
+
+	file, err := os.OpenFile(hashDevice, os.O_WRONLY|os.O_CREATE, devicePerm)
+	if err != nil {
+		return result, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	superblock, err := createVeritySuperblock(params)
+	if err != nil {
+		return result, err
+	}
+
+	if _, err = file.WriteAt(superblock, int64(params.HashOffset)); err != nil { //nolint:gosec
+		return result, aoserrors.Wrap(err)
+	}
+
+	offset := int64(params.HashOffset + uint64(params.HashBlockSize)) //nolint:gosec
+
+	// Tree is stored from the top level to the bottom one.
+	for i := len(levels) - 1; i >= 0; i-- {
+		if _, err = file.WriteAt(levels[i], offset); err != nil {
+			return result, aoserrors.Wrap(err)
+		}
+
+		offset += int64(len(levels[i]))
+	}
+
+	if err = file.Sync(); err != nil {
+		return result, aoserrors.Wrap(err)
+	}
+
+	return params, nil
+}
+
+// VerifyVerity recalculates hash tree of data device and compares it with root hash and stored hash tree.
+func VerifyVerity(dataDevice, hashDevice string, params VerityParams) error {
+	if len(params.RootHash) == 0 {
+		return aoserrors.New("root hash should be set")
+	}
+
+	expectedRootHash := params.RootHash
+
+	params.RootHash = nil
+
+	params, err := prepareVerityParams(dataDevice, params)
+	if err != nil {
+		return err
+	}
+
+	levels, rootHash, err := createHashTree(dataDevice, params)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(rootHash, expectedRootHash) {
+		return aoserrors.Errorf("verity root hash mismatch: %w", aoserrors.ErrInvalidChecksum)
+	}
+
+	file, err := os.Open(hashDevice)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	offset := int64(params.HashOffset + uint64(params.HashBlockSize)) //nolint:gosec
+
+	for i := len(levels) - 1; i >= 0; i-- {
+		storedLevel := make([]byte, len(levels[i]))
+
+		if _, err = file.ReadAt(storedLevel, offset); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		if !bytes.Equal(storedLevel, levels[i]) {
+			return aoserrors.Errorf("verity hash tree mismatch: %w", aoserrors.ErrInvalidChecksum)
+		}
+
+		offset += int64(len(levels[i]))
+	}
+
+	return nil
+}
+
+// ReadVerityParams reads verity params from veritysetup compatible superblock. Root hash is not stored in
+// superblock and should be provided separately.
+func ReadVerityParams(hashDevice string, hashOffset uint64) (params VerityParams, err error) {
+	file, err := os.Open(hashDevice)
+	if err != nil {
+		return params, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	data := make([]byte, veritySuperblock)
+
+	if _, err = file.ReadAt(data, int64(hashOffset)); err != nil { //nolint:gosec
+		return params, aoserrors.Wrap(err)
+	}
+
+	var superblock veritySuperblockData
+
+	if err = binary.Read(bytes.NewReader(data), binary.LittleEndian, &superblock); err != nil {
+		return params, aoserrors.Wrap(err)
+	}
+
+	if string(superblock.Signature[:]) != veritySignature || superblock.Version != veritySuperblockVer ||
+		superblock.HashType != verityHashType || superblock.SaltSize > verityMaxSalt {
+		return params, aoserrors.New("invalid verity superblock")
+	}
+
+	return VerityParams{
+		HashAlgorithm: string(bytes.TrimRight(superblock.Algorithm[:], "\x00")),
+		DataBlockSize: superblock.DataBlockSize,
+		HashBlockSize: superblock.HashBlockSize,
+		DataBlocks:    superblock.DataBlocks,
+		HashOffset:    hashOffset,
+		Salt:          append([]byte{}, superblock.Salt[:superblock.SaltSize]...),
+	}, nil
+}
+
+// OpenVerity creates read-only dm-verity device and returns mapped device path.
+func OpenVerity(name, dataDevice, hashDevice string, params VerityParams) (devicePath string, err error) {
+	log.WithFields(log.Fields{"name": name, "dataDevice": dataDevice}).Debug("Open verity")
+
+	if len(params.RootHash) == 0 {
+		return "", aoserrors.New("root hash should be set")
+	}
+
+	salt := "-"
+
+	if len(params.Salt) != 0 {
+		salt = hex.EncodeToString(params.Salt)
+	}
+
+	return createDevice(name, dmTarget{
+		length:     params.DataBlocks * uint64(params.DataBlockSize) / sectorSize,
+		targetType: "verity",
+		params: fmt.Sprintf("%d %s %s %d %d %d %d %s %s %s", verityHashType, dataDevice, hashDevice,
+			params.DataBlockSize, params.HashBlockSize, params.DataBlocks,
+			(params.HashOffset+uint64(params.HashBlockSize))/uint64(params.HashBlockSize),
+			params.HashAlgorithm, hex.EncodeToString(params.RootHash), salt),
+	}, true)
+}
+
+// CloseVerity removes dm-verity device.
+func CloseVerity(name string) error {
+	return removeDevice(name)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func prepareVerityParams(dataDevice string, params VerityParams) (VerityParams, error) {
+	if params.HashAlgorithm == "" {
+		params.HashAlgorithm = DefaultVerityHashAlgorithm
+	}
+
+	if _, err := newVerityHash(params.HashAlgorithm); err != nil {
+		return params, err
+	}
+
+	if params.DataBlockSize == 0 {
+		params.DataBlockSize = DefaultVerityBlockSize
+	}
+
+	if params.HashBlockSize == 0 {
+		params.HashBlockSize = DefaultVerityBlockSize
+	}
+
+	if params.HashOffset%uint64(params.HashBlockSize) != 0 {
+		return params, aoserrors.New("hash offset should be aligned to hash block size")
+	}
+
+	if params.Salt == nil {
+		params.Salt = make([]byte, DefaultVeritySaltSize)
+
+		if _, err := rand.Read(params.Salt); err != nil {
+			return params, aoserrors.Wrap(err)
+		}
+	}
+
+	if len(params.Salt) > verityMaxSalt {
+		return params, aoserrors.New("verity salt too long")
+	}
+
+	if params.DataBlocks == 0 {
+		size, err := getDeviceSize(dataDevice)
+		if err != nil {
+			return params, err
+		}
+
+		params.DataBlocks = size / uint64(params.DataBlockSize)
+	}
+
+	if params.DataBlocks == 0 {
+		return params, aoserrors.New("data device is empty")
+	}
+
+	return params, nil
+}
+
+// createHashTree calculates hash tree levels starting from the bottom one and root hash.
+func createHashTree(dataDevice string, params VerityParams) (levels [][]byte, rootHash []byte, err error) {
+	hasher, err := newVerityHash(params.HashAlgorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(dataDevice)
+	if err != nil {
+		return nil, nil, aoserrors.Wrap(err)
+	}
+	defer file.Close()
+
+	reader := io.LimitReader(file, int64(params.DataBlocks*uint64(params.DataBlockSize))) //nolint:gosec
+	block := make([]byte, params.DataBlockSize)
+
+	var digests [][]byte
+
+	for range params.DataBlocks {
+		if _, err = io.ReadFull(reader, block); err != nil {
+			return nil, nil, aoserrors.Wrap(err)
+		}
+
+		digests = append(digests, saltedHash(hasher, params.Salt, block))
+	}
+
+	digestSize := hasher.Size()
+	hashesPerBlock := int(params.HashBlockSize) / digestSize
+
+	for len(digests) > 1 {
+		var (
+			level      []byte
+			nextLevel  [][]byte
+			blockCount = (len(digests) + hashesPerBlock - 1) / hashesPerBlock
+		)
+
+		for i := range blockCount {
+			hashBlock := make([]byte, params.HashBlockSize)
+
+			for j, digest := range digests[i*hashesPerBlock : min((i+1)*hashesPerBlock, len(digests))] {
+				copy(hashBlock[j*digestSize:], digest)
+			}
+
+			level = append(level, hashBlock...)
+			nextLevel = append(nextLevel, saltedHash(hasher, params.Salt, hashBlock))
+		}
+
+		levels = append(levels, level)
+		digests = nextLevel
+	}
+
+	return levels, digests[0], nil
+}
+
+func createVeritySuperblock(params VerityParams) ([]byte, error) {
+	superblock := veritySuperblockData{
+		Version:       veritySuperblockVer,
+		HashType:      verityHashType,
+		DataBlockSize: params.DataBlockSize,
+		HashBlockSize: params.HashBlockSize,
+		DataBlocks:    params.DataBlocks,
+		SaltSize:      uint16(len(params.Salt)), //nolint:gosec // salt size is checked
+	}
+
+	copy(superblock.Signature[:], veritySignature)
+	copy(superblock.Algorithm[:], params.HashAlgorithm)
+	copy(superblock.Salt[:], params.Salt)
+
+	if _, err := rand.Read(superblock.UUID[:]); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	superblock.UUID[6] = superblock.UUID[6]&0x0f | 0x40
+	superblock.UUID[8] = superblock.UUID[8]&0x3f | 0x80
+
+	var buffer bytes.Buffer
+
+	if err := binary.Write(&buffer, binary.LittleEndian, superblock); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func newVerityHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "sha256":
+		return sha256.New(), nil
+
+	case "sha512":
+		return sha512.New(), nil
+
+	default:
+		return nil, aoserrors.Errorf("unsupported verity hash algorithm: %s", algorithm)
+	}
+}
+
+func saltedHash(hasher hash.Hash, salt, data []byte) []byte {
+	hasher.Reset()
+	hasher.Write(salt)
+	hasher.Write(data)
+
+	return hasher.Sum(nil)
+}
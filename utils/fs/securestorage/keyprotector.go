@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securestorage
+
+import (
+	"crypto"
+	"encoding/json"
+	"io"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/tpmkey"
+	"github.com/aosedge/aos_common/utils/cryptutils"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// KeyProtector protects volume key stored in volume header.
+type KeyProtector interface {
+	ProtectKey(key []byte) (protectedKey []byte, err error)
+	UnprotectKey(protectedKey []byte) (key []byte, err error)
+}
+
+// EnvelopeKeyProtector wraps volume key for asymmetric key. Private key may be loaded with
+// cryptutils.LoadPrivateKeyByURL from file, TPM or PKCS11 storage.
+type EnvelopeKeyProtector struct {
+	PrivateKey crypto.PrivateKey
+}
+
+// TPMKeyProtector seals volume key by TPM bound to PCR values.
+type TPMKeyProtector struct {
+	Device         io.ReadWriter
+	ParentHandle   tpmutil.Handle
+	ParentPassword string
+	PCRSelection   tpm2.PCRSelection
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ProtectKey wraps key for private key public part.
+func (protector *EnvelopeKeyProtector) ProtectKey(key []byte) (protectedKey []byte, err error) {
+	signer, ok := protector.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, aoserrors.New("private key doesn't provide public key")
+	}
+
+	recipient, err := cryptutils.WrapDEK(key, signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	if protectedKey, err = json.Marshal(recipient); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return protectedKey, nil
+}
+
+// UnprotectKey unwraps key with private key.
+func (protector *EnvelopeKeyProtector) UnprotectKey(protectedKey []byte) (key []byte, err error) {
+	var recipient cryptutils.EnvelopeRecipient
+
+	if err = json.Unmarshal(protectedKey, &recipient); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return cryptutils.UnwrapDEK(recipient, protector.PrivateKey)
+}
+
+// ProtectKey seals key by TPM.
+func (protector *TPMKeyProtector) ProtectKey(key []byte) (protectedKey []byte, err error) {
+	sealed, err := tpmkey.Seal(
+		protector.Device, protector.ParentHandle, protector.ParentPassword, protector.PCRSelection, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if protectedKey, err = json.Marshal(sealed); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return protectedKey, nil
+}
+
+// UnprotectKey unseals key by TPM.
+func (protector *TPMKeyProtector) UnprotectKey(protectedKey []byte) (key []byte, err error) {
+	var sealed tpmkey.SealedData
+
+	if err = json.Unmarshal(protectedKey, &sealed); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return tpmkey.Unseal(protector.Device, protector.ParentHandle, protector.ParentPassword, sealed)
+}
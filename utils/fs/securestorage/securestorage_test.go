@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securestorage_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/utils/fs/securestorage"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	testLUKS2HeaderSize = 16 * 1024
+	testLUKS2Metadata   = `{"keyslots":{"0":{"type":"luks2","key_size":%d}},` +
+		`"tokens":{"0":{"type":"%s","keyslots":["0"],"protectedKey":"%s"}},` +
+		`"segments":{"0":{"type":"crypt","offset":"16777216","size":"dynamic","iv_tweak":"0",` +
+		`"encryption":"%s","sector_size":512}},"digests":{},"config":{"json_size":"12288","keyslots_size":"0"}}`
+)
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+var tmpDir string
+
+/***********************************************************************************************************************
+ * Init
+ **********************************************************************************************************************/
+
+func init() {
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: false,
+		TimestampFormat:  "2006-01-02 15:04:05.000",
+		FullTimestamp:    true,
+	})
+	log.SetLevel(log.DebugLevel)
+	log.SetOutput(os.Stdout)
+}
+
+/***********************************************************************************************************************
+ * Main
+ **********************************************************************************************************************/
+
+func TestMain(m *testing.M) {
+	var err error
+
+	if tmpDir, err = os.MkdirTemp("", "aos_"); err != nil {
+		log.Fatalf("Error creating tmp dir: %s", err)
+	}
+
+	ret := m.Run()
+
+	if err = os.RemoveAll(tmpDir); err != nil {
+		log.Fatalf("Error removing tmp dir: %s", err)
+	}
+
+	os.Exit(ret)
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestVerity(t *testing.T) {
+	dataFile := filepath.Join(tmpDir, "verity.img")
+	hashFile := filepath.Join(tmpDir, "verity.hash")
+
+	data := make([]byte, 300*securestorage.DefaultVerityBlockSize)
+
+	if _, err := rand.Read(data); err != nil {
+		t.Fatalf("Can't generate data: %v", err)
+	}
+
+	if err := os.WriteFile(dataFile, data, 0o600); err != nil {
+		t.Fatalf("Can't write data file: %v", err)
+	}
+
+	params, err := securestorage.FormatVerity(dataFile, hashFile, securestorage.VerityParams{})
+	if err != nil {
+		t.Fatalf("Can't format verity: %v", err)
+	}
+
+	if params.DataBlocks != 300 || len(params.RootHash) != 32 || len(params.Salt) == 0 {
+		t.Errorf("Wrong verity params: %+v", params)
+	}
+
+	storedParams, err := securestorage.ReadVerityParams(hashFile, 0)
+	if err != nil {
+		t.Fatalf("Can't read verity params: %v", err)
+	}
+
+	storedParams.RootHash = params.RootHash
+
+	if err = securestorage.VerifyVerity(dataFile, hashFile, storedParams); err != nil {
+		t.Errorf("Verify verity error: %v", err)
+	}
+
+	data[len(data)-1] ^= 0xff
+
+	if err := os.WriteFile(dataFile, data, 0o600); err != nil {
+		t.Fatalf("Can't write data file: %v", err)
+	}
+
+	if err = securestorage.VerifyVerity(dataFile, hashFile, params); err == nil {
+		t.Error("Verify verity should fail on modified data")
+	}
+}
+
+func TestCryptVolume(t *testing.T) {
+	if _, err := exec.LookPath("cryptsetup"); err != nil {
+		t.Skip("cryptsetup is not available")
+	}
+
+	device := filepath.Join(tmpDir, "crypt.img")
+
+	if err := os.WriteFile(device, nil, 0o600); err != nil {
+		t.Fatalf("Can't create device file: %v", err)
+	}
+
+	if err := os.Truncate(device, 32*1024*1024); err != nil {
+		t.Fatalf("Can't resize device file: %v", err)
+	}
+
+	if formatted, err := securestorage.IsCryptFormatted(device); err != nil || formatted {
+		t.Errorf("Device should not be formatted: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate key: %v", err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Can't generate key: %v", err)
+	}
+
+	ecProtector := &securestorage.EnvelopeKeyProtector{PrivateKey: ecKey}
+	rsaProtector := &securestorage.EnvelopeKeyProtector{PrivateKey: rsaKey}
+
+	if err = securestorage.FormatCrypt(device, ecProtector, securestorage.CryptConfig{}); err != nil {
+		t.Fatalf("Can't format crypt volume: %v", err)
+	}
+
+	if formatted, err := securestorage.IsCryptFormatted(device); err != nil || !formatted {
+		t.Errorf("Device should be formatted: %v", err)
+	}
+
+	header, err := securestorage.ReadCryptHeader(device)
+	if err != nil {
+		t.Fatalf("Can't read crypt header: %v", err)
+	}
+
+	if header.Cipher != securestorage.DefaultCryptCipher || header.KeySize != securestorage.DefaultCryptKeySize ||
+		header.PayloadOffset == 0 {
+		t.Errorf("Wrong crypt header: %+v", header)
+	}
+
+	if output, err := exec.Command("cryptsetup", "luksDump", device).CombinedOutput(); err != nil {
+		t.Errorf("cryptsetup can't read volume header: %v: %s", err, output)
+	}
+
+	if err = securestorage.ChangeCryptKeyProtector(device, rsaProtector, ecProtector); err == nil {
+		t.Error("Change key protector with wrong key should fail")
+	}
+
+	if err = securestorage.ChangeCryptKeyProtector(device, ecProtector, rsaProtector); err != nil {
+		t.Fatalf("Can't change key protector: %v", err)
+	}
+
+	if _, err = securestorage.OpenCrypt(device, "aos-test", ecProtector); err == nil {
+		t.Error("Open crypt volume with old key should fail")
+	}
+
+	if err = securestorage.ChangeCryptKeyProtector(device, rsaProtector, ecProtector); err != nil {
+		t.Errorf("Can't change key protector: %v", err)
+	}
+
+	if err = securestorage.FormatCrypt(
+		device, ecProtector, securestorage.CryptConfig{Cipher: "cipher_null-ecb"}); err == nil {
+		t.Error("Format crypt volume with unsupported cipher should fail")
+	}
+}
+
+func TestReadCryptHeader(t *testing.T) {
+	device := filepath.Join(tmpDir, "crypt-header.img")
+	protectedKey := []byte("protected key")
+
+	validMetadata := fmt.Sprintf(testLUKS2Metadata, securestorage.DefaultCryptKeySize, "aos-protected-key",
+		base64.StdEncoding.EncodeToString(protectedKey), securestorage.DefaultCryptCipher)
+
+	type testData struct {
+		name     string
+		version  uint16
+		metadata string
+		tamper   bool
+		err      bool
+	}
+
+	data := []testData{
+		{name: "valid", version: 2, metadata: validMetadata},
+		{name: "luks1", version: 1, metadata: validMetadata, err: true},
+		{name: "tampered", version: 2, metadata: validMetadata, tamper: true, err: true},
+		{
+			name: "unsupported cipher", version: 2, err: true,
+			metadata: fmt.Sprintf(testLUKS2Metadata, 64, "aos-protected-key",
+				base64.StdEncoding.EncodeToString(protectedKey), "cipher_null-ecb"),
+		},
+		{
+			name: "unsupported key size", version: 2, err: true,
+			metadata: fmt.Sprintf(testLUKS2Metadata, 16, "aos-protected-key",
+				base64.StdEncoding.EncodeToString(protectedKey), securestorage.DefaultCryptCipher),
+		},
+		{
+			name: "no protected key", version: 2, err: true,
+			metadata: fmt.Sprintf(testLUKS2Metadata, 64, "luks2-keyring", "", securestorage.DefaultCryptCipher),
+		},
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Can't generate key: %v", err)
+	}
+
+	protector := &securestorage.EnvelopeKeyProtector{PrivateKey: key}
+
+	for _, item := range data {
+		t.Run(item.name, func(t *testing.T) {
+			if err := writeLUKS2Header(device, item.version, item.metadata, item.tamper); err != nil {
+				t.Fatalf("Can't write header: %v", err)
+			}
+
+			if formatted, err := securestorage.IsCryptFormatted(device); err != nil || !formatted {
+				t.Errorf("Device should be formatted: %v", err)
+			}
+
+			header, err := securestorage.ReadCryptHeader(device)
+			if item.err {
+				if err == nil {
+					t.Error("Read crypt header should fail")
+				}
+
+				if err = securestorage.ChangeCryptKeyProtector(device, protector, protector); err == nil {
+					t.Error("Change key protector should fail")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Can't read crypt header: %v", err)
+			}
+
+			if header.Version != 2 || header.UUID != "test-uuid" ||
+				header.Cipher != securestorage.DefaultCryptCipher ||
+				header.KeySize != securestorage.DefaultCryptKeySize || header.PayloadOffset != 32768 ||
+				string(header.ProtectedKey) != string(protectedKey) {
+				t.Errorf("Wrong crypt header: %+v", header)
+			}
+		})
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func writeLUKS2Header(device string, version uint16, metadata string, tamper bool) error {
+	data := make([]byte, testLUKS2HeaderSize)
+
+	copy(data, "LUKS\xba\xbe")
+	binary.BigEndian.PutUint16(data[6:], version)
+	binary.BigEndian.PutUint64(data[8:], testLUKS2HeaderSize)
+	copy(data[72:], "sha256")
+	copy(data[168:], "test-uuid")
+	copy(data[4096:], metadata)
+
+	checksum := sha256.Sum256(data)
+
+	copy(data[448:], checksum[:])
+
+	if tamper {
+		data[4096+len(metadata)-2] ^= 0x01
+	}
+
+	return os.WriteFile(device, data, 0o600)
+}
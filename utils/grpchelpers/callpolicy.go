@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/aostypes"
+	"github.com/aosedge/aos_common/utils/retryhelper"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	defaultRetryBackoff = 100 * time.Millisecond
+	defaultHedgingDelay = 100 * time.Millisecond
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// RetryPolicy unary call retry policy. Failed call is retried with exponential backoff if its status code is
+// retryable. Errors marked with aoserrors.MarkPermanent are not retried.
+type RetryPolicy struct {
+	// MaxAttempts max number of call attempts including the first one.
+	MaxAttempts int `json:"maxAttempts"`
+	// InitialBackoff delay before the first retry, default is 100ms. Delay is doubled on each retry.
+	InitialBackoff aostypes.Duration `json:"initialBackoff"`
+	// MaxBackoff max delay between retries, not limited if not set.
+	MaxBackoff aostypes.Duration `json:"maxBackoff"`
+	// RetryableCodes status codes to retry the call on, default is Unavailable.
+	RetryableCodes []codes.Code `json:"retryableCodes"`
+}
+
+// HedgingPolicy unary call hedging policy. Additional call attempts are sent each hedging delay without waiting for
+// previous attempts to finish. The first successful response is used and other attempts are cancelled. Hedging should
+// be used for idempotent methods only.
+type HedgingPolicy struct {
+	// MaxAttempts max number of call attempts including the first one.
+	MaxAttempts int `json:"maxAttempts"`
+	// HedgingDelay delay between sending attempts, default is 100ms.
+	HedgingDelay aostypes.Duration `json:"hedgingDelay"`
+	// NonFatalCodes status codes which cause next attempt to be sent immediately instead of failing the call,
+	// default is Unavailable.
+	NonFatalCodes []codes.Code `json:"nonFatalCodes"`
+}
+
+// CallPolicy unary call policy. If both retry and hedging are set, each retry attempt is hedged.
+type CallPolicy struct {
+	Retry   *RetryPolicy   `json:"retry,omitempty"`
+	Hedging *HedgingPolicy `json:"hedging,omitempty"`
+}
+
+// CallPolicyConfig unary calls policy configuration.
+type CallPolicyConfig struct {
+	// Default policy of methods without method specific policy.
+	Default CallPolicy `json:"default"`
+	// Methods method specific policies. Key is full method name: /package.Service/Method.
+	Methods map[string]CallPolicy `json:"methods"`
+}
+
+type hedgingResult struct {
+	reply proto.Message
+	err   error
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// WithDefaultCallPolicy sets client call policy for all unary RPC calls without method specific policy.
+func WithDefaultCallPolicy(policy CallPolicy) InterceptorOption {
+	return func(config *interceptorConfig) {
+		config.defaultCallPolicy = policy
+	}
+}
+
+// WithMethodCallPolicy sets client call policy for unary RPC method. Method is full method name:
+// /package.Service/Method.
+func WithMethodCallPolicy(method string, policy CallPolicy) InterceptorOption {
+	return func(config *interceptorConfig) {
+		config.methodCallPolicies[method] = policy
+	}
+}
+
+// WithCallPolicyConfig sets client call policies from configuration.
+func WithCallPolicyConfig(policyConfig CallPolicyConfig) InterceptorOption {
+	return func(config *interceptorConfig) {
+		config.defaultCallPolicy = policyConfig.Default
+
+		for method, policy := range policyConfig.Methods {
+			config.methodCallPolicies[method] = policy
+		}
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (config *interceptorConfig) methodCallPolicy(method string) CallPolicy {
+	if policy, ok := config.methodCallPolicies[method]; ok {
+		return policy
+	}
+
+	return config.defaultCallPolicy
+}
+
+func (config *interceptorConfig) invoke(
+	ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	policy := config.methodCallPolicy(method)
+
+	invokeFunc := func() error {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	if policy.Hedging != nil {
+		invokeFunc = func() error {
+			return invokeHedged(ctx, *policy.Hedging, method, req, reply, cc, invoker, opts...)
+		}
+	}
+
+	if policy.Retry == nil || policy.Retry.MaxAttempts <= 1 {
+		return invokeFunc()
+	}
+
+	return invokeWithRetry(ctx, *policy.Retry, method, invokeFunc)
+}
+
+func invokeWithRetry(ctx context.Context, policy RetryPolicy, method string, invokeFunc func() error) error {
+	var callErr error
+
+	retryableCodes := policy.RetryableCodes
+	if len(retryableCodes) == 0 {
+		retryableCodes = []codes.Code{codes.Unavailable}
+	}
+
+	initialBackoff := policy.InitialBackoff.Duration
+	if initialBackoff == 0 {
+		initialBackoff = defaultRetryBackoff
+	}
+
+	if err := retryhelper.RetryIf(ctx,
+		func() error {
+			callErr = invokeFunc()
+
+			return callErr
+		},
+		func(retryCount int, delay time.Duration, err error) {
+			log.WithFields(log.Fields{
+				"method": method, "correlationID": CorrelationIDFromContext(ctx), "retry": retryCount, "delay": delay,
+			}).Debugf("Retry RPC call: %v", err)
+		},
+		func(err error) bool {
+			return slices.Contains(retryableCodes, status.Code(err)) && !aoserrors.IsPermanent(err)
+		}, policy.MaxAttempts, initialBackoff, policy.MaxBackoff.Duration); err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err() //nolint:wrapcheck // return status error as is
+		}
+
+		return callErr
+	}
+
+	return nil
+}
+
+func invokeHedged(
+	ctx context.Context, policy HedgingPolicy, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	replyMessage, ok := reply.(proto.Message)
+	if !ok || policy.MaxAttempts <= 1 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	nonFatalCodes := policy.NonFatalCodes
+	if len(nonFatalCodes) == 0 {
+		nonFatalCodes = []codes.Code{codes.Unavailable}
+	}
+
+	hedgingDelay := policy.HedgingDelay.Duration
+	if hedgingDelay == 0 {
+		hedgingDelay = defaultHedgingDelay
+	}
+
+	ctx, cancelFunction := context.WithCancel(ctx)
+	defer cancelFunction()
+
+	results := make(chan hedgingResult, policy.MaxAttempts)
+	hedgingTimer := time.NewTimer(hedgingDelay)
+
+	defer hedgingTimer.Stop()
+
+	started, running := 0, 0
+
+	startAttempt := func() {
+		attemptReply := replyMessage.ProtoReflect().New().Interface()
+
+		started++
+		running++
+
+		go func() {
+			results <- hedgingResult{reply: attemptReply, err: invoker(ctx, method, req, attemptReply, cc, opts...)}
+		}()
+
+		if !hedgingTimer.Stop() {
+			select {
+			case <-hedgingTimer.C:
+			default:
+			}
+		}
+
+		hedgingTimer.Reset(hedgingDelay)
+	}
+
+	startAttempt()
+
+	var lastErr error
+
+	for running > 0 {
+		select {
+		case <-hedgingTimer.C:
+			if started < policy.MaxAttempts {
+				log.WithFields(log.Fields{
+					"method": method, "correlationID": CorrelationIDFromContext(ctx), "attempt": started + 1,
+				}).Debug("Send hedged RPC call")
+
+				startAttempt()
+			}
+
+		case result := <-results:
+			running--
+
+			if result.err == nil {
+				proto.Reset(replyMessage)
+				proto.Merge(replyMessage, result.reply)
+
+				return nil
+			}
+
+			if !slices.Contains(nonFatalCodes, status.Code(result.err)) {
+				return result.err
+			}
+
+			lastErr = result.err
+
+			if started < policy.MaxAttempts {
+				startAttempt()
+			}
+		}
+	}
+
+	return lastErr
+}
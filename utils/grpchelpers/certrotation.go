@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/cryptutils"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// ServerCertificate provides server TLS certificate which can be reloaded without server restart. Reloaded
+// certificate is used for new connections, established connections and streams are kept.
+type ServerCertificate struct {
+	sync.RWMutex
+
+	cryptocontext *cryptutils.CryptoContext
+	certProvider  CertProvider
+	certType      string
+	certificate   *tls.Certificate
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewServerCertificate creates server certificate and loads current certificate of specified type.
+func NewServerCertificate(
+	cryptocontext *cryptutils.CryptoContext, certProvider CertProvider, certType string,
+) (*ServerCertificate, error) {
+	serverCert := &ServerCertificate{
+		cryptocontext: cryptocontext,
+		certProvider:  certProvider,
+		certType:      certType,
+	}
+
+	if err := serverCert.Reload(); err != nil {
+		return nil, err
+	}
+
+	return serverCert, nil
+}
+
+// NewRotatingServerOptions creates protected server options which take certificate from server certificate on each
+// TLS handshake. Unlike NewProtectedServerOptions, renewed certificate is applied without server restart.
+func NewRotatingServerOptions(
+	cryptocontext *cryptutils.CryptoContext, serverCert *ServerCertificate, insecureConn bool,
+	tlsOptions ...TLSOption,
+) ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+
+	if !insecureConn {
+		tlsConfig, err := cryptocontext.GetClientTLSConfig()
+		if err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+
+		tlsConfig.GetCertificate = serverCert.GetCertificate
+
+		for _, option := range tlsOptions {
+			option(tlsConfig)
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	return opts, nil
+}
+
+// Reload requests current certificate from certificate provider and loads it.
+func (serverCert *ServerCertificate) Reload() error {
+	certURL, keyURL, err := serverCert.certProvider.GetCertificate(serverCert.certType, nil, "")
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	certificate, err := serverCert.cryptocontext.GetTLSCertificate(certURL, keyURL)
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	serverCert.Lock()
+	defer serverCert.Unlock()
+
+	serverCert.certificate = &certificate
+
+	log.WithFields(log.Fields{
+		"certType": serverCert.certType, "serial": certificate.Leaf.SerialNumber.Text(16),
+	}).Debug("Server certificate loaded")
+
+	return nil
+}
+
+// GetCertificate returns current certificate. It is used as tls.Config GetCertificate callback.
+func (serverCert *ServerCertificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	serverCert.RLock()
+	defer serverCert.RUnlock()
+
+	return serverCert.certificate, nil
+}
+
+// HandleCertEvents reloads certificate on renewed events of the server certificate type. It blocks until context is
+// canceled or events channel is closed.
+func (serverCert *ServerCertificate) HandleCertEvents(ctx context.Context, events <-chan cryptutils.CertEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if event.EventType != cryptutils.CertEventRenewed || event.Cert.Type != serverCert.certType {
+				continue
+			}
+
+			if err := serverCert.Reload(); err != nil {
+				log.WithField("certType", serverCert.certType).Errorf("Can't reload server certificate: %v", err)
+			}
+		}
+	}
+}
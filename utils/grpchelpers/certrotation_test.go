@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/aosedge/aos_common/api/iamanager"
+	"github.com/aosedge/aos_common/utils/cryptutils"
+	"github.com/aosedge/aos_common/utils/grpchelpers"
+	"github.com/aosedge/aos_common/utils/testtools"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testCertProvider struct {
+	sync.Mutex
+
+	certURL string
+	keyURL  string
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestServerCertificateRotation(t *testing.T) {
+	pki, err := testtools.NewPKIBuilder().WithLeaf("server1").WithLeaf("server2").Build()
+	if err != nil {
+		t.Fatalf("Can't build PKI: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	certProvider := &testCertProvider{}
+
+	if certProvider.certURL, certProvider.keyURL, err = writeLeaf(tmpDir, pki.Leaves["server1"]); err != nil {
+		t.Fatalf("Can't write certificate: %v", err)
+	}
+
+	cryptoContext, err := cryptutils.NewCryptoContext("")
+	if err != nil {
+		t.Fatalf("Can't create crypto context: %v", err)
+	}
+	defer cryptoContext.Close()
+
+	serverCert, err := grpchelpers.NewServerCertificate(cryptoContext, certProvider, "online")
+	if err != nil {
+		t.Fatalf("Can't create server certificate: %v", err)
+	}
+
+	serverOptions, err := grpchelpers.NewRotatingServerOptions(cryptoContext, serverCert, false)
+	if err != nil {
+		t.Fatalf("Can't create server options: %v", err)
+	}
+
+	testServer := grpchelpers.NewGRPCServer(testURL)
+	defer testServer.StopServer()
+
+	testServer.RegisterService(&pb.IAMPublicService_ServiceDesc, testIAMServer{})
+
+	if err = testServer.RestartServer(serverOptions); err != nil {
+		t.Fatalf("Server not started: err=%v", err)
+	}
+
+	if commonName := getServerCommonName(t); commonName != "server1" {
+		t.Errorf("Wrong server certificate: %s", commonName)
+	}
+
+	// Renew certificate and notify about it
+
+	newCertURL, newKeyURL, err := writeLeaf(tmpDir, pki.Leaves["server2"])
+	if err != nil {
+		t.Fatalf("Can't write certificate: %v", err)
+	}
+
+	certProvider.set(newCertURL, newKeyURL)
+
+	ctx, cancelFunction := context.WithCancel(context.Background())
+	events := make(chan cryptutils.CertEvent, 2)
+	done := make(chan struct{})
+
+	go func() {
+		serverCert.HandleCertEvents(ctx, events)
+		close(done)
+	}()
+
+	events <- cryptutils.CertEvent{EventType: cryptutils.CertEventRenewed, Cert: cryptutils.WatchedCert{Type: "offline"}}
+	events <- cryptutils.CertEvent{EventType: cryptutils.CertEventRenewed, Cert: cryptutils.WatchedCert{Type: "online"}}
+
+	for range 50 {
+		if getServerCommonName(t) == "server2" {
+			break
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if commonName := getServerCommonName(t); commonName != "server2" {
+		t.Errorf("Server certificate is not rotated: %s", commonName)
+	}
+
+	cancelFunction()
+	<-done
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (provider *testCertProvider) GetCertificate(
+	certType string, issuer []byte, serial string,
+) (certURL, keyURL string, err error) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	return provider.certURL, provider.keyURL, nil
+}
+
+func (provider *testCertProvider) set(certURL, keyURL string) {
+	provider.Lock()
+	defer provider.Unlock()
+
+	provider.certURL, provider.keyURL = certURL, keyURL
+}
+
+func writeLeaf(dir string, leaf testtools.PKICert) (certURL, keyURL string, err error) {
+	certFile := filepath.Join(dir, leaf.Cert.Subject.CommonName+".pem")
+	keyFile := filepath.Join(dir, leaf.Cert.Subject.CommonName+".key")
+
+	if err = os.WriteFile(certFile, cryptutils.CertToPEM(leaf.Cert), 0o600); err != nil {
+		return "", "", err
+	}
+
+	ecKey, ok := leaf.Key.(*ecdsa.PrivateKey)
+	if !ok {
+		return "", "", os.ErrInvalid
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(
+		&pem.Block{Type: cryptutils.PEMBlockECPrivateKey, Bytes: keyDER}), 0o600); err != nil {
+		return "", "", err
+	}
+
+	return "file://" + certFile, "file://" + keyFile, nil
+}
+
+func getServerCommonName(t *testing.T) string {
+	t.Helper()
+
+	conn, err := tls.Dial("tcp", "localhost"+testURL, &tls.Config{
+		InsecureSkipVerify: true, //nolint:gosec // test checks server certificate only
+		NextProtos:         []string{"h2"},
+		MinVersion:         tls.VersionTLS12,
+	})
+	if err != nil {
+		t.Fatalf("Can't connect to server: %v", err)
+	}
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
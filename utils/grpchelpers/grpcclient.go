@@ -18,6 +18,8 @@
 package grpchelpers
 
 import (
+	"crypto/tls"
+
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
@@ -35,6 +37,9 @@ type CertProvider interface {
 	GetCertificate(certType string, issuer []byte, serial string) (certURL, keyURL string, err error)
 }
 
+// TLSOption modifies TLS configuration of protected connection.
+type TLSOption func(tlsConfig *tls.Config)
+
 /***********************************************************************************************************************
  * Public
  **********************************************************************************************************************/
@@ -63,10 +68,24 @@ func CreatePublicConnection(serverURL string, cryptocontext *cryptutils.CryptoCo
 	return connection, nil
 }
 
+// WithSPIFFEIDs authorizes peers by SPIFFE ID patterns.
+func WithSPIFFEIDs(patterns ...string) TLSOption {
+	return func(tlsConfig *tls.Config) {
+		tlsConfig.VerifyConnection = cryptutils.VerifySPIFFEPeer(patterns...)
+	}
+}
+
+// WithPinnedPeer rejects peers which certificate chain doesn't match pin set.
+func WithPinnedPeer(pinSet cryptutils.PinSet) TLSOption {
+	return func(tlsConfig *tls.Config) {
+		tlsConfig.VerifyPeerCertificate = cryptutils.VerifyPinnedPeer(pinSet)
+	}
+}
+
 // CreateProtectedConnection creates protected GRPC connection.
 func CreateProtectedConnection(
 	certType string, protectedURL string, cryptocontext *cryptutils.CryptoContext,
-	certProvider CertProvider, insecureConn bool,
+	certProvider CertProvider, insecureConn bool, tlsOptions ...TLSOption,
 ) (connection *grpc.ClientConn, err error) {
 	var secureOpt grpc.DialOption
 
@@ -83,6 +102,10 @@ func CreateProtectedConnection(
 			return nil, aoserrors.Wrap(err)
 		}
 
+		for _, option := range tlsOptions {
+			option(tlsConfig)
+		}
+
 		secureOpt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
 	}
 
@@ -97,7 +97,7 @@ func (conn *GRPCConn) Close() {
 		conn.connStarted.Done()
 	}
 
-	if conn.grpcConn == nil {
+	if conn.grpcConn != nil {
 		conn.grpcConn.Close()
 		conn.grpcConn = nil
 	}
@@ -111,8 +111,8 @@ func (conn *GRPCConn) Close() {
 func (conn *GRPCConn) Invoke(ctx context.Context, method string, args any, reply any,
 	opts ...grpc.CallOption,
 ) error {
-	lock := make(chan struct{})
-	defer close(lock)
+	// Buffered to not block the waiting goroutine if context is done before connection is started
+	lock := make(chan struct{}, 1)
 
 	go func() {
 		conn.connStarted.Wait()
@@ -19,6 +19,7 @@ package grpchelpers
 
 import (
 	"net"
+	"strings"
 	"sync"
 
 	log "github.com/sirupsen/logrus"
@@ -45,8 +46,9 @@ type GRPCServer struct {
 
 	serverAddress string
 
-	grpcServer *grpc.Server
-	services   []RegisteredService
+	grpcServer         *grpc.Server
+	services           []RegisteredService
+	interceptorOptions []InterceptorOption
 
 	stopWG sync.WaitGroup
 }
@@ -55,17 +57,19 @@ type GRPCServer struct {
  * Public
  **********************************************************************************************************************/
 
-// NewGRPCServer creates a new instance of GRPCServer.
-func NewGRPCServer(address string) *GRPCServer {
+// NewGRPCServer creates a new instance of GRPCServer. If interceptor options are provided, standard logging,
+// recovery, timeout and request size interceptors are installed. Address with unix:// scheme serves on unix socket.
+func NewGRPCServer(address string, interceptorOptions ...InterceptorOption) *GRPCServer {
 	return &GRPCServer{
-		serverAddress: address,
-		services:      make([]RegisteredService, 0),
+		serverAddress:      address,
+		services:           make([]RegisteredService, 0),
+		interceptorOptions: interceptorOptions,
 	}
 }
 
 // NewProtectedServerOptions creates protected server options.
 func NewProtectedServerOptions(cryptocontext *cryptutils.CryptoContext, certProvider CertProvider,
-	certType string, insecureConn bool,
+	certType string, insecureConn bool, tlsOptions ...TLSOption,
 ) ([]grpc.ServerOption, error) {
 	var opts []grpc.ServerOption
 
@@ -80,6 +84,10 @@ func NewProtectedServerOptions(cryptocontext *cryptutils.CryptoContext, certProv
 			return nil, aoserrors.Wrap(err)
 		}
 
+		for _, option := range tlsOptions {
+			option(tlsConfig)
+		}
+
 		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
 
@@ -131,13 +139,27 @@ func (server *GRPCServer) stopGRPCServer() {
 func (server *GRPCServer) startGRPCServer(options []grpc.ServerOption) error {
 	log.WithField("serverURL", server.serverAddress).Debug("Start GRPC server")
 
-	listener, err := net.Listen("tcp", server.serverAddress)
+	var (
+		listener net.Listener
+		err      error
+	)
+
+	if socketPath, ok := strings.CutPrefix(server.serverAddress, UnixSocketScheme); ok {
+		listener, err = listenUnixSocket(socketPath)
+	} else {
+		listener, err = net.Listen("tcp", server.serverAddress)
+	}
+
 	if err != nil {
 		log.Errorf("Failed to listen: %v", err)
 
 		return aoserrors.Wrap(err)
 	}
 
+	if len(server.interceptorOptions) > 0 {
+		options = append(NewInterceptorServerOptions(server.interceptorOptions...), options...)
+	}
+
 	server.grpcServer = grpc.NewServer(options...)
 
 	for _, service := range server.services {
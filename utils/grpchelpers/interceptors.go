@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/pbconvert"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// CorrelationIDKey metadata key used to pass correlation ID between services.
+const CorrelationIDKey = "x-correlation-id"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// InterceptorOption configures standard interceptors.
+type InterceptorOption func(config *interceptorConfig)
+
+type interceptorConfig struct {
+	logLevel       log.Level
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
+	maxRequestSize int
+
+	defaultCallPolicy  CallPolicy
+	methodCallPolicies map[string]CallPolicy
+}
+
+type correlationIDContextKey struct{}
+
+type sizeLimitedServerStream struct {
+	grpc.ServerStream
+
+	ctx            context.Context //nolint:containedctx // overrides stream context
+	method         string
+	maxRequestSize int
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// WithLogLevel sets level used to log RPC calls. Failed calls are always logged with error level.
+func WithLogLevel(level log.Level) InterceptorOption {
+	return func(config *interceptorConfig) {
+		config.logLevel = level
+	}
+}
+
+// WithDefaultTimeout sets timeout for all unary RPC calls without method specific timeout.
+func WithDefaultTimeout(timeout time.Duration) InterceptorOption {
+	return func(config *interceptorConfig) {
+		config.defaultTimeout = timeout
+	}
+}
+
+// WithMethodTimeout sets timeout for unary RPC method. Method is full method name: /package.Service/Method.
+func WithMethodTimeout(method string, timeout time.Duration) InterceptorOption {
+	return func(config *interceptorConfig) {
+		config.methodTimeouts[method] = timeout
+	}
+}
+
+// WithMaxRequestSize sets maximum size of request message in bytes.
+func WithMaxRequestSize(size int) InterceptorOption {
+	return func(config *interceptorConfig) {
+		config.maxRequestSize = size
+	}
+}
+
+// ContextWithCorrelationID returns context with correlation ID.
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, correlationID)
+}
+
+// CorrelationIDFromContext returns correlation ID from context.
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDContextKey{}).(string)
+
+	return correlationID
+}
+
+// NewUnaryServerInterceptor creates unary server interceptor which logs calls with correlation ID, recovers panics,
+// applies timeouts and limits request size.
+func NewUnaryServerInterceptor(options ...InterceptorOption) grpc.UnaryServerInterceptor {
+	config := newInterceptorConfig(options...)
+
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		ctx = incomingCorrelationContext(ctx)
+		start := time.Now()
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = panicToStatus(ctx, info.FullMethod, recovered)
+			}
+
+			config.logCall(ctx, info.FullMethod, start, err)
+		}()
+
+		if err = config.checkRequestSize(req); err != nil {
+			return nil, pbconvert.ErrorToStatus(err)
+		}
+
+		if timeout := config.methodTimeout(info.FullMethod); timeout > 0 {
+			var cancelFunction context.CancelFunc
+
+			ctx, cancelFunction = context.WithTimeout(ctx, timeout)
+			defer cancelFunction()
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// NewStreamServerInterceptor creates stream server interceptor which logs calls with correlation ID, recovers
+// panics and limits received messages size.
+func NewStreamServerInterceptor(options ...InterceptorOption) grpc.StreamServerInterceptor {
+	config := newInterceptorConfig(options...)
+
+	return func(
+		srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) (err error) {
+		ctx := incomingCorrelationContext(stream.Context())
+		start := time.Now()
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = panicToStatus(ctx, info.FullMethod, recovered)
+			}
+
+			config.logCall(ctx, info.FullMethod, start, err)
+		}()
+
+		return handler(srv, &sizeLimitedServerStream{
+			ServerStream: stream, ctx: ctx, method: info.FullMethod, maxRequestSize: config.maxRequestSize,
+		})
+	}
+}
+
+// NewUnaryClientInterceptor creates unary client interceptor which propagates correlation ID, logs calls, applies
+// timeouts and retry/hedging call policies.
+func NewUnaryClientInterceptor(options ...InterceptorOption) grpc.UnaryClientInterceptor {
+	config := newInterceptorConfig(options...)
+
+	return func(
+		ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) (err error) {
+		ctx = outgoingCorrelationContext(ctx)
+		start := time.Now()
+
+		defer func() {
+			config.logCall(ctx, method, start, err)
+		}()
+
+		if timeout := config.methodTimeout(method); timeout > 0 {
+			var cancelFunction context.CancelFunc
+
+			ctx, cancelFunction = context.WithTimeout(ctx, timeout)
+			defer cancelFunction()
+		}
+
+		return config.invoke(ctx, method, req, reply, cc, invoker, opts...)
+	}
+}
+
+// NewStreamClientInterceptor creates stream client interceptor which propagates correlation ID and logs stream
+// creation.
+func NewStreamClientInterceptor(options ...InterceptorOption) grpc.StreamClientInterceptor {
+	config := newInterceptorConfig(options...)
+
+	return func(
+		ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (stream grpc.ClientStream, err error) {
+		ctx = outgoingCorrelationContext(ctx)
+		start := time.Now()
+
+		defer func() {
+			config.logCall(ctx, method, start, err)
+		}()
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// NewInterceptorServerOptions returns server options which install standard unary and stream interceptors.
+func NewInterceptorServerOptions(options ...InterceptorOption) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(NewUnaryServerInterceptor(options...)),
+		grpc.ChainStreamInterceptor(NewStreamServerInterceptor(options...)),
+	}
+}
+
+// NewInterceptorDialOptions returns dial options which install standard unary and stream client interceptors.
+func NewInterceptorDialOptions(options ...InterceptorOption) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(NewUnaryClientInterceptor(options...)),
+		grpc.WithChainStreamInterceptor(NewStreamClientInterceptor(options...)),
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newInterceptorConfig(options ...InterceptorOption) *interceptorConfig {
+	config := &interceptorConfig{
+		logLevel: log.DebugLevel, methodTimeouts: make(map[string]time.Duration),
+		methodCallPolicies: make(map[string]CallPolicy),
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+
+	return config
+}
+
+func (config *interceptorConfig) methodTimeout(method string) time.Duration {
+	if timeout, ok := config.methodTimeouts[method]; ok {
+		return timeout
+	}
+
+	return config.defaultTimeout
+}
+
+func (config *interceptorConfig) checkRequestSize(req interface{}) error {
+	if config.maxRequestSize <= 0 {
+		return nil
+	}
+
+	message, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	if size := proto.Size(message); size > config.maxRequestSize {
+		return aoserrors.Errorf("%w: request size %d exceeds limit %d", aoserrors.ErrNoMemory, size,
+			config.maxRequestSize)
+	}
+
+	return nil
+}
+
+func (config *interceptorConfig) logCall(ctx context.Context, method string, start time.Time, err error) {
+	entry := log.WithFields(log.Fields{
+		"method":        method,
+		"correlationID": CorrelationIDFromContext(ctx),
+		"duration":      time.Since(start),
+	})
+
+	if err != nil {
+		entry.WithField("code", status.Code(err)).Errorf("RPC call failed: %v", err)
+
+		return
+	}
+
+	entry.Log(config.logLevel, "RPC call")
+}
+
+func (stream *sizeLimitedServerStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *sizeLimitedServerStream) RecvMsg(message interface{}) error {
+	if err := stream.ServerStream.RecvMsg(message); err != nil {
+		return err //nolint:wrapcheck // keep stream errors (io.EOF) as is
+	}
+
+	config := interceptorConfig{maxRequestSize: stream.maxRequestSize}
+
+	if err := config.checkRequestSize(message); err != nil {
+		return pbconvert.ErrorToStatus(err)
+	}
+
+	return nil
+}
+
+func incomingCorrelationContext(ctx context.Context) context.Context {
+	if values := metadata.ValueFromIncomingContext(ctx, CorrelationIDKey); len(values) > 0 && values[0] != "" {
+		return ContextWithCorrelationID(ctx, values[0])
+	}
+
+	return ContextWithCorrelationID(ctx, uuid.New().String())
+}
+
+func outgoingCorrelationContext(ctx context.Context) context.Context {
+	correlationID := CorrelationIDFromContext(ctx)
+	if correlationID == "" {
+		correlationID = uuid.New().String()
+		ctx = ContextWithCorrelationID(ctx, correlationID)
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, CorrelationIDKey, correlationID)
+}
+
+func panicToStatus(ctx context.Context, method string, recovered interface{}) error {
+	log.WithFields(log.Fields{
+		"method": method, "correlationID": CorrelationIDFromContext(ctx),
+	}).Errorf("RPC call panic: %v\n%s", recovered, debug.Stack())
+
+	return pbconvert.ErrorToStatus(aoserrors.Errorf("%w: panic in %s: %v", aoserrors.ErrRuntime, method, recovered))
+}
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/aostypes"
+	pb "github.com/aosedge/aos_common/api/iamanager"
+	"github.com/aosedge/aos_common/utils/grpchelpers"
+	"github.com/aosedge/aos_common/utils/pbconvert"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testInterceptorServer struct {
+	pb.UnimplementedIAMPublicServiceServer
+}
+
+type testCallPolicyServer struct {
+	pb.UnimplementedIAMPublicServiceServer
+	calls atomic.Int32
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestInterceptors(t *testing.T) {
+	const getCertMethod = "/iamanager.v5.IAMPublicService/GetCert"
+
+	testServer := grpchelpers.NewGRPCServer(testURL,
+		grpchelpers.WithMethodTimeout(getCertMethod, 100*time.Millisecond),
+		grpchelpers.WithMaxRequestSize(64))
+	defer testServer.StopServer()
+
+	testServer.RegisterService(&pb.IAMPublicService_ServiceDesc, testInterceptorServer{})
+
+	if err := testServer.RestartServer(nil); err != nil {
+		t.Fatalf("Server not started: err=%v", err)
+	}
+
+	connection, err := grpc.NewClient(testURL, append(grpchelpers.NewInterceptorDialOptions(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	if err != nil {
+		t.Fatalf("Can't create connection: %v", err)
+	}
+	defer connection.Close()
+
+	client := pb.NewIAMPublicServiceClient(connection)
+
+	ctx, cancelFunction := context.WithTimeout(
+		grpchelpers.ContextWithCorrelationID(context.Background(), "correlation1"), 5*time.Second)
+	defer cancelFunction()
+
+	// Correlation ID is propagated to server
+
+	certInfo, err := client.GetCert(ctx, &pb.GetCertRequest{Type: "echo"})
+	if err != nil {
+		t.Fatalf("GetCert call should succeed: %v", err)
+	}
+
+	if certInfo.GetCertUrl() != "correlation1" {
+		t.Errorf("Wrong correlation ID: %s", certInfo.GetCertUrl())
+	}
+
+	// Panic is converted to runtime error
+
+	_, err = client.GetCert(ctx, &pb.GetCertRequest{Type: "panic"})
+	if status.Code(err) != codes.Internal || !errors.Is(pbconvert.ErrorFromStatus(err), aoserrors.ErrRuntime) {
+		t.Errorf("Wrong panic error: %v", err)
+	}
+
+	// Method timeout is applied
+
+	if certInfo, err = client.GetCert(ctx, &pb.GetCertRequest{Type: "timeout"}); err != nil {
+		t.Fatalf("GetCert call should succeed: %v", err)
+	}
+
+	if certInfo.GetCertUrl() != context.DeadlineExceeded.Error() {
+		t.Errorf("Method timeout is not applied: %s", certInfo.GetCertUrl())
+	}
+
+	// Request size is limited
+
+	_, err = client.GetCert(ctx, &pb.GetCertRequest{Type: strings.Repeat("x", 100)})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Wrong request size error: %v", err)
+	}
+}
+
+func TestCallPolicies(t *testing.T) {
+	const getCertMethod = "/iamanager.v5.IAMPublicService/GetCert"
+
+	testServer := grpchelpers.NewGRPCServer(testURL)
+	defer testServer.StopServer()
+
+	callPolicyServer := &testCallPolicyServer{}
+
+	testServer.RegisterService(&pb.IAMPublicService_ServiceDesc, callPolicyServer)
+
+	if err := testServer.RestartServer(nil); err != nil {
+		t.Fatalf("Server not started: err=%v", err)
+	}
+
+	retryPolicy := grpchelpers.RetryPolicy{
+		MaxAttempts: 3, InitialBackoff: aostypes.Duration{Duration: 10 * time.Millisecond},
+	}
+
+	retryOptions := []grpchelpers.InterceptorOption{
+		grpchelpers.WithDefaultCallPolicy(grpchelpers.CallPolicy{Retry: &retryPolicy}),
+	}
+
+	testData := []struct {
+		name          string
+		options       []grpchelpers.InterceptorOption
+		requestType   string
+		expectedCode  codes.Code
+		expectedCalls int32
+		maxDuration   time.Duration
+	}{
+		{
+			name:        "retry succeeds",
+			options:     retryOptions,
+			requestType: "unavailable", expectedCode: codes.OK, expectedCalls: 3,
+		},
+		{
+			name:        "not retryable code",
+			options:     retryOptions,
+			requestType: "notFound", expectedCode: codes.NotFound, expectedCalls: 1,
+		},
+		{
+			name: "retry attempts exceeded",
+			options: []grpchelpers.InterceptorOption{
+				grpchelpers.WithDefaultCallPolicy(grpchelpers.CallPolicy{Retry: &retryPolicy}),
+				grpchelpers.WithMethodCallPolicy(getCertMethod, grpchelpers.CallPolicy{
+					Retry: &grpchelpers.RetryPolicy{MaxAttempts: 2},
+				}),
+			},
+			requestType: "unavailable", expectedCode: codes.Unavailable, expectedCalls: 2,
+		},
+		{
+			name:        "no policy",
+			requestType: "unavailable", expectedCode: codes.Unavailable, expectedCalls: 1,
+		},
+		{
+			name: "hedging",
+			options: []grpchelpers.InterceptorOption{grpchelpers.WithCallPolicyConfig(grpchelpers.CallPolicyConfig{
+				Methods: map[string]grpchelpers.CallPolicy{
+					getCertMethod: {Hedging: &grpchelpers.HedgingPolicy{
+						MaxAttempts: 2, HedgingDelay: aostypes.Duration{Duration: 50 * time.Millisecond},
+					}},
+				},
+			})},
+			requestType: "slow", expectedCode: codes.OK, expectedCalls: 2, maxDuration: time.Second,
+		},
+	}
+
+	for _, item := range testData {
+		t.Run(item.name, func(t *testing.T) {
+			callPolicyServer.calls.Store(0)
+
+			connection, err := grpc.NewClient(testURL, append(grpchelpers.NewInterceptorDialOptions(item.options...),
+				grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+			if err != nil {
+				t.Fatalf("Can't create connection: %v", err)
+			}
+			defer connection.Close()
+
+			ctx, cancelFunction := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFunction()
+
+			start := time.Now()
+
+			certInfo, err := pb.NewIAMPublicServiceClient(connection).GetCert(
+				ctx, &pb.GetCertRequest{Type: item.requestType})
+			if status.Code(err) != item.expectedCode {
+				t.Fatalf("Wrong call error: %v", err)
+			}
+
+			if item.maxDuration != 0 && time.Since(start) > item.maxDuration {
+				t.Errorf("Call takes too long: %v", time.Since(start))
+			}
+
+			if err == nil && certInfo.GetCertUrl() != strconv.Itoa(int(item.expectedCalls)) {
+				t.Errorf("Wrong response: %s", certInfo.GetCertUrl())
+			}
+
+			if calls := callPolicyServer.calls.Load(); calls != item.expectedCalls {
+				t.Errorf("Wrong calls count: %d", calls)
+			}
+		})
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (testInterceptorServer) GetCert(ctx context.Context, req *pb.GetCertRequest) (*pb.CertInfo, error) {
+	switch req.GetType() {
+	case "panic":
+		panic("test panic")
+
+	case "timeout":
+		<-ctx.Done()
+
+		return &pb.CertInfo{CertUrl: ctx.Err().Error()}, nil
+
+	default:
+		return &pb.CertInfo{CertUrl: grpchelpers.CorrelationIDFromContext(ctx)}, nil
+	}
+}
+
+func (server *testCallPolicyServer) GetCert(ctx context.Context, req *pb.GetCertRequest) (*pb.CertInfo, error) {
+	call := server.calls.Add(1)
+
+	switch req.GetType() {
+	case "unavailable":
+		if call < 3 {
+			return nil, status.Error(codes.Unavailable, "service is restarting")
+		}
+
+	case "notFound":
+		return nil, status.Error(codes.NotFound, "not found")
+
+	case "slow":
+		if call == 1 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+
+			return nil, status.Error(codes.DeadlineExceeded, "slow call")
+		}
+	}
+
+	return &pb.CertInfo{CertUrl: strconv.Itoa(int(call))}, nil
+}
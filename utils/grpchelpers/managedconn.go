@@ -0,0 +1,363 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/cryptutils"
+	"github.com/aosedge/aos_common/utils/retryhelper"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Managed connection states.
+const (
+	ConnectionDisconnected ConnectionState = iota
+	ConnectionConnecting
+	ConnectionConnected
+)
+
+const (
+	defaultConnectTimeout = 10 * time.Second
+	defaultMinBackoff     = 1 * time.Second
+	defaultMaxBackoff     = 1 * time.Minute
+	stateChannelSize      = 16
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// ConnectionState managed connection state.
+type ConnectionState int
+
+// ConnectionFactory creates new grpc client connection. It is called on each reconnect, so transport credentials
+// are rebuilt with the current certificates.
+type ConnectionFactory func() (*grpc.ClientConn, error)
+
+// ManagedConnConfig managed connection configuration.
+type ManagedConnConfig struct {
+	// Factory creates new grpc connection.
+	Factory ConnectionFactory
+	// CertEvents optional certificate events channel. Connection is rebuilt on renewed certificate event.
+	CertEvents <-chan cryptutils.CertEvent
+	// CertType if set, only certificate events of this type cause reconnect.
+	CertType string
+	// ConnectTimeout time to wait for connection to become ready.
+	ConnectTimeout time.Duration
+	// MinBackoff initial reconnect delay.
+	MinBackoff time.Duration
+	// MaxBackoff maximum reconnect delay.
+	MaxBackoff time.Duration
+}
+
+// ManagedConn grpc connection which is automatically reconnected with backoff on failure and rebuilt on certificate
+// renewal. RPCs are blocked while connection is not established.
+type ManagedConn struct {
+	*GRPCConn
+
+	config         ManagedConnConfig
+	states         chan ConnectionState
+	reconnect      chan struct{}
+	cancelFunction context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewManagedConn creates managed connection and starts connecting in background.
+func NewManagedConn(config ManagedConnConfig) (*ManagedConn, error) {
+	if config.Factory == nil {
+		return nil, aoserrors.New("connection factory is not set")
+	}
+
+	if config.ConnectTimeout <= 0 {
+		config.ConnectTimeout = defaultConnectTimeout
+	}
+
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = defaultMinBackoff
+	}
+
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+
+	managed := &ManagedConn{
+		GRPCConn:  NewGRPCConn(),
+		config:    config,
+		states:    make(chan ConnectionState, stateChannelSize),
+		reconnect: make(chan struct{}, 1),
+	}
+
+	ctx, cancelFunction := context.WithCancel(context.Background())
+
+	managed.cancelFunction = cancelFunction
+
+	managed.wg.Add(1)
+
+	go managed.run(ctx)
+
+	return managed, nil
+}
+
+// ProtectedConnectionFactory returns factory which creates protected connection.
+func ProtectedConnectionFactory(
+	certType string, protectedURL string, cryptocontext *cryptutils.CryptoContext,
+	certProvider CertProvider, insecureConn bool, tlsOptions ...TLSOption,
+) ConnectionFactory {
+	return func() (*grpc.ClientConn, error) {
+		return CreateProtectedConnection(
+			certType, protectedURL, cryptocontext, certProvider, insecureConn, tlsOptions...)
+	}
+}
+
+// PublicConnectionFactory returns factory which creates public connection.
+func PublicConnectionFactory(
+	serverURL string, cryptocontext *cryptutils.CryptoContext, insecureConn bool,
+) ConnectionFactory {
+	return func() (*grpc.ClientConn, error) {
+		return CreatePublicConnection(serverURL, cryptocontext, insecureConn)
+	}
+}
+
+// StateChanges returns connection state changes channel.
+func (managed *ManagedConn) StateChanges() <-chan ConnectionState {
+	return managed.states
+}
+
+// Reconnect forces connection to be rebuilt.
+func (managed *ManagedConn) Reconnect() {
+	select {
+	case managed.reconnect <- struct{}{}:
+
+	default:
+	}
+}
+
+// Close closes managed connection.
+func (managed *ManagedConn) Close() {
+	managed.cancelFunction()
+	managed.wg.Wait()
+
+	managed.GRPCConn.Close()
+
+	close(managed.states)
+}
+
+// String returns connection state string representation.
+func (state ConnectionState) String() string {
+	switch state {
+	case ConnectionDisconnected:
+		return "disconnected"
+
+	case ConnectionConnecting:
+		return "connecting"
+
+	case ConnectionConnected:
+		return "connected"
+
+	default:
+		return "unknown"
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (managed *ManagedConn) run(ctx context.Context) {
+	defer managed.wg.Done()
+
+	certEvents := managed.config.CertEvents
+
+	for {
+		managed.setState(ConnectionConnecting)
+
+		connection := managed.connect(ctx)
+		if connection == nil {
+			return
+		}
+
+		managed.GRPCConn.Set(connection)
+		managed.GRPCConn.Start()
+
+		managed.setState(ConnectionConnected)
+
+		for {
+			refresh := managed.monitor(ctx, connection, &certEvents)
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !refresh {
+				break
+			}
+
+			log.Debug("Refresh grpc connection")
+
+			// Establish new connection before closing the current one to not block RPCs
+			newConnection := managed.connect(ctx)
+			if newConnection == nil {
+				return
+			}
+
+			managed.GRPCConn.Set(newConnection)
+			connection.Close()
+
+			connection = newConnection
+		}
+
+		log.Warn("Grpc connection lost")
+
+		managed.GRPCConn.Stop()
+		managed.setState(ConnectionDisconnected)
+	}
+}
+
+func (managed *ManagedConn) connect(ctx context.Context) (connection *grpc.ClientConn) {
+	if err := retryhelper.RetryIf(ctx,
+		func() (err error) {
+			connection, err = managed.createConnection(ctx)
+
+			return err
+		},
+		func(retryCount int, delay time.Duration, err error) {
+			log.WithFields(log.Fields{"retryCount": retryCount, "delay": delay}).Warnf(
+				"Can't establish grpc connection: %v", err)
+		},
+		nil, 0, managed.config.MinBackoff, managed.config.MaxBackoff); err != nil {
+		return nil
+	}
+
+	return connection
+}
+
+func (managed *ManagedConn) createConnection(ctx context.Context) (*grpc.ClientConn, error) {
+	connection, err := managed.config.Factory()
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	connectCtx, cancelFunction := context.WithTimeout(ctx, managed.config.ConnectTimeout)
+	defer cancelFunction()
+
+	connection.Connect()
+
+	for {
+		state := connection.GetState()
+
+		if state == connectivity.Ready {
+			return connection, nil
+		}
+
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			connection.Close()
+
+			return nil, aoserrors.Errorf("connection state: %s", state)
+		}
+
+		if !connection.WaitForStateChange(connectCtx, state) {
+			connection.Close()
+
+			return nil, aoserrors.Wrap(connectCtx.Err())
+		}
+	}
+}
+
+// monitor waits until connection is lost or should be refreshed. Returns true if connection should be refreshed.
+func (managed *ManagedConn) monitor(
+	ctx context.Context, connection *grpc.ClientConn, certEvents *<-chan cryptutils.CertEvent,
+) (refresh bool) {
+	monitorCtx, cancelFunction := context.WithCancel(ctx)
+	defer cancelFunction()
+
+	connectionLost := make(chan struct{})
+
+	go func() {
+		state := connectivity.Ready
+
+		for connection.WaitForStateChange(monitorCtx, state) {
+			state = connection.GetState()
+
+			switch state {
+			case connectivity.Idle:
+				connection.Connect()
+
+			case connectivity.TransientFailure, connectivity.Shutdown:
+				close(connectionLost)
+
+				return
+
+			default:
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+
+		case <-connectionLost:
+			return false
+
+		case <-managed.reconnect:
+			return true
+
+		case event, ok := <-*certEvents:
+			if !ok {
+				*certEvents = nil
+
+				continue
+			}
+
+			if event.EventType != cryptutils.CertEventRenewed ||
+				(managed.config.CertType != "" && event.Cert.Type != managed.config.CertType) {
+				continue
+			}
+
+			log.WithFields(log.Fields{"certType": event.Cert.Type, "serial": event.Serial}).Debug(
+				"Certificate renewed")
+
+			return true
+		}
+	}
+}
+
+func (managed *ManagedConn) setState(state ConnectionState) {
+	select {
+	case managed.states <- state:
+
+	default:
+		log.WithField("state", state).Warn("Connection state channel is full")
+	}
+}
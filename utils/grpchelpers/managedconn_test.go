@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/aosedge/aos_common/utils/cryptutils"
+	"github.com/aosedge/aos_common/utils/grpchelpers"
+)
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestManagedConnReconnect(t *testing.T) {
+	testServer := startTestServer(testURL)
+	defer func() { testServer.StopServer() }()
+
+	var factoryCalls atomic.Int32
+
+	certEvents := make(chan cryptutils.CertEvent, 1)
+
+	conn, err := grpchelpers.NewManagedConn(grpchelpers.ManagedConnConfig{
+		Factory: func() (*grpc.ClientConn, error) {
+			factoryCalls.Add(1)
+
+			return NewGRPCConn(testURL)
+		},
+		CertEvents: certEvents,
+		CertType:   "online",
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Can't create managed connection: %v", err)
+	}
+	defer conn.Close()
+
+	waitConnectionState(t, conn, grpchelpers.ConnectionConnected)
+
+	if err := getNodeInfo(conn.GRPCConn); err != nil {
+		t.Fatalf("GetNodeInfo call should succeed: err=%v", err)
+	}
+
+	// Certificate of other type should be ignored, renewed certificate should rebuild connection
+
+	certEvents <- cryptutils.CertEvent{
+		EventType: cryptutils.CertEventRenewed, Cert: cryptutils.WatchedCert{Type: "offline"},
+	}
+	certEvents <- cryptutils.CertEvent{
+		EventType: cryptutils.CertEventRenewed, Cert: cryptutils.WatchedCert{Type: "online"},
+	}
+
+	waitFactoryCalls(t, &factoryCalls, 2)
+
+	if err := getNodeInfo(conn.GRPCConn); err != nil {
+		t.Fatalf("GetNodeInfo call should succeed: err=%v", err)
+	}
+
+	conn.Reconnect()
+
+	waitFactoryCalls(t, &factoryCalls, 3)
+
+	// Connection lost and restored after server restart
+
+	testServer.StopServer()
+
+	waitConnectionState(t, conn, grpchelpers.ConnectionDisconnected)
+
+	if err := getNodeInfo(conn.GRPCConn); err == nil {
+		t.Error("GetNodeInfo call should fail")
+	}
+
+	testServer = startTestServer(testURL)
+
+	waitConnectionState(t, conn, grpchelpers.ConnectionConnected)
+
+	if err := getNodeInfo(conn.GRPCConn); err != nil {
+		t.Fatalf("GetNodeInfo call should succeed: err=%v", err)
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func waitConnectionState(t *testing.T, conn *grpchelpers.ManagedConn, expectedState grpchelpers.ConnectionState) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case state := <-conn.StateChanges():
+			if state == expectedState {
+				return
+			}
+
+		case <-timeout:
+			t.Fatalf("Wait connection state %s timeout", expectedState)
+		}
+	}
+}
+
+func waitFactoryCalls(t *testing.T, factoryCalls *atomic.Int32, expectedCalls int32) {
+	t.Helper()
+
+	for range 50 {
+		if factoryCalls.Load() >= expectedCalls {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatalf("Wrong factory calls count: %d", factoryCalls.Load())
+}
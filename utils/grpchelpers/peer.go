@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"path"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/cryptutils"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// AnyMethod allow-list key applied to methods without own entry.
+const AnyMethod = "*"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// PeerIdentity Aos identity of authenticated peer.
+type PeerIdentity struct {
+	// Name peer certificate subject common name.
+	Name string
+	// DNSNames peer certificate DNS SANs.
+	DNSNames []string
+	// SPIFFEID peer SPIFFE ID if certificate contains one.
+	SPIFFEID *cryptutils.SPIFFEID
+	// Certificate verified peer certificate.
+	Certificate *x509.Certificate
+}
+
+// MethodAllowList maps full method name (/package.Service/Method) to identity patterns allowed to call it. Pattern
+// is either SPIFFE ID pattern (see cryptutils.MatchSPIFFEID) or path.Match pattern applied to common name and DNS
+// names. AnyMethod entry is used for methods without own entry. Methods without entry are denied.
+type MethodAllowList map[string][]string
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// WithClientAuth requires and verifies client certificates against crypto context root CAs. It should be used on
+// servers which authorize peers by identity.
+func WithClientAuth() TLSOption {
+	return func(tlsConfig *tls.Config) {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		tlsConfig.ClientCAs = tlsConfig.RootCAs
+	}
+}
+
+// PeerCertificateFromContext returns verified peer certificate from grpc context.
+func PeerCertificateFromContext(ctx context.Context) (*x509.Certificate, error) {
+	grpcPeer, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, aoserrors.New("no peer in context")
+	}
+
+	tlsInfo, ok := grpcPeer.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, aoserrors.New("peer connection is not TLS")
+	}
+
+	if len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, aoserrors.New("peer certificate is not verified")
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0], nil
+}
+
+// PeerIdentityFromContext returns identity of verified peer from grpc context.
+func PeerIdentityFromContext(ctx context.Context) (identity PeerIdentity, err error) {
+	cert, err := PeerCertificateFromContext(ctx)
+	if err != nil {
+		return identity, err
+	}
+
+	return NewPeerIdentity(cert), nil
+}
+
+// NewPeerIdentity creates peer identity from certificate.
+func NewPeerIdentity(cert *x509.Certificate) PeerIdentity {
+	identity := PeerIdentity{Name: cert.Subject.CommonName, DNSNames: cert.DNSNames, Certificate: cert}
+
+	if spiffeID, err := cryptutils.GetSPIFFEID(cert); err == nil {
+		identity.SPIFFEID = &spiffeID
+	}
+
+	return identity
+}
+
+// Matches checks if identity matches pattern.
+func (identity PeerIdentity) Matches(pattern string) bool {
+	if strings.HasPrefix(pattern, cryptutils.SchemeSPIFFE+"://") {
+		return identity.SPIFFEID != nil && cryptutils.MatchSPIFFEID(pattern, *identity.SPIFFEID)
+	}
+
+	for _, name := range append([]string{identity.Name}, identity.DNSNames...) {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns identity string representation.
+func (identity PeerIdentity) String() string {
+	if identity.SPIFFEID != nil {
+		return identity.SPIFFEID.String()
+	}
+
+	return identity.Name
+}
+
+// NewAuthUnaryServerInterceptor creates unary server interceptor which authorizes peers by method allow-list.
+func NewAuthUnaryServerInterceptor(allowList MethodAllowList) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := allowList.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// NewAuthStreamServerInterceptor creates stream server interceptor which authorizes peers by method allow-list.
+func NewAuthStreamServerInterceptor(allowList MethodAllowList) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		if err := allowList.authorize(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// NewAuthServerOptions returns server options which install authorization interceptors.
+func NewAuthServerOptions(allowList MethodAllowList) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(NewAuthUnaryServerInterceptor(allowList)),
+		grpc.ChainStreamInterceptor(NewAuthStreamServerInterceptor(allowList)),
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (allowList MethodAllowList) authorize(ctx context.Context, method string) error {
+	identity, err := PeerIdentityFromContext(ctx)
+	if err != nil {
+		log.WithField("method", method).Warnf("Peer is not authenticated: %v", err)
+
+		return status.Error(codes.Unauthenticated, err.Error()) //nolint:wrapcheck // return grpc status as is
+	}
+
+	patterns, ok := allowList[method]
+	if !ok {
+		patterns = allowList[AnyMethod]
+	}
+
+	for _, pattern := range patterns {
+		if identity.Matches(pattern) {
+			return nil
+		}
+	}
+
+	log.WithFields(log.Fields{"method": method, "peer": identity}).Warn("Peer is not authorized")
+
+	return status.Errorf(codes.PermissionDenied, "peer %s is not authorized to call %s", identity, method)
+}
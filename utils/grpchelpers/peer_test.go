@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers_test
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/aosedge/aos_common/api/iamanager"
+	"github.com/aosedge/aos_common/utils/cryptutils"
+	"github.com/aosedge/aos_common/utils/grpchelpers"
+	"github.com/aosedge/aos_common/utils/testtools"
+)
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestPeerAuthorization(t *testing.T) {
+	pki, err := testtools.NewPKIBuilder().
+		WithLeaf("server").
+		WithLeaf("sm", testtools.WithDNSNames("sm.node1.aos")).
+		WithLeaf("cm").Build()
+	if err != nil {
+		t.Fatalf("Can't build PKI: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	rootCA := filepath.Join(tmpDir, "rootCA.pem")
+
+	if err = os.WriteFile(rootCA, cryptutils.CertToPEM(pki.Root.Cert), 0o600); err != nil {
+		t.Fatalf("Can't write root CA: %v", err)
+	}
+
+	certProvider := &testCertProvider{}
+
+	if certProvider.certURL, certProvider.keyURL, err = writeLeaf(tmpDir, pki.Leaves["server"]); err != nil {
+		t.Fatalf("Can't write certificate: %v", err)
+	}
+
+	cryptoContext, err := cryptutils.NewCryptoContext(rootCA)
+	if err != nil {
+		t.Fatalf("Can't create crypto context: %v", err)
+	}
+	defer cryptoContext.Close()
+
+	serverCert, err := grpchelpers.NewServerCertificate(cryptoContext, certProvider, "online")
+	if err != nil {
+		t.Fatalf("Can't create server certificate: %v", err)
+	}
+
+	serverOptions, err := grpchelpers.NewRotatingServerOptions(
+		cryptoContext, serverCert, false, grpchelpers.WithClientAuth())
+	if err != nil {
+		t.Fatalf("Can't create server options: %v", err)
+	}
+
+	testServer := grpchelpers.NewGRPCServer(testURL)
+	defer testServer.StopServer()
+
+	testServer.RegisterService(&pb.IAMPublicService_ServiceDesc, testIAMServer{})
+
+	if err = testServer.RestartServer(append(serverOptions, grpchelpers.NewAuthServerOptions(
+		grpchelpers.MethodAllowList{
+			"/iamanager.v5.IAMPublicService/GetNodeInfo": {"*.node1.aos"},
+			grpchelpers.AnyMethod:                        {"cm"},
+		})...)); err != nil {
+		t.Fatalf("Server not started: err=%v", err)
+	}
+
+	// SM is allowed to call GetNodeInfo by DNS name
+
+	if code := callWithClientCert(t, pki, "sm"); code != codes.OK {
+		t.Errorf("Wrong sm call result: %s", code)
+	}
+
+	// CM is allowed by common name for any method without own entry only
+
+	if code := callWithClientCert(t, pki, "cm"); code != codes.PermissionDenied {
+		t.Errorf("Wrong cm call result: %s", code)
+	}
+
+	// Identity matches
+
+	identity := grpchelpers.NewPeerIdentity(pki.Leaves["sm"].Cert)
+
+	if !identity.Matches("sm") || !identity.Matches("sm.*.aos") || identity.Matches("cm") ||
+		identity.Matches("spiffe://aos/...") {
+		t.Errorf("Wrong identity matching: %v", identity)
+	}
+}
+
+func TestUnixSocketPeerCredentials(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+
+	testServer := grpchelpers.NewGRPCServer(grpchelpers.UnixSocketScheme + socketPath)
+	defer testServer.StopServer()
+
+	peerCredentialsChannel := make(chan grpchelpers.UnixPeerCredentials, 1)
+
+	testServer.RegisterService(&pb.IAMPublicService_ServiceDesc, testIAMServer{})
+
+	uid := uint32(os.Getuid()) //nolint:gosec // uid is not negative
+
+	options := append(grpchelpers.NewUnixServerOptions(),
+		grpc.ChainUnaryInterceptor(func(
+			ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			if peerCredentials, err := grpchelpers.UnixPeerCredentialsFromContext(ctx); err == nil {
+				peerCredentialsChannel <- peerCredentials
+			}
+
+			return handler(ctx, req)
+		}))
+
+	options = append(options, grpchelpers.NewUIDAuthServerOptions(grpchelpers.UIDAllowList{
+		"/iamanager.v5.IAMPublicService/GetNodeInfo": {uid},
+		grpchelpers.AnyMethod:                        {uid + 1},
+	})...)
+
+	if err := testServer.RestartServer(options); err != nil {
+		t.Fatalf("Server not started: err=%v", err)
+	}
+
+	connection, err := grpchelpers.CreateUnixConnection(socketPath)
+	if err != nil {
+		t.Fatalf("Can't create connection: %v", err)
+	}
+	defer connection.Close()
+
+	ctx, cancelFunction := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFunction()
+
+	client := pb.NewIAMPublicServiceClient(connection)
+
+	if _, err = client.GetNodeInfo(ctx, &emptypb.Empty{}); err != nil {
+		t.Fatalf("Can't get node info: %v", err)
+	}
+
+	peerCredentials := <-peerCredentialsChannel
+
+	if peerCredentials.UID != uid || peerCredentials.GID != uint32(os.Getgid()) || //nolint:gosec
+		peerCredentials.PID != int32(os.Getpid()) { //nolint:gosec // pid fits int32
+		t.Errorf("Wrong peer credentials: %v", peerCredentials)
+	}
+
+	if _, err = client.GetCert(ctx, &pb.GetCertRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Wrong get cert result: %v", err)
+	}
+
+	<-peerCredentialsChannel
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func callWithClientCert(t *testing.T, pki *testtools.PKI, name string) codes.Code {
+	t.Helper()
+
+	clientCert, err := pki.TLSCertificate(name)
+	if err != nil {
+		t.Fatalf("Can't get client certificate: %v", err)
+	}
+
+	connection, err := grpc.NewClient("127.0.0.1"+testURL, grpc.WithTransportCredentials(credentials.NewTLS(
+		&tls.Config{RootCAs: pki.RootPool(), Certificates: []tls.Certificate{clientCert}, MinVersion: tls.VersionTLS12})))
+	if err != nil {
+		t.Fatalf("Can't create connection: %v", err)
+	}
+	defer connection.Close()
+
+	ctx, cancelFunction := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFunction()
+
+	_, err = pb.NewIAMPublicServiceClient(connection).GetNodeInfo(ctx, &emptypb.Empty{})
+
+	return status.Code(err)
+}
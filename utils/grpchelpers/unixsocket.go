@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpchelpers
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// UnixSocketScheme address scheme of unix socket servers and connections.
+const UnixSocketScheme = "unix://"
+
+const unixAuthType = "unix"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// UnixPeerCredentials credentials of unix socket peer process.
+type UnixPeerCredentials struct {
+	credentials.CommonAuthInfo
+
+	UID uint32
+	GID uint32
+	PID int32
+}
+
+// UIDAllowList maps full method name (/package.Service/Method) to peer UIDs allowed to call it. AnyMethod entry is
+// used for methods without own entry. Methods without entry are denied.
+type UIDAllowList map[string][]uint32
+
+type unixCredentials struct{}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewUnixCredentials creates transport credentials which obtain unix socket peer credentials (SO_PEERCRED) on
+// handshake. It should be used only for connections over unix sockets.
+func NewUnixCredentials() credentials.TransportCredentials {
+	return unixCredentials{}
+}
+
+// NewUnixServerOptions returns server options for serving over unix socket.
+func NewUnixServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{grpc.Creds(NewUnixCredentials())}
+}
+
+// CreateUnixConnection creates GRPC connection over unix socket.
+func CreateUnixConnection(socketPath string) (connection *grpc.ClientConn, err error) {
+	if connection, err = grpc.NewClient(
+		UnixSocketScheme+strings.TrimPrefix(socketPath, UnixSocketScheme),
+		grpc.WithTransportCredentials(NewUnixCredentials())); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return connection, nil
+}
+
+// UnixPeerCredentialsFromContext returns unix socket peer credentials from grpc context.
+func UnixPeerCredentialsFromContext(ctx context.Context) (peerCredentials UnixPeerCredentials, err error) {
+	grpcPeer, ok := peer.FromContext(ctx)
+	if !ok {
+		return peerCredentials, aoserrors.New("no peer in context")
+	}
+
+	if peerCredentials, ok = grpcPeer.AuthInfo.(UnixPeerCredentials); !ok {
+		return peerCredentials, aoserrors.New("peer connection is not unix socket")
+	}
+
+	return peerCredentials, nil
+}
+
+// AuthType returns auth type.
+func (peerCredentials UnixPeerCredentials) AuthType() string {
+	return unixAuthType
+}
+
+// NewUIDAuthServerOptions returns server options which install unix peer UID authorization interceptors.
+func NewUIDAuthServerOptions(allowList UIDAllowList) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(
+			ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			if err := allowList.authorize(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(
+			srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+		) error {
+			if err := allowList.authorize(stream.Context(), info.FullMethod); err != nil {
+				return err
+			}
+
+			return handler(srv, stream)
+		}),
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (allowList UIDAllowList) authorize(ctx context.Context, method string) error {
+	peerCredentials, err := UnixPeerCredentialsFromContext(ctx)
+	if err != nil {
+		log.WithField("method", method).Warnf("Peer credentials not available: %v", err)
+
+		return status.Error(codes.Unauthenticated, err.Error()) //nolint:wrapcheck // return grpc status as is
+	}
+
+	uids, ok := allowList[method]
+	if !ok {
+		uids = allowList[AnyMethod]
+	}
+
+	for _, uid := range uids {
+		if uid == peerCredentials.UID {
+			return nil
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"method": method, "uid": peerCredentials.UID, "pid": peerCredentials.PID,
+	}).Warn("Peer is not authorized")
+
+	return status.Errorf(codes.PermissionDenied, "peer uid %d is not authorized to call %s", peerCredentials.UID, method)
+}
+
+func (unixCredentials) ClientHandshake(
+	_ context.Context, _ string, conn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+	return handshakeUnixConn(conn)
+}
+
+func (unixCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return handshakeUnixConn(conn)
+}
+
+func (unixCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: unixAuthType}
+}
+
+func (creds unixCredentials) Clone() credentials.TransportCredentials {
+	return creds
+}
+
+func (unixCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+func handshakeUnixConn(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	peerCredentials, err := getUnixPeerCredentials(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, peerCredentials, nil
+}
+
+func getUnixPeerCredentials(conn net.Conn) (peerCredentials UnixPeerCredentials, err error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return peerCredentials, aoserrors.New("connection is not unix socket")
+	}
+
+	rawConn, err := unixConn.SyscallConn()
+	if err != nil {
+		return peerCredentials, aoserrors.Wrap(err)
+	}
+
+	var (
+		ucred    *unix.Ucred
+		ucredErr error
+	)
+
+	if err = rawConn.Control(func(fd uintptr) {
+		ucred, ucredErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return peerCredentials, aoserrors.Wrap(err)
+	}
+
+	if ucredErr != nil {
+		return peerCredentials, aoserrors.Wrap(ucredErr)
+	}
+
+	return UnixPeerCredentials{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		UID:            ucred.Uid,
+		GID:            ucred.Gid,
+		PID:            ucred.Pid,
+	}, nil
+}
+
+func listenUnixSocket(socketPath string) (net.Listener, error) {
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return listener, nil
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconvert
+
+import (
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	pbsm "github.com/aosedge/aos_common/api/servicemanager"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// AlertToPB converts alert from Aos type to protobuf. Only alerts transferred over SM gRPC API are supported:
+// SystemAlert, CoreAlert, SystemQuotaAlert, InstanceQuotaAlert, DeviceAllocateAlert, ResourceValidateAlert and
+// ServiceInstanceAlert.
+func AlertToPB(alert interface{}) (*pbsm.Alert, error) {
+	var (
+		pbAlert     = &pbsm.Alert{}
+		commonAlert cloudprotocol.AlertItem
+	)
+
+	switch alertItem := alert.(type) {
+	case cloudprotocol.SystemAlert:
+		commonAlert = alertItem.AlertItem
+		pbAlert.AlertItem = &pbsm.Alert_SystemAlert{
+			SystemAlert: &pbsm.SystemAlert{Message: alertItem.Message},
+		}
+
+	case cloudprotocol.CoreAlert:
+		commonAlert = alertItem.AlertItem
+		pbAlert.AlertItem = &pbsm.Alert_CoreAlert{
+			CoreAlert: &pbsm.CoreAlert{CoreComponent: alertItem.CoreComponent, Message: alertItem.Message},
+		}
+
+	case cloudprotocol.SystemQuotaAlert:
+		commonAlert = alertItem.AlertItem
+		pbAlert.AlertItem = &pbsm.Alert_SystemQuotaAlert{
+			SystemQuotaAlert: &pbsm.SystemQuotaAlert{
+				Parameter: alertItem.Parameter,
+				Value:     alertItem.Value,
+				Status:    alertItem.Status,
+			},
+		}
+
+	case cloudprotocol.InstanceQuotaAlert:
+		commonAlert = alertItem.AlertItem
+		pbAlert.AlertItem = &pbsm.Alert_InstanceQuotaAlert{
+			InstanceQuotaAlert: &pbsm.InstanceQuotaAlert{
+				Instance:  InstanceIdentToPB(alertItem.InstanceIdent),
+				Parameter: alertItem.Parameter,
+				Value:     alertItem.Value,
+				Status:    alertItem.Status,
+			},
+		}
+
+	case cloudprotocol.DeviceAllocateAlert:
+		commonAlert = alertItem.AlertItem
+		pbAlert.AlertItem = &pbsm.Alert_DeviceAllocateAlert{
+			DeviceAllocateAlert: &pbsm.DeviceAllocateAlert{
+				Instance: InstanceIdentToPB(alertItem.InstanceIdent),
+				Device:   alertItem.Device,
+				Message:  alertItem.Message,
+			},
+		}
+
+	case cloudprotocol.ResourceValidateAlert:
+		commonAlert = alertItem.AlertItem
+		pbAlert.AlertItem = &pbsm.Alert_ResourceValidateAlert{
+			ResourceValidateAlert: &pbsm.ResourceValidateAlert{
+				Name:   alertItem.Name,
+				Errors: errorInfosToPB(alertItem.Errors),
+			},
+		}
+
+	case cloudprotocol.ServiceInstanceAlert:
+		commonAlert = alertItem.AlertItem
+		pbAlert.AlertItem = &pbsm.Alert_InstanceAlert{
+			InstanceAlert: &pbsm.InstanceAlert{
+				Instance:       InstanceIdentToPB(alertItem.InstanceIdent),
+				ServiceVersion: alertItem.ServiceVersion,
+				Message:        alertItem.Message,
+			},
+		}
+
+	default:
+		return nil, aoserrors.Errorf("unsupported alert type: %T", alert)
+	}
+
+	pbAlert.Timestamp = timestamppb.New(commonAlert.Timestamp)
+	pbAlert.Tag = commonAlert.Tag
+
+	return pbAlert, nil
+}
+
+// AlertFromPB converts alert from protobuf to Aos type. Node ID is not part of protobuf message and should be
+// provided by caller.
+func AlertFromPB(pbAlert *pbsm.Alert, nodeID string) (interface{}, error) {
+	alertItem := cloudprotocol.AlertItem{Tag: pbAlert.GetTag()}
+
+	if timestamp := timeFromPB(pbAlert.GetTimestamp()); timestamp != nil {
+		alertItem.Timestamp = *timestamp
+	}
+
+	switch pbAlertItem := pbAlert.GetAlertItem().(type) {
+	case *pbsm.Alert_SystemAlert:
+		return cloudprotocol.SystemAlert{
+			AlertItem: alertItem,
+			NodeID:    nodeID,
+			Message:   pbAlertItem.SystemAlert.GetMessage(),
+		}, nil
+
+	case *pbsm.Alert_CoreAlert:
+		return cloudprotocol.CoreAlert{
+			AlertItem:     alertItem,
+			NodeID:        nodeID,
+			CoreComponent: pbAlertItem.CoreAlert.GetCoreComponent(),
+			Message:       pbAlertItem.CoreAlert.GetMessage(),
+		}, nil
+
+	case *pbsm.Alert_SystemQuotaAlert:
+		return cloudprotocol.SystemQuotaAlert{
+			AlertItem: alertItem,
+			NodeID:    nodeID,
+			Parameter: pbAlertItem.SystemQuotaAlert.GetParameter(),
+			Value:     pbAlertItem.SystemQuotaAlert.GetValue(),
+			Status:    pbAlertItem.SystemQuotaAlert.GetStatus(),
+		}, nil
+
+	case *pbsm.Alert_InstanceQuotaAlert:
+		return cloudprotocol.InstanceQuotaAlert{
+			AlertItem:     alertItem,
+			InstanceIdent: InstanceIdentFromPB(pbAlertItem.InstanceQuotaAlert.GetInstance()),
+			Parameter:     pbAlertItem.InstanceQuotaAlert.GetParameter(),
+			Value:         pbAlertItem.InstanceQuotaAlert.GetValue(),
+			Status:        pbAlertItem.InstanceQuotaAlert.GetStatus(),
+		}, nil
+
+	case *pbsm.Alert_DeviceAllocateAlert:
+		return cloudprotocol.DeviceAllocateAlert{
+			AlertItem:     alertItem,
+			InstanceIdent: InstanceIdentFromPB(pbAlertItem.DeviceAllocateAlert.GetInstance()),
+			NodeID:        nodeID,
+			Device:        pbAlertItem.DeviceAllocateAlert.GetDevice(),
+			Message:       pbAlertItem.DeviceAllocateAlert.GetMessage(),
+		}, nil
+
+	case *pbsm.Alert_ResourceValidateAlert:
+		return cloudprotocol.ResourceValidateAlert{
+			AlertItem: alertItem,
+			NodeID:    nodeID,
+			Name:      pbAlertItem.ResourceValidateAlert.GetName(),
+			Errors:    errorInfosFromPB(pbAlertItem.ResourceValidateAlert.GetErrors()),
+		}, nil
+
+	case *pbsm.Alert_InstanceAlert:
+		return cloudprotocol.ServiceInstanceAlert{
+			AlertItem:      alertItem,
+			InstanceIdent:  InstanceIdentFromPB(pbAlertItem.InstanceAlert.GetInstance()),
+			ServiceVersion: pbAlertItem.InstanceAlert.GetServiceVersion(),
+			Message:        pbAlertItem.InstanceAlert.GetMessage(),
+		}, nil
+
+	default:
+		return nil, aoserrors.Errorf("unsupported alert type: %T", pbAlertItem)
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconvert
+
+import (
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	pbsm "github.com/aosedge/aos_common/api/servicemanager"
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// OverrideEnvVarsToPB converts OverrideEnvVars from Aos type to protobuf.
+func OverrideEnvVarsToPB(envVars cloudprotocol.OverrideEnvVars) *pbsm.OverrideEnvVars {
+	pbEnvVars := &pbsm.OverrideEnvVars{}
+
+	if len(envVars.Items) > 0 {
+		pbEnvVars.EnvVars = make([]*pbsm.OverrideInstanceEnvVar, len(envVars.Items))
+
+		for i, item := range envVars.Items {
+			pbEnvVars.EnvVars[i] = &pbsm.OverrideInstanceEnvVar{
+				InstanceFilter: InstanceFilterToPB(item.InstanceFilter),
+				Variables:      envVarsInfoToPB(item.Variables),
+			}
+		}
+	}
+
+	return pbEnvVars
+}
+
+// OverrideEnvVarsFromPB converts OverrideEnvVars from protobuf to Aos type.
+func OverrideEnvVarsFromPB(pbEnvVars *pbsm.OverrideEnvVars) cloudprotocol.OverrideEnvVars {
+	envVars := cloudprotocol.OverrideEnvVars{MessageType: cloudprotocol.OverrideEnvVarsMessageType}
+
+	if len(pbEnvVars.GetEnvVars()) > 0 {
+		envVars.Items = make([]cloudprotocol.EnvVarsInstanceInfo, len(pbEnvVars.GetEnvVars()))
+
+		for i, pbItem := range pbEnvVars.GetEnvVars() {
+			envVars.Items[i] = cloudprotocol.EnvVarsInstanceInfo{
+				InstanceFilter: InstanceFilterFromPB(pbItem.GetInstanceFilter()),
+				Variables:      envVarsInfoFromPB(pbItem.GetVariables()),
+			}
+		}
+	}
+
+	return envVars
+}
+
+// OverrideEnvVarsStatusToPB converts OverrideEnvVarsStatus and common error from Aos type to protobuf.
+func OverrideEnvVarsStatusToPB(
+	status cloudprotocol.OverrideEnvVarsStatus, errorInfo *cloudprotocol.ErrorInfo,
+) *pbsm.OverrideEnvVarStatus {
+	pbStatus := &pbsm.OverrideEnvVarStatus{Error: ErrorInfoToPB(errorInfo)}
+
+	if len(status.Statuses) > 0 {
+		pbStatus.EnvVarsStatus = make([]*pbsm.EnvVarInstanceStatus, len(status.Statuses))
+
+		for i, instanceStatus := range status.Statuses {
+			pbInstanceStatus := &pbsm.EnvVarInstanceStatus{
+				InstanceFilter: InstanceFilterToPB(instanceStatus.InstanceFilter),
+			}
+
+			if len(instanceStatus.Statuses) > 0 {
+				pbInstanceStatus.Statuses = make([]*pbsm.EnvVarStatus, len(instanceStatus.Statuses))
+
+				for j, envVarStatus := range instanceStatus.Statuses {
+					pbInstanceStatus.Statuses[j] = &pbsm.EnvVarStatus{
+						Name:  envVarStatus.Name,
+						Error: ErrorInfoToPB(envVarStatus.ErrorInfo),
+					}
+				}
+			}
+
+			pbStatus.EnvVarsStatus[i] = pbInstanceStatus
+		}
+	}
+
+	return pbStatus
+}
+
+// OverrideEnvVarsStatusFromPB converts OverrideEnvVarsStatus and common error from protobuf to Aos type.
+func OverrideEnvVarsStatusFromPB(
+	pbStatus *pbsm.OverrideEnvVarStatus,
+) (status cloudprotocol.OverrideEnvVarsStatus, errorInfo *cloudprotocol.ErrorInfo) {
+	status.MessageType = cloudprotocol.OverrideEnvVarsStatusMessageType
+
+	if len(pbStatus.GetEnvVarsStatus()) > 0 {
+		status.Statuses = make([]cloudprotocol.EnvVarsInstanceStatus, len(pbStatus.GetEnvVarsStatus()))
+
+		for i, pbInstanceStatus := range pbStatus.GetEnvVarsStatus() {
+			instanceStatus := cloudprotocol.EnvVarsInstanceStatus{
+				InstanceFilter: InstanceFilterFromPB(pbInstanceStatus.GetInstanceFilter()),
+			}
+
+			if len(pbInstanceStatus.GetStatuses()) > 0 {
+				instanceStatus.Statuses = make([]cloudprotocol.EnvVarStatus, len(pbInstanceStatus.GetStatuses()))
+
+				for j, pbEnvVarStatus := range pbInstanceStatus.GetStatuses() {
+					instanceStatus.Statuses[j] = cloudprotocol.EnvVarStatus{
+						Name:      pbEnvVarStatus.GetName(),
+						ErrorInfo: ErrorInfoFromPB(pbEnvVarStatus.GetError()),
+					}
+				}
+			}
+
+			status.Statuses[i] = instanceStatus
+		}
+	}
+
+	return status, ErrorInfoFromPB(pbStatus.GetError())
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func envVarsInfoToPB(envVars []cloudprotocol.EnvVarInfo) []*pbsm.EnvVarInfo {
+	if len(envVars) == 0 {
+		return nil
+	}
+
+	pbEnvVars := make([]*pbsm.EnvVarInfo, len(envVars))
+
+	for i, envVar := range envVars {
+		pbEnvVars[i] = &pbsm.EnvVarInfo{Name: envVar.Name, Value: envVar.Value, Ttl: timeToPB(envVar.TTL)}
+	}
+
+	return pbEnvVars
+}
+
+func envVarsInfoFromPB(pbEnvVars []*pbsm.EnvVarInfo) []cloudprotocol.EnvVarInfo {
+	if len(pbEnvVars) == 0 {
+		return nil
+	}
+
+	envVars := make([]cloudprotocol.EnvVarInfo, len(pbEnvVars))
+
+	for i, pbEnvVar := range pbEnvVars {
+		envVars[i] = cloudprotocol.EnvVarInfo{
+			Name:  pbEnvVar.GetName(),
+			Value: pbEnvVar.GetValue(),
+			TTL:   timeFromPB(pbEnvVar.GetTtl()),
+		}
+	}
+
+	return envVars
+}
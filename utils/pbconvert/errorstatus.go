@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconvert
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	pbcommon "github.com/aosedge/aos_common/api/common"
+)
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var aosCodeToGRPCCode = map[int]codes.Code{
+	cloudprotocol.ErrorCodeNone:            codes.OK,
+	cloudprotocol.ErrorCodeFailed:          codes.Unknown,
+	cloudprotocol.ErrorCodeRuntime:         codes.Internal,
+	cloudprotocol.ErrorCodeNoMemory:        codes.ResourceExhausted,
+	cloudprotocol.ErrorCodeOutOfRange:      codes.OutOfRange,
+	cloudprotocol.ErrorCodeNotFound:        codes.NotFound,
+	cloudprotocol.ErrorCodeInvalidArgument: codes.InvalidArgument,
+	cloudprotocol.ErrorCodeTimeout:         codes.DeadlineExceeded,
+	cloudprotocol.ErrorCodeAlreadyExist:    codes.AlreadyExists,
+	cloudprotocol.ErrorCodeWrongState:      codes.FailedPrecondition,
+	cloudprotocol.ErrorCodeInvalidChecksum: codes.DataLoss,
+	cloudprotocol.ErrorCodeAlreadyLoggedIn: codes.AlreadyExists,
+	cloudprotocol.ErrorCodeNotSupported:    codes.Unimplemented,
+	cloudprotocol.ErrorCodeCanceled:        codes.Canceled,
+}
+
+//nolint:gochecknoglobals
+var grpcCodeToAosCode = map[codes.Code]int{
+	codes.OK:                 cloudprotocol.ErrorCodeNone,
+	codes.Canceled:           cloudprotocol.ErrorCodeCanceled,
+	codes.Unknown:            cloudprotocol.ErrorCodeFailed,
+	codes.InvalidArgument:    cloudprotocol.ErrorCodeInvalidArgument,
+	codes.DeadlineExceeded:   cloudprotocol.ErrorCodeTimeout,
+	codes.NotFound:           cloudprotocol.ErrorCodeNotFound,
+	codes.AlreadyExists:      cloudprotocol.ErrorCodeAlreadyExist,
+	codes.ResourceExhausted:  cloudprotocol.ErrorCodeNoMemory,
+	codes.FailedPrecondition: cloudprotocol.ErrorCodeWrongState,
+	codes.Aborted:            cloudprotocol.ErrorCodeWrongState,
+	codes.OutOfRange:         cloudprotocol.ErrorCodeOutOfRange,
+	codes.Unimplemented:      cloudprotocol.ErrorCodeNotSupported,
+	codes.Internal:           cloudprotocol.ErrorCodeRuntime,
+	codes.DataLoss:           cloudprotocol.ErrorCodeInvalidChecksum,
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// ErrorInfoToStatus converts ErrorInfo to gRPC status. Full error info is attached as status details.
+func ErrorInfoToStatus(errorInfo *cloudprotocol.ErrorInfo) *status.Status {
+	if errorInfo == nil || errorInfo.AosCode == cloudprotocol.ErrorCodeNone {
+		return status.New(codes.OK, "")
+	}
+
+	grpcCode, ok := aosCodeToGRPCCode[errorInfo.AosCode]
+	if !ok {
+		grpcCode = codes.Unknown
+	}
+
+	grpcStatus := status.New(grpcCode, errorInfo.Message)
+
+	detailedStatus, err := grpcStatus.WithDetails(protoadapt.MessageV1Of(ErrorInfoToPB(errorInfo)))
+	if err != nil {
+		return grpcStatus
+	}
+
+	return detailedStatus
+}
+
+// ErrorInfoFromStatus converts gRPC status to ErrorInfo. If status has ErrorInfo details, they are used, otherwise
+// Aos code is derived from gRPC code.
+func ErrorInfoFromStatus(grpcStatus *status.Status) *cloudprotocol.ErrorInfo {
+	if grpcStatus == nil || grpcStatus.Code() == codes.OK {
+		return nil
+	}
+
+	for _, detail := range grpcStatus.Details() {
+		if pbErrorInfo, ok := detail.(*pbcommon.ErrorInfo); ok {
+			return ErrorInfoFromPB(pbErrorInfo)
+		}
+	}
+
+	aosCode, ok := grpcCodeToAosCode[grpcStatus.Code()]
+	if !ok {
+		aosCode = cloudprotocol.ErrorCodeFailed
+	}
+
+	return &cloudprotocol.ErrorInfo{AosCode: aosCode, Message: grpcStatus.Message()}
+}
+
+// ErrorToStatus converts error to gRPC status error preserving Aos error category. Status errors are returned as is.
+func ErrorToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return ErrorInfoToStatus(cloudprotocol.NewErrorInfo(err)).Err()
+}
+
+// ErrorFromStatus converts gRPC status error to Aos error which matches corresponding aoserrors category with
+// errors.Is. Non status errors are wrapped as is.
+func ErrorFromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var grpcStatus interface{ GRPCStatus() *status.Status }
+
+	if !errors.As(err, &grpcStatus) {
+		return aoserrors.Wrap(err)
+	}
+
+	return ErrorInfoFromStatus(grpcStatus.GRPCStatus()).Err()
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconvert
+
+import (
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	pbsm "github.com/aosedge/aos_common/api/servicemanager"
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// SystemLogRequestToPB converts system log request from Aos type to protobuf. Only log ID and time range are
+// transferred.
+func SystemLogRequestToPB(request cloudprotocol.RequestLog) *pbsm.SystemLogRequest {
+	return &pbsm.SystemLogRequest{
+		LogId: request.LogID,
+		From:  timeToPB(request.Filter.From),
+		Till:  timeToPB(request.Filter.Till),
+	}
+}
+
+// SystemLogRequestFromPB converts system log request from protobuf to Aos type.
+func SystemLogRequestFromPB(pbRequest *pbsm.SystemLogRequest) cloudprotocol.RequestLog {
+	return cloudprotocol.RequestLog{
+		MessageType: cloudprotocol.RequestLogMessageType,
+		LogID:       pbRequest.GetLogId(),
+		LogType:     cloudprotocol.SystemLog,
+		Filter: cloudprotocol.LogFilter{
+			From: timeFromPB(pbRequest.GetFrom()),
+			Till: timeFromPB(pbRequest.GetTill()),
+		},
+	}
+}
+
+// InstanceLogRequestToPB converts instance log request from Aos type to protobuf. Only log ID, time range and
+// instance filter are transferred.
+func InstanceLogRequestToPB(request cloudprotocol.RequestLog) *pbsm.InstanceLogRequest {
+	return &pbsm.InstanceLogRequest{
+		LogId:          request.LogID,
+		InstanceFilter: InstanceFilterToPB(request.Filter.InstanceFilter),
+		From:           timeToPB(request.Filter.From),
+		Till:           timeToPB(request.Filter.Till),
+	}
+}
+
+// InstanceLogRequestFromPB converts instance log request from protobuf to Aos type.
+func InstanceLogRequestFromPB(pbRequest *pbsm.InstanceLogRequest) cloudprotocol.RequestLog {
+	return cloudprotocol.RequestLog{
+		MessageType: cloudprotocol.RequestLogMessageType,
+		LogID:       pbRequest.GetLogId(),
+		LogType:     cloudprotocol.ServiceLog,
+		Filter: cloudprotocol.LogFilter{
+			From:           timeFromPB(pbRequest.GetFrom()),
+			Till:           timeFromPB(pbRequest.GetTill()),
+			InstanceFilter: InstanceFilterFromPB(pbRequest.GetInstanceFilter()),
+		},
+	}
+}
+
+// InstanceCrashLogRequestToPB converts instance crash log request from Aos type to protobuf. Only log ID, time range
+// and instance filter are transferred.
+func InstanceCrashLogRequestToPB(request cloudprotocol.RequestLog) *pbsm.InstanceCrashLogRequest {
+	return &pbsm.InstanceCrashLogRequest{
+		LogId:          request.LogID,
+		InstanceFilter: InstanceFilterToPB(request.Filter.InstanceFilter),
+		From:           timeToPB(request.Filter.From),
+		Till:           timeToPB(request.Filter.Till),
+	}
+}
+
+// InstanceCrashLogRequestFromPB converts instance crash log request from protobuf to Aos type.
+func InstanceCrashLogRequestFromPB(pbRequest *pbsm.InstanceCrashLogRequest) cloudprotocol.RequestLog {
+	return cloudprotocol.RequestLog{
+		MessageType: cloudprotocol.RequestLogMessageType,
+		LogID:       pbRequest.GetLogId(),
+		LogType:     cloudprotocol.CrashLog,
+		Filter: cloudprotocol.LogFilter{
+			From:           timeFromPB(pbRequest.GetFrom()),
+			Till:           timeFromPB(pbRequest.GetTill()),
+			InstanceFilter: InstanceFilterFromPB(pbRequest.GetInstanceFilter()),
+		},
+	}
+}
+
+// LogDataToPB converts push log from Aos type to protobuf.
+func LogDataToPB(pushLog cloudprotocol.PushLog) *pbsm.LogData {
+	return &pbsm.LogData{
+		LogId:     pushLog.LogID,
+		PartCount: pushLog.PartsCount,
+		Part:      pushLog.Part,
+		Data:      pushLog.Content,
+		Status:    pushLog.Status,
+		Error:     ErrorInfoToPB(pushLog.ErrorInfo),
+	}
+}
+
+// LogDataFromPB converts log data from protobuf to Aos push log. Node ID is not part of protobuf message and should
+// be provided by caller.
+func LogDataFromPB(pbLogData *pbsm.LogData, nodeID string) cloudprotocol.PushLog {
+	return cloudprotocol.PushLog{
+		MessageType: cloudprotocol.PushLogMessageType,
+		NodeID:      nodeID,
+		LogID:       pbLogData.GetLogId(),
+		PartsCount:  pbLogData.GetPartCount(),
+		Part:        pbLogData.GetPart(),
+		Content:     pbLogData.GetData(),
+		Status:      pbLogData.GetStatus(),
+		ErrorInfo:   ErrorInfoFromPB(pbLogData.GetError()),
+	}
+}
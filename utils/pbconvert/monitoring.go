@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconvert
+
+import (
+	"github.com/aosedge/aos_common/aostypes"
+	pbsm "github.com/aosedge/aos_common/api/servicemanager"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// MonitoringDataToPB converts MonitoringData from Aos type to protobuf.
+func MonitoringDataToPB(data aostypes.MonitoringData) *pbsm.MonitoringData {
+	pbData := &pbsm.MonitoringData{
+		Ram:       data.RAM,
+		Cpu:       data.CPU,
+		Download:  data.Download,
+		Upload:    data.Upload,
+		Timestamp: timestamppb.New(data.Timestamp),
+	}
+
+	if len(data.Partitions) > 0 {
+		pbData.Partitions = make([]*pbsm.PartitionUsage, len(data.Partitions))
+
+		for i, partition := range data.Partitions {
+			pbData.Partitions[i] = &pbsm.PartitionUsage{Name: partition.Name, UsedSize: partition.UsedSize}
+		}
+	}
+
+	return pbData
+}
+
+// MonitoringDataFromPB converts MonitoringData from protobuf to Aos type.
+func MonitoringDataFromPB(pbData *pbsm.MonitoringData) aostypes.MonitoringData {
+	data := aostypes.MonitoringData{
+		RAM:      pbData.GetRam(),
+		CPU:      pbData.GetCpu(),
+		Download: pbData.GetDownload(),
+		Upload:   pbData.GetUpload(),
+	}
+
+	if timestamp := timeFromPB(pbData.GetTimestamp()); timestamp != nil {
+		data.Timestamp = *timestamp
+	}
+
+	if len(pbData.GetPartitions()) > 0 {
+		data.Partitions = make([]aostypes.PartitionUsage, len(pbData.GetPartitions()))
+
+		for i, partition := range pbData.GetPartitions() {
+			data.Partitions[i] = aostypes.PartitionUsage{Name: partition.GetName(), UsedSize: partition.GetUsedSize()}
+		}
+	}
+
+	return data
+}
+
+// InstanceMonitoringToPB converts InstanceMonitoring from Aos type to protobuf.
+func InstanceMonitoringToPB(monitoring aostypes.InstanceMonitoring) *pbsm.InstanceMonitoring {
+	return &pbsm.InstanceMonitoring{
+		Instance:       InstanceIdentToPB(monitoring.InstanceIdent),
+		MonitoringData: MonitoringDataToPB(monitoring.MonitoringData),
+	}
+}
+
+// InstanceMonitoringFromPB converts InstanceMonitoring from protobuf to Aos type.
+func InstanceMonitoringFromPB(pbMonitoring *pbsm.InstanceMonitoring) aostypes.InstanceMonitoring {
+	return aostypes.InstanceMonitoring{
+		InstanceIdent:  InstanceIdentFromPB(pbMonitoring.GetInstance()),
+		MonitoringData: MonitoringDataFromPB(pbMonitoring.GetMonitoringData()),
+	}
+}
+
+// InstantMonitoringToPB converts NodeMonitoring from Aos type to protobuf instant monitoring.
+func InstantMonitoringToPB(monitoring aostypes.NodeMonitoring) *pbsm.InstantMonitoring {
+	return &pbsm.InstantMonitoring{
+		NodeMonitoring:      MonitoringDataToPB(monitoring.NodeData),
+		InstancesMonitoring: instancesMonitoringToPB(monitoring.InstancesData),
+	}
+}
+
+// InstantMonitoringFromPB converts protobuf instant monitoring to Aos NodeMonitoring type. Node ID is not part of
+// protobuf message and should be provided by caller.
+func InstantMonitoringFromPB(pbMonitoring *pbsm.InstantMonitoring, nodeID string) aostypes.NodeMonitoring {
+	return aostypes.NodeMonitoring{
+		NodeID:        nodeID,
+		NodeData:      MonitoringDataFromPB(pbMonitoring.GetNodeMonitoring()),
+		InstancesData: instancesMonitoringFromPB(pbMonitoring.GetInstancesMonitoring()),
+	}
+}
+
+// AverageMonitoringToPB converts NodeMonitoring from Aos type to protobuf average monitoring.
+func AverageMonitoringToPB(monitoring aostypes.NodeMonitoring) *pbsm.AverageMonitoring {
+	return &pbsm.AverageMonitoring{
+		NodeMonitoring:      MonitoringDataToPB(monitoring.NodeData),
+		InstancesMonitoring: instancesMonitoringToPB(monitoring.InstancesData),
+	}
+}
+
+// AverageMonitoringFromPB converts protobuf average monitoring to Aos NodeMonitoring type. Node ID is not part of
+// protobuf message and should be provided by caller.
+func AverageMonitoringFromPB(pbMonitoring *pbsm.AverageMonitoring, nodeID string) aostypes.NodeMonitoring {
+	return aostypes.NodeMonitoring{
+		NodeID:        nodeID,
+		NodeData:      MonitoringDataFromPB(pbMonitoring.GetNodeMonitoring()),
+		InstancesData: instancesMonitoringFromPB(pbMonitoring.GetInstancesMonitoring()),
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func instancesMonitoringToPB(instancesData []aostypes.InstanceMonitoring) []*pbsm.InstanceMonitoring {
+	if len(instancesData) == 0 {
+		return nil
+	}
+
+	pbInstancesData := make([]*pbsm.InstanceMonitoring, len(instancesData))
+
+	for i, instanceData := range instancesData {
+		pbInstancesData[i] = InstanceMonitoringToPB(instanceData)
+	}
+
+	return pbInstancesData
+}
+
+func instancesMonitoringFromPB(pbInstancesData []*pbsm.InstanceMonitoring) []aostypes.InstanceMonitoring {
+	if len(pbInstancesData) == 0 {
+		return nil
+	}
+
+	instancesData := make([]aostypes.InstanceMonitoring, len(pbInstancesData))
+
+	for i, pbInstanceData := range pbInstancesData {
+		instancesData[i] = InstanceMonitoringFromPB(pbInstanceData)
+	}
+
+	return instancesData
+}
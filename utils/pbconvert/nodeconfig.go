@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbconvert
+
+import (
+	"encoding/json"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/api/cloudprotocol"
+	pbsm "github.com/aosedge/aos_common/api/servicemanager"
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NodeConfigToPB converts node config from Aos type to protobuf set node config request.
+func NodeConfigToPB(nodeConfig cloudprotocol.NodeConfig, version string) (*pbsm.SetNodeConfig, error) {
+	rawConfig, err := json.Marshal(nodeConfig)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return &pbsm.SetNodeConfig{NodeConfig: string(rawConfig), Version: version}, nil
+}
+
+// NodeConfigFromPB converts protobuf set node config request to Aos node config and version.
+func NodeConfigFromPB(pbNodeConfig *pbsm.SetNodeConfig) (
+	nodeConfig cloudprotocol.NodeConfig, version string, err error,
+) {
+	if err = json.Unmarshal([]byte(pbNodeConfig.GetNodeConfig()), &nodeConfig); err != nil {
+		return nodeConfig, "", aoserrors.Wrap(err)
+	}
+
+	return nodeConfig, pbNodeConfig.GetVersion(), nil
+}
+
+// CheckNodeConfigToPB converts node config from Aos type to protobuf check node config request.
+func CheckNodeConfigToPB(nodeConfig cloudprotocol.NodeConfig, version string) (*pbsm.CheckNodeConfig, error) {
+	pbNodeConfig, err := NodeConfigToPB(nodeConfig, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbsm.CheckNodeConfig{NodeConfig: pbNodeConfig.GetNodeConfig(), Version: pbNodeConfig.GetVersion()}, nil
+}
+
+// CheckNodeConfigFromPB converts protobuf check node config request to Aos node config and version.
+func CheckNodeConfigFromPB(pbNodeConfig *pbsm.CheckNodeConfig) (
+	nodeConfig cloudprotocol.NodeConfig, version string, err error,
+) {
+	return NodeConfigFromPB(&pbsm.SetNodeConfig{
+		NodeConfig: pbNodeConfig.GetNodeConfig(), Version: pbNodeConfig.GetVersion(),
+	})
+}
@@ -18,11 +18,14 @@
 package pbconvert
 
 import (
+	"time"
+
 	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/api/cloudprotocol"
 	pbcommon "github.com/aosedge/aos_common/api/common"
 	pbiam "github.com/aosedge/aos_common/api/iamanager"
 	pbsm "github.com/aosedge/aos_common/api/servicemanager"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 /***********************************************************************************************************************
@@ -210,3 +213,55 @@ func NodeInfoFromPB(pbNodeInfo *pbiam.NodeInfo) cloudprotocol.NodeInfo {
 
 	return nodeInfo
 }
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func timeToPB(value *time.Time) *timestamppb.Timestamp {
+	if value == nil {
+		return nil
+	}
+
+	return timestamppb.New(*value)
+}
+
+func timeFromPB(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	value := timestamp.AsTime()
+
+	return &value
+}
+
+func errorInfosToPB(errorInfos []cloudprotocol.ErrorInfo) []*pbcommon.ErrorInfo {
+	if len(errorInfos) == 0 {
+		return nil
+	}
+
+	pbErrorInfos := make([]*pbcommon.ErrorInfo, len(errorInfos))
+
+	for i := range errorInfos {
+		pbErrorInfos[i] = ErrorInfoToPB(&errorInfos[i])
+	}
+
+	return pbErrorInfos
+}
+
+func errorInfosFromPB(pbErrorInfos []*pbcommon.ErrorInfo) []cloudprotocol.ErrorInfo {
+	if len(pbErrorInfos) == 0 {
+		return nil
+	}
+
+	errorInfos := make([]cloudprotocol.ErrorInfo, 0, len(pbErrorInfos))
+
+	for _, pbErrorInfo := range pbErrorInfos {
+		if errorInfo := ErrorInfoFromPB(pbErrorInfo); errorInfo != nil {
+			errorInfos = append(errorInfos, *errorInfo)
+		}
+	}
+
+	return errorInfos
+}
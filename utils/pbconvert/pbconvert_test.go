@@ -18,10 +18,15 @@
 package pbconvert_test
 
 import (
+	"context"
+	"errors"
+	"math"
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/aosedge/aos_common/aoserrors"
 	"github.com/aosedge/aos_common/aostypes"
 	"github.com/aosedge/aos_common/api/cloudprotocol"
 	pbcommon "github.com/aosedge/aos_common/api/common"
@@ -30,6 +35,8 @@ import (
 
 	"github.com/aosedge/aos_common/utils/pbconvert"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -209,6 +216,59 @@ func TestErrorInfoFromPB(t *testing.T) {
 	}
 }
 
+func TestErrorStatusConversion(t *testing.T) {
+	testData := []struct {
+		err          error
+		expectedCode codes.Code
+		category     error
+	}{
+		{err: aoserrors.Errorf("instance not found: %w", aoserrors.ErrNotFound), expectedCode: codes.NotFound,
+			category: aoserrors.ErrNotFound},
+		{err: aoserrors.Wrap(context.DeadlineExceeded), expectedCode: codes.DeadlineExceeded,
+			category: aoserrors.ErrTimeout},
+		{err: aoserrors.New("generic error"), expectedCode: codes.Unknown, category: aoserrors.ErrFailed},
+	}
+
+	for _, item := range testData {
+		statusErr := pbconvert.ErrorToStatus(item.err)
+
+		grpcStatus, ok := status.FromError(statusErr)
+		if !ok {
+			t.Fatalf("Status error expected: %v", statusErr)
+		}
+
+		if grpcStatus.Code() != item.expectedCode {
+			t.Errorf("Wrong status code: %s", grpcStatus.Code())
+		}
+
+		if grpcStatus.Message() != item.err.Error() {
+			t.Errorf("Wrong status message: %s", grpcStatus.Message())
+		}
+
+		err := pbconvert.ErrorFromStatus(statusErr)
+		if !errors.Is(err, item.category) {
+			t.Errorf("Error should match category %v: %v", item.category, err)
+		}
+	}
+
+	// Status without error info details
+
+	err := pbconvert.ErrorFromStatus(status.Error(codes.AlreadyExists, "exists"))
+	if !errors.Is(err, aoserrors.ErrAlreadyExist) {
+		t.Errorf("Error should match already exist category: %v", err)
+	}
+
+	errorInfo := pbconvert.ErrorInfoFromStatus(
+		pbconvert.ErrorInfoToStatus(&cloudprotocol.ErrorInfo{AosCode: 2, ExitCode: 5, Message: "runtime"}))
+	if errorInfo == nil || *errorInfo != (cloudprotocol.ErrorInfo{AosCode: 2, ExitCode: 5, Message: "runtime"}) {
+		t.Errorf("Wrong error info: %v", errorInfo)
+	}
+
+	if pbconvert.ErrorToStatus(nil) != nil || pbconvert.ErrorFromStatus(nil) != nil {
+		t.Error("Nil error expected")
+	}
+}
+
 func TestNodeInfoFromPB(t *testing.T) {
 	expectedNodeInfo := cloudprotocol.NodeInfo{
 		NodeID:   "node1",
@@ -280,3 +340,266 @@ func TestNodeInfoFromPB(t *testing.T) {
 		t.Error("Incorrect node info")
 	}
 }
+
+func TestNodeConfigConversion(t *testing.T) {
+	nodeID := "node1"
+	nodeConfig := cloudprotocol.NodeConfig{
+		NodeID:   &nodeID,
+		NodeType: "type1",
+		Devices:  []cloudprotocol.DeviceInfo{{Name: "dev1", SharedCount: 1, HostDevices: []string{"/dev/dev1"}}},
+		Labels:   []string{"label1"},
+		Priority: 10,
+	}
+
+	pbNodeConfig, err := pbconvert.NodeConfigToPB(nodeConfig, "1.0.0")
+	if err != nil {
+		t.Fatalf("Can't convert node config: %v", err)
+	}
+
+	receivedNodeConfig, version, err := pbconvert.NodeConfigFromPB(pbNodeConfig)
+	if err != nil {
+		t.Fatalf("Can't convert node config: %v", err)
+	}
+
+	if version != "1.0.0" || !reflect.DeepEqual(nodeConfig, receivedNodeConfig) {
+		t.Errorf("Wrong node config: %v, version: %s", receivedNodeConfig, version)
+	}
+
+	pbCheckNodeConfig, err := pbconvert.CheckNodeConfigToPB(nodeConfig, "2.0.0")
+	if err != nil {
+		t.Fatalf("Can't convert node config: %v", err)
+	}
+
+	if receivedNodeConfig, version, err = pbconvert.CheckNodeConfigFromPB(pbCheckNodeConfig); err != nil {
+		t.Fatalf("Can't convert node config: %v", err)
+	}
+
+	if version != "2.0.0" || !reflect.DeepEqual(nodeConfig, receivedNodeConfig) {
+		t.Errorf("Wrong node config: %v, version: %s", receivedNodeConfig, version)
+	}
+
+	if _, _, err = pbconvert.NodeConfigFromPB(&pbsm.SetNodeConfig{NodeConfig: "invalid"}); err == nil {
+		t.Error("Error expected for invalid node config")
+	}
+}
+
+func TestUnsupportedAlert(t *testing.T) {
+	if _, err := pbconvert.AlertToPB(cloudprotocol.KernelAlert{}); err == nil {
+		t.Error("Error expected for unsupported alert")
+	}
+
+	if _, err := pbconvert.AlertFromPB(&pbsm.Alert{}, "node1"); err == nil {
+		t.Error("Error expected for empty alert")
+	}
+}
+
+func FuzzMonitoringRoundTrip(f *testing.F) {
+	f.Add(int64(1700000000), int64(500), uint64(1024), uint64(50), uint64(10), uint64(20), "storage", uint64(4096),
+		"service1", "subject1", uint64(1))
+	f.Add(int64(0), int64(0), uint64(0), uint64(0), uint64(0), uint64(0), "", uint64(0), "", "", uint64(0))
+
+	f.Fuzz(func(t *testing.T, sec, nsec int64, ram, cpu, download, upload uint64, partition string, usedSize uint64,
+		serviceID, subjectID string, instance uint64,
+	) {
+		data := aostypes.MonitoringData{
+			Timestamp: fuzzTime(sec, nsec),
+			RAM:       ram,
+			CPU:       cpu,
+			Download:  download,
+			Upload:    upload,
+		}
+
+		if partition != "" {
+			data.Partitions = []aostypes.PartitionUsage{{Name: partition, UsedSize: usedSize}}
+		}
+
+		monitoring := aostypes.NodeMonitoring{
+			NodeID:   "node1",
+			NodeData: data,
+			InstancesData: []aostypes.InstanceMonitoring{{
+				InstanceIdent:  aostypes.InstanceIdent{ServiceID: serviceID, SubjectID: subjectID, Instance: instance},
+				MonitoringData: data,
+			}},
+		}
+
+		if received := pbconvert.InstantMonitoringFromPB(
+			pbconvert.InstantMonitoringToPB(monitoring), "node1"); !reflect.DeepEqual(monitoring, received) {
+			t.Errorf("Wrong instant monitoring: %v", received)
+		}
+
+		if received := pbconvert.AverageMonitoringFromPB(
+			pbconvert.AverageMonitoringToPB(monitoring), "node1"); !reflect.DeepEqual(monitoring, received) {
+			t.Errorf("Wrong average monitoring: %v", received)
+		}
+	})
+}
+
+func FuzzAlertRoundTrip(f *testing.F) {
+	f.Add(int64(1700000000), int64(500), "tag", "message", "cpu", uint64(90), "service1", "subject1", uint64(1),
+		int32(42))
+	f.Add(int64(0), int64(0), "", "", "", uint64(0), "", "", uint64(0), int32(0))
+
+	f.Fuzz(func(t *testing.T, sec, nsec int64, tag, message, parameter string, value uint64,
+		serviceID, subjectID string, instance uint64, aosCode int32,
+	) {
+		alertItem := cloudprotocol.AlertItem{Timestamp: fuzzTime(sec, nsec), Tag: tag}
+		instanceIdent := aostypes.InstanceIdent{ServiceID: serviceID, SubjectID: subjectID, Instance: instance}
+
+		alerts := []interface{}{
+			cloudprotocol.SystemAlert{AlertItem: alertItem, NodeID: "node1", Message: message},
+			cloudprotocol.CoreAlert{AlertItem: alertItem, NodeID: "node1", CoreComponent: parameter, Message: message},
+			cloudprotocol.SystemQuotaAlert{
+				AlertItem: alertItem, NodeID: "node1", Parameter: parameter, Value: value, Status: message,
+			},
+			cloudprotocol.InstanceQuotaAlert{
+				AlertItem: alertItem, InstanceIdent: instanceIdent, Parameter: parameter, Value: value, Status: message,
+			},
+			cloudprotocol.DeviceAllocateAlert{
+				AlertItem: alertItem, InstanceIdent: instanceIdent, NodeID: "node1", Device: parameter, Message: message,
+			},
+			cloudprotocol.ResourceValidateAlert{
+				AlertItem: alertItem, NodeID: "node1", Name: parameter,
+				Errors: []cloudprotocol.ErrorInfo{{AosCode: int(aosCode), Message: message}},
+			},
+			cloudprotocol.ServiceInstanceAlert{
+				AlertItem: alertItem, InstanceIdent: instanceIdent, ServiceVersion: parameter, Message: message,
+			},
+		}
+
+		for _, alert := range alerts {
+			pbAlert, err := pbconvert.AlertToPB(alert)
+			if err != nil {
+				t.Fatalf("Can't convert alert: %v", err)
+			}
+
+			received, err := pbconvert.AlertFromPB(pbAlert, "node1")
+			if err != nil {
+				t.Fatalf("Can't convert alert: %v", err)
+			}
+
+			if !reflect.DeepEqual(alert, received) {
+				t.Errorf("Wrong alert: %v", received)
+			}
+		}
+	})
+}
+
+func FuzzEnvVarsRoundTrip(f *testing.F) {
+	f.Add("service1", "subject1", uint64(1), "var1", "value1", int64(1700000000), int32(42), "error")
+	f.Add("", "", uint64(0), "", "", int64(0), int32(0), "")
+
+	f.Fuzz(func(t *testing.T, serviceID, subjectID string, instance uint64, name, value string, ttl int64,
+		aosCode int32, message string,
+	) {
+		filter := fuzzInstanceFilter(serviceID, subjectID, instance)
+		ttlTime := fuzzTime(ttl, 0)
+		errorInfo := &cloudprotocol.ErrorInfo{AosCode: int(aosCode), Message: message}
+
+		envVars := cloudprotocol.OverrideEnvVars{
+			MessageType: cloudprotocol.OverrideEnvVarsMessageType,
+			Items: []cloudprotocol.EnvVarsInstanceInfo{{
+				InstanceFilter: filter,
+				Variables:      []cloudprotocol.EnvVarInfo{{Name: name, Value: value, TTL: &ttlTime}, {Name: name}},
+			}},
+		}
+
+		if received := pbconvert.OverrideEnvVarsFromPB(
+			pbconvert.OverrideEnvVarsToPB(envVars)); !reflect.DeepEqual(envVars, received) {
+			t.Errorf("Wrong env vars: %v", received)
+		}
+
+		status := cloudprotocol.OverrideEnvVarsStatus{
+			MessageType: cloudprotocol.OverrideEnvVarsStatusMessageType,
+			Statuses: []cloudprotocol.EnvVarsInstanceStatus{{
+				InstanceFilter: filter,
+				Statuses:       []cloudprotocol.EnvVarStatus{{Name: name, ErrorInfo: errorInfo}, {Name: name}},
+			}},
+		}
+
+		receivedStatus, receivedErrorInfo := pbconvert.OverrideEnvVarsStatusFromPB(
+			pbconvert.OverrideEnvVarsStatusToPB(status, errorInfo))
+
+		if !reflect.DeepEqual(status, receivedStatus) || !reflect.DeepEqual(errorInfo, receivedErrorInfo) {
+			t.Errorf("Wrong env vars status: %v, error: %v", receivedStatus, receivedErrorInfo)
+		}
+	})
+}
+
+func FuzzLogRoundTrip(f *testing.F) {
+	f.Add("log1", "service1", "subject1", uint64(1), int64(1700000000), int64(1700000100), []byte("data"))
+	f.Add("", "", "", uint64(0), int64(0), int64(0), []byte(nil))
+
+	f.Fuzz(func(t *testing.T, logID, serviceID, subjectID string, instance uint64, from, till int64, data []byte) {
+		fromTime, tillTime := fuzzTime(from, 0), fuzzTime(till, 0)
+
+		request := cloudprotocol.RequestLog{
+			MessageType: cloudprotocol.RequestLogMessageType,
+			LogID:       logID,
+			LogType:     cloudprotocol.SystemLog,
+			Filter:      cloudprotocol.LogFilter{From: &fromTime, Till: &tillTime},
+		}
+
+		if received := pbconvert.SystemLogRequestFromPB(
+			pbconvert.SystemLogRequestToPB(request)); !reflect.DeepEqual(request, received) {
+			t.Errorf("Wrong system log request: %v", received)
+		}
+
+		request.Filter.InstanceFilter = fuzzInstanceFilter(serviceID, subjectID, instance)
+		request.LogType = cloudprotocol.ServiceLog
+
+		if received := pbconvert.InstanceLogRequestFromPB(
+			pbconvert.InstanceLogRequestToPB(request)); !reflect.DeepEqual(request, received) {
+			t.Errorf("Wrong instance log request: %v", received)
+		}
+
+		request.LogType = cloudprotocol.CrashLog
+
+		if received := pbconvert.InstanceCrashLogRequestFromPB(
+			pbconvert.InstanceCrashLogRequestToPB(request)); !reflect.DeepEqual(request, received) {
+			t.Errorf("Wrong instance crash log request: %v", received)
+		}
+
+		pushLog := cloudprotocol.PushLog{
+			MessageType: cloudprotocol.PushLogMessageType,
+			NodeID:      "node1",
+			LogID:       logID,
+			PartsCount:  instance,
+			Part:        instance,
+			Content:     data,
+			Status:      cloudprotocol.LogStatusOk,
+		}
+
+		if received := pbconvert.LogDataFromPB(
+			pbconvert.LogDataToPB(pushLog), "node1"); !reflect.DeepEqual(pushLog, received) {
+			t.Errorf("Wrong push log: %v", received)
+		}
+	})
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func fuzzTime(sec, nsec int64) time.Time {
+	// Limit time to range supported by protobuf timestamp: 0001-01-01 to 9999-12-31
+	const maxSeconds = 253402300799
+
+	return time.Unix(sec%maxSeconds, nsec%int64(time.Second)).UTC()
+}
+
+func fuzzInstanceFilter(serviceID, subjectID string, instance uint64) (filter cloudprotocol.InstanceFilter) {
+	if serviceID != "" {
+		filter.ServiceID = &serviceID
+	}
+
+	if subjectID != "" {
+		filter.SubjectID = &subjectID
+	}
+
+	// Protobuf filter uses -1 for any instance
+	instance &= math.MaxInt64
+
+	filter.Instance = &instance
+
+	return filter
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/clock"
 )
 
 /***********************************************************************************************************************
@@ -38,10 +39,22 @@ const (
  * Public
  **********************************************************************************************************************/
 
-// Retry performs operation defined number of times with configured delay.
+// Retry performs operation defined number of times with configured delay. Errors marked with
+// aoserrors.MarkPermanent are not retried.
 func Retry(
 	ctx context.Context, retryFunc func() error, retryCbk func(retryCount int, delay time.Duration, err error),
 	maxTry int, delay, maxDelay time.Duration,
+) (err error) {
+	return RetryIf(ctx, retryFunc, retryCbk, func(err error) bool { return !aoserrors.IsPermanent(err) },
+		maxTry, delay, maxDelay)
+}
+
+// RetryIf performs operation defined number of times with configured delay while shouldRetry returns true for the
+// operation error. Delays are measured by clock from context (see clock.ContextWithClock), system clock is used by
+// default.
+func RetryIf(
+	ctx context.Context, retryFunc func() error, retryCbk func(retryCount int, delay time.Duration, err error),
+	shouldRetry func(err error) bool, maxTry int, delay, maxDelay time.Duration,
 ) (err error) {
 	try := 1
 
@@ -50,6 +63,10 @@ func Retry(
 			return nil
 		}
 
+		if shouldRetry != nil && !shouldRetry(err) {
+			break
+		}
+
 		if try < maxTry || maxTry == 0 {
 			if ctx.Err() == nil && retryCbk != nil {
 				retryCbk(try, delay, err)
@@ -59,7 +76,7 @@ func Retry(
 			case <-ctx.Done():
 				return aoserrors.Wrap(ctx.Err())
 
-			case <-time.After(delay):
+			case <-clock.FromContext(ctx).After(delay):
 			}
 
 			delay *= 2
@@ -84,6 +101,12 @@ func DefaultRetry(ctx context.Context, retryFunc func() error) (err error) {
 	return Retry(ctx, retryFunc, nil, defaultMaxTry, defaultRetryDelay, 0)
 }
 
+// RetryableRetry performs operation default number of times with default delay while operation error is
+// retryable according to aoserrors.IsRetryable.
+func RetryableRetry(ctx context.Context, retryFunc func() error) (err error) {
+	return RetryIf(ctx, retryFunc, nil, aoserrors.IsRetryable, defaultMaxTry, defaultRetryDelay, 0)
+}
+
 // DefaultInfinitRetry performs operation default number of times with default delay.
 func DefaultInfinitRetry(ctx context.Context, retryFunc func() error,
 	retryCbk func(retryCount int, delay time.Duration, err error),
@@ -23,7 +23,9 @@ import (
 	"time"
 
 	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/clock"
 	"github.com/aosedge/aos_common/utils/retryhelper"
+	"github.com/aosedge/aos_common/utils/testtools"
 )
 
 /***********************************************************************************************************************
@@ -99,6 +101,86 @@ func TestRetryHelper(t *testing.T) {
 	}
 }
 
+func TestRetryByErrorClassification(t *testing.T) {
+	const retryDelay = 10 * time.Millisecond
+
+	callCount := 0
+
+	if err := retryhelper.Retry(context.Background(), func() error {
+		callCount++
+
+		return aoserrors.MarkPermanent(aoserrors.New("permanent error"))
+	}, nil, 3, retryDelay, 0); !aoserrors.IsPermanent(err) {
+		t.Errorf("Permanent error expected: %v", err)
+	}
+
+	if callCount != 1 {
+		t.Errorf("Wrong call count: %d", callCount)
+	}
+
+	callCount = 0
+
+	if err := retryhelper.RetryIf(context.Background(), func() error {
+		callCount++
+
+		if callCount == 1 {
+			return aoserrors.Wrap(aoserrors.ErrTimeout)
+		}
+
+		return aoserrors.New("not retryable error")
+	}, nil, aoserrors.IsRetryable, 3, retryDelay, 0); err == nil {
+		t.Error("Error expected")
+	}
+
+	if callCount != 2 {
+		t.Errorf("Wrong call count: %d", callCount)
+	}
+}
+
+func TestRetryWithFakeClock(t *testing.T) {
+	fakeClock := testtools.NewFakeClock(time.Unix(0, 0))
+	ctx := clock.ContextWithClock(context.Background(), fakeClock)
+	expectedDelays := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}
+	timeStamps := []time.Time{}
+	result := make(chan error, 1)
+
+	go func() {
+		result <- retryhelper.Retry(ctx, func() error {
+			timeStamps = append(timeStamps, fakeClock.Now())
+
+			if len(timeStamps) <= len(expectedDelays) {
+				return aoserrors.New("some error occurs")
+			}
+
+			return nil
+		}, nil, 0, 1*time.Second, 4*time.Second)
+	}()
+
+	for _, delay := range expectedDelays {
+		if err := fakeClock.WaitForWaiters(1, 5*time.Second); err != nil {
+			t.Fatalf("Retry is not waiting: %v", err)
+		}
+
+		fakeClock.Advance(delay)
+	}
+
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("Retry error: %v", err)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait retry timeout")
+	}
+
+	for i, delay := range expectedDelays {
+		if timeStamps[i+1].Sub(timeStamps[i]) != delay {
+			t.Errorf("Wrong retry delay: %v", timeStamps[i+1].Sub(timeStamps[i]))
+		}
+	}
+}
+
 /***********************************************************************************************************************
  * Private
  **********************************************************************************************************************/
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testtools
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/clock"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const waitersPollPeriod = time.Millisecond
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// FakeClock deterministic clock. Time is changed only by Advance and Set, timers and tickers are fired
+// synchronously in deadline order while time is advanced. AfterFunc callbacks are called from Advance and Set.
+type FakeClock struct {
+	sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	clock    *FakeClock
+	deadline time.Time
+	ticker   bool
+	period   time.Duration
+	channel  chan time.Time
+	callback func()
+}
+
+type fakeTimer struct {
+	*fakeWaiter
+}
+
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewFakeClock creates fake clock with start time.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns current fake time.
+func (fakeClock *FakeClock) Now() time.Time {
+	fakeClock.Lock()
+	defer fakeClock.Unlock()
+
+	return fakeClock.now
+}
+
+// After returns channel which receives fake time once duration is elapsed.
+func (fakeClock *FakeClock) After(duration time.Duration) <-chan time.Time {
+	return fakeClock.NewTimer(duration).C()
+}
+
+// NewTimer creates fake timer.
+func (fakeClock *FakeClock) NewTimer(duration time.Duration) clock.Timer {
+	timer := fakeTimer{&fakeWaiter{clock: fakeClock, channel: make(chan time.Time, 1)}}
+
+	timer.reset(duration)
+
+	return timer
+}
+
+// NewTicker creates fake ticker.
+func (fakeClock *FakeClock) NewTicker(period time.Duration) clock.Ticker {
+	if period <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	ticker := fakeTicker{&fakeWaiter{clock: fakeClock, ticker: true, channel: make(chan time.Time, 1)}}
+
+	ticker.reset(period)
+
+	return ticker
+}
+
+// AfterFunc creates fake timer which calls callback once duration is elapsed.
+func (fakeClock *FakeClock) AfterFunc(duration time.Duration, callback func()) clock.Timer {
+	timer := fakeTimer{&fakeWaiter{clock: fakeClock, callback: callback}}
+
+	timer.reset(duration)
+
+	return timer
+}
+
+// Advance advances fake time by duration and fires expired timers and tickers.
+func (fakeClock *FakeClock) Advance(duration time.Duration) {
+	fakeClock.Set(fakeClock.Now().Add(duration))
+}
+
+// Set sets fake time and fires expired timers and tickers. Time can't be moved backward.
+func (fakeClock *FakeClock) Set(now time.Time) {
+	fakeClock.Lock()
+	defer fakeClock.Unlock()
+
+	for {
+		waiter := fakeClock.nextExpired(now)
+		if waiter == nil {
+			break
+		}
+
+		fakeClock.now = waiter.deadline
+
+		if callback := waiter.fire(); callback != nil {
+			fakeClock.Unlock()
+			callback()
+			fakeClock.Lock()
+		}
+	}
+
+	if now.After(fakeClock.now) {
+		fakeClock.now = now
+	}
+}
+
+// Waiters returns number of active timers and tickers.
+func (fakeClock *FakeClock) Waiters() int {
+	fakeClock.Lock()
+	defer fakeClock.Unlock()
+
+	return len(fakeClock.waiters)
+}
+
+// WaitForWaiters waits until clock has at least count active timers and tickers. It is used to make sure tested code
+// is waiting for the clock before advancing it.
+func (fakeClock *FakeClock) WaitForWaiters(count int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for fakeClock.Waiters() < count {
+		if time.Now().After(deadline) {
+			return aoserrors.Errorf("wait for %d clock waiters timeout", count)
+		}
+
+		time.Sleep(waitersPollPeriod)
+	}
+
+	return nil
+}
+
+// Stop stops timer. It returns true if timer was active.
+func (timer fakeTimer) Stop() bool {
+	return timer.stop()
+}
+
+// Reset changes timer duration. It returns true if timer was active.
+func (timer fakeTimer) Reset(duration time.Duration) bool {
+	return timer.reset(duration)
+}
+
+// Stop stops ticker.
+func (ticker fakeTicker) Stop() {
+	ticker.stop()
+}
+
+// Reset changes ticker period.
+func (ticker fakeTicker) Reset(period time.Duration) {
+	ticker.reset(period)
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (fakeClock *FakeClock) nextExpired(now time.Time) (expired *fakeWaiter) {
+	for _, waiter := range fakeClock.waiters {
+		if waiter.deadline.After(now) {
+			continue
+		}
+
+		if expired == nil || waiter.deadline.Before(expired.deadline) {
+			expired = waiter
+		}
+	}
+
+	return expired
+}
+
+func (fakeClock *FakeClock) removeWaiter(waiter *fakeWaiter) bool {
+	index := slices.Index(fakeClock.waiters, waiter)
+	if index == -1 {
+		return false
+	}
+
+	fakeClock.waiters = slices.Delete(fakeClock.waiters, index, index+1)
+
+	return true
+}
+
+func (waiter *fakeWaiter) C() <-chan time.Time {
+	return waiter.channel
+}
+
+func (waiter *fakeWaiter) stop() bool {
+	waiter.clock.Lock()
+	defer waiter.clock.Unlock()
+
+	return waiter.clock.removeWaiter(waiter)
+}
+
+func (waiter *fakeWaiter) reset(duration time.Duration) bool {
+	waiter.clock.Lock()
+	defer waiter.clock.Unlock()
+
+	active := waiter.clock.removeWaiter(waiter)
+
+	if waiter.ticker {
+		waiter.period = duration
+	}
+
+	waiter.deadline = waiter.clock.now.Add(duration)
+	waiter.clock.waiters = append(waiter.clock.waiters, waiter)
+
+	return active
+}
+
+func (waiter *fakeWaiter) fire() (callback func()) {
+	if waiter.ticker {
+		waiter.deadline = waiter.deadline.Add(waiter.period)
+	} else {
+		waiter.clock.removeWaiter(waiter)
+	}
+
+	if waiter.callback != nil {
+		return waiter.callback
+	}
+
+	// Like system timers, ticks are dropped if previous one is not received yet.
+	select {
+	case waiter.channel <- waiter.clock.now:
+
+	default:
+	}
+
+	return nil
+}
@@ -24,7 +24,6 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
-	"encoding/pem"
 	"math/big"
 	"net"
 	"time"
@@ -68,12 +67,14 @@ var DefaultCertificateTemplate = x509.Certificate{ //nolint:gochecknoglobals
 
 // CreateCSR creates CSR.
 func CreateCSR(key crypto.PrivateKey) (csr []byte, err error) {
-	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
-	if err != nil {
-		return nil, aoserrors.Wrap(err)
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, aoserrors.New("key doesn't implement signer")
 	}
 
-	csr = pem.EncodeToMemory(&pem.Block{Type: cryptutils.PEMBlockCertificateRequest, Bytes: csrDER})
+	if csr, err = cryptutils.CreateCSR(signer, cryptutils.CSRParams{}); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
 
 	return csr, nil
 }
@@ -0,0 +1,374 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testtools
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// GRPCExpectation scripted gRPC request/response expectation.
+// Expectations handle methods which have no registered service implementation.
+type GRPCExpectation struct {
+	// Method full method name, e.g. "/package.Service/Method". Empty matches any method.
+	Method string
+	// Request prototype used to decode request. If nil, request is not decoded and Match is not called.
+	Request proto.Message
+	// Match checks decoded request. Nil matches any request.
+	Match func(request proto.Message) bool
+	// Responses messages sent back to client in order. Single response is expected for unary methods.
+	Responses []proto.Message
+	// Err status error returned to client.
+	Err error
+	// Fault fault injected while handling expectation.
+	Fault Fault
+}
+
+// GRPCMockServer gRPC mock server with scripted expectations.
+type GRPCMockServer struct {
+	sync.Mutex
+
+	TLS *HarnessTLS
+
+	grpcServer     *grpc.Server
+	memoryListener *MemoryListener
+	listener       *trackingListener
+	expectations   []GRPCExpectation
+	received       []string
+	unexpected     []string
+}
+
+type rawFrame []byte
+
+type harnessCodec struct{}
+
+type trackingListener struct {
+	net.Listener
+	sync.Mutex
+	connections map[net.Conn]struct{}
+}
+
+type trackedConn struct {
+	net.Conn
+	listener *trackingListener
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewGRPCMockServer creates gRPC mock server on in-memory listener. Use Dial to connect to it.
+// If withTLS is set, TLS fixtures are generated in dir.
+func NewGRPCMockServer(withTLS bool, dir string) (mockServer *GRPCMockServer, err error) {
+	mockServer = &GRPCMockServer{}
+
+	options := []grpc.ServerOption{
+		grpc.ForceServerCodec(harnessCodec{}),
+		grpc.UnknownServiceHandler(mockServer.handleStream),
+	}
+
+	if withTLS {
+		if mockServer.TLS, err = NewHarnessTLS(dir); err != nil {
+			return nil, err
+		}
+
+		tlsCert, err := mockServer.TLS.PKI.TLSCertificate(HarnessLeafName)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{tlsCert},
+			MinVersion:   tls.VersionTLS12,
+		})))
+	}
+
+	mockServer.memoryListener = NewMemoryListener()
+	mockServer.listener = &trackingListener{
+		Listener: mockServer.memoryListener, connections: make(map[net.Conn]struct{}),
+	}
+	mockServer.grpcServer = grpc.NewServer(options...)
+
+	return mockServer, nil
+}
+
+// Server returns underlying gRPC server to register service implementations. Services should be registered
+// before Start.
+func (mockServer *GRPCMockServer) Server() *grpc.Server {
+	return mockServer.grpcServer
+}
+
+// Start starts serving.
+func (mockServer *GRPCMockServer) Start() {
+	go func() {
+		_ = mockServer.grpcServer.Serve(mockServer.listener)
+	}()
+}
+
+// Close stops mock server.
+func (mockServer *GRPCMockServer) Close() {
+	mockServer.grpcServer.Stop()
+}
+
+// Dial creates client connection to mock server with transport credentials returned by ClientCredentials.
+func (mockServer *GRPCMockServer) Dial(options ...grpc.DialOption) (*grpc.ClientConn, error) {
+	options = append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return mockServer.memoryListener.DialContext(ctx, "memory", address)
+		}),
+		grpc.WithTransportCredentials(mockServer.ClientCredentials()),
+	}, options...)
+
+	conn, err := grpc.NewClient("passthrough:///localhost", options...)
+
+	return conn, aoserrors.Wrap(err)
+}
+
+// ClientCredentials returns transport credentials for connecting to mock server.
+func (mockServer *GRPCMockServer) ClientCredentials() credentials.TransportCredentials {
+	if mockServer.TLS == nil {
+		return insecure.NewCredentials()
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		RootCAs:    mockServer.TLS.PKI.RootPool(),
+		ServerName: "localhost",
+		MinVersion: tls.VersionTLS12,
+	})
+}
+
+// Expect appends scripted expectations. Expectations are handled in order.
+func (mockServer *GRPCMockServer) Expect(expectations ...GRPCExpectation) {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	mockServer.expectations = append(mockServer.expectations, expectations...)
+}
+
+// Received returns full method names of all received requests.
+func (mockServer *GRPCMockServer) Received() []string {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	return append([]string(nil), mockServer.received...)
+}
+
+// Verify checks that all expectations are met and no unexpected requests are received.
+func (mockServer *GRPCMockServer) Verify() error {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	if len(mockServer.expectations) != 0 {
+		return aoserrors.Errorf("%d expectations are not met", len(mockServer.expectations))
+	}
+
+	if len(mockServer.unexpected) != 0 {
+		return aoserrors.Errorf("unexpected request received: %s", mockServer.unexpected[0])
+	}
+
+	return nil
+}
+
+// DisconnectClients closes all client connections.
+func (mockServer *GRPCMockServer) DisconnectClients() {
+	mockServer.listener.closeConnections()
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (mockServer *GRPCMockServer) handleStream(srv any, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+
+	for {
+		var frame rawFrame
+
+		if err := stream.RecvMsg(&frame); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err //nolint:wrapcheck // return status error as is
+		}
+
+		if err := mockServer.handleRequest(stream, method, frame); err != nil {
+			return err
+		}
+	}
+}
+
+func (mockServer *GRPCMockServer) handleRequest(stream grpc.ServerStream, method string, frame rawFrame) error {
+	expectation, ok := mockServer.nextExpectation(method, frame)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unexpected request %s", method)
+	}
+
+	expectation.Fault.delay()
+
+	switch {
+	case expectation.Fault.Disconnect:
+		mockServer.DisconnectClients()
+
+		return status.Error(codes.Unavailable, "fault injected")
+
+	case expectation.Fault.Drop:
+		<-stream.Context().Done()
+
+		return status.FromContextError(stream.Context().Err()).Err()
+
+	case expectation.Fault.Malformed:
+		return stream.SendMsg(MalformedFrame) //nolint:wrapcheck // return status error as is
+	}
+
+	for _, response := range expectation.Responses {
+		if err := stream.SendMsg(response); err != nil {
+			return err //nolint:wrapcheck // return status error as is
+		}
+	}
+
+	if expectation.Err != nil {
+		return expectation.Err
+	}
+
+	return nil
+}
+
+func (mockServer *GRPCMockServer) nextExpectation(
+	method string, frame rawFrame,
+) (expectation GRPCExpectation, ok bool) {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	mockServer.received = append(mockServer.received, method)
+
+	if len(mockServer.expectations) == 0 || !mockServer.expectations[0].matches(method, frame) {
+		mockServer.unexpected = append(mockServer.unexpected, method)
+
+		return expectation, false
+	}
+
+	expectation = mockServer.expectations[0]
+	mockServer.expectations = mockServer.expectations[1:]
+
+	return expectation, true
+}
+
+func (expectation GRPCExpectation) matches(method string, frame rawFrame) bool {
+	if expectation.Method != "" && expectation.Method != method {
+		return false
+	}
+
+	if expectation.Request == nil {
+		return true
+	}
+
+	request := expectation.Request.ProtoReflect().New().Interface()
+
+	if err := proto.Unmarshal(frame, request); err != nil {
+		return false
+	}
+
+	return expectation.Match == nil || expectation.Match(request)
+}
+
+func (harnessCodec) Marshal(v any) ([]byte, error) {
+	switch value := v.(type) {
+	case rawFrame:
+		return value, nil
+
+	case []byte:
+		return value, nil
+
+	case proto.Message:
+		data, err := proto.Marshal(value)
+
+		return data, aoserrors.Wrap(err)
+
+	default:
+		return nil, aoserrors.Errorf("unsupported message type %T", v)
+	}
+}
+
+func (harnessCodec) Unmarshal(data []byte, v any) error {
+	switch value := v.(type) {
+	case *rawFrame:
+		*value = append(rawFrame(nil), data...)
+
+		return nil
+
+	case proto.Message:
+		return aoserrors.Wrap(proto.Unmarshal(data, value))
+
+	default:
+		return aoserrors.Errorf("unsupported message type %T", v)
+	}
+}
+
+func (harnessCodec) Name() string {
+	return "proto"
+}
+
+func (listener *trackingListener) Accept() (net.Conn, error) {
+	conn, err := listener.Listener.Accept()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // grpc checks listener errors
+	}
+
+	listener.Lock()
+	defer listener.Unlock()
+
+	listener.connections[conn] = struct{}{}
+
+	return &trackedConn{Conn: conn, listener: listener}, nil
+}
+
+func (listener *trackingListener) closeConnections() {
+	listener.Lock()
+	defer listener.Unlock()
+
+	for conn := range listener.connections {
+		conn.Close()
+		delete(listener.connections, conn)
+	}
+}
+
+func (conn *trackedConn) Close() error {
+	conn.listener.Lock()
+	delete(conn.listener.connections, conn.Conn)
+	conn.listener.Unlock()
+
+	return conn.Conn.Close() //nolint:wrapcheck // keep net errors as is
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testtools
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// HarnessLeafName name of PKI leaf certificate used by mock servers.
+const HarnessLeafName = "server"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// Fault fault injected by mock server while handling scripted expectation.
+type Fault struct {
+	// Delay delays response.
+	Delay time.Duration
+	// Drop drops request: no response is sent.
+	Drop bool
+	// Malformed sends malformed frame instead of response.
+	Malformed bool
+	// Disconnect closes client connection after request is received.
+	Disconnect bool
+}
+
+// MemoryListener in-memory listener: connections are created by DialContext as connected unix socket pairs, no
+// network or file system address is used.
+type MemoryListener struct {
+	connections chan net.Conn
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+type memoryAddr struct{}
+
+// HarnessTLS TLS fixtures used by mock servers.
+type HarnessTLS struct {
+	PKI      *PKI
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// MalformedFrame payload sent by mock servers on malformed fault.
+var MalformedFrame = []byte{0xff, 0xff, 0xff, 0xff, 0x0f, '{'} //nolint:gochecknoglobals
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewHarnessTLS generates test PKI and writes mock server TLS files into dir.
+// If dir is empty, temporary dir is created.
+func NewHarnessTLS(dir string) (harnessTLS *HarnessTLS, err error) {
+	if dir == "" {
+		if dir, err = os.MkdirTemp("", "aos_harness_"); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+	}
+
+	harnessTLS = &HarnessTLS{}
+
+	if harnessTLS.PKI, err = NewPKIBuilder().WithLeaf(HarnessLeafName).Build(); err != nil {
+		return nil, err
+	}
+
+	if harnessTLS.CAFile, harnessTLS.CertFile, harnessTLS.KeyFile, err = harnessTLS.PKI.WriteTLSFiles(
+		dir, HarnessLeafName); err != nil {
+		return nil, err
+	}
+
+	return harnessTLS, nil
+}
+
+// NewMemoryListener creates in-memory listener.
+func NewMemoryListener() *MemoryListener {
+	return &MemoryListener{connections: make(chan net.Conn), done: make(chan struct{})}
+}
+
+// Accept waits for and returns the next connection dialed to the listener.
+func (listener *MemoryListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-listener.connections:
+		return conn, nil
+
+	case <-listener.done:
+		return nil, net.ErrClosed //nolint:wrapcheck // servers check closed listener error
+	}
+}
+
+// Close closes the listener.
+func (listener *MemoryListener) Close() error {
+	listener.closeOnce.Do(func() { close(listener.done) })
+
+	return nil
+}
+
+// Addr returns listener address.
+func (listener *MemoryListener) Addr() net.Addr {
+	return memoryAddr{}
+}
+
+// DialContext connects to the listener. Network and address are ignored, the signature matches net.Dialer one.
+func (listener *MemoryListener) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	serverConn, err := newSocketConn(fds[0])
+	if err != nil {
+		syscall.Close(fds[1])
+
+		return nil, err
+	}
+
+	clientConn, err := newSocketConn(fds[1])
+	if err != nil {
+		serverConn.Close()
+
+		return nil, err
+	}
+
+	select {
+	case listener.connections <- serverConn:
+		return clientConn, nil
+
+	case <-listener.done:
+		err = aoserrors.Wrap(net.ErrClosed)
+
+	case <-ctx.Done():
+		err = aoserrors.Wrap(ctx.Err())
+	}
+
+	serverConn.Close()
+	clientConn.Close()
+
+	return nil, err
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newSocketConn(fd int) (net.Conn, error) {
+	file := os.NewFile(uintptr(fd), "memory")
+	defer file.Close()
+
+	conn, err := net.FileConn(file)
+
+	return conn, aoserrors.Wrap(err)
+}
+
+func (memoryAddr) Network() string {
+	return "memory"
+}
+
+func (memoryAddr) String() string {
+	return "memory"
+}
+
+func (fault Fault) delay() {
+	if fault.Delay > 0 {
+		time.Sleep(fault.Delay)
+	}
+}
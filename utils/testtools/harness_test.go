@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testtools_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/aosedge/aos_common/utils/testtools"
+	"github.com/aosedge/aos_common/wsclient"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	testMethod  = "/aos.test.Service/Echo"
+	waitTimeout = 5 * time.Second
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testWSMessage struct {
+	Type string `json:"type"`
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestGRPCMockServer(t *testing.T) {
+	mockServer, err := testtools.NewGRPCMockServer(true, t.TempDir())
+	if err != nil {
+		t.Fatalf("Can't create gRPC mock server: %v", err)
+	}
+	defer mockServer.Close()
+
+	mockServer.Expect(
+		testtools.GRPCExpectation{
+			Method:  testMethod,
+			Request: &durationpb.Duration{},
+			Match: func(request proto.Message) bool {
+				duration, ok := request.(*durationpb.Duration)
+
+				return ok && duration.GetSeconds() == 1
+			},
+			Responses: []proto.Message{&durationpb.Duration{Seconds: 2}},
+		},
+		testtools.GRPCExpectation{Method: testMethod, Err: status.Error(codes.NotFound, "not found")},
+		testtools.GRPCExpectation{Fault: testtools.Fault{Malformed: true}},
+		testtools.GRPCExpectation{Fault: testtools.Fault{Drop: true}},
+	)
+
+	mockServer.Start()
+
+	conn, err := mockServer.Dial()
+	if err != nil {
+		t.Fatalf("Can't connect to gRPC mock server: %v", err)
+	}
+	defer conn.Close()
+
+	var response durationpb.Duration
+
+	if err = conn.Invoke(context.Background(), testMethod, &durationpb.Duration{Seconds: 1}, &response); err != nil {
+		t.Fatalf("Can't invoke method: %v", err)
+	}
+
+	if response.GetSeconds() != 2 {
+		t.Errorf("Wrong response: %v", response.GetSeconds())
+	}
+
+	if err = conn.Invoke(context.Background(), testMethod, &durationpb.Duration{}, &response); status.Code(
+		err) != codes.NotFound {
+		t.Errorf("Wrong scripted error: %v", err)
+	}
+
+	if err = conn.Invoke(context.Background(), testMethod, &durationpb.Duration{}, &response); err == nil {
+		t.Error("Error expected due to malformed response")
+	}
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancelFunc()
+
+	if err = conn.Invoke(ctx, testMethod, &durationpb.Duration{}, &response); status.Code(
+		err) != codes.DeadlineExceeded {
+		t.Errorf("Wrong dropped request error: %v", err)
+	}
+
+	if err = mockServer.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+
+	if err = conn.Invoke(context.Background(), testMethod, &durationpb.Duration{}, &response); status.Code(
+		err) != codes.Unimplemented {
+		t.Errorf("Wrong unexpected request error: %v", err)
+	}
+
+	if err = mockServer.Verify(); err == nil {
+		t.Error("Verify should fail due to unexpected request")
+	}
+
+	if received := mockServer.Received(); len(received) != 5 || received[0] != testMethod {
+		t.Errorf("Wrong received requests: %v", received)
+	}
+}
+
+func TestWSMockServer(t *testing.T) {
+	mockServer, err := testtools.NewWSMockServer(t.TempDir())
+	if err != nil {
+		t.Fatalf("Can't create ws mock server: %v", err)
+	}
+	defer mockServer.Close()
+
+	mockServer.Expect(
+		testtools.WSExpectation{
+			Match: testtools.MatchMessage([]byte(`{"type":"ping"}`)), Responses: [][]byte{[]byte("pong")},
+		},
+		testtools.WSExpectation{Fault: testtools.Fault{Malformed: true}},
+	)
+
+	messageChannel := make(chan []byte, 1)
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: mockServer.TLS.CAFile, DialContext: mockServer.DialContext,
+	}, func(message []byte) {
+		messageChannel <- message
+	})
+	if err != nil {
+		t.Fatalf("Can't create ws client: %v", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(mockServer.URL); err != nil {
+		t.Fatalf("Can't connect to ws mock server: %v", err)
+	}
+
+	if err = mockServer.WaitClients(1, waitTimeout); err != nil {
+		t.Fatalf("Wait clients failed: %v", err)
+	}
+
+	for _, expected := range [][]byte{[]byte("pong"), testtools.MalformedFrame} {
+		if err = client.SendMessage(testWSMessage{Type: "ping"}); err != nil {
+			t.Fatalf("Can't send message: %v", err)
+		}
+
+		select {
+		case message := <-messageChannel:
+			if string(message) != string(expected) {
+				t.Errorf("Wrong message: %v", message)
+			}
+
+		case <-time.After(waitTimeout):
+			t.Fatal("Wait message timeout")
+		}
+	}
+
+	if err = mockServer.Send([]byte("notification")); err != nil {
+		t.Fatalf("Can't send notification: %v", err)
+	}
+
+	select {
+	case message := <-messageChannel:
+		if string(message) != "notification" {
+			t.Errorf("Wrong notification: %s", message)
+		}
+
+	case <-time.After(waitTimeout):
+		t.Fatal("Wait notification timeout")
+	}
+
+	if err = mockServer.Verify(); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
@@ -0,0 +1,712 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testtools
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // OCSP cert ID and responder key hashes are SHA-1
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	defaultPKIValidity = 24 * time.Hour
+	defaultCRLValidity = 24 * time.Hour
+	serialNumberBits   = 128
+)
+
+// OCSP response statuses (RFC 6960, 4.2.1).
+const (
+	OCSPSuccessful       = 0
+	OCSPMalformedRequest = 1
+	OCSPInternalError    = 2
+	OCSPUnauthorized     = 6
+)
+
+const maxOCSPRequestSize = 64 * 1024
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// CertOption modifies certificate generated by PKI builder.
+type CertOption func(template *x509.Certificate, spec *certSpec)
+
+// PKICert generated PKI certificate.
+type PKICert struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+	// Chain certificate chain from the certificate up to the root.
+	Chain []*x509.Certificate
+	// CRL DER encoded CRL issued by CA certificate.
+	CRL []byte
+}
+
+// PKI generated test PKI.
+type PKI struct {
+	Root          PKICert
+	Intermediates []PKICert
+	Leaves        map[string]PKICert
+}
+
+// PKIBuilder builds test PKI hierarchies.
+type PKIBuilder struct {
+	rootSpec          certSpec
+	intermediateSpecs []certSpec
+	leafSpecs         []certSpec
+	crlURL            string
+	ocspURL           string
+}
+
+type certSpec struct {
+	name    string
+	options []CertOption
+	revoked bool
+}
+
+// OCSP ASN.1 structures (RFC 6960).
+
+type ocspCertID struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	NameHash      []byte
+	IssuerKeyHash []byte
+	SerialNumber  *big.Int
+}
+
+type ocspRequest struct {
+	TBSRequest ocspTBSRequest
+}
+
+type ocspTBSRequest struct {
+	Version       int           `asn1:"explicit,tag:0,default:0,optional"`
+	RequestorName asn1.RawValue `asn1:"explicit,tag:1,optional"`
+	RequestList   []ocspRequestEntry
+}
+
+type ocspRequestEntry struct {
+	CertID ocspCertID
+}
+
+type ocspResponse struct {
+	Status        asn1.Enumerated
+	ResponseBytes ocspResponseBytes `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseBytes struct {
+	ResponseType asn1.ObjectIdentifier
+	Response     []byte
+}
+
+type ocspBasicResponse struct {
+	TBSResponseData    asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+}
+
+type ocspResponseData struct {
+	ResponderKeyHash []byte    `asn1:"explicit,tag:2"`
+	ProducedAt       time.Time `asn1:"generalized"`
+	Responses        []ocspSingleResponse
+}
+
+type ocspSingleResponse struct {
+	CertID     ocspCertID
+	Good       asn1.Flag       `asn1:"tag:0,optional"`
+	Revoked    ocspRevokedInfo `asn1:"tag:1,optional"`
+	ThisUpdate time.Time       `asn1:"generalized"`
+	NextUpdate time.Time       `asn1:"generalized,explicit,tag:0,optional"`
+}
+
+type ocspRevokedInfo struct {
+	RevocationTime time.Time `asn1:"generalized"`
+}
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals
+var (
+	oidSHA1                     = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256                   = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSignatureECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidOCSPBasicResponse        = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewPKIBuilder creates PKI builder which generates root CA with options.
+func NewPKIBuilder(rootOptions ...CertOption) *PKIBuilder {
+	return &PKIBuilder{rootSpec: certSpec{name: "Aos Test Root CA", options: rootOptions}}
+}
+
+// WithIntermediate adds intermediate CA level. Leaves are issued by the last intermediate CA.
+func (builder *PKIBuilder) WithIntermediate(options ...CertOption) *PKIBuilder {
+	builder.intermediateSpecs = append(builder.intermediateSpecs, certSpec{options: options})
+
+	return builder
+}
+
+// WithIntermediates adds count intermediate CA levels with default options.
+func (builder *PKIBuilder) WithIntermediates(count int) *PKIBuilder {
+	for range count {
+		builder.WithIntermediate()
+	}
+
+	return builder
+}
+
+// WithLeaf adds leaf certificate with name used as subject common name and PKI leaves key.
+func (builder *PKIBuilder) WithLeaf(name string, options ...CertOption) *PKIBuilder {
+	builder.leafSpecs = append(builder.leafSpecs, certSpec{name: name, options: options})
+
+	return builder
+}
+
+// WithCRLDistributionPoint sets CRL distribution point URL for issued certificates.
+func (builder *PKIBuilder) WithCRLDistributionPoint(crlURL string) *PKIBuilder {
+	builder.crlURL = crlURL
+
+	return builder
+}
+
+// WithOCSPServer sets OCSP responder URL for issued certificates.
+func (builder *PKIBuilder) WithOCSPServer(ocspURL string) *PKIBuilder {
+	builder.ocspURL = ocspURL
+
+	return builder
+}
+
+// Build generates PKI.
+func (builder *PKIBuilder) Build() (pki *PKI, err error) {
+	pki = &PKI{Leaves: make(map[string]PKICert)}
+
+	root, err := builder.generateCert(&builder.rootSpec, true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cas := []PKICert{root}
+
+	var revoked [][]*x509.Certificate
+
+	for i, spec := range builder.intermediateSpecs {
+		if spec.name == "" {
+			spec.name = "Aos Test Intermediate CA " + big.NewInt(int64(i+1)).String()
+		}
+
+		intermediate, err := builder.generateCert(&spec, true, &cas[len(cas)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		revoked = appendRevoked(revoked, len(cas)-1, spec, intermediate.Cert)
+		cas = append(cas, intermediate)
+	}
+
+	issuerIndex := len(cas) - 1
+
+	for _, spec := range builder.leafSpecs {
+		leaf, err := builder.generateCert(&spec, false, &cas[issuerIndex])
+		if err != nil {
+			return nil, err
+		}
+
+		revoked = appendRevoked(revoked, issuerIndex, spec, leaf.Cert)
+		pki.Leaves[spec.name] = leaf
+	}
+
+	for i := range cas {
+		var revokedCerts []*x509.Certificate
+
+		if i < len(revoked) {
+			revokedCerts = revoked[i]
+		}
+
+		if cas[i].CRL, err = createCRL(cas[i], revokedCerts); err != nil {
+			return nil, err
+		}
+	}
+
+	pki.Root = cas[0]
+	pki.Intermediates = cas[1:]
+
+	return pki, nil
+}
+
+// RootPool returns certificate pool with PKI root certificate.
+func (pki *PKI) RootPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+
+	pool.AddCert(pki.Root.Cert)
+
+	return pool
+}
+
+// TLSCertificate returns TLS certificate of leaf with chain up to the root (excluding).
+func (pki *PKI) TLSCertificate(name string) (tls.Certificate, error) {
+	leaf, ok := pki.Leaves[name]
+	if !ok {
+		return tls.Certificate{}, aoserrors.Errorf("leaf %s not found", name)
+	}
+
+	tlsCert := tls.Certificate{PrivateKey: leaf.Key, Leaf: leaf.Cert}
+
+	for _, cert := range leaf.Chain[:len(leaf.Chain)-1] {
+		tlsCert.Certificate = append(tlsCert.Certificate, cert.Raw)
+	}
+
+	return tlsCert, nil
+}
+
+// WriteTLSFiles writes PEM encoded root CA, leaf certificate chain and leaf key into dir.
+func (pki *PKI) WriteTLSFiles(dir, name string) (caFile, certFile, keyFile string, err error) {
+	leaf, ok := pki.Leaves[name]
+	if !ok {
+		return "", "", "", aoserrors.Errorf("leaf %s not found", name)
+	}
+
+	caFile = filepath.Join(dir, name+"-ca.pem")
+	certFile = filepath.Join(dir, name+"-cert.pem")
+	keyFile = filepath.Join(dir, name+"-key.pem")
+
+	if err = os.WriteFile(caFile, pem.EncodeToMemory(
+		&pem.Block{Type: "CERTIFICATE", Bytes: pki.Root.Cert.Raw}), 0o600); err != nil {
+		return "", "", "", aoserrors.Wrap(err)
+	}
+
+	var chainPEM []byte
+
+	for _, cert := range leaf.Chain[:len(leaf.Chain)-1] {
+		chainPEM = append(chainPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+
+	if err = os.WriteFile(certFile, chainPEM, 0o600); err != nil {
+		return "", "", "", aoserrors.Wrap(err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(leaf.Key)
+	if err != nil {
+		return "", "", "", aoserrors.Wrap(err)
+	}
+
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(
+		&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return "", "", "", aoserrors.Wrap(err)
+	}
+
+	return caFile, certFile, keyFile, nil
+}
+
+// CRLHandler returns HTTP handler which serves CRL of the leaves issuing CA.
+func (pki *PKI) CRLHandler() http.Handler {
+	issuer := pki.Root
+
+	if len(pki.Intermediates) != 0 {
+		issuer = pki.Intermediates[len(pki.Intermediates)-1]
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pkix-crl")
+		_, _ = w.Write(issuer.CRL)
+	})
+}
+
+// OCSPHandler returns HTTP handler which responds to OCSP requests (POST or GET) for certificates issued by PKI
+// CAs. Certificate status is taken from the issuer CRL and the response is signed by the issuer.
+func (pki *PKI) OCSPHandler() http.Handler {
+	cas := append([]PKICert{pki.Root}, pki.Intermediates...)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			request []byte
+			err     error
+		)
+
+		switch r.Method {
+		case http.MethodPost:
+			request, err = io.ReadAll(io.LimitReader(r.Body, maxOCSPRequestSize))
+
+		case http.MethodGet:
+			var encoded string
+
+			escapedPath := r.URL.EscapedPath()
+
+			if encoded, err = url.PathUnescape(escapedPath[strings.LastIndex(escapedPath, "/")+1:]); err == nil {
+				request, err = base64.StdEncoding.DecodeString(encoded)
+			}
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		response := createOCSPResponse(cas, request, err)
+
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		_, _ = w.Write(response)
+	})
+}
+
+// Expired makes certificate expired.
+func Expired() CertOption {
+	return func(template *x509.Certificate, spec *certSpec) {
+		template.NotBefore = time.Now().Add(-2 * defaultPKIValidity)
+		template.NotAfter = time.Now().Add(-defaultPKIValidity)
+	}
+}
+
+// NotYetValid makes certificate not yet valid.
+func NotYetValid() CertOption {
+	return func(template *x509.Certificate, spec *certSpec) {
+		template.NotBefore = time.Now().Add(defaultPKIValidity)
+		template.NotAfter = time.Now().Add(2 * defaultPKIValidity)
+	}
+}
+
+// WithValidity sets certificate validity period.
+func WithValidity(notBefore, notAfter time.Time) CertOption {
+	return func(template *x509.Certificate, spec *certSpec) {
+		template.NotBefore = notBefore
+		template.NotAfter = notAfter
+	}
+}
+
+// Revoked adds certificate to the issuer CRL.
+func Revoked() CertOption {
+	return func(template *x509.Certificate, spec *certSpec) {
+		spec.revoked = true
+	}
+}
+
+// WithKeyUsage sets certificate key usage and extended key usages.
+func WithKeyUsage(keyUsage x509.KeyUsage, extKeyUsages ...x509.ExtKeyUsage) CertOption {
+	return func(template *x509.Certificate, spec *certSpec) {
+		template.KeyUsage = keyUsage
+		template.ExtKeyUsage = extKeyUsages
+	}
+}
+
+// WithSubject sets certificate subject.
+func WithSubject(subject pkix.Name) CertOption {
+	return func(template *x509.Certificate, spec *certSpec) {
+		template.Subject = subject
+	}
+}
+
+// WithDNSNames sets certificate DNS names.
+func WithDNSNames(dnsNames ...string) CertOption {
+	return func(template *x509.Certificate, spec *certSpec) {
+		template.DNSNames = dnsNames
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (builder *PKIBuilder) generateCert(spec *certSpec, isCA bool, issuer *PKICert) (pkiCert PKICert, err error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return pkiCert, aoserrors.Wrap(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: spec.name, Organization: []string{"EPAM"}},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(defaultPKIValidity),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth}
+		template.DNSNames = DefaultCertificateTemplate.DNSNames
+		template.IPAddresses = DefaultCertificateTemplate.IPAddresses
+	}
+
+	if issuer != nil && builder.crlURL != "" {
+		template.CRLDistributionPoints = []string{builder.crlURL}
+	}
+
+	if issuer != nil && builder.ocspURL != "" {
+		template.OCSPServer = []string{builder.ocspURL}
+	}
+
+	for _, option := range spec.options {
+		option(template, spec)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return pkiCert, aoserrors.Wrap(err)
+	}
+
+	parent, parentKey := template, crypto.Signer(key)
+
+	if issuer != nil {
+		parent, parentKey = issuer.Cert, issuer.Key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return pkiCert, aoserrors.Wrap(err)
+	}
+
+	if pkiCert.Cert, err = x509.ParseCertificate(der); err != nil {
+		return pkiCert, aoserrors.Wrap(err)
+	}
+
+	pkiCert.Key = key
+	pkiCert.Chain = []*x509.Certificate{pkiCert.Cert}
+
+	if issuer != nil {
+		pkiCert.Chain = append(pkiCert.Chain, issuer.Chain...)
+	}
+
+	return pkiCert, nil
+}
+
+func appendRevoked(revoked [][]*x509.Certificate, issuerIndex int, spec certSpec,
+	cert *x509.Certificate,
+) [][]*x509.Certificate {
+	if !spec.revoked {
+		return revoked
+	}
+
+	for len(revoked) <= issuerIndex {
+		revoked = append(revoked, nil)
+	}
+
+	revoked[issuerIndex] = append(revoked[issuerIndex], cert)
+
+	return revoked
+}
+
+func createCRL(ca PKICert, revokedCerts []*x509.Certificate) ([]byte, error) {
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Minute),
+		NextUpdate: time.Now().Add(defaultCRLValidity),
+	}
+
+	for _, cert := range revokedCerts {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries,
+			x509.RevocationListEntry{SerialNumber: cert.SerialNumber, RevocationTime: time.Now().Add(-time.Minute)})
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, template, ca.Cert, ca.Key)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return crl, nil
+}
+
+func createOCSPResponse(cas []PKICert, request []byte, requestErr error) []byte {
+	if requestErr != nil {
+		return marshalOCSPStatus(OCSPMalformedRequest)
+	}
+
+	var ocspReq ocspRequest
+
+	if rest, err := asn1.Unmarshal(request, &ocspReq); err != nil || len(rest) != 0 ||
+		len(ocspReq.TBSRequest.RequestList) != 1 {
+		return marshalOCSPStatus(OCSPMalformedRequest)
+	}
+
+	certID := ocspReq.TBSRequest.RequestList[0].CertID
+
+	for _, ca := range cas {
+		matched, err := matchOCSPIssuer(certID, ca.Cert)
+		if err != nil {
+			return marshalOCSPStatus(OCSPMalformedRequest)
+		}
+
+		if !matched {
+			continue
+		}
+
+		response, err := signOCSPResponse(ca, certID)
+		if err != nil {
+			return marshalOCSPStatus(OCSPInternalError)
+		}
+
+		return response
+	}
+
+	return marshalOCSPStatus(OCSPUnauthorized)
+}
+
+func marshalOCSPStatus(status asn1.Enumerated) []byte {
+	response, _ := asn1.Marshal(ocspResponse{Status: status})
+
+	return response
+}
+
+func matchOCSPIssuer(certID ocspCertID, issuer *x509.Certificate) (bool, error) {
+	hashFunc, err := getOCSPHashFunc(certID.HashAlgorithm)
+	if err != nil {
+		return false, err
+	}
+
+	keyHash, err := getPublicKeyHash(issuer, hashFunc)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(certID.NameHash, hashFunc(issuer.RawSubject)) && bytes.Equal(certID.IssuerKeyHash, keyHash), nil
+}
+
+func getOCSPHashFunc(algorithm pkix.AlgorithmIdentifier) (func(data []byte) []byte, error) {
+	switch {
+	case algorithm.Algorithm.Equal(oidSHA1):
+		return func(data []byte) []byte {
+			sum := sha1.Sum(data) //nolint:gosec
+
+			return sum[:]
+		}, nil
+
+	case algorithm.Algorithm.Equal(oidSHA256):
+		return func(data []byte) []byte {
+			sum := sha256.Sum256(data)
+
+			return sum[:]
+		}, nil
+
+	default:
+		return nil, aoserrors.Errorf("unsupported OCSP hash algorithm: %v", algorithm.Algorithm)
+	}
+}
+
+func getPublicKeyHash(cert *x509.Certificate, hashFunc func(data []byte) []byte) ([]byte, error) {
+	var publicKeyInfo subjectPublicKeyInfo
+
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return hashFunc(publicKeyInfo.PublicKey.RightAlign()), nil
+}
+
+func signOCSPResponse(ca PKICert, certID ocspCertID) ([]byte, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	singleResponse := ocspSingleResponse{
+		CertID:     certID,
+		Good:       true,
+		ThisUpdate: now.Add(-time.Minute),
+		NextUpdate: now.Add(defaultCRLValidity),
+	}
+
+	crl, err := x509.ParseRevocationList(ca.CRL)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(certID.SerialNumber) == 0 {
+			singleResponse.Good = false
+			singleResponse.Revoked = ocspRevokedInfo{RevocationTime: entry.RevocationTime.UTC().Truncate(time.Second)}
+
+			break
+		}
+	}
+
+	// Responder is identified by SHA-1 hash of its public key
+	sha1Func, err := getOCSPHashFunc(pkix.AlgorithmIdentifier{Algorithm: oidSHA1})
+	if err != nil {
+		return nil, err
+	}
+
+	responderKeyHash, err := getPublicKeyHash(ca.Cert, sha1Func)
+	if err != nil {
+		return nil, err
+	}
+
+	tbsResponseData, err := asn1.Marshal(ocspResponseData{
+		ResponderKeyHash: responderKeyHash,
+		ProducedAt:       now,
+		Responses:        []ocspSingleResponse{singleResponse},
+	})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	digest := sha256.Sum256(tbsResponseData)
+
+	// PKI keys are ECDSA keys
+	signature, err := ca.Key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	basicResponse, err := asn1.Marshal(ocspBasicResponse{
+		TBSResponseData:    asn1.RawValue{FullBytes: tbsResponseData},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSignatureECDSAWithSHA256},
+		Signature:          asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	response, err := asn1.Marshal(ocspResponse{
+		Status:        OCSPSuccessful,
+		ResponseBytes: ocspResponseBytes{ResponseType: oidOCSPBasicResponse, Response: basicResponse},
+	})
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return response, nil
+}
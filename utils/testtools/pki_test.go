@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testtools_test
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // OCSP cert ID uses SHA-1
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/testtools"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	ocspStatusGood    = 0
+	ocspStatusRevoked = 1
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+type testOCSPCertID struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	NameHash      []byte
+	IssuerKeyHash []byte
+	SerialNumber  *big.Int
+}
+
+type testOCSPRequest struct {
+	TBSRequest struct {
+		RequestList []struct {
+			CertID testOCSPCertID
+		}
+	}
+}
+
+type testOCSPResponse struct {
+	Status        asn1.Enumerated
+	ResponseBytes struct {
+		ResponseType asn1.ObjectIdentifier
+		Response     []byte
+	} `asn1:"explicit,tag:0,optional"`
+}
+
+type testOCSPBasicResponse struct {
+	TBSResponseData    asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+}
+
+type testOCSPResponseData struct {
+	ResponderID asn1.RawValue
+	ProducedAt  asn1.RawValue
+	Responses   []struct {
+		CertID     testOCSPCertID
+		CertStatus asn1.RawValue
+	}
+}
+
+/***********************************************************************************************************************
+ * Tests
+ **********************************************************************************************************************/
+
+func TestOCSPResponder(t *testing.T) {
+	serveMux := http.NewServeMux()
+
+	server := httptest.NewServer(serveMux)
+	defer server.Close()
+
+	pki, err := testtools.NewPKIBuilder().WithIntermediates(1).WithOCSPServer(server.URL).
+		WithLeaf("good").WithLeaf("revoked", testtools.Revoked()).Build()
+	if err != nil {
+		t.Fatalf("Can't build PKI: %v", err)
+	}
+
+	otherPKI, err := testtools.NewPKIBuilder().WithLeaf("other").Build()
+	if err != nil {
+		t.Fatalf("Can't build PKI: %v", err)
+	}
+
+	serveMux.Handle("/", pki.OCSPHandler())
+
+	issuer := pki.Intermediates[0].Cert
+
+	if leaf := pki.Leaves["good"].Cert; len(leaf.OCSPServer) != 1 || leaf.OCSPServer[0] != server.URL {
+		t.Errorf("Wrong OCSP server: %v", leaf.OCSPServer)
+	}
+
+	testData := []struct {
+		cert           *x509.Certificate
+		issuer         *x509.Certificate
+		usePost        bool
+		expectedStatus int
+	}{
+		{cert: pki.Leaves["good"].Cert, issuer: issuer, usePost: true, expectedStatus: ocspStatusGood},
+		{cert: pki.Leaves["good"].Cert, issuer: issuer, expectedStatus: ocspStatusGood},
+		{cert: pki.Leaves["revoked"].Cert, issuer: issuer, usePost: true, expectedStatus: ocspStatusRevoked},
+		{cert: pki.Leaves["revoked"].Cert, issuer: issuer, expectedStatus: ocspStatusRevoked},
+		{cert: issuer, issuer: pki.Root.Cert, usePost: true, expectedStatus: ocspStatusGood},
+	}
+
+	for _, data := range testData {
+		request, err := createOCSPRequest(data.cert, data.issuer)
+		if err != nil {
+			t.Fatalf("Can't create OCSP request: %v", err)
+		}
+
+		response, err := sendOCSPRequest(server.URL, request, data.usePost)
+		if err != nil {
+			t.Fatalf("Can't send OCSP request: %v", err)
+		}
+
+		certStatus, err := parseOCSPResponse(response, data.issuer, data.cert.SerialNumber)
+		if err != nil {
+			t.Fatalf("Can't parse OCSP response: %v", err)
+		}
+
+		if certStatus != data.expectedStatus {
+			t.Errorf("Wrong certificate %s status: %d", data.cert.Subject.CommonName, certStatus)
+		}
+	}
+
+	// Certificate of unknown issuer
+
+	request, err := createOCSPRequest(otherPKI.Leaves["other"].Cert, otherPKI.Root.Cert)
+	if err != nil {
+		t.Fatalf("Can't create OCSP request: %v", err)
+	}
+
+	for request, expectedStatus := range map[string]asn1.Enumerated{
+		string(request): testtools.OCSPUnauthorized,
+		"malformed":     testtools.OCSPMalformedRequest,
+	} {
+		response, err := sendOCSPRequest(server.URL, []byte(request), true)
+		if err != nil {
+			t.Fatalf("Can't send OCSP request: %v", err)
+		}
+
+		var ocspResponse testOCSPResponse
+
+		if _, err = asn1.Unmarshal(response, &ocspResponse); err != nil {
+			t.Fatalf("Can't parse OCSP response: %v", err)
+		}
+
+		if ocspResponse.Status != expectedStatus {
+			t.Errorf("Wrong OCSP response status: %d, expected: %d", ocspResponse.Status, expectedStatus)
+		}
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func createOCSPRequest(cert, issuer *x509.Certificate) ([]byte, error) {
+	var publicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	nameHash := sha1.Sum(issuer.RawSubject)                   //nolint:gosec
+	keyHash := sha1.Sum(publicKeyInfo.PublicKey.RightAlign()) //nolint:gosec
+
+	var request testOCSPRequest
+
+	request.TBSRequest.RequestList = append(request.TBSRequest.RequestList, struct{ CertID testOCSPCertID }{
+		CertID: testOCSPCertID{
+			HashAlgorithm: pkix.AlgorithmIdentifier{
+				Algorithm: asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}, Parameters: asn1.NullRawValue,
+			},
+			NameHash:      nameHash[:],
+			IssuerKeyHash: keyHash[:],
+			SerialNumber:  cert.SerialNumber,
+		},
+	})
+
+	data, err := asn1.Marshal(request)
+
+	return data, aoserrors.Wrap(err)
+}
+
+func sendOCSPRequest(serverURL string, request []byte, usePost bool) ([]byte, error) {
+	var (
+		response *http.Response
+		err      error
+	)
+
+	if usePost {
+		response, err = http.Post(serverURL, "application/ocsp-request", bytes.NewReader(request))
+	} else {
+		response, err = http.Get(serverURL + "/" + url.PathEscape(base64.StdEncoding.EncodeToString(request)))
+	}
+
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, aoserrors.Errorf("wrong HTTP status: %d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(response.Body)
+
+	return data, aoserrors.Wrap(err)
+}
+
+func parseOCSPResponse(response []byte, issuer *x509.Certificate, serialNumber *big.Int) (certStatus int, err error) {
+	var ocspResponse testOCSPResponse
+
+	if _, err = asn1.Unmarshal(response, &ocspResponse); err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	if ocspResponse.Status != testtools.OCSPSuccessful {
+		return 0, aoserrors.Errorf("wrong OCSP response status: %d", ocspResponse.Status)
+	}
+
+	var basicResponse testOCSPBasicResponse
+
+	if _, err = asn1.Unmarshal(ocspResponse.ResponseBytes.Response, &basicResponse); err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	if err = issuer.CheckSignature(x509.ECDSAWithSHA256, basicResponse.TBSResponseData.FullBytes,
+		basicResponse.Signature.RightAlign()); err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	var responseData testOCSPResponseData
+
+	if _, err = asn1.Unmarshal(basicResponse.TBSResponseData.FullBytes, &responseData); err != nil {
+		return 0, aoserrors.Wrap(err)
+	}
+
+	if len(responseData.Responses) != 1 || responseData.Responses[0].CertID.SerialNumber.Cmp(serialNumber) != 0 {
+		return 0, aoserrors.New("wrong OCSP single response")
+	}
+
+	return responseData.Responses[0].CertStatus.Tag, nil
+}
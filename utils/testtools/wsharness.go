@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testtools
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/wsserver"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// WSExpectation scripted websocket request/response expectation.
+type WSExpectation struct {
+	// Match checks received message. Nil matches any message.
+	Match func(message []byte) bool
+	// Responses messages sent back to client in order.
+	Responses [][]byte
+	// Fault fault injected while handling expectation.
+	Fault Fault
+}
+
+// WSMockServer websocket mock server with scripted expectations.
+type WSMockServer struct {
+	sync.Mutex
+
+	URL string
+	TLS *HarnessTLS
+
+	server       *wsserver.Server
+	listener     *MemoryListener
+	expectations []WSExpectation
+	received     [][]byte
+	unexpected   [][]byte
+	clientCond   *sync.Cond
+	clients      int
+}
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// MatchMessage returns matcher which compares received message with expected one.
+func MatchMessage(expected []byte) func(message []byte) bool {
+	return func(message []byte) bool {
+		return bytes.Equal(message, expected)
+	}
+}
+
+// NewWSMockServer creates websocket mock server on in-memory listener with TLS fixtures generated in dir.
+// Clients should connect to URL using DialContext and TLS.CAFile.
+func NewWSMockServer(dir string) (mockServer *WSMockServer, err error) {
+	mockServer = &WSMockServer{URL: "wss://localhost", listener: NewMemoryListener()}
+	mockServer.clientCond = sync.NewCond(&mockServer.Mutex)
+
+	if mockServer.TLS, err = NewHarnessTLS(dir); err != nil {
+		return nil, err
+	}
+
+	if mockServer.server, err = wsserver.NewWithParam("WSMockServer", "", mockServer.TLS.CertFile,
+		mockServer.TLS.KeyFile, wsserver.ServerParam{Listener: mockServer.listener}, mockServer); err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	return mockServer, nil
+}
+
+// Close closes mock server.
+func (mockServer *WSMockServer) Close() {
+	mockServer.server.Close()
+	mockServer.listener.Close()
+}
+
+// DialContext connects to mock server in-memory listener.
+func (mockServer *WSMockServer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return mockServer.listener.DialContext(ctx, network, address)
+}
+
+// Expect appends scripted expectations. Expectations are handled in order.
+func (mockServer *WSMockServer) Expect(expectations ...WSExpectation) {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	mockServer.expectations = append(mockServer.expectations, expectations...)
+}
+
+// Received returns all received messages.
+func (mockServer *WSMockServer) Received() [][]byte {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	return append([][]byte(nil), mockServer.received...)
+}
+
+// Verify checks that all expectations are met and no unexpected messages are received.
+func (mockServer *WSMockServer) Verify() error {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	if len(mockServer.expectations) != 0 {
+		return aoserrors.Errorf("%d expectations are not met", len(mockServer.expectations))
+	}
+
+	if len(mockServer.unexpected) != 0 {
+		return aoserrors.Errorf("unexpected message received: %s", mockServer.unexpected[0])
+	}
+
+	return nil
+}
+
+// WaitClients waits until count clients are connected.
+func (mockServer *WSMockServer) WaitClients(count int, timeout time.Duration) error {
+	timer := time.AfterFunc(timeout, func() {
+		mockServer.Lock()
+		defer mockServer.Unlock()
+
+		mockServer.clientCond.Broadcast()
+	})
+	defer timer.Stop()
+
+	deadline := time.Now().Add(timeout)
+
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	for mockServer.clients < count {
+		if !time.Now().Before(deadline) {
+			return aoserrors.New("wait clients timeout")
+		}
+
+		mockServer.clientCond.Wait()
+	}
+
+	return nil
+}
+
+// Send sends message to all connected clients.
+func (mockServer *WSMockServer) Send(message []byte) error {
+	for _, client := range mockServer.server.GetClients() {
+		if err := client.SendMessage(websocket.TextMessage, message); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// ClientConnected websocket client connected handler.
+func (mockServer *WSMockServer) ClientConnected(client *wsserver.Client) {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	mockServer.clients++
+	mockServer.clientCond.Broadcast()
+}
+
+// ClientDisconnected websocket client disconnected handler.
+func (mockServer *WSMockServer) ClientDisconnected(client *wsserver.Client) {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	mockServer.clients--
+	mockServer.clientCond.Broadcast()
+}
+
+// ProcessMessage handles received message according to scripted expectations.
+func (mockServer *WSMockServer) ProcessMessage(
+	client *wsserver.Client, messageType int, message []byte,
+) (response []byte, err error) {
+	expectation, ok := mockServer.nextExpectation(message)
+	if !ok {
+		return nil, nil
+	}
+
+	expectation.Fault.delay()
+
+	switch {
+	case expectation.Fault.Disconnect:
+		return nil, aoserrors.Wrap(client.SendMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "fault injected")))
+
+	case expectation.Fault.Drop:
+		return nil, nil
+
+	case expectation.Fault.Malformed:
+		return nil, aoserrors.Wrap(client.SendMessage(messageType, MalformedFrame))
+	}
+
+	for _, response := range expectation.Responses {
+		if err := client.SendMessage(messageType, response); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
+	}
+
+	return nil, nil
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (mockServer *WSMockServer) nextExpectation(message []byte) (expectation WSExpectation, ok bool) {
+	mockServer.Lock()
+	defer mockServer.Unlock()
+
+	mockServer.received = append(mockServer.received, message)
+
+	if len(mockServer.expectations) == 0 ||
+		(mockServer.expectations[0].Match != nil && !mockServer.expectations[0].Match(message)) {
+		mockServer.unexpected = append(mockServer.unexpected, message)
+
+		return expectation, false
+	}
+
+	expectation = mockServer.expectations[0]
+	mockServer.expectations = mockServer.expectations[1:]
+
+	return expectation, true
+}
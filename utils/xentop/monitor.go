@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xentop
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	// frameFlushTimeout time after last received line when iteration is considered complete. xentop writes whole
+	// iteration at once, so it is much less than poll interval.
+	frameFlushTimeout = 100 * time.Millisecond
+	restartDelay      = 1 * time.Second
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// StreamCommand command which output is read continuously.
+type StreamCommand interface {
+	StdoutPipe() (io.ReadCloser, error)
+	Start() error
+	Wait() error
+}
+
+// Monitor runs single xentop process in continuous batch mode and publishes parsed domain infos of each iteration
+// to subscribers.
+type Monitor struct {
+	sync.Mutex
+
+	interval       time.Duration
+	last           map[string]SystemInfo
+	subscribers    map[chan map[string]SystemInfo]struct{}
+	cancelFunction context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+/***********************************************************************************************************************
+ * Variable
+ **********************************************************************************************************************/
+
+// StreamExecContext global variable are used to be able to mocking the continuous xentop in tests.
+var StreamExecContext = newExecStreamCommander //nolint:gochecknoglobals
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// NewMonitor creates xentop monitor with poll interval.
+func NewMonitor(interval time.Duration) (*Monitor, error) {
+	if interval <= 0 {
+		return nil, aoserrors.New("invalid xentop interval")
+	}
+
+	monitor := &Monitor{
+		interval:    interval,
+		subscribers: make(map[chan map[string]SystemInfo]struct{}),
+	}
+
+	ctx, cancelFunction := context.WithCancel(context.Background())
+
+	monitor.cancelFunction = cancelFunction
+
+	monitor.wg.Add(1)
+
+	go monitor.run(ctx)
+
+	return monitor, nil
+}
+
+// Close stops xentop process and closes all subscription channels.
+func (monitor *Monitor) Close() {
+	monitor.cancelFunction()
+	monitor.wg.Wait()
+
+	monitor.Lock()
+	defer monitor.Unlock()
+
+	for subscriber := range monitor.subscribers {
+		close(subscriber)
+	}
+
+	monitor.subscribers = make(map[chan map[string]SystemInfo]struct{})
+}
+
+// Last returns domain infos of the last iteration.
+func (monitor *Monitor) Last() map[string]SystemInfo {
+	monitor.Lock()
+	defer monitor.Unlock()
+
+	return monitor.last
+}
+
+// Subscribe subscribes to domain infos updates. If subscriber is slow, only the latest update is kept.
+func (monitor *Monitor) Subscribe() <-chan map[string]SystemInfo {
+	monitor.Lock()
+	defer monitor.Unlock()
+
+	subscriber := make(chan map[string]SystemInfo, 1)
+
+	monitor.subscribers[subscriber] = struct{}{}
+
+	return subscriber
+}
+
+// Unsubscribe unsubscribes from domain infos updates and closes subscription channel.
+func (monitor *Monitor) Unsubscribe(subscription <-chan map[string]SystemInfo) {
+	monitor.Lock()
+	defer monitor.Unlock()
+
+	for subscriber := range monitor.subscribers {
+		if subscriber == subscription {
+			delete(monitor.subscribers, subscriber)
+			close(subscriber)
+
+			return
+		}
+	}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (monitor *Monitor) run(ctx context.Context) {
+	defer monitor.wg.Done()
+
+	for {
+		if err := monitor.runXentop(ctx); err != nil && ctx.Err() == nil {
+			log.Errorf("Xentop failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-time.After(restartDelay):
+		}
+	}
+}
+
+func (monitor *Monitor) runXentop(ctx context.Context) error {
+	cmd := StreamExecContext(ctx, "xentop", "-b", "-f", "-d",
+		strconv.FormatFloat(monitor.interval.Seconds(), 'f', -1, 64))
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	if err = cmd.Start(); err != nil {
+		return aoserrors.Wrap(err)
+	}
+
+	lines := make(chan string)
+
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(stdout)
+
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	monitor.processLines(lines)
+
+	return aoserrors.Wrap(cmd.Wait())
+}
+
+func (monitor *Monitor) processLines(lines <-chan string) {
+	var (
+		header      []string
+		systemInfos map[string]SystemInfo
+	)
+
+	flushTimer := time.NewTimer(frameFlushTimeout)
+	flushTimer.Stop()
+
+	defer flushTimer.Stop()
+
+	flush := func() {
+		if systemInfos != nil {
+			monitor.publish(systemInfos)
+			systemInfos = nil
+		}
+	}
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				flush()
+
+				return
+			}
+
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			if newHeader, ok := parseHeader(line); ok {
+				flush()
+
+				header = newHeader
+				systemInfos = make(map[string]SystemInfo)
+
+				flushTimer.Reset(frameFlushTimeout)
+
+				continue
+			}
+
+			if header == nil || systemInfos == nil {
+				continue
+			}
+
+			systemInfo, err := parseDomainLine(header, line)
+			if err != nil {
+				log.Errorf("Can't parse xentop line: %v", err)
+
+				continue
+			}
+
+			systemInfos[systemInfo.Name] = systemInfo
+
+			flushTimer.Reset(frameFlushTimeout)
+
+		case <-flushTimer.C:
+			flush()
+		}
+	}
+}
+
+func (monitor *Monitor) publish(systemInfos map[string]SystemInfo) {
+	monitor.Lock()
+	defer monitor.Unlock()
+
+	monitor.last = systemInfos
+
+	for subscriber := range monitor.subscribers {
+		// Drop stale update if subscriber didn't read it
+		select {
+		case <-subscriber:
+
+		default:
+		}
+
+		subscriber <- systemInfos
+	}
+}
+
+func newExecStreamCommander(ctx context.Context, name string, arg ...string) StreamCommand {
+	return exec.CommandContext(ctx, name, arg...)
+}
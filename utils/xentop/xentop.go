@@ -79,29 +79,26 @@ func GetSystemInfos() (map[string]SystemInfo, error) {
 		return nil, aoserrors.Wrap(err)
 	}
 
-	xentopLines := strings.Split(string(output), "\n")
-	if len(xentopLines) < 1 {
-		return nil, aoserrors.New("xentop output empty")
-	}
+	return ParseOutput(string(output))
+}
 
-	header := strings.Fields(xentopLines[0])
-	if len(header) < 1 || header[0] != "NAME" {
+// ParseOutput parses single xentop batch iteration output.
+func ParseOutput(output string) (map[string]SystemInfo, error) {
+	xentopLines := strings.Split(output, "\n")
+
+	header, ok := parseHeader(xentopLines[0])
+	if !ok {
 		return nil, aoserrors.New("unexpected xentop header")
 	}
 
 	systemInfos := make(map[string]SystemInfo)
 
 	for _, line := range xentopLines[1:] {
-		// Below strings.Fields function splits a string into an array of strings using unicode.IsSpace for this.
-		// Therefore replace the space in no limit with a hyphen since it is a single value
-		line = strings.ReplaceAll(line, "no limit", "no-limit")
-
-		info := strings.Fields(line)
-		if len(info) != len(header) {
-			return nil, aoserrors.New("unexpected xentop data")
+		if strings.TrimSpace(line) == "" {
+			continue
 		}
 
-		systemInfo, err := fillDomainInfo(header, info)
+		systemInfo, err := parseDomainLine(header, line)
 		if err != nil {
 			return nil, err
 		}
@@ -116,6 +113,28 @@ func GetSystemInfos() (map[string]SystemInfo, error) {
  * Private
  **********************************************************************************************************************/
 
+func parseHeader(line string) (header []string, ok bool) {
+	header = strings.Fields(line)
+	if len(header) < 1 || header[0] != "NAME" {
+		return nil, false
+	}
+
+	return header, true
+}
+
+func parseDomainLine(header []string, line string) (SystemInfo, error) {
+	// Below strings.Fields function splits a string into an array of strings using unicode.IsSpace for this.
+	// Therefore replace the space in no limit with a hyphen since it is a single value
+	line = strings.ReplaceAll(line, "no limit", "no-limit")
+
+	info := strings.Fields(line)
+	if len(info) != len(header) {
+		return SystemInfo{}, aoserrors.New("unexpected xentop data")
+	}
+
+	return fillDomainInfo(header, info)
+}
+
 func prepareKeyValueXentopInfo(header []string, info []string) map[string]string {
 	xentopInfo := make(map[string]string)
 
@@ -18,10 +18,13 @@
 package xentop_test
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aosedge/aos_common/utils/xentop"
 	log "github.com/sirupsen/logrus"
@@ -29,6 +32,10 @@ import (
 
 type testShellCommand struct{}
 
+type testStreamCommand struct {
+	reader io.ReadCloser
+}
+
 /***********************************************************************************************************************
  * Init
  **********************************************************************************************************************/
@@ -81,6 +88,66 @@ func TestGetSystemInfo(t *testing.T) {
 	}
 }
 
+func TestMonitor(t *testing.T) {
+	reader, writer := io.Pipe()
+
+	xentop.StreamExecContext = func(ctx context.Context, name string, arg ...string) xentop.StreamCommand {
+		go func() {
+			<-ctx.Done()
+			writer.Close()
+		}()
+
+		return testStreamCommand{reader: reader}
+	}
+
+	monitor, err := xentop.NewMonitor(time.Second)
+	if err != nil {
+		t.Fatalf("Can't create monitor: %v", err)
+	}
+	defer monitor.Close()
+
+	subscription := monitor.Subscribe()
+
+	header := "   NAME  STATE   CPU(sec) CPU(%)     MEM(k) MEM(%)  MAXMEM(k) MAXMEM(%) VCPUS NETS NETTX(k) NETRX(k) " +
+		"VBDS   VBD_OO   VBD_RD   VBD_WR  VBD_RSECT  VBD_WSECT SSID"
+
+	for i, cpu := range []string{"10.5", "20.5"} {
+		domD := fmt.Sprintf("   DomD --b---        180    %s    2084104   25.2    2098176      25.4     4    1"+
+			"        5        6 2        0        7        8          9         10   14", cpu)
+
+		if _, err := fmt.Fprintf(writer, "%s\n%s\n", header, domD); err != nil {
+			t.Fatalf("Can't write xentop output: %v", err)
+		}
+
+		select {
+		case systemInfos := <-subscription:
+			expectedSystemInfo := xentop.SystemInfo{
+				Name: "DomD", State: "--b---", CPUTime: 180, CPUFraction: []float32{10.5, 20.5}[i],
+				Memory: 2084104, MemoryFraction: 25.2, MaxMemory: 2098176, MaxMemoryFraction: 25.4,
+				VirtualCpus: 4, NetworkInterfaces: 1, NetworkTx: 5, NetworkRx: 6, VirtualDisks: 2,
+				DiskReadOps: 7, DiskWriteOps: 8, DiskSectorsRead: 9, DiskSectorsWritten: 10, SSID: 14,
+			}
+
+			if !reflect.DeepEqual(systemInfos["DomD"], expectedSystemInfo) {
+				t.Errorf("Unexpected system info: %v", systemInfos["DomD"])
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatal("Wait system infos timeout")
+		}
+	}
+
+	if systemInfos := monitor.Last(); systemInfos["DomD"].CPUFraction != 20.5 {
+		t.Errorf("Unexpected last system infos: %v", systemInfos)
+	}
+
+	monitor.Unsubscribe(subscription)
+
+	if _, ok := <-subscription; ok {
+		t.Error("Subscription channel should be closed")
+	}
+}
+
 func (cmd testShellCommand) CombinedOutput() ([]byte, error) {
 	header := "   NAME  STATE   CPU(sec) CPU(%)     MEM(k) MEM(%)  MAXMEM(k) MAXMEM(%) VCPUS NETS NETTX(k) NETRX(k) " +
 		"VBDS   VBD_OO   VBD_RD   VBD_WR  VBD_RSECT  VBD_WSECT SSID"
@@ -91,9 +158,21 @@ func (cmd testShellCommand) CombinedOutput() ([]byte, error) {
 	domD := "   DomD --b---        180    0.0    2084104   25.2    2098176      25.4     4    0        0        0 " +
 		"0        0        0        0          0          0   14"
 
-	return []byte(fmt.Sprintf("%s\n%s\n%s", header, dom0, domD)), nil
+	return []byte(fmt.Sprintf("%s\n%s\n%s\n", header, dom0, domD)), nil
 }
 
 func newTestShellCommander(name string, arg ...string) xentop.ShellCommand {
 	return testShellCommand{}
 }
+
+func (cmd testStreamCommand) StdoutPipe() (io.ReadCloser, error) {
+	return cmd.reader, nil
+}
+
+func (cmd testStreamCommand) Start() error {
+	return nil
+}
+
+func (cmd testStreamCommand) Wait() error {
+	return nil
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wsclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+const (
+	defaultBatchMaxMessages = 64
+	defaultBatchMaxSize     = 64 * 1024
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// BatchParam write batching parameters. Batching is disabled if Window is zero.
+type BatchParam struct {
+	// Window time during which messages sent by SendMessage are coalesced into single frame.
+	Window time.Duration
+	// MaxMessages batch is sent immediately when it contains this number of messages.
+	MaxMessages int
+	// MaxSize batch is sent immediately when its size reaches this number of bytes.
+	MaxSize int
+}
+
+// MessageBatch frame which contains multiple messages coalesced by write batching. Peer should unpack received frames
+// with UnpackMessages.
+type MessageBatch struct {
+	Messages []json.RawMessage `json:"batchMessages"`
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+//nolint:gochecknoglobals // batch frame prefix
+var batchPrefix = []byte(`{"batchMessages":`)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// UnpackMessages returns messages contained in received frame. Frame which is not a message batch is returned as
+// single message.
+func UnpackMessages(data []byte) [][]byte {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), batchPrefix) {
+		return [][]byte{data}
+	}
+
+	var batch MessageBatch
+
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return [][]byte{data}
+	}
+
+	messages := make([][]byte, 0, len(batch.Messages))
+
+	for _, message := range batch.Messages {
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+// Flush sends pending batched messages.
+func (client *Client) Flush() error {
+	client.Lock()
+	defer client.Unlock()
+
+	if !client.isConnected {
+		return aoserrors.New("client is disconnected")
+	}
+
+	return client.flushBatch()
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (param *BatchParam) setDefaults() {
+	if param.Window == 0 {
+		return
+	}
+
+	if param.MaxMessages <= 0 {
+		param.MaxMessages = defaultBatchMaxMessages
+	}
+
+	if param.MaxSize <= 0 {
+		param.MaxSize = defaultBatchMaxSize
+	}
+}
+
+// addToBatch adds message to pending batch. Should be called with client mutex locked.
+func (client *Client) addToBatch(messageJSON []byte) error {
+	client.batch = append(client.batch, messageJSON)
+	client.batchSize += len(messageJSON)
+
+	batching := client.clientParam.Batching
+
+	if len(client.batch) >= batching.MaxMessages || client.batchSize >= batching.MaxSize {
+		return client.flushBatch()
+	}
+
+	if client.batchTimer == nil {
+		client.batchTimer = client.clientParam.Clock.AfterFunc(batching.Window, client.flushPending)
+	}
+
+	return nil
+}
+
+func (client *Client) flushPending() {
+	client.Lock()
+	defer client.Unlock()
+
+	if !client.isConnected {
+		return
+	}
+
+	if err := client.flushBatch(); err != nil {
+		log.WithFields(log.Fields{"client": client.name}).Errorf("Can't send message batch: %v", err)
+	}
+}
+
+// flushBatch sends pending batch. Should be called with client mutex locked.
+func (client *Client) flushBatch() error {
+	messages := client.dropBatch()
+
+	switch len(messages) {
+	case 0:
+		return nil
+
+	case 1:
+		return client.writeMessage(messages[0])
+
+	default:
+		batch := MessageBatch{Messages: make([]json.RawMessage, 0, len(messages))}
+
+		for _, message := range messages {
+			batch.Messages = append(batch.Messages, message)
+		}
+
+		batchJSON, err := json.Marshal(batch)
+		if err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		log.WithFields(log.Fields{"client": client.name, "count": len(messages)}).Debug("Send message batch")
+
+		return client.writeMessage(batchJSON)
+	}
+}
+
+// dropBatch resets pending batch and returns its messages. Should be called with client mutex locked.
+func (client *Client) dropBatch() (messages [][]byte) {
+	if client.batchTimer != nil {
+		client.batchTimer.Stop()
+		client.batchTimer = nil
+	}
+
+	messages, client.batch, client.batchSize = client.batch, nil, 0
+
+	return messages
+}
+
+// writeMessage writes message frame. Should be called with client mutex locked.
+func (client *Client) writeMessage(data []byte) error {
+	if err := client.connection.SetWriteDeadline(time.Now().Add(client.clientParam.WebSocketTimeout)); err != nil {
+		log.WithFields(log.Fields{"client": client.name}).Debugf("Can't set write deadline timeout: %s", err)
+
+		client.connection.Close()
+
+		return aoserrors.Wrap(err)
+	}
+
+	if err := client.connection.WriteMessage(websocket.TextMessage, data); err != nil {
+		log.WithFields(log.Fields{"client": client.name}).Debugf("Send message error: %s", err)
+		client.connection.Close()
+
+		return aoserrors.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wsclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+
+	"github.com/gorilla/websocket"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// TLS alerts related to peer certificate (RFC 8446).
+const (
+	tlsAlertBadCertificate         tls.AlertError = 42
+	tlsAlertUnsupportedCertificate tls.AlertError = 43
+	tlsAlertCertificateRevoked     tls.AlertError = 44
+	tlsAlertCertificateExpired     tls.AlertError = 45
+	tlsAlertCertificateUnknown     tls.AlertError = 46
+	tlsAlertUnknownCA              tls.AlertError = 48
+	tlsAlertAccessDenied           tls.AlertError = 49
+	tlsAlertCertificateRequired    tls.AlertError = 116
+)
+
+// Close codes registered by IANA for authentication failures.
+const (
+	CloseCodeUnauthorized = 3000
+	CloseCodeForbidden    = 3003
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// DisconnectError error sent to ErrorChannel on remote disconnect. It wraps one of disconnect cause errors:
+// ErrAuthFailure, ErrServerShutdown, ErrServerClose or ErrNetworkLoss.
+type DisconnectError struct {
+	// Code WebSocket close code, zero if connection is lost without close frame.
+	Code int
+	// Reason close reason text sent by server.
+	Reason string
+	// Cause disconnect cause.
+	Cause error
+	// Err original error.
+	Err error
+}
+
+/***********************************************************************************************************************
+ * Vars
+ **********************************************************************************************************************/
+
+// Disconnect causes.
+var (
+	// ErrAuthFailure server rejected client authentication or authorization.
+	ErrAuthFailure = errors.New("authentication failure")
+	// ErrServerShutdown server is going down or restarting.
+	ErrServerShutdown = errors.New("server shutdown")
+	// ErrServerClose server closed connection for other reason.
+	ErrServerClose = errors.New("connection closed by server")
+	// ErrNetworkLoss connection is lost without close frame.
+	ErrNetworkLoss = errors.New("network loss")
+)
+
+/***********************************************************************************************************************
+ * Public
+ **********************************************************************************************************************/
+
+// Error returns disconnect error message.
+func (err *DisconnectError) Error() string {
+	if err.Code != 0 {
+		return fmt.Sprintf("%v: code %d, reason %q", err.Cause, err.Code, err.Reason)
+	}
+
+	return fmt.Sprintf("%v: %v", err.Cause, err.Err)
+}
+
+// Unwrap returns disconnect cause and original error.
+func (err *DisconnectError) Unwrap() []error {
+	return []error{err.Cause, err.Err}
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newDisconnectError(err error) *DisconnectError {
+	disconnectErr := &DisconnectError{Cause: ErrNetworkLoss, Err: err}
+
+	var closeErr *websocket.CloseError
+
+	if !errors.As(err, &closeErr) {
+		return disconnectErr
+	}
+
+	disconnectErr.Code = closeErr.Code
+	disconnectErr.Reason = closeErr.Text
+
+	switch closeErr.Code {
+	case CloseCodeUnauthorized, CloseCodeForbidden:
+		disconnectErr.Cause = ErrAuthFailure
+
+	case websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseServiceRestart,
+		websocket.CloseTryAgainLater:
+		disconnectErr.Cause = ErrServerShutdown
+
+	case websocket.CloseAbnormalClosure:
+		disconnectErr.Cause = ErrNetworkLoss
+
+	default:
+		disconnectErr.Cause = ErrServerClose
+	}
+
+	return disconnectErr
+}
+
+// isAuthError returns true if connection failed due to server or client authentication.
+func isAuthError(response *http.Response, err error) bool {
+	if response != nil &&
+		(response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden) {
+		return true
+	}
+
+	var (
+		certErr     *tls.CertificateVerificationError
+		unknownErr  x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+		invalidErr  x509.CertificateInvalidError
+	)
+
+	if errors.As(err, &certErr) || errors.As(err, &unknownErr) || errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) {
+		return true
+	}
+
+	if alert, ok := getTLSAlert(err); ok {
+		switch alert {
+		case tlsAlertBadCertificate, tlsAlertUnsupportedCertificate, tlsAlertCertificateRevoked,
+			tlsAlertCertificateExpired, tlsAlertCertificateUnknown, tlsAlertUnknownCA, tlsAlertAccessDenied,
+			tlsAlertCertificateRequired:
+			return true
+		}
+	}
+
+	return false
+}
+
+// getTLSAlert returns TLS alert the connection failed with. Alerts received from peer are reported by crypto/tls as
+// net.OpError with unexported alert type, so its value is taken by reflection.
+func getTLSAlert(err error) (tls.AlertError, bool) {
+	var alertErr tls.AlertError
+
+	if errors.As(err, &alertErr) {
+		return alertErr, true
+	}
+
+	var opErr *net.OpError
+
+	if errors.As(err, &opErr) && opErr.Op == "remote error" {
+		if value := reflect.ValueOf(opErr.Err); value.Kind() == reflect.Uint8 {
+			return tls.AlertError(value.Uint()), true
+		}
+	}
+
+	return 0, false
+}
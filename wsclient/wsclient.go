@@ -18,10 +18,16 @@
 package wsclient
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,6 +35,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/clock"
 	"github.com/aosedge/aos_common/utils/cryptutils"
 )
 
@@ -41,12 +48,19 @@ const (
 	errorChannelSize        = 1
 )
 
+// Proxy URL schemes.
+const (
+	ProxySchemeHTTP   = "http"
+	ProxySchemeSOCKS5 = "socks5"
+)
+
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
 
 // Client VIS client object.
 type Client struct {
+	// ErrorChannel receives *DisconnectError on remote disconnect.
 	ErrorChannel chan error
 
 	name           string
@@ -59,12 +73,66 @@ type Client struct {
 	wsDialer          websocket.Dialer
 	clientParam       ClientParam
 	cryptoContext     *cryptutils.CryptoContext
+	// connectedChannel is closed when connection is established.
+	connectedChannel chan struct{}
+	// connectionLostChannel is closed when current connection is lost.
+	connectionLostChannel chan struct{}
+	closeChannel          chan struct{}
+	closeOnce             sync.Once
+	batch                 [][]byte
+	batchSize             int
+	batchTimer            clock.Timer
 }
 
 // ClientParam client parameters.
 type ClientParam struct {
 	CaCertFile       string
 	WebSocketTimeout time.Duration
+	Proxy            ProxyParam
+	TLS              TLSParam
+	// Dialer used to establish TCP connection. If nil, default dialer is used.
+	Dialer *net.Dialer
+	// DialContext establishes connection instead of Dialer if set, e.g. to connect to in-memory test server.
+	DialContext func(ctx context.Context, network, address string) (net.Conn, error)
+	// BindToDevice binds connection to the specified network interface or VRF (requires CAP_NET_RAW).
+	BindToDevice string
+	// Batching coalesces messages sent by SendMessage into single frame if enabled.
+	Batching BatchParam
+	// Clock time source used for request timeouts and batching window. If nil, system clock is used.
+	Clock clock.Clock
+}
+
+// TLSParam TLS parameters.
+type TLSParam struct {
+	// Profile named TLS profile (see cryptutils.TLSProfile*). MinVersion and CipherSuites override profile values.
+	Profile string
+	// SessionCacheSize enables TLS session resumption with the specified cache size.
+	SessionCacheSize int
+	MinVersion       uint16
+	CipherSuites     []uint16
+	// ServerName overrides server name used for SNI and certificate verification.
+	ServerName string
+	// AllowedSPIFFEIDs authorizes server by SPIFFE ID patterns if set.
+	AllowedSPIFFEIDs []string
+	// PeerPins rejects server if its certificate chain doesn't match pin set.
+	PeerPins *cryptutils.PinSet
+}
+
+// ProxyParam proxy parameters.
+// If URL is empty, proxy is taken from HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+// unless DisableEnvironment is set.
+type ProxyParam struct {
+	URL                string
+	User               string
+	Password           string
+	DisableEnvironment bool
+}
+
+// RequestOptions request options.
+type RequestOptions struct {
+	// Idempotent allows to resend request after the client is reconnected if connection is lost before response is
+	// received. Retries are performed within the original request timeout.
+	Idempotent bool
 }
 
 type requestParam struct {
@@ -88,6 +156,16 @@ func New(name string, clientParam ClientParam, messageHandler func([]byte)) (cli
 		ErrorChannel:      make(chan error, errorChannelSize),
 		disconnectChannel: make(chan bool),
 		clientParam:       clientParam,
+		connectedChannel:  make(chan struct{}),
+		closeChannel:      make(chan struct{}),
+	}
+
+	if client.clientParam.Clock == nil {
+		client.clientParam.Clock = clock.New()
+	}
+
+	if client.wsDialer.Proxy, err = getProxyFunc(clientParam.Proxy); err != nil {
+		return nil, aoserrors.Wrap(err)
 	}
 
 	// Check if system root certificate override is active and if so update tls config with custom CA
@@ -109,12 +187,23 @@ func New(name string, clientParam ClientParam, messageHandler func([]byte)) (cli
 		}).Debug("Updating TLS config based on caCert")
 	}
 
+	if client.wsDialer.TLSClientConfig, err = applyTLSParam(client.wsDialer.TLSClientConfig, clientParam.TLS); err != nil {
+		return nil, err
+	}
+	client.wsDialer.NetDialContext = getNetDialer(clientParam.Dialer, clientParam.BindToDevice).DialContext
+
+	if clientParam.DialContext != nil {
+		client.wsDialer.NetDialContext = clientParam.DialContext
+	}
+
 	if clientParam.WebSocketTimeout > 0 {
 		client.clientParam.WebSocketTimeout = clientParam.WebSocketTimeout
 	} else {
 		client.clientParam.WebSocketTimeout = defaultWebsocketTimeout
 	}
 
+	client.clientParam.Batching.setDefaults()
+
 	return client, nil
 }
 
@@ -133,14 +222,25 @@ func (client *Client) Connect(url string) (err error) {
 		return aoserrors.Errorf("client %s already connected", client.name)
 	}
 
-	connection, _, err := client.wsDialer.Dial(url, nil)
+	connection, response, err := client.wsDialer.Dial(url, nil)
+	if response != nil && response.Body != nil {
+		response.Body.Close()
+	}
+
 	if err != nil {
+		if isAuthError(response, err) {
+			return aoserrors.Errorf("%w: %w", ErrAuthFailure, err)
+		}
+
 		return aoserrors.Wrap(err)
 	}
 
 	client.connection = connection
 
 	client.isConnected = true
+	client.connectionLostChannel = make(chan struct{})
+
+	close(client.connectedChannel)
 
 	go client.processMessages()
 
@@ -159,7 +259,11 @@ func (client *Client) Disconnect() (err error) {
 
 	log.WithFields(log.Fields{"client": client.name}).Debug("Disconnect")
 
-	client.isConnected = false
+	if e := client.flushBatch(); e != nil {
+		log.Errorf("Can't send message batch: %s", e)
+	}
+
+	client.setDisconnected()
 
 	if e := client.connection.SetWriteDeadline(time.Now().Add(client.clientParam.WebSocketTimeout)); e != nil {
 		log.Errorf("Can't set write deadline timeout: %s", e)
@@ -206,6 +310,8 @@ func (client *Client) IsConnected() (result bool) {
 func (client *Client) Close() (err error) {
 	log.WithFields(log.Fields{"client": client.name}).Info("Close ws client")
 
+	client.closeOnce.Do(func() { close(client.closeChannel) })
+
 	if disconnectErr := aoserrors.Wrap(client.Disconnect()); disconnectErr != nil {
 		if err == nil {
 			err = aoserrors.Wrap(disconnectErr)
@@ -225,6 +331,13 @@ func (client *Client) Close() (err error) {
 
 // SendRequest sends request and waits for response.
 func (client *Client) SendRequest(idField string, idValue interface{}, req interface{}, rsp interface{}) (err error) {
+	return client.SendRequestWithOptions(idField, idValue, req, rsp, RequestOptions{})
+}
+
+// SendRequestWithOptions sends request with options and waits for response.
+func (client *Client) SendRequestWithOptions(
+	idField string, idValue interface{}, req interface{}, rsp interface{}, options RequestOptions,
+) (err error) {
 	requestID := reflect.ValueOf(req).Elem()
 
 	if requestID.Kind() == reflect.Ptr {
@@ -238,31 +351,185 @@ func (client *Client) SendRequest(idField string, idValue interface{}, req inter
 		}
 	}
 
-	param := requestParam{id: idValue, idField: idField, rspChannel: make(chan bool), rsp: rsp}
+	if options.Idempotent {
+		// Check marshaling before sending to not retry requests which can't be sent at all
+		if _, err = json.Marshal(req); err != nil {
+			return aoserrors.Wrap(err)
+		}
+	}
+
+	// Response channel is buffered to not block receiving while the request is being resent
+	param := requestParam{id: idValue, idField: idField, rspChannel: make(chan bool, 1), rsp: rsp}
 	client.requests.Store(param.id, param)
 
 	defer client.requests.Delete(param.id)
 
-	if err = client.SendMessage(req); err != nil {
-		return aoserrors.Wrap(err)
+	timeout := client.clientParam.Clock.NewTimer(client.clientParam.WebSocketTimeout)
+	defer timeout.Stop()
+
+	for {
+		connectionLost := client.getConnectionLostChannel()
+
+		// Requests are not batched to not delay them and to report send errors
+		if err = client.sendMessage(req, false); err != nil {
+			if !options.Idempotent {
+				return aoserrors.Wrap(err)
+			}
+
+			log.WithFields(log.Fields{
+				"client": client.name, "id": idValue,
+			}).Warnf("Can't send request, retry after reconnect: %v", err)
+		} else {
+			var lost <-chan struct{}
+
+			if options.Idempotent {
+				lost = connectionLost
+			}
+
+			// Wait response or timeout
+			select {
+			case <-timeout.C():
+				return aoserrors.New("wait response timeout")
+
+			case _, ok := <-param.rspChannel:
+				if !ok {
+					return aoserrors.New("response channel is closed")
+				}
+
+				return nil
+
+			case <-lost:
+				log.WithFields(log.Fields{
+					"client": client.name, "id": idValue,
+				}).Warn("Connection lost, retry request after reconnect")
+			}
+		}
+
+		if err = client.waitReconnect(connectionLost, timeout.C()); err != nil {
+			return err
+		}
 	}
+}
 
-	// Wait response or timeout
-	select {
-	case <-time.After(client.clientParam.WebSocketTimeout):
-		return aoserrors.New("wait response timeout")
+// SendMessage sends message without waiting for response. If batching is enabled, message is queued and sent
+// within batching window, in this case send errors are logged and reported as disconnect.
+func (client *Client) SendMessage(message interface{}) (err error) {
+	return client.sendMessage(message, client.clientParam.Batching.Window > 0)
+}
 
-	case _, ok := <-param.rspChannel:
-		if !ok {
-			return aoserrors.New("response channel is closed")
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func applyTLSParam(tlsConfig *tls.Config, param TLSParam) (*tls.Config, error) {
+	if param.Profile == cryptutils.TLSProfileDefault && param.SessionCacheSize == 0 && param.MinVersion == 0 &&
+		len(param.CipherSuites) == 0 && param.ServerName == "" && len(param.AllowedSPIFFEIDs) == 0 &&
+		param.PeerPins == nil {
+		return tlsConfig, nil
+	}
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if param.Profile != cryptutils.TLSProfileDefault {
+		if err := cryptutils.ApplyTLSProfile(tlsConfig, param.Profile); err != nil {
+			return nil, aoserrors.Wrap(err)
 		}
 	}
 
-	return nil
+	if param.SessionCacheSize > 0 {
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(param.SessionCacheSize)
+	}
+
+	if param.MinVersion != 0 {
+		tlsConfig.MinVersion = param.MinVersion
+	}
+
+	if len(param.CipherSuites) != 0 {
+		tlsConfig.CipherSuites = param.CipherSuites
+	}
+
+	if param.ServerName != "" {
+		tlsConfig.ServerName = param.ServerName
+	}
+
+	if len(param.AllowedSPIFFEIDs) != 0 {
+		tlsConfig.VerifyConnection = cryptutils.VerifySPIFFEPeer(param.AllowedSPIFFEIDs...)
+	}
+
+	if param.PeerPins != nil {
+		tlsConfig.VerifyPeerCertificate = cryptutils.VerifyPinnedPeer(*param.PeerPins)
+	}
+
+	return tlsConfig, nil
 }
 
-// SendMessage sends message without waiting for response.
-func (client *Client) SendMessage(message interface{}) (err error) {
+func getNetDialer(dialer *net.Dialer, device string) *net.Dialer {
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	if device == "" {
+		return dialer
+	}
+
+	bindDialer := *dialer
+	prevControl := dialer.Control
+
+	bindDialer.Control = func(network, address string, rawConn syscall.RawConn) error {
+		if prevControl != nil {
+			if err := prevControl(network, address, rawConn); err != nil {
+				return err
+			}
+		}
+
+		var bindErr error
+
+		if err := rawConn.Control(func(fd uintptr) {
+			bindErr = syscall.SetsockoptString(int(fd), syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, device)
+		}); err != nil {
+			return aoserrors.Wrap(err)
+		}
+
+		return aoserrors.Wrap(bindErr)
+	}
+
+	return &bindDialer
+}
+
+func getProxyFunc(param ProxyParam) (proxyFunc func(*http.Request) (*url.URL, error), err error) {
+	if param.URL == "" {
+		if param.DisableEnvironment {
+			return nil, nil
+		}
+
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(param.URL)
+	if err != nil {
+		return nil, aoserrors.Wrap(err)
+	}
+
+	if proxyURL.Scheme != ProxySchemeHTTP && proxyURL.Scheme != ProxySchemeSOCKS5 {
+		return nil, aoserrors.Errorf("unsupported proxy scheme: %s", proxyURL.Scheme)
+	}
+
+	if proxyURL.Host == "" {
+		return nil, aoserrors.Errorf("proxy host is not specified: %s", param.URL)
+	}
+
+	if param.User != "" {
+		proxyURL.User = url.UserPassword(param.User, param.Password)
+	}
+
+	log.WithFields(log.Fields{"proxy": proxyURL.Redacted()}).Debug("Use proxy")
+
+	return http.ProxyURL(proxyURL), nil
+}
+
+func (client *Client) sendMessage(message interface{}, batch bool) (err error) {
 	client.Lock()
 	defer client.Unlock()
 
@@ -277,33 +544,23 @@ func (client *Client) SendMessage(message interface{}) (err error) {
 
 	log.WithFields(log.Fields{"client": client.name, "message": string(messageJSON)}).Debug("Send message")
 
-	if err := client.connection.SetWriteDeadline(time.Now().Add(client.clientParam.WebSocketTimeout)); err != nil {
-		log.WithFields(log.Fields{"client": client.name}).Debugf("Can't set write deadline timeout: %s", err)
-
-		client.connection.Close()
-
-		return aoserrors.Wrap(err)
+	if batch {
+		return client.addToBatch(messageJSON)
 	}
 
-	if err = client.connection.WriteMessage(websocket.TextMessage, messageJSON); err != nil {
-		log.WithFields(log.Fields{"client": client.name}).Debugf("Send message error: %s", err)
-		client.connection.Close()
-
-		return aoserrors.Wrap(err)
+	// Send pending batch first to keep messages order
+	if err = client.flushBatch(); err != nil {
+		return err
 	}
 
-	return nil
+	return client.writeMessage(messageJSON)
 }
 
-/***********************************************************************************************************************
- * Private
- **********************************************************************************************************************/
-
 func (client *Client) processMessages() {
 	for {
-		_, message, err := client.connection.ReadMessage()
+		_, frame, err := client.connection.ReadMessage()
 		if err != nil {
-			if !websocket.IsCloseError(err, websocket.CloseNormalClosure) &&
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) &&
 				!strings.Contains(err.Error(), "use of closed network connection") {
 				log.WithFields(log.Fields{"client": client.name}).Errorf("Receive message error: %s", err)
 			}
@@ -313,12 +570,14 @@ func (client *Client) processMessages() {
 			return
 		}
 
-		log.WithFields(log.Fields{"client": client.name, "message": string(message)}).Debug("Receive message")
+		for _, message := range UnpackMessages(frame) {
+			log.WithFields(log.Fields{"client": client.name, "message": string(message)}).Debug("Receive message")
 
-		rspFound := client.findRequestID(message)
+			rspFound := client.findRequestID(message)
 
-		if client.messageHandler != nil && !rspFound {
-			client.messageHandler(message)
+			if client.messageHandler != nil && !rspFound {
+				client.messageHandler(message)
+			}
 		}
 	}
 }
@@ -370,10 +629,65 @@ func (client *Client) disconnect(err error) {
 		log.WithFields(log.Fields{"client": client.name}).Debug("Remote disconnect")
 
 		client.connection.Close()
-		client.isConnected = false
+		client.setDisconnected()
 
-		client.ErrorChannel <- err
+		client.ErrorChannel <- newDisconnectError(err)
 	} else {
 		client.disconnectChannel <- true
 	}
 }
+
+func (client *Client) setDisconnected() {
+	if dropped := client.dropBatch(); len(dropped) > 0 {
+		log.WithFields(log.Fields{"client": client.name, "count": len(dropped)}).Warn("Drop pending message batch")
+	}
+
+	client.isConnected = false
+	client.connectedChannel = make(chan struct{})
+
+	close(client.connectionLostChannel)
+}
+
+func (client *Client) getConnectionLostChannel() (connectionLost <-chan struct{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	if !client.isConnected {
+		return nil
+	}
+
+	return client.connectionLostChannel
+}
+
+func (client *Client) getConnectedChannel() (connected <-chan struct{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	return client.connectedChannel
+}
+
+func (client *Client) waitReconnect(connectionLost <-chan struct{}, timeout <-chan time.Time) (err error) {
+	// Connection may be still treated as connected if send failed, wait till it is lost
+	if connectionLost != nil {
+		select {
+		case <-connectionLost:
+
+		case <-timeout:
+			return aoserrors.New("wait response timeout")
+
+		case <-client.closeChannel:
+			return aoserrors.New("client is closed")
+		}
+	}
+
+	select {
+	case <-client.getConnectedChannel():
+		return nil
+
+	case <-timeout:
+		return aoserrors.New("wait response timeout")
+
+	case <-client.closeChannel:
+		return aoserrors.New("client is closed")
+	}
+}
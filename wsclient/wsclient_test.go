@@ -19,8 +19,19 @@ package wsclient_test
 
 import (
 	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
@@ -42,6 +53,7 @@ import (
 const (
 	hostURL   = ":8088"
 	serverURL = "wss://localhost:8088"
+	proxyURL  = "localhost:8089"
 )
 
 /***********************************************************************************************************************
@@ -54,6 +66,13 @@ type testHandler struct {
 	processMessage
 }
 
+type testProxy struct {
+	server      *http.Server
+	user        string
+	password    string
+	connections int32
+}
+
 /***********************************************************************************************************************
  * Vars
  **********************************************************************************************************************/
@@ -173,6 +192,83 @@ func TestSendRequest(t *testing.T) {
 	}
 }
 
+func TestIdempotentRequestRetry(t *testing.T) {
+	type Request struct {
+		RequestID string `json:"requestId"`
+		Value     int    `json:"value"`
+	}
+
+	type Response struct {
+		RequestID string `json:"requestId"`
+		Value     int    `json:"value"`
+	}
+
+	var requestCount int32
+
+	server, err := wsserver.New("TestServer", hostURL, crtFile, keyFile, newTestHandler(
+		func(client *wsserver.Client, messageType int, data []byte) (response []byte, err error) {
+			var req Request
+
+			if err = json.Unmarshal(data, &req); err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+
+			// Drop connection on first request
+			if atomic.AddInt32(&requestCount, 1) == 1 {
+				return nil, aoserrors.Wrap(client.SendMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "")))
+			}
+
+			if response, err = json.Marshal(Response(req)); err != nil {
+				return nil, aoserrors.Wrap(err)
+			}
+
+			return response, nil
+		}))
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(1 * time.Second)
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{CaCertFile: caCert, WebSocketTimeout: 5 * time.Second}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	go func() {
+		<-client.ErrorChannel
+
+		time.Sleep(500 * time.Millisecond)
+
+		if err := client.Connect(serverURL); err != nil {
+			t.Errorf("Can't reconnect to ws server: %v", err)
+		}
+	}()
+
+	req := Request{RequestID: uuid.New().String(), Value: 42}
+	rsp := Response{}
+
+	if err = client.SendRequestWithOptions("RequestID", req.RequestID, &req, &rsp,
+		wsclient.RequestOptions{Idempotent: true}); err != nil {
+		t.Fatalf("Can't send request: %v", err)
+	}
+
+	if rsp.Value != req.Value {
+		t.Errorf("Wrong response value: %d", rsp.Value)
+	}
+
+	if count := atomic.LoadInt32(&requestCount); count != 2 {
+		t.Errorf("Wrong request count: %d", count)
+	}
+}
+
 func TestMultipleResponses(t *testing.T) {
 	type Header struct {
 		Type      string `json:"type"`
@@ -338,7 +434,62 @@ func TestErrorChannel(t *testing.T) {
 	server.Close()
 
 	select {
-	case <-client.ErrorChannel:
+	case err = <-client.ErrorChannel:
+		var disconnectErr *wsclient.DisconnectError
+
+		if !errors.As(err, &disconnectErr) || disconnectErr.Code != websocket.CloseGoingAway ||
+			disconnectErr.Reason != wsserver.CloseReasonServerClosed {
+			t.Errorf("Wrong disconnect error: %v", err)
+		}
+
+		if !errors.Is(err, wsclient.ErrServerShutdown) {
+			t.Errorf("Wrong disconnect cause: %v", err)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Error("Waiting error channel timeout")
+	}
+}
+
+func TestDisconnectReason(t *testing.T) {
+	server, err := wsserver.New("TestServer", hostURL, crtFile, keyFile, newTestHandler(
+		func(client *wsserver.Client, messageType int, data []byte) (response []byte, err error) {
+			return nil, aoserrors.Wrap(client.SendMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(wsclient.CloseCodeUnauthorized, "token expired")))
+		}))
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(1 * time.Second)
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	if err = client.SendMessage("auth"); err != nil {
+		t.Fatalf("Can't send message: %s", err)
+	}
+
+	select {
+	case err = <-client.ErrorChannel:
+		var disconnectErr *wsclient.DisconnectError
+
+		if !errors.As(err, &disconnectErr) || disconnectErr.Code != wsclient.CloseCodeUnauthorized ||
+			disconnectErr.Reason != "token expired" {
+			t.Errorf("Wrong disconnect error: %v", err)
+		}
+
+		if !errors.Is(err, wsclient.ErrAuthFailure) {
+			t.Errorf("Wrong disconnect cause: %v", err)
+		}
 
 	case <-time.After(5 * time.Second):
 		t.Error("Waiting error channel timeout")
@@ -463,6 +614,102 @@ func TestSendMessage(t *testing.T) {
 	}
 }
 
+func TestSendMessageBatching(t *testing.T) {
+	type Message struct {
+		Type  string `json:"type"`
+		Value int    `json:"value"`
+	}
+
+	frameChannel := make(chan []byte, 10)
+
+	server, err := wsserver.New("TestServer", hostURL, crtFile, keyFile, newTestHandler(
+		func(client *wsserver.Client, messageType int, data []byte) (response []byte, err error) {
+			frameChannel <- data
+
+			return data, nil
+		}))
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	messageChannel := make(chan Message, 10)
+
+	time.Sleep(1 * time.Second)
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert,
+		Batching:   wsclient.BatchParam{Window: 100 * time.Millisecond, MaxMessages: 3},
+	}, func(data []byte) {
+		var message Message
+
+		if err := json.Unmarshal(data, &message); err != nil {
+			t.Errorf("Parse message error: %s", err)
+
+			return
+		}
+
+		messageChannel <- message
+	})
+	if err != nil {
+		t.Fatalf("Error create a new ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	testData := []struct {
+		count   int
+		batched bool
+	}{
+		{count: 1},
+		{count: 2, batched: true},
+		{count: 3, batched: true},
+	}
+
+	value := 0
+
+	for _, item := range testData {
+		for range item.count {
+			if err = client.SendMessage(&Message{Type: "NOTIFY", Value: value + 1}); err != nil {
+				t.Errorf("Error sending message form client: %s", err)
+			}
+
+			value++
+		}
+
+		select {
+		case frame := <-frameChannel:
+			if batched := strings.HasPrefix(string(frame), `{"batchMessages":`); batched != item.batched {
+				t.Errorf("Wrong frame batching: %s", frame)
+			}
+
+			if messages := wsclient.UnpackMessages(frame); len(messages) != item.count {
+				t.Errorf("Wrong batch messages count: %d", len(messages))
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatal("Waiting frame timeout")
+		}
+	}
+
+	// Echoed batches are unpacked by client in order
+
+	for i := 1; i <= value; i++ {
+		select {
+		case message := <-messageChannel:
+			if message.Value != i {
+				t.Errorf("Wrong message value: %d", message.Value)
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatal("Waiting message timeout")
+		}
+	}
+}
+
 func TestConnectDisconnect(t *testing.T) {
 	server, err := wsserver.New("TestServer", hostURL, crtFile, keyFile, nil)
 	if err != nil {
@@ -524,8 +771,8 @@ func TestWrongCaCert(t *testing.T) {
 		t.Fatalf("Can't create client: %s", err)
 	}
 
-	if err = client.Connect(serverURL); err == nil {
-		t.Error("Expecting an error due to unset custom CA cert")
+	if err = client.Connect(serverURL); !errors.Is(err, wsclient.ErrAuthFailure) {
+		t.Errorf("Expecting auth failure due to unset custom CA cert: %v", err)
 	}
 
 	client.Close()
@@ -543,6 +790,57 @@ func TestWrongCaCert(t *testing.T) {
 	}
 }
 
+func TestTLSAlertAuthFailure(t *testing.T) {
+	type testData struct {
+		alert       byte
+		authFailure bool
+	}
+
+	data := []testData{
+		{alert: 42, authFailure: true},  // bad_certificate
+		{alert: 48, authFailure: true},  // unknown_ca
+		{alert: 116, authFailure: true}, // certificate_required
+		{alert: 40, authFailure: false}, // handshake_failure
+		{alert: 70, authFailure: false}, // protocol_version
+	}
+
+	for _, item := range data {
+		listener, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatalf("Can't create listener: %v", err)
+		}
+
+		// Reply to client hello with fatal TLS alert record.
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			_, _ = conn.Read(make([]byte, 4096))
+			_, _ = conn.Write([]byte{0x15, 0x03, 0x03, 0x00, 0x02, 0x02, item.alert})
+		}()
+
+		client, err := wsclient.New("Test", wsclient.ClientParam{CaCertFile: caCert}, nil)
+		if err != nil {
+			t.Fatalf("Can't create client: %s", err)
+		}
+
+		err = client.Connect("wss://" + listener.Addr().String())
+		if err == nil {
+			t.Errorf("Connect error expected for alert %d", item.alert)
+		}
+
+		if errors.Is(err, wsclient.ErrAuthFailure) != item.authFailure {
+			t.Errorf("Wrong auth failure classification for alert %d: %v", item.alert, err)
+		}
+
+		client.Close()
+		listener.Close()
+	}
+}
+
 func TestWSTimeout(t *testing.T) {
 	type Request struct {
 		Type      string
@@ -597,26 +895,665 @@ func TestWSTimeout(t *testing.T) {
 	}
 }
 
-/*******************************************************************************
- * Private
- ******************************************************************************/
+func TestWSTimeoutWithFakeClock(t *testing.T) {
+	type Request struct {
+		Type      string
+		RequestID string
+	}
 
-func newTestHandler(p processMessage) (handler *testHandler) {
-	return &testHandler{p}
-}
+	server, err := wsserver.New("TestServer", hostURL, crtFile, keyFile, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
 
-func (handler *testHandler) ClientConnected(client *wsserver.Client) {
-}
+	time.Sleep(1 * time.Second)
 
-func (handler *testHandler) ProcessMessage(
-	client *wsserver.Client, messageType int, message []byte,
-) (response []byte, err error) {
-	response, err = handler.processMessage(client, messageType, message)
+	fakeClock := testtools.NewFakeClock(time.Now())
 
-	return response, aoserrors.Wrap(err)
+	client, err := wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert, WebSocketTimeout: time.Hour, Clock: fakeClock,
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	result := make(chan error, 1)
+
+	go func() {
+		req := Request{Type: "GET", RequestID: uuid.New().String()}
+
+		result <- client.SendRequest("RequestID", req.RequestID, &req, nil)
+	}()
+
+	if err = fakeClock.WaitForWaiters(1, 5*time.Second); err != nil {
+		t.Fatalf("Request is not waiting: %v", err)
+	}
+
+	fakeClock.Advance(time.Hour - time.Second)
+
+	select {
+	case err = <-result:
+		t.Fatalf("Unexpected request result: %v", err)
+
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	fakeClock.Advance(time.Second)
+
+	select {
+	case err = <-result:
+		if err == nil {
+			t.Error("Error expected")
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatal("Request timeout is not applied")
+	}
 }
 
-func (handler *testHandler) ClientDisconnected(client *wsserver.Client) {
+func TestProxy(t *testing.T) {
+	server, err := wsserver.New("TestServer", hostURL, crtFile, keyFile, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	proxy := newTestProxy(proxyURL, "user", "password")
+	defer proxy.close()
+
+	time.Sleep(1 * time.Second)
+
+	if _, err = wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert, Proxy: wsclient.ProxyParam{URL: "ftp://" + proxyURL},
+	}, nil); err == nil {
+		t.Error("Error expected due to unsupported proxy scheme")
+	}
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert, Proxy: wsclient.ProxyParam{URL: "http://" + proxyURL, User: "user", Password: "wrong"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+
+	if err = client.Connect(serverURL); err == nil {
+		t.Error("Error expected due to wrong proxy credentials")
+	}
+
+	client.Close()
+
+	client, err = wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert, Proxy: wsclient.ProxyParam{URL: "http://" + proxyURL, User: "user", Password: "password"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	if atomic.LoadInt32(&proxy.connections) != 1 {
+		t.Errorf("Wrong proxy connections count: %d", atomic.LoadInt32(&proxy.connections))
+	}
+}
+
+func TestServerLimits(t *testing.T) {
+	type Message struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+
+	server, err := wsserver.NewWithParam("TestServer", hostURL, crtFile, keyFile, wsserver.ServerParam{
+		MaxClients: 1, MaxMessageSize: 128, MessageRateLimit: 1, MessageRateBurst: 2,
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(1 * time.Second)
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	// Max clients
+
+	secondClient, err := wsclient.New("Test", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer secondClient.Close()
+
+	if err = secondClient.Connect(serverURL); err == nil {
+		t.Error("Error expected due to max clients limit")
+	}
+
+	// Max message size
+
+	if err = client.SendMessage(&Message{Type: "NOTIFY", Value: strings.Repeat("a", 256)}); err != nil {
+		t.Fatalf("Can't send message: %s", err)
+	}
+
+	if err = waitCloseCode(client, wsserver.CloseCodeMessageTooBig); err != nil {
+		t.Errorf("Wrong close code: %s", err)
+	}
+
+	// Rate limit
+
+	if err = connectWithRetry(client, serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err = client.SendMessage(&Message{Type: "NOTIFY"}); err != nil {
+			t.Fatalf("Can't send message: %s", err)
+		}
+	}
+
+	if err = waitCloseCode(client, wsserver.CloseCodeRateLimitExceeded); err != nil {
+		t.Errorf("Wrong close code: %s", err)
+	}
+}
+
+func TestConnectionEvents(t *testing.T) {
+	type Message struct {
+		Type string `json:"type"`
+	}
+
+	eventChannel := make(chan wsserver.ConnectionEvent, 10)
+
+	server, err := wsserver.NewWithParam("TestServer", hostURL, crtFile, keyFile, wsserver.ServerParam{
+		MaxClients:   1,
+		EventHandler: func(event wsserver.ConnectionEvent) { eventChannel <- event },
+	}, newTestHandler(
+		func(client *wsserver.Client, messageType int, data []byte) (response []byte, err error) {
+			return data, nil
+		}))
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(1 * time.Second)
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	event, err := waitConnectionEvent(eventChannel, wsserver.ConnectionEventConnect)
+	if err != nil {
+		t.Fatalf("Can't wait connect event: %s", err)
+	}
+
+	if event.Server != "TestServer" || event.RemoteAddr == "" {
+		t.Errorf("Wrong connect event: %v", event)
+	}
+
+	secondClient, err := wsclient.New("Test", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer secondClient.Close()
+
+	if err = secondClient.Connect(serverURL); err == nil {
+		t.Error("Error expected due to max clients limit")
+	}
+
+	if event, err = waitConnectionEvent(eventChannel, wsserver.ConnectionEventReject); err != nil {
+		t.Fatalf("Can't wait reject event: %s", err)
+	}
+
+	if event.Reason != wsserver.RejectReasonTooManyClients {
+		t.Errorf("Wrong reject reason: %s", event.Reason)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err = client.SendMessage(&Message{Type: "NOTIFY"}); err != nil {
+			t.Fatalf("Can't send message: %s", err)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if err = client.Disconnect(); err != nil {
+		t.Fatalf("Can't disconnect ws client: %s", err)
+	}
+
+	if event, err = waitConnectionEvent(eventChannel, wsserver.ConnectionEventDisconnect); err != nil {
+		t.Fatalf("Can't wait disconnect event: %s", err)
+	}
+
+	if event.CloseCode != websocket.CloseNormalClosure {
+		t.Errorf("Wrong close code: %d", event.CloseCode)
+	}
+
+	if event.ReceivedMessages != 2 || event.SentMessages != 2 || event.ReceivedBytes == 0 || event.SentBytes == 0 {
+		t.Errorf("Wrong message counters: %v", event)
+	}
+
+	if event.Duration <= 0 {
+		t.Errorf("Wrong connection duration: %v", event.Duration)
+	}
+}
+
+func TestTLSParamAndDialer(t *testing.T) {
+	server, err := wsserver.New("TestServer", hostURL, crtFile, keyFile, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(1 * time.Second)
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert, TLS: wsclient.TLSParam{ServerName: "unknown.host"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+
+	if err = client.Connect(serverURL); err == nil {
+		t.Error("Error expected due to wrong server name")
+	}
+
+	client.Close()
+
+	var dialCount int32
+
+	client, err = wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert,
+		TLS: wsclient.TLSParam{
+			SessionCacheSize: 1, MinVersion: tls.VersionTLS13, ServerName: "localhost",
+		},
+		Dialer: &net.Dialer{Control: func(network, address string, c syscall.RawConn) error {
+			atomic.AddInt32(&dialCount, 1)
+
+			return nil
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect("wss://127.0.0.1:8088"); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+
+	if atomic.LoadInt32(&dialCount) != 1 {
+		t.Errorf("Wrong dial count: %d", atomic.LoadInt32(&dialCount))
+	}
+}
+
+func TestTLSProfiles(t *testing.T) {
+	if _, err := wsserver.NewWithParam("TestServer", hostURL, crtFile, keyFile, wsserver.ServerParam{
+		TLSProfile: "unknown",
+	}, nil); err == nil {
+		t.Error("Error expected due to unknown TLS profile")
+	}
+
+	server, err := wsserver.NewWithParam("TestServer", hostURL, crtFile, keyFile, wsserver.ServerParam{
+		TLSProfile: cryptutils.TLSProfileStrict,
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(1 * time.Second)
+
+	if _, err = wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert, TLS: wsclient.TLSParam{Profile: "unknown"},
+	}, nil); err == nil {
+		t.Error("Error expected due to unknown TLS profile")
+	}
+
+	client, err := wsclient.New("Test", wsclient.ClientParam{
+		CaCertFile: caCert, TLS: wsclient.TLSParam{Profile: cryptutils.TLSProfileFIPSLike},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+}
+
+func TestClientCertificateAndSubprotocol(t *testing.T) {
+	pki, err := testtools.NewPKIBuilder().WithIntermediates(1).WithLeaf("client").Build()
+	if err != nil {
+		t.Fatalf("Can't build PKI: %v", err)
+	}
+
+	otherPKI, err := testtools.NewPKIBuilder().WithLeaf("client").Build()
+	if err != nil {
+		t.Fatalf("Can't build PKI: %v", err)
+	}
+
+	if _, err = wsserver.NewWithParam("TestServer", hostURL, crtFile, keyFile, wsserver.ServerParam{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+	}, nil); err == nil {
+		t.Error("Error expected due to missing client CAs")
+	}
+
+	server, err := wsserver.NewWithParam("TestServer", hostURL, crtFile, keyFile, wsserver.ServerParam{
+		Subprotocols: []string{"aos.v2", "aos.v1"},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pki.RootPool(),
+	}, newTestHandler(
+		func(client *wsserver.Client, messageType int, data []byte) (response []byte, err error) {
+			value, _ := client.Metadata("count")
+			count, _ := value.(int)
+
+			client.SetMetadata("count", count+1)
+
+			commonName := ""
+
+			if certs := client.PeerCertificates(); len(certs) > 0 {
+				commonName = certs[0].Subject.CommonName
+			}
+
+			return []byte(fmt.Sprintf("%s %s %d", client.Subprotocol(), commonName, count+1)), nil
+		}))
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	time.Sleep(1 * time.Second)
+
+	serverCA, err := os.ReadFile(caCert)
+	if err != nil {
+		t.Fatalf("Can't read CA certificate: %v", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM(serverCA)
+
+	clientCert, err := pki.TLSCertificate("client")
+	if err != nil {
+		t.Fatalf("Can't get client certificate: %v", err)
+	}
+
+	otherClientCert, err := otherPKI.TLSCertificate("client")
+	if err != nil {
+		t.Fatalf("Can't get client certificate: %v", err)
+	}
+
+	for _, certs := range [][]tls.Certificate{nil, {otherClientCert}} {
+		dialer := websocket.Dialer{TLSClientConfig: &tls.Config{
+			RootCAs: rootCAs, Certificates: certs, MinVersion: tls.VersionTLS12,
+		}}
+
+		if connection, _, err := dialer.Dial(serverURL, nil); err == nil {
+			if _, _, err = connection.ReadMessage(); err == nil {
+				t.Error("Error expected due to wrong client certificate")
+			}
+
+			connection.Close()
+		}
+	}
+
+	dialer := websocket.Dialer{
+		TLSClientConfig: &tls.Config{
+			RootCAs: rootCAs, Certificates: []tls.Certificate{clientCert}, MinVersion: tls.VersionTLS12,
+		},
+		Subprotocols: []string{"aos.v1", "aos.v2"},
+	}
+
+	connection, _, err := dialer.Dial(serverURL, nil)
+	if err != nil {
+		t.Fatalf("Can't connect to ws server: %v", err)
+	}
+	defer connection.Close()
+
+	if connection.Subprotocol() != "aos.v2" {
+		t.Errorf("Wrong negotiated subprotocol: %s", connection.Subprotocol())
+	}
+
+	for i := 1; i <= 2; i++ {
+		if err = connection.WriteMessage(websocket.TextMessage, []byte("request")); err != nil {
+			t.Fatalf("Can't send message: %v", err)
+		}
+
+		_, response, err := connection.ReadMessage()
+		if err != nil {
+			t.Fatalf("Can't read message: %v", err)
+		}
+
+		if expected := fmt.Sprintf("aos.v2 client %d", i); string(response) != expected {
+			t.Errorf("Wrong response: %s, expected: %s", response, expected)
+		}
+	}
+}
+
+func TestHTTPHandlers(t *testing.T) {
+	server, err := wsserver.NewWithParam("TestServer", hostURL, crtFile, keyFile, wsserver.ServerParam{
+		HTTPHandlers: map[string]http.Handler{
+			"/status": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			}),
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws server: %s", err)
+	}
+	defer server.Close()
+
+	if err = server.HandleHTTP("/items/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("item " + r.PathValue("id")))
+	})); err != nil {
+		t.Fatalf("Can't register HTTP handler: %v", err)
+	}
+
+	for _, path := range []string{"", "/status", "/items/{id}", "/{category}/list", "/items/{"} {
+		if err = server.HandleHTTP(path, http.NotFoundHandler()); err == nil {
+			t.Errorf("Error expected for HTTP handler path: %s", path)
+		}
+	}
+
+	time.Sleep(1 * time.Second)
+
+	serverCA, err := os.ReadFile(caCert)
+	if err != nil {
+		t.Fatalf("Can't read CA certificate: %v", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM(serverCA)
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}},
+		Timeout:   5 * time.Second,
+	}
+
+	for path, expectedBody := range map[string]string{"/status": "ok", "/items/42": "item 42"} {
+		response, err := httpClient.Get("https://localhost" + hostURL + path)
+		if err != nil {
+			t.Fatalf("Can't send HTTP request: %v", err)
+		}
+
+		body, err := io.ReadAll(response.Body)
+		response.Body.Close()
+
+		if err != nil {
+			t.Fatalf("Can't read HTTP response: %v", err)
+		}
+
+		if response.StatusCode != http.StatusOK || string(body) != expectedBody {
+			t.Errorf("Wrong HTTP response for %s: %d %s", path, response.StatusCode, body)
+		}
+	}
+
+	// Web socket handler is still served on default path
+	client, err := wsclient.New("Test", wsclient.ClientParam{CaCertFile: caCert}, nil)
+	if err != nil {
+		t.Fatalf("Can't create ws client: %s", err)
+	}
+	defer client.Close()
+
+	if err = client.Connect(serverURL); err != nil {
+		t.Fatalf("Can't connect to ws server: %s", err)
+	}
+}
+
+/*******************************************************************************
+ * Private
+ ******************************************************************************/
+
+func newTestHandler(p processMessage) (handler *testHandler) {
+	return &testHandler{p}
+}
+
+func (handler *testHandler) ClientConnected(client *wsserver.Client) {
+}
+
+func (handler *testHandler) ProcessMessage(
+	client *wsserver.Client, messageType int, message []byte,
+) (response []byte, err error) {
+	response, err = handler.processMessage(client, messageType, message)
+
+	return response, aoserrors.Wrap(err)
+}
+
+func (handler *testHandler) ClientDisconnected(client *wsserver.Client) {
+}
+
+func newTestProxy(url, user, password string) (proxy *testProxy) {
+	proxy = &testProxy{user: user, password: password}
+
+	proxy.server = &http.Server{Addr: url, Handler: proxy, ReadHeaderTimeout: time.Second}
+
+	go func() {
+		if err := proxy.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Errorf("Proxy listening error: %v", err)
+		}
+	}()
+
+	return proxy
+}
+
+func (proxy *testProxy) close() {
+	proxy.server.Close()
+}
+
+func (proxy *testProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodConnect {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if r.Header.Get("Proxy-Authorization") != "Basic "+base64.StdEncoding.EncodeToString(
+		[]byte(proxy.user+":"+proxy.password)) {
+		w.WriteHeader(http.StatusProxyAuthRequired)
+
+		return
+	}
+
+	destConn, err := net.Dial("tcp", r.Host)
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		destConn.Close()
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	srcConn, _, err := hijacker.Hijack()
+	if err != nil {
+		destConn.Close()
+
+		return
+	}
+
+	atomic.AddInt32(&proxy.connections, 1)
+
+	go func() {
+		defer destConn.Close()
+		defer srcConn.Close()
+
+		_, _ = io.Copy(destConn, srcConn)
+	}()
+
+	go func() {
+		_, _ = io.Copy(srcConn, destConn)
+	}()
+}
+
+func waitCloseCode(client *wsclient.Client, code int) error {
+	select {
+	case err := <-client.ErrorChannel:
+		var disconnectErr *wsclient.DisconnectError
+
+		if !errors.As(err, &disconnectErr) || disconnectErr.Code != code {
+			return aoserrors.Errorf("unexpected error: %v", err)
+		}
+
+		return nil
+
+	case <-time.After(5 * time.Second):
+		return aoserrors.New("wait close timeout")
+	}
+}
+
+func waitConnectionEvent(
+	eventChannel <-chan wsserver.ConnectionEvent, eventType string,
+) (event wsserver.ConnectionEvent, err error) {
+	select {
+	case event = <-eventChannel:
+		if event.Type != eventType {
+			return event, aoserrors.Errorf("unexpected event type: %s", event.Type)
+		}
+
+		return event, nil
+
+	case <-time.After(5 * time.Second):
+		return event, aoserrors.New("wait event timeout")
+	}
+}
+
+func connectWithRetry(client *wsclient.Client, url string) (err error) {
+	for i := 0; i < 10; i++ {
+		if err = client.Connect(url); err == nil {
+			return nil
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return err
 }
 
 func savePEMFile(data []byte) (string, error) {
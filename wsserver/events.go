@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wsserver
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aosedge/aos_common/aoserrors"
+)
+
+/***********************************************************************************************************************
+ * Consts
+ **********************************************************************************************************************/
+
+// Connection event types.
+const (
+	ConnectionEventConnect    = "connect"
+	ConnectionEventDisconnect = "disconnect"
+	ConnectionEventReject     = "reject"
+)
+
+// CloseReasonServerClosed disconnect reason of clients closed on server close.
+const CloseReasonServerClosed = "server closed"
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// ConnectionEvent client connection lifecycle event.
+type ConnectionEvent struct {
+	Type       string
+	Server     string
+	RemoteAddr string
+	Timestamp  time.Time
+	// Subprotocol subprotocol negotiated with client.
+	Subprotocol string
+	// PeerSubject common name of client TLS certificate if presented.
+	PeerSubject string
+	// CloseCode close code received from or sent to client, set for disconnect event.
+	CloseCode int
+	// Reason disconnect or reject reason.
+	Reason string
+	// Duration connection duration, set for disconnect event.
+	Duration time.Duration
+	// Message counters, set for disconnect event.
+	ReceivedMessages uint64
+	ReceivedBytes    uint64
+	SentMessages     uint64
+	SentBytes        uint64
+}
+
+// ConnectionEventHandler handles connection lifecycle events. It is called synchronously from connection goroutine.
+type ConnectionEventHandler func(event ConnectionEvent)
+
+type connectionStats struct {
+	connectTime      time.Time
+	closeCode        int
+	closeReason      string
+	receivedMessages atomic.Uint64
+	receivedBytes    atomic.Uint64
+	sentMessages     atomic.Uint64
+	sentBytes        atomic.Uint64
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func (server *Server) emitEvent(event ConnectionEvent) {
+	event.Server = server.name
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	log.WithFields(log.Fields{
+		"server":      event.Server,
+		"event":       event.Type,
+		"remoteAddr":  event.RemoteAddr,
+		"subprotocol": event.Subprotocol,
+		"peerSubject": event.PeerSubject,
+		"closeCode":   event.CloseCode,
+		"reason":      event.Reason,
+		"duration":    event.Duration,
+		"received":    event.ReceivedMessages,
+		"sent":        event.SentMessages,
+	}).Info("Connection event")
+
+	if server.param.EventHandler != nil {
+		server.param.EventHandler(event)
+	}
+}
+
+func (client *Client) newEvent(eventType string) ConnectionEvent {
+	event := ConnectionEvent{
+		Type:        eventType,
+		RemoteAddr:  client.RemoteAddr,
+		Subprotocol: client.Subprotocol(),
+	}
+
+	if len(client.peerCertificates) > 0 {
+		event.PeerSubject = client.peerCertificates[0].Subject.CommonName
+	}
+
+	if eventType != ConnectionEventDisconnect {
+		return event
+	}
+
+	client.Lock()
+	event.CloseCode, event.Reason = client.stats.closeCode, client.stats.closeReason
+	client.Unlock()
+
+	event.Timestamp = time.Now()
+	event.Duration = event.Timestamp.Sub(client.stats.connectTime)
+	event.ReceivedMessages = client.stats.receivedMessages.Load()
+	event.ReceivedBytes = client.stats.receivedBytes.Load()
+	event.SentMessages = client.stats.sentMessages.Load()
+	event.SentBytes = client.stats.sentBytes.Load()
+
+	return event
+}
+
+// setCloseStatus sets connection close code and reason. The first set status is kept.
+func (client *Client) setCloseStatus(code int, reason string) {
+	client.Lock()
+	defer client.Unlock()
+
+	if client.stats.closeCode != 0 {
+		return
+	}
+
+	client.stats.closeCode, client.stats.closeReason = code, reason
+}
+
+// getCloseStatus returns close code and reason to be sent in close frame. Normal closure is returned if status is not
+// set or can't be sent in close frame.
+func (client *Client) getCloseStatus() (code int, reason string) {
+	client.Lock()
+	defer client.Unlock()
+
+	switch client.stats.closeCode {
+	case 0, websocket.CloseNoStatusReceived, websocket.CloseAbnormalClosure, websocket.CloseTLSHandshake:
+		return websocket.CloseNormalClosure, ""
+
+	default:
+		return client.stats.closeCode, client.stats.closeReason
+	}
+}
+
+func (client *Client) setReadCloseStatus(err error) {
+	var closeErr *websocket.CloseError
+
+	switch {
+	case errors.As(err, &closeErr):
+		client.setCloseStatus(closeErr.Code, closeErr.Text)
+
+	case errors.Is(err, websocket.ErrReadLimit):
+		client.setCloseStatus(CloseCodeMessageTooBig, err.Error())
+
+	default:
+		client.setCloseStatus(websocket.CloseAbnormalClosure, err.Error())
+	}
+}
+
+// errorReason returns error message without aos error location.
+func errorReason(err error) string {
+	var aosErr *aoserrors.Error
+
+	for errors.As(err, &aosErr) {
+		if err = errors.Unwrap(aosErr); err == nil {
+			return aosErr.Error()
+		}
+	}
+
+	return err.Error()
+}
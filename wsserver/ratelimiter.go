@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright (C) 2026 Renesas Electronics Corporation.
+// Copyright (C) 2026 EPAM Systems, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wsserver
+
+import (
+	"time"
+)
+
+/***********************************************************************************************************************
+ * Types
+ **********************************************************************************************************************/
+
+// rateLimiter token bucket rate limiter.
+type rateLimiter struct {
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastUpdate time.Time
+}
+
+/***********************************************************************************************************************
+ * Private
+ **********************************************************************************************************************/
+
+func newRateLimiter(rate float64, burst int) (limiter *rateLimiter) {
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), lastUpdate: time.Now()}
+}
+
+func (limiter *rateLimiter) allow() bool {
+	now := time.Now()
+
+	limiter.tokens += now.Sub(limiter.lastUpdate).Seconds() * limiter.rate
+	if limiter.tokens > limiter.burst {
+		limiter.tokens = limiter.burst
+	}
+
+	limiter.lastUpdate = now
+
+	if limiter.tokens < 1 {
+		return false
+	}
+
+	limiter.tokens--
+
+	return true
+}
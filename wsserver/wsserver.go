@@ -19,7 +19,10 @@ package wsserver
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
@@ -29,6 +32,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/aosedge/aos_common/aoserrors"
+	"github.com/aosedge/aos_common/utils/cryptutils"
 )
 
 /***********************************************************************************************************************
@@ -39,6 +43,17 @@ const (
 	writeSocketTimeout = 10 * time.Second
 )
 
+const defaultWebSocketPath = "/"
+
+// Close codes and reasons used to reject misbehaving clients.
+const (
+	CloseCodeRateLimitExceeded = websocket.ClosePolicyViolation
+	CloseCodeMessageTooBig     = websocket.CloseMessageTooBig
+
+	CloseReasonRateLimitExceeded = "message rate limit exceeded"
+	RejectReasonTooManyClients   = "too many clients"
+)
+
 /***********************************************************************************************************************
  * Types
  **********************************************************************************************************************/
@@ -49,16 +64,52 @@ type Server struct {
 	httpServer *http.Server
 	upgrader   websocket.Upgrader
 	sync.Mutex
-	clients map[string]*Client
-	handler ClientHandler
+	clients   map[string]*Client
+	handler   ClientHandler
+	param     ServerParam
+	serveMux  *http.ServeMux
+	httpPaths map[string]struct{}
+}
+
+// ServerParam server parameters. Zero value of any limit means unlimited.
+type ServerParam struct {
+	// MaxClients max number of concurrently connected clients.
+	MaxClients int
+	// MaxMessageSize max size of incoming message in bytes.
+	MaxMessageSize int64
+	// MessageRateLimit max number of incoming messages per second per client.
+	MessageRateLimit float64
+	// MessageRateBurst max number of incoming messages which may exceed rate limit at once.
+	MessageRateBurst int
+	// Subprotocols supported subprotocols in order of preference.
+	Subprotocols []string
+	// ClientAuth policy of requesting TLS client certificates available by Client.PeerCertificates.
+	ClientAuth tls.ClientAuthType
+	// ClientCAs root CAs used to verify client certificates. Required if ClientAuth verifies client certificates.
+	ClientCAs *x509.CertPool
+	// TLSProfile named TLS profile (see cryptutils.TLSProfile*) of server TLS config.
+	TLSProfile string
+	// WebSocketPath path pattern of WebSocket upgrade requests, "/" if not set.
+	WebSocketPath string
+	// HTTPHandlers plain HTTP handlers (health, metrics, etc.) served on the same listener by path pattern.
+	HTTPHandlers map[string]http.Handler
+	// EventHandler receives client connect, disconnect and reject events.
+	EventHandler ConnectionEventHandler
+	// Listener serves connections accepted by listener instead of listening on URL, e.g. in-memory test listener.
+	Listener net.Listener
 }
 
 // Client websocket client handler.
 type Client struct {
-	RemoteAddr string
-	handler    ClientHandler
-	connection *websocket.Conn
+	RemoteAddr       string
+	handler          ClientHandler
+	connection       *websocket.Conn
+	rateLimiter      *rateLimiter
+	peerCertificates []*x509.Certificate
 	sync.Mutex
+	metadataMutex sync.RWMutex
+	metadata      map[interface{}]interface{}
+	stats         connectionStats
 }
 
 // ClientHandler provides interface to handle client.
@@ -74,26 +125,76 @@ type ClientHandler interface {
 
 // New creates new Web socket server.
 func New(name, url, cert, key string, handler ClientHandler) (server *Server, err error) {
+	return NewWithParam(name, url, cert, key, ServerParam{}, handler)
+}
+
+// NewWithParam creates new Web socket server with specified parameters.
+func NewWithParam(
+	name, url, cert, key string, param ServerParam, handler ClientHandler,
+) (server *Server, err error) {
+	if param.ClientAuth >= tls.VerifyClientCertIfGiven && param.ClientCAs == nil {
+		return nil, aoserrors.New("client CAs should be set to verify client certificates")
+	}
+
 	server = &Server{
 		name: name,
 		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool { return true },
+			CheckOrigin:  func(r *http.Request) bool { return true },
+			Subprotocols: param.Subprotocols,
 		},
-		handler: handler,
-		clients: make(map[string]*Client),
+		handler:   handler,
+		clients:   make(map[string]*Client),
+		param:     param,
+		serveMux:  http.NewServeMux(),
+		httpPaths: make(map[string]struct{}),
 	}
 
-	log.WithField("server", server.name).Debug("Create ws server")
+	webSocketPath := param.WebSocketPath
+	if webSocketPath == "" {
+		webSocketPath = defaultWebSocketPath
+	}
 
-	serveMux := http.NewServeMux()
-	serveMux.HandleFunc("/", server.handleConnection)
+	log.WithFields(log.Fields{
+		"server":           server.name,
+		"maxClients":       param.MaxClients,
+		"maxMessageSize":   param.MaxMessageSize,
+		"messageRateLimit": param.MessageRateLimit,
+	}).Debug("Create ws server")
+
+	if err = server.HandleHTTP(webSocketPath, http.HandlerFunc(server.handleConnection)); err != nil {
+		return nil, err
+	}
+
+	for path, httpHandler := range param.HTTPHandlers {
+		if err = server.HandleHTTP(path, httpHandler); err != nil {
+			return nil, err
+		}
+	}
+
+	server.httpServer = &http.Server{Addr: url, Handler: server.serveMux, ReadHeaderTimeout: time.Second}
+
+	if param.ClientAuth != tls.NoClientCert || param.ClientCAs != nil ||
+		param.TLSProfile != cryptutils.TLSProfileDefault {
+		if server.httpServer.TLSConfig, err = cryptutils.NewTLSProfileConfig(param.TLSProfile); err != nil {
+			return nil, aoserrors.Wrap(err)
+		}
 
-	server.httpServer = &http.Server{Addr: url, Handler: serveMux, ReadHeaderTimeout: time.Second}
+		server.httpServer.TLSConfig.ClientAuth = param.ClientAuth
+		server.httpServer.TLSConfig.ClientCAs = param.ClientCAs
+	}
 
 	go func(crt, key string) {
 		log.WithFields(log.Fields{"address": url, "crt": crt, "key": key}).Debug("Listen for clients")
 
-		if err := server.httpServer.ListenAndServeTLS(crt, key); !errors.Is(err, http.ErrServerClosed) {
+		var err error
+
+		if param.Listener != nil {
+			err = server.httpServer.ServeTLS(param.Listener, crt, key)
+		} else {
+			err = server.httpServer.ListenAndServeTLS(crt, key)
+		}
+
+		if !errors.Is(err, http.ErrServerClosed) {
 			log.Error("Server listening error: ", aoserrors.Wrap(err))
 
 			return
@@ -103,6 +204,31 @@ func New(name, url, cert, key string, handler ClientHandler) (server *Server, er
 	return server, nil
 }
 
+// HandleHTTP registers plain HTTP handler for path pattern on server listener. It may be called while server is
+// running.
+func (server *Server) HandleHTTP(path string, handler http.Handler) error {
+	server.Lock()
+	defer server.Unlock()
+
+	if path == "" || handler == nil {
+		return aoserrors.New("invalid HTTP handler")
+	}
+
+	if _, ok := server.httpPaths[path]; ok {
+		return aoserrors.Errorf("HTTP handler for %s already registered", path)
+	}
+
+	log.WithFields(log.Fields{"server": server.name, "path": path}).Debug("Register HTTP handler")
+
+	if err := registerHTTPHandler(server.serveMux, path, handler); err != nil {
+		return err
+	}
+
+	server.httpPaths[path] = struct{}{}
+
+	return nil
+}
+
 // GetClients return client list.
 func (server *Server) GetClients() (clients []*Client) {
 	server.Lock()
@@ -125,6 +251,7 @@ func (server *Server) Close() {
 	log.WithField("server", server.name).Debug("Close ws server")
 
 	for _, client := range server.clients {
+		client.setCloseStatus(websocket.CloseGoingAway, CloseReasonServerClosed)
 		client.close(true)
 	}
 
@@ -172,9 +299,66 @@ func (client *Client) SendMessage(messageType int, data []byte) (err error) {
 		return aoserrors.Wrap(err)
 	}
 
+	if messageType == websocket.TextMessage || messageType == websocket.BinaryMessage {
+		client.stats.sentMessages.Add(1)
+		client.stats.sentBytes.Add(uint64(len(data)))
+	}
+
 	return nil
 }
 
+// Subprotocol returns subprotocol negotiated with client.
+func (client *Client) Subprotocol() string {
+	return client.connection.Subprotocol()
+}
+
+// PeerCertificates returns certificates presented by client over TLS. The first certificate is the client leaf one.
+func (client *Client) PeerCertificates() []*x509.Certificate {
+	return client.peerCertificates
+}
+
+// SetMetadata stores client metadata value by key.
+func (client *Client) SetMetadata(key, value interface{}) {
+	client.metadataMutex.Lock()
+	defer client.metadataMutex.Unlock()
+
+	if client.metadata == nil {
+		client.metadata = make(map[interface{}]interface{})
+	}
+
+	client.metadata[key] = value
+}
+
+// Metadata returns client metadata value by key.
+func (client *Client) Metadata(key interface{}) (value interface{}, ok bool) {
+	client.metadataMutex.RLock()
+	defer client.metadataMutex.RUnlock()
+
+	value, ok = client.metadata[key]
+
+	return value, ok
+}
+
+// DeleteMetadata deletes client metadata value by key.
+func (client *Client) DeleteMetadata(key interface{}) {
+	client.metadataMutex.Lock()
+	defer client.metadataMutex.Unlock()
+
+	delete(client.metadata, key)
+}
+
+// GetMetadata returns typed client metadata value by key.
+func GetMetadata[T any](client *Client, key interface{}) (value T, ok bool) {
+	rawValue, ok := client.Metadata(key)
+	if !ok {
+		return value, false
+	}
+
+	value, ok = rawValue.(T)
+
+	return value, ok
+}
+
 /***********************************************************************************************************************
  * Private
  **********************************************************************************************************************/
@@ -183,24 +367,43 @@ func (server *Server) newClient(w http.ResponseWriter, r *http.Request) (client
 	server.Lock()
 	defer server.Unlock()
 
-	defer func() {
+	client = &Client{RemoteAddr: r.RemoteAddr, handler: server.handler}
+	client.stats.connectTime = time.Now()
+
+	defer func(client *Client) {
 		if err != nil {
 			if client.connection != nil {
 				client.connection.Close()
 			}
 		}
-	}()
-
-	client = &Client{RemoteAddr: r.RemoteAddr, handler: server.handler}
+	}(client)
 
 	if !websocket.IsWebSocketUpgrade(r) {
 		return nil, aoserrors.New("new connection is not websocket")
 	}
 
+	if server.param.MaxClients > 0 && len(server.clients) >= server.param.MaxClients {
+		http.Error(w, RejectReasonTooManyClients, http.StatusServiceUnavailable)
+
+		return nil, aoserrors.New(RejectReasonTooManyClients)
+	}
+
 	if client.connection, err = server.upgrader.Upgrade(w, r, nil); err != nil {
 		return nil, aoserrors.Wrap(err)
 	}
 
+	if r.TLS != nil {
+		client.peerCertificates = r.TLS.PeerCertificates
+	}
+
+	if server.param.MaxMessageSize > 0 {
+		client.connection.SetReadLimit(server.param.MaxMessageSize)
+	}
+
+	if server.param.MessageRateLimit > 0 {
+		client.rateLimiter = newRateLimiter(server.param.MessageRateLimit, server.param.MessageRateBurst)
+	}
+
 	server.clients[client.RemoteAddr] = client
 
 	return client, nil
@@ -222,7 +425,9 @@ func (client *Client) close(sendCloseMessage bool) (err error) {
 	}).Info("Close client")
 
 	if sendCloseMessage {
-		_ = client.SendMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		code, reason := client.getCloseStatus()
+
+		_ = client.SendMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason))
 	}
 
 	return aoserrors.Wrap(client.connection.Close())
@@ -237,6 +442,19 @@ func (client *Client) run() {
 				log.Errorf("Error reading socket: %s", err)
 			}
 
+			client.setReadCloseStatus(err)
+
+			break
+		}
+
+		if client.rateLimiter != nil && !client.rateLimiter.allow() {
+			log.WithField("remoteAddr", client.RemoteAddr).Warn("Message rate limit exceeded")
+
+			client.setCloseStatus(CloseCodeRateLimitExceeded, CloseReasonRateLimitExceeded)
+
+			_ = client.SendMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(CloseCodeRateLimitExceeded, CloseReasonRateLimitExceeded))
+
 			break
 		}
 
@@ -252,6 +470,9 @@ func (client *Client) run() {
 			}).Debug("Receive message")
 		}
 
+		client.stats.receivedMessages.Add(1)
+		client.stats.receivedBytes.Add(uint64(len(message)))
+
 		if client.handler != nil {
 			response, err := client.handler.ProcessMessage(client, messageType, message)
 			if err != nil {
@@ -279,9 +500,15 @@ func (server *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		log.Errorf("Can't create client handler: %s", err)
 
+		server.emitEvent(ConnectionEvent{
+			Type: ConnectionEventReject, RemoteAddr: r.RemoteAddr, Reason: errorReason(err),
+		})
+
 		return
 	}
 
+	server.emitEvent(client.newEvent(ConnectionEventConnect))
+
 	if server.handler != nil {
 		server.handler.ClientConnected(client)
 	}
@@ -292,7 +519,23 @@ func (server *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
 		log.Errorf("Can't delete client handler: %s", err)
 	}
 
+	server.emitEvent(client.newEvent(ConnectionEventDisconnect))
+
 	if server.handler != nil {
 		server.handler.ClientDisconnected(client)
 	}
 }
+
+// registerHTTPHandler registers handler in serve mux. ServeMux panics on invalid or conflicting patterns, the panic
+// is returned as error.
+func registerHTTPHandler(serveMux *http.ServeMux, path string, handler http.Handler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = aoserrors.Errorf("can't register HTTP handler for %s: %v", path, recovered)
+		}
+	}()
+
+	serveMux.Handle(path, handler)
+
+	return nil
+}